@@ -0,0 +1,4 @@
+-- Remove the credit manager role (role_permissions and user_roles cascade delete)
+DELETE FROM roles WHERE name = 'Credit Manager';
+
+DELETE FROM permissions WHERE code IN ('sales:credit:read', 'sales:credit:approve');
@@ -0,0 +1,17 @@
+-- Seed the credit manager role, which reviews sales orders held for credit
+INSERT INTO roles (name, description, is_system) VALUES
+    ('Credit Manager', 'Reviews sales orders held over the customer credit limit', true)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (code, name, service, resource, action, description) VALUES
+    ('sales:credit:read', 'View Credit Holds', 'sales', 'credit', 'read', 'View sales orders held for credit review'),
+    ('sales:credit:approve', 'Review Credit Holds', 'sales', 'credit', 'approve', 'Approve or reject credit holds')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT
+    (SELECT id FROM roles WHERE name = 'Credit Manager'),
+    id
+FROM permissions
+WHERE code IN ('sales:customer:read', 'sales:order:read', 'sales:credit:read', 'sales:credit:approve')
+ON CONFLICT (role_id, permission_id) DO NOTHING;
@@ -0,0 +1 @@
+DELETE FROM file_categories WHERE code = 'COA';
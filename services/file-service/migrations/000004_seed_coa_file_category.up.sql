@@ -0,0 +1,7 @@
+-- Certificates of Analysis issued by manufacturing (printable PDF + machine-readable JSON)
+INSERT INTO file_categories (code, name, description, allowed_extensions, max_file_size, storage_bucket) VALUES
+('COA', 'Certificates of Analysis', 'Certificates of Analysis for finished lots and supplier CoA scans',
+ '["pdf", "json"]',
+ 10485760, -- 10 MB
+ 'certificates')
+ON CONFLICT (code) DO NOTHING;
@@ -13,10 +13,27 @@ import (
 	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/handler"
 	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/router"
 	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/filestore"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/masterdata"
 	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/persistence/postgres"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/sales"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/scheduler"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/batchrecord"
 	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/bom"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/capa"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/coa"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/costing"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/dispensing"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/equipment"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/lineclearance"
 	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/ncr"
 	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/qc"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/recall"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/routing"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/sampling"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/spc"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/stability"
 	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/traceability"
 	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/workorder"
 	"github.com/erp-cosmetics/shared/pkg/database"
@@ -70,6 +87,19 @@ func main() {
 	qcRepo := postgres.NewQCRepository(db)
 	ncrRepo := postgres.NewNCRRepository(db)
 	traceRepo := postgres.NewTraceabilityRepository(db)
+	routingRepo := postgres.NewRoutingRepository(db)
+	opRepo := postgres.NewWOOperationRepository(db)
+	batchRecordRepo := postgres.NewBatchRecordRepository(db)
+	dispensingRepo := postgres.NewDispensingRepository(db)
+	samplingRepo := postgres.NewSamplingPlanRepository(db)
+	coaRepo := postgres.NewCoARepository(db)
+	capaRepo := postgres.NewCAPARepository(db)
+	recallRepo := postgres.NewRecallRepository(db)
+	stabilityRepo := postgres.NewStabilityRepository(db)
+	equipmentRepo := postgres.NewEquipmentRepository(db)
+	lineClearanceRepo := postgres.NewLineClearanceRepository(db)
+	costingRepo := postgres.NewCostingRepository(db)
+	transactor := postgres.NewTransactor(db)
 
 	// Initialize event publisher
 	eventPub := event.NewPublisher(natsClient, log)
@@ -83,39 +113,162 @@ func main() {
 
 	// Initialize Work Order use cases
 	createWOUC := workorder.NewCreateWOUseCase(woRepo, bomRepo, eventPub)
+	createReworkWOUC := workorder.NewCreateReworkWOUseCase(woRepo, bomRepo, ncrRepo, eventPub)
 	getWOUC := workorder.NewGetWOUseCase(woRepo)
 	listWOsUC := workorder.NewListWOsUseCase(woRepo)
-	releaseWOUC := workorder.NewReleaseWOUseCase(woRepo, eventPub)
-	startWOUC := workorder.NewStartWOUseCase(woRepo, eventPub)
-	completeWOUC := workorder.NewCompleteWOUseCase(woRepo, traceRepo, eventPub)
+	releaseWOUC := workorder.NewReleaseWOUseCase(woRepo, routingRepo, opRepo, transactor, eventPub)
+	startWOUC := workorder.NewStartWOUseCase(woRepo, lineClearanceRepo, eventPub)
+	completeWOUC := workorder.NewCompleteWOUseCase(woRepo, bomRepo, traceRepo, transactor, eventPub)
+	cancelWOUC := workorder.NewCancelWOUseCase(woRepo, eventPub)
+	materialVarianceUC := workorder.NewGetMaterialVarianceUseCase(woRepo, bomRepo)
+
+	// Initialize SPC use cases
+	getSPCChartUC := spc.NewGetSPCChartUseCase(qcRepo)
+	monitorSPCUC := spc.NewMonitorSPCUseCase(qcRepo, eventPub)
 
 	// Initialize QC use cases
 	getCheckpointsUC := qc.NewGetCheckpointsUseCase(qcRepo)
-	createInspectionUC := qc.NewCreateInspectionUseCase(qcRepo, eventPub)
+	createInspectionUC := qc.NewCreateInspectionUseCase(qcRepo, samplingRepo, transactor, eventPub, monitorSPCUC, log)
 	getInspectionUC := qc.NewGetInspectionUseCase(qcRepo)
 	listInspectionsUC := qc.NewListInspectionsUseCase(qcRepo)
-	approveInspectionUC := qc.NewApproveInspectionUseCase(qcRepo, eventPub)
+	approveInspectionUC := qc.NewApproveInspectionUseCase(qcRepo, samplingRepo, eventPub)
 
 	// Initialize NCR use cases
 	createNCRUC := ncr.NewCreateNCRUseCase(ncrRepo, eventPub)
 	getNCRUC := ncr.NewGetNCRUseCase(ncrRepo)
 	listNCRsUC := ncr.NewListNCRsUseCase(ncrRepo)
-	closeNCRUC := ncr.NewCloseNCRUseCase(ncrRepo)
+	closeNCRUC := ncr.NewCloseNCRUseCase(ncrRepo, capaRepo)
+
+	// Initialize CAPA use cases
+	createCAPAUC := capa.NewCreateCAPAUseCase(capaRepo, ncrRepo)
+	getCAPAUC := capa.NewGetCAPAUseCase(capaRepo)
+	listCAPAsUC := capa.NewListCAPAsUseCase(capaRepo)
+	linkCAPAUC := capa.NewLinkCAPAUseCase(capaRepo, ncrRepo)
+	addCAPAActionUC := capa.NewAddCAPAActionUseCase(capaRepo)
+	completeCAPAActionUC := capa.NewCompleteCAPAActionUseCase(capaRepo)
+	verifyCAPAUC := capa.NewVerifyCAPAUseCase(capaRepo)
+	capaRemindersUC := capa.NewSendOverdueRemindersUseCase(capaRepo, eventPub, 24*time.Hour)
 
 	// Initialize Traceability use cases
 	traceBackwardUC := traceability.NewTraceBackwardUseCase(traceRepo, woRepo)
 	traceForwardUC := traceability.NewTraceForwardUseCase(traceRepo)
 
+	// Initialize Routing use cases
+	createWorkCenterUC := routing.NewCreateWorkCenterUseCase(routingRepo)
+	listWorkCentersUC := routing.NewListWorkCentersUseCase(routingRepo)
+	createRoutingUC := routing.NewCreateRoutingUseCase(routingRepo)
+	getRoutingUC := routing.NewGetRoutingUseCase(routingRepo)
+	listRoutingsUC := routing.NewListRoutingsUseCase(routingRepo)
+	activateRoutingUC := routing.NewActivateRoutingUseCase(routingRepo)
+
+	// Initialize Operation execution use cases
+	getOperationsUC := routing.NewGetWOOperationsUseCase(opRepo)
+	startOperationUC := routing.NewStartOperationUseCase(woRepo, opRepo, equipmentRepo)
+	pauseOperationUC := routing.NewPauseOperationUseCase(woRepo, opRepo)
+	resumeOperationUC := routing.NewResumeOperationUseCase(woRepo, opRepo, equipmentRepo)
+	completeOperationUC := routing.NewCompleteOperationUseCase(woRepo, opRepo, qcRepo, transactor, eventPub)
+
+	// Initialize Batch Record use cases
+	generateBatchRecordUC := batchrecord.NewGenerateBatchRecordUseCase(batchRecordRepo, woRepo, opRepo, qcRepo, ncrRepo, traceRepo, lineClearanceRepo)
+	getBatchRecordUC := batchrecord.NewGetBatchRecordUseCase(batchRecordRepo)
+	listBatchRecordVersionsUC := batchrecord.NewListBatchRecordVersionsUseCase(batchRecordRepo)
+	signBatchRecordUC := batchrecord.NewSignBatchRecordUseCase(batchRecordRepo, eventPub)
+
+	// Initialize Dispensing use cases
+	wmsClient := wms.NewClient(cfg.WMSServiceURL)
+	generateWeighingTicketsUC := dispensing.NewGenerateWeighingTicketsUseCase(dispensingRepo, woRepo, bomRepo, cfg.DispensingDefaultTolerancePct)
+	listWeighingTicketsUC := dispensing.NewListWeighingTicketsUseCase(dispensingRepo)
+	getWeighingTicketUC := dispensing.NewGetWeighingTicketUseCase(dispensingRepo)
+	recordScaleReadingUC := dispensing.NewRecordScaleReadingUseCase(dispensingRepo, woRepo, equipmentRepo, traceRepo, wmsClient, eventPub)
+	verifyWeighingUC := dispensing.NewVerifyWeighingUseCase(dispensingRepo, woRepo, traceRepo, eventPub)
+	cancelWeighingTicketUC := dispensing.NewCancelWeighingTicketUseCase(dispensingRepo)
+
+	// Initialize Sampling use cases
+	createSamplingPlanUC := sampling.NewCreateSamplingPlanUseCase(samplingRepo)
+	getSamplingPlanUC := sampling.NewGetSamplingPlanUseCase(samplingRepo)
+	listSamplingPlansUC := sampling.NewListSamplingPlansUseCase(samplingRepo)
+	assignSamplingPlanUC := sampling.NewAssignSamplingPlanUseCase(samplingRepo, qcRepo)
+	determineSampleUC := sampling.NewDetermineSampleUseCase(samplingRepo)
+	listSamplingStatesUC := sampling.NewListSamplingStatesUseCase(samplingRepo)
+	resumeSamplingUC := sampling.NewResumeSamplingUseCase(samplingRepo)
+
+	// Initialize CoA use cases
+	fileClient := filestore.NewClient(cfg.FileServiceURL)
+	generateCoAUC := coa.NewGenerateCoAUseCase(coaRepo, qcRepo, fileClient)
+	getCoAUC := coa.NewGetCoAUseCase(coaRepo)
+	listCoAsUC := coa.NewListCoAsUseCase(coaRepo)
+	recordSupplierCoAUC := coa.NewRecordSupplierCoAUseCase(coaRepo, qcRepo)
+	getSupplierCoAUC := coa.NewGetSupplierCoAUseCase(coaRepo)
+	listSupplierCoAsUC := coa.NewListSupplierCoAsUseCase(coaRepo)
+
+	// Initialize Recall use cases
+	salesClient := sales.NewClient(cfg.SalesServiceURL)
+	initiateRecallUC := recall.NewInitiateRecallUseCase(recallRepo, traceRepo, wmsClient, salesClient, eventPub)
+	getRecallUC := recall.NewGetRecallUseCase(recallRepo)
+	listRecallsUC := recall.NewListRecallsUseCase(recallRepo)
+	completeRecallUC := recall.NewCompleteRecallUseCase(recallRepo)
+
+	// Initialize Stability use cases
+	createStudyUC := stability.NewCreateStudyUseCase(stabilityRepo, bomRepo, qcRepo)
+	getStudyUC := stability.NewGetStudyUseCase(stabilityRepo)
+	listStudiesUC := stability.NewListStudiesUseCase(stabilityRepo)
+	recordStabilityResultsUC := stability.NewRecordResultsUseCase(stabilityRepo, qcRepo)
+	evaluateStudyUC := stability.NewEvaluateStudyUseCase(stabilityRepo, qcRepo)
+	completeStudyUC := stability.NewCompleteStudyUseCase(stabilityRepo, qcRepo)
+
+	// Initialize Equipment use cases
+	registerEquipmentUC := equipment.NewRegisterEquipmentUseCase(equipmentRepo, routingRepo)
+	getEquipmentUC := equipment.NewGetEquipmentUseCase(equipmentRepo)
+	listEquipmentUC := equipment.NewListEquipmentUseCase(equipmentRepo)
+	updateEquipmentStatusUC := equipment.NewUpdateStatusUseCase(equipmentRepo)
+	recordCalibrationUC := equipment.NewRecordCalibrationUseCase(equipmentRepo)
+	createMaintenancePlanUC := equipment.NewCreateMaintenancePlanUseCase(equipmentRepo)
+	recordMaintenanceUC := equipment.NewRecordMaintenanceUseCase(equipmentRepo)
+	listMaintenanceRecordsUC := equipment.NewListMaintenanceRecordsUseCase(equipmentRepo)
+	equipmentAlertsUC := equipment.NewSendDueAlertsUseCase(equipmentRepo, eventPub, 14, 24*time.Hour)
+
+	// Initialize Line Clearance use cases
+	masterDataClient := masterdata.NewClient(cfg.MasterDataServiceURL)
+	createLineClearanceUC := lineclearance.NewCreateLineClearanceUseCase(lineClearanceRepo, woRepo, masterDataClient)
+	getLineClearanceUC := lineclearance.NewGetLineClearanceUseCase(lineClearanceRepo)
+	listLineClearancesUC := lineclearance.NewListLineClearancesUseCase(lineClearanceRepo)
+	checkLineClearanceItemUC := lineclearance.NewCheckItemUseCase(lineClearanceRepo)
+	completeLineClearanceUC := lineclearance.NewCompleteLineClearanceUseCase(lineClearanceRepo)
+	verifyLineClearanceUC := lineclearance.NewVerifyLineClearanceUseCase(lineClearanceRepo)
+
+	// Initialize Costing use cases
+	calculateWOCostUC := costing.NewCalculateWOCostUseCase(costingRepo, woRepo, bomRepo, opRepo, routingRepo, wmsClient, eventPub)
+	getWOCostUC := costing.NewGetWOCostUseCase(costingRepo)
+	setOverheadRateUC := costing.NewSetOverheadRateUseCase(costingRepo)
+	listOverheadRatesUC := costing.NewListOverheadRatesUseCase(costingRepo)
+
 	// Initialize handlers
 	bomHandler := handler.NewBOMHandler(createBOMUC, getBOMUC, listBOMsUC, approveBOMUC, getActiveBOMUC)
-	woHandler := handler.NewWOHandler(createWOUC, getWOUC, listWOsUC, releaseWOUC, startWOUC, completeWOUC)
+	woHandler := handler.NewWOHandler(createWOUC, getWOUC, listWOsUC, releaseWOUC, startWOUC, completeWOUC, materialVarianceUC, createReworkWOUC, cancelWOUC)
 	qcHandler := handler.NewQCHandler(getCheckpointsUC, createInspectionUC, getInspectionUC, listInspectionsUC, approveInspectionUC)
 	ncrHandler := handler.NewNCRHandler(createNCRUC, getNCRUC, listNCRsUC, closeNCRUC)
 	traceHandler := handler.NewTraceHandler(traceBackwardUC, traceForwardUC)
+	routingHandler := handler.NewRoutingHandler(createWorkCenterUC, listWorkCentersUC, createRoutingUC, getRoutingUC, listRoutingsUC, activateRoutingUC)
+	operationHandler := handler.NewOperationHandler(getOperationsUC, startOperationUC, pauseOperationUC, resumeOperationUC, completeOperationUC)
+	batchRecordHandler := handler.NewBatchRecordHandler(generateBatchRecordUC, getBatchRecordUC, listBatchRecordVersionsUC, signBatchRecordUC)
+	dispensingHandler := handler.NewDispensingHandler(generateWeighingTicketsUC, listWeighingTicketsUC, getWeighingTicketUC, recordScaleReadingUC, verifyWeighingUC, cancelWeighingTicketUC)
+	spcHandler := handler.NewSPCHandler(getSPCChartUC)
+	samplingHandler := handler.NewSamplingHandler(createSamplingPlanUC, getSamplingPlanUC, listSamplingPlansUC, assignSamplingPlanUC, determineSampleUC, listSamplingStatesUC, resumeSamplingUC)
+	coaHandler := handler.NewCoAHandler(generateCoAUC, getCoAUC, listCoAsUC, recordSupplierCoAUC, getSupplierCoAUC, listSupplierCoAsUC)
+	capaHandler := handler.NewCAPAHandler(createCAPAUC, getCAPAUC, listCAPAsUC, linkCAPAUC, addCAPAActionUC, completeCAPAActionUC, verifyCAPAUC)
+	recallHandler := handler.NewRecallHandler(initiateRecallUC, getRecallUC, listRecallsUC, completeRecallUC)
+	stabilityHandler := handler.NewStabilityHandler(createStudyUC, getStudyUC, listStudiesUC, recordStabilityResultsUC, evaluateStudyUC, completeStudyUC)
+	equipmentHandler := handler.NewEquipmentHandler(registerEquipmentUC, getEquipmentUC, listEquipmentUC, updateEquipmentStatusUC, recordCalibrationUC, createMaintenancePlanUC, recordMaintenanceUC, listMaintenanceRecordsUC)
+	lineClearanceHandler := handler.NewLineClearanceHandler(createLineClearanceUC, getLineClearanceUC, listLineClearancesUC, checkLineClearanceItemUC, completeLineClearanceUC, verifyLineClearanceUC)
+	costingHandler := handler.NewCostingHandler(calculateWOCostUC, getWOCostUC, setOverheadRateUC, listOverheadRatesUC)
 	healthHandler := handler.NewHealthHandler()
 
 	// Setup router
-	r := router.SetupRouter(bomHandler, woHandler, qcHandler, ncrHandler, traceHandler, healthHandler)
+	r := router.SetupRouter(bomHandler, woHandler, qcHandler, ncrHandler, traceHandler, routingHandler, operationHandler, batchRecordHandler, dispensingHandler, spcHandler, samplingHandler, coaHandler, capaHandler, recallHandler, stabilityHandler, equipmentHandler, lineClearanceHandler, costingHandler, healthHandler)
+
+	// Start scheduler (CAPA overdue reminders, equipment due alerts)
+	sched := scheduler.NewScheduler(capaRemindersUC, equipmentAlertsUC, log, nil)
+	sched.Start()
 
 	// Start HTTP server
 	srv := &http.Server{
@@ -143,6 +296,8 @@ func main() {
 		log.Fatal("Server forced to shutdown", zap.Error(err))
 	}
 
+	sched.Stop()
+
 	if natsClient != nil {
 		natsClient.Close()
 	}
@@ -32,6 +32,19 @@ type Config struct {
 
 	// WMS gRPC
 	WMSGRPCAddress string
+
+	// File service (CoA documents)
+	FileServiceURL string
+
+	// WMS and sales REST APIs (recall reports)
+	WMSServiceURL   string
+	SalesServiceURL string
+
+	// Master data REST API (material allergen flags for line clearance)
+	MasterDataServiceURL string
+
+	// Weighing tolerance (% of target) for BOM lines without min/max
+	DispensingDefaultTolerancePct float64
 }
 
 // Load loads configuration from environment
@@ -52,21 +65,33 @@ func Load() (*Config, error) {
 	viper.SetDefault("DB_SSLMODE", "disable")
 	viper.SetDefault("NATS_URL", "nats://localhost:4222")
 	viper.SetDefault("WMS_GRPC_ADDRESS", "localhost:9086")
+	viper.SetDefault("FILE_SERVICE_URL", "http://localhost:8091")
+	viper.SetDefault("WMS_SERVICE_URL", "http://localhost:8086")
+	viper.SetDefault("SALES_SERVICE_URL", "http://localhost:8088")
+	viper.SetDefault("MASTER_DATA_SERVICE_URL", "http://localhost:8083")
+	viper.SetDefault("DISPENSING_DEFAULT_TOLERANCE_PCT", 1.0)
 
 	cfg := &Config{
-		ServiceName:    viper.GetString("SERVICE_NAME"),
-		Port:           viper.GetString("PORT"),
-		GRPCPort:       viper.GetString("GRPC_PORT"),
-		LogLevel:       viper.GetString("LOG_LEVEL"),
-		LogFormat:      viper.GetString("LOG_FORMAT"),
-		DBHost:         viper.GetString("DB_HOST"),
-		DBPort:         viper.GetString("DB_PORT"),
-		DBUser:         viper.GetString("DB_USER"),
-		DBPassword:     viper.GetString("DB_PASSWORD"),
-		DBName:         viper.GetString("DB_NAME"),
-		DBSSLMode:      viper.GetString("DB_SSLMODE"),
-		NATSUrl:        viper.GetString("NATS_URL"),
-		WMSGRPCAddress: viper.GetString("WMS_GRPC_ADDRESS"),
+		ServiceName:     viper.GetString("SERVICE_NAME"),
+		Port:            viper.GetString("PORT"),
+		GRPCPort:        viper.GetString("GRPC_PORT"),
+		LogLevel:        viper.GetString("LOG_LEVEL"),
+		LogFormat:       viper.GetString("LOG_FORMAT"),
+		DBHost:          viper.GetString("DB_HOST"),
+		DBPort:          viper.GetString("DB_PORT"),
+		DBUser:          viper.GetString("DB_USER"),
+		DBPassword:      viper.GetString("DB_PASSWORD"),
+		DBName:          viper.GetString("DB_NAME"),
+		DBSSLMode:       viper.GetString("DB_SSLMODE"),
+		NATSUrl:         viper.GetString("NATS_URL"),
+		WMSGRPCAddress:  viper.GetString("WMS_GRPC_ADDRESS"),
+		FileServiceURL:  viper.GetString("FILE_SERVICE_URL"),
+		WMSServiceURL:   viper.GetString("WMS_SERVICE_URL"),
+		SalesServiceURL: viper.GetString("SALES_SERVICE_URL"),
+
+		MasterDataServiceURL: viper.GetString("MASTER_DATA_SERVICE_URL"),
+
+		DispensingDefaultTolerancePct: viper.GetFloat64("DISPENSING_DEFAULT_TOLERANCE_PCT"),
 	}
 
 	// Load encryption key (32 bytes for AES-256)
@@ -75,7 +100,7 @@ func Load() (*Config, error) {
 		// Default key for development only - CHANGE IN PRODUCTION
 		keyHex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
 	}
-	
+
 	key, err := hex.DecodeString(keyHex)
 	if err != nil {
 		return nil, fmt.Errorf("invalid BOM_ENCRYPTION_KEY: %w", err)
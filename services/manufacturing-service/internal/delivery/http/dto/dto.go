@@ -93,6 +93,29 @@ type CreateWORequest struct {
 	Shift            string     `json:"shift"`
 	Priority         string     `json:"priority"`
 	Notes            string     `json:"notes"`
+	Outputs          []WOOutputRequest `json:"outputs"`
+}
+
+// WOOutputRequest declares a co-product or by-product of a work order
+type WOOutputRequest struct {
+	OutputType      string    `json:"output_type" binding:"required,oneof=CO_PRODUCT BY_PRODUCT"`
+	ProductID       uuid.UUID `json:"product_id" binding:"required"`
+	PlannedQuantity float64   `json:"planned_quantity"`
+	UOMID           uuid.UUID `json:"uom_id" binding:"required"`
+	Notes           string    `json:"notes"`
+}
+
+// CreateReworkWORequest is the request for creating a rework work order from an NCR
+type CreateReworkWORequest struct {
+	NCRID          uuid.UUID `json:"ncr_id" binding:"required"`
+	BOMID          uuid.UUID `json:"bom_id" binding:"required"`
+	Quantity       float64   `json:"quantity"`
+	UOMID          uuid.UUID `json:"uom_id"`
+	BatchNumber    string    `json:"batch_number"`
+	ProductionLine string    `json:"production_line"`
+	Shift          string    `json:"shift"`
+	Priority       string    `json:"priority"`
+	Notes          string    `json:"notes"`
 }
 
 // WOResponse is the response for a work order
@@ -102,6 +125,7 @@ type WOResponse struct {
 	WODate           time.Time    `json:"wo_date"`
 	ProductID        uuid.UUID    `json:"product_id"`
 	BOMID            uuid.UUID    `json:"bom_id"`
+	WOType           string       `json:"wo_type"`
 	Status           string       `json:"status"`
 	Priority         string       `json:"priority"`
 	PlannedQuantity  float64      `json:"planned_quantity"`
@@ -115,6 +139,10 @@ type WOResponse struct {
 	ActualStartDate  *time.Time   `json:"actual_start_date,omitempty"`
 	ActualEndDate    *time.Time   `json:"actual_end_date,omitempty"`
 	ProductionLine   string       `json:"production_line,omitempty"`
+	NCRID            *uuid.UUID   `json:"ncr_id,omitempty"`
+	SourceLotID      *uuid.UUID   `json:"source_lot_id,omitempty"`
+	SourceLotNumber  string       `json:"source_lot_number,omitempty"`
+	Outputs          []entity.WOOutput `json:"outputs,omitempty"`
 	Notes            string       `json:"notes,omitempty"`
 	CreatedAt        time.Time    `json:"created_at"`
 }
@@ -129,7 +157,16 @@ type CompleteWORequest struct {
 	ActualQuantity   float64 `json:"actual_quantity" binding:"required"`
 	GoodQuantity     float64 `json:"good_quantity" binding:"required"`
 	RejectedQuantity float64 `json:"rejected_quantity"`
+	Backflush        bool    `json:"backflush"`
 	Notes            string  `json:"notes"`
+	Outputs          []CompleteWOOutputRequest `json:"outputs"`
+}
+
+// CompleteWOOutputRequest records the produced quantity of a declared co-product or by-product
+type CompleteWOOutputRequest struct {
+	OutputID  uuid.UUID `json:"output_id" binding:"required"`
+	Quantity  float64   `json:"quantity"`
+	LotNumber string    `json:"lot_number"`
 }
 
 // ===== QC DTOs =====
@@ -148,6 +185,9 @@ type CreateInspectionRequest struct {
 	SampleSize        *int                          `json:"sample_size"`
 	InspectorName     string                        `json:"inspector_name"`
 	Items             []CreateInspectionItemRequest `json:"items"`
+
+	SupplierID  *uuid.UUID `json:"supplier_id"`  // Switching history subject for IQC plans
+	DefectCount *int       `json:"defect_count"` // Defects found in the AQL sample
 }
 
 // CreateInspectionItemRequest is the request for a QC inspection item
@@ -159,10 +199,12 @@ type CreateInspectionItemRequest struct {
 	TargetValue   string `json:"target_value"`
 	MinValue      string `json:"min_value"`
 	MaxValue      string `json:"max_value"`
-	ActualValue   string `json:"actual_value"`
-	UOM           string `json:"uom"`
-	Result        string `json:"result" binding:"required"`
-	Notes         string `json:"notes"`
+	ActualValue   string   `json:"actual_value"`
+	NumericValue  *float64 `json:"numeric_value"`
+	UOM           string   `json:"uom"`
+	IsCritical    bool     `json:"is_critical"`
+	Result        string   `json:"result" binding:"omitempty,oneof=PASS FAIL N/A"` // Derived for numeric tests with limits
+	Notes         string   `json:"notes"`
 }
 
 // ApproveInspectionRequest is the request for approving an inspection
@@ -170,9 +212,61 @@ type ApproveInspectionRequest struct {
 	Result           string   `json:"result" binding:"required"`
 	AcceptedQuantity *float64 `json:"accepted_quantity"`
 	RejectedQuantity *float64 `json:"rejected_quantity"`
+	DefectCount      *int     `json:"defect_count"`
 	Notes            string   `json:"notes"`
 }
 
+// ===== Sampling DTOs =====
+
+// CreateSamplingPlanRequest is the request for creating an AQL sampling plan
+type CreateSamplingPlanRequest struct {
+	PlanCode        string  `json:"plan_code" binding:"required"`
+	Name            string  `json:"name" binding:"required"`
+	InspectionLevel string  `json:"inspection_level"`
+	AQL             float64 `json:"aql" binding:"required"`
+	SubjectType     string  `json:"subject_type" binding:"required,oneof=SUPPLIER PRODUCT MATERIAL"`
+	Notes           string  `json:"notes"`
+}
+
+// AssignSamplingPlanRequest is the request for attaching a sampling plan to a checkpoint
+type AssignSamplingPlanRequest struct {
+	SamplingPlanID *uuid.UUID `json:"sampling_plan_id"` // null detaches the plan
+}
+
+// ===== CoA DTOs =====
+
+// GenerateCoARequest is the request for issuing a CoA for a finished lot
+type GenerateCoARequest struct {
+	InspectionID uuid.UUID `json:"inspection_id" binding:"required"`
+	IssuedByName string    `json:"issued_by_name"`
+}
+
+// RecordSupplierCoARequest is the request for capturing a supplier CoA at goods receipt
+type RecordSupplierCoARequest struct {
+	GRNID             uuid.UUID                 `json:"grn_id" binding:"required"`
+	GRNNumber         string                    `json:"grn_number"`
+	GRNLineItemID     *uuid.UUID                `json:"grn_line_item_id"`
+	SupplierID        uuid.UUID                 `json:"supplier_id" binding:"required"`
+	MaterialID        uuid.UUID                 `json:"material_id" binding:"required"`
+	LotID             *uuid.UUID                `json:"lot_id"`
+	SupplierLotNumber string                    `json:"supplier_lot_number"`
+	CertificateNumber string                    `json:"certificate_number"`
+	IssueDate         string                    `json:"issue_date"` // YYYY-MM-DD
+	CheckpointID      uuid.UUID                 `json:"checkpoint_id" binding:"required"`
+	DocumentFileID    *uuid.UUID                `json:"document_file_id"`
+	Notes             string                    `json:"notes"`
+	Values            []SupplierCoAValueRequest `json:"values" binding:"required,min=1,dive"`
+}
+
+// SupplierCoAValueRequest is one value reported on a supplier CoA
+type SupplierCoAValueRequest struct {
+	TestName      string   `json:"test_name" binding:"required"`
+	ReportedValue string   `json:"reported_value"`
+	NumericValue  *float64 `json:"numeric_value"`
+	UOM           string   `json:"uom"`
+	Result        string   `json:"result" binding:"omitempty,oneof=PASS FAIL N/A"`
+}
+
 // ===== NCR DTOs =====
 
 // CreateNCRRequest is the request for creating an NCR
@@ -200,3 +294,249 @@ type CloseNCRRequest struct {
 	DispositionQty   *float64 `json:"disposition_quantity"`
 	ClosureNotes     string  `json:"closure_notes"`
 }
+
+// ===== CAPA DTOs =====
+
+// CreateCAPARequest is the request for creating a CAPA
+type CreateCAPARequest struct {
+	Title                 string              `json:"title" binding:"required"`
+	Description           string              `json:"description"`
+	RootCause             string              `json:"root_cause"`
+	OwnerID               uuid.UUID           `json:"owner_id" binding:"required"`
+	DueDate               string              `json:"due_date"` // YYYY-MM-DD
+	EffectivenessCriteria string              `json:"effectiveness_criteria"`
+	VerificationDueDate   string              `json:"verification_due_date"` // YYYY-MM-DD
+	Links                 []CAPALinkRequest   `json:"links" binding:"dive"`
+	Actions               []CAPAActionRequest `json:"actions" binding:"dive"`
+}
+
+// CAPALinkRequest links a CAPA to an NCR, supplier evaluation or customer complaint
+type CAPALinkRequest struct {
+	SourceType      string    `json:"source_type" binding:"required,oneof=NCR SUPPLIER_EVALUATION CUSTOMER_COMPLAINT"`
+	SourceID        uuid.UUID `json:"source_id" binding:"required"`
+	SourceReference string    `json:"source_reference"`
+}
+
+// CAPAActionRequest is the request for adding a CAPA action
+type CAPAActionRequest struct {
+	ActionType  string    `json:"action_type" binding:"required,oneof=CORRECTIVE PREVENTIVE"`
+	Description string    `json:"description" binding:"required"`
+	OwnerID     uuid.UUID `json:"owner_id" binding:"required"`
+	DueDate     string    `json:"due_date" binding:"required"` // YYYY-MM-DD
+	IsCritical  bool      `json:"is_critical"`
+}
+
+// CompleteCAPAActionRequest is the request for completing a CAPA action
+type CompleteCAPAActionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// VerifyCAPARequest is the request for recording CAPA effectiveness
+type VerifyCAPARequest struct {
+	Effective *bool  `json:"effective" binding:"required"`
+	Notes     string `json:"notes"`
+}
+
+// ===== Recall DTOs =====
+
+// InitiateRecallRequest is the request for starting a recall or mock recall
+type InitiateRecallRequest struct {
+	MaterialLotID uuid.UUID `json:"material_lot_id" binding:"required"`
+	Mode          string    `json:"mode" binding:"required,oneof=LIVE MOCK"`
+	Reason        string    `json:"reason" binding:"required"`
+}
+
+// CompleteRecallRequest is the request for completing a recall
+type CompleteRecallRequest struct {
+	Notes string `json:"notes"`
+}
+
+// ===== Stability DTOs =====
+
+// CreateStabilityStudyRequest is the request for creating a stability study
+type CreateStabilityStudyRequest struct {
+	BOMID          uuid.UUID                   `json:"bom_id" binding:"required"`
+	WorkOrderID    *uuid.UUID                  `json:"work_order_id"`
+	LotID          *uuid.UUID                  `json:"lot_id"`
+	LotNumber      string                      `json:"lot_number" binding:"required"`
+	CheckpointID   uuid.UUID                   `json:"checkpoint_id" binding:"required"`
+	SampleQuantity float64                     `json:"sample_quantity" binding:"required,gt=0"`
+	StartDate      string                      `json:"start_date" binding:"required"` // YYYY-MM-DD
+	PullMonths     []int                       `json:"pull_months"`                   // e.g. [0, 1, 3, 6, 12]
+	Conditions     []StabilityConditionRequest `json:"conditions" binding:"required,min=1,dive"`
+	Purpose        string                      `json:"purpose"`
+}
+
+// StabilityConditionRequest is a storage condition of a stability study
+type StabilityConditionRequest struct {
+	Name          string   `json:"name" binding:"required"` // e.g. 25°C/60%RH
+	ConditionType string   `json:"condition_type" binding:"required,oneof=LONG_TERM INTERMEDIATE ACCELERATED"`
+	TemperatureC  float64  `json:"temperature_c"`
+	HumidityRH    *float64 `json:"humidity_rh"`
+	PullMonths    []int    `json:"pull_months"` // Overrides the study pull months
+}
+
+// RecordStabilityResultsRequest is the request for recording the results of a pull point
+type RecordStabilityResultsRequest struct {
+	TesterName string                        `json:"tester_name"`
+	Items      []CreateInspectionItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CompleteStabilityStudyRequest is the request for completing a stability study
+type CompleteStabilityStudyRequest struct {
+	Notes string `json:"notes"`
+}
+
+// ===== Equipment DTOs =====
+
+// RegisterEquipmentRequest is the request for registering equipment
+type RegisterEquipmentRequest struct {
+	Code                    string     `json:"code" binding:"required"` // Scales: the scale_id sent with weighing readings
+	Name                    string     `json:"name" binding:"required"`
+	EquipmentType           string     `json:"equipment_type" binding:"required,oneof=SCALE MIXER HOMOGENIZER FILLING_LINE TANK OTHER"`
+	ProductionLine          string     `json:"production_line"`
+	WorkCenterID            *uuid.UUID `json:"work_center_id"`
+	Manufacturer            string     `json:"manufacturer"`
+	Model                   string     `json:"model"`
+	SerialNumber            string     `json:"serial_number"`
+	RequiresCalibration     *bool      `json:"requires_calibration"` // Default true
+	CalibrationIntervalDays int        `json:"calibration_interval_days" binding:"gte=0"`
+	ResponsibleUserID       *uuid.UUID `json:"responsible_user_id"`
+	Notes                   string     `json:"notes"`
+}
+
+// UpdateEquipmentStatusRequest is the request for changing the equipment status
+type UpdateEquipmentStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=ACTIVE OUT_OF_SERVICE RETIRED"`
+}
+
+// RecordCalibrationRequest is the request for recording a calibration certificate
+type RecordCalibrationRequest struct {
+	CertificateNumber string `json:"certificate_number" binding:"required"`
+	CalibrationDate   string `json:"calibration_date" binding:"required"` // YYYY-MM-DD
+	DueDate           string `json:"due_date"`                            // YYYY-MM-DD, defaults to the equipment interval
+	Result            string `json:"result" binding:"required,oneof=PASS FAIL"`
+	CalibratedBy      string `json:"calibrated_by"`
+	CertificateURL    string `json:"certificate_url"`
+	Notes             string `json:"notes"`
+}
+
+// CreateMaintenancePlanRequest is the request for creating a preventive maintenance plan
+type CreateMaintenancePlanRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Tasks        string `json:"tasks"`
+	IntervalDays int    `json:"interval_days" binding:"required,gt=0"`
+	FirstDueDate string `json:"first_due_date" binding:"required"` // YYYY-MM-DD
+}
+
+// RecordMaintenanceRequest is the request for recording performed maintenance
+type RecordMaintenanceRequest struct {
+	PerformedDate string `json:"performed_date" binding:"required"` // YYYY-MM-DD
+	Notes         string `json:"notes"`
+}
+
+// ===== Line Clearance DTOs =====
+
+// CreateLineClearanceRequest is the request for opening a line clearance
+type CreateLineClearanceRequest struct {
+	WorkOrderID     uuid.UUID                  `json:"work_order_id" binding:"required"`
+	CleaningLevel   string                     `json:"cleaning_level" binding:"omitempty,oneof=MINOR STANDARD MAJOR"` // Defaults to the required level
+	AdditionalItems []LineClearanceItemRequest `json:"additional_items" binding:"dive"`
+	Notes           string                     `json:"notes"`
+}
+
+// LineClearanceItemRequest is an extra checklist item
+type LineClearanceItemRequest struct {
+	Section     string `json:"section" binding:"required,oneof=CLEANING CLEARANCE"`
+	Description string `json:"description" binding:"required"`
+}
+
+// CheckLineClearanceItemRequest is the request for checking a checklist item
+type CheckLineClearanceItemRequest struct {
+	Remarks string `json:"remarks"`
+}
+
+// ===== Costing DTOs =====
+
+// SetOverheadRateRequest is the request for setting a line overhead absorption rate
+type SetOverheadRateRequest struct {
+	ProductionLine string  `json:"production_line" binding:"required"`
+	RatePerHour    float64 `json:"rate_per_hour" binding:"gte=0"`
+	Notes          string  `json:"notes"`
+}
+
+// ===== Routing DTOs =====
+
+// CreateWorkCenterRequest is the request for creating a work center
+type CreateWorkCenterRequest struct {
+	Code           string  `json:"code" binding:"required"`
+	Name           string  `json:"name" binding:"required"`
+	Description    string  `json:"description"`
+	ProductionLine string  `json:"production_line"`
+	CostPerHour    float64 `json:"cost_per_hour"`
+}
+
+// CreateRoutingRequest is the request for creating a routing
+type CreateRoutingRequest struct {
+	RoutingNumber string                          `json:"routing_number" binding:"required"`
+	ProductID     uuid.UUID                       `json:"product_id" binding:"required"`
+	BOMID         *uuid.UUID                      `json:"bom_id"`
+	Version       int                             `json:"version"`
+	Name          string                          `json:"name" binding:"required"`
+	BaseQuantity  float64                         `json:"base_quantity" binding:"required"`
+	Notes         string                          `json:"notes"`
+	Operations    []CreateRoutingOperationRequest `json:"operations" binding:"required,min=1"`
+}
+
+// CreateRoutingOperationRequest is the request for a routing operation
+type CreateRoutingOperationRequest struct {
+	Sequence          int                           `json:"sequence"`
+	OperationCode     string                        `json:"operation_code" binding:"required"`
+	Name              string                        `json:"name" binding:"required"`
+	OperationType     string                        `json:"operation_type" binding:"required"`
+	WorkCenterID      uuid.UUID                     `json:"work_center_id" binding:"required"`
+	SetupMinutes      float64                       `json:"setup_minutes"`
+	RunMinutes        float64                       `json:"run_minutes"`
+	Instructions      string                        `json:"instructions"`
+	ProcessParameters []entity.ProcessParameterSpec `json:"process_parameters"`
+	QCCheckpointID    *uuid.UUID                    `json:"qc_checkpoint_id"`
+}
+
+// OperationActionRequest is the request for start/pause/resume of an operation
+type OperationActionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// CompleteOperationRequest is the request for completing an operation
+type CompleteOperationRequest struct {
+	Parameters []ParameterReadingRequest `json:"parameters"`
+	Notes      string                    `json:"notes"`
+}
+
+// ParameterReadingRequest is a recorded process parameter value
+type ParameterReadingRequest struct {
+	Name  string  `json:"name" binding:"required"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
+}
+
+// ===== Batch Record DTOs =====
+
+// SignBatchRecordRequest is the request for signing a batch record
+type SignBatchRecordRequest struct {
+	Role       string `json:"role" binding:"required"`
+	SignerName string `json:"signer_name" binding:"required"`
+	Meaning    string `json:"meaning"`
+}
+
+// ===== Dispensing DTOs =====
+
+// ScaleReadingRequest is the request for recording a scale reading
+type ScaleReadingRequest struct {
+	ScaleID     string    `json:"scale_id" binding:"required"`
+	LotID       uuid.UUID `json:"lot_id" binding:"required"`
+	LotNumber   string    `json:"lot_number" binding:"required"`
+	GrossWeight float64   `json:"gross_weight" binding:"required,gt=0"`
+	TareWeight  float64   `json:"tare_weight" binding:"gte=0"`
+	UnitCost    float64   `json:"unit_cost" binding:"gte=0"`
+}
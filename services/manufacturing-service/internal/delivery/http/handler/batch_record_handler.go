@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/batchrecord"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BatchRecordHandler handles electronic batch record requests
+type BatchRecordHandler struct {
+	generateUC     *batchrecord.GenerateBatchRecordUseCase
+	getUC          *batchrecord.GetBatchRecordUseCase
+	listVersionsUC *batchrecord.ListBatchRecordVersionsUseCase
+	signUC         *batchrecord.SignBatchRecordUseCase
+}
+
+// NewBatchRecordHandler creates a new BatchRecordHandler
+func NewBatchRecordHandler(
+	generateUC *batchrecord.GenerateBatchRecordUseCase,
+	getUC *batchrecord.GetBatchRecordUseCase,
+	listVersionsUC *batchrecord.ListBatchRecordVersionsUseCase,
+	signUC *batchrecord.SignBatchRecordUseCase,
+) *BatchRecordHandler {
+	return &BatchRecordHandler{
+		generateUC:     generateUC,
+		getUC:          getUC,
+		listVersionsUC: listVersionsUC,
+		signUC:         signUC,
+	}
+}
+
+// GenerateBatchRecord generates a new batch record version for a completed work order
+func (h *BatchRecordHandler) GenerateBatchRecord(c *gin.Context) {
+	woID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	result, err := h.generateUC.Execute(c.Request.Context(), woID, userID)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	created(c, result)
+}
+
+// GetBatchRecord gets a batch record (JSON)
+func (h *BatchRecordHandler) GetBatchRecord(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid batch record ID")
+		return
+	}
+
+	result, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Batch record not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// GetBatchRecordPDF renders a batch record as PDF
+func (h *BatchRecordHandler) GetBatchRecordPDF(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid batch record ID")
+		return
+	}
+
+	record, err := h.getUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Batch record not found")
+		return
+	}
+
+	data, err := batchrecord.RenderPDF(record)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=\""+record.RecordNumber+".pdf\"")
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// ListBatchRecordVersions lists all versions of a batch record
+func (h *BatchRecordHandler) ListBatchRecordVersions(c *gin.Context) {
+	batchNumber := c.Query("batch_number")
+	if batchNumber == "" {
+		badRequest(c, "batch_number is required")
+		return
+	}
+
+	result, err := h.listVersionsUC.Execute(c.Request.Context(), batchNumber)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// SignBatchRecord adds an electronic signature to a batch record
+func (h *BatchRecordHandler) SignBatchRecord(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid batch record ID")
+		return
+	}
+
+	var req dto.SignBatchRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	input := batchrecord.SignBatchRecordInput{
+		RecordID:   id,
+		Role:       entity.SignatureRole(req.Role),
+		SignerID:   getUserIDFromContext(c),
+		SignerName: req.SignerName,
+		Meaning:    req.Meaning,
+	}
+
+	result, err := h.signUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
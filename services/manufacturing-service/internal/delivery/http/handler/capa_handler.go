@@ -0,0 +1,328 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/capa"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CAPAHandler handles CAPA requests
+type CAPAHandler struct {
+	createCAPAUC     *capa.CreateCAPAUseCase
+	getCAPAUC        *capa.GetCAPAUseCase
+	listCAPAsUC      *capa.ListCAPAsUseCase
+	linkCAPAUC       *capa.LinkCAPAUseCase
+	addActionUC      *capa.AddCAPAActionUseCase
+	completeActionUC *capa.CompleteCAPAActionUseCase
+	verifyCAPAUC     *capa.VerifyCAPAUseCase
+}
+
+// NewCAPAHandler creates a new CAPAHandler
+func NewCAPAHandler(
+	createCAPAUC *capa.CreateCAPAUseCase,
+	getCAPAUC *capa.GetCAPAUseCase,
+	listCAPAsUC *capa.ListCAPAsUseCase,
+	linkCAPAUC *capa.LinkCAPAUseCase,
+	addActionUC *capa.AddCAPAActionUseCase,
+	completeActionUC *capa.CompleteCAPAActionUseCase,
+	verifyCAPAUC *capa.VerifyCAPAUseCase,
+) *CAPAHandler {
+	return &CAPAHandler{
+		createCAPAUC:     createCAPAUC,
+		getCAPAUC:        getCAPAUC,
+		listCAPAsUC:      listCAPAsUC,
+		linkCAPAUC:       linkCAPAUC,
+		addActionUC:      addActionUC,
+		completeActionUC: completeActionUC,
+		verifyCAPAUC:     verifyCAPAUC,
+	}
+}
+
+// CreateCAPA creates a CAPA with its links and actions
+func (h *CAPAHandler) CreateCAPA(c *gin.Context) {
+	var req dto.CreateCAPARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	input := capa.CreateCAPAInput{
+		Title:                 req.Title,
+		Description:           req.Description,
+		RootCause:             req.RootCause,
+		OwnerID:               req.OwnerID,
+		EffectivenessCriteria: req.EffectivenessCriteria,
+		CreatedBy:             getUserIDFromContext(c),
+	}
+	if req.DueDate != "" {
+		dueDate, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			badRequest(c, "Invalid due_date")
+			return
+		}
+		input.DueDate = &dueDate
+	}
+	if req.VerificationDueDate != "" {
+		verificationDueDate, err := time.Parse("2006-01-02", req.VerificationDueDate)
+		if err != nil {
+			badRequest(c, "Invalid verification_due_date")
+			return
+		}
+		input.VerificationDueDate = &verificationDueDate
+	}
+	for _, l := range req.Links {
+		input.Links = append(input.Links, toCAPALinkInput(l))
+	}
+	for _, a := range req.Actions {
+		action, err := toCAPAActionInput(a)
+		if err != nil {
+			badRequest(c, "Invalid action due_date")
+			return
+		}
+		input.Actions = append(input.Actions, action)
+	}
+
+	result, err := h.createCAPAUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrNCRNotFound:
+			notFound(c, "NCR not found")
+		case entity.ErrInvalidCAPASource, entity.ErrInvalidCAPAAction:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetCAPA gets a CAPA by ID
+func (h *CAPAHandler) GetCAPA(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CAPA ID")
+		return
+	}
+
+	result, err := h.getCAPAUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "CAPA not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListCAPAs lists CAPAs
+func (h *CAPAHandler) ListCAPAs(c *gin.Context) {
+	filter := repository.CAPAFilter{
+		Page:     getPageFromQuery(c),
+		PageSize: getPageSizeFromQuery(c),
+	}
+
+	if status := c.Query("status"); status != "" {
+		s := entity.CAPAStatus(status)
+		filter.Status = &s
+	}
+	if ownerID := c.Query("owner_id"); ownerID != "" {
+		id, err := uuid.Parse(ownerID)
+		if err != nil {
+			badRequest(c, "Invalid owner_id")
+			return
+		}
+		filter.OwnerID = &id
+	}
+	if sourceType := c.Query("source_type"); sourceType != "" {
+		st := entity.CAPASourceType(sourceType)
+		filter.SourceType = &st
+	}
+	if sourceID := c.Query("source_id"); sourceID != "" {
+		id, err := uuid.Parse(sourceID)
+		if err != nil {
+			badRequest(c, "Invalid source_id")
+			return
+		}
+		filter.SourceID = &id
+	}
+
+	capas, total, err := h.listCAPAsUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, capas, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// LinkCAPA links a CAPA to another NCR, supplier evaluation or customer complaint
+func (h *CAPAHandler) LinkCAPA(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CAPA ID")
+		return
+	}
+
+	var req dto.CAPALinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.linkCAPAUC.Execute(c.Request.Context(), id, toCAPALinkInput(req), getUserIDFromContext(c))
+	if err != nil {
+		switch err {
+		case entity.ErrCAPANotFound:
+			notFound(c, "CAPA not found")
+		case entity.ErrNCRNotFound:
+			notFound(c, "NCR not found")
+		case entity.ErrInvalidCAPASource, entity.ErrCAPALinkExists:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// AddAction adds an action to a CAPA
+func (h *CAPAHandler) AddAction(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CAPA ID")
+		return
+	}
+
+	var req dto.CAPAActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+	input, err := toCAPAActionInput(req)
+	if err != nil {
+		badRequest(c, "Invalid due_date")
+		return
+	}
+
+	result, err := h.addActionUC.Execute(c.Request.Context(), id, input)
+	if err != nil {
+		switch err {
+		case entity.ErrCAPANotFound:
+			notFound(c, "CAPA not found")
+		case entity.ErrCAPAClosed, entity.ErrInvalidCAPAAction:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// CompleteAction completes a CAPA action
+func (h *CAPAHandler) CompleteAction(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CAPA ID")
+		return
+	}
+	actionID, err := uuid.Parse(c.Param("action_id"))
+	if err != nil {
+		badRequest(c, "Invalid action ID")
+		return
+	}
+
+	var req dto.CompleteCAPAActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.completeActionUC.Execute(c.Request.Context(), capa.CompleteCAPAActionInput{
+		CAPAID:      id,
+		ActionID:    actionID,
+		Notes:       req.Notes,
+		CompletedBy: getUserIDFromContext(c),
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrCAPANotFound:
+			notFound(c, "CAPA not found")
+		case entity.ErrCAPAActionNotFound:
+			notFound(c, "CAPA action not found")
+		case entity.ErrCAPAActionNotOpen:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// VerifyCAPA records the effectiveness verification of a CAPA
+func (h *CAPAHandler) VerifyCAPA(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CAPA ID")
+		return
+	}
+
+	var req dto.VerifyCAPARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.verifyCAPAUC.Execute(c.Request.Context(), capa.VerifyCAPAInput{
+		CAPAID:     id,
+		Effective:  *req.Effective,
+		Notes:      req.Notes,
+		VerifiedBy: getUserIDFromContext(c),
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrCAPANotFound:
+			notFound(c, "CAPA not found")
+		case entity.ErrCAPANotPendingVerification:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+func toCAPALinkInput(req dto.CAPALinkRequest) capa.LinkInput {
+	return capa.LinkInput{
+		SourceType:      entity.CAPASourceType(req.SourceType),
+		SourceID:        req.SourceID,
+		SourceReference: req.SourceReference,
+	}
+}
+
+func toCAPAActionInput(req dto.CAPAActionRequest) (capa.ActionInput, error) {
+	dueDate, err := time.Parse("2006-01-02", req.DueDate)
+	if err != nil {
+		return capa.ActionInput{}, err
+	}
+	return capa.ActionInput{
+		ActionType:  entity.CAPAActionType(req.ActionType),
+		Description: req.Description,
+		OwnerID:     req.OwnerID,
+		DueDate:     dueDate,
+		IsCritical:  req.IsCritical,
+	}, nil
+}
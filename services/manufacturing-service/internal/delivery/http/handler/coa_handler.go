@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/coa"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CoAHandler handles certificate of analysis requests
+type CoAHandler struct {
+	generateCoAUC       *coa.GenerateCoAUseCase
+	getCoAUC            *coa.GetCoAUseCase
+	listCoAsUC          *coa.ListCoAsUseCase
+	recordSupplierCoAUC *coa.RecordSupplierCoAUseCase
+	getSupplierCoAUC    *coa.GetSupplierCoAUseCase
+	listSupplierCoAsUC  *coa.ListSupplierCoAsUseCase
+}
+
+// NewCoAHandler creates a new CoAHandler
+func NewCoAHandler(
+	generateCoAUC *coa.GenerateCoAUseCase,
+	getCoAUC *coa.GetCoAUseCase,
+	listCoAsUC *coa.ListCoAsUseCase,
+	recordSupplierCoAUC *coa.RecordSupplierCoAUseCase,
+	getSupplierCoAUC *coa.GetSupplierCoAUseCase,
+	listSupplierCoAsUC *coa.ListSupplierCoAsUseCase,
+) *CoAHandler {
+	return &CoAHandler{
+		generateCoAUC:       generateCoAUC,
+		getCoAUC:            getCoAUC,
+		listCoAsUC:          listCoAsUC,
+		recordSupplierCoAUC: recordSupplierCoAUC,
+		getSupplierCoAUC:    getSupplierCoAUC,
+		listSupplierCoAsUC:  listSupplierCoAsUC,
+	}
+}
+
+// GenerateCoA issues a CoA for a finished lot from its approved FQC inspection
+func (h *CoAHandler) GenerateCoA(c *gin.Context) {
+	var req dto.GenerateCoARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.generateCoAUC.Execute(c.Request.Context(), coa.GenerateCoAInput{
+		InspectionID: req.InspectionID,
+		IssuedBy:     getUserIDFromContext(c),
+		IssuedByName: req.IssuedByName,
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrQCInspectionNotFound:
+			notFound(c, "Inspection not found")
+		case entity.ErrCoAInspectionNotPassed, entity.ErrCoALotRequired, entity.ErrCoANoResults:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetCoA gets a CoA by ID
+func (h *CoAHandler) GetCoA(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CoA ID")
+		return
+	}
+
+	result, err := h.getCoAUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "CoA not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// GetCoAPDF renders a CoA as PDF
+func (h *CoAHandler) GetCoAPDF(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid CoA ID")
+		return
+	}
+
+	result, err := h.getCoAUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "CoA not found")
+		return
+	}
+
+	data, err := coa.RenderPDF(result)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	c.Header("Content-Disposition", "inline; filename=\""+result.CoANumber+".pdf\"")
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+// ListCoAs lists CoAs
+func (h *CoAHandler) ListCoAs(c *gin.Context) {
+	filter := repository.CoAFilter{
+		Page:     getPageFromQuery(c),
+		PageSize: getPageSizeFromQuery(c),
+	}
+	if s := c.Query("lot_id"); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			filter.LotID = &id
+		}
+	}
+	if s := c.Query("product_id"); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			filter.ProductID = &id
+		}
+	}
+
+	result, total, err := h.listCoAsUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, result, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// RecordSupplierCoA captures a supplier CoA for a received material lot
+func (h *CoAHandler) RecordSupplierCoA(c *gin.Context) {
+	var req dto.RecordSupplierCoARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	input := coa.RecordSupplierCoAInput{
+		GRNID:             req.GRNID,
+		GRNNumber:         req.GRNNumber,
+		GRNLineItemID:     req.GRNLineItemID,
+		SupplierID:        req.SupplierID,
+		MaterialID:        req.MaterialID,
+		LotID:             req.LotID,
+		SupplierLotNumber: req.SupplierLotNumber,
+		CertificateNumber: req.CertificateNumber,
+		CheckpointID:      req.CheckpointID,
+		DocumentFileID:    req.DocumentFileID,
+		Notes:             req.Notes,
+		ReceivedBy:        getUserIDFromContext(c),
+	}
+	if req.IssueDate != "" {
+		issueDate, err := time.Parse("2006-01-02", req.IssueDate)
+		if err != nil {
+			badRequest(c, "Invalid issue_date")
+			return
+		}
+		input.IssueDate = &issueDate
+	}
+	for _, v := range req.Values {
+		input.Values = append(input.Values, coa.SupplierCoAValueInput{
+			TestName:      v.TestName,
+			ReportedValue: v.ReportedValue,
+			NumericValue:  v.NumericValue,
+			UOM:           v.UOM,
+			Result:        entity.ItemResult(v.Result),
+		})
+	}
+
+	result, err := h.recordSupplierCoAUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrQCCheckpointNotFound:
+			notFound(c, "IQC checkpoint not found")
+		case entity.ErrSupplierCoANoValues:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetSupplierCoA gets a supplier CoA by ID
+func (h *CoAHandler) GetSupplierCoA(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid supplier CoA ID")
+		return
+	}
+
+	result, err := h.getSupplierCoAUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Supplier CoA not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListSupplierCoAs lists supplier CoAs
+func (h *CoAHandler) ListSupplierCoAs(c *gin.Context) {
+	filter := repository.SupplierCoAFilter{
+		Page:     getPageFromQuery(c),
+		PageSize: getPageSizeFromQuery(c),
+	}
+	if s := c.Query("grn_id"); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			filter.GRNID = &id
+		}
+	}
+	if s := c.Query("lot_id"); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			filter.LotID = &id
+		}
+	}
+	if s := c.Query("material_id"); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			filter.MaterialID = &id
+		}
+	}
+	if s := c.Query("supplier_id"); s != "" {
+		if id, err := uuid.Parse(s); err == nil {
+			filter.SupplierID = &id
+		}
+	}
+	if s := c.Query("status"); s != "" {
+		status := entity.SupplierCoAStatus(s)
+		filter.Status = &status
+	}
+
+	result, total, err := h.listSupplierCoAsUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, result, newMeta(filter.Page, filter.PageSize, total))
+}
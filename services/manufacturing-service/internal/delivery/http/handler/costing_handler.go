@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/costing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CostingHandler handles work order costing requests
+type CostingHandler struct {
+	calculateWOCostUC   *costing.CalculateWOCostUseCase
+	getWOCostUC         *costing.GetWOCostUseCase
+	setOverheadRateUC   *costing.SetOverheadRateUseCase
+	listOverheadRatesUC *costing.ListOverheadRatesUseCase
+}
+
+// NewCostingHandler creates a new CostingHandler
+func NewCostingHandler(
+	calculateWOCostUC *costing.CalculateWOCostUseCase,
+	getWOCostUC *costing.GetWOCostUseCase,
+	setOverheadRateUC *costing.SetOverheadRateUseCase,
+	listOverheadRatesUC *costing.ListOverheadRatesUseCase,
+) *CostingHandler {
+	return &CostingHandler{
+		calculateWOCostUC:   calculateWOCostUC,
+		getWOCostUC:         getWOCostUC,
+		setOverheadRateUC:   setOverheadRateUC,
+		listOverheadRatesUC: listOverheadRatesUC,
+	}
+}
+
+// CalculateWOCost costs a completed work order and pushes the unit cost to the finished goods lot
+func (h *CostingHandler) CalculateWOCost(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	result, err := h.calculateWOCostUC.Execute(c.Request.Context(), id, getUserIDFromContext(c))
+	if err != nil {
+		switch err {
+		case entity.ErrWONotFound:
+			notFound(c, "Work order not found")
+		case entity.ErrBOMNotFound:
+			notFound(c, "BOM not found")
+		case entity.ErrWONotCompleted, entity.ErrWOCostNoOutput, entity.ErrWorkCenterNotFound:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// GetWOCost gets the actual cost of a work order
+func (h *CostingHandler) GetWOCost(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	result, err := h.getWOCostUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Work order has not been costed")
+		return
+	}
+
+	success(c, result)
+}
+
+// SetOverheadRate creates or updates the overhead absorption rate of a production line
+func (h *CostingHandler) SetOverheadRate(c *gin.Context) {
+	var req dto.SetOverheadRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.setOverheadRateUC.Execute(c.Request.Context(), costing.SetOverheadRateInput{
+		ProductionLine: req.ProductionLine,
+		RatePerHour:    req.RatePerHour,
+		Notes:          req.Notes,
+		UpdatedBy:      getUserIDFromContext(c),
+	})
+	if err != nil {
+		if err == entity.ErrInvalidOverheadRate {
+			badRequest(c, err.Error())
+			return
+		}
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// ListOverheadRates lists the overhead absorption rates of all production lines
+func (h *CostingHandler) ListOverheadRates(c *gin.Context) {
+	result, err := h.listOverheadRatesUC.Execute(c.Request.Context())
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
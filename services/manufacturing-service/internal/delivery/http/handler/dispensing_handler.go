@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/dispensing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DispensingHandler handles weighing and dispensing requests
+type DispensingHandler struct {
+	generateTicketsUC *dispensing.GenerateWeighingTicketsUseCase
+	listTicketsUC     *dispensing.ListWeighingTicketsUseCase
+	getTicketUC       *dispensing.GetWeighingTicketUseCase
+	recordReadingUC   *dispensing.RecordScaleReadingUseCase
+	verifyUC          *dispensing.VerifyWeighingUseCase
+	cancelTicketUC    *dispensing.CancelWeighingTicketUseCase
+}
+
+// NewDispensingHandler creates a new DispensingHandler
+func NewDispensingHandler(
+	generateTicketsUC *dispensing.GenerateWeighingTicketsUseCase,
+	listTicketsUC *dispensing.ListWeighingTicketsUseCase,
+	getTicketUC *dispensing.GetWeighingTicketUseCase,
+	recordReadingUC *dispensing.RecordScaleReadingUseCase,
+	verifyUC *dispensing.VerifyWeighingUseCase,
+	cancelTicketUC *dispensing.CancelWeighingTicketUseCase,
+) *DispensingHandler {
+	return &DispensingHandler{
+		generateTicketsUC: generateTicketsUC,
+		listTicketsUC:     listTicketsUC,
+		getTicketUC:       getTicketUC,
+		recordReadingUC:   recordReadingUC,
+		verifyUC:          verifyUC,
+		cancelTicketUC:    cancelTicketUC,
+	}
+}
+
+// GenerateTickets generates weighing tickets for a work order
+func (h *DispensingHandler) GenerateTickets(c *gin.Context) {
+	woID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	result, err := h.generateTicketsUC.Execute(c.Request.Context(), woID, userID)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	created(c, result)
+}
+
+// ListTickets lists the weighing tickets of a work order
+func (h *DispensingHandler) ListTickets(c *gin.Context) {
+	woID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	result, err := h.listTicketsUC.Execute(c.Request.Context(), woID)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// GetTicket gets a weighing ticket by ID
+func (h *DispensingHandler) GetTicket(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid weighing ticket ID")
+		return
+	}
+
+	result, err := h.getTicketUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Weighing ticket not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// RecordReading records a scale reading against a weighing ticket
+func (h *DispensingHandler) RecordReading(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid weighing ticket ID")
+		return
+	}
+
+	var req dto.ScaleReadingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	input := dispensing.RecordScaleReadingInput{
+		TicketID:    id,
+		ScaleID:     req.ScaleID,
+		LotID:       req.LotID,
+		LotNumber:   req.LotNumber,
+		GrossWeight: req.GrossWeight,
+		TareWeight:  req.TareWeight,
+		UnitCost:    req.UnitCost,
+		ReadBy:      getUserIDFromContext(c),
+	}
+
+	result, err := h.recordReadingUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		if err == entity.ErrWeighingTicketNotFound {
+			notFound(c, err.Error())
+			return
+		}
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// VerifyWeighing verifies a critical weighing (second person)
+func (h *DispensingHandler) VerifyWeighing(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid weighing ticket ID")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	result, err := h.verifyUC.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// CancelTicket cancels an unconfirmed weighing ticket
+func (h *DispensingHandler) CancelTicket(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid weighing ticket ID")
+		return
+	}
+
+	result, err := h.cancelTicketUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
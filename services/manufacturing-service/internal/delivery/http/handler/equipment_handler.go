@@ -0,0 +1,338 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/equipment"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EquipmentHandler handles equipment, calibration and maintenance requests
+type EquipmentHandler struct {
+	registerEquipmentUC      *equipment.RegisterEquipmentUseCase
+	getEquipmentUC           *equipment.GetEquipmentUseCase
+	listEquipmentUC          *equipment.ListEquipmentUseCase
+	updateStatusUC           *equipment.UpdateStatusUseCase
+	recordCalibrationUC      *equipment.RecordCalibrationUseCase
+	createMaintenancePlanUC  *equipment.CreateMaintenancePlanUseCase
+	recordMaintenanceUC      *equipment.RecordMaintenanceUseCase
+	listMaintenanceRecordsUC *equipment.ListMaintenanceRecordsUseCase
+}
+
+// NewEquipmentHandler creates a new EquipmentHandler
+func NewEquipmentHandler(
+	registerEquipmentUC *equipment.RegisterEquipmentUseCase,
+	getEquipmentUC *equipment.GetEquipmentUseCase,
+	listEquipmentUC *equipment.ListEquipmentUseCase,
+	updateStatusUC *equipment.UpdateStatusUseCase,
+	recordCalibrationUC *equipment.RecordCalibrationUseCase,
+	createMaintenancePlanUC *equipment.CreateMaintenancePlanUseCase,
+	recordMaintenanceUC *equipment.RecordMaintenanceUseCase,
+	listMaintenanceRecordsUC *equipment.ListMaintenanceRecordsUseCase,
+) *EquipmentHandler {
+	return &EquipmentHandler{
+		registerEquipmentUC:      registerEquipmentUC,
+		getEquipmentUC:           getEquipmentUC,
+		listEquipmentUC:          listEquipmentUC,
+		updateStatusUC:           updateStatusUC,
+		recordCalibrationUC:      recordCalibrationUC,
+		createMaintenancePlanUC:  createMaintenancePlanUC,
+		recordMaintenanceUC:      recordMaintenanceUC,
+		listMaintenanceRecordsUC: listMaintenanceRecordsUC,
+	}
+}
+
+// RegisterEquipment registers a piece of equipment
+func (h *EquipmentHandler) RegisterEquipment(c *gin.Context) {
+	var req dto.RegisterEquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	requiresCalibration := true
+	if req.RequiresCalibration != nil {
+		requiresCalibration = *req.RequiresCalibration
+	}
+
+	result, err := h.registerEquipmentUC.Execute(c.Request.Context(), equipment.RegisterEquipmentInput{
+		Code:                    req.Code,
+		Name:                    req.Name,
+		EquipmentType:           entity.EquipmentType(req.EquipmentType),
+		ProductionLine:          req.ProductionLine,
+		WorkCenterID:            req.WorkCenterID,
+		Manufacturer:            req.Manufacturer,
+		Model:                   req.Model,
+		SerialNumber:            req.SerialNumber,
+		RequiresCalibration:     requiresCalibration,
+		CalibrationIntervalDays: req.CalibrationIntervalDays,
+		ResponsibleUserID:       req.ResponsibleUserID,
+		Notes:                   req.Notes,
+		CreatedBy:               getUserIDFromContext(c),
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrWorkCenterNotFound:
+			notFound(c, "Work center not found")
+		case entity.ErrInvalidEquipmentType, entity.ErrEquipmentCodeExists:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetEquipment gets equipment with its calibrations and maintenance plans
+func (h *EquipmentHandler) GetEquipment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid equipment ID")
+		return
+	}
+
+	result, err := h.getEquipmentUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Equipment not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListEquipment lists equipment
+func (h *EquipmentHandler) ListEquipment(c *gin.Context) {
+	filter := repository.EquipmentFilter{
+		ProductionLine: c.Query("production_line"),
+		Search:         c.Query("search"),
+		Page:           getPageFromQuery(c),
+		PageSize:       getPageSizeFromQuery(c),
+	}
+
+	if equipmentType := c.Query("equipment_type"); equipmentType != "" {
+		t := entity.EquipmentType(equipmentType)
+		filter.EquipmentType = &t
+	}
+	if status := c.Query("status"); status != "" {
+		s := entity.EquipmentStatus(status)
+		filter.Status = &s
+	}
+	if workCenterID := c.Query("work_center_id"); workCenterID != "" {
+		id, err := uuid.Parse(workCenterID)
+		if err != nil {
+			badRequest(c, "Invalid work_center_id")
+			return
+		}
+		filter.WorkCenterID = &id
+	}
+	if calibrationDue := c.Query("calibration_due_before"); calibrationDue != "" {
+		d, err := time.Parse("2006-01-02", calibrationDue)
+		if err != nil {
+			badRequest(c, "Invalid calibration_due_before")
+			return
+		}
+		filter.CalibrationDue = &d
+	}
+
+	result, total, err := h.listEquipmentUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, result, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// UpdateStatus takes equipment out of service, back into service or retires it
+func (h *EquipmentHandler) UpdateStatus(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid equipment ID")
+		return
+	}
+
+	var req dto.UpdateEquipmentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.updateStatusUC.Execute(c.Request.Context(), id, entity.EquipmentStatus(req.Status))
+	if err != nil {
+		switch err {
+		case entity.ErrEquipmentNotFound:
+			notFound(c, "Equipment not found")
+		case entity.ErrInvalidEquipmentStatus:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// RecordCalibration records a calibration certificate
+func (h *EquipmentHandler) RecordCalibration(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid equipment ID")
+		return
+	}
+
+	var req dto.RecordCalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	calibrationDate, err := time.Parse("2006-01-02", req.CalibrationDate)
+	if err != nil {
+		badRequest(c, "Invalid calibration_date")
+		return
+	}
+	input := equipment.RecordCalibrationInput{
+		EquipmentID:       id,
+		CertificateNumber: req.CertificateNumber,
+		CalibrationDate:   calibrationDate,
+		Result:            entity.CalibrationResult(req.Result),
+		CalibratedBy:      req.CalibratedBy,
+		CertificateURL:    req.CertificateURL,
+		Notes:             req.Notes,
+		RecordedBy:        getUserIDFromContext(c),
+	}
+	if req.DueDate != "" {
+		dueDate, err := time.Parse("2006-01-02", req.DueDate)
+		if err != nil {
+			badRequest(c, "Invalid due_date")
+			return
+		}
+		input.DueDate = &dueDate
+	}
+
+	result, err := h.recordCalibrationUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrEquipmentNotFound:
+			notFound(c, "Equipment not found")
+		case entity.ErrInvalidCalibration:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// CreateMaintenancePlan creates a preventive maintenance plan
+func (h *EquipmentHandler) CreateMaintenancePlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid equipment ID")
+		return
+	}
+
+	var req dto.CreateMaintenancePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	firstDueDate, err := time.Parse("2006-01-02", req.FirstDueDate)
+	if err != nil {
+		badRequest(c, "Invalid first_due_date")
+		return
+	}
+
+	result, err := h.createMaintenancePlanUC.Execute(c.Request.Context(), equipment.CreateMaintenancePlanInput{
+		EquipmentID:  id,
+		Name:         req.Name,
+		Tasks:        req.Tasks,
+		IntervalDays: req.IntervalDays,
+		FirstDueDate: firstDueDate,
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrEquipmentNotFound:
+			notFound(c, "Equipment not found")
+		case entity.ErrInvalidMaintenanceInterval:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// RecordMaintenance records performed maintenance against a plan
+func (h *EquipmentHandler) RecordMaintenance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid equipment ID")
+		return
+	}
+	planID, err := uuid.Parse(c.Param("plan_id"))
+	if err != nil {
+		badRequest(c, "Invalid maintenance plan ID")
+		return
+	}
+
+	var req dto.RecordMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	performedDate, err := time.Parse("2006-01-02", req.PerformedDate)
+	if err != nil {
+		badRequest(c, "Invalid performed_date")
+		return
+	}
+
+	result, err := h.recordMaintenanceUC.Execute(c.Request.Context(), equipment.RecordMaintenanceInput{
+		EquipmentID:   id,
+		PlanID:        planID,
+		PerformedDate: performedDate,
+		PerformedBy:   getUserIDFromContext(c),
+		Notes:         req.Notes,
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrMaintenancePlanNotFound:
+			notFound(c, "Maintenance plan not found")
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// ListMaintenanceRecords lists the maintenance performed on a piece of equipment
+func (h *EquipmentHandler) ListMaintenanceRecords(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid equipment ID")
+		return
+	}
+
+	result, err := h.listMaintenanceRecordsUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
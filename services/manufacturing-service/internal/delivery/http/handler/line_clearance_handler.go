@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/lineclearance"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// LineClearanceHandler handles cleaning and line clearance requests
+type LineClearanceHandler struct {
+	createLineClearanceUC   *lineclearance.CreateLineClearanceUseCase
+	getLineClearanceUC      *lineclearance.GetLineClearanceUseCase
+	listLineClearancesUC    *lineclearance.ListLineClearancesUseCase
+	checkItemUC             *lineclearance.CheckItemUseCase
+	completeLineClearanceUC *lineclearance.CompleteLineClearanceUseCase
+	verifyLineClearanceUC   *lineclearance.VerifyLineClearanceUseCase
+}
+
+// NewLineClearanceHandler creates a new LineClearanceHandler
+func NewLineClearanceHandler(
+	createLineClearanceUC *lineclearance.CreateLineClearanceUseCase,
+	getLineClearanceUC *lineclearance.GetLineClearanceUseCase,
+	listLineClearancesUC *lineclearance.ListLineClearancesUseCase,
+	checkItemUC *lineclearance.CheckItemUseCase,
+	completeLineClearanceUC *lineclearance.CompleteLineClearanceUseCase,
+	verifyLineClearanceUC *lineclearance.VerifyLineClearanceUseCase,
+) *LineClearanceHandler {
+	return &LineClearanceHandler{
+		createLineClearanceUC:   createLineClearanceUC,
+		getLineClearanceUC:      getLineClearanceUC,
+		listLineClearancesUC:    listLineClearancesUC,
+		checkItemUC:             checkItemUC,
+		completeLineClearanceUC: completeLineClearanceUC,
+		verifyLineClearanceUC:   verifyLineClearanceUC,
+	}
+}
+
+// CreateLineClearance opens the cleaning and line clearance checklist for a work order
+func (h *LineClearanceHandler) CreateLineClearance(c *gin.Context) {
+	var req dto.CreateLineClearanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	input := lineclearance.CreateLineClearanceInput{
+		WorkOrderID:   req.WorkOrderID,
+		CleaningLevel: entity.CleaningLevel(req.CleaningLevel),
+		Notes:         req.Notes,
+		CreatedBy:     getUserIDFromContext(c),
+	}
+	for _, item := range req.AdditionalItems {
+		input.AdditionalItems = append(input.AdditionalItems, lineclearance.LineClearanceItemInput{
+			Section:     entity.LineClearanceSection(item.Section),
+			Description: item.Description,
+		})
+	}
+
+	result, err := h.createLineClearanceUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrWONotFound:
+			notFound(c, "Work order not found")
+		case entity.ErrLineClearanceNoLine, entity.ErrLineClearanceWOStarted, entity.ErrLineClearanceExists,
+			entity.ErrInvalidCleaningLevel, entity.ErrCleaningLevelBelowRequired, entity.ErrInvalidLineClearanceItem:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetLineClearance gets a line clearance with its checklist
+func (h *LineClearanceHandler) GetLineClearance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid line clearance ID")
+		return
+	}
+
+	result, err := h.getLineClearanceUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Line clearance not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListLineClearances lists line clearances
+func (h *LineClearanceHandler) ListLineClearances(c *gin.Context) {
+	filter := repository.LineClearanceFilter{
+		ProductionLine: c.Query("production_line"),
+		Page:           getPageFromQuery(c),
+		PageSize:       getPageSizeFromQuery(c),
+	}
+
+	if status := c.Query("status"); status != "" {
+		s := entity.LineClearanceStatus(status)
+		filter.Status = &s
+	}
+	if woID := c.Query("work_order_id"); woID != "" {
+		id, err := uuid.Parse(woID)
+		if err != nil {
+			badRequest(c, "Invalid work_order_id")
+			return
+		}
+		filter.WorkOrderID = &id
+	}
+
+	result, total, err := h.listLineClearancesUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, result, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// CheckItem checks off a checklist item
+func (h *LineClearanceHandler) CheckItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid line clearance ID")
+		return
+	}
+	itemID, err := uuid.Parse(c.Param("item_id"))
+	if err != nil {
+		badRequest(c, "Invalid checklist item ID")
+		return
+	}
+
+	var req dto.CheckLineClearanceItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.checkItemUC.Execute(c.Request.Context(), lineclearance.CheckItemInput{
+		ClearanceID: id,
+		ItemID:      itemID,
+		CheckedBy:   getUserIDFromContext(c),
+		Remarks:     req.Remarks,
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrLineClearanceNotFound:
+			notFound(c, "Line clearance not found")
+		case entity.ErrLineClearanceItemNotFound:
+			notFound(c, "Checklist item not found")
+		case entity.ErrLineClearanceNotOpen:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// CompleteLineClearance records the performer's signature
+func (h *LineClearanceHandler) CompleteLineClearance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid line clearance ID")
+		return
+	}
+
+	result, err := h.completeLineClearanceUC.Execute(c.Request.Context(), id, getUserIDFromContext(c))
+	if err != nil {
+		switch err {
+		case entity.ErrLineClearanceNotFound:
+			notFound(c, "Line clearance not found")
+		case entity.ErrLineClearanceNotOpen, entity.ErrLineClearanceIncomplete:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// VerifyLineClearance records the QA verification that releases the line
+func (h *LineClearanceHandler) VerifyLineClearance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid line clearance ID")
+		return
+	}
+
+	result, err := h.verifyLineClearanceUC.Execute(c.Request.Context(), id, getUserIDFromContext(c))
+	if err != nil {
+		switch err {
+		case entity.ErrLineClearanceNotFound:
+			notFound(c, "Line clearance not found")
+		case entity.ErrLineClearanceNotCompleted, entity.ErrLineClearanceSameSigner:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
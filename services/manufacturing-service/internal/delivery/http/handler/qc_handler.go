@@ -68,7 +68,9 @@ func (h *QCHandler) CreateInspection(c *gin.Context) {
 			MinValue:      item.MinValue,
 			MaxValue:      item.MaxValue,
 			ActualValue:   item.ActualValue,
+			NumericValue:  item.NumericValue,
 			UOM:           item.UOM,
+			IsCritical:    item.IsCritical,
 			Result:        entity.ItemResult(item.Result),
 			Notes:         item.Notes,
 		})
@@ -88,11 +90,20 @@ func (h *QCHandler) CreateInspection(c *gin.Context) {
 		InspectorID:       userID,
 		InspectorName:     req.InspectorName,
 		Items:             items,
+		SupplierID:        req.SupplierID,
+		DefectCount:       req.DefectCount,
 	}
 
 	result, err := h.createInspectionUC.Execute(c.Request.Context(), input)
 	if err != nil {
-		internalError(c, err.Error())
+		switch err {
+		case entity.ErrQCCheckpointNotFound:
+			notFound(c, "QC checkpoint not found")
+		case entity.ErrQCItemResultRequired, entity.ErrQCCriticalTestMissing, entity.ErrSamplingSubjectRequired, entity.ErrSamplingDiscontinued, entity.ErrInvalidSamplingPlan:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
 		return
 	}
 
@@ -162,6 +173,7 @@ func (h *QCHandler) ApproveInspection(c *gin.Context) {
 		Result:           entity.InspectionResult(req.Result),
 		AcceptedQuantity: req.AcceptedQuantity,
 		RejectedQuantity: req.RejectedQuantity,
+		DefectCount:      req.DefectCount,
 		ApproverID:       userID,
 		Notes:            req.Notes,
 	}
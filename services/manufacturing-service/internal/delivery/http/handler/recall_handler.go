@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/recall"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RecallHandler handles product recall requests
+type RecallHandler struct {
+	initiateRecallUC *recall.InitiateRecallUseCase
+	getRecallUC      *recall.GetRecallUseCase
+	listRecallsUC    *recall.ListRecallsUseCase
+	completeRecallUC *recall.CompleteRecallUseCase
+}
+
+// NewRecallHandler creates a new RecallHandler
+func NewRecallHandler(
+	initiateRecallUC *recall.InitiateRecallUseCase,
+	getRecallUC *recall.GetRecallUseCase,
+	listRecallsUC *recall.ListRecallsUseCase,
+	completeRecallUC *recall.CompleteRecallUseCase,
+) *RecallHandler {
+	return &RecallHandler{
+		initiateRecallUC: initiateRecallUC,
+		getRecallUC:      getRecallUC,
+		listRecallsUC:    listRecallsUC,
+		completeRecallUC: completeRecallUC,
+	}
+}
+
+// InitiateRecall starts a recall from a suspect material lot and returns the recall report
+func (h *RecallHandler) InitiateRecall(c *gin.Context) {
+	var req dto.InitiateRecallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.initiateRecallUC.Execute(c.Request.Context(), recall.InitiateRecallInput{
+		MaterialLotID: req.MaterialLotID,
+		Mode:          entity.RecallMode(req.Mode),
+		Reason:        req.Reason,
+		InitiatedBy:   getUserIDFromContext(c),
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidRecallMode, entity.ErrRecallLotNotUsed:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetRecall gets a recall with its report
+func (h *RecallHandler) GetRecall(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid recall ID")
+		return
+	}
+
+	result, err := h.getRecallUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Recall not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListRecalls lists recalls
+func (h *RecallHandler) ListRecalls(c *gin.Context) {
+	filter := repository.RecallFilter{
+		Page:     getPageFromQuery(c),
+		PageSize: getPageSizeFromQuery(c),
+	}
+
+	if mode := c.Query("mode"); mode != "" {
+		m := entity.RecallMode(mode)
+		filter.Mode = &m
+	}
+	if status := c.Query("status"); status != "" {
+		s := entity.RecallStatus(status)
+		filter.Status = &s
+	}
+	if lotID := c.Query("material_lot_id"); lotID != "" {
+		id, err := uuid.Parse(lotID)
+		if err != nil {
+			badRequest(c, "Invalid material_lot_id")
+			return
+		}
+		filter.MaterialLotID = &id
+	}
+
+	recalls, total, err := h.listRecallsUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, recalls, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// CompleteRecall stops the recall clock
+func (h *RecallHandler) CompleteRecall(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid recall ID")
+		return
+	}
+
+	var req dto.CompleteRecallRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.completeRecallUC.Execute(c.Request.Context(), recall.CompleteRecallInput{
+		RecallID:    id,
+		Notes:       req.Notes,
+		CompletedBy: getUserIDFromContext(c),
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrRecallNotFound:
+			notFound(c, "Recall not found")
+		case entity.ErrRecallCompleted:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/routing"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RoutingHandler handles work center and routing requests
+type RoutingHandler struct {
+	createWorkCenterUC *routing.CreateWorkCenterUseCase
+	listWorkCentersUC  *routing.ListWorkCentersUseCase
+	createRoutingUC    *routing.CreateRoutingUseCase
+	getRoutingUC       *routing.GetRoutingUseCase
+	listRoutingsUC     *routing.ListRoutingsUseCase
+	activateRoutingUC  *routing.ActivateRoutingUseCase
+}
+
+// NewRoutingHandler creates a new RoutingHandler
+func NewRoutingHandler(
+	createWorkCenterUC *routing.CreateWorkCenterUseCase,
+	listWorkCentersUC *routing.ListWorkCentersUseCase,
+	createRoutingUC *routing.CreateRoutingUseCase,
+	getRoutingUC *routing.GetRoutingUseCase,
+	listRoutingsUC *routing.ListRoutingsUseCase,
+	activateRoutingUC *routing.ActivateRoutingUseCase,
+) *RoutingHandler {
+	return &RoutingHandler{
+		createWorkCenterUC: createWorkCenterUC,
+		listWorkCentersUC:  listWorkCentersUC,
+		createRoutingUC:    createRoutingUC,
+		getRoutingUC:       getRoutingUC,
+		listRoutingsUC:     listRoutingsUC,
+		activateRoutingUC:  activateRoutingUC,
+	}
+}
+
+// CreateWorkCenter creates a new work center
+func (h *RoutingHandler) CreateWorkCenter(c *gin.Context) {
+	var req dto.CreateWorkCenterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.createWorkCenterUC.Execute(c.Request.Context(), routing.CreateWorkCenterInput{
+		Code:           req.Code,
+		Name:           req.Name,
+		Description:    req.Description,
+		ProductionLine: req.ProductionLine,
+		CostPerHour:    req.CostPerHour,
+	})
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	created(c, result)
+}
+
+// ListWorkCenters lists active work centers
+func (h *RoutingHandler) ListWorkCenters(c *gin.Context) {
+	result, err := h.listWorkCentersUC.Execute(c.Request.Context())
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+	success(c, result)
+}
+
+// CreateRouting creates a new routing
+func (h *RoutingHandler) CreateRouting(c *gin.Context) {
+	var req dto.CreateRoutingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	var ops []routing.CreateRoutingOperationInput
+	for _, op := range req.Operations {
+		ops = append(ops, routing.CreateRoutingOperationInput{
+			Sequence:          op.Sequence,
+			OperationCode:     op.OperationCode,
+			Name:              op.Name,
+			OperationType:     entity.OperationType(op.OperationType),
+			WorkCenterID:      op.WorkCenterID,
+			SetupMinutes:      op.SetupMinutes,
+			RunMinutes:        op.RunMinutes,
+			Instructions:      op.Instructions,
+			ProcessParameters: op.ProcessParameters,
+			QCCheckpointID:    op.QCCheckpointID,
+		})
+	}
+
+	input := routing.CreateRoutingInput{
+		RoutingNumber: req.RoutingNumber,
+		ProductID:     req.ProductID,
+		BOMID:         req.BOMID,
+		Version:       req.Version,
+		Name:          req.Name,
+		BaseQuantity:  req.BaseQuantity,
+		Notes:         req.Notes,
+		Operations:    ops,
+		CreatedBy:     userID,
+	}
+
+	result, err := h.createRoutingUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		if err == entity.ErrWorkCenterNotFound {
+			badRequest(c, err.Error())
+			return
+		}
+		internalError(c, err.Error())
+		return
+	}
+
+	created(c, result)
+}
+
+// GetRouting gets a routing by ID
+func (h *RoutingHandler) GetRouting(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid routing ID")
+		return
+	}
+
+	result, err := h.getRoutingUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Routing not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListRoutings lists routings
+func (h *RoutingHandler) ListRoutings(c *gin.Context) {
+	filter := repository.RoutingFilter{
+		Page:     getPageFromQuery(c),
+		PageSize: getPageSizeFromQuery(c),
+	}
+
+	if productID := c.Query("product_id"); productID != "" {
+		if id, err := uuid.Parse(productID); err == nil {
+			filter.ProductID = &id
+		}
+	}
+	if status := c.Query("status"); status != "" {
+		s := entity.RoutingStatus(status)
+		filter.Status = &s
+	}
+
+	routings, total, err := h.listRoutingsUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, routings, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// ActivateRouting activates a routing
+func (h *RoutingHandler) ActivateRouting(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid routing ID")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	result, err := h.activateRoutingUC.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// OperationHandler handles work order operation execution requests
+type OperationHandler struct {
+	getOperationsUC     *routing.GetWOOperationsUseCase
+	startOperationUC    *routing.StartOperationUseCase
+	pauseOperationUC    *routing.PauseOperationUseCase
+	resumeOperationUC   *routing.ResumeOperationUseCase
+	completeOperationUC *routing.CompleteOperationUseCase
+}
+
+// NewOperationHandler creates a new OperationHandler
+func NewOperationHandler(
+	getOperationsUC *routing.GetWOOperationsUseCase,
+	startOperationUC *routing.StartOperationUseCase,
+	pauseOperationUC *routing.PauseOperationUseCase,
+	resumeOperationUC *routing.ResumeOperationUseCase,
+	completeOperationUC *routing.CompleteOperationUseCase,
+) *OperationHandler {
+	return &OperationHandler{
+		getOperationsUC:     getOperationsUC,
+		startOperationUC:    startOperationUC,
+		pauseOperationUC:    pauseOperationUC,
+		resumeOperationUC:   resumeOperationUC,
+		completeOperationUC: completeOperationUC,
+	}
+}
+
+// GetOperations lists the operations of a work order
+func (h *OperationHandler) GetOperations(c *gin.Context) {
+	woID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	result, err := h.getOperationsUC.Execute(c.Request.Context(), woID)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// StartOperation starts an operation
+func (h *OperationHandler) StartOperation(c *gin.Context) {
+	input, ok := bindOperationAction(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.startOperationUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// PauseOperation pauses an operation
+func (h *OperationHandler) PauseOperation(c *gin.Context) {
+	input, ok := bindOperationAction(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.pauseOperationUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// ResumeOperation resumes a paused operation
+func (h *OperationHandler) ResumeOperation(c *gin.Context) {
+	input, ok := bindOperationAction(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.resumeOperationUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+// CompleteOperation completes an operation with its process parameters
+func (h *OperationHandler) CompleteOperation(c *gin.Context) {
+	woID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+	opID, err := uuid.Parse(c.Param("op_id"))
+	if err != nil {
+		badRequest(c, "Invalid operation ID")
+		return
+	}
+
+	var req dto.CompleteOperationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			badRequest(c, err.Error())
+			return
+		}
+	}
+
+	var params []routing.ParameterReadingInput
+	for _, p := range req.Parameters {
+		params = append(params, routing.ParameterReadingInput{
+			Name:  p.Name,
+			Value: p.Value,
+			Unit:  p.Unit,
+		})
+	}
+
+	input := routing.CompleteOperationInput{
+		WOID:        woID,
+		OperationID: opID,
+		OperatorID:  getUserIDFromContext(c),
+		Parameters:  params,
+		Notes:       req.Notes,
+	}
+
+	result, err := h.completeOperationUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
+func bindOperationAction(c *gin.Context) (routing.OperationActionInput, bool) {
+	woID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return routing.OperationActionInput{}, false
+	}
+	opID, err := uuid.Parse(c.Param("op_id"))
+	if err != nil {
+		badRequest(c, "Invalid operation ID")
+		return routing.OperationActionInput{}, false
+	}
+
+	var req dto.OperationActionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			badRequest(c, err.Error())
+			return routing.OperationActionInput{}, false
+		}
+	}
+
+	return routing.OperationActionInput{
+		WOID:        woID,
+		OperationID: opID,
+		OperatorID:  getUserIDFromContext(c),
+		Notes:       req.Notes,
+	}, true
+}
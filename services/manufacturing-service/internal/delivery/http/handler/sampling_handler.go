@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/sampling"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SamplingHandler handles AQL sampling plan requests
+type SamplingHandler struct {
+	createPlanUC      *sampling.CreateSamplingPlanUseCase
+	getPlanUC         *sampling.GetSamplingPlanUseCase
+	listPlansUC       *sampling.ListSamplingPlansUseCase
+	assignPlanUC      *sampling.AssignSamplingPlanUseCase
+	determineSampleUC *sampling.DetermineSampleUseCase
+	listStatesUC      *sampling.ListSamplingStatesUseCase
+	resumeUC          *sampling.ResumeSamplingUseCase
+}
+
+// NewSamplingHandler creates a new SamplingHandler
+func NewSamplingHandler(
+	createPlanUC *sampling.CreateSamplingPlanUseCase,
+	getPlanUC *sampling.GetSamplingPlanUseCase,
+	listPlansUC *sampling.ListSamplingPlansUseCase,
+	assignPlanUC *sampling.AssignSamplingPlanUseCase,
+	determineSampleUC *sampling.DetermineSampleUseCase,
+	listStatesUC *sampling.ListSamplingStatesUseCase,
+	resumeUC *sampling.ResumeSamplingUseCase,
+) *SamplingHandler {
+	return &SamplingHandler{
+		createPlanUC:      createPlanUC,
+		getPlanUC:         getPlanUC,
+		listPlansUC:       listPlansUC,
+		assignPlanUC:      assignPlanUC,
+		determineSampleUC: determineSampleUC,
+		listStatesUC:      listStatesUC,
+		resumeUC:          resumeUC,
+	}
+}
+
+// CreatePlan creates a new AQL sampling plan
+func (h *SamplingHandler) CreatePlan(c *gin.Context) {
+	var req dto.CreateSamplingPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	plan, err := h.createPlanUC.Execute(c.Request.Context(), sampling.CreateSamplingPlanInput{
+		PlanCode:        req.PlanCode,
+		Name:            req.Name,
+		InspectionLevel: entity.InspectionLevel(req.InspectionLevel),
+		AQL:             req.AQL,
+		SubjectType:     entity.SamplingSubjectType(req.SubjectType),
+		Notes:           req.Notes,
+		CreatedBy:       getUserIDFromContext(c),
+	})
+	if err != nil {
+		if err == entity.ErrInvalidSamplingPlan {
+			badRequest(c, err.Error())
+			return
+		}
+		internalError(c, err.Error())
+		return
+	}
+
+	created(c, plan)
+}
+
+// ListPlans lists sampling plans
+func (h *SamplingHandler) ListPlans(c *gin.Context) {
+	plans, err := h.listPlansUC.Execute(c.Request.Context(), c.Query("active") == "true")
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+	success(c, plans)
+}
+
+// GetPlan gets a sampling plan by ID
+func (h *SamplingHandler) GetPlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid sampling plan ID")
+		return
+	}
+
+	plan, err := h.getPlanUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Sampling plan not found")
+		return
+	}
+	success(c, plan)
+}
+
+// DetermineSample returns the sample size and accept/reject numbers for a lot
+func (h *SamplingHandler) DetermineSample(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid sampling plan ID")
+		return
+	}
+	lotQty, err := parseInt(c.Query("lot_quantity"))
+	if err != nil {
+		badRequest(c, "Invalid lot_quantity")
+		return
+	}
+
+	input := sampling.DetermineSampleInput{PlanID: id, LotQuantity: lotQty}
+	if s := c.Query("subject_id"); s != "" {
+		subjectID, err := uuid.Parse(s)
+		if err != nil {
+			badRequest(c, "Invalid subject_id")
+			return
+		}
+		input.SubjectID = &subjectID
+	}
+
+	plan, err := h.determineSampleUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrSamplingPlanNotFound:
+			notFound(c, "Sampling plan not found")
+		case entity.ErrInvalidSamplingPlan, entity.ErrSamplingDiscontinued:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+	success(c, plan)
+}
+
+// ListStates lists the switching states of a sampling plan
+func (h *SamplingHandler) ListStates(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid sampling plan ID")
+		return
+	}
+
+	states, err := h.listStatesUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+	success(c, states)
+}
+
+// ResumeState resumes a discontinued supplier/product on tightened inspection
+func (h *SamplingHandler) ResumeState(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid sampling state ID")
+		return
+	}
+
+	state, err := h.resumeUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case entity.ErrSamplingStateNotFound:
+			notFound(c, "Sampling state not found")
+		case entity.ErrSamplingNotDiscontinued:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+	success(c, state)
+}
+
+// AssignToCheckpoint attaches or detaches a sampling plan on a QC checkpoint
+func (h *SamplingHandler) AssignToCheckpoint(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid checkpoint ID")
+		return
+	}
+
+	var req dto.AssignSamplingPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	checkpoint, err := h.assignPlanUC.Execute(c.Request.Context(), id, req.SamplingPlanID)
+	if err != nil {
+		switch err {
+		case entity.ErrQCCheckpointNotFound:
+			notFound(c, "QC checkpoint not found")
+		case entity.ErrSamplingPlanNotFound:
+			notFound(c, "Sampling plan not found")
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+	success(c, checkpoint)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/spc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SPCHandler handles statistical process control requests
+type SPCHandler struct {
+	getChartUC *spc.GetSPCChartUseCase
+}
+
+// NewSPCHandler creates a new SPCHandler
+func NewSPCHandler(getChartUC *spc.GetSPCChartUseCase) *SPCHandler {
+	return &SPCHandler{getChartUC: getChartUC}
+}
+
+// GetChart returns a control chart with capability indices for a product and test item
+func (h *SPCHandler) GetChart(c *gin.Context) {
+	productID, err := uuid.Parse(c.Query("product_id"))
+	if err != nil {
+		badRequest(c, "Invalid product ID")
+		return
+	}
+	testName := c.Query("test_name")
+	if testName == "" {
+		badRequest(c, "test_name is required")
+		return
+	}
+
+	input := spc.GetSPCChartInput{
+		ProductID: productID,
+		TestName:  testName,
+		ChartType: entity.SPCChartType(c.Query("chart_type")),
+	}
+	if s := c.Query("subgroup_size"); s != "" {
+		if input.SubgroupSize, err = parseInt(s); err != nil {
+			badRequest(c, "Invalid subgroup_size")
+			return
+		}
+	}
+	if s := c.Query("limit"); s != "" {
+		if input.Limit, err = parseInt(s); err != nil {
+			badRequest(c, "Invalid limit")
+			return
+		}
+	}
+	if d := c.Query("date_from"); d != "" {
+		input.DateFrom = &d
+	}
+	if d := c.Query("date_to"); d != "" {
+		input.DateTo = &d
+	}
+	if input.LSL, err = parseOptionalFloat(c.Query("lsl")); err != nil {
+		badRequest(c, "Invalid lsl")
+		return
+	}
+	if input.USL, err = parseOptionalFloat(c.Query("usl")); err != nil {
+		badRequest(c, "Invalid usl")
+		return
+	}
+
+	result, err := h.getChartUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrInvalidSPCChartType, entity.ErrInvalidSubgroupSize, entity.ErrInsufficientSPCData:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+func parseOptionalFloat(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
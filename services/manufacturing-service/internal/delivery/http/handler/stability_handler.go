@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/stability"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// StabilityHandler handles stability study requests
+type StabilityHandler struct {
+	createStudyUC   *stability.CreateStudyUseCase
+	getStudyUC      *stability.GetStudyUseCase
+	listStudiesUC   *stability.ListStudiesUseCase
+	recordResultsUC *stability.RecordResultsUseCase
+	evaluateStudyUC *stability.EvaluateStudyUseCase
+	completeStudyUC *stability.CompleteStudyUseCase
+}
+
+// NewStabilityHandler creates a new StabilityHandler
+func NewStabilityHandler(
+	createStudyUC *stability.CreateStudyUseCase,
+	getStudyUC *stability.GetStudyUseCase,
+	listStudiesUC *stability.ListStudiesUseCase,
+	recordResultsUC *stability.RecordResultsUseCase,
+	evaluateStudyUC *stability.EvaluateStudyUseCase,
+	completeStudyUC *stability.CompleteStudyUseCase,
+) *StabilityHandler {
+	return &StabilityHandler{
+		createStudyUC:   createStudyUC,
+		getStudyUC:      getStudyUC,
+		listStudiesUC:   listStudiesUC,
+		recordResultsUC: recordResultsUC,
+		evaluateStudyUC: evaluateStudyUC,
+		completeStudyUC: completeStudyUC,
+	}
+}
+
+// CreateStudy creates a stability study and schedules its pull point inspections
+func (h *StabilityHandler) CreateStudy(c *gin.Context) {
+	var req dto.CreateStabilityStudyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		badRequest(c, "Invalid start_date")
+		return
+	}
+
+	input := stability.CreateStudyInput{
+		BOMID:          req.BOMID,
+		WorkOrderID:    req.WorkOrderID,
+		LotID:          req.LotID,
+		LotNumber:      req.LotNumber,
+		CheckpointID:   req.CheckpointID,
+		SampleQuantity: req.SampleQuantity,
+		StartDate:      startDate,
+		PullMonths:     req.PullMonths,
+		Purpose:        req.Purpose,
+		CreatedBy:      getUserIDFromContext(c),
+	}
+	for _, cond := range req.Conditions {
+		input.Conditions = append(input.Conditions, stability.ConditionInput{
+			Name:          cond.Name,
+			ConditionType: entity.StabilityConditionType(cond.ConditionType),
+			TemperatureC:  cond.TemperatureC,
+			HumidityRH:    cond.HumidityRH,
+			PullMonths:    cond.PullMonths,
+		})
+	}
+
+	result, err := h.createStudyUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrBOMNotFound:
+			notFound(c, "BOM not found")
+		case entity.ErrQCCheckpointNotFound:
+			notFound(c, "QC checkpoint not found")
+		case entity.ErrInvalidStabilityCondition, entity.ErrStabilityLongTermRequired, entity.ErrInvalidStabilityPullPoints:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, result)
+}
+
+// GetStudy gets a stability study with its conditions and pull points
+func (h *StabilityHandler) GetStudy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid stability study ID")
+		return
+	}
+
+	result, err := h.getStudyUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		notFound(c, "Stability study not found")
+		return
+	}
+
+	success(c, result)
+}
+
+// ListStudies lists stability studies
+func (h *StabilityHandler) ListStudies(c *gin.Context) {
+	filter := repository.StabilityFilter{
+		Page:     getPageFromQuery(c),
+		PageSize: getPageSizeFromQuery(c),
+	}
+
+	if productID := c.Query("product_id"); productID != "" {
+		id, err := uuid.Parse(productID)
+		if err != nil {
+			badRequest(c, "Invalid product_id")
+			return
+		}
+		filter.ProductID = &id
+	}
+	if bomID := c.Query("bom_id"); bomID != "" {
+		id, err := uuid.Parse(bomID)
+		if err != nil {
+			badRequest(c, "Invalid bom_id")
+			return
+		}
+		filter.BOMID = &id
+	}
+	if status := c.Query("status"); status != "" {
+		s := entity.StabilityStudyStatus(status)
+		filter.Status = &s
+	}
+
+	studies, total, err := h.listStudiesUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		internalError(c, err.Error())
+		return
+	}
+
+	successWithMeta(c, studies, newMeta(filter.Page, filter.PageSize, total))
+}
+
+// RecordResults records the test results of a pull point
+func (h *StabilityHandler) RecordResults(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid stability study ID")
+		return
+	}
+	pullPointID, err := uuid.Parse(c.Param("pull_point_id"))
+	if err != nil {
+		badRequest(c, "Invalid pull point ID")
+		return
+	}
+
+	var req dto.RecordStabilityResultsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	input := stability.RecordResultsInput{
+		StudyID:     id,
+		PullPointID: pullPointID,
+		TestedBy:    getUserIDFromContext(c),
+		TesterName:  req.TesterName,
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, stability.ResultItemInput{
+			TestName:      item.TestName,
+			TestMethod:    item.TestMethod,
+			Specification: item.Specification,
+			MinValue:      item.MinValue,
+			MaxValue:      item.MaxValue,
+			ActualValue:   item.ActualValue,
+			NumericValue:  item.NumericValue,
+			UOM:           item.UOM,
+			Result:        entity.ItemResult(item.Result),
+			Notes:         item.Notes,
+		})
+	}
+
+	result, err := h.recordResultsUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrStabilityStudyNotFound:
+			notFound(c, "Stability study not found")
+		case entity.ErrStabilityPullPointNotFound:
+			notFound(c, "Stability pull point not found")
+		case entity.ErrQCInspectionNotFound:
+			notFound(c, "QC inspection not found")
+		case entity.ErrStabilityStudyClosed, entity.ErrStabilityPullPointTested, entity.ErrStabilityResultsRequired,
+			entity.ErrQCAlreadyApproved, entity.ErrQCItemResultRequired:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// EvaluateStudy trends the recorded results and updates the shelf-life recommendation
+func (h *StabilityHandler) EvaluateStudy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid stability study ID")
+		return
+	}
+
+	result, err := h.evaluateStudyUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		switch err {
+		case entity.ErrStabilityStudyNotFound:
+			notFound(c, "Stability study not found")
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
+
+// CompleteStudy completes a stability study with its final recommendation
+func (h *StabilityHandler) CompleteStudy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid stability study ID")
+		return
+	}
+
+	var req dto.CompleteStabilityStudyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	result, err := h.completeStudyUC.Execute(c.Request.Context(), stability.CompleteStudyInput{
+		StudyID:     id,
+		Notes:       req.Notes,
+		CompletedBy: getUserIDFromContext(c),
+	})
+	if err != nil {
+		switch err {
+		case entity.ErrStabilityStudyNotFound:
+			notFound(c, "Stability study not found")
+		case entity.ErrStabilityStudyClosed:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	success(c, result)
+}
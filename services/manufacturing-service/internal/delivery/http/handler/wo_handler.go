@@ -19,6 +19,9 @@ type WOHandler struct {
 	releaseWOUC  *workorder.ReleaseWOUseCase
 	startWOUC    *workorder.StartWOUseCase
 	completeWOUC *workorder.CompleteWOUseCase
+	varianceUC   *workorder.GetMaterialVarianceUseCase
+	reworkWOUC   *workorder.CreateReworkWOUseCase
+	cancelWOUC   *workorder.CancelWOUseCase
 }
 
 // NewWOHandler creates a new WOHandler
@@ -29,6 +32,9 @@ func NewWOHandler(
 	releaseWOUC *workorder.ReleaseWOUseCase,
 	startWOUC *workorder.StartWOUseCase,
 	completeWOUC *workorder.CompleteWOUseCase,
+	varianceUC *workorder.GetMaterialVarianceUseCase,
+	reworkWOUC *workorder.CreateReworkWOUseCase,
+	cancelWOUC *workorder.CancelWOUseCase,
 ) *WOHandler {
 	return &WOHandler{
 		createWOUC:   createWOUC,
@@ -37,6 +43,9 @@ func NewWOHandler(
 		releaseWOUC:  releaseWOUC,
 		startWOUC:    startWOUC,
 		completeWOUC: completeWOUC,
+		varianceUC:   varianceUC,
+		reworkWOUC:   reworkWOUC,
+		cancelWOUC:   cancelWOUC,
 	}
 }
 
@@ -70,9 +79,22 @@ func (h *WOHandler) CreateWO(c *gin.Context) {
 		Notes:            req.Notes,
 		CreatedBy:        userID,
 	}
+	for _, out := range req.Outputs {
+		input.Outputs = append(input.Outputs, workorder.WOOutputInput{
+			OutputType:      entity.WOOutputType(out.OutputType),
+			ProductID:       out.ProductID,
+			PlannedQuantity: out.PlannedQuantity,
+			UOMID:           out.UOMID,
+			Notes:           out.Notes,
+		})
+	}
 
 	result, err := h.createWOUC.Execute(c.Request.Context(), input)
 	if err != nil {
+		if err == entity.ErrInvalidWOOutput {
+			badRequest(c, err.Error())
+			return
+		}
 		internalError(c, err.Error())
 		return
 	}
@@ -80,6 +102,45 @@ func (h *WOHandler) CreateWO(c *gin.Context) {
 	created(c, toWOResponse(result))
 }
 
+// CreateReworkWO creates a rework work order for the rejected lot of an NCR
+func (h *WOHandler) CreateReworkWO(c *gin.Context) {
+	var req dto.CreateReworkWORequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	input := workorder.CreateReworkWOInput{
+		NCRID:          req.NCRID,
+		BOMID:          req.BOMID,
+		Quantity:       req.Quantity,
+		UOMID:          req.UOMID,
+		BatchNumber:    req.BatchNumber,
+		ProductionLine: req.ProductionLine,
+		Shift:          req.Shift,
+		Priority:       entity.WOPriority(req.Priority),
+		Notes:          req.Notes,
+		CreatedBy:      userID,
+	}
+
+	result, err := h.reworkWOUC.Execute(c.Request.Context(), input)
+	if err != nil {
+		switch err {
+		case entity.ErrNCRNotFound:
+			notFound(c, "NCR not found")
+		case entity.ErrNCRNotReworkable, entity.ErrReworkQtyExceeded, entity.ErrReworkProductMismatch, entity.ErrBOMNotFound:
+			badRequest(c, err.Error())
+		default:
+			internalError(c, err.Error())
+		}
+		return
+	}
+
+	created(c, toWOResponse(result))
+}
+
 // GetWO gets a work order by ID
 func (h *WOHandler) GetWO(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -113,6 +174,11 @@ func (h *WOHandler) ListWOs(c *gin.Context) {
 		p := entity.WOPriority(priority)
 		filter.Priority = &p
 	}
+	if productID := c.Query("product_id"); productID != "" {
+		if id, err := uuid.Parse(productID); err == nil {
+			filter.ProductID = &id
+		}
+	}
 
 	wos, total, err := h.listWOsUC.Execute(c.Request.Context(), filter)
 	if err != nil {
@@ -147,6 +213,25 @@ func (h *WOHandler) ReleaseWO(c *gin.Context) {
 	success(c, toWOResponse(result))
 }
 
+// CancelWO cancels a work order that has not started
+func (h *WOHandler) CancelWO(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	userID := getUserIDFromContext(c)
+
+	result, err := h.cancelWOUC.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		badRequest(c, err.Error())
+		return
+	}
+
+	success(c, toWOResponse(result))
+}
+
 // StartWO starts a work order
 func (h *WOHandler) StartWO(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
@@ -191,9 +276,17 @@ func (h *WOHandler) CompleteWO(c *gin.Context) {
 		ActualQuantity:   req.ActualQuantity,
 		GoodQuantity:     req.GoodQuantity,
 		RejectedQuantity: req.RejectedQuantity,
+		Backflush:        req.Backflush,
 		Notes:            req.Notes,
 		UpdatedBy:        userID,
 	}
+	for _, out := range req.Outputs {
+		input.Outputs = append(input.Outputs, workorder.CompleteWOOutputInput{
+			OutputID:  out.OutputID,
+			Quantity:  out.Quantity,
+			LotNumber: out.LotNumber,
+		})
+	}
 
 	result, err := h.completeWOUC.Execute(c.Request.Context(), input)
 	if err != nil {
@@ -204,6 +297,27 @@ func (h *WOHandler) CompleteWO(c *gin.Context) {
 	success(c, toWOResponse(result))
 }
 
+// GetMaterialVariance gets the actual-vs-standard material variance of a work order
+func (h *WOHandler) GetMaterialVariance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		badRequest(c, "Invalid work order ID")
+		return
+	}
+
+	result, err := h.varianceUC.Execute(c.Request.Context(), id)
+	if err != nil {
+		if err == entity.ErrWONotFound {
+			notFound(c, "Work order not found")
+			return
+		}
+		internalError(c, err.Error())
+		return
+	}
+
+	success(c, result)
+}
+
 func toWOResponse(wo *entity.WorkOrder) dto.WOResponse {
 	return dto.WOResponse{
 		ID:               wo.ID,
@@ -211,6 +325,7 @@ func toWOResponse(wo *entity.WorkOrder) dto.WOResponse {
 		WODate:           wo.WODate,
 		ProductID:        wo.ProductID,
 		BOMID:            wo.BOMID,
+		WOType:           string(wo.WOType),
 		Status:           string(wo.Status),
 		Priority:         string(wo.Priority),
 		PlannedQuantity:  wo.PlannedQuantity,
@@ -224,6 +339,10 @@ func toWOResponse(wo *entity.WorkOrder) dto.WOResponse {
 		ActualStartDate:  wo.ActualStartDate,
 		ActualEndDate:    wo.ActualEndDate,
 		ProductionLine:   wo.ProductionLine,
+		NCRID:            wo.NCRID,
+		SourceLotID:      wo.SourceLotID,
+		SourceLotNumber:  wo.SourceLotNumber,
+		Outputs:          wo.Outputs,
 		Notes:            wo.Notes,
 		CreatedAt:        wo.CreatedAt,
 	}
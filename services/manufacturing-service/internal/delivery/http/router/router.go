@@ -12,6 +12,19 @@ func SetupRouter(
 	qcHandler *handler.QCHandler,
 	ncrHandler *handler.NCRHandler,
 	traceHandler *handler.TraceHandler,
+	routingHandler *handler.RoutingHandler,
+	operationHandler *handler.OperationHandler,
+	batchRecordHandler *handler.BatchRecordHandler,
+	dispensingHandler *handler.DispensingHandler,
+	spcHandler *handler.SPCHandler,
+	samplingHandler *handler.SamplingHandler,
+	coaHandler *handler.CoAHandler,
+	capaHandler *handler.CAPAHandler,
+	recallHandler *handler.RecallHandler,
+	stabilityHandler *handler.StabilityHandler,
+	equipmentHandler *handler.EquipmentHandler,
+	lineClearanceHandler *handler.LineClearanceHandler,
+	costingHandler *handler.CostingHandler,
 	healthHandler *handler.HealthHandler,
 ) *gin.Engine {
 	r := gin.New()
@@ -41,11 +54,63 @@ func SetupRouter(
 		workOrders := v1.Group("/work-orders")
 		{
 			workOrders.POST("", woHandler.CreateWO)
+			workOrders.POST("/rework", woHandler.CreateReworkWO)
 			workOrders.GET("", woHandler.ListWOs)
 			workOrders.GET("/:id", woHandler.GetWO)
 			workOrders.PATCH("/:id/release", woHandler.ReleaseWO)
 			workOrders.PATCH("/:id/start", woHandler.StartWO)
 			workOrders.PATCH("/:id/complete", woHandler.CompleteWO)
+			workOrders.PATCH("/:id/cancel", woHandler.CancelWO)
+			workOrders.GET("/:id/material-variance", woHandler.GetMaterialVariance)
+			workOrders.POST("/:id/costing", costingHandler.CalculateWOCost)
+			workOrders.GET("/:id/costing", costingHandler.GetWOCost)
+
+			// Operation execution
+			workOrders.GET("/:id/operations", operationHandler.GetOperations)
+			workOrders.PATCH("/:id/operations/:op_id/start", operationHandler.StartOperation)
+			workOrders.PATCH("/:id/operations/:op_id/pause", operationHandler.PauseOperation)
+			workOrders.PATCH("/:id/operations/:op_id/resume", operationHandler.ResumeOperation)
+			workOrders.PATCH("/:id/operations/:op_id/complete", operationHandler.CompleteOperation)
+
+			// Electronic batch record
+			workOrders.POST("/:id/batch-record", batchRecordHandler.GenerateBatchRecord)
+
+			// Weighing & dispensing
+			workOrders.POST("/:id/weighing-tickets", dispensingHandler.GenerateTickets)
+			workOrders.GET("/:id/weighing-tickets", dispensingHandler.ListTickets)
+		}
+
+		// Weighing ticket routes
+		weighingTickets := v1.Group("/weighing-tickets")
+		{
+			weighingTickets.GET("/:id", dispensingHandler.GetTicket)
+			weighingTickets.POST("/:id/readings", dispensingHandler.RecordReading)
+			weighingTickets.PATCH("/:id/verify", dispensingHandler.VerifyWeighing)
+			weighingTickets.PATCH("/:id/cancel", dispensingHandler.CancelTicket)
+		}
+
+		// Batch record routes
+		batchRecords := v1.Group("/batch-records")
+		{
+			batchRecords.GET("", batchRecordHandler.ListBatchRecordVersions)
+			batchRecords.GET("/:id", batchRecordHandler.GetBatchRecord)
+			batchRecords.GET("/:id/pdf", batchRecordHandler.GetBatchRecordPDF)
+			batchRecords.POST("/:id/sign", batchRecordHandler.SignBatchRecord)
+		}
+
+		// Routing routes
+		workCenters := v1.Group("/work-centers")
+		{
+			workCenters.POST("", routingHandler.CreateWorkCenter)
+			workCenters.GET("", routingHandler.ListWorkCenters)
+		}
+
+		routings := v1.Group("/routings")
+		{
+			routings.POST("", routingHandler.CreateRouting)
+			routings.GET("", routingHandler.ListRoutings)
+			routings.GET("/:id", routingHandler.GetRouting)
+			routings.POST("/:id/activate", routingHandler.ActivateRouting)
 		}
 
 		// QC routes
@@ -59,6 +124,38 @@ func SetupRouter(
 			qcInspections.PATCH("/:id/approve", qcHandler.ApproveInspection)
 		}
 
+		v1.PATCH("/qc-checkpoints/:id/sampling-plan", samplingHandler.AssignToCheckpoint)
+
+		// AQL sampling routes
+		samplingPlans := v1.Group("/sampling-plans")
+		{
+			samplingPlans.POST("", samplingHandler.CreatePlan)
+			samplingPlans.GET("", samplingHandler.ListPlans)
+			samplingPlans.GET("/:id", samplingHandler.GetPlan)
+			samplingPlans.GET("/:id/sample", samplingHandler.DetermineSample)
+			samplingPlans.GET("/:id/states", samplingHandler.ListStates)
+		}
+		v1.PATCH("/sampling-states/:id/resume", samplingHandler.ResumeState)
+
+		// CoA routes
+		coas := v1.Group("/coas")
+		{
+			coas.POST("", coaHandler.GenerateCoA)
+			coas.GET("", coaHandler.ListCoAs)
+			coas.GET("/:id", coaHandler.GetCoA)
+			coas.GET("/:id/pdf", coaHandler.GetCoAPDF)
+		}
+
+		supplierCoAs := v1.Group("/supplier-coas")
+		{
+			supplierCoAs.POST("", coaHandler.RecordSupplierCoA)
+			supplierCoAs.GET("", coaHandler.ListSupplierCoAs)
+			supplierCoAs.GET("/:id", coaHandler.GetSupplierCoA)
+		}
+
+		// SPC routes
+		v1.GET("/spc/charts", spcHandler.GetChart)
+
 		// NCR routes
 		ncrs := v1.Group("/ncrs")
 		{
@@ -68,12 +165,75 @@ func SetupRouter(
 			ncrs.PATCH("/:id/close", ncrHandler.CloseNCR)
 		}
 
+		// CAPA routes
+		capas := v1.Group("/capas")
+		{
+			capas.POST("", capaHandler.CreateCAPA)
+			capas.GET("", capaHandler.ListCAPAs)
+			capas.GET("/:id", capaHandler.GetCAPA)
+			capas.POST("/:id/links", capaHandler.LinkCAPA)
+			capas.POST("/:id/actions", capaHandler.AddAction)
+			capas.PATCH("/:id/actions/:action_id/complete", capaHandler.CompleteAction)
+			capas.PATCH("/:id/verify", capaHandler.VerifyCAPA)
+		}
+
 		// Traceability routes
 		trace := v1.Group("/traceability")
 		{
 			trace.GET("/backward/:lot_id", traceHandler.TraceBackward)
 			trace.GET("/forward/:lot_id", traceHandler.TraceForward)
 		}
+
+		// Recall routes
+		recalls := v1.Group("/recalls")
+		{
+			recalls.POST("", recallHandler.InitiateRecall)
+			recalls.GET("", recallHandler.ListRecalls)
+			recalls.GET("/:id", recallHandler.GetRecall)
+			recalls.PATCH("/:id/complete", recallHandler.CompleteRecall)
+		}
+
+		// Stability study routes
+		stabilityStudies := v1.Group("/stability-studies")
+		{
+			stabilityStudies.POST("", stabilityHandler.CreateStudy)
+			stabilityStudies.GET("", stabilityHandler.ListStudies)
+			stabilityStudies.GET("/:id", stabilityHandler.GetStudy)
+			stabilityStudies.POST("/:id/pull-points/:pull_point_id/results", stabilityHandler.RecordResults)
+			stabilityStudies.POST("/:id/evaluate", stabilityHandler.EvaluateStudy)
+			stabilityStudies.PATCH("/:id/complete", stabilityHandler.CompleteStudy)
+		}
+
+		// Equipment routes
+		equipment := v1.Group("/equipment")
+		{
+			equipment.POST("", equipmentHandler.RegisterEquipment)
+			equipment.GET("", equipmentHandler.ListEquipment)
+			equipment.GET("/:id", equipmentHandler.GetEquipment)
+			equipment.PATCH("/:id/status", equipmentHandler.UpdateStatus)
+			equipment.POST("/:id/calibrations", equipmentHandler.RecordCalibration)
+			equipment.POST("/:id/maintenance-plans", equipmentHandler.CreateMaintenancePlan)
+			equipment.POST("/:id/maintenance-plans/:plan_id/records", equipmentHandler.RecordMaintenance)
+			equipment.GET("/:id/maintenance-records", equipmentHandler.ListMaintenanceRecords)
+		}
+
+		// Line clearance routes
+		lineClearances := v1.Group("/line-clearances")
+		{
+			lineClearances.POST("", lineClearanceHandler.CreateLineClearance)
+			lineClearances.GET("", lineClearanceHandler.ListLineClearances)
+			lineClearances.GET("/:id", lineClearanceHandler.GetLineClearance)
+			lineClearances.PATCH("/:id/items/:item_id/check", lineClearanceHandler.CheckItem)
+			lineClearances.PATCH("/:id/complete", lineClearanceHandler.CompleteLineClearance)
+			lineClearances.PATCH("/:id/verify", lineClearanceHandler.VerifyLineClearance)
+		}
+
+		// Overhead absorption rate routes
+		overheadRates := v1.Group("/overhead-rates")
+		{
+			overheadRates.PUT("", costingHandler.SetOverheadRate)
+			overheadRates.GET("", costingHandler.ListOverheadRates)
+		}
 	}
 
 	return r
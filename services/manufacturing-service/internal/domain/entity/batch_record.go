@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BatchRecordStatus represents electronic batch record status
+type BatchRecordStatus string
+
+const (
+	BatchRecordStatusDraft      BatchRecordStatus = "DRAFT"
+	BatchRecordStatusReleased   BatchRecordStatus = "RELEASED"
+	BatchRecordStatusSuperseded BatchRecordStatus = "SUPERSEDED"
+)
+
+// SignatureRole represents the role an electronic signature is given in
+type SignatureRole string
+
+const (
+	SignatureRoleProduction SignatureRole = "PRODUCTION"
+	SignatureRoleQC         SignatureRole = "QC"
+	SignatureRoleQA         SignatureRole = "QA" // QA sign-off releases and locks the batch
+)
+
+// signatureOrder is the order in which sign-offs must be given
+var signatureOrder = []SignatureRole{SignatureRoleProduction, SignatureRoleQC, SignatureRoleQA}
+
+// BatchRecord is an immutable, versioned electronic batch manufacturing record (eBMR, ISO 22716)
+type BatchRecord struct {
+	ID           uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RecordNumber string            `json:"record_number" gorm:"type:varchar(30);unique;not null"`
+	BatchNumber  string            `json:"batch_number" gorm:"type:varchar(50);not null"`
+	WorkOrderID  uuid.UUID         `json:"work_order_id" gorm:"type:uuid;not null"`
+	Version      int               `json:"version" gorm:"not null"`
+	Status       BatchRecordStatus `json:"status" gorm:"type:varchar(20);default:'DRAFT'"`
+	Content      json.RawMessage   `json:"content" gorm:"type:jsonb;not null"` // BatchRecordContent snapshot
+	ContentHash  string            `json:"content_hash" gorm:"type:varchar(64);not null"`
+	GeneratedBy  uuid.UUID         `json:"generated_by" gorm:"type:uuid;not null"`
+	GeneratedAt  time.Time         `json:"generated_at" gorm:"not null"`
+	ReleasedBy   *uuid.UUID        `json:"released_by" gorm:"type:uuid"`
+	ReleasedAt   *time.Time        `json:"released_at"`
+	CreatedAt    time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time         `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Signatures []BatchRecordSignature `json:"signatures,omitempty" gorm:"foreignKey:BatchRecordID"`
+}
+
+// TableName returns the table name
+func (BatchRecord) TableName() string {
+	return "batch_records"
+}
+
+// BatchRecordSignature is an electronic sign-off on a batch record version
+type BatchRecordSignature struct {
+	ID            uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	BatchRecordID uuid.UUID     `json:"batch_record_id" gorm:"type:uuid;not null"`
+	Role          SignatureRole `json:"role" gorm:"type:varchar(20);not null"`
+	SignerID      uuid.UUID     `json:"signer_id" gorm:"type:uuid;not null"`
+	SignerName    string        `json:"signer_name" gorm:"type:varchar(100)"`
+	Meaning       string        `json:"meaning" gorm:"type:varchar(200)"` // e.g. "Reviewed and approved"
+	ContentHash   string        `json:"content_hash" gorm:"type:varchar(64);not null"`
+	SignedAt      time.Time     `json:"signed_at" gorm:"not null"`
+	CreatedAt     time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (BatchRecordSignature) TableName() string {
+	return "batch_record_signatures"
+}
+
+// BatchRecordContent is the snapshot assembled into a batch record
+type BatchRecordContent struct {
+	BatchNumber   string               `json:"batch_number"`
+	WorkOrder     *WorkOrder           `json:"work_order"`
+	LineClearance *LineClearance       `json:"line_clearance,omitempty"`
+	Operations    []*WOOperation       `json:"operations"`
+	QCInspections []*QCInspection      `json:"qc_inspections"`
+	NCRs          []*NCR               `json:"ncrs"`
+	Traceability  []*BatchTraceability `json:"traceability"`
+	GeneratedAt   time.Time            `json:"generated_at"`
+}
+
+// SetContent serializes the snapshot and computes its hash
+func (b *BatchRecord) SetContent(content *BatchRecordContent) error {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	b.Content = data
+	b.ContentHash = HashContent(data)
+	return nil
+}
+
+// GetContent parses the snapshot
+func (b *BatchRecord) GetContent() (*BatchRecordContent, error) {
+	var content BatchRecordContent
+	if err := json.Unmarshal(b.Content, &content); err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// HashContent returns the hex SHA-256 of record content. The JSON is
+// canonicalized first (sorted keys, no whitespace) since jsonb storage does
+// not preserve the original byte layout.
+func HashContent(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err == nil {
+		if canonical, err := json.Marshal(v); err == nil {
+			data = canonical
+		}
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyIntegrity returns true if the content has not been altered since generation
+func (b *BatchRecord) VerifyIntegrity() bool {
+	return HashContent(b.Content) == b.ContentHash
+}
+
+// IsLocked returns true if the record can no longer be signed or regenerated
+func (b *BatchRecord) IsLocked() bool {
+	return b.Status == BatchRecordStatusReleased
+}
+
+// HasSignature returns the signature given in a role, if any
+func (b *BatchRecord) HasSignature(role SignatureRole) *BatchRecordSignature {
+	for i := range b.Signatures {
+		if b.Signatures[i].Role == role {
+			return &b.Signatures[i]
+		}
+	}
+	return nil
+}
+
+// Sign adds an electronic signature. Sign-offs follow PRODUCTION → QC → QA;
+// the QA signature releases the batch and locks the record.
+func (b *BatchRecord) Sign(role SignatureRole, signerID uuid.UUID, signerName, meaning string) (*BatchRecordSignature, error) {
+	if b.Status != BatchRecordStatusDraft {
+		return nil, errors.New("only draft batch records can be signed")
+	}
+	if b.HasSignature(role) != nil {
+		return nil, errors.New("batch record already signed in this role")
+	}
+
+	known := false
+	for _, r := range signatureOrder {
+		if r == role {
+			known = true
+			break
+		}
+		if b.HasSignature(r) == nil {
+			return nil, errors.New("sign-off " + string(r) + " is required first")
+		}
+	}
+	if !known {
+		return nil, errors.New("unknown signature role")
+	}
+
+	if role == SignatureRoleQA {
+		if prod := b.HasSignature(SignatureRoleProduction); prod != nil && prod.SignerID == signerID {
+			return nil, errors.New("QA release must be signed by a different person than production")
+		}
+		if qc := b.HasSignature(SignatureRoleQC); qc != nil && qc.SignerID == signerID {
+			return nil, errors.New("QA release must be signed by a different person than QC")
+		}
+	}
+
+	now := time.Now()
+	sig := BatchRecordSignature{
+		BatchRecordID: b.ID,
+		Role:          role,
+		SignerID:      signerID,
+		SignerName:    signerName,
+		Meaning:       meaning,
+		ContentHash:   b.ContentHash,
+		SignedAt:      now,
+	}
+	b.Signatures = append(b.Signatures, sig)
+
+	if role == SignatureRoleQA {
+		b.Status = BatchRecordStatusReleased
+		b.ReleasedBy = &signerID
+		b.ReleasedAt = &now
+	}
+	b.UpdatedAt = now
+	return &b.Signatures[len(b.Signatures)-1], nil
+}
+
+// Supersede marks a draft record as replaced by a newer version
+func (b *BatchRecord) Supersede() error {
+	if b.Status != BatchRecordStatusDraft {
+		return errors.New("only draft batch records can be superseded")
+	}
+	b.Status = BatchRecordStatusSuperseded
+	b.UpdatedAt = time.Now()
+	return nil
+}
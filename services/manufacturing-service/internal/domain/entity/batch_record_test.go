@@ -0,0 +1,78 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDraftBatchRecord(t *testing.T) *entity.BatchRecord {
+	record := &entity.BatchRecord{
+		ID:          uuid.New(),
+		BatchNumber: "BATCH-001",
+		Version:     1,
+		Status:      entity.BatchRecordStatusDraft,
+	}
+	assert.NoError(t, record.SetContent(&entity.BatchRecordContent{BatchNumber: "BATCH-001"}))
+	return record
+}
+
+func TestBatchRecord_Integrity(t *testing.T) {
+	record := newDraftBatchRecord(t)
+	assert.True(t, record.VerifyIntegrity())
+
+	// jsonb may reorder keys and whitespace; the hash must survive that
+	record.Content = []byte(`{ "traceability": null, "batch_number": "BATCH-001", "work_order": null, "operations": null, "qc_inspections": null, "ncrs": null, "generated_at": "0001-01-01T00:00:00Z" }`)
+	assert.True(t, record.VerifyIntegrity())
+
+	record.Content = []byte(`{"batch_number":"BATCH-999"}`)
+	assert.False(t, record.VerifyIntegrity())
+}
+
+func TestBatchRecord_SignOff(t *testing.T) {
+	record := newDraftBatchRecord(t)
+	operator := uuid.New()
+	qc := uuid.New()
+
+	t.Run("QA cannot sign before production and QC", func(t *testing.T) {
+		_, err := record.Sign(entity.SignatureRoleQA, uuid.New(), "QA", "Released")
+		assert.Error(t, err)
+	})
+
+	t.Run("Production then QC", func(t *testing.T) {
+		sig, err := record.Sign(entity.SignatureRoleProduction, operator, "Operator", "Executed")
+		assert.NoError(t, err)
+		assert.Equal(t, record.ContentHash, sig.ContentHash)
+
+		_, err = record.Sign(entity.SignatureRoleProduction, operator, "Operator", "Executed")
+		assert.Error(t, err)
+
+		_, err = record.Sign(entity.SignatureRoleQC, qc, "QC", "Tested")
+		assert.NoError(t, err)
+	})
+
+	t.Run("QA must differ from production signer", func(t *testing.T) {
+		_, err := record.Sign(entity.SignatureRoleQA, operator, "Operator", "Released")
+		assert.Error(t, err)
+		assert.False(t, record.IsLocked())
+	})
+
+	t.Run("QA must differ from QC signer", func(t *testing.T) {
+		_, err := record.Sign(entity.SignatureRoleQA, qc, "QC", "Released")
+		assert.Error(t, err)
+		assert.False(t, record.IsLocked())
+	})
+
+	t.Run("QA releases and locks", func(t *testing.T) {
+		qa := uuid.New()
+		_, err := record.Sign(entity.SignatureRoleQA, qa, "QA", "Released")
+		assert.NoError(t, err)
+		assert.True(t, record.IsLocked())
+		assert.Equal(t, entity.BatchRecordStatusReleased, record.Status)
+		assert.Equal(t, &qa, record.ReleasedBy)
+
+		assert.Error(t, record.Supersede())
+	})
+}
@@ -0,0 +1,243 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CAPAStatus represents CAPA status
+type CAPAStatus string
+
+const (
+	CAPAStatusOpen                CAPAStatus = "OPEN"
+	CAPAStatusInProgress          CAPAStatus = "IN_PROGRESS"
+	CAPAStatusPendingVerification CAPAStatus = "PENDING_VERIFICATION" // All actions done, effectiveness not yet verified
+	CAPAStatusClosed              CAPAStatus = "CLOSED"
+)
+
+// CAPAActionType represents the kind of CAPA action
+type CAPAActionType string
+
+const (
+	CAPAActionCorrective CAPAActionType = "CORRECTIVE"
+	CAPAActionPreventive CAPAActionType = "PREVENTIVE"
+)
+
+// CAPAActionStatus represents CAPA action status
+type CAPAActionStatus string
+
+const (
+	CAPAActionStatusOpen      CAPAActionStatus = "OPEN"
+	CAPAActionStatusCompleted CAPAActionStatus = "COMPLETED"
+	CAPAActionStatusCancelled CAPAActionStatus = "CANCELLED"
+)
+
+// CAPASourceType is the kind of record a CAPA addresses
+type CAPASourceType string
+
+const (
+	CAPASourceNCR                CAPASourceType = "NCR"
+	CAPASourceSupplierEvaluation CAPASourceType = "SUPPLIER_EVALUATION" // supplier-service evaluation
+	CAPASourceCustomerComplaint  CAPASourceType = "CUSTOMER_COMPLAINT"
+)
+
+// IsValid returns true for a known source type
+func (t CAPASourceType) IsValid() bool {
+	switch t {
+	case CAPASourceNCR, CAPASourceSupplierEvaluation, CAPASourceCustomerComplaint:
+		return true
+	}
+	return false
+}
+
+// CAPA represents a corrective and preventive action record
+type CAPA struct {
+	ID                    uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CAPANumber            string     `json:"capa_number" gorm:"type:varchar(30);unique;not null"` // CAPA-YYYY-XXXX
+	Title                 string     `json:"title" gorm:"type:varchar(200);not null"`
+	Description           string     `json:"description" gorm:"type:text"`
+	RootCause             string     `json:"root_cause" gorm:"type:text"`
+	Status                CAPAStatus `json:"status" gorm:"type:varchar(30);default:'OPEN'"`
+	OwnerID               uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
+	DueDate               *time.Time `json:"due_date" gorm:"type:date"`
+	EffectivenessCriteria string     `json:"effectiveness_criteria" gorm:"type:text"`
+	VerificationDueDate   *time.Time `json:"verification_due_date" gorm:"type:date"`
+	IsEffective           *bool      `json:"is_effective"`
+	VerifiedBy            *uuid.UUID `json:"verified_by" gorm:"type:uuid"`
+	VerifiedAt            *time.Time `json:"verified_at"`
+	VerificationNotes     string     `json:"verification_notes" gorm:"type:text"`
+	ClosedAt              *time.Time `json:"closed_at"`
+	CreatedBy             *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedAt             time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt             time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Actions []CAPAAction `json:"actions,omitempty" gorm:"foreignKey:CAPAID"`
+	Links   []CAPALink   `json:"links,omitempty" gorm:"foreignKey:CAPAID"`
+}
+
+// TableName returns the table name
+func (CAPA) TableName() string {
+	return "capas"
+}
+
+// CAPAAction is an action assigned to an owner with a due date
+type CAPAAction struct {
+	ID              uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CAPAID          uuid.UUID        `json:"capa_id" gorm:"type:uuid;not null"`
+	ActionType      CAPAActionType   `json:"action_type" gorm:"type:varchar(20);not null"`
+	Description     string           `json:"description" gorm:"type:text;not null"`
+	OwnerID         uuid.UUID        `json:"owner_id" gorm:"type:uuid;not null"`
+	DueDate         time.Time        `json:"due_date" gorm:"type:date;not null"`
+	IsCritical      bool             `json:"is_critical" gorm:"default:false"` // Blocks closing the linked NCRs while open
+	Status          CAPAActionStatus `json:"status" gorm:"type:varchar(20);default:'OPEN'"`
+	CompletedAt     *time.Time       `json:"completed_at"`
+	CompletedBy     *uuid.UUID       `json:"completed_by" gorm:"type:uuid"`
+	CompletionNotes string           `json:"completion_notes" gorm:"type:text"`
+	LastRemindedAt  *time.Time       `json:"last_reminded_at"`
+	CreatedAt       time.Time        `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time        `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	CAPA *CAPA `json:"capa,omitempty" gorm:"foreignKey:CAPAID"`
+}
+
+// TableName returns the table name
+func (CAPAAction) TableName() string {
+	return "capa_actions"
+}
+
+// CAPALink links a CAPA to an NCR, supplier evaluation or customer complaint
+type CAPALink struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CAPAID          uuid.UUID      `json:"capa_id" gorm:"type:uuid;not null"`
+	SourceType      CAPASourceType `json:"source_type" gorm:"type:varchar(30);not null"`
+	SourceID        uuid.UUID      `json:"source_id" gorm:"type:uuid;not null"`
+	SourceReference string         `json:"source_reference" gorm:"type:varchar(50)"` // NCR number, complaint number...
+	CreatedBy       *uuid.UUID     `json:"created_by" gorm:"type:uuid"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (CAPALink) TableName() string {
+	return "capa_links"
+}
+
+// CAPA business methods
+
+// IsClosed returns true if the CAPA is closed
+func (c *CAPA) IsClosed() bool {
+	return c.Status == CAPAStatusClosed
+}
+
+// HasLink returns true if the CAPA is already linked to the source record
+func (c *CAPA) HasLink(sourceType CAPASourceType, sourceID uuid.UUID) bool {
+	for _, l := range c.Links {
+		if l.SourceType == sourceType && l.SourceID == sourceID {
+			return true
+		}
+	}
+	return false
+}
+
+// AddAction adds an open action. A CAPA awaiting verification goes back in progress.
+func (c *CAPA) AddAction(action *CAPAAction) error {
+	if c.IsClosed() {
+		return ErrCAPAClosed
+	}
+	if action.ActionType != CAPAActionCorrective && action.ActionType != CAPAActionPreventive {
+		return ErrInvalidCAPAAction
+	}
+	action.CAPAID = c.ID
+	action.Status = CAPAActionStatusOpen
+	c.Actions = append(c.Actions, *action)
+	c.Status = CAPAStatusInProgress
+	c.UpdatedAt = time.Now()
+	return nil
+}
+
+// CompleteAction completes an open action; the CAPA moves to effectiveness
+// verification once no action is left open
+func (c *CAPA) CompleteAction(actionID, completedBy uuid.UUID, notes string) (*CAPAAction, error) {
+	action := c.findAction(actionID)
+	if action == nil {
+		return nil, ErrCAPAActionNotFound
+	}
+	if action.Status != CAPAActionStatusOpen {
+		return nil, ErrCAPAActionNotOpen
+	}
+	now := time.Now()
+	action.Status = CAPAActionStatusCompleted
+	action.CompletedAt = &now
+	action.CompletedBy = &completedBy
+	action.CompletionNotes = notes
+	action.UpdatedAt = now
+
+	if c.OpenActionCount() == 0 {
+		c.Status = CAPAStatusPendingVerification
+	}
+	c.UpdatedAt = now
+	return action, nil
+}
+
+// VerifyEffectiveness records the effectiveness check. An effective CAPA is
+// closed; an ineffective one returns to in progress for further actions.
+func (c *CAPA) VerifyEffectiveness(effective bool, verifiedBy uuid.UUID, notes string) error {
+	if c.Status != CAPAStatusPendingVerification {
+		return ErrCAPANotPendingVerification
+	}
+	now := time.Now()
+	c.IsEffective = &effective
+	c.VerifiedBy = &verifiedBy
+	c.VerifiedAt = &now
+	c.VerificationNotes = notes
+	if effective {
+		c.Status = CAPAStatusClosed
+		c.ClosedAt = &now
+	} else {
+		c.Status = CAPAStatusInProgress
+	}
+	c.UpdatedAt = now
+	return nil
+}
+
+// OpenActionCount returns the number of open actions
+func (c *CAPA) OpenActionCount() int {
+	count := 0
+	for _, a := range c.Actions {
+		if a.Status == CAPAActionStatusOpen {
+			count++
+		}
+	}
+	return count
+}
+
+func (c *CAPA) findAction(id uuid.UUID) *CAPAAction {
+	for i := range c.Actions {
+		if c.Actions[i].ID == id {
+			return &c.Actions[i]
+		}
+	}
+	return nil
+}
+
+// IsOverdue returns true if the action is still open after its due date
+func (a *CAPAAction) IsOverdue(asOf time.Time) bool {
+	due := time.Date(a.DueDate.Year(), a.DueDate.Month(), a.DueDate.Day(), 0, 0, 0, 0, asOf.Location())
+	return a.Status == CAPAActionStatusOpen && !asOf.Before(due.AddDate(0, 0, 1))
+}
+
+// DaysOverdue returns the number of whole days past the due date
+func (a *CAPAAction) DaysOverdue(asOf time.Time) int {
+	due := time.Date(a.DueDate.Year(), a.DueDate.Month(), a.DueDate.Day(), 0, 0, 0, 0, asOf.Location())
+	return int(asOf.Sub(due).Hours() / 24)
+}
+
+// ReminderDue returns true if an overdue action has not been reminded within the interval
+func (a *CAPAAction) ReminderDue(asOf time.Time, interval time.Duration) bool {
+	if !a.IsOverdue(asOf) {
+		return false
+	}
+	return a.LastRemindedAt == nil || asOf.Sub(*a.LastRemindedAt) >= interval
+}
@@ -0,0 +1,74 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCAPA_Lifecycle(t *testing.T) {
+	// Arrange
+	capa := &entity.CAPA{ID: uuid.New(), Status: entity.CAPAStatusOpen}
+	corrective := &entity.CAPAAction{ID: uuid.New(), ActionType: entity.CAPAActionCorrective, DueDate: time.Now()}
+	preventive := &entity.CAPAAction{ID: uuid.New(), ActionType: entity.CAPAActionPreventive, DueDate: time.Now()}
+
+	// Act & Assert
+	assert.NoError(t, capa.AddAction(corrective))
+	assert.NoError(t, capa.AddAction(preventive))
+	assert.Equal(t, entity.CAPAStatusInProgress, capa.Status)
+	assert.Equal(t, entity.ErrCAPANotPendingVerification, capa.VerifyEffectiveness(true, uuid.New(), ""))
+
+	_, err := capa.CompleteAction(corrective.ID, uuid.New(), "SOP updated")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CAPAStatusInProgress, capa.Status)
+	_, err = capa.CompleteAction(corrective.ID, uuid.New(), "")
+	assert.Equal(t, entity.ErrCAPAActionNotOpen, err)
+
+	_, err = capa.CompleteAction(preventive.ID, uuid.New(), "Operators trained")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CAPAStatusPendingVerification, capa.Status)
+
+	assert.NoError(t, capa.VerifyEffectiveness(true, uuid.New(), "No recurrence in 3 batches"))
+	assert.Equal(t, entity.CAPAStatusClosed, capa.Status)
+	assert.NotNil(t, capa.ClosedAt)
+	assert.Equal(t, entity.ErrCAPAClosed, capa.AddAction(&entity.CAPAAction{ActionType: entity.CAPAActionCorrective}))
+}
+
+func TestCAPA_IneffectiveVerificationReopens(t *testing.T) {
+	// Arrange
+	capa := &entity.CAPA{ID: uuid.New(), Status: entity.CAPAStatusPendingVerification}
+
+	// Act
+	err := capa.VerifyEffectiveness(false, uuid.New(), "Defect recurred")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CAPAStatusInProgress, capa.Status)
+	assert.False(t, *capa.IsEffective)
+	assert.Nil(t, capa.ClosedAt)
+}
+
+func TestCAPAAction_ReminderDue(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-20 * time.Hour)
+
+	tests := []struct {
+		name     string
+		action   entity.CAPAAction
+		expected bool
+	}{
+		{"due today is not overdue", entity.CAPAAction{Status: entity.CAPAActionStatusOpen, DueDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)}, false},
+		{"past due and never reminded", entity.CAPAAction{Status: entity.CAPAActionStatusOpen, DueDate: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)}, true},
+		{"reminded within the interval", entity.CAPAAction{Status: entity.CAPAActionStatusOpen, DueDate: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), LastRemindedAt: &yesterday}, false},
+		{"completed actions are not reminded", entity.CAPAAction{Status: entity.CAPAActionStatusCompleted, DueDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.action.ReminderDue(now, 24*time.Hour))
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CoAConclusion is the release statement printed on a certificate
+type CoAConclusion string
+
+const (
+	CoAConclusionConforms              CoAConclusion = "CONFORMS"
+	CoAConclusionConformsConditionally CoAConclusion = "CONFORMS_CONDITIONALLY"
+)
+
+// CertificateOfAnalysis is the CoA issued to customers for a finished lot
+type CertificateOfAnalysis struct {
+	ID               uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CoANumber        string          `json:"coa_number" gorm:"type:varchar(30);unique;not null"` // COA-YYYY-XXXX
+	InspectionID     uuid.UUID       `json:"inspection_id" gorm:"type:uuid;not null"`
+	InspectionNumber string          `json:"inspection_number" gorm:"type:varchar(30)"`
+	ProductID        *uuid.UUID      `json:"product_id" gorm:"type:uuid"`
+	LotID            uuid.UUID       `json:"lot_id" gorm:"type:uuid;not null"`
+	LotNumber        string          `json:"lot_number" gorm:"type:varchar(50)"`
+	WorkOrderID      *uuid.UUID      `json:"work_order_id" gorm:"type:uuid"`
+	Quantity         float64         `json:"quantity" gorm:"type:decimal(15,4)"`
+	Results          json.RawMessage `json:"results" gorm:"type:jsonb;not null"` // []CoAResult snapshot
+	Conclusion       CoAConclusion   `json:"conclusion" gorm:"type:varchar(30);not null"`
+	ApprovedAt       *time.Time      `json:"approved_at"` // QC release of the inspection
+	IssuedBy         uuid.UUID       `json:"issued_by" gorm:"type:uuid;not null"`
+	IssuedByName     string          `json:"issued_by_name" gorm:"type:varchar(100)"`
+	IssuedAt         time.Time       `json:"issued_at" gorm:"not null"`
+	PDFFileID        *uuid.UUID      `json:"pdf_file_id" gorm:"type:uuid"`  // file-service
+	JSONFileID       *uuid.UUID      `json:"json_file_id" gorm:"type:uuid"` // file-service
+	CreatedAt        time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (CertificateOfAnalysis) TableName() string {
+	return "certificates_of_analysis"
+}
+
+// CoAResult is one test line of a certificate
+type CoAResult struct {
+	TestName      string     `json:"test_name"`
+	Method        string     `json:"method,omitempty"`
+	Specification string     `json:"specification,omitempty"`
+	MinLimit      *float64   `json:"min_limit,omitempty"`
+	MaxLimit      *float64   `json:"max_limit,omitempty"`
+	Result        string     `json:"result"`
+	UOM           string     `json:"uom,omitempty"`
+	Conclusion    ItemResult `json:"conclusion"`
+}
+
+// NewCoAFromInspection builds a certificate from an approved final inspection and its items
+func NewCoAFromInspection(inspection *QCInspection) (*CertificateOfAnalysis, error) {
+	if inspection.InspectionType != CheckpointTypeFQC {
+		return nil, ErrCoAInspectionNotPassed
+	}
+	conclusion := CoAConclusionConforms
+	switch inspection.Result {
+	case InspectionResultPassed:
+	case InspectionResultConditional:
+		conclusion = CoAConclusionConformsConditionally
+	default:
+		return nil, ErrCoAInspectionNotPassed
+	}
+	if inspection.LotID == nil {
+		return nil, ErrCoALotRequired
+	}
+
+	var results []CoAResult
+	for _, item := range inspection.Items {
+		if item.Result == ItemResultNA {
+			continue
+		}
+		spec := item.Specification
+		if spec == "" && (item.MinValue != "" || item.MaxValue != "") {
+			spec = item.MinValue + " - " + item.MaxValue
+		}
+		results = append(results, CoAResult{
+			TestName:      item.TestName,
+			Method:        item.TestMethod,
+			Specification: spec,
+			MinLimit:      item.MinLimit,
+			MaxLimit:      item.MaxLimit,
+			Result:        item.ActualValue,
+			UOM:           item.UOM,
+			Conclusion:    item.Result,
+		})
+	}
+	if len(results) == 0 {
+		return nil, ErrCoANoResults
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+
+	coa := &CertificateOfAnalysis{
+		InspectionID:     inspection.ID,
+		InspectionNumber: inspection.InspectionNumber,
+		ProductID:        inspection.ProductID,
+		LotID:            *inspection.LotID,
+		LotNumber:        inspection.LotNumber,
+		Quantity:         inspection.InspectedQuantity,
+		Results:          data,
+		Conclusion:       conclusion,
+		ApprovedAt:       inspection.ApprovedAt,
+		IssuedAt:         time.Now(),
+	}
+	if inspection.AcceptedQuantity != nil {
+		coa.Quantity = *inspection.AcceptedQuantity
+	}
+	if inspection.ReferenceType == ReferenceTypeWorkOrder {
+		woID := inspection.ReferenceID
+		coa.WorkOrderID = &woID
+	}
+	return coa, nil
+}
+
+// GetResults returns the certified test results
+func (c *CertificateOfAnalysis) GetResults() ([]CoAResult, error) {
+	var results []CoAResult
+	err := json.Unmarshal(c.Results, &results)
+	return results, err
+}
+
+// SupplierCoAStatus is the outcome of comparing a supplier CoA with our specs
+type SupplierCoAStatus string
+
+const (
+	SupplierCoAStatusConforming    SupplierCoAStatus = "CONFORMING"
+	SupplierCoAStatusNonConforming SupplierCoAStatus = "NON_CONFORMING"
+	SupplierCoAStatusIncomplete    SupplierCoAStatus = "INCOMPLETE" // A specified numeric test is not reported
+)
+
+// SupplierCoA is a supplier's certificate captured when a material lot is received
+type SupplierCoA struct {
+	ID                uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	GRNID             uuid.UUID         `json:"grn_id" gorm:"type:uuid;not null"`
+	GRNNumber         string            `json:"grn_number" gorm:"type:varchar(30)"`
+	GRNLineItemID     *uuid.UUID        `json:"grn_line_item_id" gorm:"type:uuid"`
+	SupplierID        uuid.UUID         `json:"supplier_id" gorm:"type:uuid;not null"`
+	MaterialID        uuid.UUID         `json:"material_id" gorm:"type:uuid;not null"`
+	LotID             *uuid.UUID        `json:"lot_id" gorm:"type:uuid"`
+	SupplierLotNumber string            `json:"supplier_lot_number" gorm:"type:varchar(50)"`
+	CertificateNumber string            `json:"certificate_number" gorm:"type:varchar(50)"`
+	IssueDate         *time.Time        `json:"issue_date" gorm:"type:date"`
+	CheckpointID      uuid.UUID         `json:"checkpoint_id" gorm:"type:uuid;not null"` // IQC specs compared against
+	DocumentFileID    *uuid.UUID        `json:"document_file_id" gorm:"type:uuid"`       // Scanned certificate in file-service
+	Status            SupplierCoAStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Notes             string            `json:"notes" gorm:"type:text"`
+	ReceivedBy        uuid.UUID         `json:"received_by" gorm:"type:uuid;not null"`
+	CreatedAt         time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Values []SupplierCoAValue `json:"values,omitempty" gorm:"foreignKey:SupplierCoAID"`
+}
+
+// TableName returns the table name
+func (SupplierCoA) TableName() string {
+	return "supplier_coas"
+}
+
+// SupplierCoAValue is one reported test value and its comparison with our spec
+type SupplierCoAValue struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SupplierCoAID uuid.UUID  `json:"supplier_coa_id" gorm:"type:uuid;not null"`
+	TestName      string     `json:"test_name" gorm:"type:varchar(100);not null"`
+	ReportedValue string     `json:"reported_value" gorm:"type:varchar(100)"`
+	NumericValue  *float64   `json:"numeric_value" gorm:"type:decimal(18,6)"`
+	UOM           string     `json:"uom" gorm:"type:varchar(20)"`
+	Specification string     `json:"specification" gorm:"type:varchar(200)"`
+	MinLimit      *float64   `json:"min_limit" gorm:"type:decimal(18,6)"`
+	MaxLimit      *float64   `json:"max_limit" gorm:"type:decimal(18,6)"`
+	Result        ItemResult `json:"result" gorm:"type:varchar(20);not null"`
+	OutOfSpec     bool       `json:"out_of_spec" gorm:"default:false"`
+	Missing       bool       `json:"missing" gorm:"default:false"` // Specified by us, not reported by the supplier
+}
+
+// TableName returns the table name
+func (SupplierCoAValue) TableName() string {
+	return "supplier_coa_values"
+}
+
+// CompareWithSpecs evaluates the reported values against the checkpoint
+// templates and sets the status. Numeric values are checked against our
+// limits; values without a numeric spec keep the result given at capture
+// (N/A when none). Specified numeric tests the supplier did not report are
+// added as missing.
+func (s *SupplierCoA) CompareWithSpecs(templates []TestItemTemplate) {
+	reported := make(map[*TestItemTemplate]bool)
+	for i := range s.Values {
+		v := &s.Values[i]
+		item := QCInspectionItem{
+			TestName:     v.TestName,
+			ActualValue:  v.ReportedValue,
+			NumericValue: v.NumericValue,
+			UOM:          v.UOM,
+			Result:       v.Result,
+		}
+		if tpl := FindTestItemTemplate(templates, v.TestName); tpl != nil {
+			item.ApplySpec(tpl)
+			reported[tpl] = true
+		}
+		item.Evaluate()
+
+		v.NumericValue = item.NumericValue
+		v.UOM = item.UOM
+		v.Specification = item.Specification
+		v.MinLimit = item.MinLimit
+		v.MaxLimit = item.MaxLimit
+		v.Result = item.Result
+		if v.Result == "" {
+			v.Result = ItemResultNA
+		}
+		v.OutOfSpec = item.OutOfSpec
+	}
+
+	for i := range templates {
+		tpl := &templates[i]
+		if reported[tpl] || TestItemType(tpl.Type) != TestItemTypeNumeric || (tpl.Min == nil && tpl.Max == nil) {
+			continue
+		}
+		s.Values = append(s.Values, SupplierCoAValue{
+			TestName:      tpl.Name,
+			UOM:           tpl.Unit,
+			Specification: tpl.Specification,
+			MinLimit:      tpl.Min,
+			MaxLimit:      tpl.Max,
+			Result:        ItemResultNA,
+			Missing:       true,
+		})
+	}
+
+	s.Status = SupplierCoAStatusConforming
+	for _, v := range s.Values {
+		if v.OutOfSpec {
+			s.Status = SupplierCoAStatusNonConforming
+			return
+		}
+		if v.Missing {
+			s.Status = SupplierCoAStatusIncomplete
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFQCInspection(result entity.InspectionResult) *entity.QCInspection {
+	lotID := uuid.New()
+	accepted := 950.0
+	return &entity.QCInspection{
+		ID:                uuid.New(),
+		InspectionNumber:  "QC-2026-0010",
+		InspectionType:    entity.CheckpointTypeFQC,
+		ReferenceType:     entity.ReferenceTypeWorkOrder,
+		ReferenceID:       uuid.New(),
+		LotID:             &lotID,
+		LotNumber:         "LOT-2026-001",
+		InspectedQuantity: 1000,
+		AcceptedQuantity:  &accepted,
+		Result:            result,
+		Items: []entity.QCInspectionItem{
+			{TestName: "pH", TestType: entity.TestItemTypeNumeric, MinLimit: floatPtr(5), MaxLimit: floatPtr(7), ActualValue: "6.1", Result: entity.ItemResultPass},
+			{TestName: "Appearance", Specification: "Smooth cream", ActualValue: "Conforms", Result: entity.ItemResultPass},
+			{TestName: "Fragrance", Result: entity.ItemResultNA},
+		},
+	}
+}
+
+func TestNewCoAFromInspection(t *testing.T) {
+	t.Run("Passed FQC inspection produces a conforming certificate", func(t *testing.T) {
+		// Arrange
+		inspection := newFQCInspection(entity.InspectionResultPassed)
+
+		// Act
+		coa, err := entity.NewCoAFromInspection(inspection)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CoAConclusionConforms, coa.Conclusion)
+		assert.Equal(t, *inspection.LotID, coa.LotID)
+		assert.Equal(t, 950.0, coa.Quantity)
+		assert.Equal(t, inspection.ReferenceID, *coa.WorkOrderID)
+		results, err := coa.GetResults()
+		assert.NoError(t, err)
+		assert.Len(t, results, 2) // N/A items are left off the certificate
+		assert.Equal(t, "6.1", results[0].Result)
+	})
+
+	t.Run("Conditional release is stated on the certificate", func(t *testing.T) {
+		coa, err := entity.NewCoAFromInspection(newFQCInspection(entity.InspectionResultConditional))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.CoAConclusionConformsConditionally, coa.Conclusion)
+	})
+
+	t.Run("Failed or non-final inspections are rejected", func(t *testing.T) {
+		failed := newFQCInspection(entity.InspectionResultFailed)
+		iqc := newFQCInspection(entity.InspectionResultPassed)
+		iqc.InspectionType = entity.CheckpointTypeIQC
+
+		_, errFailed := entity.NewCoAFromInspection(failed)
+		_, errIQC := entity.NewCoAFromInspection(iqc)
+
+		assert.Equal(t, entity.ErrCoAInspectionNotPassed, errFailed)
+		assert.Equal(t, entity.ErrCoAInspectionNotPassed, errIQC)
+	})
+
+	t.Run("Lot is required", func(t *testing.T) {
+		inspection := newFQCInspection(entity.InspectionResultPassed)
+		inspection.LotID = nil
+
+		_, err := entity.NewCoAFromInspection(inspection)
+
+		assert.Equal(t, entity.ErrCoALotRequired, err)
+	})
+}
+
+func TestSupplierCoA_CompareWithSpecs(t *testing.T) {
+	templates := []entity.TestItemTemplate{
+		{Name: "pH", Type: "NUMERIC", Min: floatPtr(4), Max: floatPtr(8)},
+		{Name: "Moisture", Type: "NUMERIC", Max: floatPtr(0.5), Unit: "%"},
+		{Name: "Appearance", Type: "PASS_FAIL", Specification: "White powder"},
+	}
+
+	t.Run("Values within spec conform", func(t *testing.T) {
+		// Arrange
+		coa := &entity.SupplierCoA{Values: []entity.SupplierCoAValue{
+			{TestName: "pH", NumericValue: floatPtr(6.2)},
+			{TestName: "moisture", NumericValue: floatPtr(0.3)},
+			{TestName: "Appearance", ReportedValue: "White powder", Result: entity.ItemResultPass},
+		}}
+
+		// Act
+		coa.CompareWithSpecs(templates)
+
+		// Assert
+		assert.Equal(t, entity.SupplierCoAStatusConforming, coa.Status)
+		assert.Equal(t, entity.ItemResultPass, coa.Values[0].Result)
+		assert.Equal(t, "%", coa.Values[1].UOM)
+	})
+
+	t.Run("Out of spec value makes the certificate non-conforming", func(t *testing.T) {
+		coa := &entity.SupplierCoA{Values: []entity.SupplierCoAValue{
+			{TestName: "pH", NumericValue: floatPtr(8.6), Result: entity.ItemResultPass},
+			{TestName: "Moisture", NumericValue: floatPtr(0.2)},
+		}}
+
+		coa.CompareWithSpecs(templates)
+
+		assert.Equal(t, entity.SupplierCoAStatusNonConforming, coa.Status)
+		assert.True(t, coa.Values[0].OutOfSpec)
+		assert.Equal(t, entity.ItemResultFail, coa.Values[0].Result)
+	})
+
+	t.Run("Unreported numeric spec is flagged as missing", func(t *testing.T) {
+		coa := &entity.SupplierCoA{Values: []entity.SupplierCoAValue{
+			{TestName: "pH", NumericValue: floatPtr(5)},
+			{TestName: "Heavy metals", ReportedValue: "< 10 ppm"},
+		}}
+
+		coa.CompareWithSpecs(templates)
+
+		assert.Equal(t, entity.SupplierCoAStatusIncomplete, coa.Status)
+		assert.Len(t, coa.Values, 3)
+		assert.Equal(t, entity.ItemResultNA, coa.Values[1].Result)
+		assert.Equal(t, "Moisture", coa.Values[2].TestName)
+		assert.True(t, coa.Values[2].Missing)
+	})
+}
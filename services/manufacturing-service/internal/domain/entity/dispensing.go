@@ -0,0 +1,206 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeighingTicketStatus represents weighing ticket status
+type WeighingTicketStatus string
+
+const (
+	WeighingTicketStatusPending   WeighingTicketStatus = "PENDING"   // Waiting for an in-tolerance scale reading
+	WeighingTicketStatusWeighed   WeighingTicketStatus = "WEIGHED"   // Critical line, waiting for second-person verification
+	WeighingTicketStatusConfirmed WeighingTicketStatus = "CONFIRMED" // Material issue posted
+	WeighingTicketStatusCancelled WeighingTicketStatus = "CANCELLED"
+)
+
+// weighingEpsilon absorbs float rounding when comparing against tolerance limits
+const weighingEpsilon = 1e-6
+
+// WeighingTicket is a dispensing instruction for one work order line
+type WeighingTicket struct {
+	ID             uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TicketNumber   string               `json:"ticket_number" gorm:"type:varchar(30);unique;not null"`
+	WorkOrderID    uuid.UUID            `json:"work_order_id" gorm:"type:uuid;not null"`
+	WOLineItemID   uuid.UUID            `json:"wo_line_item_id" gorm:"type:uuid;not null"`
+	LineNumber     int                  `json:"line_number" gorm:"not null"`
+	MaterialID     uuid.UUID            `json:"material_id" gorm:"type:uuid;not null"`
+	UOMID          uuid.UUID            `json:"uom_id" gorm:"type:uuid;not null"`
+	TargetQuantity float64              `json:"target_quantity" gorm:"type:decimal(15,4);not null"`
+	MinQuantity    float64              `json:"min_quantity" gorm:"type:decimal(15,4);not null"`
+	MaxQuantity    float64              `json:"max_quantity" gorm:"type:decimal(15,4);not null"`
+	IsCritical     bool                 `json:"is_critical" gorm:"default:false"`
+	Status         WeighingTicketStatus `json:"status" gorm:"type:varchar(20);default:'PENDING'"`
+
+	// Accepted reading
+	LotID           *uuid.UUID `json:"lot_id" gorm:"type:uuid"`
+	LotNumber       string     `json:"lot_number" gorm:"type:varchar(50)"`
+	ActualQuantity  *float64   `json:"actual_quantity" gorm:"type:decimal(15,4)"`
+	ScaleID         string     `json:"scale_id" gorm:"type:varchar(50)"`
+	UnitCost        float64    `json:"unit_cost" gorm:"type:decimal(18,4);default:0"`
+	WeighedBy       *uuid.UUID `json:"weighed_by" gorm:"type:uuid"`
+	WeighedAt       *time.Time `json:"weighed_at"`
+	VerifiedBy      *uuid.UUID `json:"verified_by" gorm:"type:uuid"`
+	VerifiedAt      *time.Time `json:"verified_at"`
+	MaterialIssueID *uuid.UUID `json:"material_issue_id" gorm:"type:uuid"`
+
+	CreatedBy *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedAt time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Readings []WeighingReading `json:"readings,omitempty" gorm:"foreignKey:WeighingTicketID"`
+}
+
+// TableName returns the table name
+func (WeighingTicket) TableName() string {
+	return "weighing_tickets"
+}
+
+// WeighingReading is a scale reading recorded against a ticket, accepted or not
+type WeighingReading struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WeighingTicketID uuid.UUID `json:"weighing_ticket_id" gorm:"type:uuid;not null"`
+	ScaleID          string    `json:"scale_id" gorm:"type:varchar(50);not null"`
+	LotID            uuid.UUID `json:"lot_id" gorm:"type:uuid;not null"`
+	LotNumber        string    `json:"lot_number" gorm:"type:varchar(50);not null"`
+	GrossWeight      float64   `json:"gross_weight" gorm:"type:decimal(15,4);not null"`
+	TareWeight       float64   `json:"tare_weight" gorm:"type:decimal(15,4);default:0"`
+	NetWeight        float64   `json:"net_weight" gorm:"type:decimal(15,4);not null"`
+	UnitCost         float64   `json:"unit_cost" gorm:"type:decimal(18,4);default:0"` // Lot cost, if known
+	InTolerance      bool      `json:"in_tolerance"`
+	ReadBy           uuid.UUID `json:"read_by" gorm:"type:uuid;not null"`
+	ReadAt           time.Time `json:"read_at" gorm:"not null"`
+}
+
+// TableName returns the table name
+func (WeighingReading) TableName() string {
+	return "weighing_readings"
+}
+
+// NewWeighingTicket builds a ticket for a WO line. Tolerances come from the BOM
+// line and are scaled by ratio (WO planned quantity / BOM batch size); a missing
+// limit falls back to defaultTolerancePct percent of the target.
+func NewWeighingTicket(wo *WorkOrder, line *WOLineItem, bomItem *BOMLineItem, ratio, defaultTolerancePct float64) *WeighingTicket {
+	tolerance := line.PlannedQuantity * defaultTolerancePct / 100
+	ticket := &WeighingTicket{
+		WorkOrderID:    wo.ID,
+		WOLineItemID:   line.ID,
+		LineNumber:     line.LineNumber,
+		MaterialID:     line.MaterialID,
+		UOMID:          line.UOMID,
+		TargetQuantity: line.PlannedQuantity,
+		MinQuantity:    line.PlannedQuantity - tolerance,
+		MaxQuantity:    line.PlannedQuantity + tolerance,
+		IsCritical:     line.IsCritical,
+		Status:         WeighingTicketStatusPending,
+	}
+	if bomItem != nil {
+		if bomItem.QuantityMin != nil {
+			ticket.MinQuantity = *bomItem.QuantityMin * ratio
+		}
+		if bomItem.QuantityMax != nil {
+			ticket.MaxQuantity = *bomItem.QuantityMax * ratio
+		}
+		ticket.IsCritical = ticket.IsCritical || bomItem.IsCritical
+	}
+	return ticket
+}
+
+// InTolerance returns true if a net weight is within the ticket limits
+func (t *WeighingTicket) InTolerance(net float64) bool {
+	return net >= t.MinQuantity-weighingEpsilon && net <= t.MaxQuantity+weighingEpsilon
+}
+
+// RecordReading records a scale reading. An in-tolerance reading is accepted:
+// non-critical tickets are confirmed immediately, critical ones wait for verification.
+func (t *WeighingTicket) RecordReading(reading *WeighingReading) error {
+	if t.Status != WeighingTicketStatusPending {
+		return errors.New("weighing ticket is not pending")
+	}
+	if reading.GrossWeight < reading.TareWeight {
+		return errors.New("gross weight must not be less than tare weight")
+	}
+
+	reading.WeighingTicketID = t.ID
+	reading.NetWeight = reading.GrossWeight - reading.TareWeight
+	reading.InTolerance = t.InTolerance(reading.NetWeight)
+	if reading.ReadAt.IsZero() {
+		reading.ReadAt = time.Now()
+	}
+	t.Readings = append(t.Readings, *reading)
+
+	if !reading.InTolerance {
+		return nil
+	}
+
+	net := reading.NetWeight
+	readAt := reading.ReadAt
+	readBy := reading.ReadBy
+	lotID := reading.LotID
+	t.LotID = &lotID
+	t.LotNumber = reading.LotNumber
+	t.ActualQuantity = &net
+	t.ScaleID = reading.ScaleID
+	t.UnitCost = reading.UnitCost
+	t.WeighedBy = &readBy
+	t.WeighedAt = &readAt
+	t.Status = WeighingTicketStatusWeighed
+	if !t.IsCritical {
+		t.Status = WeighingTicketStatusConfirmed
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// Verify records the second-person verification of a critical weighing
+func (t *WeighingTicket) Verify(verifierID uuid.UUID) error {
+	if t.Status != WeighingTicketStatusWeighed {
+		return errors.New("weighing ticket is not awaiting verification")
+	}
+	if t.WeighedBy != nil && *t.WeighedBy == verifierID {
+		return errors.New("verifier must be a different person than the weigher")
+	}
+	now := time.Now()
+	t.VerifiedBy = &verifierID
+	t.VerifiedAt = &now
+	t.Status = WeighingTicketStatusConfirmed
+	t.UpdatedAt = now
+	return nil
+}
+
+// Cancel cancels a ticket that has not been confirmed
+func (t *WeighingTicket) Cancel() error {
+	if t.Status == WeighingTicketStatusConfirmed || t.Status == WeighingTicketStatusCancelled {
+		return errors.New("weighing ticket cannot be cancelled")
+	}
+	t.Status = WeighingTicketStatusCancelled
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// BuildMaterialIssue builds the material issue for a confirmed ticket
+func (t *WeighingTicket) BuildMaterialIssue(issueNumber string) (*WOMaterialIssue, error) {
+	if t.Status != WeighingTicketStatusConfirmed || t.LotID == nil || t.ActualQuantity == nil {
+		return nil, errors.New("weighing ticket is not confirmed")
+	}
+	lineID := t.WOLineItemID
+	return &WOMaterialIssue{
+		WorkOrderID:  t.WorkOrderID,
+		WOLineItemID: &lineID,
+		IssueNumber:  issueNumber,
+		IssueType:    MaterialIssueTypeIssue,
+		IssueDate:    time.Now(),
+		MaterialID:   t.MaterialID,
+		LotID:        *t.LotID,
+		LotNumber:    t.LotNumber,
+		Quantity:     *t.ActualQuantity,
+		UOMID:        t.UOMID,
+		UnitCost:     t.UnitCost,
+		IssuedBy:     t.WeighedBy,
+		Notes:        "Weighing ticket " + t.TicketNumber,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWeighingTicket(critical bool) *entity.WeighingTicket {
+	wo := &entity.WorkOrder{ID: uuid.New()}
+	line := &entity.WOLineItem{ID: uuid.New(), LineNumber: 1, MaterialID: uuid.New(), PlannedQuantity: 20, IsCritical: critical}
+	bomItem := &entity.BOMLineItem{Quantity: 10, QuantityMin: floatPtr(9.8), QuantityMax: floatPtr(10.2)}
+
+	// WO is twice the BOM batch size
+	return entity.NewWeighingTicket(wo, line, bomItem, 2, 1)
+}
+
+func TestNewWeighingTicket_ScalesTolerance(t *testing.T) {
+	ticket := newWeighingTicket(false)
+
+	assert.Equal(t, 20.0, ticket.TargetQuantity)
+	assert.InDelta(t, 19.6, ticket.MinQuantity, 1e-9)
+	assert.InDelta(t, 20.4, ticket.MaxQuantity, 1e-9)
+	assert.True(t, ticket.InTolerance(20.4))
+	assert.False(t, ticket.InTolerance(20.5))
+}
+
+func TestNewWeighingTicket_DefaultTolerance(t *testing.T) {
+	wo := &entity.WorkOrder{ID: uuid.New()}
+	line := &entity.WOLineItem{ID: uuid.New(), MaterialID: uuid.New(), PlannedQuantity: 50}
+
+	// BOM line without min/max gets the configured ±2%
+	ticket := entity.NewWeighingTicket(wo, line, &entity.BOMLineItem{Quantity: 25}, 2, 2)
+
+	assert.InDelta(t, 49.0, ticket.MinQuantity, 1e-9)
+	assert.InDelta(t, 51.0, ticket.MaxQuantity, 1e-9)
+	assert.True(t, ticket.InTolerance(50.8))
+	assert.False(t, ticket.InTolerance(51.2))
+}
+
+func TestWeighingTicket_RecordReading(t *testing.T) {
+	t.Run("Out of tolerance reading is kept but not accepted", func(t *testing.T) {
+		ticket := newWeighingTicket(false)
+		reading := &entity.WeighingReading{LotID: uuid.New(), GrossWeight: 21.5, TareWeight: 0.5, ReadBy: uuid.New()}
+
+		assert.NoError(t, ticket.RecordReading(reading))
+		assert.False(t, reading.InTolerance)
+		assert.Equal(t, entity.WeighingTicketStatusPending, ticket.Status)
+		assert.Len(t, ticket.Readings, 1)
+		assert.Nil(t, ticket.ActualQuantity)
+	})
+
+	t.Run("Non-critical line is confirmed immediately", func(t *testing.T) {
+		ticket := newWeighingTicket(false)
+		lotID := uuid.New()
+		reading := &entity.WeighingReading{LotID: lotID, LotNumber: "LOT-1", GrossWeight: 20.6, TareWeight: 0.5, ReadBy: uuid.New()}
+
+		assert.NoError(t, ticket.RecordReading(reading))
+		assert.Equal(t, entity.WeighingTicketStatusConfirmed, ticket.Status)
+
+		issue, err := ticket.BuildMaterialIssue("ISS-2026-0001")
+		assert.NoError(t, err)
+		assert.Equal(t, lotID, issue.LotID)
+		assert.InDelta(t, 20.1, issue.Quantity, 1e-9)
+	})
+
+	t.Run("Critical line needs a different verifier", func(t *testing.T) {
+		ticket := newWeighingTicket(true)
+		weigher := uuid.New()
+		reading := &entity.WeighingReading{LotID: uuid.New(), GrossWeight: 20, ReadBy: weigher}
+
+		assert.NoError(t, ticket.RecordReading(reading))
+		assert.Equal(t, entity.WeighingTicketStatusWeighed, ticket.Status)
+
+		_, err := ticket.BuildMaterialIssue("ISS-2026-0001")
+		assert.Error(t, err)
+
+		assert.Error(t, ticket.Verify(weigher))
+		assert.NoError(t, ticket.Verify(uuid.New()))
+		assert.Equal(t, entity.WeighingTicketStatusConfirmed, ticket.Status)
+	})
+}
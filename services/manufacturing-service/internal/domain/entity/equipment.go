@@ -0,0 +1,237 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EquipmentType represents the kind of production equipment
+type EquipmentType string
+
+const (
+	EquipmentTypeScale       EquipmentType = "SCALE"
+	EquipmentTypeMixer       EquipmentType = "MIXER"
+	EquipmentTypeHomogenizer EquipmentType = "HOMOGENIZER"
+	EquipmentTypeFillingLine EquipmentType = "FILLING_LINE"
+	EquipmentTypeTank        EquipmentType = "TANK"
+	EquipmentTypeOther       EquipmentType = "OTHER"
+)
+
+// IsValid returns true for a known equipment type
+func (t EquipmentType) IsValid() bool {
+	switch t {
+	case EquipmentTypeScale, EquipmentTypeMixer, EquipmentTypeHomogenizer,
+		EquipmentTypeFillingLine, EquipmentTypeTank, EquipmentTypeOther:
+		return true
+	}
+	return false
+}
+
+// EquipmentStatus represents equipment status
+type EquipmentStatus string
+
+const (
+	EquipmentStatusActive       EquipmentStatus = "ACTIVE"
+	EquipmentStatusOutOfService EquipmentStatus = "OUT_OF_SERVICE" // Failed calibration or under repair
+	EquipmentStatusRetired      EquipmentStatus = "RETIRED"
+)
+
+// IsValid returns true for a known equipment status
+func (s EquipmentStatus) IsValid() bool {
+	switch s {
+	case EquipmentStatusActive, EquipmentStatusOutOfService, EquipmentStatusRetired:
+		return true
+	}
+	return false
+}
+
+// CalibrationResult represents the outcome of a calibration
+type CalibrationResult string
+
+const (
+	CalibrationResultPass CalibrationResult = "PASS"
+	CalibrationResultFail CalibrationResult = "FAIL"
+)
+
+// EquipmentDueType identifies what an equipment due-date alert is about
+type EquipmentDueType string
+
+const (
+	EquipmentDueCalibration EquipmentDueType = "CALIBRATION"
+	EquipmentDueMaintenance EquipmentDueType = "MAINTENANCE"
+)
+
+// Equipment represents a registered piece of production equipment.
+// Scales are matched to dispensing readings by Code (the reading's scale_id).
+type Equipment struct {
+	ID                      uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code                    string          `json:"code" gorm:"type:varchar(30);unique;not null"`
+	Name                    string          `json:"name" gorm:"type:varchar(100);not null"`
+	EquipmentType           EquipmentType   `json:"equipment_type" gorm:"type:varchar(20);not null"`
+	ProductionLine          string          `json:"production_line" gorm:"type:varchar(50)"`
+	WorkCenterID            *uuid.UUID      `json:"work_center_id" gorm:"type:uuid"`
+	Manufacturer            string          `json:"manufacturer" gorm:"type:varchar(100)"`
+	Model                   string          `json:"model" gorm:"type:varchar(100)"`
+	SerialNumber            string          `json:"serial_number" gorm:"type:varchar(100)"`
+	Status                  EquipmentStatus `json:"status" gorm:"type:varchar(20);default:'ACTIVE'"`
+	RequiresCalibration     bool            `json:"requires_calibration" gorm:"default:true"`
+	CalibrationIntervalDays int             `json:"calibration_interval_days" gorm:"default:0"`
+	LastCalibrationDate     *time.Time      `json:"last_calibration_date" gorm:"type:date"`
+	CalibrationDueDate      *time.Time      `json:"calibration_due_date" gorm:"type:date"`
+	LastCalibrationAlertAt  *time.Time      `json:"last_calibration_alert_at"`
+	ResponsibleUserID       *uuid.UUID      `json:"responsible_user_id" gorm:"type:uuid"`
+	Notes                   string          `json:"notes" gorm:"type:text"`
+	CreatedBy               *uuid.UUID      `json:"created_by" gorm:"type:uuid"`
+	CreatedAt               time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt               time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Calibrations     []CalibrationRecord `json:"calibrations,omitempty" gorm:"foreignKey:EquipmentID"`
+	MaintenancePlans []MaintenancePlan   `json:"maintenance_plans,omitempty" gorm:"foreignKey:EquipmentID"`
+}
+
+// TableName returns the table name
+func (Equipment) TableName() string {
+	return "equipment"
+}
+
+// CalibrationRecord is a calibration certificate for a piece of equipment
+type CalibrationRecord struct {
+	ID                uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EquipmentID       uuid.UUID         `json:"equipment_id" gorm:"type:uuid;not null"`
+	CertificateNumber string            `json:"certificate_number" gorm:"type:varchar(50);not null"`
+	CalibrationDate   time.Time         `json:"calibration_date" gorm:"type:date;not null"`
+	DueDate           time.Time         `json:"due_date" gorm:"type:date;not null"`
+	Result            CalibrationResult `json:"result" gorm:"type:varchar(10);not null"`
+	CalibratedBy      string            `json:"calibrated_by" gorm:"type:varchar(200)"` // Lab or service provider
+	CertificateURL    string            `json:"certificate_url" gorm:"type:varchar(500)"`
+	Notes             string            `json:"notes" gorm:"type:text"`
+	RecordedBy        uuid.UUID         `json:"recorded_by" gorm:"type:uuid;not null"`
+	CreatedAt         time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (CalibrationRecord) TableName() string {
+	return "equipment_calibrations"
+}
+
+// MaintenancePlan is a recurring preventive maintenance task for a piece of equipment
+type MaintenancePlan struct {
+	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	EquipmentID       uuid.UUID  `json:"equipment_id" gorm:"type:uuid;not null"`
+	Name              string     `json:"name" gorm:"type:varchar(200);not null"`
+	Tasks             string     `json:"tasks" gorm:"type:text"`
+	IntervalDays      int        `json:"interval_days" gorm:"not null"`
+	LastPerformedDate *time.Time `json:"last_performed_date" gorm:"type:date"`
+	NextDueDate       time.Time  `json:"next_due_date" gorm:"type:date;not null"`
+	IsActive          bool       `json:"is_active" gorm:"default:true"`
+	LastAlertAt       *time.Time `json:"last_alert_at"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Equipment *Equipment `json:"equipment,omitempty" gorm:"foreignKey:EquipmentID"`
+}
+
+// TableName returns the table name
+func (MaintenancePlan) TableName() string {
+	return "equipment_maintenance_plans"
+}
+
+// MaintenanceRecord records a performed preventive maintenance
+type MaintenanceRecord struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PlanID        uuid.UUID `json:"plan_id" gorm:"type:uuid;not null"`
+	EquipmentID   uuid.UUID `json:"equipment_id" gorm:"type:uuid;not null"`
+	PerformedDate time.Time `json:"performed_date" gorm:"type:date;not null"`
+	PerformedBy   uuid.UUID `json:"performed_by" gorm:"type:uuid;not null"`
+	Notes         string    `json:"notes" gorm:"type:text"`
+	CreatedAt     time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (MaintenanceRecord) TableName() string {
+	return "equipment_maintenance_records"
+}
+
+// Equipment business methods
+
+// IsCalibrationOverdue returns true if the equipment needs calibration and has
+// never been calibrated or its certificate expired before asOf
+func (e *Equipment) IsCalibrationOverdue(asOf time.Time) bool {
+	if !e.RequiresCalibration {
+		return false
+	}
+	if e.CalibrationDueDate == nil {
+		return true
+	}
+	return !asOf.Before(startOfDay(*e.CalibrationDueDate, asOf.Location()).AddDate(0, 0, 1))
+}
+
+// CheckUsable returns an error if the equipment may not be used for production at asOf
+func (e *Equipment) CheckUsable(asOf time.Time) error {
+	if e.Status != EquipmentStatusActive {
+		return ErrEquipmentNotActive
+	}
+	if e.IsCalibrationOverdue(asOf) {
+		return ErrEquipmentCalibrationOverdue
+	}
+	return nil
+}
+
+// ApplyCalibration updates the calibration status from a certificate. A pass
+// moves the due date and returns out-of-service equipment to use; a fail takes
+// the equipment out of service until it is recalibrated.
+func (e *Equipment) ApplyCalibration(record *CalibrationRecord) {
+	if record.Result == CalibrationResultFail {
+		e.Status = EquipmentStatusOutOfService
+		return
+	}
+	e.LastCalibrationDate = &record.CalibrationDate
+	e.CalibrationDueDate = &record.DueDate
+	e.LastCalibrationAlertAt = nil
+	if e.Status == EquipmentStatusOutOfService {
+		e.Status = EquipmentStatusActive
+	}
+}
+
+// CalibrationAlertDue returns true if the calibration falls due within leadDays
+// of asOf and no alert was sent within the interval
+func (e *Equipment) CalibrationAlertDue(asOf time.Time, leadDays int, interval time.Duration) bool {
+	if !e.RequiresCalibration || e.Status == EquipmentStatusRetired || e.CalibrationDueDate == nil {
+		return false
+	}
+	if DaysUntil(*e.CalibrationDueDate, asOf) > leadDays {
+		return false
+	}
+	return e.LastCalibrationAlertAt == nil || asOf.Sub(*e.LastCalibrationAlertAt) >= interval
+}
+
+// MaintenancePlan business methods
+
+// RecordPerformed moves the plan to its next due date
+func (p *MaintenancePlan) RecordPerformed(performedDate time.Time) {
+	p.LastPerformedDate = &performedDate
+	p.NextDueDate = performedDate.AddDate(0, 0, p.IntervalDays)
+	p.LastAlertAt = nil
+}
+
+// AlertDue returns true if the maintenance falls due within leadDays of asOf
+// and no alert was sent within the interval
+func (p *MaintenancePlan) AlertDue(asOf time.Time, leadDays int, interval time.Duration) bool {
+	if !p.IsActive || DaysUntil(p.NextDueDate, asOf) > leadDays {
+		return false
+	}
+	return p.LastAlertAt == nil || asOf.Sub(*p.LastAlertAt) >= interval
+}
+
+// DaysUntil returns the whole days from asOf to the due date; negative when overdue
+func DaysUntil(due time.Time, asOf time.Time) int {
+	d := startOfDay(due, asOf.Location())
+	return int(d.Sub(startOfDay(asOf, asOf.Location())).Hours() / 24)
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
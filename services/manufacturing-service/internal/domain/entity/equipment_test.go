@@ -0,0 +1,80 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquipment_IsCalibrationOverdue(t *testing.T) {
+	due := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	eq := &entity.Equipment{Status: entity.EquipmentStatusActive, RequiresCalibration: true, CalibrationDueDate: &due}
+
+	assert.False(t, eq.IsCalibrationOverdue(time.Date(2026, 6, 30, 17, 0, 0, 0, time.UTC))) // Valid through the due date
+	assert.True(t, eq.IsCalibrationOverdue(time.Date(2026, 7, 1, 8, 0, 0, 0, time.UTC)))
+
+	eq.CalibrationDueDate = nil
+	assert.True(t, eq.IsCalibrationOverdue(due)) // Never calibrated
+
+	eq.RequiresCalibration = false
+	assert.False(t, eq.IsCalibrationOverdue(due))
+}
+
+func TestEquipment_ApplyCalibration(t *testing.T) {
+	// Arrange
+	oldDue := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	alertedAt := time.Date(2026, 1, 20, 8, 0, 0, 0, time.UTC)
+	eq := &entity.Equipment{
+		Status:                 entity.EquipmentStatusActive,
+		RequiresCalibration:    true,
+		CalibrationDueDate:     &oldDue,
+		LastCalibrationAlertAt: &alertedAt,
+	}
+	asOf := time.Date(2026, 2, 2, 8, 0, 0, 0, time.UTC)
+
+	// Act & Assert: a failed calibration takes the equipment out of service
+	eq.ApplyCalibration(&entity.CalibrationRecord{CalibrationDate: asOf, DueDate: asOf, Result: entity.CalibrationResultFail})
+	assert.Equal(t, entity.EquipmentStatusOutOfService, eq.Status)
+	assert.Equal(t, oldDue, *eq.CalibrationDueDate)
+	assert.Equal(t, entity.ErrEquipmentNotActive, eq.CheckUsable(asOf))
+
+	// Act & Assert: a pass after repair returns it to service
+	newDue := time.Date(2026, 8, 2, 0, 0, 0, 0, time.UTC)
+	eq.ApplyCalibration(&entity.CalibrationRecord{CalibrationDate: asOf, DueDate: newDue, Result: entity.CalibrationResultPass})
+	assert.Equal(t, entity.EquipmentStatusActive, eq.Status)
+	assert.Equal(t, newDue, *eq.CalibrationDueDate)
+	assert.Nil(t, eq.LastCalibrationAlertAt)
+	assert.NoError(t, eq.CheckUsable(asOf))
+}
+
+func TestEquipment_CalibrationAlertDue(t *testing.T) {
+	due := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	eq := &entity.Equipment{Status: entity.EquipmentStatusActive, RequiresCalibration: true, CalibrationDueDate: &due}
+	asOf := time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)
+
+	assert.False(t, eq.CalibrationAlertDue(asOf, 7, 24*time.Hour)) // 10 days out
+	assert.True(t, eq.CalibrationAlertDue(asOf, 14, 24*time.Hour))
+
+	alertedAt := asOf.Add(-2 * time.Hour)
+	eq.LastCalibrationAlertAt = &alertedAt
+	assert.False(t, eq.CalibrationAlertDue(asOf, 14, 24*time.Hour))
+	assert.True(t, eq.CalibrationAlertDue(asOf.Add(23*time.Hour), 14, 24*time.Hour))
+}
+
+func TestMaintenancePlan_RecordPerformed(t *testing.T) {
+	// Arrange
+	alertedAt := time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)
+	plan := &entity.MaintenancePlan{IntervalDays: 90, NextDueDate: time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC), IsActive: true, LastAlertAt: &alertedAt}
+	performed := time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	plan.RecordPerformed(performed)
+
+	// Assert
+	assert.Equal(t, time.Date(2026, 7, 4, 0, 0, 0, 0, time.UTC), plan.NextDueDate)
+	assert.Equal(t, performed, *plan.LastPerformedDate)
+	assert.Nil(t, plan.LastAlertAt)
+	assert.Equal(t, -2, entity.DaysUntil(time.Date(2026, 4, 3, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 5, 15, 0, 0, 0, time.UTC)))
+}
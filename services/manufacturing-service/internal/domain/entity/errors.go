@@ -28,10 +28,113 @@ var (
 	ErrQCInspectionNotFound    = &DomainError{Code: "QC_NOT_FOUND", Message: "QC inspection not found"}
 	ErrQCAlreadyApproved       = &DomainError{Code: "QC_ALREADY_APPROVED", Message: "QC inspection already approved"}
 	ErrQCCheckpointNotFound    = &DomainError{Code: "QC_CHECKPOINT_NOT_FOUND", Message: "QC checkpoint not found"}
+	ErrQCItemResultRequired    = &DomainError{Code: "QC_ITEM_RESULT_REQUIRED", Message: "Result is required for items that cannot be evaluated against limits"}
+	ErrQCCriticalTestMissing   = &DomainError{Code: "QC_CRITICAL_TEST_MISSING", Message: "Every critical test of the checkpoint must be recorded"}
 	
 	ErrNCRNotFound             = &DomainError{Code: "NCR_NOT_FOUND", Message: "NCR not found"}
 	ErrNCRAlreadyClosed        = &DomainError{Code: "NCR_ALREADY_CLOSED", Message: "NCR is already closed"}
 	
 	ErrTraceNotFound           = &DomainError{Code: "TRACE_NOT_FOUND", Message: "Traceability record not found"}
 	ErrLotNotFound             = &DomainError{Code: "LOT_NOT_FOUND", Message: "Lot not found"}
+
+	ErrWorkCenterNotFound      = &DomainError{Code: "WORK_CENTER_NOT_FOUND", Message: "Work center not found"}
+	ErrRoutingNotFound         = &DomainError{Code: "ROUTING_NOT_FOUND", Message: "Routing not found"}
+	ErrRoutingCannotActivate   = &DomainError{Code: "ROUTING_CANNOT_ACTIVATE", Message: "Routing cannot be activated"}
+	ErrOperationNotFound       = &DomainError{Code: "OPERATION_NOT_FOUND", Message: "Work order operation not found"}
+	ErrOperationOutOfSequence  = &DomainError{Code: "OPERATION_OUT_OF_SEQUENCE", Message: "Previous operations must be completed first"}
+	ErrOperationInvalidState   = &DomainError{Code: "OPERATION_INVALID_STATE", Message: "Operation cannot change to the requested state"}
+	ErrWONotInProgress         = &DomainError{Code: "WO_NOT_IN_PROGRESS", Message: "Work order is not in progress"}
+
+	ErrBatchRecordNotFound     = &DomainError{Code: "BATCH_RECORD_NOT_FOUND", Message: "Batch record not found"}
+	ErrBatchRecordLocked       = &DomainError{Code: "BATCH_RECORD_LOCKED", Message: "Batch record is locked after QA release"}
+	ErrBatchRecordTampered     = &DomainError{Code: "BATCH_RECORD_TAMPERED", Message: "Batch record content does not match its hash"}
+	ErrWONotCompleted          = &DomainError{Code: "WO_NOT_COMPLETED", Message: "Work order is not completed"}
+
+	ErrWeighingTicketNotFound  = &DomainError{Code: "WEIGHING_TICKET_NOT_FOUND", Message: "Weighing ticket not found"}
+	ErrWeighingOutOfTolerance  = &DomainError{Code: "WEIGHING_OUT_OF_TOLERANCE", Message: "Net weight is outside the BOM tolerance"}
+	ErrWONotDispensable        = &DomainError{Code: "WO_NOT_DISPENSABLE", Message: "Materials can only be dispensed for released or in-progress work orders"}
+	ErrWeighingLineMismatch    = &DomainError{Code: "WEIGHING_LINE_MISMATCH", Message: "Weighing ticket does not match a line of the work order"}
+	ErrWeighingLotMismatch     = &DomainError{Code: "WEIGHING_LOT_MISMATCH", Message: "Lot is not of the material on the work order line"}
+
+	ErrNCRNotReworkable        = &DomainError{Code: "NCR_NOT_REWORKABLE", Message: "NCR must have a REWORK disposition on a finished product lot"}
+	ErrReworkQtyExceeded       = &DomainError{Code: "REWORK_QTY_EXCEEDED", Message: "Rework quantity exceeds the NCR disposition quantity"}
+	ErrReworkProductMismatch   = &DomainError{Code: "REWORK_PRODUCT_MISMATCH", Message: "BOM is not of the product of the NCR"}
+	ErrInvalidWOOutput         = &DomainError{Code: "INVALID_WO_OUTPUT", Message: "Invalid co-product or by-product"}
+
+	ErrInvalidSPCChartType     = &DomainError{Code: "INVALID_SPC_CHART_TYPE", Message: "Chart type must be XBAR_R or INDIVIDUALS"}
+	ErrInvalidSubgroupSize     = &DomainError{Code: "INVALID_SUBGROUP_SIZE", Message: "Subgroup size must be between 2 and 10"}
+	ErrInsufficientSPCData     = &DomainError{Code: "INSUFFICIENT_SPC_DATA", Message: "Not enough numeric results to build the control chart"}
+
+	ErrSamplingPlanNotFound    = &DomainError{Code: "SAMPLING_PLAN_NOT_FOUND", Message: "AQL sampling plan not found"}
+	ErrInvalidSamplingPlan     = &DomainError{Code: "INVALID_SAMPLING_PLAN", Message: "Unsupported inspection level, AQL or lot quantity"}
+	ErrSamplingStateNotFound   = &DomainError{Code: "SAMPLING_STATE_NOT_FOUND", Message: "Sampling state not found"}
+	ErrSamplingSubjectRequired = &DomainError{Code: "SAMPLING_SUBJECT_REQUIRED", Message: "Supplier, product or material is required by the sampling plan"}
+	ErrSamplingDiscontinued    = &DomainError{Code: "SAMPLING_DISCONTINUED", Message: "Acceptance inspection is discontinued for this subject until corrective action"}
+	ErrSamplingNotDiscontinued = &DomainError{Code: "SAMPLING_NOT_DISCONTINUED", Message: "Sampling state is not discontinued"}
+	ErrDefectCountRequired     = &DomainError{Code: "DEFECT_COUNT_REQUIRED", Message: "Defect count is required for inspections with a sampling plan"}
+
+	ErrCoANotFound             = &DomainError{Code: "COA_NOT_FOUND", Message: "Certificate of analysis not found"}
+	ErrCoAInspectionNotPassed  = &DomainError{Code: "COA_INSPECTION_NOT_PASSED", Message: "CoA can only be issued from a passed or conditionally passed FQC inspection"}
+	ErrCoALotRequired          = &DomainError{Code: "COA_LOT_REQUIRED", Message: "Inspection has no lot to certify"}
+	ErrCoANoResults            = &DomainError{Code: "COA_NO_RESULTS", Message: "Inspection has no test results to certify"}
+	ErrSupplierCoANotFound     = &DomainError{Code: "SUPPLIER_COA_NOT_FOUND", Message: "Supplier CoA not found"}
+	ErrSupplierCoANoValues     = &DomainError{Code: "SUPPLIER_COA_NO_VALUES", Message: "Supplier CoA must contain at least one reported value"}
+
+	ErrCAPANotFound               = &DomainError{Code: "CAPA_NOT_FOUND", Message: "CAPA not found"}
+	ErrCAPAClosed                 = &DomainError{Code: "CAPA_CLOSED", Message: "CAPA is closed"}
+	ErrCAPAActionNotFound         = &DomainError{Code: "CAPA_ACTION_NOT_FOUND", Message: "CAPA action not found"}
+	ErrCAPAActionNotOpen          = &DomainError{Code: "CAPA_ACTION_NOT_OPEN", Message: "CAPA action is not open"}
+	ErrInvalidCAPAAction          = &DomainError{Code: "INVALID_CAPA_ACTION", Message: "CAPA action type must be CORRECTIVE or PREVENTIVE"}
+	ErrCAPANotPendingVerification = &DomainError{Code: "CAPA_NOT_PENDING_VERIFICATION", Message: "All CAPA actions must be completed before effectiveness verification"}
+	ErrInvalidCAPASource          = &DomainError{Code: "INVALID_CAPA_SOURCE", Message: "Source type must be NCR, SUPPLIER_EVALUATION or CUSTOMER_COMPLAINT"}
+	ErrCAPALinkExists             = &DomainError{Code: "CAPA_LINK_EXISTS", Message: "CAPA is already linked to this record"}
+	ErrNCROpenCriticalCAPA        = &DomainError{Code: "NCR_OPEN_CRITICAL_CAPA", Message: "NCR cannot be closed while critical CAPA actions are open"}
+
+	// Recall errors
+	ErrRecallNotFound    = &DomainError{Code: "RECALL_NOT_FOUND", Message: "Recall not found"}
+	ErrRecallCompleted   = &DomainError{Code: "RECALL_COMPLETED", Message: "Recall is already completed"}
+	ErrInvalidRecallMode = &DomainError{Code: "INVALID_RECALL_MODE", Message: "Recall mode must be LIVE or MOCK"}
+	ErrRecallLotNotUsed  = &DomainError{Code: "RECALL_LOT_NOT_USED", Message: "Material lot has not been used in any work order"}
+
+	// Stability study errors
+	ErrStabilityStudyNotFound     = &DomainError{Code: "STABILITY_STUDY_NOT_FOUND", Message: "Stability study not found"}
+	ErrStabilityStudyClosed       = &DomainError{Code: "STABILITY_STUDY_CLOSED", Message: "Stability study is not active"}
+	ErrStabilityPullPointNotFound = &DomainError{Code: "STABILITY_PULL_POINT_NOT_FOUND", Message: "Stability pull point not found"}
+	ErrStabilityPullPointTested   = &DomainError{Code: "STABILITY_PULL_POINT_TESTED", Message: "Results are already recorded for this pull point"}
+	ErrInvalidStabilityCondition  = &DomainError{Code: "INVALID_STABILITY_CONDITION", Message: "Condition type must be LONG_TERM, INTERMEDIATE or ACCELERATED"}
+	ErrStabilityLongTermRequired  = &DomainError{Code: "STABILITY_LONG_TERM_REQUIRED", Message: "A stability study needs a long-term storage condition"}
+	ErrInvalidStabilityPullPoints = &DomainError{Code: "INVALID_STABILITY_PULL_POINTS", Message: "Pull points must be distinct, non-negative months"}
+	ErrStabilityResultsRequired   = &DomainError{Code: "STABILITY_RESULTS_REQUIRED", Message: "At least one test result is required"}
+
+	// Equipment errors
+	ErrEquipmentNotFound           = &DomainError{Code: "EQUIPMENT_NOT_FOUND", Message: "Equipment not found"}
+	ErrEquipmentCodeExists         = &DomainError{Code: "EQUIPMENT_CODE_EXISTS", Message: "Equipment code already exists"}
+	ErrInvalidEquipmentType        = &DomainError{Code: "INVALID_EQUIPMENT_TYPE", Message: "Equipment type must be SCALE, MIXER, HOMOGENIZER, FILLING_LINE, TANK or OTHER"}
+	ErrInvalidEquipmentStatus      = &DomainError{Code: "INVALID_EQUIPMENT_STATUS", Message: "Equipment status must be ACTIVE, OUT_OF_SERVICE or RETIRED"}
+	ErrEquipmentNotActive          = &DomainError{Code: "EQUIPMENT_NOT_ACTIVE", Message: "Equipment is out of service or retired"}
+	ErrEquipmentCalibrationOverdue = &DomainError{Code: "EQUIPMENT_CALIBRATION_OVERDUE", Message: "Equipment calibration is overdue"}
+	ErrInvalidCalibration          = &DomainError{Code: "INVALID_CALIBRATION", Message: "Calibration result must be PASS or FAIL and due date must follow the calibration date"}
+	ErrMaintenancePlanNotFound     = &DomainError{Code: "MAINTENANCE_PLAN_NOT_FOUND", Message: "Maintenance plan not found"}
+	ErrInvalidMaintenanceInterval  = &DomainError{Code: "INVALID_MAINTENANCE_INTERVAL", Message: "Maintenance interval must be greater than zero"}
+
+	// Line clearance errors
+	ErrLineClearanceNotFound      = &DomainError{Code: "LINE_CLEARANCE_NOT_FOUND", Message: "Line clearance not found"}
+	ErrLineClearanceExists        = &DomainError{Code: "LINE_CLEARANCE_EXISTS", Message: "A line clearance already exists for this work order"}
+	ErrLineClearanceRequired      = &DomainError{Code: "LINE_CLEARANCE_REQUIRED", Message: "A verified line clearance is required before the work order can start on this line"}
+	ErrLineClearanceOutdated      = &DomainError{Code: "LINE_CLEARANCE_OUTDATED", Message: "Another work order ran on the line after the line clearance; the line must be cleared again"}
+	ErrLineClearanceNoLine        = &DomainError{Code: "LINE_CLEARANCE_NO_LINE", Message: "Work order has no production line"}
+	ErrLineClearanceWOStarted     = &DomainError{Code: "LINE_CLEARANCE_WO_STARTED", Message: "Line clearance can only be recorded before the work order starts"}
+	ErrLineClearanceNotOpen       = &DomainError{Code: "LINE_CLEARANCE_NOT_OPEN", Message: "Line clearance is already signed"}
+	ErrLineClearanceIncomplete    = &DomainError{Code: "LINE_CLEARANCE_INCOMPLETE", Message: "All checklist items must be checked before signing"}
+	ErrLineClearanceNotCompleted  = &DomainError{Code: "LINE_CLEARANCE_NOT_COMPLETED", Message: "Line clearance must be completed before verification"}
+	ErrLineClearanceSameSigner    = &DomainError{Code: "LINE_CLEARANCE_SAME_SIGNER", Message: "Verifier must be a different person than the performer"}
+	ErrLineClearanceItemNotFound  = &DomainError{Code: "LINE_CLEARANCE_ITEM_NOT_FOUND", Message: "Line clearance checklist item not found"}
+	ErrInvalidCleaningLevel       = &DomainError{Code: "INVALID_CLEANING_LEVEL", Message: "Cleaning level must be MINOR, STANDARD or MAJOR"}
+	ErrCleaningLevelBelowRequired = &DomainError{Code: "CLEANING_LEVEL_BELOW_REQUIRED", Message: "Cleaning level is below the level required for this changeover"}
+	ErrInvalidLineClearanceItem   = &DomainError{Code: "INVALID_LINE_CLEARANCE_ITEM", Message: "Checklist item section must be CLEANING or CLEARANCE and description is required"}
+
+	// Work order costing errors
+	ErrWOCostNotFound      = &DomainError{Code: "WO_COST_NOT_FOUND", Message: "Work order has not been costed"}
+	ErrWOCostNoOutput      = &DomainError{Code: "WO_COST_NO_OUTPUT", Message: "Work order has no good output to cost"}
+	ErrInvalidOverheadRate = &DomainError{Code: "INVALID_OVERHEAD_RATE", Message: "Production line is required and overhead rate cannot be negative"}
 )
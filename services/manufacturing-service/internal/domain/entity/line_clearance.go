@@ -0,0 +1,258 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CleaningLevel represents the depth of cleaning required before a batch
+type CleaningLevel string
+
+const (
+	CleaningLevelMinor    CleaningLevel = "MINOR"    // Same product campaign: residue removal and wipe-down
+	CleaningLevelStandard CleaningLevel = "STANDARD" // Product changeover: wash, rinse and sanitise contact parts
+	CleaningLevelMajor    CleaningLevel = "MAJOR"    // Allergen changeover: full strip-down, parts change and swab test
+)
+
+// IsValid returns true for a known cleaning level
+func (l CleaningLevel) IsValid() bool {
+	return l.rank() > 0
+}
+
+// Covers returns true if this cleaning level satisfies the required level
+func (l CleaningLevel) Covers(required CleaningLevel) bool {
+	return l.rank() >= required.rank()
+}
+
+func (l CleaningLevel) rank() int {
+	switch l {
+	case CleaningLevelMinor:
+		return 1
+	case CleaningLevelStandard:
+		return 2
+	case CleaningLevelMajor:
+		return 3
+	}
+	return 0
+}
+
+// LineClearanceStatus represents line clearance status
+type LineClearanceStatus string
+
+const (
+	LineClearanceStatusOpen      LineClearanceStatus = "OPEN"      // Checklist being worked through
+	LineClearanceStatusCompleted LineClearanceStatus = "COMPLETED" // Signed by the performer, waiting for QA verification
+	LineClearanceStatusVerified  LineClearanceStatus = "VERIFIED"  // Line released for the next batch
+)
+
+// LineClearanceSection groups checklist items
+type LineClearanceSection string
+
+const (
+	LineClearanceSectionCleaning  LineClearanceSection = "CLEANING"
+	LineClearanceSectionClearance LineClearanceSection = "CLEARANCE" // Removal of the previous batch's materials, labels and documents
+)
+
+// IsValid returns true for a known checklist section
+func (s LineClearanceSection) IsValid() bool {
+	return s == LineClearanceSectionCleaning || s == LineClearanceSectionClearance
+}
+
+// LineProduct describes a work order on a line for cleaning-level decisions
+type LineProduct struct {
+	ProductID        uuid.UUID
+	ContainsAllergen bool // Any BOM material is flagged IsAllergen in master data
+}
+
+// DetermineCleaningLevel returns the cleaning level required before next runs
+// on a line after previous (nil when nothing has run on the line), with the reason
+func DetermineCleaningLevel(previous *LineProduct, next LineProduct) (CleaningLevel, string) {
+	switch {
+	case previous == nil:
+		return CleaningLevelStandard, "No previous work order recorded on the line"
+	case previous.ContainsAllergen != next.ContainsAllergen:
+		return CleaningLevelMajor, "Changeover between allergen and non-allergen formulas"
+	case previous.ProductID == next.ProductID:
+		return CleaningLevelMinor, "Same product campaign"
+	case previous.ContainsAllergen:
+		return CleaningLevelMajor, "Changeover between different allergen-containing formulas"
+	default:
+		return CleaningLevelStandard, "Product changeover"
+	}
+}
+
+// LineClearance is the cleaning and line clearance record that releases a
+// production line for a work order
+type LineClearance struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ClearanceNumber  string    `json:"clearance_number" gorm:"type:varchar(30);unique;not null"`
+	ProductionLine   string    `json:"production_line" gorm:"type:varchar(50);not null"`
+	WorkOrderID      uuid.UUID `json:"work_order_id" gorm:"type:uuid;not null"`
+	ProductID        uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	BatchNumber      string    `json:"batch_number" gorm:"type:varchar(50)"`
+	ContainsAllergen bool      `json:"contains_allergen" gorm:"default:false"`
+
+	// Previous work order on the line
+	PreviousWorkOrderID      *uuid.UUID `json:"previous_work_order_id" gorm:"type:uuid"`
+	PreviousProductID        *uuid.UUID `json:"previous_product_id" gorm:"type:uuid"`
+	PreviousBatchNumber      string     `json:"previous_batch_number" gorm:"type:varchar(50)"`
+	PreviousContainsAllergen bool       `json:"previous_contains_allergen" gorm:"default:false"`
+
+	RequiredLevel CleaningLevel       `json:"required_level" gorm:"type:varchar(20);not null"`
+	CleaningLevel CleaningLevel       `json:"cleaning_level" gorm:"type:varchar(20);not null"` // Performed level, never below required
+	LevelReason   string              `json:"level_reason" gorm:"type:varchar(200)"`
+	Status        LineClearanceStatus `json:"status" gorm:"type:varchar(20);default:'OPEN'"`
+	CompletedBy   *uuid.UUID          `json:"completed_by" gorm:"type:uuid"`
+	CompletedAt   *time.Time          `json:"completed_at"`
+	VerifiedBy    *uuid.UUID          `json:"verified_by" gorm:"type:uuid"`
+	VerifiedAt    *time.Time          `json:"verified_at"`
+	Notes         string              `json:"notes" gorm:"type:text"`
+	CreatedBy     *uuid.UUID          `json:"created_by" gorm:"type:uuid"`
+	CreatedAt     time.Time           `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time           `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Items []LineClearanceItem `json:"items,omitempty" gorm:"foreignKey:LineClearanceID"`
+}
+
+// TableName returns the table name
+func (LineClearance) TableName() string {
+	return "line_clearances"
+}
+
+// LineClearanceItem is a checklist step of a line clearance
+type LineClearanceItem struct {
+	ID              uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	LineClearanceID uuid.UUID            `json:"line_clearance_id" gorm:"type:uuid;not null"`
+	Section         LineClearanceSection `json:"section" gorm:"type:varchar(20);not null"`
+	Sequence        int                  `json:"sequence" gorm:"not null"`
+	Description     string               `json:"description" gorm:"type:varchar(300);not null"`
+	IsChecked       bool                 `json:"is_checked" gorm:"default:false"`
+	CheckedBy       *uuid.UUID           `json:"checked_by" gorm:"type:uuid"`
+	CheckedAt       *time.Time           `json:"checked_at"`
+	Remarks         string               `json:"remarks" gorm:"type:text"`
+}
+
+// TableName returns the table name
+func (LineClearanceItem) TableName() string {
+	return "line_clearance_items"
+}
+
+// cleaningSteps lists the cleaning steps added at each level; a level also
+// includes the steps of every level below it
+var cleaningSteps = map[CleaningLevel][]string{
+	CleaningLevelMinor: {
+		"Remove product residue from vessels, transfer lines and filling nozzles",
+		"Wipe product contact surfaces with 70% ethanol",
+	},
+	CleaningLevelStandard: {
+		"Dismantle and wash product contact parts with detergent and hot water",
+		"Rinse with purified water and sanitise product contact surfaces",
+		"Dry equipment and inspect for visible residue",
+	},
+	CleaningLevelMajor: {
+		"Strip down mixer, homogenizer, pumps, hoses and filling heads",
+		"Replace gaskets and filters exposed to the previous formula",
+		"Allergen swab test on product contact surfaces passed",
+	},
+}
+
+// clearanceSteps are performed at every cleaning level
+var clearanceSteps = []string{
+	"Previous batch materials, bulk and packaging removed from the line",
+	"Previous batch labels, printed packaging and documents removed",
+	"Waste bins emptied and the area swept",
+	"Line status board shows the new product and batch number",
+}
+
+// DefaultLineClearanceItems returns the standard checklist for a cleaning level
+func DefaultLineClearanceItems(level CleaningLevel) []LineClearanceItem {
+	var items []LineClearanceItem
+	seq := 0
+	for _, l := range []CleaningLevel{CleaningLevelMinor, CleaningLevelStandard, CleaningLevelMajor} {
+		if !level.Covers(l) {
+			break
+		}
+		for _, step := range cleaningSteps[l] {
+			seq++
+			items = append(items, LineClearanceItem{Section: LineClearanceSectionCleaning, Sequence: seq, Description: step})
+		}
+	}
+	for _, step := range clearanceSteps {
+		seq++
+		items = append(items, LineClearanceItem{Section: LineClearanceSectionClearance, Sequence: seq, Description: step})
+	}
+	return items
+}
+
+// LineClearance business methods
+
+// CheckItem ticks off a checklist item
+func (c *LineClearance) CheckItem(itemID uuid.UUID, checkedBy uuid.UUID, remarks string) (*LineClearanceItem, error) {
+	if c.Status != LineClearanceStatusOpen {
+		return nil, ErrLineClearanceNotOpen
+	}
+	for i := range c.Items {
+		if c.Items[i].ID != itemID {
+			continue
+		}
+		now := time.Now()
+		c.Items[i].IsChecked = true
+		c.Items[i].CheckedBy = &checkedBy
+		c.Items[i].CheckedAt = &now
+		c.Items[i].Remarks = remarks
+		return &c.Items[i], nil
+	}
+	return nil, ErrLineClearanceItemNotFound
+}
+
+// Complete records the performer's signature once every item is checked
+func (c *LineClearance) Complete(completedBy uuid.UUID) error {
+	if c.Status != LineClearanceStatusOpen {
+		return ErrLineClearanceNotOpen
+	}
+	for _, item := range c.Items {
+		if !item.IsChecked {
+			return ErrLineClearanceIncomplete
+		}
+	}
+	now := time.Now()
+	c.CompletedBy = &completedBy
+	c.CompletedAt = &now
+	c.Status = LineClearanceStatusCompleted
+	c.UpdatedAt = now
+	return nil
+}
+
+// Verify records the QA verification that releases the line. The verifier
+// must be a different person than the performer.
+func (c *LineClearance) Verify(verifiedBy uuid.UUID) error {
+	if c.Status != LineClearanceStatusCompleted {
+		return ErrLineClearanceNotCompleted
+	}
+	if c.CompletedBy != nil && *c.CompletedBy == verifiedBy {
+		return ErrLineClearanceSameSigner
+	}
+	now := time.Now()
+	c.VerifiedBy = &verifiedBy
+	c.VerifiedAt = &now
+	c.Status = LineClearanceStatusVerified
+	c.UpdatedAt = now
+	return nil
+}
+
+// IsReleased returns true if the line is released for the work order
+func (c *LineClearance) IsReleased() bool {
+	return c.Status == LineClearanceStatusVerified
+}
+
+// FollowsLastRun returns true if last, the most recently started other work
+// order on the line (nil if none), is still the previous run the clearance was
+// taken after. Otherwise another batch ran in between and the line must be cleared again.
+func (c *LineClearance) FollowsLastRun(last *WorkOrder) bool {
+	if last == nil {
+		return c.PreviousWorkOrderID == nil
+	}
+	return c.PreviousWorkOrderID != nil && *c.PreviousWorkOrderID == last.ID
+}
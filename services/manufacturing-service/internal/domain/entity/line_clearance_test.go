@@ -0,0 +1,87 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineCleaningLevel(t *testing.T) {
+	cream := uuid.New()
+	lotion := uuid.New()
+
+	tests := []struct {
+		name     string
+		previous *entity.LineProduct
+		next     entity.LineProduct
+		want     entity.CleaningLevel
+	}{
+		{"first run on the line", nil, entity.LineProduct{ProductID: cream}, entity.CleaningLevelStandard},
+		{"same product", &entity.LineProduct{ProductID: cream}, entity.LineProduct{ProductID: cream}, entity.CleaningLevelMinor},
+		{"same allergen product", &entity.LineProduct{ProductID: cream, ContainsAllergen: true}, entity.LineProduct{ProductID: cream, ContainsAllergen: true}, entity.CleaningLevelMinor},
+		{"product changeover", &entity.LineProduct{ProductID: cream}, entity.LineProduct{ProductID: lotion}, entity.CleaningLevelStandard},
+		{"allergen to non-allergen", &entity.LineProduct{ProductID: cream, ContainsAllergen: true}, entity.LineProduct{ProductID: lotion}, entity.CleaningLevelMajor},
+		{"non-allergen to allergen", &entity.LineProduct{ProductID: cream}, entity.LineProduct{ProductID: lotion, ContainsAllergen: true}, entity.CleaningLevelMajor},
+		{"between allergen products", &entity.LineProduct{ProductID: cream, ContainsAllergen: true}, entity.LineProduct{ProductID: lotion, ContainsAllergen: true}, entity.CleaningLevelMajor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, reason := entity.DetermineCleaningLevel(tt.previous, tt.next)
+			assert.Equal(t, tt.want, level)
+			assert.NotEmpty(t, reason)
+		})
+	}
+}
+
+func TestDefaultLineClearanceItems(t *testing.T) {
+	minor := entity.DefaultLineClearanceItems(entity.CleaningLevelMinor)
+	major := entity.DefaultLineClearanceItems(entity.CleaningLevelMajor)
+
+	assert.Less(t, len(minor), len(major))
+	assert.Equal(t, entity.LineClearanceSectionClearance, minor[len(minor)-1].Section) // Clearance steps at every level
+	swab := false
+	for i, item := range major {
+		assert.Equal(t, i+1, item.Sequence)
+		swab = swab || strings.HasPrefix(item.Description, "Allergen swab")
+	}
+	assert.True(t, swab)
+}
+
+func TestLineClearance_SignOff(t *testing.T) {
+	// Arrange
+	operator := uuid.New()
+	clearance := &entity.LineClearance{
+		Status: entity.LineClearanceStatusOpen,
+		Items: []entity.LineClearanceItem{
+			{ID: uuid.New(), Section: entity.LineClearanceSectionCleaning, Sequence: 1},
+			{ID: uuid.New(), Section: entity.LineClearanceSectionClearance, Sequence: 2},
+		},
+	}
+
+	// Act & Assert: every item must be checked before the performer signs
+	_, err := clearance.CheckItem(clearance.Items[0].ID, operator, "")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ErrLineClearanceIncomplete, clearance.Complete(operator))
+
+	_, err = clearance.CheckItem(uuid.New(), operator, "")
+	assert.Equal(t, entity.ErrLineClearanceItemNotFound, err)
+
+	_, err = clearance.CheckItem(clearance.Items[1].ID, operator, "Status board updated")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ErrLineClearanceNotCompleted, clearance.Verify(uuid.New()))
+	assert.NoError(t, clearance.Complete(operator))
+
+	// Act & Assert: the performer cannot verify their own clearance
+	assert.Equal(t, entity.ErrLineClearanceSameSigner, clearance.Verify(operator))
+	assert.False(t, clearance.IsReleased())
+
+	assert.NoError(t, clearance.Verify(uuid.New()))
+	assert.True(t, clearance.IsReleased())
+
+	_, err = clearance.CheckItem(clearance.Items[0].ID, operator, "")
+	assert.Equal(t, entity.ErrLineClearanceNotOpen, err)
+}
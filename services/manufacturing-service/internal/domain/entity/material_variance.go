@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+)
+
+// StandardRequirement returns the standard quantity of a BOM component for an
+// output quantity, including the component's scrap allowance
+func StandardRequirement(item *BOMLineItem, outputQty, batchSize float64) float64 {
+	if batchSize <= 0 {
+		return 0
+	}
+	return item.Quantity * (outputQty / batchSize) * (1 + item.ScrapPercentage/100)
+}
+
+// MaterialVarianceLine compares actual and standard consumption of one WO line
+type MaterialVarianceLine struct {
+	WOLineItemID        uuid.UUID `json:"wo_line_item_id"`
+	LineNumber          int       `json:"line_number"`
+	MaterialID          uuid.UUID `json:"material_id"`
+	StandardQuantity    float64   `json:"standard_quantity"`
+	IssuedQuantity      float64   `json:"issued_quantity"`
+	BackflushedQuantity float64   `json:"backflushed_quantity"`
+	ReturnedQuantity    float64   `json:"returned_quantity"`
+	ActualQuantity      float64   `json:"actual_quantity"`
+	UsageVarianceQty    float64   `json:"usage_variance_qty"` // actual - standard
+	StandardUnitCost    float64   `json:"standard_unit_cost"`
+	ActualUnitCost      float64   `json:"actual_unit_cost"`
+	StandardCost        float64   `json:"standard_cost"`
+	ActualCost          float64   `json:"actual_cost"`
+	UsageVariance       float64   `json:"usage_variance"` // (actual qty - standard qty) × standard cost
+	PriceVariance       float64   `json:"price_variance"` // (actual cost - standard cost) × actual qty
+}
+
+// MaterialVarianceReport is the actual-vs-standard material report of a work order
+type MaterialVarianceReport struct {
+	WorkOrderID        uuid.UUID              `json:"work_order_id"`
+	WONumber           string                 `json:"wo_number"`
+	OutputQuantity     float64                `json:"output_quantity"`
+	Lines              []MaterialVarianceLine `json:"lines"`
+	TotalStandardCost  float64                `json:"total_standard_cost"`
+	TotalActualCost    float64                `json:"total_actual_cost"`
+	TotalUsageVariance float64                `json:"total_usage_variance"`
+	TotalPriceVariance float64                `json:"total_price_variance"`
+}
+
+// BuildMaterialVarianceReport compares actual issues with standard BOM quantities
+// and costs. Standard quantities are based on the actual output once the WO is
+// completed, otherwise on the planned quantity. Issues without a known unit cost
+// (and backflushed quantities) are valued at standard cost.
+func BuildMaterialVarianceReport(wo *WorkOrder, bom *BOM, bomItems []*BOMLineItem, lines []*WOLineItem, issues []*WOMaterialIssue) *MaterialVarianceReport {
+	output := wo.PlannedQuantity
+	if wo.ActualQuantity != nil {
+		output = *wo.ActualQuantity
+	}
+
+	bomItemByID := make(map[uuid.UUID]*BOMLineItem, len(bomItems))
+	for _, item := range bomItems {
+		bomItemByID[item.ID] = item
+	}
+
+	report := &MaterialVarianceReport{
+		WorkOrderID:    wo.ID,
+		WONumber:       wo.WONumber,
+		OutputQuantity: output,
+	}
+
+	for _, line := range lines {
+		v := MaterialVarianceLine{
+			WOLineItemID:        line.ID,
+			LineNumber:          line.LineNumber,
+			MaterialID:          line.MaterialID,
+			StandardQuantity:    line.PlannedQuantity,
+			IssuedQuantity:      line.IssuedQuantity,
+			BackflushedQuantity: line.BackflushedQuantity,
+			ReturnedQuantity:    line.ReturnedQuantity,
+			ActualQuantity:      line.ConsumedQuantity(),
+		}
+		if line.BOMLineItemID != nil {
+			if item, ok := bomItemByID[*line.BOMLineItemID]; ok {
+				v.StandardQuantity = StandardRequirement(item, output, bom.BatchSize)
+				v.StandardUnitCost = item.UnitCost
+			}
+		}
+
+		// Actual cost: issued lots at their cost, less returns, plus backflush at standard
+		actualCost := line.BackflushedQuantity * v.StandardUnitCost
+		for _, issue := range issues {
+			if issue.WOLineItemID == nil || *issue.WOLineItemID != line.ID {
+				continue
+			}
+			unitCost := issue.UnitCost
+			if unitCost == 0 {
+				unitCost = v.StandardUnitCost
+			}
+			if issue.IssueType == MaterialIssueTypeReturn {
+				actualCost -= issue.Quantity * unitCost
+			} else {
+				actualCost += issue.Quantity * unitCost
+			}
+		}
+
+		v.UsageVarianceQty = v.ActualQuantity - v.StandardQuantity
+		v.StandardCost = v.StandardQuantity * v.StandardUnitCost
+		v.ActualCost = actualCost
+		if v.ActualQuantity != 0 {
+			v.ActualUnitCost = actualCost / v.ActualQuantity
+		}
+		v.UsageVariance = v.UsageVarianceQty * v.StandardUnitCost
+		v.PriceVariance = v.ActualCost - v.ActualQuantity*v.StandardUnitCost
+
+		report.Lines = append(report.Lines, v)
+		report.TotalStandardCost += v.StandardCost
+		report.TotalActualCost += v.ActualCost
+		report.TotalUsageVariance += v.UsageVariance
+		report.TotalPriceVariance += v.PriceVariance
+	}
+
+	return report
+}
@@ -0,0 +1,78 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardRequirement_IncludesScrap(t *testing.T) {
+	item := &entity.BOMLineItem{Quantity: 10, ScrapPercentage: 5}
+
+	assert.InDelta(t, 21.0, entity.StandardRequirement(item, 200, 100), 1e-9)
+	assert.Equal(t, 0.0, entity.StandardRequirement(item, 200, 0))
+}
+
+func TestWOLineItem_BackflushAndReturns(t *testing.T) {
+	lineID := uuid.New()
+	lotA, lotB := uuid.New(), uuid.New()
+
+	t.Run("Shortfall is backflushed", func(t *testing.T) {
+		line := &entity.WOLineItem{ID: lineID, IssuedQuantity: 8}
+
+		qty, surplus := line.Backflush(10)
+
+		assert.InDelta(t, 2.0, qty, 1e-9)
+		assert.Equal(t, 0.0, surplus)
+		assert.InDelta(t, 10.0, line.ConsumedQuantity(), 1e-9)
+	})
+
+	t.Run("Surplus is returned from the latest lot first", func(t *testing.T) {
+		line := &entity.WOLineItem{ID: lineID, IssuedQuantity: 14}
+		issues := []*entity.WOMaterialIssue{
+			{WOLineItemID: &lineID, IssueType: entity.MaterialIssueTypeIssue, LotID: lotA, LotNumber: "A", Quantity: 10},
+			{WOLineItemID: &lineID, IssueType: entity.MaterialIssueTypeIssue, LotID: lotB, LotNumber: "B", Quantity: 4},
+		}
+
+		qty, surplus := line.Backflush(9)
+		returns := line.PlanReturns(issues, surplus)
+
+		assert.Equal(t, 0.0, qty)
+		assert.Len(t, returns, 2)
+		assert.Equal(t, lotB, returns[0].LotID)
+		assert.InDelta(t, 4.0, returns[0].Quantity, 1e-9)
+		assert.Equal(t, lotA, returns[1].LotID)
+		assert.InDelta(t, 1.0, returns[1].Quantity, 1e-9)
+		assert.Equal(t, entity.MaterialIssueTypeReturn, returns[0].IssueType)
+		assert.InDelta(t, 9.0, line.ConsumedQuantity(), 1e-9)
+	})
+}
+
+func TestBuildMaterialVarianceReport(t *testing.T) {
+	bomItemID := uuid.New()
+	lineID := uuid.New()
+	actual := 100.0
+
+	wo := &entity.WorkOrder{ID: uuid.New(), PlannedQuantity: 100, ActualQuantity: &actual}
+	bom := &entity.BOM{BatchSize: 100}
+	bomItems := []*entity.BOMLineItem{{ID: bomItemID, Quantity: 10, UnitCost: 5}}
+	lines := []*entity.WOLineItem{{ID: lineID, BOMLineItemID: &bomItemID, IssuedQuantity: 12, ReturnedQuantity: 1}}
+	issues := []*entity.WOMaterialIssue{
+		{WOLineItemID: &lineID, IssueType: entity.MaterialIssueTypeIssue, Quantity: 12, UnitCost: 6},
+		{WOLineItemID: &lineID, IssueType: entity.MaterialIssueTypeReturn, Quantity: 1, UnitCost: 6},
+	}
+
+	report := entity.BuildMaterialVarianceReport(wo, bom, bomItems, lines, issues)
+
+	assert.Len(t, report.Lines, 1)
+	line := report.Lines[0]
+	assert.InDelta(t, 10.0, line.StandardQuantity, 1e-9)
+	assert.InDelta(t, 11.0, line.ActualQuantity, 1e-9)
+	assert.InDelta(t, 50.0, line.StandardCost, 1e-9)
+	assert.InDelta(t, 66.0, line.ActualCost, 1e-9)
+	assert.InDelta(t, 5.0, line.UsageVariance, 1e-9)  // 1 extra unit at standard cost
+	assert.InDelta(t, 11.0, line.PriceVariance, 1e-9) // 11 units at +1
+	assert.InDelta(t, report.TotalActualCost-report.TotalStandardCost, report.TotalUsageVariance+report.TotalPriceVariance, 1e-9)
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -138,3 +139,20 @@ func (n *NCR) Close(closedBy uuid.UUID, notes string) error {
 	n.UpdatedAt = now
 	return nil
 }
+
+// ReworkQuantity returns the quantity released for rework by the NCR disposition
+func (n *NCR) ReworkQuantity() (float64, error) {
+	if n.Disposition == nil || *n.Disposition != DispositionRework {
+		return 0, errors.New("NCR disposition is not rework")
+	}
+	if n.ProductID == nil || n.LotID == nil {
+		return 0, errors.New("NCR does not reference a finished product lot")
+	}
+	if n.DispositionQuantity != nil {
+		return *n.DispositionQuantity, nil
+	}
+	if n.QuantityAffected != nil {
+		return *n.QuantityAffected, nil
+	}
+	return 0, errors.New("NCR has no rework quantity")
+}
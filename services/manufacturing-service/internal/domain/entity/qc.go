@@ -2,6 +2,8 @@ package entity
 
 import (
 	"encoding/json"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +16,9 @@ const (
 	CheckpointTypeIQC  CheckpointType = "IQC"  // Incoming QC
 	CheckpointTypeIPQC CheckpointType = "IPQC" // In-Process QC
 	CheckpointTypeFQC  CheckpointType = "FQC"  // Final QC
+
+	// CheckpointTypeStability marks inspections scheduled by a stability study
+	CheckpointTypeStability CheckpointType = "STABILITY"
 )
 
 // InspectionResult represents QC result
@@ -35,6 +40,14 @@ const (
 	ItemResultNA   ItemResult = "N/A"
 )
 
+// TestItemType represents how a test item is evaluated
+type TestItemType string
+
+const (
+	TestItemTypePassFail TestItemType = "PASS_FAIL"
+	TestItemTypeNumeric  TestItemType = "NUMERIC"
+)
+
 // ReferenceType represents what is being inspected
 type ReferenceType string
 
@@ -42,6 +55,8 @@ const (
 	ReferenceTypeWorkOrder ReferenceType = "WORK_ORDER"
 	ReferenceTypeGRN       ReferenceType = "GRN"
 	ReferenceTypeLot       ReferenceType = "LOT"
+
+	ReferenceTypeStabilityStudy ReferenceType = "STABILITY_STUDY"
 )
 
 // QCCheckpoint represents a QC checkpoint template
@@ -53,6 +68,7 @@ type QCCheckpoint struct {
 	CheckpointType CheckpointType `json:"checkpoint_type" gorm:"type:varchar(20);not null"`
 	AppliesTo      string         `json:"applies_to" gorm:"type:varchar(20);default:'ALL'"` // ALL, MATERIAL, PRODUCT
 	TestItems      json.RawMessage `json:"test_items" gorm:"type:jsonb;not null"`
+	SamplingPlanID *uuid.UUID     `json:"sampling_plan_id" gorm:"type:uuid"` // AQL plan for sample size and Ac/Re
 	IsActive       bool           `json:"is_active" gorm:"default:true"`
 	CreatedAt      time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt      time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
@@ -72,6 +88,7 @@ type TestItemTemplate struct {
 	Min           *float64 `json:"min,omitempty"`
 	Max           *float64 `json:"max,omitempty"`
 	Unit          string   `json:"unit,omitempty"`
+	IsCritical    bool     `json:"is_critical,omitempty"` // A failure forces the inspection to FAILED
 }
 
 // QCInspection represents an actual QC inspection
@@ -91,7 +108,18 @@ type QCInspection struct {
 	AcceptedQuantity  *float64         `json:"accepted_quantity" gorm:"type:decimal(15,4)"`
 	RejectedQuantity  *float64         `json:"rejected_quantity" gorm:"type:decimal(15,4)"`
 	SampleSize        *int             `json:"sample_size"`
+
+	// AQL sampling (ISO 2859-1), set when the checkpoint has a sampling plan
+	SupplierID       *uuid.UUID         `json:"supplier_id" gorm:"type:uuid"` // Switching history subject for IQC
+	SamplingPlanID   *uuid.UUID         `json:"sampling_plan_id" gorm:"type:uuid"`
+	SamplingSeverity InspectionSeverity `json:"sampling_severity" gorm:"type:varchar(20)"`
+	SampleCodeLetter string             `json:"sample_code_letter" gorm:"type:varchar(2)"`
+	AcceptNumber     *int               `json:"accept_number"`
+	RejectNumber     *int               `json:"reject_number"`
+	DefectCount      *int               `json:"defect_count"` // Nonconforming units found in the sample
+
 	Result            InspectionResult `json:"result" gorm:"type:varchar(20);default:'PENDING'"`
+	EvaluatedResult   InspectionResult `json:"evaluated_result" gorm:"type:varchar(20);default:'PENDING'"` // Derived from item evaluation
 	OverallScore      *float64         `json:"overall_score" gorm:"type:decimal(5,2)"`
 	InspectorID       uuid.UUID        `json:"inspector_id" gorm:"type:uuid;not null"`
 	InspectorName     string           `json:"inspector_name" gorm:"type:varchar(100)"`
@@ -113,20 +141,27 @@ func (QCInspection) TableName() string {
 
 // QCInspectionItem represents an individual test in an inspection
 type QCInspectionItem struct {
-	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	InspectionID  uuid.UUID  `json:"inspection_id" gorm:"type:uuid;not null"`
-	ItemNumber    int        `json:"item_number" gorm:"not null"`
-	TestName      string     `json:"test_name" gorm:"type:varchar(100);not null"`
-	TestMethod    string     `json:"test_method" gorm:"type:varchar(100)"`
-	Specification string     `json:"specification" gorm:"type:varchar(200)"`
-	TargetValue   string     `json:"target_value" gorm:"type:varchar(100)"`
-	MinValue      string     `json:"min_value" gorm:"type:varchar(100)"`
-	MaxValue      string     `json:"max_value" gorm:"type:varchar(100)"`
-	ActualValue   string     `json:"actual_value" gorm:"type:varchar(100)"`
-	UOM           string     `json:"uom" gorm:"type:varchar(20)"`
-	Result        ItemResult `json:"result" gorm:"type:varchar(20);not null"`
-	Notes         string     `json:"notes" gorm:"type:text"`
-	CreatedAt     time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ID            uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	InspectionID  uuid.UUID    `json:"inspection_id" gorm:"type:uuid;not null"`
+	ItemNumber    int          `json:"item_number" gorm:"not null"`
+	TestName      string       `json:"test_name" gorm:"type:varchar(100);not null"`
+	TestMethod    string       `json:"test_method" gorm:"type:varchar(100)"`
+	Specification string       `json:"specification" gorm:"type:varchar(200)"`
+	TargetValue   string       `json:"target_value" gorm:"type:varchar(100)"`
+	MinValue      string       `json:"min_value" gorm:"type:varchar(100)"`
+	MaxValue      string       `json:"max_value" gorm:"type:varchar(100)"`
+	ActualValue   string       `json:"actual_value" gorm:"type:varchar(100)"`
+	UOM           string       `json:"uom" gorm:"type:varchar(20)"`
+	TestType      TestItemType `json:"test_type" gorm:"type:varchar(20);default:'PASS_FAIL'"`
+	MinLimit      *float64     `json:"min_limit" gorm:"type:decimal(18,6)"`
+	MaxLimit      *float64     `json:"max_limit" gorm:"type:decimal(18,6)"`
+	NumericValue  *float64     `json:"numeric_value" gorm:"type:decimal(18,6)"`
+	IsCritical    bool         `json:"is_critical" gorm:"default:false"`
+	OutOfSpec     bool         `json:"out_of_spec" gorm:"default:false"`
+	EnteredResult ItemResult   `json:"entered_result" gorm:"type:varchar(20)"` // Result as submitted by the inspector
+	Result        ItemResult   `json:"result" gorm:"type:varchar(20);not null"`
+	Notes         string       `json:"notes" gorm:"type:text"`
+	CreatedAt     time.Time    `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
 // TableName returns the table name
@@ -173,8 +208,18 @@ func (q *QCInspection) ConditionalPass(approverID uuid.UUID) {
 	q.UpdatedAt = now
 }
 
-// CalculateScore calculates the overall score based on items
+// CalculateScore evaluates the items against their specs, then calculates the
+// overall score and the derived result
 func (q *QCInspection) CalculateScore() {
+	q.scoreItems()
+	if q.SamplingRejected() {
+		q.EvaluatedResult = InspectionResultFailed
+	} else if q.EvaluatedResult == InspectionResultPending && q.SamplingPlanID != nil && q.DefectCount != nil {
+		q.EvaluatedResult = InspectionResultPassed
+	}
+}
+
+func (q *QCInspection) scoreItems() {
 	if len(q.Items) == 0 {
 		return
 	}
@@ -182,7 +227,9 @@ func (q *QCInspection) CalculateScore() {
 	passCount := 0
 	totalCount := 0
 	
-	for _, item := range q.Items {
+	for i := range q.Items {
+		item := &q.Items[i]
+		item.Evaluate()
 		if item.Result != ItemResultNA {
 			totalCount++
 			if item.Result == ItemResultPass {
@@ -194,5 +241,131 @@ func (q *QCInspection) CalculateScore() {
 	if totalCount > 0 {
 		score := float64(passCount) / float64(totalCount) * 100
 		q.OverallScore = &score
+		q.EvaluatedResult = InspectionResultPassed
+		if passCount < totalCount {
+			q.EvaluatedResult = InspectionResultFailed
+		}
+	}
+}
+
+// ApplySamplePlan records the sample size and accept/reject numbers for the lot
+func (q *QCInspection) ApplySamplePlan(planID uuid.UUID, plan *SamplePlan) {
+	sampleSize, ac, re := plan.SampleSize, plan.AcceptNumber, plan.RejectNumber
+	q.SamplingPlanID = &planID
+	q.SamplingSeverity = plan.Severity
+	q.SampleCodeLetter = plan.CodeLetter
+	q.SampleSize = &sampleSize
+	q.AcceptNumber = &ac
+	q.RejectNumber = &re
+}
+
+// SamplingRejected returns true if the defect count reaches the reject number
+func (q *QCInspection) SamplingRejected() bool {
+	return q.RejectNumber != nil && q.DefectCount != nil && *q.DefectCount >= *q.RejectNumber
+}
+
+// HasCriticalFailure returns true if a critical test item failed
+func (q *QCInspection) HasCriticalFailure() bool {
+	for _, item := range q.Items {
+		if item.IsCritical && item.Result == ItemResultFail {
+			return true
+		}
+	}
+	return false
+}
+
+// TestItemTemplates returns the test item templates of the checkpoint
+func (c *QCCheckpoint) TestItemTemplates() ([]TestItemTemplate, error) {
+	var templates []TestItemTemplate
+	if len(c.TestItems) == 0 {
+		return templates, nil
+	}
+	err := json.Unmarshal(c.TestItems, &templates)
+	return templates, err
+}
+
+// FindTestItemTemplate returns the template with the given test name (case-insensitive)
+func FindTestItemTemplate(templates []TestItemTemplate, name string) *TestItemTemplate {
+	for i := range templates {
+		if strings.EqualFold(strings.TrimSpace(templates[i].Name), strings.TrimSpace(name)) {
+			return &templates[i]
+		}
+	}
+	return nil
+}
+
+// ApplySpec takes type, limits and criticality from the checkpoint template,
+// overriding whatever the caller submitted
+func (i *QCInspectionItem) ApplySpec(tpl *TestItemTemplate) {
+	i.TestType = TestItemType(tpl.Type)
+	if i.TestMethod == "" {
+		i.TestMethod = tpl.Method
+	}
+	if tpl.Specification != "" {
+		i.Specification = tpl.Specification
+	}
+	if tpl.Unit != "" {
+		i.UOM = tpl.Unit
+	}
+	i.MinLimit = tpl.Min
+	i.MaxLimit = tpl.Max
+	i.MinValue = formatNumber(tpl.Min)
+	i.MaxValue = formatNumber(tpl.Max)
+	i.IsCritical = tpl.IsCritical
+}
+
+// Evaluate derives the item result. Numeric items with a reading and at least
+// one limit are passed or failed against the limits; other items keep the
+// result entered by the inspector. Failed items are flagged out of spec.
+func (i *QCInspectionItem) Evaluate() {
+	if i.EnteredResult == "" {
+		i.EnteredResult = i.Result
+	}
+	i.Result = i.EnteredResult
+
+	if i.TestType == "" {
+		i.TestType = TestItemTypePassFail
+		if i.MinLimit != nil || i.MaxLimit != nil || parseNumber(i.MinValue) != nil || parseNumber(i.MaxValue) != nil {
+			i.TestType = TestItemTypeNumeric
+		}
+	}
+
+	if i.TestType == TestItemTypeNumeric {
+		if i.MinLimit == nil {
+			i.MinLimit = parseNumber(i.MinValue)
+		}
+		if i.MaxLimit == nil {
+			i.MaxLimit = parseNumber(i.MaxValue)
+		}
+		if i.NumericValue == nil {
+			i.NumericValue = parseNumber(i.ActualValue)
+		} else if i.ActualValue == "" {
+			i.ActualValue = formatNumber(i.NumericValue)
+		}
+		if i.NumericValue != nil && (i.MinLimit != nil || i.MaxLimit != nil) {
+			v := *i.NumericValue
+			inSpec := (i.MinLimit == nil || v >= *i.MinLimit) && (i.MaxLimit == nil || v <= *i.MaxLimit)
+			i.Result = ItemResultFail
+			if inSpec {
+				i.Result = ItemResultPass
+			}
+		}
+	}
+
+	i.OutOfSpec = i.Result == ItemResultFail
+}
+
+func parseNumber(s string) *float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+func formatNumber(v *float64) string {
+	if v == nil {
+		return ""
 	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
 }
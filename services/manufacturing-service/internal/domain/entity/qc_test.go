@@ -0,0 +1,191 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQCInspectionItem_Evaluate(t *testing.T) {
+	t.Run("Numeric reading within limits passes", func(t *testing.T) {
+		// Arrange
+		item := &entity.QCInspectionItem{
+			TestType:     entity.TestItemTypeNumeric,
+			MinLimit:     floatPtr(4.0),
+			MaxLimit:     floatPtr(8.0),
+			NumericValue: floatPtr(5.5),
+			Result:       entity.ItemResultFail,
+		}
+
+		// Act
+		item.Evaluate()
+
+		// Assert
+		assert.Equal(t, entity.ItemResultPass, item.Result)
+		assert.Equal(t, entity.ItemResultFail, item.EnteredResult)
+		assert.False(t, item.OutOfSpec)
+		assert.Equal(t, "5.5", item.ActualValue)
+	})
+
+	t.Run("Numeric reading outside limits fails even if entered as pass", func(t *testing.T) {
+		item := &entity.QCInspectionItem{
+			TestType:     entity.TestItemTypeNumeric,
+			MinLimit:     floatPtr(8),
+			MaxLimit:     floatPtr(12),
+			NumericValue: floatPtr(12.5),
+			Result:       entity.ItemResultPass,
+		}
+
+		item.Evaluate()
+
+		assert.Equal(t, entity.ItemResultFail, item.Result)
+		assert.True(t, item.OutOfSpec)
+	})
+
+	t.Run("Limits and reading parsed from legacy string values", func(t *testing.T) {
+		item := &entity.QCInspectionItem{
+			MinValue:    "4.0",
+			MaxValue:    "8.0",
+			ActualValue: " 3.9 ",
+		}
+
+		item.Evaluate()
+
+		assert.Equal(t, entity.TestItemTypeNumeric, item.TestType)
+		assert.Equal(t, 3.9, *item.NumericValue)
+		assert.Equal(t, entity.ItemResultFail, item.Result)
+		assert.True(t, item.OutOfSpec)
+	})
+
+	t.Run("One-sided limit", func(t *testing.T) {
+		item := &entity.QCInspectionItem{
+			TestType:     entity.TestItemTypeNumeric,
+			MaxLimit:     floatPtr(100),
+			NumericValue: floatPtr(20),
+		}
+
+		item.Evaluate()
+
+		assert.Equal(t, entity.ItemResultPass, item.Result)
+	})
+
+	t.Run("Numeric without limits keeps entered result", func(t *testing.T) {
+		item := &entity.QCInspectionItem{
+			TestType:     entity.TestItemTypeNumeric,
+			NumericValue: floatPtr(25),
+			Result:       entity.ItemResultPass,
+		}
+
+		item.Evaluate()
+
+		assert.Equal(t, entity.ItemResultPass, item.Result)
+		assert.False(t, item.OutOfSpec)
+	})
+
+	t.Run("Pass/fail item flagged when failed", func(t *testing.T) {
+		item := &entity.QCInspectionItem{Result: entity.ItemResultFail}
+
+		item.Evaluate()
+
+		assert.Equal(t, entity.TestItemTypePassFail, item.TestType)
+		assert.True(t, item.OutOfSpec)
+	})
+
+	t.Run("Re-evaluation is stable", func(t *testing.T) {
+		item := &entity.QCInspectionItem{
+			TestType:     entity.TestItemTypeNumeric,
+			MinLimit:     floatPtr(1),
+			NumericValue: floatPtr(2),
+			Result:       entity.ItemResultFail,
+		}
+
+		item.Evaluate()
+		item.Evaluate()
+
+		assert.Equal(t, entity.ItemResultPass, item.Result)
+		assert.Equal(t, entity.ItemResultFail, item.EnteredResult)
+	})
+}
+
+func TestQCInspectionItem_ApplySpec(t *testing.T) {
+	// Arrange
+	item := &entity.QCInspectionItem{
+		TestName: "Cap Torque",
+		MinValue: "0",
+		MaxValue: "100",
+	}
+	tpl := &entity.TestItemTemplate{
+		Name:          "Cap Torque",
+		Method:        "Torque Meter",
+		Specification: "8-12 lb-in",
+		Type:          "NUMERIC",
+		Min:           floatPtr(8),
+		Max:           floatPtr(12),
+		IsCritical:    true,
+	}
+
+	// Act
+	item.ApplySpec(tpl)
+
+	// Assert
+	assert.Equal(t, entity.TestItemTypeNumeric, item.TestType)
+	assert.Equal(t, "8", item.MinValue)
+	assert.Equal(t, "12", item.MaxValue)
+	assert.Equal(t, 12.0, *item.MaxLimit)
+	assert.Equal(t, "Torque Meter", item.TestMethod)
+	assert.True(t, item.IsCritical)
+}
+
+func TestQCInspection_CalculateScore(t *testing.T) {
+	t.Run("Derives score and result from evaluation", func(t *testing.T) {
+		// Arrange
+		inspection := &entity.QCInspection{
+			Items: []entity.QCInspectionItem{
+				{TestType: entity.TestItemTypeNumeric, MinLimit: floatPtr(4), MaxLimit: floatPtr(8), NumericValue: floatPtr(9), Result: entity.ItemResultPass},
+				{Result: entity.ItemResultPass},
+				{Result: entity.ItemResultPass},
+				{Result: entity.ItemResultNA},
+			},
+		}
+
+		// Act
+		inspection.CalculateScore()
+
+		// Assert
+		assert.InDelta(t, 66.67, *inspection.OverallScore, 0.01)
+		assert.Equal(t, entity.InspectionResultFailed, inspection.EvaluatedResult)
+		assert.False(t, inspection.HasCriticalFailure())
+	})
+
+	t.Run("All pass", func(t *testing.T) {
+		inspection := &entity.QCInspection{
+			Items: []entity.QCInspectionItem{{Result: entity.ItemResultPass}},
+		}
+
+		inspection.CalculateScore()
+
+		assert.Equal(t, 100.0, *inspection.OverallScore)
+		assert.Equal(t, entity.InspectionResultPassed, inspection.EvaluatedResult)
+	})
+
+	t.Run("Critical failure detected", func(t *testing.T) {
+		inspection := &entity.QCInspection{
+			Items: []entity.QCInspectionItem{
+				{Result: entity.ItemResultPass},
+				{Result: entity.ItemResultFail, IsCritical: true},
+			},
+		}
+
+		inspection.CalculateScore()
+
+		assert.True(t, inspection.HasCriticalFailure())
+	})
+}
+
+func TestFindTestItemTemplate(t *testing.T) {
+	templates := []entity.TestItemTemplate{{Name: "pH Level"}, {Name: "Viscosity"}}
+
+	assert.Equal(t, "Viscosity", entity.FindTestItemTemplate(templates, " viscosity").Name)
+	assert.Nil(t, entity.FindTestItemTemplate(templates, "Odor"))
+}
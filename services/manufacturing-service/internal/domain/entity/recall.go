@@ -0,0 +1,199 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecallMode distinguishes a real recall from an audit exercise
+type RecallMode string
+
+const (
+	RecallModeLive RecallMode = "LIVE" // Blocks stock in WMS
+	RecallModeMock RecallMode = "MOCK" // Timed exercise, nothing is blocked
+)
+
+// RecallStatus represents recall status
+type RecallStatus string
+
+const (
+	RecallStatusOpen      RecallStatus = "OPEN"
+	RecallStatusCompleted RecallStatus = "COMPLETED"
+)
+
+// MockRecallTarget is the time allowed to complete a mock recall for the annual audit
+const MockRecallTarget = 4 * time.Hour
+
+// Recall is a product recall started from a suspect supplier material lot
+type Recall struct {
+	ID                uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RecallNumber      string          `json:"recall_number" gorm:"type:varchar(30);unique;not null"` // RCL-YYYY-XXXX
+	Mode              RecallMode      `json:"mode" gorm:"type:varchar(10);not null"`
+	Status            RecallStatus    `json:"status" gorm:"type:varchar(20);default:'OPEN'"`
+	Reason            string          `json:"reason" gorm:"type:text;not null"`
+	MaterialLotID     uuid.UUID       `json:"material_lot_id" gorm:"type:uuid;not null"`
+	MaterialLotNumber string          `json:"material_lot_number" gorm:"type:varchar(50)"`
+	MaterialID        *uuid.UUID      `json:"material_id" gorm:"type:uuid"`
+	SupplierLotNumber string          `json:"supplier_lot_number" gorm:"type:varchar(100)"`
+	AffectedLotCount  int             `json:"affected_lot_count" gorm:"default:0"`
+	CustomerCount     int             `json:"customer_count" gorm:"default:0"`
+	ShippedQuantity   float64         `json:"shipped_quantity" gorm:"type:decimal(15,4);default:0"`
+	StockQuantity     float64         `json:"stock_quantity" gorm:"type:decimal(15,4);default:0"` // Remaining in WMS
+	Report            json.RawMessage `json:"report" gorm:"type:jsonb;not null"`                  // RecallReport snapshot
+	StockBlocked      bool            `json:"stock_blocked" gorm:"default:false"`
+	StartedAt         time.Time       `json:"started_at" gorm:"not null"`
+	TracedAt          *time.Time      `json:"traced_at"` // Report produced
+	CompletedAt       *time.Time      `json:"completed_at"`
+	DurationSeconds   *int64          `json:"duration_seconds"`
+	WithinTarget      *bool           `json:"within_target"` // Mock recall finished within MockRecallTarget
+	CompletionNotes   string          `json:"completion_notes" gorm:"type:text"`
+	InitiatedBy       uuid.UUID       `json:"initiated_by" gorm:"type:uuid;not null"`
+	CompletedBy       *uuid.UUID      `json:"completed_by" gorm:"type:uuid"`
+	CreatedAt         time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (Recall) TableName() string {
+	return "recalls"
+}
+
+// RecallReport lists what was made from the suspect lot, who received it and what is left in stock
+type RecallReport struct {
+	MaterialLot RecallLot        `json:"material_lot"`
+	Lots        []RecallLot      `json:"lots"` // Lots made from the material lot, all levels
+	Customers   []RecallCustomer `json:"customers"`
+	Stock       []RecallStock    `json:"stock"` // Stock to block, including the material lot itself
+}
+
+// RecallLot is a lot reached by the forward trace
+type RecallLot struct {
+	LotID           *uuid.UUID `json:"lot_id"` // Nil until the lot is received in WMS
+	LotNumber       string     `json:"lot_number"`
+	ProductID       *uuid.UUID `json:"product_id,omitempty"`
+	WorkOrderID     *uuid.UUID `json:"work_order_id,omitempty"`
+	Level           int        `json:"level"`                      // 1 = made directly from the material lot
+	QuantityUsed    float64    `json:"quantity_used"`              // Of the parent lot
+	IsFinished      bool       `json:"is_finished"`                // Not used in any further work order
+	ShippedQuantity float64    `json:"shipped_quantity,omitempty"` // Sum of sales issues
+	StockQuantity   float64    `json:"stock_quantity,omitempty"`
+}
+
+// RecallCustomer is a customer who received recalled lots
+type RecallCustomer struct {
+	CustomerID    uuid.UUID        `json:"customer_id"`
+	CustomerCode  string           `json:"customer_code"`
+	CustomerName  string           `json:"customer_name"`
+	Email         string           `json:"email"`
+	Phone         string           `json:"phone"`
+	Contacts      []RecallContact  `json:"contacts"`
+	Deliveries    []RecallDelivery `json:"deliveries"`
+	TotalQuantity float64          `json:"total_quantity"`
+}
+
+// RecallContact is a customer contact to notify
+type RecallContact struct {
+	Name      string `json:"name"`
+	Position  string `json:"position,omitempty"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`
+	IsPrimary bool   `json:"is_primary"`
+}
+
+// RecallDelivery is a quantity of a recalled lot issued against a sales order
+type RecallDelivery struct {
+	LotID            uuid.UUID            `json:"lot_id"`
+	LotNumber        string               `json:"lot_number"`
+	SalesOrderID     uuid.UUID            `json:"sales_order_id"`
+	SalesOrderNumber string               `json:"sales_order_number"`
+	GoodsIssueNumber string               `json:"goods_issue_number"`
+	IssueDate        time.Time            `json:"issue_date"`
+	Quantity         float64              `json:"quantity"`
+	DeliveryAddress  string               `json:"delivery_address,omitempty"`
+	Shipments        []RecallShipmentInfo `json:"shipments,omitempty"`
+}
+
+// RecallShipmentInfo is a sales shipment of the order
+type RecallShipmentInfo struct {
+	ShipmentNumber string     `json:"shipment_number"`
+	Status         string     `json:"status"`
+	ShippedDate    *time.Time `json:"shipped_date,omitempty"`
+	Carrier        string     `json:"carrier,omitempty"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	RecipientName  string     `json:"recipient_name,omitempty"`
+	RecipientPhone string     `json:"recipient_phone,omitempty"`
+}
+
+// RecallStock is remaining WMS stock of a recalled lot at one location
+type RecallStock struct {
+	LotID        uuid.UUID `json:"lot_id"`
+	LotNumber    string    `json:"lot_number"`
+	WarehouseID  uuid.UUID `json:"warehouse_id"`
+	LocationID   uuid.UUID `json:"location_id"`
+	LocationCode string    `json:"location_code,omitempty"`
+	Quantity     float64   `json:"quantity"`
+	ReservedQty  float64   `json:"reserved_qty"`
+}
+
+// IsValid returns true for a known recall mode
+func (m RecallMode) IsValid() bool {
+	return m == RecallModeLive || m == RecallModeMock
+}
+
+// Recall business methods
+
+// SetReport stores the report snapshot and its totals
+func (r *Recall) SetReport(report *RecallReport, tracedAt time.Time) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	r.Report = data
+	r.AffectedLotCount = len(report.Lots)
+	r.CustomerCount = len(report.Customers)
+	r.ShippedQuantity = 0
+	for _, c := range report.Customers {
+		r.ShippedQuantity += c.TotalQuantity
+	}
+	r.StockQuantity = 0
+	for _, s := range report.Stock {
+		r.StockQuantity += s.Quantity
+	}
+	r.TracedAt = &tracedAt
+	r.UpdatedAt = tracedAt
+	return nil
+}
+
+// LotIDsToBlock returns the distinct lots with remaining stock
+func (r *RecallReport) LotIDsToBlock() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, s := range r.Stock {
+		if !seen[s.LotID] {
+			seen[s.LotID] = true
+			ids = append(ids, s.LotID)
+		}
+	}
+	return ids
+}
+
+// Complete stops the recall clock; a mock recall is checked against MockRecallTarget
+func (r *Recall) Complete(completedBy uuid.UUID, notes string, now time.Time) error {
+	if r.Status == RecallStatusCompleted {
+		return ErrRecallCompleted
+	}
+	duration := int64(now.Sub(r.StartedAt).Seconds())
+	r.Status = RecallStatusCompleted
+	r.CompletedAt = &now
+	r.CompletedBy = &completedBy
+	r.CompletionNotes = notes
+	r.DurationSeconds = &duration
+	if r.Mode == RecallModeMock {
+		within := now.Sub(r.StartedAt) <= MockRecallTarget
+		r.WithinTarget = &within
+	}
+	r.UpdatedAt = now
+	return nil
+}
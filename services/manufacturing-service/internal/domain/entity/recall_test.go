@@ -0,0 +1,79 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecall_SetReport(t *testing.T) {
+	// Arrange
+	bulkLot, finishedLot := uuid.New(), uuid.New()
+	recall := &entity.Recall{ID: uuid.New(), Mode: entity.RecallModeLive}
+	report := &entity.RecallReport{
+		Lots: []entity.RecallLot{{LotID: &bulkLot}, {LotID: &finishedLot}},
+		Customers: []entity.RecallCustomer{
+			{CustomerName: "Hasaki", TotalQuantity: 120},
+			{CustomerName: "Guardian", TotalQuantity: 30},
+		},
+		Stock: []entity.RecallStock{
+			{LotID: finishedLot, Quantity: 40},
+			{LotID: finishedLot, Quantity: 10},
+			{LotID: bulkLot, Quantity: 5},
+		},
+	}
+
+	// Act
+	err := recall.SetReport(report, time.Now())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, recall.AffectedLotCount)
+	assert.Equal(t, 2, recall.CustomerCount)
+	assert.Equal(t, 150.0, recall.ShippedQuantity)
+	assert.Equal(t, 55.0, recall.StockQuantity)
+	assert.NotNil(t, recall.TracedAt)
+	assert.Equal(t, []uuid.UUID{finishedLot, bulkLot}, report.LotIDsToBlock())
+
+	var stored entity.RecallReport
+	assert.NoError(t, json.Unmarshal(recall.Report, &stored))
+	assert.Len(t, stored.Customers, 2)
+}
+
+func TestRecall_Complete(t *testing.T) {
+	started := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+
+	t.Run("mock recall within target", func(t *testing.T) {
+		recall := &entity.Recall{Mode: entity.RecallModeMock, Status: entity.RecallStatusOpen, StartedAt: started}
+
+		err := recall.Complete(uuid.New(), "All customers reached", started.Add(2*time.Hour+30*time.Minute))
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.RecallStatusCompleted, recall.Status)
+		assert.Equal(t, int64(9000), *recall.DurationSeconds)
+		assert.True(t, *recall.WithinTarget)
+	})
+
+	t.Run("mock recall over target", func(t *testing.T) {
+		recall := &entity.Recall{Mode: entity.RecallModeMock, Status: entity.RecallStatusOpen, StartedAt: started}
+
+		err := recall.Complete(uuid.New(), "", started.Add(5*time.Hour))
+
+		assert.NoError(t, err)
+		assert.False(t, *recall.WithinTarget)
+	})
+
+	t.Run("live recall has no target", func(t *testing.T) {
+		recall := &entity.Recall{Mode: entity.RecallModeLive, Status: entity.RecallStatusOpen, StartedAt: started}
+
+		err := recall.Complete(uuid.New(), "", started.Add(48*time.Hour))
+
+		assert.NoError(t, err)
+		assert.Nil(t, recall.WithinTarget)
+		assert.Equal(t, entity.ErrRecallCompleted, recall.Complete(uuid.New(), "", started.Add(49*time.Hour)))
+	})
+}
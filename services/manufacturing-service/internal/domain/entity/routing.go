@@ -0,0 +1,373 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OperationType represents the kind of manufacturing operation
+type OperationType string
+
+const (
+	OperationTypeWeighing OperationType = "WEIGHING"
+	OperationTypeMixing   OperationType = "MIXING"
+	OperationTypeFilling  OperationType = "FILLING"
+	OperationTypePacking  OperationType = "PACKING"
+	OperationTypeOther    OperationType = "OTHER"
+)
+
+// RoutingStatus represents routing status
+type RoutingStatus string
+
+const (
+	RoutingStatusDraft    RoutingStatus = "DRAFT"
+	RoutingStatusActive   RoutingStatus = "ACTIVE"
+	RoutingStatusObsolete RoutingStatus = "OBSOLETE"
+)
+
+// WOOperationStatus represents the execution status of a work order operation
+type WOOperationStatus string
+
+const (
+	WOOperationStatusPending    WOOperationStatus = "PENDING"
+	WOOperationStatusInProgress WOOperationStatus = "IN_PROGRESS"
+	WOOperationStatusPaused     WOOperationStatus = "PAUSED"
+	WOOperationStatusCompleted  WOOperationStatus = "COMPLETED"
+)
+
+// OperationAction represents an execution event on a work order operation
+type OperationAction string
+
+const (
+	OperationActionStart    OperationAction = "START"
+	OperationActionPause    OperationAction = "PAUSE"
+	OperationActionResume   OperationAction = "RESUME"
+	OperationActionComplete OperationAction = "COMPLETE"
+)
+
+// WorkCenter represents a production resource (weighing room, mixer, filling line...)
+type WorkCenter struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code           string    `json:"code" gorm:"type:varchar(20);unique;not null"`
+	Name           string    `json:"name" gorm:"type:varchar(100);not null"`
+	Description    string    `json:"description" gorm:"type:text"`
+	ProductionLine string    `json:"production_line" gorm:"type:varchar(50)"`
+	CostPerHour    float64   `json:"cost_per_hour" gorm:"type:decimal(18,2);default:0"`
+	IsActive       bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt      time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (WorkCenter) TableName() string {
+	return "work_centers"
+}
+
+// Routing represents the sequence of operations used to make a product
+type Routing struct {
+	ID            uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RoutingNumber string        `json:"routing_number" gorm:"type:varchar(50);unique;not null"`
+	ProductID     uuid.UUID     `json:"product_id" gorm:"type:uuid;not null"`
+	BOMID         *uuid.UUID    `json:"bom_id" gorm:"type:uuid"`
+	Version       int           `json:"version" gorm:"default:1"`
+	Name          string        `json:"name" gorm:"type:varchar(200);not null"`
+	Status        RoutingStatus `json:"status" gorm:"type:varchar(30);default:'DRAFT'"`
+	BaseQuantity  float64       `json:"base_quantity" gorm:"type:decimal(15,4);not null"` // Quantity the run times are expressed for
+	Notes         string        `json:"notes" gorm:"type:text"`
+	CreatedBy     *uuid.UUID    `json:"created_by" gorm:"type:uuid"`
+	UpdatedBy     *uuid.UUID    `json:"updated_by" gorm:"type:uuid"`
+	CreatedAt     time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt     time.Time     `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Operations []RoutingOperation `json:"operations,omitempty" gorm:"foreignKey:RoutingID"`
+}
+
+// TableName returns the table name
+func (Routing) TableName() string {
+	return "routings"
+}
+
+// RoutingOperation represents a single step in a routing
+type RoutingOperation struct {
+	ID                uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RoutingID         uuid.UUID       `json:"routing_id" gorm:"type:uuid;not null"`
+	Sequence          int             `json:"sequence" gorm:"not null"`
+	OperationCode     string          `json:"operation_code" gorm:"type:varchar(20);not null"`
+	Name              string          `json:"name" gorm:"type:varchar(100);not null"`
+	OperationType     OperationType   `json:"operation_type" gorm:"type:varchar(20);not null"`
+	WorkCenterID      uuid.UUID       `json:"work_center_id" gorm:"type:uuid;not null"`
+	SetupMinutes      float64         `json:"setup_minutes" gorm:"type:decimal(10,2);default:0"`
+	RunMinutes        float64         `json:"run_minutes" gorm:"type:decimal(10,2);default:0"` // For routing base quantity
+	Instructions      string          `json:"instructions" gorm:"type:text"`
+	ProcessParameters json.RawMessage `json:"process_parameters" gorm:"type:jsonb"` // []ProcessParameterSpec
+	QCCheckpointID    *uuid.UUID      `json:"qc_checkpoint_id" gorm:"type:uuid"`    // Triggers IPQC on completion
+	CreatedAt         time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt         time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (RoutingOperation) TableName() string {
+	return "routing_operations"
+}
+
+// ProcessParameterSpec defines a critical process parameter for an operation
+type ProcessParameterSpec struct {
+	Name       string   `json:"name"`
+	Unit       string   `json:"unit,omitempty"`
+	Target     *float64 `json:"target,omitempty"`
+	Min        *float64 `json:"min,omitempty"`
+	Max        *float64 `json:"max,omitempty"`
+	IsCritical bool     `json:"is_critical"`
+}
+
+// ProcessParameterReading is an actual value recorded during execution
+type ProcessParameterReading struct {
+	Name       string    `json:"name"`
+	Value      float64   `json:"value"`
+	Unit       string    `json:"unit,omitempty"`
+	InSpec     bool      `json:"in_spec"`
+	RecordedBy uuid.UUID `json:"recorded_by"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// WOOperation represents a routing operation instantiated for a work order
+type WOOperation struct {
+	ID                 uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WorkOrderID        uuid.UUID         `json:"work_order_id" gorm:"type:uuid;not null"`
+	RoutingOperationID *uuid.UUID        `json:"routing_operation_id" gorm:"type:uuid"`
+	Sequence           int               `json:"sequence" gorm:"not null"`
+	OperationCode      string            `json:"operation_code" gorm:"type:varchar(20);not null"`
+	Name               string            `json:"name" gorm:"type:varchar(100);not null"`
+	OperationType      OperationType     `json:"operation_type" gorm:"type:varchar(20);not null"`
+	WorkCenterID       uuid.UUID         `json:"work_center_id" gorm:"type:uuid;not null"`
+	Status             WOOperationStatus `json:"status" gorm:"type:varchar(20);default:'PENDING'"`
+	StandardMinutes    float64           `json:"standard_minutes" gorm:"type:decimal(10,2);default:0"`
+	ActualMinutes      float64           `json:"actual_minutes" gorm:"type:decimal(10,2);default:0"`
+	ActualStartAt      *time.Time        `json:"actual_start_at"`
+	ActualEndAt        *time.Time        `json:"actual_end_at"`
+	LastResumedAt      *time.Time        `json:"last_resumed_at"`
+	StartedBy          *uuid.UUID        `json:"started_by" gorm:"type:uuid"`
+	CompletedBy        *uuid.UUID        `json:"completed_by" gorm:"type:uuid"`
+	ParameterSpecs     json.RawMessage   `json:"parameter_specs" gorm:"type:jsonb"`
+	ParameterReadings  json.RawMessage   `json:"parameter_readings" gorm:"type:jsonb"`
+	HasDeviation       bool              `json:"has_deviation" gorm:"default:false"`
+	QCCheckpointID     *uuid.UUID        `json:"qc_checkpoint_id" gorm:"type:uuid"`
+	QCInspectionID     *uuid.UUID        `json:"qc_inspection_id" gorm:"type:uuid"`
+	Notes              string            `json:"notes" gorm:"type:text"`
+	CreatedAt          time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt          time.Time         `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Logs []WOOperationLog `json:"logs,omitempty" gorm:"foreignKey:WOOperationID"`
+}
+
+// TableName returns the table name
+func (WOOperation) TableName() string {
+	return "wo_operations"
+}
+
+// WOOperationLog records who did what on an operation and when
+type WOOperationLog struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WOOperationID uuid.UUID       `json:"wo_operation_id" gorm:"type:uuid;not null"`
+	Action        OperationAction `json:"action" gorm:"type:varchar(20);not null"`
+	OperatorID    uuid.UUID       `json:"operator_id" gorm:"type:uuid;not null"`
+	OccurredAt    time.Time       `json:"occurred_at" gorm:"not null"`
+	Notes         string          `json:"notes" gorm:"type:text"`
+	CreatedAt     time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (WOOperationLog) TableName() string {
+	return "wo_operation_logs"
+}
+
+// Routing business methods
+
+// IsActive returns true if routing can be used for new work orders
+func (r *Routing) IsActive() bool {
+	return r.Status == RoutingStatusActive
+}
+
+// Activate makes the routing available for work orders
+func (r *Routing) Activate() error {
+	if r.Status != RoutingStatusDraft {
+		return errors.New("only draft routings can be activated")
+	}
+	if len(r.Operations) == 0 {
+		return errors.New("routing has no operations")
+	}
+	r.Status = RoutingStatusActive
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// BuildWOOperations instantiates the routing operations for a work order,
+// scaling standard times to the planned quantity
+func (r *Routing) BuildWOOperations(wo *WorkOrder) []*WOOperation {
+	var ops []*WOOperation
+	for i := range r.Operations {
+		rop := &r.Operations[i]
+		ops = append(ops, &WOOperation{
+			WorkOrderID:        wo.ID,
+			RoutingOperationID: &rop.ID,
+			Sequence:           rop.Sequence,
+			OperationCode:      rop.OperationCode,
+			Name:               rop.Name,
+			OperationType:      rop.OperationType,
+			WorkCenterID:       rop.WorkCenterID,
+			Status:             WOOperationStatusPending,
+			StandardMinutes:    rop.StandardMinutesFor(wo.PlannedQuantity, r.BaseQuantity),
+			ParameterSpecs:     rop.ProcessParameters,
+			QCCheckpointID:     rop.QCCheckpointID,
+		})
+	}
+	return ops
+}
+
+// StandardMinutesFor returns the standard time of an operation scaled to a quantity
+func (op *RoutingOperation) StandardMinutesFor(quantity, baseQuantity float64) float64 {
+	if baseQuantity <= 0 {
+		return op.SetupMinutes + op.RunMinutes
+	}
+	return op.SetupMinutes + op.RunMinutes*(quantity/baseQuantity)
+}
+
+// GetParameterSpecs returns the parsed process parameter specs
+func (op *RoutingOperation) GetParameterSpecs() ([]ProcessParameterSpec, error) {
+	return parseParameterSpecs(op.ProcessParameters)
+}
+
+// WOOperation business methods
+
+// Start starts the operation
+func (o *WOOperation) Start(operatorID uuid.UUID) error {
+	if o.Status != WOOperationStatusPending {
+		return errors.New("operation cannot be started from current status")
+	}
+	now := time.Now()
+	o.Status = WOOperationStatusInProgress
+	o.ActualStartAt = &now
+	o.LastResumedAt = &now
+	o.StartedBy = &operatorID
+	o.UpdatedAt = now
+	return nil
+}
+
+// Pause pauses a running operation, accumulating the elapsed time
+func (o *WOOperation) Pause() error {
+	if o.Status != WOOperationStatusInProgress {
+		return errors.New("only in-progress operations can be paused")
+	}
+	now := time.Now()
+	o.accumulate(now)
+	o.Status = WOOperationStatusPaused
+	o.UpdatedAt = now
+	return nil
+}
+
+// Resume resumes a paused operation
+func (o *WOOperation) Resume() error {
+	if o.Status != WOOperationStatusPaused {
+		return errors.New("only paused operations can be resumed")
+	}
+	now := time.Now()
+	o.Status = WOOperationStatusInProgress
+	o.LastResumedAt = &now
+	o.UpdatedAt = now
+	return nil
+}
+
+// Complete completes the operation
+func (o *WOOperation) Complete(operatorID uuid.UUID) error {
+	if o.Status != WOOperationStatusInProgress && o.Status != WOOperationStatusPaused {
+		return errors.New("operation cannot be completed from current status")
+	}
+	now := time.Now()
+	if o.Status == WOOperationStatusInProgress {
+		o.accumulate(now)
+	}
+	o.Status = WOOperationStatusCompleted
+	o.ActualEndAt = &now
+	o.CompletedBy = &operatorID
+	o.UpdatedAt = now
+	return nil
+}
+
+// IsDone returns true if the operation no longer blocks the next one
+func (o *WOOperation) IsDone() bool {
+	return o.Status == WOOperationStatusCompleted
+}
+
+// RecordParameters validates readings against the operation specs and stores them.
+// Missing critical parameters are reported as an error.
+func (o *WOOperation) RecordParameters(readings []ProcessParameterReading) error {
+	specs, err := parseParameterSpecs(o.ParameterSpecs)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*ProcessParameterReading, len(readings))
+	for i := range readings {
+		byName[readings[i].Name] = &readings[i]
+	}
+
+	deviation := false
+	for _, spec := range specs {
+		reading, ok := byName[spec.Name]
+		if !ok {
+			if spec.IsCritical {
+				return errors.New("missing critical process parameter: " + spec.Name)
+			}
+			continue
+		}
+		reading.InSpec = spec.InSpec(reading.Value)
+		if reading.Unit == "" {
+			reading.Unit = spec.Unit
+		}
+		if !reading.InSpec && spec.IsCritical {
+			deviation = true
+		}
+	}
+
+	data, err := json.Marshal(readings)
+	if err != nil {
+		return err
+	}
+	o.ParameterReadings = data
+	o.HasDeviation = deviation
+	return nil
+}
+
+// InSpec returns true if value is within the spec limits
+func (s ProcessParameterSpec) InSpec(value float64) bool {
+	if s.Min != nil && value < *s.Min {
+		return false
+	}
+	if s.Max != nil && value > *s.Max {
+		return false
+	}
+	return true
+}
+
+func (o *WOOperation) accumulate(now time.Time) {
+	if o.LastResumedAt != nil {
+		o.ActualMinutes += now.Sub(*o.LastResumedAt).Minutes()
+	}
+	o.LastResumedAt = nil
+}
+
+func parseParameterSpecs(raw json.RawMessage) ([]ProcessParameterSpec, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var specs []ProcessParameterSpec
+	if err := json.Unmarshal(raw, &specs); err != nil {
+		return nil, err
+	}
+	return specs, nil
+}
@@ -0,0 +1,77 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestRoutingOperation_StandardMinutesFor(t *testing.T) {
+	op := &entity.RoutingOperation{SetupMinutes: 15, RunMinutes: 60}
+
+	assert.Equal(t, 135.0, op.StandardMinutesFor(200, 100))
+	assert.Equal(t, 75.0, op.StandardMinutesFor(100, 0))
+}
+
+func TestWOOperation_Execution(t *testing.T) {
+	op := &entity.WOOperation{Status: entity.WOOperationStatusPending}
+	operator := uuid.New()
+
+	t.Run("Pending to In Progress", func(t *testing.T) {
+		assert.NoError(t, op.Start(operator))
+		assert.Equal(t, entity.WOOperationStatusInProgress, op.Status)
+		assert.NotNil(t, op.ActualStartAt)
+	})
+
+	t.Run("Pause and Resume", func(t *testing.T) {
+		assert.NoError(t, op.Pause())
+		assert.Equal(t, entity.WOOperationStatusPaused, op.Status)
+		assert.Error(t, op.Pause())
+		assert.NoError(t, op.Resume())
+		assert.Equal(t, entity.WOOperationStatusInProgress, op.Status)
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		assert.NoError(t, op.Complete(operator))
+		assert.True(t, op.IsDone())
+		assert.Equal(t, &operator, op.CompletedBy)
+		assert.Error(t, op.Start(operator))
+	})
+}
+
+func TestWOOperation_RecordParameters(t *testing.T) {
+	specs, _ := json.Marshal([]entity.ProcessParameterSpec{
+		{Name: "Temperature", Unit: "C", Min: floatPtr(70), Max: floatPtr(80), IsCritical: true},
+		{Name: "Speed", Unit: "rpm", Max: floatPtr(3000)},
+	})
+
+	t.Run("Missing Critical Parameter Fails", func(t *testing.T) {
+		op := &entity.WOOperation{ParameterSpecs: specs}
+		err := op.RecordParameters([]entity.ProcessParameterReading{{Name: "Speed", Value: 1500}})
+		assert.Error(t, err)
+	})
+
+	t.Run("Out of Spec Critical Parameter Flags Deviation", func(t *testing.T) {
+		op := &entity.WOOperation{ParameterSpecs: specs}
+		err := op.RecordParameters([]entity.ProcessParameterReading{{Name: "Temperature", Value: 85}})
+		assert.NoError(t, err)
+		assert.True(t, op.HasDeviation)
+
+		var readings []entity.ProcessParameterReading
+		assert.NoError(t, json.Unmarshal(op.ParameterReadings, &readings))
+		assert.False(t, readings[0].InSpec)
+		assert.Equal(t, "C", readings[0].Unit)
+	})
+
+	t.Run("In Spec", func(t *testing.T) {
+		op := &entity.WOOperation{ParameterSpecs: specs}
+		err := op.RecordParameters([]entity.ProcessParameterReading{{Name: "Temperature", Value: 75}})
+		assert.NoError(t, err)
+		assert.False(t, op.HasDeviation)
+	})
+}
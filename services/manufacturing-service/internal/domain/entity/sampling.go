@@ -0,0 +1,385 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InspectionLevel represents an ISO 2859-1 inspection level
+type InspectionLevel string
+
+const (
+	InspectionLevelS1  InspectionLevel = "S-1" // Special levels: small samples for costly or destructive tests
+	InspectionLevelS2  InspectionLevel = "S-2"
+	InspectionLevelS3  InspectionLevel = "S-3"
+	InspectionLevelS4  InspectionLevel = "S-4"
+	InspectionLevelI   InspectionLevel = "I"
+	InspectionLevelII  InspectionLevel = "II" // Default general level
+	InspectionLevelIII InspectionLevel = "III"
+)
+
+// inspectionLevelColumns maps a level to its column in the code letter table
+var inspectionLevelColumns = map[InspectionLevel]int{
+	InspectionLevelS1: 0, InspectionLevelS2: 1, InspectionLevelS3: 2, InspectionLevelS4: 3,
+	InspectionLevelI: 4, InspectionLevelII: 5, InspectionLevelIII: 6,
+}
+
+// IsValid checks if the inspection level is valid
+func (l InspectionLevel) IsValid() bool {
+	_, ok := inspectionLevelColumns[l]
+	return ok
+}
+
+// InspectionSeverity represents the switching state of a sampling scheme
+type InspectionSeverity string
+
+const (
+	InspectionSeverityNormal       InspectionSeverity = "NORMAL"
+	InspectionSeverityTightened    InspectionSeverity = "TIGHTENED"
+	InspectionSeverityReduced      InspectionSeverity = "REDUCED"
+	InspectionSeverityDiscontinued InspectionSeverity = "DISCONTINUED" // Acceptance inspection suspended
+)
+
+// SamplingSubjectType identifies whose quality history drives switching
+type SamplingSubjectType string
+
+const (
+	SamplingSubjectSupplier SamplingSubjectType = "SUPPLIER"
+	SamplingSubjectProduct  SamplingSubjectType = "PRODUCT"
+	SamplingSubjectMaterial SamplingSubjectType = "MATERIAL"
+)
+
+// Switching rule thresholds (ISO 2859-1 clause 9)
+const (
+	samplingHistoryWindow      = 5 // Normal -> tightened when 2 of the last 5 lots are rejected
+	samplingTightenRejections  = 2
+	samplingRelaxAcceptances   = 5  // Tightened -> normal after 5 consecutive accepted lots
+	samplingDiscontinueRejects = 5  // Discontinue after 5 rejected lots on tightened
+	samplingReducedScore       = 30 // Normal -> reduced when the switching score reaches 30
+)
+
+// StandardAQLs are the preferred AQL values (percent nonconforming) supported by the tables
+var StandardAQLs = []float64{0.010, 0.015, 0.025, 0.040, 0.065, 0.10, 0.15, 0.25, 0.40, 0.65, 1.0, 1.5, 2.5, 4.0, 6.5, 10}
+
+// Sample size code letters and their normal/tightened sample sizes (Table 2-A/2-B)
+var (
+	sampleCodeLetters = []string{"A", "B", "C", "D", "E", "F", "G", "H", "J", "K", "L", "M", "N", "P", "Q", "R"}
+	sampleSizes       = []int{2, 3, 5, 8, 13, 20, 32, 50, 80, 125, 200, 315, 500, 800, 1250, 2000}
+)
+
+// codeLetterTable is ISO 2859-1 Table 1: lot size upper bound -> code letter index per inspection level
+var codeLetterTable = []struct {
+	maxLot  int
+	letters [7]int // S-1, S-2, S-3, S-4, I, II, III
+}{
+	{8, [7]int{0, 0, 0, 0, 0, 0, 1}},
+	{15, [7]int{0, 0, 0, 0, 0, 1, 2}},
+	{25, [7]int{0, 0, 1, 1, 1, 2, 3}},
+	{50, [7]int{0, 1, 1, 2, 2, 3, 4}},
+	{90, [7]int{1, 1, 2, 2, 2, 4, 5}},
+	{150, [7]int{1, 1, 2, 3, 3, 5, 6}},
+	{280, [7]int{1, 2, 3, 4, 4, 6, 7}},
+	{500, [7]int{1, 2, 3, 4, 5, 7, 8}},
+	{1200, [7]int{2, 2, 4, 5, 6, 8, 9}},
+	{3200, [7]int{2, 3, 4, 6, 7, 9, 10}},
+	{10000, [7]int{2, 3, 5, 6, 8, 10, 11}},
+	{35000, [7]int{2, 3, 5, 7, 9, 11, 12}},
+	{150000, [7]int{3, 4, 6, 8, 10, 12, 13}},
+	{500000, [7]int{3, 4, 6, 8, 11, 13, 14}},
+	{math.MaxInt32, [7]int{3, 4, 7, 9, 12, 14, 15}},
+}
+
+// Acceptance numbers along a diagonal of the master tables, starting at the
+// first Ac=0 plan. arrowUp/arrowDown mean "use the first plan above/below".
+const (
+	arrowUp   = -1
+	arrowDown = -2
+)
+
+var (
+	normalAcceptance    = []int{0, arrowUp, arrowDown, 1, 2, 3, 5, 7, 10, 14, 21}
+	tightenedAcceptance = []int{0, arrowDown, 1, 2, 3, 5, 8, 12, 18}
+)
+
+// AQLSamplingPlan is an ISO 2859-1 single sampling plan attached to QC checkpoints
+type AQLSamplingPlan struct {
+	ID              uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PlanCode        string              `json:"plan_code" gorm:"type:varchar(30);unique;not null"`
+	Name            string              `json:"name" gorm:"type:varchar(100);not null"`
+	InspectionLevel InspectionLevel     `json:"inspection_level" gorm:"type:varchar(5);not null;default:'II'"`
+	AQL             float64             `json:"aql" gorm:"type:decimal(6,3);not null"`
+	SubjectType     SamplingSubjectType `json:"subject_type" gorm:"type:varchar(20);not null"` // History used for switching
+	IsActive        bool                `json:"is_active" gorm:"default:true"`
+	Notes           string              `json:"notes" gorm:"type:text"`
+	CreatedBy       *uuid.UUID          `json:"created_by" gorm:"type:uuid"`
+	CreatedAt       time.Time           `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time           `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (AQLSamplingPlan) TableName() string {
+	return "aql_sampling_plans"
+}
+
+// Validate checks the plan against the supported tables
+func (p *AQLSamplingPlan) Validate() error {
+	if !p.InspectionLevel.IsValid() || aqlIndex(p.AQL) < 0 {
+		return ErrInvalidSamplingPlan
+	}
+	switch p.SubjectType {
+	case SamplingSubjectSupplier, SamplingSubjectProduct, SamplingSubjectMaterial:
+		return nil
+	}
+	return ErrInvalidSamplingPlan
+}
+
+// SubjectFor returns the history subject of an inspection for this plan
+func (p *AQLSamplingPlan) SubjectFor(q *QCInspection) (uuid.UUID, bool) {
+	var id *uuid.UUID
+	switch p.SubjectType {
+	case SamplingSubjectSupplier:
+		id = q.SupplierID
+	case SamplingSubjectProduct:
+		id = q.ProductID
+	case SamplingSubjectMaterial:
+		id = q.MaterialID
+	}
+	if id == nil {
+		return uuid.Nil, false
+	}
+	return *id, true
+}
+
+// SamplePlan is the sample size and accept/reject numbers for one lot
+type SamplePlan struct {
+	LotQuantity     int                `json:"lot_quantity"`
+	InspectionLevel InspectionLevel    `json:"inspection_level"`
+	AQL             float64            `json:"aql"`
+	Severity        InspectionSeverity `json:"severity"`
+	CodeLetter      string             `json:"code_letter"`
+	SampleSize      int                `json:"sample_size"`
+	AcceptNumber    int                `json:"accept_number"`
+	RejectNumber    int                `json:"reject_number"`
+	FullInspection  bool               `json:"full_inspection"` // Sample size reaches the lot size
+
+	// Acceptance number one AQL step tighter, used for the switching score (-1 if none)
+	TighterAcceptNumber int `json:"-"`
+}
+
+// Accepts returns true if the lot is accepted with the given number of nonconforming units
+func (p *SamplePlan) Accepts(defects int) bool {
+	return defects <= p.AcceptNumber
+}
+
+// DetermineSamplePlan looks up the sample size code letter for the lot size and
+// level, then the sample size and Ac/Re for the AQL and severity. Reduced
+// inspection uses the reduced sample size with the normal Ac/Re for that size.
+func DetermineSamplePlan(lotQuantity int, level InspectionLevel, aql float64, severity InspectionSeverity) (*SamplePlan, error) {
+	column, ok := inspectionLevelColumns[level]
+	idx := aqlIndex(aql)
+	if !ok || idx < 0 || lotQuantity <= 0 {
+		return nil, ErrInvalidSamplingPlan
+	}
+
+	letter := 0
+	for _, row := range codeLetterTable {
+		if lotQuantity <= row.maxLot {
+			letter = row.letters[column]
+			break
+		}
+	}
+
+	plan := &SamplePlan{
+		LotQuantity:     lotQuantity,
+		InspectionLevel: level,
+		AQL:             aql,
+		Severity:        severity,
+		CodeLetter:      sampleCodeLetters[letter],
+	}
+
+	var row, ac int
+	switch severity {
+	case InspectionSeverityTightened:
+		row, ac = resolveAcceptance(tightenedAcceptance, 16-idx, letter)
+		plan.TighterAcceptNumber = -1
+	case InspectionSeverityReduced:
+		row = letter - 2
+		if row < 0 {
+			row = 0
+		}
+		row, ac = resolveAcceptance(normalAcceptance, 14-idx, row)
+		plan.TighterAcceptNumber = -1
+	default:
+		row, ac = resolveAcceptance(normalAcceptance, 14-idx, letter)
+		plan.TighterAcceptNumber = -1
+		if idx > 0 {
+			_, plan.TighterAcceptNumber = resolveAcceptance(normalAcceptance, 15-idx, row)
+		}
+	}
+
+	plan.SampleSize = sampleSizes[row]
+	plan.AcceptNumber = ac
+	plan.RejectNumber = ac + 1
+	if plan.SampleSize >= lotQuantity {
+		plan.SampleSize = lotQuantity
+		plan.FullInspection = true
+	}
+	return plan, nil
+}
+
+// resolveAcceptance follows the arrows of a master table diagonal starting at
+// the given sample size row. firstRow is the row holding the Ac=0 plan.
+func resolveAcceptance(sequence []int, firstRow, row int) (int, int) {
+	last := len(sampleSizes) - 1
+	if firstRow > last {
+		firstRow = last
+	}
+	if row < firstRow {
+		row = firstRow // Arrow down to the first plan
+	}
+	movingDown := false
+	for {
+		k := row - firstRow
+		entry := arrowUp
+		if k < len(sequence) {
+			entry = sequence[k]
+		}
+		switch {
+		case entry >= 0:
+			return row, entry
+		case entry == arrowDown || movingDown || row == 0:
+			if row == last {
+				return row, sequence[0]
+			}
+			movingDown = true
+			row++
+		default:
+			row--
+		}
+	}
+}
+
+func aqlIndex(aql float64) int {
+	for i, v := range StandardAQLs {
+		if math.Abs(v-aql) < 1e-9 {
+			return i
+		}
+	}
+	return -1
+}
+
+// SamplingState tracks normal/tightened/reduced switching for one plan and subject
+type SamplingState struct {
+	ID                  uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SamplingPlanID      uuid.UUID           `json:"sampling_plan_id" gorm:"type:uuid;not null"`
+	SubjectType         SamplingSubjectType `json:"subject_type" gorm:"type:varchar(20);not null"`
+	SubjectID           uuid.UUID           `json:"subject_id" gorm:"type:uuid;not null"`
+	Severity            InspectionSeverity  `json:"severity" gorm:"type:varchar(20);not null;default:'NORMAL'"`
+	RecentResults       string              `json:"recent_results" gorm:"type:varchar(10)"` // A/R of the last lots, newest last
+	ConsecutiveAccepted int                 `json:"consecutive_accepted" gorm:"default:0"`
+	SwitchingScore      int                 `json:"switching_score" gorm:"default:0"`
+	TightenedRejections int                 `json:"tightened_rejections" gorm:"default:0"`
+	LotsInspected       int                 `json:"lots_inspected" gorm:"default:0"`
+	LastSwitchedAt      *time.Time          `json:"last_switched_at"`
+	CreatedAt           time.Time           `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt           time.Time           `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (SamplingState) TableName() string {
+	return "sampling_states"
+}
+
+// NewSamplingState starts a subject on normal inspection
+func NewSamplingState(planID uuid.UUID, subjectType SamplingSubjectType, subjectID uuid.UUID) *SamplingState {
+	return &SamplingState{
+		SamplingPlanID: planID,
+		SubjectType:    subjectType,
+		SubjectID:      subjectID,
+		Severity:       InspectionSeverityNormal,
+	}
+}
+
+// RecordLot applies the ISO 2859-1 switching rules after a lot decision and
+// returns true if the severity changed
+func (s *SamplingState) RecordLot(plan *SamplePlan, defects int, accepted bool) bool {
+	s.LotsInspected++
+	result := "R"
+	if accepted {
+		result = "A"
+	}
+	s.RecentResults += result
+	if len(s.RecentResults) > samplingHistoryWindow {
+		s.RecentResults = s.RecentResults[len(s.RecentResults)-samplingHistoryWindow:]
+	}
+	if accepted {
+		s.ConsecutiveAccepted++
+	} else {
+		s.ConsecutiveAccepted = 0
+	}
+
+	switch s.Severity {
+	case InspectionSeverityNormal:
+		rejected := 0
+		for _, r := range s.RecentResults {
+			if r == 'R' {
+				rejected++
+			}
+		}
+		if rejected >= samplingTightenRejections {
+			return s.switchTo(InspectionSeverityTightened)
+		}
+
+		// Switching score: Ac >= 2 needs acceptance at one AQL step tighter
+		switch {
+		case plan.AcceptNumber >= 2 && plan.TighterAcceptNumber >= 0 && defects <= plan.TighterAcceptNumber:
+			s.SwitchingScore += 3
+		case plan.AcceptNumber < 2 && accepted:
+			s.SwitchingScore += 2
+		default:
+			s.SwitchingScore = 0
+		}
+		if s.SwitchingScore >= samplingReducedScore {
+			return s.switchTo(InspectionSeverityReduced)
+		}
+
+	case InspectionSeverityTightened:
+		if !accepted {
+			s.TightenedRejections++
+			if s.TightenedRejections >= samplingDiscontinueRejects {
+				return s.switchTo(InspectionSeverityDiscontinued)
+			}
+		}
+		if s.ConsecutiveAccepted >= samplingRelaxAcceptances {
+			return s.switchTo(InspectionSeverityNormal)
+		}
+
+	case InspectionSeverityReduced:
+		if !accepted {
+			return s.switchTo(InspectionSeverityNormal)
+		}
+	}
+	return false
+}
+
+// Resume restarts a discontinued subject on tightened inspection after corrective action
+func (s *SamplingState) Resume() error {
+	if s.Severity != InspectionSeverityDiscontinued {
+		return ErrSamplingNotDiscontinued
+	}
+	s.switchTo(InspectionSeverityTightened)
+	return nil
+}
+
+func (s *SamplingState) switchTo(severity InspectionSeverity) bool {
+	s.Severity = severity
+	s.RecentResults = ""
+	s.ConsecutiveAccepted = 0
+	s.SwitchingScore = 0
+	s.TightenedRejections = 0
+	now := time.Now()
+	s.LastSwitchedAt = &now
+	return true
+}
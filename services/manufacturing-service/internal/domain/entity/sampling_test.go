@@ -0,0 +1,162 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetermineSamplePlan_StandardTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		lot        int
+		level      entity.InspectionLevel
+		aql        float64
+		severity   entity.InspectionSeverity
+		codeLetter string
+		sampleSize int
+		ac         int
+	}{
+		{"normal L 2.5", 5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityNormal, "L", 200, 10},
+		{"normal J 1.0", 1000, entity.InspectionLevelII, 1.0, entity.InspectionSeverityNormal, "J", 80, 2},
+		{"tightened L 1.0", 5000, entity.InspectionLevelII, 1.0, entity.InspectionSeverityTightened, "L", 200, 3},
+		{"reduced L 2.5", 5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityReduced, "L", 80, 5},
+		{"special level S-4", 5000, entity.InspectionLevelS4, 4.0, entity.InspectionSeverityNormal, "G", 32, 3},
+		{"arrow down to first plan", 100, entity.InspectionLevelII, 0.65, entity.InspectionSeverityNormal, "F", 20, 0},
+		{"arrow up to previous plan", 100, entity.InspectionLevelI, 2.5, entity.InspectionSeverityNormal, "D", 5, 0},
+		{"arrow up F 1.0", 100, entity.InspectionLevelII, 1.0, entity.InspectionSeverityNormal, "F", 13, 0},
+		{"arrow down G 1.0", 200, entity.InspectionLevelII, 1.0, entity.InspectionSeverityNormal, "G", 50, 1},
+		{"first Ac 1 plan H 1.0", 400, entity.InspectionLevelII, 1.0, entity.InspectionSeverityNormal, "H", 50, 1},
+		{"arrow up H 0.40", 400, entity.InspectionLevelII, 0.40, entity.InspectionSeverityNormal, "H", 32, 0},
+		{"arrow down J 0.40", 1000, entity.InspectionLevelII, 0.40, entity.InspectionSeverityNormal, "J", 125, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			plan, err := entity.DetermineSamplePlan(tt.lot, tt.level, tt.aql, tt.severity)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.codeLetter, plan.CodeLetter)
+			assert.Equal(t, tt.sampleSize, plan.SampleSize)
+			assert.Equal(t, tt.ac, plan.AcceptNumber)
+			assert.Equal(t, tt.ac+1, plan.RejectNumber)
+			assert.False(t, plan.FullInspection)
+		})
+	}
+}
+
+func TestDetermineSamplePlan_SmallLotIsFullyInspected(t *testing.T) {
+	// Act
+	plan, err := entity.DetermineSamplePlan(10, entity.InspectionLevelII, 0.65, entity.InspectionSeverityNormal)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, plan.FullInspection)
+	assert.Equal(t, 10, plan.SampleSize)
+	assert.Equal(t, 0, plan.AcceptNumber)
+}
+
+func TestDetermineSamplePlan_RejectsNonStandardAQL(t *testing.T) {
+	// Act
+	plan, err := entity.DetermineSamplePlan(1000, entity.InspectionLevelII, 2.0, entity.InspectionSeverityNormal)
+
+	// Assert
+	assert.Nil(t, plan)
+	assert.Equal(t, entity.ErrInvalidSamplingPlan, err)
+}
+
+func TestSamplingState_SwitchesToTightenedAndBack(t *testing.T) {
+	// Arrange
+	state := entity.NewSamplingState(uuid.New(), entity.SamplingSubjectSupplier, uuid.New())
+	normal, _ := entity.DetermineSamplePlan(5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityNormal)
+	tightened, _ := entity.DetermineSamplePlan(5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityTightened)
+
+	// Act & Assert: 2 of 5 consecutive lots rejected
+	assert.False(t, state.RecordLot(normal, 12, false))
+	assert.False(t, state.RecordLot(normal, 1, true))
+	assert.True(t, state.RecordLot(normal, 15, false))
+	assert.Equal(t, entity.InspectionSeverityTightened, state.Severity)
+
+	// 5 consecutive lots accepted on tightened inspection
+	for i := 0; i < 4; i++ {
+		assert.False(t, state.RecordLot(tightened, 0, true))
+	}
+	assert.True(t, state.RecordLot(tightened, 0, true))
+	assert.Equal(t, entity.InspectionSeverityNormal, state.Severity)
+	assert.NotNil(t, state.LastSwitchedAt)
+}
+
+func TestSamplingState_DiscontinuesAfterTightenedRejections(t *testing.T) {
+	// Arrange
+	state := &entity.SamplingState{Severity: entity.InspectionSeverityTightened}
+	tightened, _ := entity.DetermineSamplePlan(5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityTightened)
+
+	// Act
+	for i := 0; i < 5; i++ {
+		state.RecordLot(tightened, 20, false)
+	}
+
+	// Assert
+	assert.Equal(t, entity.InspectionSeverityDiscontinued, state.Severity)
+	assert.NoError(t, state.Resume())
+	assert.Equal(t, entity.InspectionSeverityTightened, state.Severity)
+	assert.Equal(t, entity.ErrSamplingNotDiscontinued, state.Resume())
+}
+
+func TestSamplingState_SwitchingScoreLeadsToReduced(t *testing.T) {
+	// Arrange
+	state := entity.NewSamplingState(uuid.New(), entity.SamplingSubjectProduct, uuid.New())
+	normal, _ := entity.DetermineSamplePlan(5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityNormal)
+	reduced, _ := entity.DetermineSamplePlan(5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityReduced)
+
+	// Act: Ac >= 2, so each lot within the tighter AQL's Ac adds 3
+	for i := 0; i < 9; i++ {
+		state.RecordLot(normal, 0, true)
+	}
+	assert.Equal(t, entity.InspectionSeverityNormal, state.Severity)
+	state.RecordLot(normal, 0, true)
+
+	// Assert
+	assert.Equal(t, entity.InspectionSeverityReduced, state.Severity)
+	assert.True(t, state.RecordLot(reduced, 6, false))
+	assert.Equal(t, entity.InspectionSeverityNormal, state.Severity)
+}
+
+func TestSamplingState_SwitchingScoreResetsAboveTighterAc(t *testing.T) {
+	// Arrange
+	state := entity.NewSamplingState(uuid.New(), entity.SamplingSubjectProduct, uuid.New())
+	normal, _ := entity.DetermineSamplePlan(5000, entity.InspectionLevelII, 2.5, entity.InspectionSeverityNormal)
+
+	// Act: accepted, but above Ac of AQL 1.5
+	state.RecordLot(normal, 0, true)
+	state.RecordLot(normal, 9, true)
+
+	// Assert
+	assert.Equal(t, 0, state.SwitchingScore)
+}
+
+func TestQCInspection_SamplingDefectsDecideResult(t *testing.T) {
+	// Arrange
+	plan, _ := entity.DetermineSamplePlan(1000, entity.InspectionLevelII, 1.0, entity.InspectionSeverityNormal)
+	passed := &entity.QCInspection{EvaluatedResult: entity.InspectionResultPending}
+	passed.ApplySamplePlan(uuid.New(), plan)
+	failed := &entity.QCInspection{EvaluatedResult: entity.InspectionResultPending}
+	failed.ApplySamplePlan(uuid.New(), plan)
+	two, three := 2, 3
+	passed.DefectCount = &two
+	failed.DefectCount = &three
+
+	// Act
+	passed.CalculateScore()
+	failed.CalculateScore()
+
+	// Assert
+	assert.Equal(t, 80, *passed.SampleSize)
+	assert.Equal(t, entity.InspectionResultPassed, passed.EvaluatedResult)
+	assert.True(t, failed.SamplingRejected())
+	assert.Equal(t, entity.InspectionResultFailed, failed.EvaluatedResult)
+}
@@ -0,0 +1,378 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SPCChartType represents the statistical process control chart type
+type SPCChartType string
+
+const (
+	SPCChartTypeXbarR       SPCChartType = "XBAR_R"      // Subgroup averages and ranges
+	SPCChartTypeIndividuals SPCChartType = "INDIVIDUALS" // Individual values and moving ranges (I-MR)
+)
+
+// IsValid checks if the chart type is valid
+func (t SPCChartType) IsValid() bool {
+	return t == SPCChartTypeXbarR || t == SPCChartTypeIndividuals
+}
+
+// WesternElectricRule identifies a Western Electric run rule
+type WesternElectricRule string
+
+const (
+	WERule1 WesternElectricRule = "RULE_1" // One point beyond 3 sigma
+	WERule2 WesternElectricRule = "RULE_2" // Two of three consecutive points beyond 2 sigma on the same side
+	WERule3 WesternElectricRule = "RULE_3" // Four of five consecutive points beyond 1 sigma on the same side
+	WERule4 WesternElectricRule = "RULE_4" // Eight consecutive points on the same side of the center line
+)
+
+// Description returns a human readable explanation of the rule
+func (r WesternElectricRule) Description() string {
+	switch r {
+	case WERule1:
+		return "One point beyond the 3-sigma control limits"
+	case WERule2:
+		return "Two of three consecutive points beyond 2 sigma on the same side"
+	case WERule3:
+		return "Four of five consecutive points beyond 1 sigma on the same side"
+	case WERule4:
+		return "Eight consecutive points on the same side of the center line"
+	}
+	return string(r)
+}
+
+// Subgroup sizes supported by the X-bar/R chart constants
+const (
+	SPCMinSubgroupSize     = 2
+	SPCMaxSubgroupSize     = 10
+	SPCDefaultSubgroupSize = 5
+)
+
+// xbarRConstants holds the A2, D3, D4 and d2 factors for a subgroup size
+type xbarRConstants struct {
+	A2, D3, D4, D2 float64
+}
+
+var xbarRTable = map[int]xbarRConstants{
+	2:  {A2: 1.880, D3: 0, D4: 3.267, D2: 1.128},
+	3:  {A2: 1.023, D3: 0, D4: 2.574, D2: 1.693},
+	4:  {A2: 0.729, D3: 0, D4: 2.282, D2: 2.059},
+	5:  {A2: 0.577, D3: 0, D4: 2.114, D2: 2.326},
+	6:  {A2: 0.483, D3: 0, D4: 2.004, D2: 2.534},
+	7:  {A2: 0.419, D3: 0.076, D4: 1.924, D2: 2.704},
+	8:  {A2: 0.373, D3: 0.136, D4: 1.864, D2: 2.847},
+	9:  {A2: 0.337, D3: 0.184, D4: 1.816, D2: 2.970},
+	10: {A2: 0.308, D3: 0.223, D4: 1.777, D2: 3.078},
+}
+
+// SPCObservation is one numeric QC reading of a test item for a product
+type SPCObservation struct {
+	InspectionID     uuid.UUID `json:"inspection_id"`
+	InspectionNumber string    `json:"inspection_number"`
+	InspectionDate   time.Time `json:"inspection_date"`
+	LotNumber        string    `json:"lot_number"`
+	Value            float64   `json:"value"`
+	MinLimit         *float64  `json:"min_limit"`
+	MaxLimit         *float64  `json:"max_limit"`
+}
+
+// ControlLimits holds the center line and 3-sigma limits of a chart
+type ControlLimits struct {
+	CenterLine float64 `json:"center_line"`
+	UCL        float64 `json:"ucl"`
+	LCL        float64 `json:"lcl"`
+}
+
+// Sigma returns the one-sigma zone width implied by the limits
+func (l ControlLimits) Sigma() float64 {
+	return (l.UCL - l.CenterLine) / 3
+}
+
+// SPCPoint is a plotted point: a subgroup for X-bar/R, a single reading for I-MR
+type SPCPoint struct {
+	Index            int                   `json:"index"`
+	InspectionID     *uuid.UUID            `json:"inspection_id,omitempty"` // Individuals chart only
+	InspectionNumber string                `json:"inspection_number,omitempty"`
+	LotNumber        string                `json:"lot_number,omitempty"`
+	Date             time.Time             `json:"date"`
+	Value            float64               `json:"value"`           // Subgroup mean or individual value
+	Range            *float64              `json:"range,omitempty"` // Subgroup range or moving range
+	Violations       []WesternElectricRule `json:"violations,omitempty"`
+}
+
+// SPCViolation is a Western Electric rule triggered at a chart point
+type SPCViolation struct {
+	Rule        WesternElectricRule `json:"rule"`
+	PointIndex  int                 `json:"point_index"`
+	Value       float64             `json:"value"`
+	Description string              `json:"description"`
+}
+
+// ProcessCapability holds capability indices against the specification limits
+type ProcessCapability struct {
+	LSL          *float64 `json:"lsl"`
+	USL          *float64 `json:"usl"`
+	Mean         float64  `json:"mean"`
+	SigmaWithin  float64  `json:"sigma_within"`  // Estimated from R-bar/d2 or MR-bar/d2
+	SigmaOverall float64  `json:"sigma_overall"` // Sample standard deviation
+	Cp           *float64 `json:"cp"`
+	Cpk          *float64 `json:"cpk"`
+	Pp           *float64 `json:"pp"`
+	Ppk          *float64 `json:"ppk"`
+}
+
+// SPCChart is a computed control chart for one product and test item
+type SPCChart struct {
+	ChartType    SPCChartType      `json:"chart_type"`
+	SubgroupSize int               `json:"subgroup_size"`
+	SampleCount  int               `json:"sample_count"`
+	Limits       ControlLimits     `json:"limits"`       // X-bar or individuals chart
+	RangeLimits  ControlLimits     `json:"range_limits"` // R or moving range chart
+	Points       []SPCPoint        `json:"points"`
+	Violations   []SPCViolation    `json:"violations"`
+	Capability   ProcessCapability `json:"capability"`
+
+	values []float64
+}
+
+// BuildXbarRChart groups consecutive readings into subgroups of size n and
+// computes X-bar/R control limits. An incomplete trailing subgroup is ignored.
+func BuildXbarRChart(observations []SPCObservation, n int) (*SPCChart, error) {
+	constants, ok := xbarRTable[n]
+	if !ok {
+		return nil, ErrInvalidSubgroupSize
+	}
+	subgroups := len(observations) / n
+	if subgroups < 2 {
+		return nil, ErrInsufficientSPCData
+	}
+
+	chart := &SPCChart{ChartType: SPCChartTypeXbarR, SubgroupSize: n}
+	var sumMean, sumRange float64
+	for g := 0; g < subgroups; g++ {
+		group := observations[g*n : (g+1)*n]
+		low, high := group[0].Value, group[0].Value
+		var sum float64
+		for _, o := range group {
+			sum += o.Value
+			low = math.Min(low, o.Value)
+			high = math.Max(high, o.Value)
+			chart.values = append(chart.values, o.Value)
+		}
+		mean := sum / float64(n)
+		r := high - low
+		last := group[n-1]
+		chart.Points = append(chart.Points, SPCPoint{
+			Index:            g,
+			InspectionNumber: fmt.Sprintf("%s..%s", group[0].InspectionNumber, last.InspectionNumber),
+			Date:             last.InspectionDate,
+			Value:            mean,
+			Range:            &r,
+		})
+		sumMean += mean
+		sumRange += r
+	}
+
+	xbar := sumMean / float64(subgroups)
+	rbar := sumRange / float64(subgroups)
+	chart.SampleCount = len(chart.values)
+	chart.Limits = ControlLimits{
+		CenterLine: xbar,
+		UCL:        xbar + constants.A2*rbar,
+		LCL:        xbar - constants.A2*rbar,
+	}
+	chart.RangeLimits = ControlLimits{
+		CenterLine: rbar,
+		UCL:        constants.D4 * rbar,
+		LCL:        constants.D3 * rbar,
+	}
+	chart.Capability.SigmaWithin = rbar / constants.D2
+	chart.detectViolations()
+	return chart, nil
+}
+
+// BuildIndividualsChart computes individuals and moving range (I-MR) limits
+func BuildIndividualsChart(observations []SPCObservation) (*SPCChart, error) {
+	if len(observations) < 2 {
+		return nil, ErrInsufficientSPCData
+	}
+
+	const (
+		e2 = 2.660 // 3 / d2 for a moving range of two
+		d4 = 3.267
+		d2 = 1.128
+	)
+
+	chart := &SPCChart{ChartType: SPCChartTypeIndividuals, SubgroupSize: 1}
+	var sum, sumMR float64
+	for i, o := range observations {
+		inspectionID := o.InspectionID
+		point := SPCPoint{
+			Index:            i,
+			InspectionID:     &inspectionID,
+			InspectionNumber: o.InspectionNumber,
+			LotNumber:        o.LotNumber,
+			Date:             o.InspectionDate,
+			Value:            o.Value,
+		}
+		if i > 0 {
+			mr := math.Abs(o.Value - observations[i-1].Value)
+			point.Range = &mr
+			sumMR += mr
+		}
+		sum += o.Value
+		chart.values = append(chart.values, o.Value)
+		chart.Points = append(chart.Points, point)
+	}
+
+	mean := sum / float64(len(observations))
+	mrbar := sumMR / float64(len(observations)-1)
+	chart.SampleCount = len(observations)
+	chart.Limits = ControlLimits{
+		CenterLine: mean,
+		UCL:        mean + e2*mrbar,
+		LCL:        mean - e2*mrbar,
+	}
+	chart.RangeLimits = ControlLimits{CenterLine: mrbar, UCL: d4 * mrbar}
+	chart.Capability.SigmaWithin = mrbar / d2
+	chart.detectViolations()
+	return chart, nil
+}
+
+// detectViolations applies the Western Electric rules to the plotted points
+func (c *SPCChart) detectViolations() {
+	values := make([]float64, len(c.Points))
+	for i, p := range c.Points {
+		values[i] = p.Value
+	}
+	c.Violations = DetectWesternElectric(values, c.Limits)
+	for _, v := range c.Violations {
+		c.Points[v.PointIndex].Violations = append(c.Points[v.PointIndex].Violations, v.Rule)
+	}
+}
+
+// DetectWesternElectric evaluates Western Electric rules 1-4. A violation is
+// reported at the point that completes the pattern, and only when that point
+// itself lies in the offending zone.
+func DetectWesternElectric(values []float64, limits ControlLimits) []SPCViolation {
+	sigma := limits.Sigma()
+	if sigma <= 0 {
+		return nil
+	}
+	cl := limits.CenterLine
+
+	// side returns +1 / -1 when the value is beyond k sigma above / below the center line
+	side := func(v, k float64) int {
+		switch {
+		case v > cl+k*sigma:
+			return 1
+		case v < cl-k*sigma:
+			return -1
+		}
+		return 0
+	}
+	// countBeyond counts points in the window beyond k sigma on the given side
+	countBeyond := func(window []float64, k float64, dir int) int {
+		count := 0
+		for _, w := range window {
+			if side(w, k) == dir {
+				count++
+			}
+		}
+		return count
+	}
+
+	var violations []SPCViolation
+	add := func(rule WesternElectricRule, i int) {
+		violations = append(violations, SPCViolation{
+			Rule:        rule,
+			PointIndex:  i,
+			Value:       values[i],
+			Description: rule.Description(),
+		})
+	}
+
+	for i, v := range values {
+		if side(v, 3) != 0 {
+			add(WERule1, i)
+		}
+		if dir := side(v, 2); dir != 0 && i >= 2 && countBeyond(values[i-2:i+1], 2, dir) >= 2 {
+			add(WERule2, i)
+		}
+		if dir := side(v, 1); dir != 0 && i >= 4 && countBeyond(values[i-4:i+1], 1, dir) >= 4 {
+			add(WERule3, i)
+		}
+		if dir := side(v, 0); dir != 0 && i >= 7 && countBeyond(values[i-7:i+1], 0, dir) == 8 {
+			add(WERule4, i)
+		}
+	}
+	return violations
+}
+
+// ViolationsAt returns the violations raised at the given point
+func (c *SPCChart) ViolationsAt(index int) []SPCViolation {
+	var result []SPCViolation
+	for _, v := range c.Violations {
+		if v.PointIndex == index {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// EvaluateCapability computes Cp/Cpk (within sigma) and Pp/Ppk (overall sigma).
+// Cp and Pp need both limits; Cpk and Ppk are computed from whichever limits are set.
+func (c *SPCChart) EvaluateCapability(lsl, usl *float64) {
+	capability := ProcessCapability{
+		LSL:         lsl,
+		USL:         usl,
+		SigmaWithin: c.Capability.SigmaWithin,
+	}
+
+	n := float64(len(c.values))
+	var sum float64
+	for _, v := range c.values {
+		sum += v
+	}
+	capability.Mean = sum / n
+
+	var sq float64
+	for _, v := range c.values {
+		sq += (v - capability.Mean) * (v - capability.Mean)
+	}
+	if n > 1 {
+		capability.SigmaOverall = math.Sqrt(sq / (n - 1))
+	}
+
+	capability.Cp, capability.Cpk = capabilityIndices(capability.Mean, capability.SigmaWithin, lsl, usl)
+	capability.Pp, capability.Ppk = capabilityIndices(capability.Mean, capability.SigmaOverall, lsl, usl)
+	c.Capability = capability
+}
+
+// capabilityIndices returns the spread and centered indices for a sigma estimate
+func capabilityIndices(mean, sigma float64, lsl, usl *float64) (*float64, *float64) {
+	if sigma <= 0 || (lsl == nil && usl == nil) {
+		return nil, nil
+	}
+
+	var spread, centered *float64
+	if lsl != nil && usl != nil {
+		cp := (*usl - *lsl) / (6 * sigma)
+		spread = &cp
+	}
+
+	k := math.Inf(1)
+	if usl != nil {
+		k = math.Min(k, (*usl-mean)/(3*sigma))
+	}
+	if lsl != nil {
+		k = math.Min(k, (mean-*lsl)/(3*sigma))
+	}
+	centered = &k
+	return spread, centered
+}
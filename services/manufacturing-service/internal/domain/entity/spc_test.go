@@ -0,0 +1,145 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func observations(values ...float64) []entity.SPCObservation {
+	var obs []entity.SPCObservation
+	for _, v := range values {
+		obs = append(obs, entity.SPCObservation{Value: v})
+	}
+	return obs
+}
+
+func TestBuildIndividualsChart(t *testing.T) {
+	// Arrange
+	obs := observations(10, 12, 11, 13, 12)
+
+	// Act
+	chart, err := entity.BuildIndividualsChart(obs)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SPCChartTypeIndividuals, chart.ChartType)
+	assert.Equal(t, 5, chart.SampleCount)
+	assert.InDelta(t, 11.6, chart.Limits.CenterLine, 1e-9)
+	assert.InDelta(t, 11.6+2.66*1.5, chart.Limits.UCL, 1e-9)
+	assert.InDelta(t, 11.6-2.66*1.5, chart.Limits.LCL, 1e-9)
+	assert.InDelta(t, 1.5, chart.RangeLimits.CenterLine, 1e-9)
+	assert.InDelta(t, 3.267*1.5, chart.RangeLimits.UCL, 1e-9)
+	assert.Nil(t, chart.Points[0].Range)
+	assert.InDelta(t, 2.0, *chart.Points[1].Range, 1e-9)
+	assert.Empty(t, chart.Violations)
+}
+
+func TestBuildXbarRChart(t *testing.T) {
+	t.Run("Computes subgroup means and ranges", func(t *testing.T) {
+		// Arrange - trailing reading does not complete a subgroup
+		obs := observations(1, 3, 2, 4, 100)
+
+		// Act
+		chart, err := entity.BuildXbarRChart(obs, 2)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, chart.Points, 2)
+		assert.Equal(t, 4, chart.SampleCount)
+		assert.InDelta(t, 2.5, chart.Limits.CenterLine, 1e-9)
+		assert.InDelta(t, 2.5+1.880*2, chart.Limits.UCL, 1e-9)
+		assert.InDelta(t, 2.0, chart.RangeLimits.CenterLine, 1e-9)
+		assert.InDelta(t, 3.267*2, chart.RangeLimits.UCL, 1e-9)
+		assert.InDelta(t, 0.0, chart.RangeLimits.LCL, 1e-9)
+	})
+
+	t.Run("Rejects unsupported subgroup size", func(t *testing.T) {
+		_, err := entity.BuildXbarRChart(observations(1, 2, 3), 11)
+		assert.Equal(t, entity.ErrInvalidSubgroupSize, err)
+	})
+
+	t.Run("Needs at least two subgroups", func(t *testing.T) {
+		_, err := entity.BuildXbarRChart(observations(1, 2, 3, 4, 5), 5)
+		assert.Equal(t, entity.ErrInsufficientSPCData, err)
+	})
+}
+
+func TestDetectWesternElectric(t *testing.T) {
+	limits := entity.ControlLimits{CenterLine: 0, UCL: 3, LCL: -3}
+
+	rulesAt := func(violations []entity.SPCViolation) map[int][]entity.WesternElectricRule {
+		result := make(map[int][]entity.WesternElectricRule)
+		for _, v := range violations {
+			result[v.PointIndex] = append(result[v.PointIndex], v.Rule)
+		}
+		return result
+	}
+
+	t.Run("Rule 1 - point beyond 3 sigma", func(t *testing.T) {
+		got := rulesAt(entity.DetectWesternElectric([]float64{0.5, -3.5}, limits))
+		assert.Equal(t, map[int][]entity.WesternElectricRule{1: {entity.WERule1}}, got)
+	})
+
+	t.Run("Rule 2 - two of three beyond 2 sigma", func(t *testing.T) {
+		got := rulesAt(entity.DetectWesternElectric([]float64{2.5, 0, 2.5}, limits))
+		assert.Equal(t, map[int][]entity.WesternElectricRule{2: {entity.WERule2}}, got)
+	})
+
+	t.Run("Rule 2 - points on opposite sides do not count", func(t *testing.T) {
+		got := entity.DetectWesternElectric([]float64{-2.5, 0, 2.5}, limits)
+		assert.Empty(t, got)
+	})
+
+	t.Run("Rule 3 - four of five beyond 1 sigma", func(t *testing.T) {
+		got := rulesAt(entity.DetectWesternElectric([]float64{1.5, 1.5, 0, 1.5, 1.5}, limits))
+		assert.Equal(t, map[int][]entity.WesternElectricRule{4: {entity.WERule3}}, got)
+	})
+
+	t.Run("Rule 4 - eight on the same side", func(t *testing.T) {
+		got := rulesAt(entity.DetectWesternElectric([]float64{0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5, 0.5}, limits))
+		assert.Equal(t, map[int][]entity.WesternElectricRule{7: {entity.WERule4}}, got)
+	})
+
+	t.Run("No zones when the process has no variation", func(t *testing.T) {
+		flat := entity.ControlLimits{CenterLine: 5, UCL: 5, LCL: 5}
+		assert.Empty(t, entity.DetectWesternElectric([]float64{5, 5, 6}, flat))
+	})
+}
+
+func TestSPCChart_EvaluateCapability(t *testing.T) {
+	chart, err := entity.BuildIndividualsChart(observations(9, 10, 11, 10))
+	assert.NoError(t, err)
+	sigmaWithin := 1 / 1.128
+
+	t.Run("Two-sided specification", func(t *testing.T) {
+		// Act
+		chart.EvaluateCapability(floatPtr(4), floatPtr(16))
+
+		// Assert
+		c := chart.Capability
+		assert.InDelta(t, 10.0, c.Mean, 1e-9)
+		assert.InDelta(t, sigmaWithin, c.SigmaWithin, 1e-9)
+		assert.InDelta(t, 0.8164966, c.SigmaOverall, 1e-6)
+		assert.InDelta(t, 12/(6*sigmaWithin), *c.Cp, 1e-9)
+		assert.InDelta(t, 6/(3*sigmaWithin), *c.Cpk, 1e-9)
+		assert.InDelta(t, 12/(6*0.8164966), *c.Pp, 1e-6)
+		assert.InDelta(t, 6/(3*0.8164966), *c.Ppk, 1e-6)
+	})
+
+	t.Run("Upper limit only", func(t *testing.T) {
+		chart.EvaluateCapability(nil, floatPtr(12))
+
+		c := chart.Capability
+		assert.Nil(t, c.Cp)
+		assert.InDelta(t, 2/(3*sigmaWithin), *c.Cpk, 1e-9)
+	})
+
+	t.Run("No specification", func(t *testing.T) {
+		chart.EvaluateCapability(nil, nil)
+
+		assert.Nil(t, chart.Capability.Cp)
+		assert.Nil(t, chart.Capability.Cpk)
+	})
+}
@@ -0,0 +1,476 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StabilityStudyStatus represents stability study status
+type StabilityStudyStatus string
+
+const (
+	StabilityStudyStatusActive    StabilityStudyStatus = "ACTIVE"
+	StabilityStudyStatusCompleted StabilityStudyStatus = "COMPLETED"
+)
+
+// StabilityConditionType classifies a storage condition (ICH Q1A)
+type StabilityConditionType string
+
+const (
+	StabilityConditionLongTerm     StabilityConditionType = "LONG_TERM"    // e.g. 25°C/60%RH or 30°C/75%RH for zone IVb
+	StabilityConditionIntermediate StabilityConditionType = "INTERMEDIATE" // e.g. 30°C/65%RH
+	StabilityConditionAccelerated  StabilityConditionType = "ACCELERATED"  // e.g. 40°C/75%RH
+)
+
+// IsValid returns true for a known condition type
+func (t StabilityConditionType) IsValid() bool {
+	switch t {
+	case StabilityConditionLongTerm, StabilityConditionIntermediate, StabilityConditionAccelerated:
+		return true
+	}
+	return false
+}
+
+// StabilityPullStatus represents pull point status
+type StabilityPullStatus string
+
+const (
+	StabilityPullStatusScheduled StabilityPullStatus = "SCHEDULED"
+	StabilityPullStatusTested    StabilityPullStatus = "TESTED" // Results recorded on the inspection
+)
+
+// StabilityStudy follows one batch of a BOM version under storage conditions
+// to support the product shelf life
+type StabilityStudy struct {
+	ID                         uuid.UUID            `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	StudyNumber                string               `json:"study_number" gorm:"type:varchar(30);unique;not null"` // STB-YYYY-XXXX
+	ProductID                  uuid.UUID            `json:"product_id" gorm:"type:uuid;not null"`
+	BOMID                      uuid.UUID            `json:"bom_id" gorm:"type:uuid;not null"`
+	BOMVersion                 int                  `json:"bom_version" gorm:"not null"`
+	WorkOrderID                *uuid.UUID           `json:"work_order_id" gorm:"type:uuid"`
+	LotID                      *uuid.UUID           `json:"lot_id" gorm:"type:uuid"`
+	LotNumber                  string               `json:"lot_number" gorm:"type:varchar(50);not null"`
+	CheckpointID               uuid.UUID            `json:"checkpoint_id" gorm:"type:uuid;not null"`            // Tests and specs for every pull
+	SampleQuantity             float64              `json:"sample_quantity" gorm:"type:decimal(15,4);not null"` // Units pulled per pull point
+	StartDate                  time.Time            `json:"start_date" gorm:"type:date;not null"`
+	Status                     StabilityStudyStatus `json:"status" gorm:"type:varchar(20);default:'ACTIVE'"`
+	Purpose                    string               `json:"purpose" gorm:"type:text"`
+	RecommendedShelfLifeMonths *int                 `json:"recommended_shelf_life_months"`
+	Evaluation                 json.RawMessage      `json:"evaluation" gorm:"type:jsonb"` // StabilityEvaluation snapshot
+	EvaluatedAt                *time.Time           `json:"evaluated_at"`
+	CompletedAt                *time.Time           `json:"completed_at"`
+	CompletedBy                *uuid.UUID           `json:"completed_by" gorm:"type:uuid"`
+	Notes                      string               `json:"notes" gorm:"type:text"`
+	CreatedBy                  uuid.UUID            `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt                  time.Time            `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt                  time.Time            `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Conditions []StabilityCondition `json:"conditions,omitempty" gorm:"foreignKey:StudyID"`
+	PullPoints []StabilityPullPoint `json:"pull_points,omitempty" gorm:"foreignKey:StudyID"`
+}
+
+// TableName returns the table name
+func (StabilityStudy) TableName() string {
+	return "stability_studies"
+}
+
+// StabilityCondition is a storage condition of a study
+type StabilityCondition struct {
+	ID            uuid.UUID              `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	StudyID       uuid.UUID              `json:"study_id" gorm:"type:uuid;not null"`
+	Name          string                 `json:"name" gorm:"type:varchar(50);not null"` // e.g. 25°C/60%RH
+	ConditionType StabilityConditionType `json:"condition_type" gorm:"type:varchar(20);not null"`
+	TemperatureC  float64                `json:"temperature_c" gorm:"type:decimal(5,2);not null"`
+	HumidityRH    *float64               `json:"humidity_rh" gorm:"type:decimal(5,2)"`
+	CreatedAt     time.Time              `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (StabilityCondition) TableName() string {
+	return "stability_conditions"
+}
+
+// StabilityPullPoint is a scheduled test of the samples stored at one condition
+type StabilityPullPoint struct {
+	ID               uuid.UUID           `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	StudyID          uuid.UUID           `json:"study_id" gorm:"type:uuid;not null"`
+	ConditionID      uuid.UUID           `json:"condition_id" gorm:"type:uuid;not null"`
+	Month            int                 `json:"month" gorm:"not null"` // Months after start date; 0 = initial
+	ScheduledDate    time.Time           `json:"scheduled_date" gorm:"type:date;not null"`
+	Status           StabilityPullStatus `json:"status" gorm:"type:varchar(20);default:'SCHEDULED'"`
+	InspectionID     uuid.UUID           `json:"inspection_id" gorm:"type:uuid;not null"` // Scheduled QC inspection
+	InspectionNumber string              `json:"inspection_number" gorm:"type:varchar(30)"`
+	TestedAt         *time.Time          `json:"tested_at"`
+	TestedBy         *uuid.UUID          `json:"tested_by" gorm:"type:uuid"`
+	CreatedAt        time.Time           `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt        time.Time           `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (StabilityPullPoint) TableName() string {
+	return "stability_pull_points"
+}
+
+// StabilityReading is one test result of a tested pull point
+type StabilityReading struct {
+	ConditionID      uuid.UUID
+	Month            int
+	InspectionNumber string
+	TestName         string
+	Unit             string
+	Value            *float64 // Nil for pass/fail tests
+	MinLimit         *float64
+	MaxLimit         *float64
+	Failed           bool
+}
+
+// StabilityEvaluation is the trend analysis behind a shelf-life recommendation
+type StabilityEvaluation struct {
+	CoveredMonths              int              `json:"covered_months"`           // Latest long-term pull point tested
+	AcceleratedTested          bool             `json:"accelerated_tested"`       // Accelerated condition has results
+	AcceleratedChange          bool             `json:"accelerated_change"`       // Significant change (a failed result) at the accelerated condition
+	MaxExtrapolationMonths     int              `json:"max_extrapolation_months"` // Longest shelf life the data may support
+	RecommendedShelfLifeMonths *int             `json:"recommended_shelf_life_months"`
+	LimitingTest               string           `json:"limiting_test,omitempty"`
+	Trends                     []StabilityTrend `json:"trends"`
+}
+
+// StabilityTrend is the regression of one numeric test at one condition
+type StabilityTrend struct {
+	ConditionID      uuid.UUID              `json:"condition_id"`
+	ConditionName    string                 `json:"condition_name"`
+	ConditionType    StabilityConditionType `json:"condition_type"`
+	TestName         string                 `json:"test_name"`
+	Unit             string                 `json:"unit,omitempty"`
+	MinLimit         *float64               `json:"min_limit,omitempty"`
+	MaxLimit         *float64               `json:"max_limit,omitempty"`
+	Points           []StabilityPoint       `json:"points"`
+	Slope            *float64               `json:"slope,omitempty"` // Change per month
+	Intercept        *float64               `json:"intercept,omitempty"`
+	OutOfSpec        bool                   `json:"out_of_spec"`
+	SupportedMonths  *int                   `json:"supported_months,omitempty"`  // Long-term trends only
+	InsufficientData bool                   `json:"insufficient_data,omitempty"` // Too few distinct pull points for a confidence bound
+}
+
+// StabilityPoint is a reading on a trend
+type StabilityPoint struct {
+	Month            int     `json:"month"`
+	Value            float64 `json:"value"`
+	InspectionNumber string  `json:"inspection_number"`
+}
+
+// minRegressionPoints is the number of pull points needed to fit a trend with a confidence bound
+const minRegressionPoints = 3
+
+// Stability business methods
+
+// ConditionByID returns the condition with the given ID
+func (s *StabilityStudy) ConditionByID(id uuid.UUID) *StabilityCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].ID == id {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// PullPointByID returns the pull point with the given ID
+func (s *StabilityStudy) PullPointByID(id uuid.UUID) *StabilityPullPoint {
+	for i := range s.PullPoints {
+		if s.PullPoints[i].ID == id {
+			return &s.PullPoints[i]
+		}
+	}
+	return nil
+}
+
+// PullDate returns the scheduled date of a pull point month
+func (s *StabilityStudy) PullDate(month int) time.Time {
+	return s.StartDate.AddDate(0, month, 0)
+}
+
+// SetEvaluation stores the evaluation snapshot and its recommendation
+func (s *StabilityStudy) SetEvaluation(eval *StabilityEvaluation, now time.Time) error {
+	data, err := json.Marshal(eval)
+	if err != nil {
+		return err
+	}
+	s.Evaluation = data
+	s.RecommendedShelfLifeMonths = eval.RecommendedShelfLifeMonths
+	s.EvaluatedAt = &now
+	s.UpdatedAt = now
+	return nil
+}
+
+// Complete closes the study with its final recommendation
+func (s *StabilityStudy) Complete(completedBy uuid.UUID, notes string, now time.Time) error {
+	if s.Status != StabilityStudyStatusActive {
+		return ErrStabilityStudyClosed
+	}
+	s.Status = StabilityStudyStatusCompleted
+	s.CompletedAt = &now
+	s.CompletedBy = &completedBy
+	if notes != "" {
+		s.Notes = notes
+	}
+	s.UpdatedAt = now
+	return nil
+}
+
+// MarkTested records that the pull point results have been entered
+func (p *StabilityPullPoint) MarkTested(testedBy uuid.UUID, now time.Time) error {
+	if p.Status == StabilityPullStatusTested {
+		return ErrStabilityPullPointTested
+	}
+	p.Status = StabilityPullStatusTested
+	p.TestedAt = &now
+	p.TestedBy = &testedBy
+	p.UpdatedAt = now
+	return nil
+}
+
+// EvaluateStability fits the numeric results of each condition and derives the
+// shelf life supported by the long-term condition, following ICH Q1E: the
+// 95% confidence bound of the mean trend must stay within specification, and
+// extrapolation beyond the tested period (up to 2x and at most +12 months) is
+// only allowed when the accelerated condition shows no significant change.
+func EvaluateStability(conditions []StabilityCondition, readings []StabilityReading) *StabilityEvaluation {
+	eval := &StabilityEvaluation{Trends: []StabilityTrend{}}
+
+	condByID := make(map[uuid.UUID]*StabilityCondition)
+	for i := range conditions {
+		condByID[conditions[i].ID] = &conditions[i]
+	}
+
+	// The first long-term failure caps the shelf life whatever the trends say
+	firstFailure := -1
+	for _, r := range readings {
+		cond := condByID[r.ConditionID]
+		if cond == nil {
+			continue
+		}
+		switch cond.ConditionType {
+		case StabilityConditionLongTerm:
+			if r.Month > eval.CoveredMonths {
+				eval.CoveredMonths = r.Month
+			}
+			if r.Failed && (firstFailure < 0 || r.Month < firstFailure) {
+				firstFailure = r.Month
+			}
+		case StabilityConditionAccelerated:
+			eval.AcceleratedTested = true
+			if r.Failed {
+				eval.AcceleratedChange = true
+			}
+		}
+	}
+
+	eval.MaxExtrapolationMonths = eval.CoveredMonths
+	if eval.AcceleratedTested && !eval.AcceleratedChange {
+		eval.MaxExtrapolationMonths = min(2*eval.CoveredMonths, eval.CoveredMonths+12)
+	}
+
+	eval.Trends = buildStabilityTrends(condByID, readings)
+
+	hasLongTerm := false
+	var recommended int
+	for i := range eval.Trends {
+		t := &eval.Trends[i]
+		if t.ConditionType != StabilityConditionLongTerm {
+			continue
+		}
+		supported := t.supportedMonths(eval.CoveredMonths, eval.MaxExtrapolationMonths)
+		t.SupportedMonths = &supported
+		if !hasLongTerm || supported < recommended {
+			recommended = supported
+			eval.LimitingTest = t.TestName
+		}
+		hasLongTerm = true
+	}
+	if !hasLongTerm && eval.CoveredMonths > 0 {
+		// Pass/fail tests only: the data supports the tested period
+		recommended = eval.CoveredMonths
+		hasLongTerm = true
+	}
+	if !hasLongTerm {
+		return eval
+	}
+
+	if firstFailure >= 0 {
+		lastPassed := lastMonthBefore(readings, condByID, firstFailure)
+		if lastPassed < recommended {
+			recommended = lastPassed
+			eval.LimitingTest = ""
+			for _, r := range readings {
+				if c := condByID[r.ConditionID]; c != nil && c.ConditionType == StabilityConditionLongTerm && r.Failed && r.Month == firstFailure {
+					eval.LimitingTest = r.TestName
+					break
+				}
+			}
+		}
+	}
+	eval.RecommendedShelfLifeMonths = &recommended
+	return eval
+}
+
+func buildStabilityTrends(condByID map[uuid.UUID]*StabilityCondition, readings []StabilityReading) []StabilityTrend {
+	type trendKey struct {
+		conditionID uuid.UUID
+		testName    string
+	}
+	trends := []StabilityTrend{}
+	index := make(map[trendKey]int)
+	for _, r := range readings {
+		cond := condByID[r.ConditionID]
+		if cond == nil || r.Value == nil {
+			continue
+		}
+		key := trendKey{r.ConditionID, strings.ToLower(strings.TrimSpace(r.TestName))}
+		i, ok := index[key]
+		if !ok {
+			i = len(trends)
+			index[key] = i
+			trends = append(trends, StabilityTrend{
+				ConditionID:   cond.ID,
+				ConditionName: cond.Name,
+				ConditionType: cond.ConditionType,
+				TestName:      r.TestName,
+				Unit:          r.Unit,
+			})
+		}
+		t := &trends[i]
+		// Specs may be tightened during a study; the latest limits apply
+		if r.MinLimit != nil || r.MaxLimit != nil {
+			t.MinLimit, t.MaxLimit = r.MinLimit, r.MaxLimit
+		}
+		t.Points = append(t.Points, StabilityPoint{Month: r.Month, Value: *r.Value, InspectionNumber: r.InspectionNumber})
+	}
+
+	for i := range trends {
+		t := &trends[i]
+		sort.SliceStable(t.Points, func(a, b int) bool { return t.Points[a].Month < t.Points[b].Month })
+		for _, p := range t.Points {
+			if !t.withinSpec(p.Value, p.Value) {
+				t.OutOfSpec = true
+			}
+		}
+		if slope, intercept, ok := t.fit(); ok {
+			t.Slope, t.Intercept = &slope, &intercept
+		}
+	}
+	return trends
+}
+
+// supportedMonths returns the last whole month, up to maxMonths, at which the
+// confidence bound of the trend is within specification. Trends with too few
+// points to fit, or all points at one month, are flagged as insufficient data
+// and support the tested period only; failed results are capped by the caller.
+func (t *StabilityTrend) supportedMonths(covered, maxMonths int) int {
+	if t.MinLimit == nil && t.MaxLimit == nil {
+		return maxMonths
+	}
+	if len(t.Points) < minRegressionPoints || t.Slope == nil || t.Intercept == nil {
+		t.InsufficientData = true
+		return covered
+	}
+
+	n := float64(len(t.Points))
+	var sumX float64
+	for _, p := range t.Points {
+		sumX += float64(p.Month)
+	}
+	meanX := sumX / n
+	var sxx, sse float64
+	for _, p := range t.Points {
+		dx := float64(p.Month) - meanX
+		sxx += dx * dx
+		r := p.Value - (*t.Intercept + *t.Slope*float64(p.Month))
+		sse += r * r
+	}
+	s := math.Sqrt(sse / (n - 2))
+	// Two-sided specs use two-sided 95% limits, one-sided specs a one-sided 95% limit
+	tValue := studentT95(len(t.Points) - 2)
+	if t.MinLimit != nil && t.MaxLimit != nil {
+		tValue = studentT975(len(t.Points) - 2)
+	}
+
+	supported := -1
+	for m := 0; m <= maxMonths; m++ {
+		x := float64(m)
+		mean := *t.Intercept + *t.Slope*x
+		halfWidth := tValue * s * math.Sqrt(1/n+(x-meanX)*(x-meanX)/sxx)
+		if !t.withinSpec(mean-halfWidth, mean+halfWidth) {
+			break
+		}
+		supported = m
+	}
+	return max(supported, 0)
+}
+
+func (t *StabilityTrend) withinSpec(lower, upper float64) bool {
+	return (t.MinLimit == nil || lower >= *t.MinLimit) && (t.MaxLimit == nil || upper <= *t.MaxLimit)
+}
+
+// fit returns the least-squares line through the points
+func (t *StabilityTrend) fit() (slope, intercept float64, ok bool) {
+	if len(t.Points) < 2 {
+		return 0, 0, false
+	}
+	n := float64(len(t.Points))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range t.Points {
+		x := float64(p.Month)
+		sumX += x
+		sumY += p.Value
+		sumXY += x * p.Value
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, 0, false
+	}
+	slope = (n*sumXY - sumX*sumY) / denom
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept, true
+}
+
+// lastMonthBefore returns the latest long-term month tested before the given month
+func lastMonthBefore(readings []StabilityReading, condByID map[uuid.UUID]*StabilityCondition, month int) int {
+	last := 0
+	for _, r := range readings {
+		if c := condByID[r.ConditionID]; c != nil && c.ConditionType == StabilityConditionLongTerm && r.Month < month && r.Month > last {
+			last = r.Month
+		}
+	}
+	return last
+}
+
+// Student's t quantiles by degrees of freedom (1-30); larger samples use the normal quantile
+var (
+	t95Table = []float64{6.314, 2.920, 2.353, 2.132, 2.015, 1.943, 1.895, 1.860, 1.833, 1.812,
+		1.796, 1.782, 1.771, 1.761, 1.753, 1.746, 1.740, 1.734, 1.729, 1.725,
+		1.721, 1.717, 1.714, 1.711, 1.708, 1.706, 1.703, 1.701, 1.699, 1.697}
+	t975Table = []float64{12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+		2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+		2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042}
+)
+
+func studentT95(df int) float64 {
+	if df > len(t95Table) {
+		return 1.645
+	}
+	return t95Table[df-1]
+}
+
+func studentT975(df int) float64 {
+	if df > len(t975Table) {
+		return 1.960
+	}
+	return t975Table[df-1]
+}
@@ -0,0 +1,132 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func stabilityConditions() (entity.StabilityCondition, entity.StabilityCondition) {
+	longTerm := entity.StabilityCondition{ID: uuid.New(), Name: "25°C/60%RH", ConditionType: entity.StabilityConditionLongTerm}
+	accelerated := entity.StabilityCondition{ID: uuid.New(), Name: "40°C/75%RH", ConditionType: entity.StabilityConditionAccelerated}
+	return longTerm, accelerated
+}
+
+func stabilityReadings(conditionID uuid.UUID, testName string, min, max *float64, months []int, values []float64) []entity.StabilityReading {
+	var readings []entity.StabilityReading
+	for i, m := range months {
+		v := values[i]
+		readings = append(readings, entity.StabilityReading{
+			ConditionID: conditionID,
+			Month:       m,
+			TestName:    testName,
+			Value:       &v,
+			MinLimit:    min,
+			MaxLimit:    max,
+			Failed:      (min != nil && v < *min) || (max != nil && v > *max),
+		})
+	}
+	return readings
+}
+
+func TestEvaluateStability_ExtrapolatesStableTrend(t *testing.T) {
+	// Arrange
+	longTerm, accelerated := stabilityConditions()
+	phMin, phMax := 5.0, 6.0
+	readings := stabilityReadings(longTerm.ID, "pH", &phMin, &phMax, []int{0, 3, 6, 12}, []float64{5.50, 5.48, 5.47, 5.45})
+	readings = append(readings, stabilityReadings(accelerated.ID, "pH", &phMin, &phMax, []int{0, 3, 6}, []float64{5.50, 5.42, 5.35})...)
+
+	// Act
+	eval := entity.EvaluateStability([]entity.StabilityCondition{longTerm, accelerated}, readings)
+
+	// Assert
+	assert.Equal(t, 12, eval.CoveredMonths)
+	assert.True(t, eval.AcceleratedTested)
+	assert.False(t, eval.AcceleratedChange)
+	assert.Equal(t, 24, eval.MaxExtrapolationMonths) // min(2x12, 12+12)
+	assert.Equal(t, 24, *eval.RecommendedShelfLifeMonths)
+	assert.Len(t, eval.Trends, 2)
+	assert.Nil(t, eval.Trends[1].SupportedMonths) // Accelerated trends do not set the shelf life
+}
+
+func TestEvaluateStability_ConfidenceBoundLimitsDegradingAssay(t *testing.T) {
+	// Arrange
+	longTerm, accelerated := stabilityConditions()
+	phMin, phMax, assayMin := 5.0, 6.0, 92.0
+	readings := stabilityReadings(longTerm.ID, "pH", &phMin, &phMax, []int{0, 3, 6, 9}, []float64{5.50, 5.49, 5.49, 5.48})
+	readings = append(readings, stabilityReadings(longTerm.ID, "Assay", &assayMin, nil, []int{0, 3, 6, 9}, []float64{100.0, 98.4, 97.1, 95.5})...)
+	readings = append(readings, stabilityReadings(accelerated.ID, "Assay", &assayMin, nil, []int{0, 3, 6}, []float64{100.0, 97.0, 94.2})...)
+
+	// Act
+	eval := entity.EvaluateStability([]entity.StabilityCondition{longTerm, accelerated}, readings)
+
+	// Assert
+	assert.Equal(t, 18, eval.MaxExtrapolationMonths)
+	assert.Equal(t, 15, *eval.RecommendedShelfLifeMonths) // Lower 95% bound drops below 92% at month 16
+	assert.Equal(t, "Assay", eval.LimitingTest)
+	assert.InDelta(t, -0.4933, *eval.Trends[1].Slope, 0.0001)
+}
+
+func TestEvaluateStability_AcceleratedChangeBlocksExtrapolation(t *testing.T) {
+	// Arrange
+	longTerm, accelerated := stabilityConditions()
+	viscMin, viscMax := 8000.0, 12000.0
+	readings := stabilityReadings(longTerm.ID, "Viscosity", &viscMin, &viscMax, []int{0, 3, 6, 12}, []float64{10000, 10050, 9980, 10020})
+	readings = append(readings, stabilityReadings(accelerated.ID, "Viscosity", &viscMin, &viscMax, []int{0, 3, 6}, []float64{10000, 8900, 7600})...)
+
+	// Act
+	eval := entity.EvaluateStability([]entity.StabilityCondition{longTerm, accelerated}, readings)
+
+	// Assert
+	assert.True(t, eval.AcceleratedChange)
+	assert.Equal(t, 12, eval.MaxExtrapolationMonths)
+	assert.Equal(t, 12, *eval.RecommendedShelfLifeMonths)
+}
+
+func TestEvaluateStability_LongTermFailureCapsShelfLife(t *testing.T) {
+	// Arrange
+	longTerm, _ := stabilityConditions()
+	readings := []entity.StabilityReading{
+		{ConditionID: longTerm.ID, Month: 0, TestName: "Appearance"},
+		{ConditionID: longTerm.ID, Month: 6, TestName: "Appearance"},
+		{ConditionID: longTerm.ID, Month: 12, TestName: "Appearance", Failed: true}, // Phase separation
+	}
+
+	// Act
+	eval := entity.EvaluateStability([]entity.StabilityCondition{longTerm}, readings)
+
+	// Assert
+	assert.Equal(t, 12, eval.CoveredMonths)
+	assert.Equal(t, 6, *eval.RecommendedShelfLifeMonths)
+	assert.Equal(t, "Appearance", eval.LimitingTest)
+}
+
+func TestEvaluateStability_NoLongTermResults(t *testing.T) {
+	// Arrange
+	longTerm, accelerated := stabilityConditions()
+	phMin := 5.0
+	readings := stabilityReadings(accelerated.ID, "pH", &phMin, nil, []int{0, 3}, []float64{5.5, 5.4})
+
+	// Act
+	eval := entity.EvaluateStability([]entity.StabilityCondition{longTerm, accelerated}, readings)
+
+	// Assert
+	assert.Nil(t, eval.RecommendedShelfLifeMonths)
+}
+
+func TestEvaluateStability_AllPointsAtOneMonthIsInsufficientData(t *testing.T) {
+	// Arrange: three replicates pulled at month 0 cannot be regressed
+	longTerm, accelerated := stabilityConditions()
+	phMin, phMax := 5.0, 6.0
+	readings := stabilityReadings(longTerm.ID, "pH", &phMin, &phMax, []int{0, 0, 0}, []float64{5.50, 5.52, 5.49})
+
+	// Act
+	eval := entity.EvaluateStability([]entity.StabilityCondition{longTerm, accelerated}, readings)
+
+	// Assert
+	assert.True(t, eval.Trends[0].InsufficientData)
+	assert.Nil(t, eval.Trends[0].Slope)
+	assert.Equal(t, 0, *eval.Trends[0].SupportedMonths)
+}
@@ -29,6 +29,24 @@ func (BatchTraceability) TableName() string {
 	return "batch_traceability"
 }
 
+// ForOutput copies a material link to a secondary output lot of the same work order
+func (t *BatchTraceability) ForOutput(out *WOOutput) *BatchTraceability {
+	return &BatchTraceability{
+		WorkOrderID:       t.WorkOrderID,
+		WOMaterialIssueID: t.WOMaterialIssueID,
+		MaterialID:        t.MaterialID,
+		MaterialLotID:     t.MaterialLotID,
+		MaterialLotNumber: t.MaterialLotNumber,
+		MaterialQuantity:  t.MaterialQuantity,
+		MaterialUOMID:     t.MaterialUOMID,
+		SupplierLotNumber: t.SupplierLotNumber,
+		ProductID:         out.ProductID,
+		ProductLotID:      out.LotID,
+		ProductLotNumber:  out.LotNumber,
+		TraceDate:         t.TraceDate,
+	}
+}
+
 // BackwardTraceResult represents the result of a backward trace
 type BackwardTraceResult struct {
 	FinishedLot   FinishedLotInfo    `json:"finished_lot"`
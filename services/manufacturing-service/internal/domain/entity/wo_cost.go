@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CostElement represents a cost element of a work order
+type CostElement string
+
+const (
+	CostElementMaterial CostElement = "MATERIAL" // Issued lots at WMS valuation, backflush at standard
+	CostElementLabor    CostElement = "LABOR"    // Operation hours × work center rate
+	CostElementOverhead CostElement = "OVERHEAD" // Operation hours × line absorption rate
+)
+
+// LineOverheadRate is the overhead absorption rate of a production line,
+// charged per operation hour worked on the line
+type LineOverheadRate struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProductionLine string     `json:"production_line" gorm:"type:varchar(50);unique;not null"`
+	RatePerHour    float64    `json:"rate_per_hour" gorm:"type:decimal(18,2);not null"`
+	Notes          string     `json:"notes" gorm:"type:text"`
+	UpdatedBy      *uuid.UUID `json:"updated_by" gorm:"type:uuid"`
+	CreatedAt      time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (LineOverheadRate) TableName() string {
+	return "line_overhead_rates"
+}
+
+// WOCost is the actual cost of a completed work order and its variance against
+// the BOM standard cost. Co-products and by-products carry no cost; the whole
+// cost is absorbed by the main output.
+type WOCost struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WorkOrderID    uuid.UUID  `json:"work_order_id" gorm:"type:uuid;unique;not null"`
+	WONumber       string     `json:"wo_number" gorm:"type:varchar(30);not null"`
+	ProductID      uuid.UUID  `json:"product_id" gorm:"type:uuid;not null"`
+	BOMID          uuid.UUID  `json:"bom_id" gorm:"type:uuid;not null"`
+	BatchNumber    string     `json:"batch_number" gorm:"type:varchar(50)"`
+	OutputLotID    *uuid.UUID `json:"output_lot_id" gorm:"type:uuid"`
+	OutputQuantity float64    `json:"output_quantity" gorm:"type:decimal(15,4);not null"` // Good quantity
+
+	// Actual cost
+	MaterialCost float64 `json:"material_cost" gorm:"type:decimal(18,2);default:0"`
+	LaborCost    float64 `json:"labor_cost" gorm:"type:decimal(18,2);default:0"`
+	OverheadCost float64 `json:"overhead_cost" gorm:"type:decimal(18,2);default:0"`
+	TotalCost    float64 `json:"total_cost" gorm:"type:decimal(18,2);default:0"`
+	UnitCost     float64 `json:"unit_cost" gorm:"type:decimal(18,4);default:0"`
+	LaborHours   float64 `json:"labor_hours" gorm:"type:decimal(10,2);default:0"`
+
+	// BOM standard cost for the output quantity
+	StandardMaterialCost float64 `json:"standard_material_cost" gorm:"type:decimal(18,2);default:0"`
+	StandardLaborCost    float64 `json:"standard_labor_cost" gorm:"type:decimal(18,2);default:0"`
+	StandardOverheadCost float64 `json:"standard_overhead_cost" gorm:"type:decimal(18,2);default:0"`
+	StandardTotalCost    float64 `json:"standard_total_cost" gorm:"type:decimal(18,2);default:0"`
+	StandardUnitCost     float64 `json:"standard_unit_cost" gorm:"type:decimal(18,4);default:0"`
+
+	// Variance: actual - standard (positive = unfavourable)
+	MaterialVariance float64 `json:"material_variance" gorm:"type:decimal(18,2);default:0"`
+	LaborVariance    float64 `json:"labor_variance" gorm:"type:decimal(18,2);default:0"`
+	OverheadVariance float64 `json:"overhead_variance" gorm:"type:decimal(18,2);default:0"`
+	TotalVariance    float64 `json:"total_variance" gorm:"type:decimal(18,2);default:0"`
+	VariancePercent  float64 `json:"variance_percent" gorm:"type:decimal(8,2);default:0"`
+
+	CalculatedBy *uuid.UUID `json:"calculated_by" gorm:"type:uuid"`
+	CalculatedAt time.Time  `json:"calculated_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Associations
+	Lines []WOCostLine `json:"lines,omitempty" gorm:"foreignKey:WOCostID"`
+}
+
+// TableName returns the table name
+func (WOCost) TableName() string {
+	return "wo_costs"
+}
+
+// WOCostLine is one costed material line or operation of a work order cost
+type WOCostLine struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WOCostID    uuid.UUID   `json:"wo_cost_id" gorm:"type:uuid;not null"`
+	CostElement CostElement `json:"cost_element" gorm:"type:varchar(20);not null"`
+	ReferenceID *uuid.UUID  `json:"reference_id" gorm:"type:uuid"`      // WO line item or WO operation
+	Reference   string      `json:"reference" gorm:"type:varchar(100)"` // Material line number or operation code
+	Quantity    float64     `json:"quantity" gorm:"type:decimal(15,4)"` // Consumed quantity or hours
+	Rate        float64     `json:"rate" gorm:"type:decimal(18,4)"`     // Unit cost or hourly rate
+	Amount      float64     `json:"amount" gorm:"type:decimal(18,2)"`
+}
+
+// TableName returns the table name
+func (WOCostLine) TableName() string {
+	return "wo_cost_lines"
+}
+
+// AddLine adds a costed line and accumulates it in its cost element
+func (c *WOCost) AddLine(line WOCostLine) {
+	switch line.CostElement {
+	case CostElementMaterial:
+		c.MaterialCost += line.Amount
+	case CostElementLabor:
+		c.LaborCost += line.Amount
+		c.LaborHours += line.Quantity
+	case CostElementOverhead:
+		c.OverheadCost += line.Amount
+	}
+	c.Lines = append(c.Lines, line)
+}
+
+// ApplyStandard totals the actual cost, derives the unit cost of the output and
+// compares it with the BOM standard cost scaled to the output quantity
+func (c *WOCost) ApplyStandard(bom *BOM) {
+	c.TotalCost = c.MaterialCost + c.LaborCost + c.OverheadCost
+	if c.OutputQuantity > 0 {
+		c.UnitCost = c.TotalCost / c.OutputQuantity
+	}
+
+	if bom.BatchSize > 0 {
+		factor := c.OutputQuantity / bom.BatchSize
+		c.StandardMaterialCost = bom.MaterialCost * factor
+		c.StandardLaborCost = bom.LaborCost * factor
+		c.StandardOverheadCost = bom.OverheadCost * factor
+		c.StandardUnitCost = (bom.MaterialCost + bom.LaborCost + bom.OverheadCost) / bom.BatchSize
+	}
+	c.StandardTotalCost = c.StandardMaterialCost + c.StandardLaborCost + c.StandardOverheadCost
+
+	c.MaterialVariance = c.MaterialCost - c.StandardMaterialCost
+	c.LaborVariance = c.LaborCost - c.StandardLaborCost
+	c.OverheadVariance = c.OverheadCost - c.StandardOverheadCost
+	c.TotalVariance = c.TotalCost - c.StandardTotalCost
+	c.VariancePercent = 0
+	if c.StandardTotalCost != 0 {
+		c.VariancePercent = c.TotalVariance / c.StandardTotalCost * 100
+	}
+}
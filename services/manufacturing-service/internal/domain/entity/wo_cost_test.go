@@ -0,0 +1,41 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWOCost_ApplyStandard(t *testing.T) {
+	bom := &entity.BOM{BatchSize: 100, MaterialCost: 5000000, LaborCost: 800000, OverheadCost: 400000}
+	cost := &entity.WOCost{OutputQuantity: 95}
+
+	cost.AddLine(entity.WOCostLine{CostElement: entity.CostElementMaterial, Amount: 4900000})
+	cost.AddLine(entity.WOCostLine{CostElement: entity.CostElementLabor, Quantity: 6, Amount: 900000})
+	cost.AddLine(entity.WOCostLine{CostElement: entity.CostElementOverhead, Quantity: 6, Amount: 300000})
+
+	cost.ApplyStandard(bom)
+
+	assert.InDelta(t, 6100000.0, cost.TotalCost, 1e-6)
+	assert.InDelta(t, 6.0, cost.LaborHours, 1e-9) // Overhead hours are not labor hours
+	assert.InDelta(t, 6100000.0/95, cost.UnitCost, 1e-6)
+	assert.InDelta(t, 62000.0, cost.StandardUnitCost, 1e-6)
+	assert.InDelta(t, 4750000.0, cost.StandardMaterialCost, 1e-6) // Scaled to the good quantity
+	assert.InDelta(t, 150000.0, cost.MaterialVariance, 1e-6)
+	assert.InDelta(t, 140000.0, cost.LaborVariance, 1e-6)
+	assert.InDelta(t, -80000.0, cost.OverheadVariance, 1e-6)
+	assert.InDelta(t, 210000.0, cost.TotalVariance, 1e-6)
+	assert.InDelta(t, 210000.0/5890000*100, cost.VariancePercent, 1e-9)
+}
+
+func TestWOCost_ApplyStandard_NoBatchSize(t *testing.T) {
+	cost := &entity.WOCost{OutputQuantity: 10}
+	cost.AddLine(entity.WOCostLine{CostElement: entity.CostElementMaterial, Amount: 1000})
+
+	cost.ApplyStandard(&entity.BOM{MaterialCost: 500})
+
+	assert.InDelta(t, 100.0, cost.UnitCost, 1e-9)
+	assert.Equal(t, 0.0, cost.StandardTotalCost)
+	assert.Equal(t, 0.0, cost.VariancePercent)
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -29,6 +30,30 @@ const (
 	WOPriorityUrgent WOPriority = "URGENT"
 )
 
+// WOType represents work order type
+type WOType string
+
+const (
+	WOTypeStandard WOType = "STANDARD"
+	WOTypeRework   WOType = "REWORK" // Reprocesses a rejected finished lot into a new lot
+)
+
+// WOOutputType represents a secondary output of a work order
+type WOOutputType string
+
+const (
+	WOOutputTypeCoProduct WOOutputType = "CO_PRODUCT" // Saleable product made alongside the main product
+	WOOutputTypeByProduct WOOutputType = "BY_PRODUCT" // Incidental output (e.g. recovered material)
+)
+
+// MaterialIssueType represents the direction of a material issue
+type MaterialIssueType string
+
+const (
+	MaterialIssueTypeIssue  MaterialIssueType = "ISSUE"  // Warehouse → production
+	MaterialIssueTypeReturn MaterialIssueType = "RETURN" // Surplus production → warehouse
+)
+
 // WorkOrder represents a manufacturing work order
 type WorkOrder struct {
 	ID                uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
@@ -36,6 +61,7 @@ type WorkOrder struct {
 	WODate            time.Time   `json:"wo_date" gorm:"type:date;not null"`
 	ProductID         uuid.UUID   `json:"product_id" gorm:"type:uuid;not null"`
 	BOMID             uuid.UUID   `json:"bom_id" gorm:"type:uuid;not null"`
+	WOType            WOType      `json:"wo_type" gorm:"type:varchar(20);default:'STANDARD'"`
 	Status            WOStatus    `json:"status" gorm:"type:varchar(30);default:'PLANNED'"`
 	Priority          WOPriority  `json:"priority" gorm:"type:varchar(20);default:'NORMAL'"`
 	PlannedQuantity   float64     `json:"planned_quantity" gorm:"type:decimal(15,4);not null"`
@@ -50,6 +76,9 @@ type WorkOrder struct {
 	YieldPercentage   *float64    `json:"yield_percentage" gorm:"type:decimal(5,2)"`
 	BatchNumber       string      `json:"batch_number" gorm:"type:varchar(50)"`
 	OutputLotID       *uuid.UUID  `json:"output_lot_id" gorm:"type:uuid"`
+	NCRID             *uuid.UUID  `json:"ncr_id" gorm:"type:uuid"`              // Rework: NCR with REWORK disposition
+	SourceLotID       *uuid.UUID  `json:"source_lot_id" gorm:"type:uuid"`       // Rework: rejected lot being reworked
+	SourceLotNumber   string      `json:"source_lot_number" gorm:"type:varchar(50)"`
 	SalesOrderID      *uuid.UUID  `json:"sales_order_id" gorm:"type:uuid"`
 	ProductionLine    string      `json:"production_line" gorm:"type:varchar(50)"`
 	Shift             string      `json:"shift" gorm:"type:varchar(20)"`
@@ -63,6 +92,7 @@ type WorkOrder struct {
 	// Associations
 	Items          []WOLineItem       `json:"items,omitempty" gorm:"foreignKey:WorkOrderID"`
 	MaterialIssues []WOMaterialIssue  `json:"material_issues,omitempty" gorm:"foreignKey:WorkOrderID"`
+	Outputs        []WOOutput         `json:"outputs,omitempty" gorm:"foreignKey:WorkOrderID"`
 	BOM            *BOM               `json:"bom,omitempty" gorm:"foreignKey:BOMID"`
 }
 
@@ -80,6 +110,8 @@ type WOLineItem struct {
 	MaterialID     uuid.UUID  `json:"material_id" gorm:"type:uuid;not null"`
 	PlannedQuantity float64   `json:"planned_quantity" gorm:"type:decimal(15,4);not null"`
 	IssuedQuantity float64    `json:"issued_quantity" gorm:"type:decimal(15,4);default:0"`
+	BackflushedQuantity float64 `json:"backflushed_quantity" gorm:"type:decimal(15,4);default:0"`
+	ReturnedQuantity    float64 `json:"returned_quantity" gorm:"type:decimal(15,4);default:0"`
 	UOMID          uuid.UUID  `json:"uom_id" gorm:"type:uuid;not null"`
 	IsCritical     bool       `json:"is_critical" gorm:"default:false"`
 	Notes          string     `json:"notes" gorm:"type:text"`
@@ -98,12 +130,14 @@ type WOMaterialIssue struct {
 	WorkOrderID    uuid.UUID  `json:"work_order_id" gorm:"type:uuid;not null"`
 	WOLineItemID   *uuid.UUID `json:"wo_line_item_id" gorm:"type:uuid"`
 	IssueNumber    string     `json:"issue_number" gorm:"type:varchar(30);not null"`
+	IssueType      MaterialIssueType `json:"issue_type" gorm:"type:varchar(20);default:'ISSUE'"`
 	IssueDate      time.Time  `json:"issue_date" gorm:"default:CURRENT_TIMESTAMP"`
 	MaterialID     uuid.UUID  `json:"material_id" gorm:"type:uuid;not null"`
 	LotID          uuid.UUID  `json:"lot_id" gorm:"type:uuid;not null"`
 	LotNumber      string     `json:"lot_number" gorm:"type:varchar(50);not null"`
 	Quantity       float64    `json:"quantity" gorm:"type:decimal(15,4);not null"`
 	UOMID          uuid.UUID  `json:"uom_id" gorm:"type:uuid;not null"`
+	UnitCost       float64    `json:"unit_cost" gorm:"type:decimal(18,4);default:0"` // Actual lot cost, 0 if unknown
 	WMSMovementID  *uuid.UUID `json:"wms_movement_id" gorm:"type:uuid"`
 	IssuedBy       *uuid.UUID `json:"issued_by" gorm:"type:uuid"`
 	Notes          string     `json:"notes" gorm:"type:text"`
@@ -115,8 +149,35 @@ func (WOMaterialIssue) TableName() string {
 	return "wo_material_issues"
 }
 
+// WOOutput is a co-product or by-product of a work order, produced into its own lot
+type WOOutput struct {
+	ID              uuid.UUID    `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	WorkOrderID     uuid.UUID    `json:"work_order_id" gorm:"type:uuid;not null"`
+	OutputType      WOOutputType `json:"output_type" gorm:"type:varchar(20);not null"`
+	ProductID       uuid.UUID    `json:"product_id" gorm:"type:uuid;not null"`
+	PlannedQuantity float64      `json:"planned_quantity" gorm:"type:decimal(15,4);default:0"`
+	ActualQuantity  *float64     `json:"actual_quantity" gorm:"type:decimal(15,4)"`
+	YieldPercentage *float64     `json:"yield_percentage" gorm:"type:decimal(7,2)"`
+	UOMID           uuid.UUID    `json:"uom_id" gorm:"type:uuid;not null"`
+	LotID           *uuid.UUID   `json:"lot_id" gorm:"type:uuid"`
+	LotNumber       string       `json:"lot_number" gorm:"type:varchar(50)"`
+	Notes           string       `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time    `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time    `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (WOOutput) TableName() string {
+	return "wo_outputs"
+}
+
 // Work Order business methods
 
+// IsRework returns true if the WO reworks a rejected lot
+func (w *WorkOrder) IsRework() bool {
+	return w.WOType == WOTypeRework
+}
+
 // CanBeReleased returns true if WO can be released
 func (w *WorkOrder) CanBeReleased() bool {
 	return w.Status == WOStatusPlanned
@@ -137,6 +198,21 @@ func (w *WorkOrder) CanBeCancelled() bool {
 	return w.Status == WOStatusPlanned || w.Status == WOStatusReleased
 }
 
+// CanDispense returns true if materials can be weighed and issued to the WO
+func (w *WorkOrder) CanDispense() bool {
+	return w.Status == WOStatusReleased || w.Status == WOStatusInProgress
+}
+
+// GetLineItem returns the material line with the given ID, nil if the WO has none
+func (w *WorkOrder) GetLineItem(id uuid.UUID) *WOLineItem {
+	for i := range w.Items {
+		if w.Items[i].ID == id {
+			return &w.Items[i]
+		}
+	}
+	return nil
+}
+
 // Release releases the work order
 func (w *WorkOrder) Release() error {
 	if !w.CanBeReleased() {
@@ -170,11 +246,16 @@ func (w *WorkOrder) SendToQC() error {
 	return nil
 }
 
-// Complete completes the work order
+// Complete completes the work order and assigns the ID of the finished lot
+// WMS receives the good quantity under
 func (w *WorkOrder) Complete(actualQty, goodQty, rejectedQty float64) error {
 	if !w.CanBeCompleted() {
 		return errors.New("work order cannot be completed from current status")
 	}
+	if w.OutputLotID == nil {
+		lotID := uuid.New()
+		w.OutputLotID = &lotID
+	}
 	w.Status = WOStatusCompleted
 	w.ActualQuantity = &actualQty
 	w.GoodQuantity = &goodQty
@@ -202,12 +283,117 @@ func (w *WorkOrder) Cancel() error {
 	return nil
 }
 
+// IsValid returns true if the output type is known
+func (t WOOutputType) IsValid() bool {
+	return t == WOOutputTypeCoProduct || t == WOOutputTypeByProduct
+}
+
+// OutputLotNumber derives a lot number for a secondary output from the WO batch
+// number, e.g. B2401-CP1 for the first co-product
+func OutputLotNumber(batchNumber string, outputType WOOutputType, seq int) string {
+	suffix := "CP"
+	if outputType == WOOutputTypeByProduct {
+		suffix = "BP"
+	}
+	return fmt.Sprintf("%s-%s%d", batchNumber, suffix, seq)
+}
+
+// Record records the produced quantity and lot of the output. A produced
+// output gets the lot ID WMS receives it under.
+func (o *WOOutput) Record(qty float64, lotNumber string) error {
+	if qty < 0 {
+		return errors.New("output quantity must not be negative")
+	}
+	o.ActualQuantity = &qty
+	o.LotNumber = lotNumber
+	if qty > 0 && o.LotID == nil {
+		lotID := uuid.New()
+		o.LotID = &lotID
+	}
+	if o.PlannedQuantity > 0 {
+		yield := (qty / o.PlannedQuantity) * 100
+		o.YieldPercentage = &yield
+	}
+	o.UpdatedAt = time.Now()
+	return nil
+}
+
 // GetOutstandingQuantity returns the remaining quantity to be issued
 func (item *WOLineItem) GetOutstandingQuantity() float64 {
 	return item.PlannedQuantity - item.IssuedQuantity
 }
 
+// ConsumedQuantity returns the quantity actually consumed by the work order
+func (item *WOLineItem) ConsumedQuantity() float64 {
+	return item.IssuedQuantity + item.BackflushedQuantity - item.ReturnedQuantity
+}
+
 // IsFullyIssued returns true if all planned quantity has been issued
 func (item *WOLineItem) IsFullyIssued() bool {
 	return item.IssuedQuantity >= item.PlannedQuantity
 }
+
+// Backflush sets consumption of the line to its standard quantity. A shortfall
+// against what was issued is backflushed; the surplus to return is reported.
+func (item *WOLineItem) Backflush(standardQty float64) (backflushed, surplus float64) {
+	consumed := item.ConsumedQuantity()
+	if consumed < standardQty {
+		backflushed = standardQty - consumed
+		item.BackflushedQuantity += backflushed
+		item.UpdatedAt = time.Now()
+		return backflushed, 0
+	}
+	return 0, consumed - standardQty
+}
+
+// PlanReturns splits a surplus quantity over the lots issued to the line,
+// most recently issued lot first. Returned quantities are applied to the line.
+func (item *WOLineItem) PlanReturns(issues []*WOMaterialIssue, surplus float64) []*WOMaterialIssue {
+	// Remaining quantity per lot after earlier returns
+	remaining := make(map[uuid.UUID]float64)
+	var lotOrder []*WOMaterialIssue
+	for _, issue := range issues {
+		if issue.WOLineItemID == nil || *issue.WOLineItemID != item.ID {
+			continue
+		}
+		if issue.IssueType == MaterialIssueTypeReturn {
+			remaining[issue.LotID] -= issue.Quantity
+			continue
+		}
+		if _, seen := remaining[issue.LotID]; !seen {
+			lotOrder = append(lotOrder, issue)
+		}
+		remaining[issue.LotID] += issue.Quantity
+	}
+
+	var returns []*WOMaterialIssue
+	lineID := item.ID
+	for i := len(lotOrder) - 1; i >= 0 && surplus > weighingEpsilon; i-- {
+		lot := lotOrder[i]
+		qty := remaining[lot.LotID]
+		if qty <= 0 {
+			continue
+		}
+		if qty > surplus {
+			qty = surplus
+		}
+		returns = append(returns, &WOMaterialIssue{
+			WorkOrderID:  item.WorkOrderID,
+			WOLineItemID: &lineID,
+			IssueType:    MaterialIssueTypeReturn,
+			IssueDate:    time.Now(),
+			MaterialID:   item.MaterialID,
+			LotID:        lot.LotID,
+			LotNumber:    lot.LotNumber,
+			Quantity:     qty,
+			UOMID:        item.UOMID,
+			UnitCost:     lot.UnitCost,
+		})
+		item.ReturnedQuantity += qty
+		surplus -= qty
+	}
+	if len(returns) > 0 {
+		item.UpdatedAt = time.Now()
+	}
+	return returns
+}
@@ -35,6 +35,7 @@ func TestWorkOrder_Lifecycle(t *testing.T) {
 		assert.Equal(t, entity.WOStatusCompleted, wo.Status)
 		assert.Equal(t, 95.0, *wo.YieldPercentage)
 		assert.NotNil(t, wo.ActualEndDate)
+		assert.NotNil(t, wo.OutputLotID)
 	})
 
 	t.Run("Start from Planned Fails", func(t *testing.T) {
@@ -58,3 +59,121 @@ func TestWorkOrder_Cancellation(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestWOOutput_Record(t *testing.T) {
+	t.Run("Records lot and yield against planned quantity", func(t *testing.T) {
+		// Arrange
+		out := &entity.WOOutput{OutputType: entity.WOOutputTypeCoProduct, PlannedQuantity: 20}
+
+		// Act
+		err := out.Record(18, "B2401-CP1")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 18.0, *out.ActualQuantity)
+		assert.Equal(t, "B2401-CP1", out.LotNumber)
+		assert.NotNil(t, out.LotID)
+		assert.InDelta(t, 90.0, *out.YieldPercentage, 1e-9)
+	})
+
+	t.Run("Nothing produced gets no lot", func(t *testing.T) {
+		out := &entity.WOOutput{OutputType: entity.WOOutputTypeByProduct, PlannedQuantity: 5}
+
+		err := out.Record(0, "B2401-BP1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, out.LotID)
+	})
+
+	t.Run("No yield without planned quantity", func(t *testing.T) {
+		out := &entity.WOOutput{OutputType: entity.WOOutputTypeByProduct}
+
+		err := out.Record(3, "B2401-BP1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, out.YieldPercentage)
+	})
+
+	t.Run("Negative quantity fails", func(t *testing.T) {
+		out := &entity.WOOutput{PlannedQuantity: 20}
+
+		err := out.Record(-1, "")
+
+		assert.Error(t, err)
+		assert.Nil(t, out.ActualQuantity)
+	})
+}
+
+func TestOutputLotNumber(t *testing.T) {
+	assert.Equal(t, "B2401-CP2", entity.OutputLotNumber("B2401", entity.WOOutputTypeCoProduct, 2))
+	assert.Equal(t, "B2401-BP1", entity.OutputLotNumber("B2401", entity.WOOutputTypeByProduct, 1))
+	assert.False(t, entity.WOOutputType("PRIMARY").IsValid())
+}
+
+func TestNCR_ReworkQuantity(t *testing.T) {
+	productID := uuid.New()
+	lotID := uuid.New()
+	rework := entity.DispositionRework
+	scrap := entity.DispositionScrap
+	affected := 50.0
+	disposed := 40.0
+
+	t.Run("Uses disposition quantity", func(t *testing.T) {
+		ncr := &entity.NCR{Disposition: &rework, ProductID: &productID, LotID: &lotID, QuantityAffected: &affected, DispositionQuantity: &disposed}
+
+		qty, err := ncr.ReworkQuantity()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 40.0, qty)
+	})
+
+	t.Run("Falls back to affected quantity", func(t *testing.T) {
+		ncr := &entity.NCR{Disposition: &rework, ProductID: &productID, LotID: &lotID, QuantityAffected: &affected}
+
+		qty, err := ncr.ReworkQuantity()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 50.0, qty)
+	})
+
+	t.Run("Other disposition fails", func(t *testing.T) {
+		ncr := &entity.NCR{Disposition: &scrap, ProductID: &productID, LotID: &lotID, QuantityAffected: &affected}
+
+		_, err := ncr.ReworkQuantity()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Material NCR without product lot fails", func(t *testing.T) {
+		ncr := &entity.NCR{Disposition: &rework, LotID: &lotID, QuantityAffected: &affected}
+
+		_, err := ncr.ReworkQuantity()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBatchTraceability_ForOutput(t *testing.T) {
+	issueID := uuid.New()
+	trace := &entity.BatchTraceability{
+		WorkOrderID:       uuid.New(),
+		WOMaterialIssueID: &issueID,
+		MaterialID:        uuid.New(),
+		MaterialLotID:     uuid.New(),
+		MaterialLotNumber: "ML-001",
+		MaterialQuantity:  12.5,
+		ProductID:         uuid.New(),
+		ProductLotNumber:  "B2401",
+	}
+	coProductID := uuid.New()
+	coProductLot := uuid.New()
+
+	out := trace.ForOutput(&entity.WOOutput{ProductID: coProductID, LotID: &coProductLot, LotNumber: "B2401-CP1"})
+
+	assert.Equal(t, trace.WorkOrderID, out.WorkOrderID)
+	assert.Equal(t, trace.MaterialLotID, out.MaterialLotID)
+	assert.Equal(t, 12.5, out.MaterialQuantity)
+	assert.Equal(t, coProductID, out.ProductID)
+	assert.Equal(t, "B2401-CP1", out.ProductLotNumber)
+	assert.Equal(t, &coProductLot, out.ProductLotID)
+}
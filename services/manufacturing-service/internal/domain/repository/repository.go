@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
 	"github.com/google/uuid"
@@ -56,6 +58,17 @@ type WorkOrderRepository interface {
 	CreateMaterialIssue(ctx context.Context, issue *entity.WOMaterialIssue) error
 	GetMaterialIssues(ctx context.Context, woID uuid.UUID) ([]*entity.WOMaterialIssue, error)
 	
+	// Co-products and by-products
+	CreateOutputs(ctx context.Context, outputs []*entity.WOOutput) error
+	GetOutputs(ctx context.Context, woID uuid.UUID) ([]*entity.WOOutput, error)
+	UpdateOutput(ctx context.Context, output *entity.WOOutput) error
+	
+	// Line history: the most recently started other work order on a line, nil if none
+	GetLastStartedOnLine(ctx context.Context, productionLine string, excludeID uuid.UUID) (*entity.WorkOrder, error)
+	
+	// Rework: quantity planned on the non-cancelled rework work orders of an NCR
+	GetPlannedReworkQuantity(ctx context.Context, ncrID uuid.UUID) (float64, error)
+	
 	// Number generation
 	GenerateWONumber(ctx context.Context) (string, error)
 	GenerateIssueNumber(ctx context.Context) (string, error)
@@ -91,6 +104,9 @@ type QCRepository interface {
 	// Inspection items
 	CreateInspectionItems(ctx context.Context, items []*entity.QCInspectionItem) error
 	GetInspectionItems(ctx context.Context, inspectionID uuid.UUID) ([]*entity.QCInspectionItem, error)
+
+	// SPC
+	GetNumericResults(ctx context.Context, filter SPCFilter) ([]entity.SPCObservation, error)
 	
 	// Number generation
 	GenerateInspectionNumber(ctx context.Context) (string, error)
@@ -108,6 +124,62 @@ type QCFilter struct {
 	PageSize       int
 }
 
+// SPCFilter selects numeric results of one test item for a product
+type SPCFilter struct {
+	ProductID uuid.UUID
+	TestName  string
+	DateFrom  *string
+	DateTo    *string
+	Limit     int // Most recent N results; 0 returns all
+}
+
+// SamplingPlanRepository defines AQL sampling plan repository interface
+type SamplingPlanRepository interface {
+	Create(ctx context.Context, plan *entity.AQLSamplingPlan) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.AQLSamplingPlan, error)
+	List(ctx context.Context, activeOnly bool) ([]*entity.AQLSamplingPlan, error)
+	AssignToCheckpoint(ctx context.Context, checkpointID uuid.UUID, planID *uuid.UUID) error
+
+	// Switching states; GetState returns nil without error when the subject has no history
+	GetState(ctx context.Context, planID uuid.UUID, subjectType entity.SamplingSubjectType, subjectID uuid.UUID) (*entity.SamplingState, error)
+	GetStateByID(ctx context.Context, id uuid.UUID) (*entity.SamplingState, error)
+	ListStates(ctx context.Context, planID uuid.UUID) ([]*entity.SamplingState, error)
+	SaveState(ctx context.Context, state *entity.SamplingState) error
+}
+
+// CoARepository defines certificate of analysis repository interface
+type CoARepository interface {
+	// Finished lot certificates
+	Create(ctx context.Context, coa *entity.CertificateOfAnalysis) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.CertificateOfAnalysis, error)
+	List(ctx context.Context, filter CoAFilter) ([]*entity.CertificateOfAnalysis, int64, error)
+	GenerateCoANumber(ctx context.Context) (string, error)
+
+	// Supplier certificates captured at goods receipt
+	CreateSupplierCoA(ctx context.Context, coa *entity.SupplierCoA) error
+	GetSupplierCoAByID(ctx context.Context, id uuid.UUID) (*entity.SupplierCoA, error)
+	ListSupplierCoAs(ctx context.Context, filter SupplierCoAFilter) ([]*entity.SupplierCoA, int64, error)
+}
+
+// CoAFilter for filtering certificates of analysis
+type CoAFilter struct {
+	LotID     *uuid.UUID
+	ProductID *uuid.UUID
+	Page      int
+	PageSize  int
+}
+
+// SupplierCoAFilter for filtering supplier certificates
+type SupplierCoAFilter struct {
+	GRNID      *uuid.UUID
+	LotID      *uuid.UUID
+	MaterialID *uuid.UUID
+	SupplierID *uuid.UUID
+	Status     *entity.SupplierCoAStatus
+	Page       int
+	PageSize   int
+}
+
 // NCRRepository defines NCR repository interface
 type NCRRepository interface {
 	Create(ctx context.Context, ncr *entity.NCR) error
@@ -122,13 +194,135 @@ type NCRRepository interface {
 
 // NCRFilter for filtering NCRs
 type NCRFilter struct {
-	Status   *entity.NCRStatus
-	Severity *entity.NCRSeverity
-	NCType   *entity.NCType
-	DateFrom *string
-	DateTo   *string
-	Page     int
-	PageSize int
+	Status      *entity.NCRStatus
+	Severity    *entity.NCRSeverity
+	NCType      *entity.NCType
+	ReferenceID *uuid.UUID
+	DateFrom    *string
+	DateTo      *string
+	Page        int
+	PageSize    int
+}
+
+// CAPARepository defines CAPA repository interface
+type CAPARepository interface {
+	Create(ctx context.Context, capa *entity.CAPA) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.CAPA, error)
+	List(ctx context.Context, filter CAPAFilter) ([]*entity.CAPA, int64, error)
+	Update(ctx context.Context, capa *entity.CAPA) error
+
+	// Actions
+	CreateAction(ctx context.Context, action *entity.CAPAAction) error
+	UpdateAction(ctx context.Context, action *entity.CAPAAction) error
+	GetOverdueActions(ctx context.Context, asOf time.Time) ([]*entity.CAPAAction, error)
+	GetOpenCriticalActionsBySource(ctx context.Context, sourceType entity.CAPASourceType, sourceID uuid.UUID) ([]*entity.CAPAAction, error)
+
+	// Links
+	CreateLink(ctx context.Context, link *entity.CAPALink) error
+
+	// Number generation
+	GenerateCAPANumber(ctx context.Context) (string, error)
+}
+
+// CAPAFilter for filtering CAPAs
+type CAPAFilter struct {
+	Status     *entity.CAPAStatus
+	OwnerID    *uuid.UUID
+	SourceType *entity.CAPASourceType
+	SourceID   *uuid.UUID
+	Page       int
+	PageSize   int
+}
+
+// RecallRepository defines recall repository interface
+type RecallRepository interface {
+	Create(ctx context.Context, recall *entity.Recall) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error)
+	List(ctx context.Context, filter RecallFilter) ([]*entity.Recall, int64, error)
+	Update(ctx context.Context, recall *entity.Recall) error
+	GenerateRecallNumber(ctx context.Context) (string, error)
+}
+
+// RecallFilter defines filters for recall queries
+type RecallFilter struct {
+	Mode          *entity.RecallMode
+	Status        *entity.RecallStatus
+	MaterialLotID *uuid.UUID
+	Page          int
+	PageSize      int
+}
+
+// StabilityRepository defines stability study repository interface
+type StabilityRepository interface {
+	Create(ctx context.Context, study *entity.StabilityStudy) error // With conditions and pull points
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.StabilityStudy, error)
+	List(ctx context.Context, filter StabilityFilter) ([]*entity.StabilityStudy, int64, error)
+	Update(ctx context.Context, study *entity.StabilityStudy) error
+	UpdatePullPoint(ctx context.Context, pullPoint *entity.StabilityPullPoint) error
+	GenerateStudyNumber(ctx context.Context) (string, error)
+}
+
+// StabilityFilter defines filters for stability study queries
+type StabilityFilter struct {
+	ProductID *uuid.UUID
+	BOMID     *uuid.UUID
+	Status    *entity.StabilityStudyStatus
+	Page      int
+	PageSize  int
+}
+
+// EquipmentRepository defines equipment repository interface
+type EquipmentRepository interface {
+	Create(ctx context.Context, equipment *entity.Equipment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Equipment, error) // With calibrations and maintenance plans
+	GetByCode(ctx context.Context, code string) (*entity.Equipment, error) // nil if none
+	List(ctx context.Context, filter EquipmentFilter) ([]*entity.Equipment, int64, error)
+	Update(ctx context.Context, equipment *entity.Equipment) error
+	GetByWorkCenter(ctx context.Context, workCenterID uuid.UUID) ([]*entity.Equipment, error)
+	GetCalibrationsDue(ctx context.Context, before time.Time) ([]*entity.Equipment, error)
+
+	// Calibrations
+	CreateCalibration(ctx context.Context, record *entity.CalibrationRecord) error
+
+	// Preventive maintenance
+	CreateMaintenancePlan(ctx context.Context, plan *entity.MaintenancePlan) error
+	GetMaintenancePlanByID(ctx context.Context, id uuid.UUID) (*entity.MaintenancePlan, error)
+	UpdateMaintenancePlan(ctx context.Context, plan *entity.MaintenancePlan) error
+	GetMaintenanceDue(ctx context.Context, before time.Time) ([]*entity.MaintenancePlan, error)
+	CreateMaintenanceRecord(ctx context.Context, record *entity.MaintenanceRecord) error
+	GetMaintenanceRecords(ctx context.Context, equipmentID uuid.UUID) ([]*entity.MaintenanceRecord, error)
+}
+
+// EquipmentFilter defines filters for equipment queries
+type EquipmentFilter struct {
+	EquipmentType  *entity.EquipmentType
+	Status         *entity.EquipmentStatus
+	ProductionLine string
+	WorkCenterID   *uuid.UUID
+	CalibrationDue *time.Time // Calibration due on or before this date
+	Search         string
+	Page           int
+	PageSize       int
+}
+
+// LineClearanceRepository defines line clearance repository interface
+type LineClearanceRepository interface {
+	Create(ctx context.Context, clearance *entity.LineClearance) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.LineClearance, error)
+	GetByWorkOrder(ctx context.Context, woID uuid.UUID) (*entity.LineClearance, error) // Latest clearance of the work order
+	List(ctx context.Context, filter LineClearanceFilter) ([]*entity.LineClearance, int64, error)
+	Update(ctx context.Context, clearance *entity.LineClearance) error
+	UpdateItem(ctx context.Context, item *entity.LineClearanceItem) error
+	GenerateClearanceNumber(ctx context.Context) (string, error)
+}
+
+// LineClearanceFilter defines filter for listing line clearances
+type LineClearanceFilter struct {
+	ProductionLine string
+	WorkOrderID    *uuid.UUID
+	Status         *entity.LineClearanceStatus
+	Page           int
+	PageSize       int
 }
 
 // TraceabilityRepository defines traceability repository interface
@@ -143,6 +337,96 @@ type TraceabilityRepository interface {
 	// Forward trace: material lot → product lots
 	GetByMaterialLot(ctx context.Context, materialLotID uuid.UUID) ([]*entity.BatchTraceability, error)
 	
-	// Update product lot after WO completion
+	// Update primary product lot after WO completion (co-product/by-product traces keep their own lot)
 	UpdateProductLot(ctx context.Context, woID uuid.UUID, productLotID uuid.UUID, productLotNumber string) error
 }
+
+// RoutingRepository defines routing and work center repository interface
+type RoutingRepository interface {
+	// Work centers
+	CreateWorkCenter(ctx context.Context, wc *entity.WorkCenter) error
+	GetWorkCenterByID(ctx context.Context, id uuid.UUID) (*entity.WorkCenter, error)
+	ListWorkCenters(ctx context.Context) ([]*entity.WorkCenter, error)
+
+	// Routings
+	Create(ctx context.Context, routing *entity.Routing) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Routing, error)
+	GetActiveForProduct(ctx context.Context, productID uuid.UUID) (*entity.Routing, error) // nil if none
+	List(ctx context.Context, filter RoutingFilter) ([]*entity.Routing, int64, error)
+	Update(ctx context.Context, routing *entity.Routing) error
+
+	// Marks other active routings of the product as obsolete
+	ObsoleteActiveForProduct(ctx context.Context, productID uuid.UUID, exceptID uuid.UUID) error
+}
+
+// RoutingFilter for filtering routings
+type RoutingFilter struct {
+	ProductID *uuid.UUID
+	Status    *entity.RoutingStatus
+	Page      int
+	PageSize  int
+}
+
+// WOOperationRepository defines work order operation repository interface
+type WOOperationRepository interface {
+	CreateOperations(ctx context.Context, ops []*entity.WOOperation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.WOOperation, error)
+	GetByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.WOOperation, error)
+	Update(ctx context.Context, op *entity.WOOperation) error
+
+	// Execution logs
+	CreateLog(ctx context.Context, log *entity.WOOperationLog) error
+}
+
+// BatchRecordRepository defines electronic batch record repository interface
+type BatchRecordRepository interface {
+	Create(ctx context.Context, record *entity.BatchRecord) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.BatchRecord, error)
+	GetLatestByBatch(ctx context.Context, batchNumber string) (*entity.BatchRecord, error)
+	ListByBatch(ctx context.Context, batchNumber string) ([]*entity.BatchRecord, error)
+
+	// UpdateStatus persists status/release fields only; content is immutable
+	UpdateStatus(ctx context.Context, record *entity.BatchRecord) error
+
+	// Signatures
+	CreateSignature(ctx context.Context, sig *entity.BatchRecordSignature) error
+
+	// Number generation
+	GenerateRecordNumber(ctx context.Context) (string, error)
+}
+
+// DispensingRepository defines weighing ticket repository interface
+type DispensingRepository interface {
+	CreateTicket(ctx context.Context, ticket *entity.WeighingTicket) error
+	GetTicketByID(ctx context.Context, id uuid.UUID) (*entity.WeighingTicket, error)
+	GetTicketsByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.WeighingTicket, error)
+	UpdateTicket(ctx context.Context, ticket *entity.WeighingTicket) error
+
+	// Readings
+	CreateReading(ctx context.Context, reading *entity.WeighingReading) error
+
+	// Number generation
+	GenerateTicketNumber(ctx context.Context) (string, error)
+}
+
+// CostingRepository defines work order costing repository interface
+type CostingRepository interface {
+	// SaveWOCost replaces the cost of the work order, including its lines
+	SaveWOCost(ctx context.Context, cost *entity.WOCost) error
+	GetWOCostByWorkOrder(ctx context.Context, woID uuid.UUID) (*entity.WOCost, error)
+
+	// Overhead absorption rates
+	GetOverheadRate(ctx context.Context, productionLine string) (*entity.LineOverheadRate, error)
+	ListOverheadRates(ctx context.Context) ([]*entity.LineOverheadRate, error)
+	SaveOverheadRate(ctx context.Context, rate *entity.LineOverheadRate) error
+}
+
+// ErrNotFound is returned by lookups of a record that does not exist
+var ErrNotFound = errors.New("record not found")
+
+// Transactor runs several repository calls in one database transaction
+type Transactor interface {
+	// WithinTransaction runs fn with a context that carries the transaction;
+	// repositories called with that context join it. Nested calls join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -23,15 +23,27 @@ func NewPublisher(client *nats.Client, logger *zap.Logger) *Publisher {
 
 // Event subjects
 const (
-	SubjectBOMCreated     = "manufacturing.bom.created"
-	SubjectBOMApproved    = "manufacturing.bom.approved"
-	SubjectWOCreated      = "manufacturing.wo.created"
-	SubjectWOReleased     = "manufacturing.wo.released"
-	SubjectWOStarted      = "manufacturing.wo.started"
-	SubjectWOCompleted    = "manufacturing.wo.completed"
-	SubjectQCPassed       = "manufacturing.qc.passed"
-	SubjectQCFailed       = "manufacturing.qc.failed"
-	SubjectNCRCreated     = "manufacturing.ncr.created"
+	SubjectBOMCreated           = "manufacturing.bom.created"
+	SubjectBOMApproved          = "manufacturing.bom.approved"
+	SubjectWOCreated            = "manufacturing.wo.created"
+	SubjectWOReleased           = "manufacturing.wo.released"
+	SubjectWOStarted            = "manufacturing.wo.started"
+	SubjectWOCompleted          = "manufacturing.wo.completed"
+	SubjectWOCancelled          = "manufacturing.wo.cancelled"
+	SubjectQCPassed             = "manufacturing.qc.passed"
+	SubjectQCFailed             = "manufacturing.qc.failed"
+	SubjectNCRCreated           = "manufacturing.ncr.created"
+	SubjectWOOperationCompleted = "manufacturing.wo.operation.completed"
+	SubjectBatchReleased        = "manufacturing.batch.released"
+	SubjectMaterialIssued       = "manufacturing.wo.material.issued"
+	SubjectWOBackflushed        = "manufacturing.wo.backflushed"
+	SubjectMaterialReturned     = "manufacturing.wo.material.returned"
+	SubjectSPCViolation         = "manufacturing.qc.spc.violation"
+	SubjectCAPAActionOverdue    = "manufacturing.capa.action.overdue"
+	SubjectRecallInitiated      = "manufacturing.recall.initiated"
+	SubjectCalibrationDue       = "manufacturing.equipment.calibration.due"
+	SubjectMaintenanceDue       = "manufacturing.equipment.maintenance.due"
+	SubjectWOCosted             = "manufacturing.wo.costed"
 )
 
 // BOMEvent represents a BOM event payload
@@ -56,12 +68,26 @@ type WOEvent struct {
 
 // WOCompletedEvent represents a completed work order event
 type WOCompletedEvent struct {
-	WOID         string  `json:"wo_id"`
-	WONumber     string  `json:"wo_number"`
-	ProductID    string  `json:"product_id"`
-	BatchNumber  string  `json:"batch_number"`
-	GoodQuantity float64 `json:"good_quantity"`
-	OutputLotID  string  `json:"output_lot_id,omitempty"`
+	WOID           string     `json:"wo_id"`
+	WONumber       string     `json:"wo_number"`
+	ProductID      string     `json:"product_id"`
+	BatchNumber    string     `json:"batch_number"`
+	GoodQuantity   float64    `json:"good_quantity"`
+	UOMID          string     `json:"uom_id"`
+	OutputLotID    string     `json:"output_lot_id,omitempty"`
+	SourceLotID    string     `json:"source_lot_id,omitempty"`   // Rework: rejected lot consumed
+	SourceQuantity float64    `json:"source_quantity,omitempty"` // Rework: quantity of the rejected lot consumed
+	Outputs        []WOOutput `json:"outputs,omitempty"`         // Co-products and by-products
+}
+
+// WOOutput represents a co-product or by-product lot to receive
+type WOOutput struct {
+	OutputType string  `json:"output_type"`
+	ProductID  string  `json:"product_id"`
+	LotID      string  `json:"lot_id"`
+	LotNumber  string  `json:"lot_number"`
+	Quantity   float64 `json:"quantity"`
+	UOMID      string  `json:"uom_id"`
 }
 
 // QCEvent represents a QC event payload
@@ -85,6 +111,143 @@ type NCREvent struct {
 	LotID       string `json:"lot_id,omitempty"`
 }
 
+// OperationEvent represents a work order operation event payload
+type OperationEvent struct {
+	WOID           string  `json:"wo_id"`
+	WONumber       string  `json:"wo_number"`
+	OperationID    string  `json:"operation_id"`
+	OperationCode  string  `json:"operation_code"`
+	Sequence       int     `json:"sequence"`
+	ActualMinutes  float64 `json:"actual_minutes"`
+	HasDeviation   bool    `json:"has_deviation"`
+	QCInspectionID string  `json:"qc_inspection_id,omitempty"`
+}
+
+// BatchRecordEvent represents a batch record event payload
+type BatchRecordEvent struct {
+	BatchRecordID string `json:"batch_record_id"`
+	RecordNumber  string `json:"record_number"`
+	BatchNumber   string `json:"batch_number"`
+	WOID          string `json:"wo_id"`
+	Version       int    `json:"version"`
+	ContentHash   string `json:"content_hash"`
+}
+
+// MaterialIssuedEvent represents a material issue posted from a confirmed weighing
+type MaterialIssuedEvent struct {
+	WOID             string  `json:"wo_id"`
+	IssueID          string  `json:"issue_id"`
+	IssueNumber      string  `json:"issue_number"`
+	WeighingTicketID string  `json:"weighing_ticket_id"`
+	MaterialID       string  `json:"material_id"`
+	LotID            string  `json:"lot_id"`
+	LotNumber        string  `json:"lot_number"`
+	Quantity         float64 `json:"quantity"`
+	UOMID            string  `json:"uom_id"`
+}
+
+// WOBackflushEvent represents components consumed by backflush on WO completion
+type WOBackflushEvent struct {
+	WOID      string              `json:"wo_id"`
+	WONumber  string              `json:"wo_number"`
+	Materials []BackflushMaterial `json:"materials"`
+}
+
+// BackflushMaterial represents a backflushed component quantity
+type BackflushMaterial struct {
+	WOLineItemID string  `json:"wo_line_item_id"`
+	MaterialID   string  `json:"material_id"`
+	Quantity     float64 `json:"quantity"`
+	UOMID        string  `json:"uom_id"`
+}
+
+// MaterialReturnedEvent represents surplus material returned to the warehouse
+type MaterialReturnedEvent struct {
+	WOID     string        `json:"wo_id"`
+	WONumber string        `json:"wo_number"`
+	Items    []ReturnedLot `json:"items"`
+}
+
+// ReturnedLot represents a returned lot quantity
+type ReturnedLot struct {
+	IssueID     string  `json:"issue_id"`
+	IssueNumber string  `json:"issue_number"`
+	MaterialID  string  `json:"material_id"`
+	LotID       string  `json:"lot_id"`
+	LotNumber   string  `json:"lot_number"`
+	Quantity    float64 `json:"quantity"`
+	UOMID       string  `json:"uom_id"`
+}
+
+// SPCViolationEvent represents a Western Electric rule violation on a control chart
+type SPCViolationEvent struct {
+	ProductID        string  `json:"product_id"`
+	TestName         string  `json:"test_name"`
+	InspectionID     string  `json:"inspection_id"`
+	InspectionNumber string  `json:"inspection_number"`
+	LotNumber        string  `json:"lot_number"`
+	Rule             string  `json:"rule"`
+	Description      string  `json:"description"`
+	Value            float64 `json:"value"`
+	CenterLine       float64 `json:"center_line"`
+	UCL              float64 `json:"ucl"`
+	LCL              float64 `json:"lcl"`
+	OutOfSpec        bool    `json:"out_of_spec"`
+}
+
+// CAPAActionOverdueEvent represents a reminder for an overdue CAPA action
+type CAPAActionOverdueEvent struct {
+	CAPAID      string `json:"capa_id"`
+	CAPANumber  string `json:"capa_number"`
+	CAPATitle   string `json:"capa_title"`
+	CAPAOwnerID string `json:"capa_owner_id"`
+	ActionID    string `json:"action_id"`
+	ActionType  string `json:"action_type"`
+	Description string `json:"description"`
+	OwnerID     string `json:"owner_id"`
+	DueDate     string `json:"due_date"`
+	DaysOverdue int    `json:"days_overdue"`
+	IsCritical  bool   `json:"is_critical"`
+}
+
+// RecallInitiatedEvent asks WMS to block the recalled lots
+type RecallInitiatedEvent struct {
+	RecallID     string   `json:"recall_id"`
+	RecallNumber string   `json:"recall_number"`
+	Reason       string   `json:"reason"`
+	LotIDs       []string `json:"lot_ids"`
+}
+
+// EquipmentDueEvent represents an alert for a calibration or preventive
+// maintenance that is due soon or overdue
+type EquipmentDueEvent struct {
+	EquipmentID       string `json:"equipment_id"`
+	EquipmentCode     string `json:"equipment_code"`
+	EquipmentName     string `json:"equipment_name"`
+	EquipmentType     string `json:"equipment_type"`
+	ProductionLine    string `json:"production_line"`
+	DueType           string `json:"due_type"` // CALIBRATION, MAINTENANCE
+	PlanID            string `json:"plan_id,omitempty"`
+	PlanName          string `json:"plan_name,omitempty"`
+	DueDate           string `json:"due_date"`
+	DaysUntilDue      int    `json:"days_until_due"` // Negative when overdue
+	IsOverdue         bool   `json:"is_overdue"`
+	ResponsibleUserID string `json:"responsible_user_id,omitempty"`
+}
+
+// WOCostedEvent carries the actual unit cost of a work order's output - WMS
+// values the finished goods lot with it
+type WOCostedEvent struct {
+	WOID           string  `json:"wo_id"`
+	WONumber       string  `json:"wo_number"`
+	ProductID      string  `json:"product_id"`
+	BatchNumber    string  `json:"batch_number"`
+	OutputLotID    string  `json:"output_lot_id,omitempty"`
+	OutputQuantity float64 `json:"output_quantity"`
+	UnitCost       float64 `json:"unit_cost"`
+	TotalCost      float64 `json:"total_cost"`
+}
+
 // Publish publishes an event
 func (p *Publisher) Publish(subject string, payload interface{}) error {
 	if p.client == nil {
@@ -143,6 +306,11 @@ func (p *Publisher) PublishWOCompleted(event WOCompletedEvent) error {
 	return p.Publish(SubjectWOCompleted, event)
 }
 
+// PublishWOCancelled publishes WO cancelled event
+func (p *Publisher) PublishWOCancelled(event WOEvent) error {
+	return p.Publish(SubjectWOCancelled, event)
+}
+
 // PublishQCPassed publishes QC passed event
 func (p *Publisher) PublishQCPassed(event QCEvent) error {
 	return p.Publish(SubjectQCPassed, event)
@@ -157,3 +325,58 @@ func (p *Publisher) PublishQCFailed(event QCEvent) error {
 func (p *Publisher) PublishNCRCreated(event NCREvent) error {
 	return p.Publish(SubjectNCRCreated, event)
 }
+
+// PublishWOOperationCompleted publishes WO operation completed event
+func (p *Publisher) PublishWOOperationCompleted(event OperationEvent) error {
+	return p.Publish(SubjectWOOperationCompleted, event)
+}
+
+// PublishBatchReleased publishes batch released event - batch record locked after QA sign-off
+func (p *Publisher) PublishBatchReleased(event BatchRecordEvent) error {
+	return p.Publish(SubjectBatchReleased, event)
+}
+
+// PublishMaterialIssued publishes material issued event - WMS deducts the dispensed lot
+func (p *Publisher) PublishMaterialIssued(event MaterialIssuedEvent) error {
+	return p.Publish(SubjectMaterialIssued, event)
+}
+
+// PublishWOBackflushed publishes WO backflushed event - WMS consumes the components (FEFO)
+func (p *Publisher) PublishWOBackflushed(event WOBackflushEvent) error {
+	return p.Publish(SubjectWOBackflushed, event)
+}
+
+// PublishMaterialReturned publishes material returned event - WMS receives the surplus lots back
+func (p *Publisher) PublishMaterialReturned(event MaterialReturnedEvent) error {
+	return p.Publish(SubjectMaterialReturned, event)
+}
+
+// PublishSPCViolation publishes SPC violation event - notification-service warns QA
+func (p *Publisher) PublishSPCViolation(event SPCViolationEvent) error {
+	return p.Publish(SubjectSPCViolation, event)
+}
+
+// PublishCAPAActionOverdue publishes a CAPA action overdue reminder
+func (p *Publisher) PublishCAPAActionOverdue(event CAPAActionOverdueEvent) error {
+	return p.Publish(SubjectCAPAActionOverdue, event)
+}
+
+// PublishRecallInitiated publishes recall initiated event - WMS blocks the lots
+func (p *Publisher) PublishRecallInitiated(event RecallInitiatedEvent) error {
+	return p.Publish(SubjectRecallInitiated, event)
+}
+
+// PublishCalibrationDue publishes an equipment calibration due alert
+func (p *Publisher) PublishCalibrationDue(event EquipmentDueEvent) error {
+	return p.Publish(SubjectCalibrationDue, event)
+}
+
+// PublishMaintenanceDue publishes an equipment preventive maintenance due alert
+func (p *Publisher) PublishMaintenanceDue(event EquipmentDueEvent) error {
+	return p.Publish(SubjectMaintenanceDue, event)
+}
+
+// PublishWOCosted publishes work order costed event - WMS revalues the finished goods lot
+func (p *Publisher) PublishWOCosted(event WOCostedEvent) error {
+	return p.Publish(SubjectWOCosted, event)
+}
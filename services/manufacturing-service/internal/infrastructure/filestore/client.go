@@ -0,0 +1,119 @@
+package filestore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client uploads generated documents to file-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new file-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// UploadInput is a document to store and the record it belongs to
+type UploadInput struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+	Category    string // file-service category code, e.g. COA
+	EntityType  string // e.g. LOT
+	EntityID    uuid.UUID
+	CreatedBy   *uuid.UUID
+}
+
+// UploadedFile is the file record returned by file-service
+type UploadedFile struct {
+	ID           uuid.UUID `json:"id"`
+	OriginalName string    `json:"original_name"`
+	FileSize     int64     `json:"file_size"`
+	Checksum     string    `json:"checksum"`
+}
+
+// Upload posts the document to POST /api/v1/files/upload
+func (c *Client) Upload(ctx context.Context, input UploadInput) (*UploadedFile, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, input.FileName))
+	header.Set("Content-Type", input.ContentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(input.Data); err != nil {
+		return nil, err
+	}
+
+	writer.WriteField("category", input.Category)
+	writer.WriteField("entity_type", input.EntityType)
+	writer.WriteField("entity_id", input.EntityID.String())
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/files/upload", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if input.CreatedBy != nil {
+		req.Header.Set("X-User-ID", input.CreatedBy.String())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("file-service upload failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool         `json:"success"`
+		Data    UploadedFile `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode file-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return nil, fmt.Errorf("file-service returned status %d", resp.StatusCode)
+	}
+	return &result.Data, nil
+}
+
+// Delete removes a stored document via DELETE /api/v1/files/:id
+func (c *Client) Delete(ctx context.Context, id uuid.UUID) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+"/api/v1/files/"+id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("file-service delete failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("file-service returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package masterdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client reads materials from master-data-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new master-data-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Material is the material master header
+type Material struct {
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	MaterialType string    `json:"material_type"`
+	IsAllergen   bool      `json:"is_allergen"`
+}
+
+// GetMaterial returns a material by ID
+func (c *Client) GetMaterial(ctx context.Context, id uuid.UUID) (*Material, error) {
+	var material Material
+	if err := c.get(ctx, "/api/v1/materials/"+id.String(), &material); err != nil {
+		return nil, err
+	}
+	return &material, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("master-data-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode master-data-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return fmt.Errorf("master-data-service returned status %d for %s", resp.StatusCode, path)
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode master-data-service data: %w", err)
+	}
+	return nil
+}
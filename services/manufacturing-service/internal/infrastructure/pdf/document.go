@@ -0,0 +1,160 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Page geometry (A4 in points)
+const (
+	pageWidth    = 595.0
+	pageHeight   = 842.0
+	marginLeft   = 50.0
+	marginTop    = 50.0
+	marginBottom = 50.0
+)
+
+type textLine struct {
+	x    float64
+	y    float64
+	size float64
+	bold bool
+	text string
+}
+
+// Document is a minimal text-only PDF writer using the standard Helvetica fonts.
+// It is intended for printable records (batch records, certificates), not layout-heavy documents.
+type Document struct {
+	pages [][]textLine
+	y     float64
+}
+
+// NewDocument creates an empty document with one page
+func NewDocument() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Heading writes a bold line
+func (d *Document) Heading(text string, size float64) {
+	d.write(marginLeft, text, size, true)
+}
+
+// Text writes a regular line
+func (d *Document) Text(text string) {
+	d.write(marginLeft, text, 10, false)
+}
+
+// Field writes a "label: value" line
+func (d *Document) Field(label, value string) {
+	d.write(marginLeft, label+": "+value, 10, false)
+}
+
+// Row writes cells at fixed column offsets (in points from the left margin)
+func (d *Document) Row(bold bool, cols []float64, cells ...string) {
+	d.ensureSpace(14)
+	for i, cell := range cells {
+		x := marginLeft
+		if i < len(cols) {
+			x += cols[i]
+		}
+		d.current().append(textLine{x: x, y: d.y, size: 9, bold: bold, text: cell})
+	}
+	d.y -= 14
+}
+
+// Spacer adds vertical space
+func (d *Document) Spacer() {
+	d.y -= 8
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	writeObj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// 1: catalog, 2: pages, 3: regular font, 4: bold font, then page/content pairs
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	writeObj("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, lines := range d.pages {
+		var content bytes.Buffer
+		for _, l := range lines {
+			font := "F1"
+			if l.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, l.size, l.x, l.y, escape(l.text))
+		}
+		writeObj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2))
+		writeObj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+type page []textLine
+
+func (p *page) append(l textLine) {
+	*p = append(*p, l)
+}
+
+func (d *Document) current() *page {
+	return (*page)(&d.pages[len(d.pages)-1])
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - marginTop
+}
+
+func (d *Document) ensureSpace(height float64) {
+	if d.y-height < marginBottom {
+		d.newPage()
+	}
+}
+
+func (d *Document) write(x float64, text string, size float64, bold bool) {
+	d.ensureSpace(size + 4)
+	d.current().append(textLine{x: x, y: d.y, size: size, bold: bold, text: text})
+	d.y -= size + 4
+}
+
+// escape escapes PDF string delimiters and replaces characters outside WinAnsi's ASCII range
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type batchRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewBatchRecordRepository creates a new batch record repository
+func NewBatchRecordRepository(db *gorm.DB) repository.BatchRecordRepository {
+	return &batchRecordRepository{db: db}
+}
+
+func (r *batchRecordRepository) Create(ctx context.Context, record *entity.BatchRecord) error {
+	return conn(ctx, r.db).Omit("Signatures").Create(record).Error
+}
+
+func (r *batchRecordRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BatchRecord, error) {
+	var record entity.BatchRecord
+	err := conn(ctx, r.db).
+		Preload("Signatures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("signed_at ASC")
+		}).
+		First(&record, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *batchRecordRepository) GetLatestByBatch(ctx context.Context, batchNumber string) (*entity.BatchRecord, error) {
+	var record entity.BatchRecord
+	err := conn(ctx, r.db).
+		Preload("Signatures", func(db *gorm.DB) *gorm.DB {
+			return db.Order("signed_at ASC")
+		}).
+		Where("batch_number = ?", batchNumber).
+		Order("version DESC").
+		First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *batchRecordRepository) ListByBatch(ctx context.Context, batchNumber string) ([]*entity.BatchRecord, error) {
+	var records []*entity.BatchRecord
+	err := conn(ctx, r.db).
+		Omit("content").
+		Preload("Signatures").
+		Where("batch_number = ?", batchNumber).
+		Order("version DESC").
+		Find(&records).Error
+	return records, err
+}
+
+func (r *batchRecordRepository) UpdateStatus(ctx context.Context, record *entity.BatchRecord) error {
+	return conn(ctx, r.db).
+		Model(record).
+		Select("status", "released_by", "released_at", "updated_at").
+		Updates(record).Error
+}
+
+func (r *batchRecordRepository) CreateSignature(ctx context.Context, sig *entity.BatchRecordSignature) error {
+	return conn(ctx, r.db).Create(sig).Error
+}
+
+func (r *batchRecordRepository) GenerateRecordNumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.BatchRecord{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("BMR-%d-%04d", year, count+1), nil
+}
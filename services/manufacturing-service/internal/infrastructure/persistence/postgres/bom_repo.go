@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,12 +22,12 @@ func NewBOMRepository(db *gorm.DB) repository.BOMRepository {
 }
 
 func (r *bomRepository) Create(ctx context.Context, bom *entity.BOM) error {
-	return r.db.WithContext(ctx).Create(bom).Error
+	return conn(ctx, r.db).Create(bom).Error
 }
 
 func (r *bomRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BOM, error) {
 	var bom entity.BOM
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		First(&bom, "id = ?", id).Error
 	if err != nil {
@@ -37,7 +38,7 @@ func (r *bomRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BOM,
 
 func (r *bomRepository) GetByNumber(ctx context.Context, bomNumber string) (*entity.BOM, error) {
 	var bom entity.BOM
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		First(&bom, "bom_number = ?", bomNumber).Error
 	if err != nil {
@@ -48,7 +49,7 @@ func (r *bomRepository) GetByNumber(ctx context.Context, bomNumber string) (*ent
 
 func (r *bomRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.BOM, error) {
 	var boms []*entity.BOM
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("product_id = ?", productID).
 		Order("version DESC").
 		Find(&boms).Error
@@ -57,7 +58,7 @@ func (r *bomRepository) GetByProductID(ctx context.Context, productID uuid.UUID)
 
 func (r *bomRepository) GetActiveBOMForProduct(ctx context.Context, productID uuid.UUID) (*entity.BOM, error) {
 	var bom entity.BOM
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		Where("product_id = ? AND status = ?", productID, entity.BOMStatusApproved).
 		Where("effective_from <= ? OR effective_from IS NULL", time.Now()).
@@ -74,7 +75,7 @@ func (r *bomRepository) List(ctx context.Context, filter repository.BOMFilter) (
 	var boms []*entity.BOM
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.BOM{})
+	query := conn(ctx, r.db).Model(&entity.BOM{})
 
 	if filter.ProductID != nil {
 		query = query.Where("product_id = ?", *filter.ProductID)
@@ -99,21 +100,21 @@ func (r *bomRepository) List(ctx context.Context, filter repository.BOMFilter) (
 }
 
 func (r *bomRepository) Update(ctx context.Context, bom *entity.BOM) error {
-	return r.db.WithContext(ctx).Save(bom).Error
+	return conn(ctx, r.db).Save(bom).Error
 }
 
 func (r *bomRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.BOM{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.BOM{}, "id = ?", id).Error
 }
 
 // Line items
 func (r *bomRepository) CreateLineItem(ctx context.Context, item *entity.BOMLineItem) error {
-	return r.db.WithContext(ctx).Create(item).Error
+	return conn(ctx, r.db).Create(item).Error
 }
 
 func (r *bomRepository) GetLineItems(ctx context.Context, bomID uuid.UUID) ([]*entity.BOMLineItem, error) {
 	var items []*entity.BOMLineItem
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("bom_id = ?", bomID).
 		Order("line_number ASC").
 		Find(&items).Error
@@ -121,21 +122,21 @@ func (r *bomRepository) GetLineItems(ctx context.Context, bomID uuid.UUID) ([]*e
 }
 
 func (r *bomRepository) UpdateLineItem(ctx context.Context, item *entity.BOMLineItem) error {
-	return r.db.WithContext(ctx).Save(item).Error
+	return conn(ctx, r.db).Save(item).Error
 }
 
 func (r *bomRepository) DeleteLineItem(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.BOMLineItem{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.BOMLineItem{}, "id = ?", id).Error
 }
 
 // Versioning
 func (r *bomRepository) CreateVersion(ctx context.Context, version *entity.BOMVersion) error {
-	return r.db.WithContext(ctx).Create(version).Error
+	return conn(ctx, r.db).Create(version).Error
 }
 
 func (r *bomRepository) GetVersions(ctx context.Context, bomID uuid.UUID) ([]*entity.BOMVersion, error) {
 	var versions []*entity.BOMVersion
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("bom_id = ?", bomID).
 		Order("version DESC").
 		Find(&versions).Error
@@ -153,15 +154,19 @@ func NewWorkOrderRepository(db *gorm.DB) repository.WorkOrderRepository {
 }
 
 func (r *workOrderRepository) Create(ctx context.Context, wo *entity.WorkOrder) error {
-	return r.db.WithContext(ctx).Create(wo).Error
+	return conn(ctx, r.db).Create(wo).Error
 }
 
 func (r *workOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WorkOrder, error) {
 	var wo entity.WorkOrder
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		Preload("MaterialIssues").
+		Preload("Outputs").
 		First(&wo, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repository.ErrNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -170,7 +175,7 @@ func (r *workOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entit
 
 func (r *workOrderRepository) GetByNumber(ctx context.Context, woNumber string) (*entity.WorkOrder, error) {
 	var wo entity.WorkOrder
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		First(&wo, "wo_number = ?", woNumber).Error
 	if err != nil {
@@ -183,7 +188,7 @@ func (r *workOrderRepository) List(ctx context.Context, filter repository.WOFilt
 	var wos []*entity.WorkOrder
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.WorkOrder{})
+	query := conn(ctx, r.db).Model(&entity.WorkOrder{})
 
 	if filter.ProductID != nil {
 		query = query.Where("product_id = ?", *filter.ProductID)
@@ -214,20 +219,20 @@ func (r *workOrderRepository) List(ctx context.Context, filter repository.WOFilt
 }
 
 func (r *workOrderRepository) Update(ctx context.Context, wo *entity.WorkOrder) error {
-	return r.db.WithContext(ctx).Save(wo).Error
+	return conn(ctx, r.db).Save(wo).Error
 }
 
 func (r *workOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.WorkOrder{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.WorkOrder{}, "id = ?", id).Error
 }
 
 func (r *workOrderRepository) CreateLineItems(ctx context.Context, items []*entity.WOLineItem) error {
-	return r.db.WithContext(ctx).Create(&items).Error
+	return conn(ctx, r.db).Create(&items).Error
 }
 
 func (r *workOrderRepository) GetLineItems(ctx context.Context, woID uuid.UUID) ([]*entity.WOLineItem, error) {
 	var items []*entity.WOLineItem
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("work_order_id = ?", woID).
 		Order("line_number ASC").
 		Find(&items).Error
@@ -235,26 +240,68 @@ func (r *workOrderRepository) GetLineItems(ctx context.Context, woID uuid.UUID)
 }
 
 func (r *workOrderRepository) UpdateLineItem(ctx context.Context, item *entity.WOLineItem) error {
-	return r.db.WithContext(ctx).Save(item).Error
+	return conn(ctx, r.db).Save(item).Error
 }
 
 func (r *workOrderRepository) CreateMaterialIssue(ctx context.Context, issue *entity.WOMaterialIssue) error {
-	return r.db.WithContext(ctx).Create(issue).Error
+	return conn(ctx, r.db).Create(issue).Error
 }
 
 func (r *workOrderRepository) GetMaterialIssues(ctx context.Context, woID uuid.UUID) ([]*entity.WOMaterialIssue, error) {
 	var issues []*entity.WOMaterialIssue
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("work_order_id = ?", woID).
 		Order("created_at ASC").
 		Find(&issues).Error
 	return issues, err
 }
 
+func (r *workOrderRepository) CreateOutputs(ctx context.Context, outputs []*entity.WOOutput) error {
+	return conn(ctx, r.db).Create(&outputs).Error
+}
+
+func (r *workOrderRepository) GetOutputs(ctx context.Context, woID uuid.UUID) ([]*entity.WOOutput, error) {
+	var outputs []*entity.WOOutput
+	err := conn(ctx, r.db).
+		Where("work_order_id = ?", woID).
+		Order("created_at ASC").
+		Find(&outputs).Error
+	return outputs, err
+}
+
+func (r *workOrderRepository) UpdateOutput(ctx context.Context, output *entity.WOOutput) error {
+	return conn(ctx, r.db).Save(output).Error
+}
+
+func (r *workOrderRepository) GetLastStartedOnLine(ctx context.Context, productionLine string, excludeID uuid.UUID) (*entity.WorkOrder, error) {
+	var wo entity.WorkOrder
+	err := conn(ctx, r.db).
+		Preload("Items").
+		Where("production_line = ? AND id <> ? AND actual_start_date IS NOT NULL", productionLine, excludeID).
+		Order("actual_start_date DESC").
+		First(&wo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &wo, nil
+}
+
+func (r *workOrderRepository) GetPlannedReworkQuantity(ctx context.Context, ncrID uuid.UUID) (float64, error) {
+	var total float64
+	err := conn(ctx, r.db).Model(&entity.WorkOrder{}).
+		Select("COALESCE(SUM(planned_quantity), 0)").
+		Where("ncr_id = ? AND wo_type = ? AND status <> ?", ncrID, entity.WOTypeRework, entity.WOStatusCancelled).
+		Scan(&total).Error
+	return total, err
+}
+
 func (r *workOrderRepository) GenerateWONumber(ctx context.Context) (string, error) {
 	var count int64
 	year := time.Now().Year()
-	r.db.WithContext(ctx).Model(&entity.WorkOrder{}).
+	conn(ctx, r.db).Model(&entity.WorkOrder{}).
 		Where("EXTRACT(YEAR FROM created_at) = ?", year).
 		Count(&count)
 	return fmt.Sprintf("WO-%d-%04d", year, count+1), nil
@@ -263,7 +310,7 @@ func (r *workOrderRepository) GenerateWONumber(ctx context.Context) (string, err
 func (r *workOrderRepository) GenerateIssueNumber(ctx context.Context) (string, error) {
 	var count int64
 	year := time.Now().Year()
-	r.db.WithContext(ctx).Model(&entity.WOMaterialIssue{}).
+	conn(ctx, r.db).Model(&entity.WOMaterialIssue{}).
 		Where("EXTRACT(YEAR FROM created_at) = ?", year).
 		Count(&count)
 	return fmt.Sprintf("ISS-%d-%04d", year, count+1), nil
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type capaRepository struct {
+	db *gorm.DB
+}
+
+// NewCAPARepository creates a new CAPA repository
+func NewCAPARepository(db *gorm.DB) repository.CAPARepository {
+	return &capaRepository{db: db}
+}
+
+func (r *capaRepository) Create(ctx context.Context, capa *entity.CAPA) error {
+	return conn(ctx, r.db).Create(capa).Error
+}
+
+func (r *capaRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CAPA, error) {
+	var capa entity.CAPA
+	err := conn(ctx, r.db).
+		Preload("Actions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("due_date ASC")
+		}).
+		Preload("Links").
+		First(&capa, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &capa, nil
+}
+
+func (r *capaRepository) List(ctx context.Context, filter repository.CAPAFilter) ([]*entity.CAPA, int64, error) {
+	var capas []*entity.CAPA
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.CAPA{})
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.SourceType != nil || filter.SourceID != nil {
+		links := r.db.Model(&entity.CAPALink{}).Select("capa_id")
+		if filter.SourceType != nil {
+			links = links.Where("source_type = ?", *filter.SourceType)
+		}
+		if filter.SourceID != nil {
+			links = links.Where("source_id = ?", *filter.SourceID)
+		}
+		query = query.Where("id IN (?)", links)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Preload("Links").Order("created_at DESC").Find(&capas).Error
+	return capas, total, err
+}
+
+func (r *capaRepository) Update(ctx context.Context, capa *entity.CAPA) error {
+	return conn(ctx, r.db).Omit("Actions", "Links").Save(capa).Error
+}
+
+func (r *capaRepository) CreateAction(ctx context.Context, action *entity.CAPAAction) error {
+	return conn(ctx, r.db).Omit("CAPA").Create(action).Error
+}
+
+func (r *capaRepository) UpdateAction(ctx context.Context, action *entity.CAPAAction) error {
+	return conn(ctx, r.db).Omit("CAPA").Save(action).Error
+}
+
+func (r *capaRepository) GetOverdueActions(ctx context.Context, asOf time.Time) ([]*entity.CAPAAction, error) {
+	var actions []*entity.CAPAAction
+	err := conn(ctx, r.db).
+		Preload("CAPA").
+		Where("status = ? AND due_date < ?", entity.CAPAActionStatusOpen, asOf.Format("2006-01-02")).
+		Order("due_date ASC").
+		Find(&actions).Error
+	return actions, err
+}
+
+func (r *capaRepository) GetOpenCriticalActionsBySource(ctx context.Context, sourceType entity.CAPASourceType, sourceID uuid.UUID) ([]*entity.CAPAAction, error) {
+	var actions []*entity.CAPAAction
+	err := conn(ctx, r.db).
+		Preload("CAPA").
+		Joins("JOIN capa_links ON capa_links.capa_id = capa_actions.capa_id").
+		Where("capa_links.source_type = ? AND capa_links.source_id = ?", sourceType, sourceID).
+		Where("capa_actions.status = ? AND capa_actions.is_critical = ?", entity.CAPAActionStatusOpen, true).
+		Find(&actions).Error
+	return actions, err
+}
+
+func (r *capaRepository) CreateLink(ctx context.Context, link *entity.CAPALink) error {
+	return conn(ctx, r.db).Create(link).Error
+}
+
+func (r *capaRepository) GenerateCAPANumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.CAPA{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("CAPA-%d-%04d", year, count+1), nil
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type coaRepository struct {
+	db *gorm.DB
+}
+
+// NewCoARepository creates a new certificate of analysis repository
+func NewCoARepository(db *gorm.DB) repository.CoARepository {
+	return &coaRepository{db: db}
+}
+
+func (r *coaRepository) Create(ctx context.Context, coa *entity.CertificateOfAnalysis) error {
+	return conn(ctx, r.db).Create(coa).Error
+}
+
+func (r *coaRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CertificateOfAnalysis, error) {
+	var coa entity.CertificateOfAnalysis
+	err := conn(ctx, r.db).First(&coa, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &coa, nil
+}
+
+func (r *coaRepository) List(ctx context.Context, filter repository.CoAFilter) ([]*entity.CertificateOfAnalysis, int64, error) {
+	var coas []*entity.CertificateOfAnalysis
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.CertificateOfAnalysis{})
+	if filter.LotID != nil {
+		query = query.Where("lot_id = ?", *filter.LotID)
+	}
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Order("issued_at DESC").Find(&coas).Error
+	return coas, total, err
+}
+
+func (r *coaRepository) GenerateCoANumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.CertificateOfAnalysis{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("COA-%d-%04d", year, count+1), nil
+}
+
+func (r *coaRepository) CreateSupplierCoA(ctx context.Context, coa *entity.SupplierCoA) error {
+	return conn(ctx, r.db).Create(coa).Error
+}
+
+func (r *coaRepository) GetSupplierCoAByID(ctx context.Context, id uuid.UUID) (*entity.SupplierCoA, error) {
+	var coa entity.SupplierCoA
+	err := conn(ctx, r.db).
+		Preload("Values").
+		First(&coa, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &coa, nil
+}
+
+func (r *coaRepository) ListSupplierCoAs(ctx context.Context, filter repository.SupplierCoAFilter) ([]*entity.SupplierCoA, int64, error) {
+	var coas []*entity.SupplierCoA
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.SupplierCoA{})
+	if filter.GRNID != nil {
+		query = query.Where("grn_id = ?", *filter.GRNID)
+	}
+	if filter.LotID != nil {
+		query = query.Where("lot_id = ?", *filter.LotID)
+	}
+	if filter.MaterialID != nil {
+		query = query.Where("material_id = ?", *filter.MaterialID)
+	}
+	if filter.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filter.SupplierID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Preload("Values").Order("created_at DESC").Find(&coas).Error
+	return coas, total, err
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type costingRepository struct {
+	db *gorm.DB
+}
+
+// NewCostingRepository creates a new work order costing repository
+func NewCostingRepository(db *gorm.DB) repository.CostingRepository {
+	return &costingRepository{db: db}
+}
+
+func (r *costingRepository) SaveWOCost(ctx context.Context, cost *entity.WOCost) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Lines of the previous calculation go with it (ON DELETE CASCADE)
+		if err := tx.Delete(&entity.WOCost{}, "work_order_id = ?", cost.WorkOrderID).Error; err != nil {
+			return err
+		}
+		return tx.Create(cost).Error
+	})
+}
+
+func (r *costingRepository) GetWOCostByWorkOrder(ctx context.Context, woID uuid.UUID) (*entity.WOCost, error) {
+	var cost entity.WOCost
+	err := conn(ctx, r.db).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("cost_element ASC, reference ASC")
+		}).
+		First(&cost, "work_order_id = ?", woID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cost, nil
+}
+
+func (r *costingRepository) GetOverheadRate(ctx context.Context, productionLine string) (*entity.LineOverheadRate, error) {
+	var rate entity.LineOverheadRate
+	err := conn(ctx, r.db).First(&rate, "production_line = ?", productionLine).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *costingRepository) ListOverheadRates(ctx context.Context) ([]*entity.LineOverheadRate, error) {
+	var rates []*entity.LineOverheadRate
+	err := conn(ctx, r.db).Order("production_line ASC").Find(&rates).Error
+	return rates, err
+}
+
+func (r *costingRepository) SaveOverheadRate(ctx context.Context, rate *entity.LineOverheadRate) error {
+	return conn(ctx, r.db).Save(rate).Error
+}
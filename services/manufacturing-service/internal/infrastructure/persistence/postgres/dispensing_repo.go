@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type dispensingRepository struct {
+	db *gorm.DB
+}
+
+// NewDispensingRepository creates a new dispensing repository
+func NewDispensingRepository(db *gorm.DB) repository.DispensingRepository {
+	return &dispensingRepository{db: db}
+}
+
+func (r *dispensingRepository) CreateTicket(ctx context.Context, ticket *entity.WeighingTicket) error {
+	return conn(ctx, r.db).Omit("Readings").Create(ticket).Error
+}
+
+func (r *dispensingRepository) GetTicketByID(ctx context.Context, id uuid.UUID) (*entity.WeighingTicket, error) {
+	var ticket entity.WeighingTicket
+	err := conn(ctx, r.db).
+		Preload("Readings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("read_at ASC")
+		}).
+		First(&ticket, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+func (r *dispensingRepository) GetTicketsByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.WeighingTicket, error) {
+	var tickets []*entity.WeighingTicket
+	err := conn(ctx, r.db).
+		Preload("Readings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("read_at ASC")
+		}).
+		Where("work_order_id = ?", woID).
+		Order("line_number ASC, created_at ASC").
+		Find(&tickets).Error
+	return tickets, err
+}
+
+func (r *dispensingRepository) UpdateTicket(ctx context.Context, ticket *entity.WeighingTicket) error {
+	return conn(ctx, r.db).Omit("Readings").Save(ticket).Error
+}
+
+func (r *dispensingRepository) CreateReading(ctx context.Context, reading *entity.WeighingReading) error {
+	return conn(ctx, r.db).Create(reading).Error
+}
+
+func (r *dispensingRepository) GenerateTicketNumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.WeighingTicket{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("WT-%d-%04d", year, count+1), nil
+}
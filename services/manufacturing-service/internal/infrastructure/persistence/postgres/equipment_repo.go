@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type equipmentRepository struct {
+	db *gorm.DB
+}
+
+// NewEquipmentRepository creates a new equipment repository
+func NewEquipmentRepository(db *gorm.DB) repository.EquipmentRepository {
+	return &equipmentRepository{db: db}
+}
+
+func (r *equipmentRepository) Create(ctx context.Context, equipment *entity.Equipment) error {
+	return conn(ctx, r.db).Create(equipment).Error
+}
+
+func (r *equipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Equipment, error) {
+	var equipment entity.Equipment
+	err := conn(ctx, r.db).
+		Preload("Calibrations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("calibration_date DESC")
+		}).
+		Preload("MaintenancePlans", func(db *gorm.DB) *gorm.DB {
+			return db.Order("next_due_date ASC")
+		}).
+		First(&equipment, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &equipment, nil
+}
+
+func (r *equipmentRepository) GetByCode(ctx context.Context, code string) (*entity.Equipment, error) {
+	var equipment entity.Equipment
+	err := conn(ctx, r.db).First(&equipment, "code = ?", code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &equipment, nil
+}
+
+func (r *equipmentRepository) List(ctx context.Context, filter repository.EquipmentFilter) ([]*entity.Equipment, int64, error) {
+	var equipment []*entity.Equipment
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.Equipment{})
+
+	if filter.EquipmentType != nil {
+		query = query.Where("equipment_type = ?", *filter.EquipmentType)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.ProductionLine != "" {
+		query = query.Where("production_line = ?", filter.ProductionLine)
+	}
+	if filter.WorkCenterID != nil {
+		query = query.Where("work_center_id = ?", *filter.WorkCenterID)
+	}
+	if filter.CalibrationDue != nil {
+		query = query.Where("requires_calibration = ? AND (calibration_due_date IS NULL OR calibration_due_date <= ?)",
+			true, filter.CalibrationDue.Format("2006-01-02"))
+	}
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ?", search, search)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Order("code ASC").Find(&equipment).Error
+	return equipment, total, err
+}
+
+func (r *equipmentRepository) Update(ctx context.Context, equipment *entity.Equipment) error {
+	return conn(ctx, r.db).Omit("Calibrations", "MaintenancePlans").Save(equipment).Error
+}
+
+func (r *equipmentRepository) GetByWorkCenter(ctx context.Context, workCenterID uuid.UUID) ([]*entity.Equipment, error) {
+	var equipment []*entity.Equipment
+	err := conn(ctx, r.db).
+		Where("work_center_id = ? AND status <> ?", workCenterID, entity.EquipmentStatusRetired).
+		Order("code ASC").
+		Find(&equipment).Error
+	return equipment, err
+}
+
+func (r *equipmentRepository) GetCalibrationsDue(ctx context.Context, before time.Time) ([]*entity.Equipment, error) {
+	var equipment []*entity.Equipment
+	err := conn(ctx, r.db).
+		Where("requires_calibration = ? AND status <> ? AND calibration_due_date <= ?",
+			true, entity.EquipmentStatusRetired, before.Format("2006-01-02")).
+		Order("calibration_due_date ASC").
+		Find(&equipment).Error
+	return equipment, err
+}
+
+func (r *equipmentRepository) CreateCalibration(ctx context.Context, record *entity.CalibrationRecord) error {
+	return conn(ctx, r.db).Create(record).Error
+}
+
+func (r *equipmentRepository) CreateMaintenancePlan(ctx context.Context, plan *entity.MaintenancePlan) error {
+	return conn(ctx, r.db).Create(plan).Error
+}
+
+func (r *equipmentRepository) GetMaintenancePlanByID(ctx context.Context, id uuid.UUID) (*entity.MaintenancePlan, error) {
+	var plan entity.MaintenancePlan
+	err := conn(ctx, r.db).First(&plan, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *equipmentRepository) UpdateMaintenancePlan(ctx context.Context, plan *entity.MaintenancePlan) error {
+	return conn(ctx, r.db).Omit("Equipment").Save(plan).Error
+}
+
+func (r *equipmentRepository) GetMaintenanceDue(ctx context.Context, before time.Time) ([]*entity.MaintenancePlan, error) {
+	var plans []*entity.MaintenancePlan
+	err := conn(ctx, r.db).
+		Preload("Equipment").
+		Joins("JOIN equipment ON equipment.id = equipment_maintenance_plans.equipment_id").
+		Where("equipment_maintenance_plans.is_active = ? AND equipment_maintenance_plans.next_due_date <= ?", true, before.Format("2006-01-02")).
+		Where("equipment.status <> ?", entity.EquipmentStatusRetired).
+		Order("equipment_maintenance_plans.next_due_date ASC").
+		Find(&plans).Error
+	return plans, err
+}
+
+func (r *equipmentRepository) CreateMaintenanceRecord(ctx context.Context, record *entity.MaintenanceRecord) error {
+	return conn(ctx, r.db).Create(record).Error
+}
+
+func (r *equipmentRepository) GetMaintenanceRecords(ctx context.Context, equipmentID uuid.UUID) ([]*entity.MaintenanceRecord, error) {
+	var records []*entity.MaintenanceRecord
+	err := conn(ctx, r.db).
+		Where("equipment_id = ?", equipmentID).
+		Order("performed_date DESC").
+		Find(&records).Error
+	return records, err
+}
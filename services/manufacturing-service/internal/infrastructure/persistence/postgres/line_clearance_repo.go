@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type lineClearanceRepository struct {
+	db *gorm.DB
+}
+
+// NewLineClearanceRepository creates a new line clearance repository
+func NewLineClearanceRepository(db *gorm.DB) repository.LineClearanceRepository {
+	return &lineClearanceRepository{db: db}
+}
+
+func (r *lineClearanceRepository) Create(ctx context.Context, clearance *entity.LineClearance) error {
+	return conn(ctx, r.db).Create(clearance).Error
+}
+
+func (r *lineClearanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.LineClearance, error) {
+	var clearance entity.LineClearance
+	err := conn(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		First(&clearance, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &clearance, nil
+}
+
+func (r *lineClearanceRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) (*entity.LineClearance, error) {
+	var clearance entity.LineClearance
+	err := conn(ctx, r.db).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		Order("created_at DESC").
+		First(&clearance, "work_order_id = ?", woID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &clearance, nil
+}
+
+func (r *lineClearanceRepository) List(ctx context.Context, filter repository.LineClearanceFilter) ([]*entity.LineClearance, int64, error) {
+	var clearances []*entity.LineClearance
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.LineClearance{})
+
+	if filter.ProductionLine != "" {
+		query = query.Where("production_line = ?", filter.ProductionLine)
+	}
+	if filter.WorkOrderID != nil {
+		query = query.Where("work_order_id = ?", *filter.WorkOrderID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Order("created_at DESC").Find(&clearances).Error
+	return clearances, total, err
+}
+
+func (r *lineClearanceRepository) Update(ctx context.Context, clearance *entity.LineClearance) error {
+	return conn(ctx, r.db).Omit("Items").Save(clearance).Error
+}
+
+func (r *lineClearanceRepository) UpdateItem(ctx context.Context, item *entity.LineClearanceItem) error {
+	return conn(ctx, r.db).Save(item).Error
+}
+
+func (r *lineClearanceRepository) GenerateClearanceNumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.LineClearance{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("LC-%d-%04d", year, count+1), nil
+}
@@ -23,7 +23,7 @@ func NewQCRepository(db *gorm.DB) repository.QCRepository {
 // Checkpoints
 func (r *qcRepository) GetCheckpoints(ctx context.Context) ([]*entity.QCCheckpoint, error) {
 	var checkpoints []*entity.QCCheckpoint
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("is_active = ?", true).
 		Order("checkpoint_type, code").
 		Find(&checkpoints).Error
@@ -32,7 +32,7 @@ func (r *qcRepository) GetCheckpoints(ctx context.Context) ([]*entity.QCCheckpoi
 
 func (r *qcRepository) GetCheckpointByID(ctx context.Context, id uuid.UUID) (*entity.QCCheckpoint, error) {
 	var checkpoint entity.QCCheckpoint
-	err := r.db.WithContext(ctx).First(&checkpoint, "id = ?", id).Error
+	err := conn(ctx, r.db).First(&checkpoint, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +41,7 @@ func (r *qcRepository) GetCheckpointByID(ctx context.Context, id uuid.UUID) (*en
 
 func (r *qcRepository) GetCheckpointsByType(ctx context.Context, cpType entity.CheckpointType) ([]*entity.QCCheckpoint, error) {
 	var checkpoints []*entity.QCCheckpoint
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("checkpoint_type = ? AND is_active = ?", cpType, true).
 		Find(&checkpoints).Error
 	return checkpoints, err
@@ -49,12 +49,12 @@ func (r *qcRepository) GetCheckpointsByType(ctx context.Context, cpType entity.C
 
 // Inspections
 func (r *qcRepository) CreateInspection(ctx context.Context, inspection *entity.QCInspection) error {
-	return r.db.WithContext(ctx).Create(inspection).Error
+	return conn(ctx, r.db).Create(inspection).Error
 }
 
 func (r *qcRepository) GetInspectionByID(ctx context.Context, id uuid.UUID) (*entity.QCInspection, error) {
 	var inspection entity.QCInspection
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		First(&inspection, "id = ?", id).Error
 	if err != nil {
@@ -65,7 +65,7 @@ func (r *qcRepository) GetInspectionByID(ctx context.Context, id uuid.UUID) (*en
 
 func (r *qcRepository) GetInspectionByNumber(ctx context.Context, number string) (*entity.QCInspection, error) {
 	var inspection entity.QCInspection
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Items").
 		First(&inspection, "inspection_number = ?", number).Error
 	if err != nil {
@@ -78,7 +78,7 @@ func (r *qcRepository) ListInspections(ctx context.Context, filter repository.QC
 	var inspections []*entity.QCInspection
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.QCInspection{})
+	query := conn(ctx, r.db).Model(&entity.QCInspection{})
 
 	if filter.InspectionType != nil {
 		query = query.Where("inspection_type = ?", *filter.InspectionType)
@@ -105,27 +105,61 @@ func (r *qcRepository) ListInspections(ctx context.Context, filter repository.QC
 }
 
 func (r *qcRepository) UpdateInspection(ctx context.Context, inspection *entity.QCInspection) error {
-	return r.db.WithContext(ctx).Save(inspection).Error
+	return conn(ctx, r.db).Save(inspection).Error
 }
 
 // Inspection items
 func (r *qcRepository) CreateInspectionItems(ctx context.Context, items []*entity.QCInspectionItem) error {
-	return r.db.WithContext(ctx).Create(&items).Error
+	return conn(ctx, r.db).Create(&items).Error
 }
 
 func (r *qcRepository) GetInspectionItems(ctx context.Context, inspectionID uuid.UUID) ([]*entity.QCInspectionItem, error) {
 	var items []*entity.QCInspectionItem
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("inspection_id = ?", inspectionID).
 		Order("item_number ASC").
 		Find(&items).Error
 	return items, err
 }
 
+// SPC
+func (r *qcRepository) GetNumericResults(ctx context.Context, filter repository.SPCFilter) ([]entity.SPCObservation, error) {
+	query := conn(ctx, r.db).
+		Table("qc_inspection_items AS i").
+		Select(`i.inspection_id, q.inspection_number, q.inspection_date, q.lot_number,
+			i.numeric_value AS value, i.min_limit, i.max_limit`).
+		Joins("JOIN qc_inspections q ON q.id = i.inspection_id").
+		Where("q.product_id = ? AND LOWER(i.test_name) = LOWER(?) AND i.numeric_value IS NOT NULL",
+			filter.ProductID, filter.TestName).
+		// Stability pulls age under stress conditions and would distort process charts
+		Where("q.inspection_type <> ?", entity.CheckpointTypeStability)
+
+	if filter.DateFrom != nil {
+		query = query.Where("q.inspection_date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		query = query.Where("q.inspection_date <= ?", *filter.DateTo)
+	}
+
+	// Take the most recent results, then return them oldest first for charting
+	var observations []entity.SPCObservation
+	query = query.Order("q.inspection_date DESC, q.created_at DESC, i.item_number DESC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Scan(&observations).Error; err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(observations)-1; i < j; i, j = i+1, j-1 {
+		observations[i], observations[j] = observations[j], observations[i]
+	}
+	return observations, nil
+}
+
 func (r *qcRepository) GenerateInspectionNumber(ctx context.Context) (string, error) {
 	var count int64
 	year := time.Now().Year()
-	r.db.WithContext(ctx).Model(&entity.QCInspection{}).
+	conn(ctx, r.db).Model(&entity.QCInspection{}).
 		Where("EXTRACT(YEAR FROM created_at) = ?", year).
 		Count(&count)
 	return fmt.Sprintf("QC-%d-%04d", year, count+1), nil
@@ -142,12 +176,12 @@ func NewNCRRepository(db *gorm.DB) repository.NCRRepository {
 }
 
 func (r *ncrRepository) Create(ctx context.Context, ncr *entity.NCR) error {
-	return r.db.WithContext(ctx).Create(ncr).Error
+	return conn(ctx, r.db).Create(ncr).Error
 }
 
 func (r *ncrRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.NCR, error) {
 	var ncr entity.NCR
-	err := r.db.WithContext(ctx).First(&ncr, "id = ?", id).Error
+	err := conn(ctx, r.db).First(&ncr, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +190,7 @@ func (r *ncrRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.NCR,
 
 func (r *ncrRepository) GetByNumber(ctx context.Context, ncrNumber string) (*entity.NCR, error) {
 	var ncr entity.NCR
-	err := r.db.WithContext(ctx).First(&ncr, "ncr_number = ?", ncrNumber).Error
+	err := conn(ctx, r.db).First(&ncr, "ncr_number = ?", ncrNumber).Error
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +201,7 @@ func (r *ncrRepository) List(ctx context.Context, filter repository.NCRFilter) (
 	var ncrs []*entity.NCR
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.NCR{})
+	query := conn(ctx, r.db).Model(&entity.NCR{})
 
 	if filter.Status != nil {
 		query = query.Where("status = ?", *filter.Status)
@@ -178,6 +212,9 @@ func (r *ncrRepository) List(ctx context.Context, filter repository.NCRFilter) (
 	if filter.NCType != nil {
 		query = query.Where("nc_type = ?", *filter.NCType)
 	}
+	if filter.ReferenceID != nil {
+		query = query.Where("reference_id = ?", *filter.ReferenceID)
+	}
 
 	query.Count(&total)
 
@@ -191,13 +228,13 @@ func (r *ncrRepository) List(ctx context.Context, filter repository.NCRFilter) (
 }
 
 func (r *ncrRepository) Update(ctx context.Context, ncr *entity.NCR) error {
-	return r.db.WithContext(ctx).Save(ncr).Error
+	return conn(ctx, r.db).Save(ncr).Error
 }
 
 func (r *ncrRepository) GenerateNCRNumber(ctx context.Context) (string, error) {
 	var count int64
 	year := time.Now().Year()
-	r.db.WithContext(ctx).Model(&entity.NCR{}).
+	conn(ctx, r.db).Model(&entity.NCR{}).
 		Where("EXTRACT(YEAR FROM created_at) = ?", year).
 		Count(&count)
 	return fmt.Sprintf("NCR-%d-%04d", year, count+1), nil
@@ -214,16 +251,16 @@ func NewTraceabilityRepository(db *gorm.DB) repository.TraceabilityRepository {
 }
 
 func (r *traceabilityRepository) Create(ctx context.Context, trace *entity.BatchTraceability) error {
-	return r.db.WithContext(ctx).Create(trace).Error
+	return conn(ctx, r.db).Create(trace).Error
 }
 
 func (r *traceabilityRepository) CreateBatch(ctx context.Context, traces []*entity.BatchTraceability) error {
-	return r.db.WithContext(ctx).Create(&traces).Error
+	return conn(ctx, r.db).Create(&traces).Error
 }
 
 func (r *traceabilityRepository) GetByProductLot(ctx context.Context, productLotID uuid.UUID) ([]*entity.BatchTraceability, error) {
 	var traces []*entity.BatchTraceability
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("product_lot_id = ?", productLotID).
 		Find(&traces).Error
 	return traces, err
@@ -231,7 +268,7 @@ func (r *traceabilityRepository) GetByProductLot(ctx context.Context, productLot
 
 func (r *traceabilityRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.BatchTraceability, error) {
 	var traces []*entity.BatchTraceability
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("work_order_id = ?", woID).
 		Find(&traces).Error
 	return traces, err
@@ -239,16 +276,16 @@ func (r *traceabilityRepository) GetByWorkOrder(ctx context.Context, woID uuid.U
 
 func (r *traceabilityRepository) GetByMaterialLot(ctx context.Context, materialLotID uuid.UUID) ([]*entity.BatchTraceability, error) {
 	var traces []*entity.BatchTraceability
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("material_lot_id = ?", materialLotID).
 		Find(&traces).Error
 	return traces, err
 }
 
 func (r *traceabilityRepository) UpdateProductLot(ctx context.Context, woID uuid.UUID, productLotID uuid.UUID, productLotNumber string) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.BatchTraceability{}).
-		Where("work_order_id = ?", woID).
+		Where("work_order_id = ? AND (product_lot_number IS NULL OR product_lot_number = '' OR product_lot_number = ?)", woID, productLotNumber).
 		Updates(map[string]interface{}{
 			"product_lot_id":     productLotID,
 			"product_lot_number": productLotNumber,
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type recallRepository struct {
+	db *gorm.DB
+}
+
+// NewRecallRepository creates a new recall repository
+func NewRecallRepository(db *gorm.DB) repository.RecallRepository {
+	return &recallRepository{db: db}
+}
+
+func (r *recallRepository) Create(ctx context.Context, recall *entity.Recall) error {
+	return conn(ctx, r.db).Create(recall).Error
+}
+
+func (r *recallRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	var recall entity.Recall
+	err := conn(ctx, r.db).First(&recall, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &recall, nil
+}
+
+func (r *recallRepository) List(ctx context.Context, filter repository.RecallFilter) ([]*entity.Recall, int64, error) {
+	var recalls []*entity.Recall
+	var total int64
+
+	// The report snapshot is only returned by GetByID
+	query := conn(ctx, r.db).Model(&entity.Recall{}).Omit("report")
+
+	if filter.Mode != nil {
+		query = query.Where("mode = ?", *filter.Mode)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.MaterialLotID != nil {
+		query = query.Where("material_lot_id = ?", *filter.MaterialLotID)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Order("started_at DESC").Find(&recalls).Error
+	return recalls, total, err
+}
+
+func (r *recallRepository) Update(ctx context.Context, recall *entity.Recall) error {
+	return conn(ctx, r.db).Save(recall).Error
+}
+
+func (r *recallRepository) GenerateRecallNumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.Recall{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("RCL-%d-%04d", year, count+1), nil
+}
@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type routingRepository struct {
+	db *gorm.DB
+}
+
+// NewRoutingRepository creates a new routing repository
+func NewRoutingRepository(db *gorm.DB) repository.RoutingRepository {
+	return &routingRepository{db: db}
+}
+
+// Work centers
+func (r *routingRepository) CreateWorkCenter(ctx context.Context, wc *entity.WorkCenter) error {
+	return conn(ctx, r.db).Create(wc).Error
+}
+
+func (r *routingRepository) GetWorkCenterByID(ctx context.Context, id uuid.UUID) (*entity.WorkCenter, error) {
+	var wc entity.WorkCenter
+	err := conn(ctx, r.db).First(&wc, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wc, nil
+}
+
+func (r *routingRepository) ListWorkCenters(ctx context.Context) ([]*entity.WorkCenter, error) {
+	var wcs []*entity.WorkCenter
+	err := conn(ctx, r.db).
+		Where("is_active = ?", true).
+		Order("code").
+		Find(&wcs).Error
+	return wcs, err
+}
+
+// Routings
+func (r *routingRepository) Create(ctx context.Context, routing *entity.Routing) error {
+	return conn(ctx, r.db).Create(routing).Error
+}
+
+func (r *routingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Routing, error) {
+	var routing entity.Routing
+	err := conn(ctx, r.db).
+		Preload("Operations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		First(&routing, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &routing, nil
+}
+
+func (r *routingRepository) GetActiveForProduct(ctx context.Context, productID uuid.UUID) (*entity.Routing, error) {
+	var routing entity.Routing
+	err := conn(ctx, r.db).
+		Preload("Operations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence ASC")
+		}).
+		Where("product_id = ? AND status = ?", productID, entity.RoutingStatusActive).
+		Order("version DESC").
+		First(&routing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &routing, nil
+}
+
+func (r *routingRepository) List(ctx context.Context, filter repository.RoutingFilter) ([]*entity.Routing, int64, error) {
+	var routings []*entity.Routing
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.Routing{})
+
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Order("created_at DESC").Find(&routings).Error
+	return routings, total, err
+}
+
+func (r *routingRepository) Update(ctx context.Context, routing *entity.Routing) error {
+	return conn(ctx, r.db).Omit("Operations").Save(routing).Error
+}
+
+func (r *routingRepository) ObsoleteActiveForProduct(ctx context.Context, productID uuid.UUID, exceptID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&entity.Routing{}).
+		Where("product_id = ? AND status = ? AND id <> ?", productID, entity.RoutingStatusActive, exceptID).
+		Update("status", entity.RoutingStatusObsolete).Error
+}
+
+// WO Operation Repository
+type woOperationRepository struct {
+	db *gorm.DB
+}
+
+// NewWOOperationRepository creates a new work order operation repository
+func NewWOOperationRepository(db *gorm.DB) repository.WOOperationRepository {
+	return &woOperationRepository{db: db}
+}
+
+func (r *woOperationRepository) CreateOperations(ctx context.Context, ops []*entity.WOOperation) error {
+	return conn(ctx, r.db).Create(&ops).Error
+}
+
+func (r *woOperationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WOOperation, error) {
+	var op entity.WOOperation
+	err := conn(ctx, r.db).
+		Preload("Logs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurred_at ASC")
+		}).
+		First(&op, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+func (r *woOperationRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.WOOperation, error) {
+	var ops []*entity.WOOperation
+	err := conn(ctx, r.db).
+		Preload("Logs", func(db *gorm.DB) *gorm.DB {
+			return db.Order("occurred_at ASC")
+		}).
+		Where("work_order_id = ?", woID).
+		Order("sequence ASC").
+		Find(&ops).Error
+	return ops, err
+}
+
+func (r *woOperationRepository) Update(ctx context.Context, op *entity.WOOperation) error {
+	return conn(ctx, r.db).Omit("Logs").Save(op).Error
+}
+
+func (r *woOperationRepository) CreateLog(ctx context.Context, log *entity.WOOperationLog) error {
+	return conn(ctx, r.db).Create(log).Error
+}
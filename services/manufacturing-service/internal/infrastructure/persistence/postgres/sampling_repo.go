@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type samplingPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewSamplingPlanRepository creates a new AQL sampling plan repository
+func NewSamplingPlanRepository(db *gorm.DB) repository.SamplingPlanRepository {
+	return &samplingPlanRepository{db: db}
+}
+
+func (r *samplingPlanRepository) Create(ctx context.Context, plan *entity.AQLSamplingPlan) error {
+	return conn(ctx, r.db).Create(plan).Error
+}
+
+func (r *samplingPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AQLSamplingPlan, error) {
+	var plan entity.AQLSamplingPlan
+	err := conn(ctx, r.db).First(&plan, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+func (r *samplingPlanRepository) List(ctx context.Context, activeOnly bool) ([]*entity.AQLSamplingPlan, error) {
+	var plans []*entity.AQLSamplingPlan
+	query := conn(ctx, r.db)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("plan_code").Find(&plans).Error
+	return plans, err
+}
+
+func (r *samplingPlanRepository) AssignToCheckpoint(ctx context.Context, checkpointID uuid.UUID, planID *uuid.UUID) error {
+	result := conn(ctx, r.db).Model(&entity.QCCheckpoint{}).
+		Where("id = ?", checkpointID).
+		Updates(map[string]interface{}{
+			"sampling_plan_id": planID,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Switching states
+func (r *samplingPlanRepository) GetState(ctx context.Context, planID uuid.UUID, subjectType entity.SamplingSubjectType, subjectID uuid.UUID) (*entity.SamplingState, error) {
+	var state entity.SamplingState
+	err := conn(ctx, r.db).
+		Where("sampling_plan_id = ? AND subject_type = ? AND subject_id = ?", planID, subjectType, subjectID).
+		First(&state).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *samplingPlanRepository) GetStateByID(ctx context.Context, id uuid.UUID) (*entity.SamplingState, error) {
+	var state entity.SamplingState
+	err := conn(ctx, r.db).First(&state, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *samplingPlanRepository) ListStates(ctx context.Context, planID uuid.UUID) ([]*entity.SamplingState, error) {
+	var states []*entity.SamplingState
+	err := conn(ctx, r.db).
+		Where("sampling_plan_id = ?", planID).
+		Order("updated_at DESC").
+		Find(&states).Error
+	return states, err
+}
+
+func (r *samplingPlanRepository) SaveState(ctx context.Context, state *entity.SamplingState) error {
+	state.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Save(state).Error
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type stabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewStabilityRepository creates a new stability study repository
+func NewStabilityRepository(db *gorm.DB) repository.StabilityRepository {
+	return &stabilityRepository{db: db}
+}
+
+func (r *stabilityRepository) Create(ctx context.Context, study *entity.StabilityStudy) error {
+	return conn(ctx, r.db).Create(study).Error
+}
+
+func (r *stabilityRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.StabilityStudy, error) {
+	var study entity.StabilityStudy
+	err := conn(ctx, r.db).
+		Preload("Conditions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("temperature_c ASC")
+		}).
+		Preload("PullPoints", func(db *gorm.DB) *gorm.DB {
+			return db.Order("scheduled_date ASC")
+		}).
+		First(&study, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &study, nil
+}
+
+func (r *stabilityRepository) List(ctx context.Context, filter repository.StabilityFilter) ([]*entity.StabilityStudy, int64, error) {
+	var studies []*entity.StabilityStudy
+	var total int64
+
+	// The evaluation snapshot is only returned by GetByID
+	query := conn(ctx, r.db).Model(&entity.StabilityStudy{}).Omit("evaluation")
+
+	if filter.ProductID != nil {
+		query = query.Where("product_id = ?", *filter.ProductID)
+	}
+	if filter.BOMID != nil {
+		query = query.Where("bom_id = ?", *filter.BOMID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	query.Count(&total)
+
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	err := query.Preload("Conditions").Order("start_date DESC").Find(&studies).Error
+	return studies, total, err
+}
+
+func (r *stabilityRepository) Update(ctx context.Context, study *entity.StabilityStudy) error {
+	return conn(ctx, r.db).Omit("Conditions", "PullPoints").Save(study).Error
+}
+
+func (r *stabilityRepository) UpdatePullPoint(ctx context.Context, pullPoint *entity.StabilityPullPoint) error {
+	return conn(ctx, r.db).Save(pullPoint).Error
+}
+
+func (r *stabilityRepository) GenerateStudyNumber(ctx context.Context) (string, error) {
+	var count int64
+	year := time.Now().Year()
+	conn(ctx, r.db).Model(&entity.StabilityStudy{}).
+		Where("EXTRACT(YEAR FROM created_at) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("STB-%d-%04d", year, count+1), nil
+}
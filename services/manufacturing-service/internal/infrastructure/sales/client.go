@@ -0,0 +1,129 @@
+package sales
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client reads sales orders, customers and shipments from sales-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new sales-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// SalesOrder is a sales order with its customer
+type SalesOrder struct {
+	ID              uuid.UUID `json:"id"`
+	SONumber        string    `json:"so_number"`
+	CustomerID      uuid.UUID `json:"customer_id"`
+	DeliveryAddress string    `json:"delivery_address"`
+	Customer        *Customer `json:"customer"`
+}
+
+// Customer is the customer header
+type Customer struct {
+	ID           uuid.UUID `json:"id"`
+	CustomerCode string    `json:"customer_code"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Phone        string    `json:"phone"`
+}
+
+// Contact is a customer contact person
+type Contact struct {
+	ContactName string `json:"contact_name"`
+	Position    string `json:"position"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+	Mobile      string `json:"mobile"`
+	IsPrimary   bool   `json:"is_primary"`
+}
+
+// Shipment is a shipment of a sales order
+type Shipment struct {
+	ID                 uuid.UUID  `json:"id"`
+	ShipmentNumber     string     `json:"shipment_number"`
+	Status             string     `json:"status"`
+	ShippedDate        *time.Time `json:"shipped_date"`
+	ActualDeliveryDate *time.Time `json:"actual_delivery_date"`
+	Carrier            string     `json:"carrier"`
+	TrackingNumber     string     `json:"tracking_number"`
+	RecipientName      string     `json:"recipient_name"`
+	RecipientPhone     string     `json:"recipient_phone"`
+	DeliveryAddress    string     `json:"delivery_address"`
+}
+
+// GetSalesOrder returns a sales order with its customer
+func (c *Client) GetSalesOrder(ctx context.Context, id uuid.UUID) (*SalesOrder, error) {
+	var order SalesOrder
+	if err := c.get(ctx, "/api/v1/sales-orders/"+id.String(), nil, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetCustomerContacts returns the contacts of a customer
+func (c *Client) GetCustomerContacts(ctx context.Context, customerID uuid.UUID) ([]Contact, error) {
+	var contacts []Contact
+	err := c.get(ctx, "/api/v1/customers/"+customerID.String()+"/contacts", nil, &contacts)
+	return contacts, err
+}
+
+// ListShipmentsByOrder returns the shipments of a sales order
+func (c *Client) ListShipmentsByOrder(ctx context.Context, salesOrderID uuid.UUID) ([]Shipment, error) {
+	query := url.Values{}
+	query.Set("sales_order_id", salesOrderID.String())
+	query.Set("limit", "100")
+
+	var shipments []Shipment
+	err := c.get(ctx, "/api/v1/shipments", query, &shipments)
+	return shipments, err
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if query != nil {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sales-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode sales-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return fmt.Errorf("sales-service returned status %d for %s", resp.StatusCode, path)
+	}
+	if err := json.Unmarshal(result.Data, out); err != nil {
+		return fmt.Errorf("failed to decode sales-service data: %w", err)
+	}
+	return nil
+}
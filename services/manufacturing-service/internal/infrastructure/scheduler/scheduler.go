@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CAPAReminderJob sends reminders for overdue CAPA actions
+type CAPAReminderJob interface {
+	Execute(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// EquipmentAlertJob sends calibration and preventive maintenance due alerts
+type EquipmentAlertJob interface {
+	Execute(ctx context.Context, asOf time.Time) (int, error)
+}
+
+// Scheduler handles scheduled manufacturing jobs
+type Scheduler struct {
+	capaReminders   CAPAReminderJob
+	equipmentAlerts EquipmentAlertJob
+	logger          *zap.Logger
+	config          *Config
+	stopChan        chan struct{}
+}
+
+// Config holds scheduler configuration
+type Config struct {
+	CAPAReminderCheckInterval   time.Duration
+	EquipmentAlertCheckInterval time.Duration
+}
+
+// DefaultConfig returns default scheduler config
+func DefaultConfig() *Config {
+	return &Config{
+		CAPAReminderCheckInterval:   1 * time.Hour, // Each action is reminded at most daily
+		EquipmentAlertCheckInterval: 1 * time.Hour, // Each due item is alerted at most daily
+	}
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(capaReminders CAPAReminderJob, equipmentAlerts EquipmentAlertJob, logger *zap.Logger, config *Config) *Scheduler {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &Scheduler{
+		capaReminders:   capaReminders,
+		equipmentAlerts: equipmentAlerts,
+		logger:          logger,
+		config:          config,
+		stopChan:        make(chan struct{}),
+	}
+}
+
+// Start starts the scheduler
+func (s *Scheduler) Start() {
+	s.logger.Info("Starting manufacturing scheduler")
+
+	go s.scheduleCAPAReminders()
+	go s.scheduleEquipmentAlerts()
+}
+
+// Stop stops the scheduler
+func (s *Scheduler) Stop() {
+	close(s.stopChan)
+	s.logger.Info("Manufacturing scheduler stopped")
+}
+
+// scheduleCAPAReminders runs the CAPA reminder check at intervals
+func (s *Scheduler) scheduleCAPAReminders() {
+	ticker := time.NewTicker(s.config.CAPAReminderCheckInterval)
+	defer ticker.Stop()
+
+	s.runCAPAReminders()
+	for {
+		select {
+		case <-ticker.C:
+			s.runCAPAReminders()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// runCAPAReminders publishes reminders for overdue CAPA actions
+func (s *Scheduler) runCAPAReminders() {
+	sent, err := s.capaReminders.Execute(context.Background(), time.Now())
+	if err != nil {
+		s.logger.Error("Failed to send CAPA reminders", zap.Error(err))
+		return
+	}
+	if sent > 0 {
+		s.logger.Info("CAPA overdue reminders sent", zap.Int("count", sent))
+	}
+}
+
+// scheduleEquipmentAlerts runs the equipment due-date check at intervals
+func (s *Scheduler) scheduleEquipmentAlerts() {
+	ticker := time.NewTicker(s.config.EquipmentAlertCheckInterval)
+	defer ticker.Stop()
+
+	s.runEquipmentAlerts()
+	for {
+		select {
+		case <-ticker.C:
+			s.runEquipmentAlerts()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+// runEquipmentAlerts publishes alerts for calibrations and maintenance falling due
+func (s *Scheduler) runEquipmentAlerts() {
+	sent, err := s.equipmentAlerts.Execute(context.Background(), time.Now())
+	if err != nil {
+		s.logger.Error("Failed to send equipment due alerts", zap.Error(err))
+		return
+	}
+	if sent > 0 {
+		s.logger.Info("Equipment due alerts sent", zap.Int("count", sent))
+	}
+}
@@ -0,0 +1,183 @@
+package wms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pageSize is the largest page wms-service returns
+const pageSize = 100
+
+// ErrNotFound is returned when wms-service has no such record
+var ErrNotFound = errors.New("not found in wms-service")
+
+// Client reads lots, lot distribution and stock from wms-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new wms-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// GoodsIssue is a goods issue with only the lines of the requested lot
+type GoodsIssue struct {
+	ID              uuid.UUID        `json:"id"`
+	IssueNumber     string           `json:"issue_number"`
+	IssueDate       time.Time        `json:"issue_date"`
+	IssueType       string           `json:"issue_type"`
+	ReferenceType   string           `json:"reference_type"`
+	ReferenceID     *uuid.UUID       `json:"reference_id"` // sales order for SALES issues
+	ReferenceNumber string           `json:"reference_number"`
+	Status          string           `json:"status"`
+	LineItems       []GoodsIssueLine `json:"line_items"`
+}
+
+// GoodsIssueLine is an issued lot line
+type GoodsIssueLine struct {
+	MaterialID uuid.UUID  `json:"material_id"`
+	LotID      *uuid.UUID `json:"lot_id"`
+	IssuedQty  float64    `json:"issued_qty"`
+}
+
+// Stock is the on-hand quantity of a lot at one location
+type Stock struct {
+	WarehouseID uuid.UUID  `json:"warehouse_id"`
+	LocationID  uuid.UUID  `json:"location_id"`
+	MaterialID  uuid.UUID  `json:"material_id"`
+	LotID       *uuid.UUID `json:"lot_id"`
+	Quantity    float64    `json:"quantity"`
+	ReservedQty float64    `json:"reserved_qty"`
+	Location    *struct {
+		Code string `json:"code"`
+	} `json:"location,omitempty"`
+}
+
+// Lot is a lot with its valuation
+type Lot struct {
+	ID         uuid.UUID `json:"id"`
+	LotNumber  string    `json:"lot_number"`
+	MaterialID uuid.UUID `json:"material_id"`
+	UnitCost   float64   `json:"unit_cost"` // 0 if the lot has not been valued
+}
+
+// GetLot returns a lot by ID
+func (c *Client) GetLot(ctx context.Context, id uuid.UUID) (*Lot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/lots/"+id.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wms-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+		Data    Lot  `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode wms-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return nil, fmt.Errorf("wms-service returned status %d for lot %s", resp.StatusCode, id)
+	}
+	return &result.Data, nil
+}
+
+// ListSalesIssuesByLot returns the SALES goods issues that shipped the lot
+func (c *Client) ListSalesIssuesByLot(ctx context.Context, lotID uuid.UUID) ([]GoodsIssue, error) {
+	query := url.Values{}
+	query.Set("lot_id", lotID.String())
+	query.Set("issue_type", "SALES")
+
+	var issues []GoodsIssue
+	err := c.getAll(ctx, "/api/v1/goods-issue", query, func(data json.RawMessage) (int, error) {
+		var page []GoodsIssue
+		if err := json.Unmarshal(data, &page); err != nil {
+			return 0, err
+		}
+		issues = append(issues, page...)
+		return len(page), nil
+	})
+	return issues, err
+}
+
+// ListStockByLot returns the remaining stock of the lot per location
+func (c *Client) ListStockByLot(ctx context.Context, lotID uuid.UUID) ([]Stock, error) {
+	query := url.Values{}
+	query.Set("lot_id", lotID.String())
+	query.Set("has_stock", "true")
+
+	var stock []Stock
+	err := c.getAll(ctx, "/api/v1/stock", query, func(data json.RawMessage) (int, error) {
+		var page []Stock
+		if err := json.Unmarshal(data, &page); err != nil {
+			return 0, err
+		}
+		stock = append(stock, page...)
+		return len(page), nil
+	})
+	return stock, err
+}
+
+// getAll pages through a list endpoint, handing each page's data to collect
+func (c *Client) getAll(ctx context.Context, path string, query url.Values, collect func(json.RawMessage) (int, error)) error {
+	query.Set("limit", strconv.Itoa(pageSize))
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("wms-service request failed: %w", err)
+		}
+
+		var result struct {
+			Success bool            `json:"success"`
+			Data    json.RawMessage `json:"data"`
+			Meta    struct {
+				TotalPages int `json:"total_pages"`
+			} `json:"meta"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode wms-service response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK || !result.Success {
+			return fmt.Errorf("wms-service returned status %d", resp.StatusCode)
+		}
+
+		n, err := collect(result.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode wms-service data: %w", err)
+		}
+		if n < pageSize || page >= result.Meta.TotalPages {
+			return nil
+		}
+	}
+}
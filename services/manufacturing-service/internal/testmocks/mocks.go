@@ -2,10 +2,15 @@ package testmocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
 	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/filestore"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/masterdata"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/sales"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -99,11 +104,57 @@ func (m *MockWorkOrderRepository) CreateLineItems(ctx context.Context, items []*
 func (m *MockWorkOrderRepository) GetByNumber(ctx context.Context, num string) (*entity.WorkOrder, error) { return nil, nil }
 func (m *MockWorkOrderRepository) List(ctx context.Context, filter repository.WOFilter) ([]*entity.WorkOrder, int64, error) { return nil, 0, nil }
 func (m *MockWorkOrderRepository) Delete(ctx context.Context, id uuid.UUID) error { return nil }
-func (m *MockWorkOrderRepository) GetLineItems(ctx context.Context, woID uuid.UUID) ([]*entity.WOLineItem, error) { return nil, nil }
-func (m *MockWorkOrderRepository) UpdateLineItem(ctx context.Context, item *entity.WOLineItem) error { return nil }
-func (m *MockWorkOrderRepository) CreateMaterialIssue(ctx context.Context, issue *entity.WOMaterialIssue) error { return nil }
 func (m *MockWorkOrderRepository) GetMaterialIssues(ctx context.Context, woID uuid.UUID) ([]*entity.WOMaterialIssue, error) { return nil, nil }
-func (m *MockWorkOrderRepository) GenerateIssueNumber(ctx context.Context) (string, error) { return "", nil }
+func (m *MockWorkOrderRepository) CreateOutputs(ctx context.Context, outputs []*entity.WOOutput) error { return nil }
+
+func (m *MockWorkOrderRepository) GetLineItems(ctx context.Context, woID uuid.UUID) ([]*entity.WOLineItem, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WOLineItem), args.Error(1)
+}
+
+func (m *MockWorkOrderRepository) UpdateLineItem(ctx context.Context, item *entity.WOLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockWorkOrderRepository) CreateMaterialIssue(ctx context.Context, issue *entity.WOMaterialIssue) error {
+	args := m.Called(ctx, issue)
+	return args.Error(0)
+}
+
+func (m *MockWorkOrderRepository) GenerateIssueNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockWorkOrderRepository) GetOutputs(ctx context.Context, woID uuid.UUID) ([]*entity.WOOutput, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WOOutput), args.Error(1)
+}
+
+func (m *MockWorkOrderRepository) UpdateOutput(ctx context.Context, output *entity.WOOutput) error {
+	args := m.Called(ctx, output)
+	return args.Error(0)
+}
+
+func (m *MockWorkOrderRepository) GetLastStartedOnLine(ctx context.Context, productionLine string, excludeID uuid.UUID) (*entity.WorkOrder, error) {
+	args := m.Called(ctx, productionLine, excludeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkOrder), args.Error(1)
+}
+
+func (m *MockWorkOrderRepository) GetPlannedReworkQuantity(ctx context.Context, ncrID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, ncrID)
+	return args.Get(0).(float64), args.Error(1)
+}
 
 // MockTraceabilityRepository
 type MockTraceabilityRepository struct {
@@ -131,9 +182,23 @@ func (m *MockTraceabilityRepository) GetByMaterialLot(ctx context.Context, lotID
 	return args.Get(0).([]*entity.BatchTraceability), args.Error(1)
 }
 
-func (m *MockTraceabilityRepository) Create(ctx context.Context, trace *entity.BatchTraceability) error { return nil }
-func (m *MockTraceabilityRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.BatchTraceability, error) { return nil, nil }
-func (m *MockTraceabilityRepository) UpdateProductLot(ctx context.Context, woID uuid.UUID, lotID uuid.UUID, lotNum string) error { return nil }
+func (m *MockTraceabilityRepository) Create(ctx context.Context, trace *entity.BatchTraceability) error {
+	args := m.Called(ctx, trace)
+	return args.Error(0)
+}
+
+func (m *MockTraceabilityRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.BatchTraceability, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.BatchTraceability), args.Error(1)
+}
+
+func (m *MockTraceabilityRepository) UpdateProductLot(ctx context.Context, woID uuid.UUID, lotID uuid.UUID, lotNum string) error {
+	args := m.Called(ctx, woID, lotID, lotNum)
+	return args.Error(0)
+}
 
 // MockQCRepository
 type MockQCRepository struct {
@@ -150,7 +215,13 @@ func (m *MockQCRepository) GenerateInspectionNumber(ctx context.Context) (string
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockQCRepository) GetCheckpointByID(ctx context.Context, id uuid.UUID) (*entity.QCCheckpoint, error) { return nil, nil }
+func (m *MockQCRepository) GetCheckpointByID(ctx context.Context, id uuid.UUID) (*entity.QCCheckpoint, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.QCCheckpoint), args.Error(1)
+}
 func (m *MockQCRepository) GetCheckpoints(ctx context.Context) ([]*entity.QCCheckpoint, error) { return nil, nil }
 func (m *MockQCRepository) GetCheckpointsByType(ctx context.Context, cpType entity.CheckpointType) ([]*entity.QCCheckpoint, error) {
 	args := m.Called(ctx, cpType)
@@ -173,7 +244,150 @@ func (m *MockQCRepository) CreateInspectionItems(ctx context.Context, items []*e
 	args := m.Called(ctx, items)
 	return args.Error(0)
 }
-func (m *MockQCRepository) GetInspectionItems(ctx context.Context, id uuid.UUID) ([]*entity.QCInspectionItem, error) { return nil, nil }
+func (m *MockQCRepository) GetInspectionItems(ctx context.Context, id uuid.UUID) ([]*entity.QCInspectionItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.QCInspectionItem), args.Error(1)
+}
+func (m *MockQCRepository) GetNumericResults(ctx context.Context, filter repository.SPCFilter) ([]entity.SPCObservation, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.SPCObservation), args.Error(1)
+}
+
+// MockSamplingPlanRepository
+type MockSamplingPlanRepository struct {
+	mock.Mock
+}
+
+func (m *MockSamplingPlanRepository) Create(ctx context.Context, plan *entity.AQLSamplingPlan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockSamplingPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AQLSamplingPlan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AQLSamplingPlan), args.Error(1)
+}
+
+func (m *MockSamplingPlanRepository) List(ctx context.Context, activeOnly bool) ([]*entity.AQLSamplingPlan, error) {
+	args := m.Called(ctx, activeOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AQLSamplingPlan), args.Error(1)
+}
+
+func (m *MockSamplingPlanRepository) AssignToCheckpoint(ctx context.Context, checkpointID uuid.UUID, planID *uuid.UUID) error {
+	args := m.Called(ctx, checkpointID, planID)
+	return args.Error(0)
+}
+
+func (m *MockSamplingPlanRepository) GetState(ctx context.Context, planID uuid.UUID, subjectType entity.SamplingSubjectType, subjectID uuid.UUID) (*entity.SamplingState, error) {
+	args := m.Called(ctx, planID, subjectType, subjectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SamplingState), args.Error(1)
+}
+
+func (m *MockSamplingPlanRepository) GetStateByID(ctx context.Context, id uuid.UUID) (*entity.SamplingState, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SamplingState), args.Error(1)
+}
+
+func (m *MockSamplingPlanRepository) ListStates(ctx context.Context, planID uuid.UUID) ([]*entity.SamplingState, error) {
+	args := m.Called(ctx, planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SamplingState), args.Error(1)
+}
+
+func (m *MockSamplingPlanRepository) SaveState(ctx context.Context, state *entity.SamplingState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+// MockCoARepository
+type MockCoARepository struct {
+	mock.Mock
+}
+
+func (m *MockCoARepository) Create(ctx context.Context, coa *entity.CertificateOfAnalysis) error {
+	args := m.Called(ctx, coa)
+	return args.Error(0)
+}
+
+func (m *MockCoARepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CertificateOfAnalysis, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CertificateOfAnalysis), args.Error(1)
+}
+
+func (m *MockCoARepository) List(ctx context.Context, filter repository.CoAFilter) ([]*entity.CertificateOfAnalysis, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.CertificateOfAnalysis), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCoARepository) GenerateCoANumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCoARepository) CreateSupplierCoA(ctx context.Context, coa *entity.SupplierCoA) error {
+	args := m.Called(ctx, coa)
+	return args.Error(0)
+}
+
+func (m *MockCoARepository) GetSupplierCoAByID(ctx context.Context, id uuid.UUID) (*entity.SupplierCoA, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SupplierCoA), args.Error(1)
+}
+
+func (m *MockCoARepository) ListSupplierCoAs(ctx context.Context, filter repository.SupplierCoAFilter) ([]*entity.SupplierCoA, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.SupplierCoA), args.Get(1).(int64), args.Error(2)
+}
+
+// MockFileUploader
+type MockFileUploader struct {
+	mock.Mock
+}
+
+func (m *MockFileUploader) Upload(ctx context.Context, input filestore.UploadInput) (*filestore.UploadedFile, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*filestore.UploadedFile), args.Error(1)
+}
+
+func (m *MockFileUploader) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
 
 // MockNCRRepository
 type MockNCRRepository struct {
@@ -190,10 +404,21 @@ func (m *MockNCRRepository) GenerateNCRNumber(ctx context.Context) (string, erro
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockNCRRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.NCR, error) { return nil, nil }
+func (m *MockNCRRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.NCR, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.NCR), args.Error(1)
+}
+
 func (m *MockNCRRepository) GetByNumber(ctx context.Context, num string) (*entity.NCR, error) { return nil, nil }
 func (m *MockNCRRepository) List(ctx context.Context, filter repository.NCRFilter) ([]*entity.NCR, int64, error) { return nil, 0, nil }
-func (m *MockNCRRepository) Update(ctx context.Context, ncr *entity.NCR) error { return nil }
+
+func (m *MockNCRRepository) Update(ctx context.Context, ncr *entity.NCR) error {
+	args := m.Called(ctx, ncr)
+	return args.Error(0)
+}
 
 // MockEventPublisher
 type MockEventPublisher struct {
@@ -230,6 +455,11 @@ func (m *MockEventPublisher) PublishWOCompleted(e event.WOCompletedEvent) error
 	return args.Error(0)
 }
 
+func (m *MockEventPublisher) PublishWOCancelled(e event.WOEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
 func (m *MockEventPublisher) PublishQCPassed(e event.QCEvent) error {
 	args := m.Called(e)
 	return args.Error(0)
@@ -244,3 +474,654 @@ func (m *MockEventPublisher) PublishNCRCreated(e event.NCREvent) error {
 	args := m.Called(e)
 	return args.Error(0)
 }
+
+func (m *MockEventPublisher) PublishWOOperationCompleted(e event.OperationEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+// MockWOOperationRepository
+type MockWOOperationRepository struct {
+	mock.Mock
+}
+
+func (m *MockWOOperationRepository) CreateOperations(ctx context.Context, ops []*entity.WOOperation) error {
+	args := m.Called(ctx, ops)
+	return args.Error(0)
+}
+
+func (m *MockWOOperationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WOOperation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WOOperation), args.Error(1)
+}
+
+func (m *MockWOOperationRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.WOOperation, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WOOperation), args.Error(1)
+}
+
+func (m *MockWOOperationRepository) Update(ctx context.Context, op *entity.WOOperation) error {
+	args := m.Called(ctx, op)
+	return args.Error(0)
+}
+
+func (m *MockWOOperationRepository) CreateLog(ctx context.Context, log *entity.WOOperationLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishBatchReleased(e event.BatchRecordEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+// MockBatchRecordRepository
+type MockBatchRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockBatchRecordRepository) Create(ctx context.Context, record *entity.BatchRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockBatchRecordRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BatchRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BatchRecord), args.Error(1)
+}
+
+func (m *MockBatchRecordRepository) GetLatestByBatch(ctx context.Context, batchNumber string) (*entity.BatchRecord, error) {
+	args := m.Called(ctx, batchNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BatchRecord), args.Error(1)
+}
+
+func (m *MockBatchRecordRepository) ListByBatch(ctx context.Context, batchNumber string) ([]*entity.BatchRecord, error) {
+	return nil, nil
+}
+
+func (m *MockBatchRecordRepository) UpdateStatus(ctx context.Context, record *entity.BatchRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockBatchRecordRepository) CreateSignature(ctx context.Context, sig *entity.BatchRecordSignature) error {
+	args := m.Called(ctx, sig)
+	return args.Error(0)
+}
+
+func (m *MockBatchRecordRepository) GenerateRecordNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockEventPublisher) PublishMaterialIssued(e event.MaterialIssuedEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+// MockDispensingRepository
+type MockDispensingRepository struct {
+	mock.Mock
+}
+
+func (m *MockDispensingRepository) CreateTicket(ctx context.Context, ticket *entity.WeighingTicket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockDispensingRepository) GetTicketByID(ctx context.Context, id uuid.UUID) (*entity.WeighingTicket, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WeighingTicket), args.Error(1)
+}
+
+func (m *MockDispensingRepository) GetTicketsByWorkOrder(ctx context.Context, woID uuid.UUID) ([]*entity.WeighingTicket, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WeighingTicket), args.Error(1)
+}
+
+func (m *MockDispensingRepository) UpdateTicket(ctx context.Context, ticket *entity.WeighingTicket) error {
+	args := m.Called(ctx, ticket)
+	return args.Error(0)
+}
+
+func (m *MockDispensingRepository) CreateReading(ctx context.Context, reading *entity.WeighingReading) error {
+	args := m.Called(ctx, reading)
+	return args.Error(0)
+}
+
+func (m *MockDispensingRepository) GenerateTicketNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockEventPublisher) PublishWOBackflushed(e event.WOBackflushEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishMaterialReturned(e event.MaterialReturnedEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishSPCViolation(e event.SPCViolationEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+// MockCAPARepository
+type MockCAPARepository struct {
+	mock.Mock
+}
+
+func (m *MockCAPARepository) Create(ctx context.Context, capa *entity.CAPA) error {
+	args := m.Called(ctx, capa)
+	return args.Error(0)
+}
+
+func (m *MockCAPARepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CAPA, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CAPA), args.Error(1)
+}
+
+func (m *MockCAPARepository) List(ctx context.Context, filter repository.CAPAFilter) ([]*entity.CAPA, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.CAPA), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCAPARepository) Update(ctx context.Context, capa *entity.CAPA) error {
+	args := m.Called(ctx, capa)
+	return args.Error(0)
+}
+
+func (m *MockCAPARepository) CreateAction(ctx context.Context, action *entity.CAPAAction) error {
+	args := m.Called(ctx, action)
+	return args.Error(0)
+}
+
+func (m *MockCAPARepository) UpdateAction(ctx context.Context, action *entity.CAPAAction) error {
+	args := m.Called(ctx, action)
+	return args.Error(0)
+}
+
+func (m *MockCAPARepository) GetOverdueActions(ctx context.Context, asOf time.Time) ([]*entity.CAPAAction, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CAPAAction), args.Error(1)
+}
+
+func (m *MockCAPARepository) GetOpenCriticalActionsBySource(ctx context.Context, sourceType entity.CAPASourceType, sourceID uuid.UUID) ([]*entity.CAPAAction, error) {
+	args := m.Called(ctx, sourceType, sourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CAPAAction), args.Error(1)
+}
+
+func (m *MockCAPARepository) CreateLink(ctx context.Context, link *entity.CAPALink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+func (m *MockCAPARepository) GenerateCAPANumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockEventPublisher) PublishCAPAActionOverdue(e event.CAPAActionOverdueEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishRecallInitiated(e event.RecallInitiatedEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishCalibrationDue(e event.EquipmentDueEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishMaintenanceDue(e event.EquipmentDueEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+func (m *MockEventPublisher) PublishWOCosted(e event.WOCostedEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+// MockRecallRepository
+type MockRecallRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecallRepository) Create(ctx context.Context, recall *entity.Recall) error {
+	args := m.Called(ctx, recall)
+	return args.Error(0)
+}
+
+func (m *MockRecallRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Recall), args.Error(1)
+}
+
+func (m *MockRecallRepository) List(ctx context.Context, filter repository.RecallFilter) ([]*entity.Recall, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*entity.Recall), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRecallRepository) Update(ctx context.Context, recall *entity.Recall) error {
+	args := m.Called(ctx, recall)
+	return args.Error(0)
+}
+
+func (m *MockRecallRepository) GenerateRecallNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockWarehouseClient
+type MockWarehouseClient struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseClient) ListSalesIssuesByLot(ctx context.Context, lotID uuid.UUID) ([]wms.GoodsIssue, error) {
+	args := m.Called(ctx, lotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]wms.GoodsIssue), args.Error(1)
+}
+
+func (m *MockWarehouseClient) ListStockByLot(ctx context.Context, lotID uuid.UUID) ([]wms.Stock, error) {
+	args := m.Called(ctx, lotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]wms.Stock), args.Error(1)
+}
+
+func (m *MockWarehouseClient) GetLot(ctx context.Context, id uuid.UUID) (*wms.Lot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*wms.Lot), args.Error(1)
+}
+
+// MockSalesClient
+type MockSalesClient struct {
+	mock.Mock
+}
+
+func (m *MockSalesClient) GetSalesOrder(ctx context.Context, id uuid.UUID) (*sales.SalesOrder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sales.SalesOrder), args.Error(1)
+}
+
+func (m *MockSalesClient) GetCustomerContacts(ctx context.Context, customerID uuid.UUID) ([]sales.Contact, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sales.Contact), args.Error(1)
+}
+
+func (m *MockSalesClient) ListShipmentsByOrder(ctx context.Context, salesOrderID uuid.UUID) ([]sales.Shipment, error) {
+	args := m.Called(ctx, salesOrderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]sales.Shipment), args.Error(1)
+}
+
+// MockStabilityRepository
+type MockStabilityRepository struct {
+	mock.Mock
+}
+
+func (m *MockStabilityRepository) Create(ctx context.Context, study *entity.StabilityStudy) error {
+	args := m.Called(ctx, study)
+	return args.Error(0)
+}
+
+func (m *MockStabilityRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.StabilityStudy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.StabilityStudy), args.Error(1)
+}
+
+func (m *MockStabilityRepository) List(ctx context.Context, filter repository.StabilityFilter) ([]*entity.StabilityStudy, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.StabilityStudy), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockStabilityRepository) Update(ctx context.Context, study *entity.StabilityStudy) error {
+	args := m.Called(ctx, study)
+	return args.Error(0)
+}
+
+func (m *MockStabilityRepository) UpdatePullPoint(ctx context.Context, pullPoint *entity.StabilityPullPoint) error {
+	args := m.Called(ctx, pullPoint)
+	return args.Error(0)
+}
+
+func (m *MockStabilityRepository) GenerateStudyNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockEquipmentRepository
+type MockEquipmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockEquipmentRepository) Create(ctx context.Context, equipment *entity.Equipment) error {
+	args := m.Called(ctx, equipment)
+	return args.Error(0)
+}
+
+func (m *MockEquipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Equipment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentRepository) GetByCode(ctx context.Context, code string) (*entity.Equipment, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentRepository) List(ctx context.Context, filter repository.EquipmentFilter) ([]*entity.Equipment, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Equipment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockEquipmentRepository) Update(ctx context.Context, equipment *entity.Equipment) error {
+	args := m.Called(ctx, equipment)
+	return args.Error(0)
+}
+
+func (m *MockEquipmentRepository) GetByWorkCenter(ctx context.Context, workCenterID uuid.UUID) ([]*entity.Equipment, error) {
+	args := m.Called(ctx, workCenterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentRepository) GetCalibrationsDue(ctx context.Context, before time.Time) ([]*entity.Equipment, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentRepository) CreateCalibration(ctx context.Context, record *entity.CalibrationRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockEquipmentRepository) CreateMaintenancePlan(ctx context.Context, plan *entity.MaintenancePlan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockEquipmentRepository) GetMaintenancePlanByID(ctx context.Context, id uuid.UUID) (*entity.MaintenancePlan, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.MaintenancePlan), args.Error(1)
+}
+
+func (m *MockEquipmentRepository) UpdateMaintenancePlan(ctx context.Context, plan *entity.MaintenancePlan) error {
+	args := m.Called(ctx, plan)
+	return args.Error(0)
+}
+
+func (m *MockEquipmentRepository) GetMaintenanceDue(ctx context.Context, before time.Time) ([]*entity.MaintenancePlan, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.MaintenancePlan), args.Error(1)
+}
+
+func (m *MockEquipmentRepository) CreateMaintenanceRecord(ctx context.Context, record *entity.MaintenanceRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockEquipmentRepository) GetMaintenanceRecords(ctx context.Context, equipmentID uuid.UUID) ([]*entity.MaintenanceRecord, error) {
+	args := m.Called(ctx, equipmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.MaintenanceRecord), args.Error(1)
+}
+
+// MockRoutingRepository
+type MockRoutingRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoutingRepository) CreateWorkCenter(ctx context.Context, wc *entity.WorkCenter) error {
+	args := m.Called(ctx, wc)
+	return args.Error(0)
+}
+
+func (m *MockRoutingRepository) GetWorkCenterByID(ctx context.Context, id uuid.UUID) (*entity.WorkCenter, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WorkCenter), args.Error(1)
+}
+
+func (m *MockRoutingRepository) ListWorkCenters(ctx context.Context) ([]*entity.WorkCenter, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.WorkCenter), args.Error(1)
+}
+
+func (m *MockRoutingRepository) Create(ctx context.Context, routing *entity.Routing) error {
+	args := m.Called(ctx, routing)
+	return args.Error(0)
+}
+
+func (m *MockRoutingRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Routing, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Routing), args.Error(1)
+}
+
+func (m *MockRoutingRepository) GetActiveForProduct(ctx context.Context, productID uuid.UUID) (*entity.Routing, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Routing), args.Error(1)
+}
+
+func (m *MockRoutingRepository) List(ctx context.Context, filter repository.RoutingFilter) ([]*entity.Routing, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.Routing), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRoutingRepository) Update(ctx context.Context, routing *entity.Routing) error {
+	args := m.Called(ctx, routing)
+	return args.Error(0)
+}
+
+func (m *MockRoutingRepository) ObsoleteActiveForProduct(ctx context.Context, productID uuid.UUID, exceptID uuid.UUID) error {
+	args := m.Called(ctx, productID, exceptID)
+	return args.Error(0)
+}
+
+// MockLineClearanceRepository
+type MockLineClearanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockLineClearanceRepository) Create(ctx context.Context, clearance *entity.LineClearance) error {
+	args := m.Called(ctx, clearance)
+	return args.Error(0)
+}
+
+func (m *MockLineClearanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.LineClearance, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LineClearance), args.Error(1)
+}
+
+func (m *MockLineClearanceRepository) GetByWorkOrder(ctx context.Context, woID uuid.UUID) (*entity.LineClearance, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LineClearance), args.Error(1)
+}
+
+func (m *MockLineClearanceRepository) List(ctx context.Context, filter repository.LineClearanceFilter) ([]*entity.LineClearance, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*entity.LineClearance), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockLineClearanceRepository) Update(ctx context.Context, clearance *entity.LineClearance) error {
+	args := m.Called(ctx, clearance)
+	return args.Error(0)
+}
+
+func (m *MockLineClearanceRepository) UpdateItem(ctx context.Context, item *entity.LineClearanceItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockLineClearanceRepository) GenerateClearanceNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockMaterialClient
+type MockMaterialClient struct {
+	mock.Mock
+}
+
+func (m *MockMaterialClient) GetMaterial(ctx context.Context, id uuid.UUID) (*masterdata.Material, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*masterdata.Material), args.Error(1)
+}
+
+// MockCostingRepository
+type MockCostingRepository struct {
+	mock.Mock
+}
+
+func (m *MockCostingRepository) SaveWOCost(ctx context.Context, cost *entity.WOCost) error {
+	args := m.Called(ctx, cost)
+	return args.Error(0)
+}
+
+func (m *MockCostingRepository) GetWOCostByWorkOrder(ctx context.Context, woID uuid.UUID) (*entity.WOCost, error) {
+	args := m.Called(ctx, woID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.WOCost), args.Error(1)
+}
+
+func (m *MockCostingRepository) GetOverheadRate(ctx context.Context, productionLine string) (*entity.LineOverheadRate, error) {
+	args := m.Called(ctx, productionLine)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.LineOverheadRate), args.Error(1)
+}
+
+func (m *MockCostingRepository) ListOverheadRates(ctx context.Context) ([]*entity.LineOverheadRate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LineOverheadRate), args.Error(1)
+}
+
+func (m *MockCostingRepository) SaveOverheadRate(ctx context.Context, rate *entity.LineOverheadRate) error {
+	args := m.Called(ctx, rate)
+	return args.Error(0)
+}
+
+// MockTransactor runs the function directly, without a transaction
+type MockTransactor struct{}
+
+func (MockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
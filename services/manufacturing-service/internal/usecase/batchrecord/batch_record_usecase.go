@@ -0,0 +1,254 @@
+package batchrecord
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing interface for batch records
+type EventPublisher interface {
+	PublishBatchReleased(event event.BatchRecordEvent) error
+}
+
+// GenerateBatchRecordUseCase assembles a batch record from a completed work order
+type GenerateBatchRecordUseCase struct {
+	repo      repository.BatchRecordRepository
+	woRepo    repository.WorkOrderRepository
+	opRepo    repository.WOOperationRepository
+	qcRepo    repository.QCRepository
+	ncrRepo   repository.NCRRepository
+	traceRepo repository.TraceabilityRepository
+	lcRepo    repository.LineClearanceRepository
+}
+
+// NewGenerateBatchRecordUseCase creates a new GenerateBatchRecordUseCase
+func NewGenerateBatchRecordUseCase(
+	repo repository.BatchRecordRepository,
+	woRepo repository.WorkOrderRepository,
+	opRepo repository.WOOperationRepository,
+	qcRepo repository.QCRepository,
+	ncrRepo repository.NCRRepository,
+	traceRepo repository.TraceabilityRepository,
+	lcRepo repository.LineClearanceRepository,
+) *GenerateBatchRecordUseCase {
+	return &GenerateBatchRecordUseCase{
+		repo:      repo,
+		woRepo:    woRepo,
+		opRepo:    opRepo,
+		qcRepo:    qcRepo,
+		ncrRepo:   ncrRepo,
+		traceRepo: traceRepo,
+		lcRepo:    lcRepo,
+	}
+}
+
+// Execute generates a new batch record version. The previous draft version,
+// if any, is superseded; a released batch cannot be regenerated.
+func (uc *GenerateBatchRecordUseCase) Execute(ctx context.Context, woID uuid.UUID, generatedBy uuid.UUID) (*entity.BatchRecord, error) {
+	wo, err := uc.woRepo.GetByID(ctx, woID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	if wo.Status != entity.WOStatusCompleted {
+		return nil, entity.ErrWONotCompleted
+	}
+
+	version := 1
+	previous, err := uc.repo.GetLatestByBatch(ctx, wo.BatchNumber)
+	if err == nil && previous != nil {
+		if previous.IsLocked() {
+			return nil, entity.ErrBatchRecordLocked
+		}
+		version = previous.Version + 1
+	}
+
+	content, err := uc.assemble(ctx, wo)
+	if err != nil {
+		return nil, err
+	}
+
+	recordNumber, err := uc.repo.GenerateRecordNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &entity.BatchRecord{
+		RecordNumber: recordNumber,
+		BatchNumber:  wo.BatchNumber,
+		WorkOrderID:  wo.ID,
+		Version:      version,
+		Status:       entity.BatchRecordStatusDraft,
+		GeneratedBy:  generatedBy,
+		GeneratedAt:  content.GeneratedAt,
+	}
+	if err := record.SetContent(content); err != nil {
+		return nil, err
+	}
+
+	if previous != nil && previous.Status == entity.BatchRecordStatusDraft {
+		if err := previous.Supersede(); err != nil {
+			return nil, err
+		}
+		if err := uc.repo.UpdateStatus(ctx, previous); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Create(ctx, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (uc *GenerateBatchRecordUseCase) assemble(ctx context.Context, wo *entity.WorkOrder) (*entity.BatchRecordContent, error) {
+	ops, err := uc.opRepo.GetByWorkOrder(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refType := entity.ReferenceTypeWorkOrder
+	inspections, _, err := uc.qcRepo.ListInspections(ctx, repository.QCFilter{
+		ReferenceType: &refType,
+		ReferenceID:   &wo.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, ins := range inspections {
+		items, err := uc.qcRepo.GetInspectionItems(ctx, ins.ID)
+		if err != nil {
+			return nil, err
+		}
+		ins.Items = nil
+		for _, item := range items {
+			ins.Items = append(ins.Items, *item)
+		}
+	}
+
+	ncrs, _, err := uc.ncrRepo.List(ctx, repository.NCRFilter{ReferenceID: &wo.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	traces, err := uc.traceRepo.GetByWorkOrder(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work orders without a production line start without a clearance
+	var clearance *entity.LineClearance
+	if wo.ProductionLine != "" {
+		if lc, err := uc.lcRepo.GetByWorkOrder(ctx, wo.ID); err == nil {
+			clearance = lc
+		}
+	}
+
+	return &entity.BatchRecordContent{
+		BatchNumber:   wo.BatchNumber,
+		WorkOrder:     wo,
+		LineClearance: clearance,
+		Operations:    ops,
+		QCInspections: inspections,
+		NCRs:          ncrs,
+		Traceability:  traces,
+		GeneratedAt:   time.Now(),
+	}, nil
+}
+
+// GetBatchRecordUseCase handles getting a batch record
+type GetBatchRecordUseCase struct {
+	repo repository.BatchRecordRepository
+}
+
+// NewGetBatchRecordUseCase creates a new GetBatchRecordUseCase
+func NewGetBatchRecordUseCase(repo repository.BatchRecordRepository) *GetBatchRecordUseCase {
+	return &GetBatchRecordUseCase{repo: repo}
+}
+
+// Execute gets a batch record by ID
+func (uc *GetBatchRecordUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.BatchRecord, error) {
+	record, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrBatchRecordNotFound
+	}
+	return record, nil
+}
+
+// ListBatchRecordVersionsUseCase handles listing versions of a batch record
+type ListBatchRecordVersionsUseCase struct {
+	repo repository.BatchRecordRepository
+}
+
+// NewListBatchRecordVersionsUseCase creates a new ListBatchRecordVersionsUseCase
+func NewListBatchRecordVersionsUseCase(repo repository.BatchRecordRepository) *ListBatchRecordVersionsUseCase {
+	return &ListBatchRecordVersionsUseCase{repo: repo}
+}
+
+// Execute lists all versions for a batch number, newest first
+func (uc *ListBatchRecordVersionsUseCase) Execute(ctx context.Context, batchNumber string) ([]*entity.BatchRecord, error) {
+	return uc.repo.ListByBatch(ctx, batchNumber)
+}
+
+// SignBatchRecordUseCase handles electronic sign-offs
+type SignBatchRecordUseCase struct {
+	repo     repository.BatchRecordRepository
+	eventPub EventPublisher
+}
+
+// NewSignBatchRecordUseCase creates a new SignBatchRecordUseCase
+func NewSignBatchRecordUseCase(repo repository.BatchRecordRepository, eventPub EventPublisher) *SignBatchRecordUseCase {
+	return &SignBatchRecordUseCase{repo: repo, eventPub: eventPub}
+}
+
+// SignBatchRecordInput is the input for signing a batch record
+type SignBatchRecordInput struct {
+	RecordID   uuid.UUID
+	Role       entity.SignatureRole
+	SignerID   uuid.UUID
+	SignerName string
+	Meaning    string
+}
+
+// Execute signs a batch record; the QA signature releases and locks the batch
+func (uc *SignBatchRecordUseCase) Execute(ctx context.Context, input SignBatchRecordInput) (*entity.BatchRecord, error) {
+	record, err := uc.repo.GetByID(ctx, input.RecordID)
+	if err != nil {
+		return nil, entity.ErrBatchRecordNotFound
+	}
+	if record.IsLocked() {
+		return nil, entity.ErrBatchRecordLocked
+	}
+	if !record.VerifyIntegrity() {
+		return nil, entity.ErrBatchRecordTampered
+	}
+
+	sig, err := record.Sign(input.Role, input.SignerID, input.SignerName, input.Meaning)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateSignature(ctx, sig); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateStatus(ctx, record); err != nil {
+		return nil, err
+	}
+
+	if record.IsLocked() {
+		uc.eventPub.PublishBatchReleased(event.BatchRecordEvent{
+			BatchRecordID: record.ID.String(),
+			RecordNumber:  record.RecordNumber,
+			BatchNumber:   record.BatchNumber,
+			WOID:          record.WorkOrderID.String(),
+			Version:       record.Version,
+			ContentHash:   record.ContentHash,
+		})
+	}
+
+	return record, nil
+}
@@ -0,0 +1,103 @@
+package batchrecord_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/batchrecord"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newSignedRecord(roles ...entity.SignatureRole) *entity.BatchRecord {
+	record := &entity.BatchRecord{
+		ID:           uuid.New(),
+		RecordNumber: "BMR-2026-0001",
+		BatchNumber:  "BATCH-001",
+		WorkOrderID:  uuid.New(),
+		Version:      1,
+		Status:       entity.BatchRecordStatusDraft,
+	}
+	record.SetContent(&entity.BatchRecordContent{BatchNumber: record.BatchNumber})
+	for _, role := range roles {
+		record.Sign(role, uuid.New(), string(role), "Signed")
+	}
+	return record
+}
+
+func TestSignBatchRecordUseCase_Execute_QAReleases(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockBatchRecordRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := batchrecord.NewSignBatchRecordUseCase(repo, eventPub)
+
+	record := newSignedRecord(entity.SignatureRoleProduction, entity.SignatureRoleQC)
+
+	repo.On("GetByID", ctx, record.ID).Return(record, nil)
+	repo.On("CreateSignature", ctx, mock.AnythingOfType("*entity.BatchRecordSignature")).Return(nil)
+	repo.On("UpdateStatus", ctx, record).Return(nil)
+	eventPub.On("PublishBatchReleased", mock.Anything).Return(nil)
+
+	// Act
+	result, err := uc.Execute(ctx, batchrecord.SignBatchRecordInput{
+		RecordID:   record.ID,
+		Role:       entity.SignatureRoleQA,
+		SignerID:   uuid.New(),
+		SignerName: "QA Manager",
+		Meaning:    "Released",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.BatchRecordStatusReleased, result.Status)
+	eventPub.AssertCalled(t, "PublishBatchReleased", mock.Anything)
+}
+
+func TestSignBatchRecordUseCase_Execute_TamperedContent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockBatchRecordRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := batchrecord.NewSignBatchRecordUseCase(repo, eventPub)
+
+	record := newSignedRecord()
+	record.Content = []byte(`{"batch_number":"BATCH-999"}`)
+
+	repo.On("GetByID", ctx, record.ID).Return(record, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, batchrecord.SignBatchRecordInput{
+		RecordID: record.ID,
+		Role:     entity.SignatureRoleProduction,
+		SignerID: uuid.New(),
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrBatchRecordTampered, err)
+	repo.AssertNotCalled(t, "CreateSignature", mock.Anything, mock.Anything)
+}
+
+func TestGenerateBatchRecordUseCase_Execute_RequiresCompletedWO(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockBatchRecordRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+
+	uc := batchrecord.NewGenerateBatchRecordUseCase(repo, woRepo, nil, nil, nil, nil, nil)
+
+	wo := &entity.WorkOrder{ID: uuid.New(), BatchNumber: "BATCH-001", Status: entity.WOStatusInProgress}
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, wo.ID, uuid.New())
+
+	// Assert
+	assert.Equal(t, entity.ErrWONotCompleted, err)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,134 @@
+package batchrecord
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/pdf"
+)
+
+const timeLayout = "2006-01-02 15:04"
+
+// RenderPDF renders a batch record version as a printable PDF
+func RenderPDF(record *entity.BatchRecord) ([]byte, error) {
+	content, err := record.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	wo := content.WorkOrder
+
+	doc := pdf.NewDocument()
+	doc.Heading("Batch Manufacturing Record", 16)
+	doc.Field("Record", fmt.Sprintf("%s (version %d, %s)", record.RecordNumber, record.Version, record.Status))
+	doc.Field("Batch Number", record.BatchNumber)
+	doc.Field("Generated", record.GeneratedAt.Format(timeLayout))
+	doc.Field("Content SHA-256", record.ContentHash)
+	doc.Spacer()
+
+	if wo != nil {
+		doc.Heading("Work Order", 12)
+		doc.Field("WO Number", wo.WONumber)
+		doc.Field("Product", wo.ProductID.String())
+		doc.Field("BOM", wo.BOMID.String())
+		doc.Field("Planned Quantity", formatQty(wo.PlannedQuantity))
+		doc.Field("Actual / Good / Rejected", fmt.Sprintf("%s / %s / %s",
+			formatQtyPtr(wo.ActualQuantity), formatQtyPtr(wo.GoodQuantity), formatQtyPtr(wo.RejectedQuantity)))
+		doc.Field("Yield %", formatQtyPtr(wo.YieldPercentage))
+		doc.Field("Production Line / Shift", wo.ProductionLine+" / "+wo.Shift)
+		doc.Field("Started / Ended", formatTimePtr(wo.ActualStartDate)+" / "+formatTimePtr(wo.ActualEndDate))
+		doc.Spacer()
+
+		doc.Heading("Material Dispensing", 12)
+		cols := []float64{0, 110, 250, 340}
+		doc.Row(true, cols, "Issue", "Lot", "Quantity", "Issued At")
+		for _, issue := range wo.MaterialIssues {
+			doc.Row(false, cols, issue.IssueNumber, issue.LotNumber, formatQty(issue.Quantity), issue.IssueDate.Format(timeLayout))
+		}
+		doc.Spacer()
+	}
+
+	doc.Heading("Line Clearance", 12)
+	if lc := content.LineClearance; lc == nil {
+		doc.Text("Not recorded")
+	} else {
+		doc.Field("Clearance", fmt.Sprintf("%s (%s)", lc.ClearanceNumber, lc.Status))
+		doc.Field("Cleaning Level", fmt.Sprintf("%s (required %s: %s)", lc.CleaningLevel, lc.RequiredLevel, lc.LevelReason))
+		doc.Field("Previous Batch", orDash(lc.PreviousBatchNumber))
+		doc.Field("Completed / Verified", formatTimePtr(lc.CompletedAt)+" / "+formatTimePtr(lc.VerifiedAt))
+		lcCols := []float64{0, 70, 430}
+		doc.Row(true, lcCols, "Section", "Step", "Checked At")
+		for _, item := range lc.Items {
+			doc.Row(false, lcCols, string(item.Section), item.Description, formatTimePtr(item.CheckedAt))
+		}
+	}
+	doc.Spacer()
+
+	doc.Heading("Operations", 12)
+	opCols := []float64{0, 40, 170, 260, 330, 410}
+	doc.Row(true, opCols, "Seq", "Operation", "Status", "Std min", "Act min", "Deviation")
+	for _, op := range content.Operations {
+		deviation := "No"
+		if op.HasDeviation {
+			deviation = "YES"
+		}
+		doc.Row(false, opCols, fmt.Sprintf("%d", op.Sequence), op.OperationCode+" "+op.Name, string(op.Status),
+			formatQty(op.StandardMinutes), formatQty(op.ActualMinutes), deviation)
+	}
+	doc.Spacer()
+
+	doc.Heading("QC Inspections", 12)
+	qcCols := []float64{0, 110, 170, 260}
+	doc.Row(true, qcCols, "Inspection", "Type", "Result", "Approved At")
+	for _, ins := range content.QCInspections {
+		doc.Row(false, qcCols, ins.InspectionNumber, string(ins.InspectionType), string(ins.Result), formatTimePtr(ins.ApprovedAt))
+		for _, item := range ins.Items {
+			doc.Row(false, []float64{20, 200, 330}, item.TestName, item.ActualValue+" "+item.UOM, string(item.Result))
+		}
+	}
+	doc.Spacer()
+
+	doc.Heading("Non-Conformances", 12)
+	if len(content.NCRs) == 0 {
+		doc.Text("None recorded")
+	}
+	for _, ncr := range content.NCRs {
+		doc.Text(fmt.Sprintf("%s [%s/%s] %s", ncr.NCRNumber, ncr.Severity, ncr.Status, ncr.Description))
+	}
+	doc.Spacer()
+
+	doc.Heading("Electronic Signatures", 12)
+	sigCols := []float64{0, 90, 250, 370}
+	doc.Row(true, sigCols, "Role", "Signer", "Meaning", "Signed At")
+	for _, sig := range record.Signatures {
+		doc.Row(false, sigCols, string(sig.Role), sig.SignerName, sig.Meaning, sig.SignedAt.Format(timeLayout))
+	}
+
+	return doc.Bytes(), nil
+}
+
+func formatQty(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatQtyPtr(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return formatQty(*v)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(timeLayout)
+}
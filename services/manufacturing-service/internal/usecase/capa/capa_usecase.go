@@ -0,0 +1,357 @@
+package capa
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing for CAPA reminders
+type EventPublisher interface {
+	PublishCAPAActionOverdue(event event.CAPAActionOverdueEvent) error
+}
+
+// LinkInput identifies a record addressed by a CAPA
+type LinkInput struct {
+	SourceType      entity.CAPASourceType
+	SourceID        uuid.UUID
+	SourceReference string
+}
+
+// ActionInput is an action to assign on a CAPA
+type ActionInput struct {
+	ActionType  entity.CAPAActionType
+	Description string
+	OwnerID     uuid.UUID
+	DueDate     time.Time
+	IsCritical  bool
+}
+
+// CreateCAPAUseCase handles CAPA creation
+type CreateCAPAUseCase struct {
+	repo    repository.CAPARepository
+	ncrRepo repository.NCRRepository
+}
+
+// NewCreateCAPAUseCase creates a new CreateCAPAUseCase
+func NewCreateCAPAUseCase(repo repository.CAPARepository, ncrRepo repository.NCRRepository) *CreateCAPAUseCase {
+	return &CreateCAPAUseCase{repo: repo, ncrRepo: ncrRepo}
+}
+
+// CreateCAPAInput is the input for creating a CAPA
+type CreateCAPAInput struct {
+	Title                 string
+	Description           string
+	RootCause             string
+	OwnerID               uuid.UUID
+	DueDate               *time.Time
+	EffectivenessCriteria string
+	VerificationDueDate   *time.Time
+	Links                 []LinkInput
+	Actions               []ActionInput
+	CreatedBy             uuid.UUID
+}
+
+// Execute creates a CAPA with its links and initial actions
+func (uc *CreateCAPAUseCase) Execute(ctx context.Context, input CreateCAPAInput) (*entity.CAPA, error) {
+	capaNumber, err := uc.repo.GenerateCAPANumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	capa := &entity.CAPA{
+		ID:                    uuid.New(),
+		CAPANumber:            capaNumber,
+		Title:                 input.Title,
+		Description:           input.Description,
+		RootCause:             input.RootCause,
+		Status:                entity.CAPAStatusOpen,
+		OwnerID:               input.OwnerID,
+		DueDate:               input.DueDate,
+		EffectivenessCriteria: input.EffectivenessCriteria,
+		VerificationDueDate:   input.VerificationDueDate,
+		CreatedBy:             &input.CreatedBy,
+	}
+
+	for _, l := range input.Links {
+		link, err := newLink(ctx, uc.ncrRepo, capa, l, input.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		capa.Links = append(capa.Links, *link)
+	}
+	for _, a := range input.Actions {
+		if err := capa.AddAction(newAction(a)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Create(ctx, capa); err != nil {
+		return nil, err
+	}
+	return capa, nil
+}
+
+// GetCAPAUseCase handles getting a CAPA
+type GetCAPAUseCase struct {
+	repo repository.CAPARepository
+}
+
+// NewGetCAPAUseCase creates a new GetCAPAUseCase
+func NewGetCAPAUseCase(repo repository.CAPARepository) *GetCAPAUseCase {
+	return &GetCAPAUseCase{repo: repo}
+}
+
+// Execute gets a CAPA by ID with its actions and links
+func (uc *GetCAPAUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.CAPA, error) {
+	capa, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrCAPANotFound
+	}
+	return capa, nil
+}
+
+// ListCAPAsUseCase handles listing CAPAs
+type ListCAPAsUseCase struct {
+	repo repository.CAPARepository
+}
+
+// NewListCAPAsUseCase creates a new ListCAPAsUseCase
+func NewListCAPAsUseCase(repo repository.CAPARepository) *ListCAPAsUseCase {
+	return &ListCAPAsUseCase{repo: repo}
+}
+
+// Execute lists CAPAs
+func (uc *ListCAPAsUseCase) Execute(ctx context.Context, filter repository.CAPAFilter) ([]*entity.CAPA, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// LinkCAPAUseCase links a CAPA to another NCR, supplier evaluation or customer complaint
+type LinkCAPAUseCase struct {
+	repo    repository.CAPARepository
+	ncrRepo repository.NCRRepository
+}
+
+// NewLinkCAPAUseCase creates a new LinkCAPAUseCase
+func NewLinkCAPAUseCase(repo repository.CAPARepository, ncrRepo repository.NCRRepository) *LinkCAPAUseCase {
+	return &LinkCAPAUseCase{repo: repo, ncrRepo: ncrRepo}
+}
+
+// Execute adds a link to the CAPA
+func (uc *LinkCAPAUseCase) Execute(ctx context.Context, capaID uuid.UUID, input LinkInput, linkedBy uuid.UUID) (*entity.CAPALink, error) {
+	capa, err := uc.repo.GetByID(ctx, capaID)
+	if err != nil {
+		return nil, entity.ErrCAPANotFound
+	}
+	if capa.HasLink(input.SourceType, input.SourceID) {
+		return nil, entity.ErrCAPALinkExists
+	}
+
+	link, err := newLink(ctx, uc.ncrRepo, capa, input, linkedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.CreateLink(ctx, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// AddCAPAActionUseCase handles adding actions to a CAPA
+type AddCAPAActionUseCase struct {
+	repo repository.CAPARepository
+}
+
+// NewAddCAPAActionUseCase creates a new AddCAPAActionUseCase
+func NewAddCAPAActionUseCase(repo repository.CAPARepository) *AddCAPAActionUseCase {
+	return &AddCAPAActionUseCase{repo: repo}
+}
+
+// Execute adds an action to the CAPA
+func (uc *AddCAPAActionUseCase) Execute(ctx context.Context, capaID uuid.UUID, input ActionInput) (*entity.CAPAAction, error) {
+	capa, err := uc.repo.GetByID(ctx, capaID)
+	if err != nil {
+		return nil, entity.ErrCAPANotFound
+	}
+
+	action := newAction(input)
+	if err := capa.AddAction(action); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.CreateAction(ctx, action); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, capa); err != nil {
+		return nil, err
+	}
+	return action, nil
+}
+
+// CompleteCAPAActionUseCase handles completing a CAPA action
+type CompleteCAPAActionUseCase struct {
+	repo repository.CAPARepository
+}
+
+// NewCompleteCAPAActionUseCase creates a new CompleteCAPAActionUseCase
+func NewCompleteCAPAActionUseCase(repo repository.CAPARepository) *CompleteCAPAActionUseCase {
+	return &CompleteCAPAActionUseCase{repo: repo}
+}
+
+// CompleteCAPAActionInput is the input for completing a CAPA action
+type CompleteCAPAActionInput struct {
+	CAPAID      uuid.UUID
+	ActionID    uuid.UUID
+	Notes       string
+	CompletedBy uuid.UUID
+}
+
+// Execute completes the action and returns the updated CAPA
+func (uc *CompleteCAPAActionUseCase) Execute(ctx context.Context, input CompleteCAPAActionInput) (*entity.CAPA, error) {
+	capa, err := uc.repo.GetByID(ctx, input.CAPAID)
+	if err != nil {
+		return nil, entity.ErrCAPANotFound
+	}
+
+	action, err := capa.CompleteAction(input.ActionID, input.CompletedBy, input.Notes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateAction(ctx, action); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, capa); err != nil {
+		return nil, err
+	}
+	return capa, nil
+}
+
+// VerifyCAPAUseCase handles the effectiveness verification of a CAPA
+type VerifyCAPAUseCase struct {
+	repo repository.CAPARepository
+}
+
+// NewVerifyCAPAUseCase creates a new VerifyCAPAUseCase
+func NewVerifyCAPAUseCase(repo repository.CAPARepository) *VerifyCAPAUseCase {
+	return &VerifyCAPAUseCase{repo: repo}
+}
+
+// VerifyCAPAInput is the input for verifying CAPA effectiveness
+type VerifyCAPAInput struct {
+	CAPAID     uuid.UUID
+	Effective  bool
+	Notes      string
+	VerifiedBy uuid.UUID
+}
+
+// Execute records the verification; an effective CAPA is closed
+func (uc *VerifyCAPAUseCase) Execute(ctx context.Context, input VerifyCAPAInput) (*entity.CAPA, error) {
+	capa, err := uc.repo.GetByID(ctx, input.CAPAID)
+	if err != nil {
+		return nil, entity.ErrCAPANotFound
+	}
+
+	if err := capa.VerifyEffectiveness(input.Effective, input.VerifiedBy, input.Notes); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, capa); err != nil {
+		return nil, err
+	}
+	return capa, nil
+}
+
+// SendOverdueRemindersUseCase publishes reminders for overdue CAPA actions
+type SendOverdueRemindersUseCase struct {
+	repo     repository.CAPARepository
+	eventPub EventPublisher
+	interval time.Duration
+}
+
+// NewSendOverdueRemindersUseCase creates a new SendOverdueRemindersUseCase.
+// An action is reminded at most once per interval while it stays overdue.
+func NewSendOverdueRemindersUseCase(repo repository.CAPARepository, eventPub EventPublisher, interval time.Duration) *SendOverdueRemindersUseCase {
+	return &SendOverdueRemindersUseCase{repo: repo, eventPub: eventPub, interval: interval}
+}
+
+// Execute sends the reminders due at asOf and returns how many were sent
+func (uc *SendOverdueRemindersUseCase) Execute(ctx context.Context, asOf time.Time) (int, error) {
+	actions, err := uc.repo.GetOverdueActions(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, action := range actions {
+		if !action.ReminderDue(asOf, uc.interval) {
+			continue
+		}
+
+		overdueEvent := event.CAPAActionOverdueEvent{
+			CAPAID:      action.CAPAID.String(),
+			ActionID:    action.ID.String(),
+			ActionType:  string(action.ActionType),
+			Description: action.Description,
+			OwnerID:     action.OwnerID.String(),
+			DueDate:     action.DueDate.Format("2006-01-02"),
+			DaysOverdue: action.DaysOverdue(asOf),
+			IsCritical:  action.IsCritical,
+		}
+		if action.CAPA != nil {
+			overdueEvent.CAPANumber = action.CAPA.CAPANumber
+			overdueEvent.CAPATitle = action.CAPA.Title
+			overdueEvent.CAPAOwnerID = action.CAPA.OwnerID.String()
+		}
+		if err := uc.eventPub.PublishCAPAActionOverdue(overdueEvent); err != nil {
+			continue
+		}
+
+		action.LastRemindedAt = &asOf
+		if err := uc.repo.UpdateAction(ctx, action); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func newAction(input ActionInput) *entity.CAPAAction {
+	return &entity.CAPAAction{
+		ID:          uuid.New(),
+		ActionType:  input.ActionType,
+		Description: input.Description,
+		OwnerID:     input.OwnerID,
+		DueDate:     input.DueDate,
+		IsCritical:  input.IsCritical,
+	}
+}
+
+// newLink validates the source and builds the link. NCRs are resolved locally;
+// supplier evaluations and complaints live in other services and are taken as given.
+func newLink(ctx context.Context, ncrRepo repository.NCRRepository, capa *entity.CAPA, input LinkInput, linkedBy uuid.UUID) (*entity.CAPALink, error) {
+	if !input.SourceType.IsValid() {
+		return nil, entity.ErrInvalidCAPASource
+	}
+
+	link := &entity.CAPALink{
+		ID:              uuid.New(),
+		CAPAID:          capa.ID,
+		SourceType:      input.SourceType,
+		SourceID:        input.SourceID,
+		SourceReference: input.SourceReference,
+		CreatedBy:       &linkedBy,
+	}
+	if input.SourceType == entity.CAPASourceNCR {
+		ncr, err := ncrRepo.GetByID(ctx, input.SourceID)
+		if err != nil {
+			return nil, entity.ErrNCRNotFound
+		}
+		link.SourceReference = ncr.NCRNumber
+	}
+	return link, nil
+}
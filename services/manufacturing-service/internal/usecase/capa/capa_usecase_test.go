@@ -0,0 +1,142 @@
+package capa_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/capa"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCAPAUseCase_Execute_LinksNCRAndSourcesFromOtherServices(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	capaRepo := new(testmocks.MockCAPARepository)
+	ncrRepo := new(testmocks.MockNCRRepository)
+
+	uc := capa.NewCreateCAPAUseCase(capaRepo, ncrRepo)
+
+	ncr := &entity.NCR{ID: uuid.New(), NCRNumber: "NCR-2026-0007"}
+	capaRepo.On("GenerateCAPANumber", ctx).Return("CAPA-2026-0001", nil)
+	ncrRepo.On("GetByID", ctx, ncr.ID).Return(ncr, nil)
+	capaRepo.On("Create", ctx, mock.AnythingOfType("*entity.CAPA")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, capa.CreateCAPAInput{
+		Title:   "Viscosity out of spec on cream line",
+		OwnerID: uuid.New(),
+		Links: []capa.LinkInput{
+			{SourceType: entity.CAPASourceNCR, SourceID: ncr.ID},
+			{SourceType: entity.CAPASourceCustomerComplaint, SourceID: uuid.New(), SourceReference: "CC-0042"},
+		},
+		Actions: []capa.ActionInput{
+			{ActionType: entity.CAPAActionCorrective, Description: "Recalibrate viscometer", OwnerID: uuid.New(), DueDate: time.Now().AddDate(0, 0, 7), IsCritical: true},
+		},
+		CreatedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "CAPA-2026-0001", res.CAPANumber)
+	assert.Equal(t, entity.CAPAStatusInProgress, res.Status)
+	assert.Len(t, res.Links, 2)
+	assert.Equal(t, "NCR-2026-0007", res.Links[0].SourceReference)
+	assert.Equal(t, res.ID, res.Actions[0].CAPAID)
+}
+
+func TestCreateCAPAUseCase_Execute_UnknownNCR(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	capaRepo := new(testmocks.MockCAPARepository)
+	ncrRepo := new(testmocks.MockNCRRepository)
+
+	uc := capa.NewCreateCAPAUseCase(capaRepo, ncrRepo)
+
+	ncrID := uuid.New()
+	capaRepo.On("GenerateCAPANumber", ctx).Return("CAPA-2026-0002", nil)
+	ncrRepo.On("GetByID", ctx, ncrID).Return(nil, errors.New("record not found"))
+
+	// Act
+	res, err := uc.Execute(ctx, capa.CreateCAPAInput{
+		Title:   "Unknown NCR",
+		OwnerID: uuid.New(),
+		Links:   []capa.LinkInput{{SourceType: entity.CAPASourceNCR, SourceID: ncrID}},
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrNCRNotFound, err)
+	capaRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCompleteCAPAActionUseCase_Execute_LastActionMovesToVerification(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	capaRepo := new(testmocks.MockCAPARepository)
+
+	uc := capa.NewCompleteCAPAActionUseCase(capaRepo)
+
+	actionID := uuid.New()
+	record := &entity.CAPA{
+		ID:     uuid.New(),
+		Status: entity.CAPAStatusInProgress,
+		Actions: []entity.CAPAAction{
+			{ID: actionID, Status: entity.CAPAActionStatusOpen},
+			{ID: uuid.New(), Status: entity.CAPAActionStatusCompleted},
+		},
+	}
+	capaRepo.On("GetByID", ctx, record.ID).Return(record, nil)
+	capaRepo.On("UpdateAction", ctx, mock.AnythingOfType("*entity.CAPAAction")).Return(nil)
+	capaRepo.On("Update", ctx, record).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, capa.CompleteCAPAActionInput{CAPAID: record.ID, ActionID: actionID, CompletedBy: uuid.New()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CAPAStatusPendingVerification, res.Status)
+	assert.Equal(t, entity.CAPAActionStatusCompleted, res.Actions[0].Status)
+}
+
+func TestSendOverdueRemindersUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	capaRepo := new(testmocks.MockCAPARepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := capa.NewSendOverdueRemindersUseCase(capaRepo, eventPub, 24*time.Hour)
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	remindedRecently := now.Add(-2 * time.Hour)
+	parent := &entity.CAPA{ID: uuid.New(), CAPANumber: "CAPA-2026-0003", Title: "Label mix-up", OwnerID: uuid.New()}
+	overdue := &entity.CAPAAction{
+		ID: uuid.New(), CAPAID: parent.ID, CAPA: parent, ActionType: entity.CAPAActionCorrective,
+		OwnerID: uuid.New(), DueDate: time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC), Status: entity.CAPAActionStatusOpen, IsCritical: true,
+	}
+	alreadyReminded := &entity.CAPAAction{
+		ID: uuid.New(), CAPAID: parent.ID, CAPA: parent, OwnerID: uuid.New(),
+		DueDate: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), Status: entity.CAPAActionStatusOpen, LastRemindedAt: &remindedRecently,
+	}
+
+	capaRepo.On("GetOverdueActions", ctx, now).Return([]*entity.CAPAAction{overdue, alreadyReminded}, nil)
+	eventPub.On("PublishCAPAActionOverdue", mock.MatchedBy(func(e event.CAPAActionOverdueEvent) bool {
+		return e.ActionID == overdue.ID.String() && e.DaysOverdue == 3 && e.CAPANumber == "CAPA-2026-0003" && e.IsCritical
+	})).Return(nil)
+	capaRepo.On("UpdateAction", ctx, overdue).Return(nil)
+
+	// Act
+	sent, err := uc.Execute(ctx, now)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, now, *overdue.LastRemindedAt)
+	eventPub.AssertNumberOfCalls(t, "PublishCAPAActionOverdue", 1)
+}
@@ -0,0 +1,274 @@
+package coa
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/filestore"
+	"github.com/google/uuid"
+)
+
+const (
+	// fileCategory is the file-service category for certificates
+	fileCategory = "COA"
+	// lotEntityType links uploaded certificates to the WMS lot
+	lotEntityType = "LOT"
+)
+
+// FileUploader defines document storage for CoAs
+type FileUploader interface {
+	Upload(ctx context.Context, input filestore.UploadInput) (*filestore.UploadedFile, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// GenerateCoAUseCase issues a certificate of analysis for a finished lot
+type GenerateCoAUseCase struct {
+	repo     repository.CoARepository
+	qcRepo   repository.QCRepository
+	uploader FileUploader
+}
+
+// NewGenerateCoAUseCase creates a new GenerateCoAUseCase. uploader may be nil,
+// in which case the certificate is only stored in the database.
+func NewGenerateCoAUseCase(repo repository.CoARepository, qcRepo repository.QCRepository, uploader FileUploader) *GenerateCoAUseCase {
+	return &GenerateCoAUseCase{repo: repo, qcRepo: qcRepo, uploader: uploader}
+}
+
+// GenerateCoAInput is the input for issuing a CoA
+type GenerateCoAInput struct {
+	InspectionID uuid.UUID
+	IssuedBy     uuid.UUID
+	IssuedByName string
+}
+
+// Execute builds the CoA from the approved FQC inspection, uploads the PDF and
+// JSON renderings to file-service against the lot, then stores the certificate.
+// Uploaded files are deleted again if the certificate cannot be stored.
+func (uc *GenerateCoAUseCase) Execute(ctx context.Context, input GenerateCoAInput) (*entity.CertificateOfAnalysis, error) {
+	inspection, err := uc.qcRepo.GetInspectionByID(ctx, input.InspectionID)
+	if err != nil {
+		return nil, entity.ErrQCInspectionNotFound
+	}
+
+	coa, err := entity.NewCoAFromInspection(inspection)
+	if err != nil {
+		return nil, err
+	}
+	coa.ID = uuid.New()
+	coa.IssuedBy = input.IssuedBy
+	coa.IssuedByName = input.IssuedByName
+	if coa.CoANumber, err = uc.repo.GenerateCoANumber(ctx); err != nil {
+		return nil, err
+	}
+
+	if uc.uploader != nil {
+		pdfData, err := RenderPDF(coa)
+		if err != nil {
+			return nil, err
+		}
+		if coa.PDFFileID, err = uc.upload(ctx, coa, ".pdf", "application/pdf", pdfData); err != nil {
+			return nil, err
+		}
+
+		jsonData, err := RenderJSON(coa)
+		if err != nil {
+			return nil, err
+		}
+		if coa.JSONFileID, err = uc.upload(ctx, coa, ".json", "application/json", jsonData); err != nil {
+			uc.discard(ctx, coa.PDFFileID)
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.Create(ctx, coa); err != nil {
+		uc.discard(ctx, coa.PDFFileID, coa.JSONFileID)
+		return nil, err
+	}
+	return coa, nil
+}
+
+// discard deletes files uploaded for a certificate that was not stored. It is
+// best effort: the original error is what the caller needs to see.
+func (uc *GenerateCoAUseCase) discard(ctx context.Context, fileIDs ...*uuid.UUID) {
+	for _, id := range fileIDs {
+		if id != nil {
+			_ = uc.uploader.Delete(ctx, *id)
+		}
+	}
+}
+
+func (uc *GenerateCoAUseCase) upload(ctx context.Context, coa *entity.CertificateOfAnalysis, ext, contentType string, data []byte) (*uuid.UUID, error) {
+	file, err := uc.uploader.Upload(ctx, filestore.UploadInput{
+		FileName:    coa.CoANumber + ext,
+		ContentType: contentType,
+		Data:        data,
+		Category:    fileCategory,
+		EntityType:  lotEntityType,
+		EntityID:    coa.LotID,
+		CreatedBy:   &coa.IssuedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &file.ID, nil
+}
+
+// GetCoAUseCase handles getting a CoA
+type GetCoAUseCase struct {
+	repo repository.CoARepository
+}
+
+// NewGetCoAUseCase creates a new GetCoAUseCase
+func NewGetCoAUseCase(repo repository.CoARepository) *GetCoAUseCase {
+	return &GetCoAUseCase{repo: repo}
+}
+
+// Execute gets a CoA by ID
+func (uc *GetCoAUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.CertificateOfAnalysis, error) {
+	coa, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrCoANotFound
+	}
+	return coa, nil
+}
+
+// ListCoAsUseCase handles listing CoAs
+type ListCoAsUseCase struct {
+	repo repository.CoARepository
+}
+
+// NewListCoAsUseCase creates a new ListCoAsUseCase
+func NewListCoAsUseCase(repo repository.CoARepository) *ListCoAsUseCase {
+	return &ListCoAsUseCase{repo: repo}
+}
+
+// Execute lists CoAs
+func (uc *ListCoAsUseCase) Execute(ctx context.Context, filter repository.CoAFilter) ([]*entity.CertificateOfAnalysis, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// RecordSupplierCoAUseCase captures a supplier CoA at goods receipt and
+// compares it with our incoming material specifications
+type RecordSupplierCoAUseCase struct {
+	repo   repository.CoARepository
+	qcRepo repository.QCRepository
+}
+
+// NewRecordSupplierCoAUseCase creates a new RecordSupplierCoAUseCase
+func NewRecordSupplierCoAUseCase(repo repository.CoARepository, qcRepo repository.QCRepository) *RecordSupplierCoAUseCase {
+	return &RecordSupplierCoAUseCase{repo: repo, qcRepo: qcRepo}
+}
+
+// RecordSupplierCoAInput is the input for capturing a supplier CoA
+type RecordSupplierCoAInput struct {
+	GRNID             uuid.UUID
+	GRNNumber         string
+	GRNLineItemID     *uuid.UUID
+	SupplierID        uuid.UUID
+	MaterialID        uuid.UUID
+	LotID             *uuid.UUID
+	SupplierLotNumber string
+	CertificateNumber string
+	IssueDate         *time.Time
+	CheckpointID      uuid.UUID // IQC checkpoint holding the material specs
+	DocumentFileID    *uuid.UUID
+	Notes             string
+	ReceivedBy        uuid.UUID
+	Values            []SupplierCoAValueInput
+}
+
+// SupplierCoAValueInput is one value reported on the supplier certificate
+type SupplierCoAValueInput struct {
+	TestName      string
+	ReportedValue string
+	NumericValue  *float64
+	UOM           string
+	Result        entity.ItemResult // For non-numeric tests, e.g. appearance "Conforms"
+}
+
+// Execute stores the supplier CoA with each value compared against the IQC specs
+func (uc *RecordSupplierCoAUseCase) Execute(ctx context.Context, input RecordSupplierCoAInput) (*entity.SupplierCoA, error) {
+	if len(input.Values) == 0 {
+		return nil, entity.ErrSupplierCoANoValues
+	}
+
+	checkpoint, err := uc.qcRepo.GetCheckpointByID(ctx, input.CheckpointID)
+	if err != nil || checkpoint == nil || checkpoint.CheckpointType != entity.CheckpointTypeIQC {
+		return nil, entity.ErrQCCheckpointNotFound
+	}
+	templates, err := checkpoint.TestItemTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	coa := &entity.SupplierCoA{
+		ID:                uuid.New(),
+		GRNID:             input.GRNID,
+		GRNNumber:         input.GRNNumber,
+		GRNLineItemID:     input.GRNLineItemID,
+		SupplierID:        input.SupplierID,
+		MaterialID:        input.MaterialID,
+		LotID:             input.LotID,
+		SupplierLotNumber: input.SupplierLotNumber,
+		CertificateNumber: input.CertificateNumber,
+		IssueDate:         input.IssueDate,
+		CheckpointID:      checkpoint.ID,
+		DocumentFileID:    input.DocumentFileID,
+		Notes:             input.Notes,
+		ReceivedBy:        input.ReceivedBy,
+	}
+	for _, v := range input.Values {
+		coa.Values = append(coa.Values, entity.SupplierCoAValue{
+			TestName:      v.TestName,
+			ReportedValue: v.ReportedValue,
+			NumericValue:  v.NumericValue,
+			UOM:           v.UOM,
+			Result:        v.Result,
+		})
+	}
+	coa.CompareWithSpecs(templates)
+	for i := range coa.Values {
+		coa.Values[i].SupplierCoAID = coa.ID
+	}
+
+	if err := uc.repo.CreateSupplierCoA(ctx, coa); err != nil {
+		return nil, err
+	}
+	return coa, nil
+}
+
+// GetSupplierCoAUseCase handles getting a supplier CoA
+type GetSupplierCoAUseCase struct {
+	repo repository.CoARepository
+}
+
+// NewGetSupplierCoAUseCase creates a new GetSupplierCoAUseCase
+func NewGetSupplierCoAUseCase(repo repository.CoARepository) *GetSupplierCoAUseCase {
+	return &GetSupplierCoAUseCase{repo: repo}
+}
+
+// Execute gets a supplier CoA by ID
+func (uc *GetSupplierCoAUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.SupplierCoA, error) {
+	coa, err := uc.repo.GetSupplierCoAByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrSupplierCoANotFound
+	}
+	return coa, nil
+}
+
+// ListSupplierCoAsUseCase handles listing supplier CoAs
+type ListSupplierCoAsUseCase struct {
+	repo repository.CoARepository
+}
+
+// NewListSupplierCoAsUseCase creates a new ListSupplierCoAsUseCase
+func NewListSupplierCoAsUseCase(repo repository.CoARepository) *ListSupplierCoAsUseCase {
+	return &ListSupplierCoAsUseCase{repo: repo}
+}
+
+// Execute lists supplier CoAs
+func (uc *ListSupplierCoAsUseCase) Execute(ctx context.Context, filter repository.SupplierCoAFilter) ([]*entity.SupplierCoA, int64, error) {
+	return uc.repo.ListSupplierCoAs(ctx, filter)
+}
@@ -0,0 +1,183 @@
+package coa_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/filestore"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/coa"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestGenerateCoAUseCase_Execute_UploadsPDFAndJSONToLot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	coaRepo := new(testmocks.MockCoARepository)
+	qcRepo := new(testmocks.MockQCRepository)
+	uploader := new(testmocks.MockFileUploader)
+
+	uc := coa.NewGenerateCoAUseCase(coaRepo, qcRepo, uploader)
+
+	lotID := uuid.New()
+	inspection := &entity.QCInspection{
+		ID:               uuid.New(),
+		InspectionNumber: "QC-2026-0020",
+		InspectionType:   entity.CheckpointTypeFQC,
+		LotID:            &lotID,
+		LotNumber:        "LOT-2026-002",
+		Result:           entity.InspectionResultPassed,
+		Items: []entity.QCInspectionItem{
+			{TestName: "pH", ActualValue: "6.0", Result: entity.ItemResultPass},
+		},
+	}
+	pdfID, jsonID := uuid.New(), uuid.New()
+
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+	coaRepo.On("GenerateCoANumber", ctx).Return("COA-2026-0001", nil)
+	uploader.On("Upload", ctx, mock.MatchedBy(func(in filestore.UploadInput) bool {
+		return in.FileName == "COA-2026-0001.pdf"
+	})).Return(&filestore.UploadedFile{ID: pdfID}, nil)
+	uploader.On("Upload", ctx, mock.MatchedBy(func(in filestore.UploadInput) bool {
+		return in.FileName == "COA-2026-0001.json"
+	})).Return(&filestore.UploadedFile{ID: jsonID}, nil)
+	coaRepo.On("Create", ctx, mock.AnythingOfType("*entity.CertificateOfAnalysis")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, coa.GenerateCoAInput{InspectionID: inspection.ID, IssuedBy: uuid.New(), IssuedByName: "QA Manager"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "COA-2026-0001", res.CoANumber)
+	assert.Equal(t, pdfID, *res.PDFFileID)
+	assert.Equal(t, jsonID, *res.JSONFileID)
+	for _, call := range uploader.Calls {
+		in := call.Arguments.Get(1).(filestore.UploadInput)
+		assert.Equal(t, "LOT", in.EntityType)
+		assert.Equal(t, lotID, in.EntityID)
+		assert.NotEmpty(t, in.Data)
+	}
+	uploader.AssertNumberOfCalls(t, "Upload", 2)
+}
+
+func TestGenerateCoAUseCase_Execute_StoreFailureDeletesUploads(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	coaRepo := new(testmocks.MockCoARepository)
+	qcRepo := new(testmocks.MockQCRepository)
+	uploader := new(testmocks.MockFileUploader)
+
+	uc := coa.NewGenerateCoAUseCase(coaRepo, qcRepo, uploader)
+
+	lotID := uuid.New()
+	inspection := &entity.QCInspection{ID: uuid.New(), InspectionType: entity.CheckpointTypeFQC, LotID: &lotID, LotNumber: "LOT-2026-003", Result: entity.InspectionResultPassed,
+		Items: []entity.QCInspectionItem{{TestName: "pH", ActualValue: "6.1", Result: entity.ItemResultPass}},
+	}
+	pdfID, jsonID := uuid.New(), uuid.New()
+	dbErr := errors.New("duplicate key value violates unique constraint")
+
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+	coaRepo.On("GenerateCoANumber", ctx).Return("COA-2026-0002", nil)
+	uploader.On("Upload", ctx, mock.MatchedBy(func(in filestore.UploadInput) bool {
+		return in.FileName == "COA-2026-0002.pdf"
+	})).Return(&filestore.UploadedFile{ID: pdfID}, nil)
+	uploader.On("Upload", ctx, mock.MatchedBy(func(in filestore.UploadInput) bool {
+		return in.FileName == "COA-2026-0002.json"
+	})).Return(&filestore.UploadedFile{ID: jsonID}, nil)
+	uploader.On("Delete", ctx, pdfID).Return(nil)
+	uploader.On("Delete", ctx, jsonID).Return(nil)
+	coaRepo.On("Create", ctx, mock.AnythingOfType("*entity.CertificateOfAnalysis")).Return(dbErr)
+
+	// Act
+	res, err := uc.Execute(ctx, coa.GenerateCoAInput{InspectionID: inspection.ID, IssuedBy: uuid.New()})
+
+	// Assert: no orphaned certificate files are left on the lot
+	assert.Nil(t, res)
+	assert.Equal(t, dbErr, err)
+	uploader.AssertExpectations(t)
+}
+
+func TestGenerateCoAUseCase_Execute_FailedInspection(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	coaRepo := new(testmocks.MockCoARepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := coa.NewGenerateCoAUseCase(coaRepo, qcRepo, nil)
+
+	lotID := uuid.New()
+	inspection := &entity.QCInspection{ID: uuid.New(), InspectionType: entity.CheckpointTypeFQC, LotID: &lotID, Result: entity.InspectionResultFailed}
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, coa.GenerateCoAInput{InspectionID: inspection.ID})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrCoAInspectionNotPassed, err)
+	coaRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRecordSupplierCoAUseCase_Execute_ComparesWithIQCSpecs(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	coaRepo := new(testmocks.MockCoARepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := coa.NewRecordSupplierCoAUseCase(coaRepo, qcRepo)
+
+	templates, _ := json.Marshal([]entity.TestItemTemplate{
+		{Name: "pH", Type: "NUMERIC", Min: floatPtr(4), Max: floatPtr(8)},
+	})
+	checkpoint := &entity.QCCheckpoint{ID: uuid.New(), CheckpointType: entity.CheckpointTypeIQC, TestItems: templates}
+
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+	coaRepo.On("CreateSupplierCoA", ctx, mock.AnythingOfType("*entity.SupplierCoA")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, coa.RecordSupplierCoAInput{
+		GRNID:        uuid.New(),
+		SupplierID:   uuid.New(),
+		MaterialID:   uuid.New(),
+		CheckpointID: checkpoint.ID,
+		ReceivedBy:   uuid.New(),
+		Values:       []coa.SupplierCoAValueInput{{TestName: "pH", NumericValue: floatPtr(9.1)}},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SupplierCoAStatusNonConforming, res.Status)
+	assert.Equal(t, res.ID, res.Values[0].SupplierCoAID)
+	assert.True(t, res.Values[0].OutOfSpec)
+}
+
+func TestRecordSupplierCoAUseCase_Execute_RequiresIQCCheckpoint(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	coaRepo := new(testmocks.MockCoARepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := coa.NewRecordSupplierCoAUseCase(coaRepo, qcRepo)
+
+	checkpoint := &entity.QCCheckpoint{ID: uuid.New(), CheckpointType: entity.CheckpointTypeFQC}
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, coa.RecordSupplierCoAInput{
+		CheckpointID: checkpoint.ID,
+		Values:       []coa.SupplierCoAValueInput{{TestName: "pH", NumericValue: floatPtr(6)}},
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrQCCheckpointNotFound, err)
+}
@@ -0,0 +1,64 @@
+package coa
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/pdf"
+)
+
+const timeLayout = "2006-01-02 15:04"
+
+// RenderPDF renders a certificate of analysis as a printable PDF
+func RenderPDF(coa *entity.CertificateOfAnalysis) ([]byte, error) {
+	results, err := coa.GetResults()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := pdf.NewDocument()
+	doc.Heading("Certificate of Analysis", 16)
+	doc.Field("Certificate No.", coa.CoANumber)
+	doc.Field("Lot Number", coa.LotNumber)
+	if coa.ProductID != nil {
+		doc.Field("Product", coa.ProductID.String())
+	}
+	doc.Field("Quantity", strconv.FormatFloat(coa.Quantity, 'f', -1, 64))
+	doc.Field("QC Inspection", coa.InspectionNumber)
+	if coa.ApprovedAt != nil {
+		doc.Field("QC Released", coa.ApprovedAt.Format(timeLayout))
+	}
+	doc.Spacer()
+
+	doc.Heading("Test Results", 12)
+	cols := []float64{0, 150, 300, 420}
+	doc.Row(true, cols, "Test", "Specification", "Result", "Conclusion")
+	for _, r := range results {
+		result := r.Result
+		if r.UOM != "" && result != "" {
+			result += " " + r.UOM
+		}
+		doc.Row(false, cols, r.TestName, r.Specification, result, string(r.Conclusion))
+	}
+	doc.Spacer()
+
+	doc.Heading("Conclusion", 12)
+	switch coa.Conclusion {
+	case entity.CoAConclusionConformsConditionally:
+		doc.Text("The lot conforms to specification with conditions recorded by QC.")
+	default:
+		doc.Text("The lot conforms to specification.")
+	}
+	doc.Spacer()
+	doc.Field("Issued by", fmt.Sprintf("%s (%s)", coa.IssuedByName, coa.IssuedBy))
+	doc.Field("Issued at", coa.IssuedAt.Format(timeLayout))
+
+	return doc.Bytes(), nil
+}
+
+// RenderJSON renders a certificate of analysis for machine consumption
+func RenderJSON(coa *entity.CertificateOfAnalysis) ([]byte, error) {
+	return json.MarshalIndent(coa, "", "  ")
+}
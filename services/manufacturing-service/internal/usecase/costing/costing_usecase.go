@@ -0,0 +1,313 @@
+package costing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing interface
+type EventPublisher interface {
+	PublishWOCosted(event event.WOCostedEvent) error
+}
+
+// WarehouseClient reads lot valuation from wms-service
+type WarehouseClient interface {
+	GetLot(ctx context.Context, id uuid.UUID) (*wms.Lot, error)
+}
+
+// CalculateWOCostUseCase handles actual costing of a completed work order
+type CalculateWOCostUseCase struct {
+	repo        repository.CostingRepository
+	woRepo      repository.WorkOrderRepository
+	bomRepo     repository.BOMRepository
+	opRepo      repository.WOOperationRepository
+	routingRepo repository.RoutingRepository
+	warehouse   WarehouseClient
+	eventPub    EventPublisher
+}
+
+// NewCalculateWOCostUseCase creates a new CalculateWOCostUseCase
+func NewCalculateWOCostUseCase(
+	repo repository.CostingRepository,
+	woRepo repository.WorkOrderRepository,
+	bomRepo repository.BOMRepository,
+	opRepo repository.WOOperationRepository,
+	routingRepo repository.RoutingRepository,
+	warehouse WarehouseClient,
+	eventPub EventPublisher,
+) *CalculateWOCostUseCase {
+	return &CalculateWOCostUseCase{
+		repo:        repo,
+		woRepo:      woRepo,
+		bomRepo:     bomRepo,
+		opRepo:      opRepo,
+		routingRepo: routingRepo,
+		warehouse:   warehouse,
+		eventPub:    eventPub,
+	}
+}
+
+// Execute costs a completed work order: material from the WMS valuation of the
+// issued lots, labor from operation times × work center rates and overhead from
+// operation hours × the line absorption rate. The result replaces any previous
+// calculation and the unit cost is pushed to the finished goods lot in WMS.
+func (uc *CalculateWOCostUseCase) Execute(ctx context.Context, woID uuid.UUID, userID uuid.UUID) (*entity.WOCost, error) {
+	wo, err := uc.woRepo.GetByID(ctx, woID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	if wo.Status != entity.WOStatusCompleted {
+		return nil, entity.ErrWONotCompleted
+	}
+	output := 0.0
+	if wo.GoodQuantity != nil {
+		output = *wo.GoodQuantity
+	} else if wo.ActualQuantity != nil {
+		output = *wo.ActualQuantity
+	}
+	if output <= 0 {
+		return nil, entity.ErrWOCostNoOutput
+	}
+
+	bom, err := uc.bomRepo.GetByID(ctx, wo.BOMID)
+	if err != nil {
+		return nil, entity.ErrBOMNotFound
+	}
+
+	cost := &entity.WOCost{
+		ID:             uuid.New(),
+		WorkOrderID:    wo.ID,
+		WONumber:       wo.WONumber,
+		ProductID:      wo.ProductID,
+		BOMID:          wo.BOMID,
+		BatchNumber:    wo.BatchNumber,
+		OutputLotID:    wo.OutputLotID,
+		OutputQuantity: output,
+		CalculatedBy:   &userID,
+		CalculatedAt:   time.Now(),
+	}
+
+	if err := uc.addMaterialCost(ctx, cost, wo, bom); err != nil {
+		return nil, err
+	}
+	if err := uc.addConversionCost(ctx, cost, wo); err != nil {
+		return nil, err
+	}
+	cost.ApplyStandard(bom)
+
+	for i := range cost.Lines {
+		cost.Lines[i].ID = uuid.New()
+		cost.Lines[i].WOCostID = cost.ID
+	}
+	if err := uc.repo.SaveWOCost(ctx, cost); err != nil {
+		return nil, err
+	}
+
+	costed := event.WOCostedEvent{
+		WOID:           wo.ID.String(),
+		WONumber:       wo.WONumber,
+		ProductID:      wo.ProductID.String(),
+		BatchNumber:    wo.BatchNumber,
+		OutputQuantity: cost.OutputQuantity,
+		UnitCost:       cost.UnitCost,
+		TotalCost:      cost.TotalCost,
+	}
+	if wo.OutputLotID != nil {
+		costed.OutputLotID = wo.OutputLotID.String()
+	}
+	uc.eventPub.PublishWOCosted(costed)
+
+	return cost, nil
+}
+
+// addMaterialCost values the material issues at the WMS lot cost (falling back to
+// the cost captured at issue, then the BOM standard) and adds one line per WO line
+func (uc *CalculateWOCostUseCase) addMaterialCost(ctx context.Context, cost *entity.WOCost, wo *entity.WorkOrder, bom *entity.BOM) error {
+	lotCost := make(map[uuid.UUID]float64)
+	issues := make([]*entity.WOMaterialIssue, 0, len(wo.MaterialIssues))
+	for i := range wo.MaterialIssues {
+		issue := wo.MaterialIssues[i]
+		unitCost, ok := lotCost[issue.LotID]
+		if !ok {
+			lot, err := uc.warehouse.GetLot(ctx, issue.LotID)
+			if err != nil {
+				return fmt.Errorf("failed to read valuation of lot %s: %w", issue.LotNumber, err)
+			}
+			unitCost = lot.UnitCost
+			lotCost[issue.LotID] = unitCost
+		}
+		if unitCost > 0 {
+			issue.UnitCost = unitCost
+		}
+		issues = append(issues, &issue)
+	}
+
+	bomItems := make([]*entity.BOMLineItem, len(bom.Items))
+	for i := range bom.Items {
+		bomItems[i] = &bom.Items[i]
+	}
+	lines := make([]*entity.WOLineItem, len(wo.Items))
+	for i := range wo.Items {
+		lines[i] = &wo.Items[i]
+	}
+
+	report := entity.BuildMaterialVarianceReport(wo, bom, bomItems, lines, issues)
+	for _, v := range report.Lines {
+		lineID := v.WOLineItemID
+		cost.AddLine(entity.WOCostLine{
+			CostElement: entity.CostElementMaterial,
+			ReferenceID: &lineID,
+			Reference:   "Line " + strconv.Itoa(v.LineNumber),
+			Quantity:    v.ActualQuantity,
+			Rate:        v.ActualUnitCost,
+			Amount:      v.ActualCost,
+		})
+	}
+	return nil
+}
+
+// addConversionCost adds labor and overhead for each operation with recorded time.
+// Overhead is absorbed at the rate of the work center's line, or the work
+// order's line when the work center is not assigned to one.
+func (uc *CalculateWOCostUseCase) addConversionCost(ctx context.Context, cost *entity.WOCost, wo *entity.WorkOrder) error {
+	ops, err := uc.opRepo.GetByWorkOrder(ctx, wo.ID)
+	if err != nil {
+		return err
+	}
+
+	overheadRates := make(map[string]float64)
+	for _, op := range ops {
+		if op.ActualMinutes <= 0 {
+			continue
+		}
+		hours := op.ActualMinutes / 60
+		opID := op.ID
+
+		wc, err := uc.routingRepo.GetWorkCenterByID(ctx, op.WorkCenterID)
+		if err != nil {
+			return entity.ErrWorkCenterNotFound
+		}
+		cost.AddLine(entity.WOCostLine{
+			CostElement: entity.CostElementLabor,
+			ReferenceID: &opID,
+			Reference:   op.OperationCode + " @ " + wc.Code,
+			Quantity:    hours,
+			Rate:        wc.CostPerHour,
+			Amount:      hours * wc.CostPerHour,
+		})
+
+		line := wc.ProductionLine
+		if line == "" {
+			line = wo.ProductionLine
+		}
+		if line == "" {
+			continue
+		}
+		rate, ok := overheadRates[line]
+		if !ok {
+			if r, err := uc.repo.GetOverheadRate(ctx, line); err == nil {
+				rate = r.RatePerHour
+			}
+			overheadRates[line] = rate
+		}
+		if rate == 0 {
+			continue
+		}
+		cost.AddLine(entity.WOCostLine{
+			CostElement: entity.CostElementOverhead,
+			ReferenceID: &opID,
+			Reference:   op.OperationCode + " @ " + line,
+			Quantity:    hours,
+			Rate:        rate,
+			Amount:      hours * rate,
+		})
+	}
+	return nil
+}
+
+// GetWOCostUseCase handles getting the cost of a work order
+type GetWOCostUseCase struct {
+	repo repository.CostingRepository
+}
+
+// NewGetWOCostUseCase creates a new GetWOCostUseCase
+func NewGetWOCostUseCase(repo repository.CostingRepository) *GetWOCostUseCase {
+	return &GetWOCostUseCase{repo: repo}
+}
+
+// Execute gets the latest cost calculation of a work order
+func (uc *GetWOCostUseCase) Execute(ctx context.Context, woID uuid.UUID) (*entity.WOCost, error) {
+	cost, err := uc.repo.GetWOCostByWorkOrder(ctx, woID)
+	if err != nil {
+		return nil, entity.ErrWOCostNotFound
+	}
+	return cost, nil
+}
+
+// SetOverheadRateUseCase handles maintaining line overhead absorption rates
+type SetOverheadRateUseCase struct {
+	repo repository.CostingRepository
+}
+
+// NewSetOverheadRateUseCase creates a new SetOverheadRateUseCase
+func NewSetOverheadRateUseCase(repo repository.CostingRepository) *SetOverheadRateUseCase {
+	return &SetOverheadRateUseCase{repo: repo}
+}
+
+// SetOverheadRateInput represents input for setting a line overhead rate
+type SetOverheadRateInput struct {
+	ProductionLine string
+	RatePerHour    float64
+	Notes          string
+	UpdatedBy      uuid.UUID
+}
+
+// Execute creates or updates the overhead rate of a production line
+func (uc *SetOverheadRateUseCase) Execute(ctx context.Context, input SetOverheadRateInput) (*entity.LineOverheadRate, error) {
+	line := strings.TrimSpace(input.ProductionLine)
+	if line == "" || input.RatePerHour < 0 {
+		return nil, entity.ErrInvalidOverheadRate
+	}
+
+	rate, err := uc.repo.GetOverheadRate(ctx, line)
+	if err != nil {
+		rate = &entity.LineOverheadRate{
+			ID:             uuid.New(),
+			ProductionLine: line,
+			CreatedAt:      time.Now(),
+		}
+	}
+	rate.RatePerHour = input.RatePerHour
+	rate.Notes = input.Notes
+	rate.UpdatedBy = &input.UpdatedBy
+	rate.UpdatedAt = time.Now()
+
+	if err := uc.repo.SaveOverheadRate(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// ListOverheadRatesUseCase handles listing line overhead rates
+type ListOverheadRatesUseCase struct {
+	repo repository.CostingRepository
+}
+
+// NewListOverheadRatesUseCase creates a new ListOverheadRatesUseCase
+func NewListOverheadRatesUseCase(repo repository.CostingRepository) *ListOverheadRatesUseCase {
+	return &ListOverheadRatesUseCase{repo: repo}
+}
+
+// Execute lists the overhead rates of all production lines
+func (uc *ListOverheadRatesUseCase) Execute(ctx context.Context) ([]*entity.LineOverheadRate, error) {
+	return uc.repo.ListOverheadRates(ctx)
+}
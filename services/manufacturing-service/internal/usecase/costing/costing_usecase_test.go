@@ -0,0 +1,137 @@
+package costing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/costing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCalculateWOCostUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockCostingRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	bomRepo := new(testmocks.MockBOMRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	routingRepo := new(testmocks.MockRoutingRepository)
+	warehouse := new(testmocks.MockWarehouseClient)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := costing.NewCalculateWOCostUseCase(repo, woRepo, bomRepo, opRepo, routingRepo, warehouse, eventPub)
+
+	bomItemID, lineID := uuid.New(), uuid.New()
+	bom := &entity.BOM{
+		ID:           uuid.New(),
+		BatchSize:    100,
+		MaterialCost: 1000000,
+		LaborCost:    200000,
+		OverheadCost: 100000,
+		Items:        []entity.BOMLineItem{{ID: bomItemID, Quantity: 50, UnitCost: 20000}},
+	}
+	lotA, lotB := uuid.New(), uuid.New()
+	good, actual := 100.0, 100.0
+	outputLot := uuid.New()
+	wo := &entity.WorkOrder{
+		ID:             uuid.New(),
+		WONumber:       "WO-2026-0040",
+		BOMID:          bom.ID,
+		Status:         entity.WOStatusCompleted,
+		ActualQuantity: &actual,
+		GoodQuantity:   &good,
+		BatchNumber:    "B2026-0040",
+		OutputLotID:    &outputLot,
+		ProductionLine: "LINE-A",
+		Items:          []entity.WOLineItem{{ID: lineID, BOMLineItemID: &bomItemID, LineNumber: 1, PlannedQuantity: 50, IssuedQuantity: 50}},
+		MaterialIssues: []entity.WOMaterialIssue{
+			{WOLineItemID: &lineID, IssueType: entity.MaterialIssueTypeIssue, LotID: lotA, LotNumber: "A", Quantity: 30},
+			{WOLineItemID: &lineID, IssueType: entity.MaterialIssueTypeIssue, LotID: lotB, LotNumber: "B", Quantity: 20, UnitCost: 21000},
+		},
+	}
+	mixer := &entity.WorkCenter{ID: uuid.New(), Code: "WC-MIX", CostPerHour: 150000}
+	filler := &entity.WorkCenter{ID: uuid.New(), Code: "WC-FILL", ProductionLine: "LINE-F", CostPerHour: 100000}
+	ops := []*entity.WOOperation{
+		{ID: uuid.New(), OperationCode: "MIX", WorkCenterID: mixer.ID, ActualMinutes: 90},
+		{ID: uuid.New(), OperationCode: "FILL", WorkCenterID: filler.ID, ActualMinutes: 60},
+		{ID: uuid.New(), OperationCode: "PACK", WorkCenterID: filler.ID}, // Not recorded
+	}
+
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	bomRepo.On("GetByID", ctx, bom.ID).Return(bom, nil)
+	warehouse.On("GetLot", ctx, lotA).Return(&wms.Lot{ID: lotA, UnitCost: 22000}, nil)
+	warehouse.On("GetLot", ctx, lotB).Return(&wms.Lot{ID: lotB}, nil) // Not valued in WMS
+	opRepo.On("GetByWorkOrder", ctx, wo.ID).Return(ops, nil)
+	routingRepo.On("GetWorkCenterByID", ctx, mixer.ID).Return(mixer, nil)
+	routingRepo.On("GetWorkCenterByID", ctx, filler.ID).Return(filler, nil)
+	repo.On("GetOverheadRate", ctx, "LINE-A").Return(&entity.LineOverheadRate{ProductionLine: "LINE-A", RatePerHour: 40000}, nil)
+	repo.On("GetOverheadRate", ctx, "LINE-F").Return(nil, errors.New("record not found"))
+	repo.On("SaveWOCost", ctx, mock.AnythingOfType("*entity.WOCost")).Return(nil)
+	eventPub.On("PublishWOCosted", mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, wo.ID, uuid.New())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.InDelta(t, 30*22000.0+20*21000.0, res.MaterialCost, 1e-6) // WMS cost, else the cost at issue
+	assert.InDelta(t, 1.5*150000+1*100000.0, res.LaborCost, 1e-6)
+	assert.InDelta(t, 1.5*40000.0, res.OverheadCost, 1e-6) // LINE-F has no rate
+	assert.InDelta(t, 2.5, res.LaborHours, 1e-9)
+	assert.InDelta(t, (1080000.0+325000+60000)/100, res.UnitCost, 1e-6)
+	assert.InDelta(t, 13000.0, res.StandardUnitCost, 1e-6)
+	assert.InDelta(t, 1465000.0-1300000, res.TotalVariance, 1e-6)
+	assert.Len(t, res.Lines, 4)
+	for _, line := range res.Lines {
+		assert.Equal(t, res.ID, line.WOCostID)
+	}
+	eventPub.AssertCalled(t, "PublishWOCosted", mock.MatchedBy(func(e event.WOCostedEvent) bool {
+		return e.OutputLotID == outputLot.String() && e.BatchNumber == "B2026-0040" && e.UnitCost == res.UnitCost
+	}))
+}
+
+func TestCalculateWOCostUseCase_Execute_NotCompleted(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	repo := new(testmocks.MockCostingRepository)
+
+	uc := costing.NewCalculateWOCostUseCase(repo, woRepo, nil, nil, nil, nil, nil)
+
+	wo := &entity.WorkOrder{ID: uuid.New(), Status: entity.WOStatusInProgress}
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, wo.ID, uuid.New())
+
+	// Assert
+	assert.Equal(t, entity.ErrWONotCompleted, err)
+	repo.AssertNotCalled(t, "SaveWOCost", mock.Anything, mock.Anything)
+}
+
+func TestSetOverheadRateUseCase_Execute_UpdatesExistingLine(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockCostingRepository)
+
+	uc := costing.NewSetOverheadRateUseCase(repo)
+
+	existing := &entity.LineOverheadRate{ID: uuid.New(), ProductionLine: "LINE-A", RatePerHour: 35000}
+	repo.On("GetOverheadRate", ctx, "LINE-A").Return(existing, nil)
+	repo.On("SaveOverheadRate", ctx, existing).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, costing.SetOverheadRateInput{ProductionLine: " LINE-A ", RatePerHour: 42000})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, existing.ID, res.ID)
+	assert.Equal(t, 42000.0, res.RatePerHour)
+}
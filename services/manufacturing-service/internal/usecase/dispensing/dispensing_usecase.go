@@ -0,0 +1,400 @@
+package dispensing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing interface for dispensing
+type EventPublisher interface {
+	PublishMaterialIssued(event event.MaterialIssuedEvent) error
+}
+
+// WarehouseClient reads lots from wms-service
+type WarehouseClient interface {
+	GetLot(ctx context.Context, id uuid.UUID) (*wms.Lot, error)
+}
+
+// GenerateWeighingTicketsUseCase generates weighing tickets for the lines of a work order
+type GenerateWeighingTicketsUseCase struct {
+	repo                repository.DispensingRepository
+	woRepo              repository.WorkOrderRepository
+	bomRepo             repository.BOMRepository
+	defaultTolerancePct float64
+}
+
+// NewGenerateWeighingTicketsUseCase creates a new GenerateWeighingTicketsUseCase.
+// defaultTolerancePct applies to BOM lines without min/max limits.
+func NewGenerateWeighingTicketsUseCase(repo repository.DispensingRepository, woRepo repository.WorkOrderRepository, bomRepo repository.BOMRepository, defaultTolerancePct float64) *GenerateWeighingTicketsUseCase {
+	return &GenerateWeighingTicketsUseCase{
+		repo:                repo,
+		woRepo:              woRepo,
+		bomRepo:             bomRepo,
+		defaultTolerancePct: defaultTolerancePct,
+	}
+}
+
+// Execute creates one ticket per WO line that has no open or confirmed ticket yet
+// and returns all tickets of the work order
+func (uc *GenerateWeighingTicketsUseCase) Execute(ctx context.Context, woID uuid.UUID, createdBy uuid.UUID) ([]*entity.WeighingTicket, error) {
+	wo, err := uc.woRepo.GetByID(ctx, woID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	if !wo.CanDispense() {
+		return nil, entity.ErrWONotDispensable
+	}
+
+	bom, err := uc.bomRepo.GetByID(ctx, wo.BOMID)
+	if err != nil {
+		return nil, entity.ErrBOMNotFound
+	}
+	bomItems, err := uc.bomRepo.GetLineItems(ctx, bom.ID)
+	if err != nil {
+		return nil, err
+	}
+	bomItemByID := make(map[uuid.UUID]*entity.BOMLineItem, len(bomItems))
+	for _, item := range bomItems {
+		bomItemByID[item.ID] = item
+	}
+
+	ratio := 1.0
+	if bom.BatchSize > 0 {
+		ratio = wo.PlannedQuantity / bom.BatchSize
+	}
+
+	lines, err := uc.woRepo.GetLineItems(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := uc.repo.GetTicketsByWorkOrder(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+	covered := make(map[uuid.UUID]bool)
+	for _, t := range existing {
+		if t.Status != entity.WeighingTicketStatusCancelled {
+			covered[t.WOLineItemID] = true
+		}
+	}
+
+	tickets := existing
+	for _, line := range lines {
+		if covered[line.ID] {
+			continue
+		}
+
+		var bomItem *entity.BOMLineItem
+		if line.BOMLineItemID != nil {
+			bomItem = bomItemByID[*line.BOMLineItemID]
+		}
+
+		ticket := entity.NewWeighingTicket(wo, line, bomItem, ratio, uc.defaultTolerancePct)
+		ticket.CreatedBy = &createdBy
+		ticket.TicketNumber, err = uc.repo.GenerateTicketNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.repo.CreateTicket(ctx, ticket); err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
+
+// ListWeighingTicketsUseCase handles listing the weighing tickets of a work order
+type ListWeighingTicketsUseCase struct {
+	repo repository.DispensingRepository
+}
+
+// NewListWeighingTicketsUseCase creates a new ListWeighingTicketsUseCase
+func NewListWeighingTicketsUseCase(repo repository.DispensingRepository) *ListWeighingTicketsUseCase {
+	return &ListWeighingTicketsUseCase{repo: repo}
+}
+
+// Execute lists the weighing tickets of a work order
+func (uc *ListWeighingTicketsUseCase) Execute(ctx context.Context, woID uuid.UUID) ([]*entity.WeighingTicket, error) {
+	return uc.repo.GetTicketsByWorkOrder(ctx, woID)
+}
+
+// GetWeighingTicketUseCase handles getting a weighing ticket
+type GetWeighingTicketUseCase struct {
+	repo repository.DispensingRepository
+}
+
+// NewGetWeighingTicketUseCase creates a new GetWeighingTicketUseCase
+func NewGetWeighingTicketUseCase(repo repository.DispensingRepository) *GetWeighingTicketUseCase {
+	return &GetWeighingTicketUseCase{repo: repo}
+}
+
+// Execute gets a weighing ticket with its readings
+func (uc *GetWeighingTicketUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.WeighingTicket, error) {
+	ticket, err := uc.repo.GetTicketByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrWeighingTicketNotFound
+	}
+	return ticket, nil
+}
+
+// RecordScaleReadingUseCase handles scale readings against a weighing ticket
+type RecordScaleReadingUseCase struct {
+	repo          repository.DispensingRepository
+	woRepo        repository.WorkOrderRepository
+	equipmentRepo repository.EquipmentRepository
+	warehouse     WarehouseClient
+	issuer        *materialIssuer
+}
+
+// NewRecordScaleReadingUseCase creates a new RecordScaleReadingUseCase
+func NewRecordScaleReadingUseCase(
+	repo repository.DispensingRepository,
+	woRepo repository.WorkOrderRepository,
+	equipmentRepo repository.EquipmentRepository,
+	traceRepo repository.TraceabilityRepository,
+	warehouse WarehouseClient,
+	eventPub EventPublisher,
+) *RecordScaleReadingUseCase {
+	return &RecordScaleReadingUseCase{
+		repo:          repo,
+		woRepo:        woRepo,
+		equipmentRepo: equipmentRepo,
+		warehouse:     warehouse,
+		issuer:        &materialIssuer{repo: repo, woRepo: woRepo, traceRepo: traceRepo, eventPub: eventPub},
+	}
+}
+
+// RecordScaleReadingInput is the input for recording a scale reading
+type RecordScaleReadingInput struct {
+	TicketID    uuid.UUID
+	ScaleID     string
+	LotID       uuid.UUID
+	LotNumber   string
+	GrossWeight float64
+	TareWeight  float64
+	UnitCost    float64
+	ReadBy      uuid.UUID
+}
+
+// Execute records a reading. Out-of-tolerance readings are kept for audit and
+// rejected with ErrWeighingOutOfTolerance; an accepted reading on a non-critical
+// line posts the material issue straight away. A registered scale must be in
+// service and within its calibration, and the lot must be of the material on
+// the ticket's work order line.
+func (uc *RecordScaleReadingUseCase) Execute(ctx context.Context, input RecordScaleReadingInput) (*entity.WeighingTicket, error) {
+	ticket, err := uc.repo.GetTicketByID(ctx, input.TicketID)
+	if err != nil {
+		return nil, entity.ErrWeighingTicketNotFound
+	}
+	wo, err := uc.woRepo.GetByID(ctx, ticket.WorkOrderID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	if !wo.CanDispense() {
+		return nil, entity.ErrWONotDispensable
+	}
+	scale, err := uc.equipmentRepo.GetByCode(ctx, input.ScaleID)
+	if err != nil {
+		return nil, err
+	}
+	if scale == nil {
+		return nil, entity.ErrEquipmentNotFound // Only registered, calibrated scales may be used
+	}
+	if err := scale.CheckUsable(time.Now()); err != nil {
+		return nil, err
+	}
+	line := wo.GetLineItem(ticket.WOLineItemID)
+	if line == nil || line.MaterialID != ticket.MaterialID {
+		return nil, entity.ErrWeighingLineMismatch
+	}
+	lot, err := uc.warehouse.GetLot(ctx, input.LotID)
+	if errors.Is(err, wms.ErrNotFound) {
+		return nil, entity.ErrLotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if lot.MaterialID != line.MaterialID || (input.LotNumber != "" && input.LotNumber != lot.LotNumber) {
+		return nil, entity.ErrWeighingLotMismatch
+	}
+
+	reading := &entity.WeighingReading{
+		ScaleID:     input.ScaleID,
+		LotID:       lot.ID,
+		LotNumber:   lot.LotNumber,
+		GrossWeight: input.GrossWeight,
+		TareWeight:  input.TareWeight,
+		UnitCost:    input.UnitCost,
+		ReadBy:      input.ReadBy,
+		ReadAt:      time.Now(),
+	}
+	if err := ticket.RecordReading(reading); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.CreateReading(ctx, reading); err != nil {
+		return nil, err
+	}
+	if !reading.InTolerance {
+		return ticket, entity.ErrWeighingOutOfTolerance
+	}
+
+	if ticket.Status == entity.WeighingTicketStatusConfirmed {
+		if err := uc.issuer.post(ctx, wo, ticket); err != nil {
+			return nil, err
+		}
+		return ticket, nil
+	}
+
+	if err := uc.repo.UpdateTicket(ctx, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// VerifyWeighingUseCase handles second-person verification of critical weighings
+type VerifyWeighingUseCase struct {
+	repo   repository.DispensingRepository
+	woRepo repository.WorkOrderRepository
+	issuer *materialIssuer
+}
+
+// NewVerifyWeighingUseCase creates a new VerifyWeighingUseCase
+func NewVerifyWeighingUseCase(
+	repo repository.DispensingRepository,
+	woRepo repository.WorkOrderRepository,
+	traceRepo repository.TraceabilityRepository,
+	eventPub EventPublisher,
+) *VerifyWeighingUseCase {
+	return &VerifyWeighingUseCase{
+		repo:   repo,
+		woRepo: woRepo,
+		issuer: &materialIssuer{repo: repo, woRepo: woRepo, traceRepo: traceRepo, eventPub: eventPub},
+	}
+}
+
+// Execute verifies a weighed ticket and posts its material issue
+func (uc *VerifyWeighingUseCase) Execute(ctx context.Context, ticketID uuid.UUID, verifierID uuid.UUID) (*entity.WeighingTicket, error) {
+	ticket, err := uc.repo.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		return nil, entity.ErrWeighingTicketNotFound
+	}
+	wo, err := uc.woRepo.GetByID(ctx, ticket.WorkOrderID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	if !wo.CanDispense() {
+		return nil, entity.ErrWONotDispensable
+	}
+
+	if err := ticket.Verify(verifierID); err != nil {
+		return nil, err
+	}
+	if err := uc.issuer.post(ctx, wo, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// CancelWeighingTicketUseCase handles cancelling a weighing ticket
+type CancelWeighingTicketUseCase struct {
+	repo repository.DispensingRepository
+}
+
+// NewCancelWeighingTicketUseCase creates a new CancelWeighingTicketUseCase
+func NewCancelWeighingTicketUseCase(repo repository.DispensingRepository) *CancelWeighingTicketUseCase {
+	return &CancelWeighingTicketUseCase{repo: repo}
+}
+
+// Execute cancels an unconfirmed ticket, e.g. when a verifier rejects a weighing.
+// A fresh ticket for the line can then be generated.
+func (uc *CancelWeighingTicketUseCase) Execute(ctx context.Context, ticketID uuid.UUID) (*entity.WeighingTicket, error) {
+	ticket, err := uc.repo.GetTicketByID(ctx, ticketID)
+	if err != nil {
+		return nil, entity.ErrWeighingTicketNotFound
+	}
+	if err := ticket.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateTicket(ctx, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// materialIssuer posts the material issue for a confirmed ticket
+type materialIssuer struct {
+	repo      repository.DispensingRepository
+	woRepo    repository.WorkOrderRepository
+	traceRepo repository.TraceabilityRepository
+	eventPub  EventPublisher
+}
+
+func (m *materialIssuer) post(ctx context.Context, wo *entity.WorkOrder, ticket *entity.WeighingTicket) error {
+	issueNumber, err := m.woRepo.GenerateIssueNumber(ctx)
+	if err != nil {
+		return err
+	}
+	issue, err := ticket.BuildMaterialIssue(issueNumber)
+	if err != nil {
+		return err
+	}
+	if err := m.woRepo.CreateMaterialIssue(ctx, issue); err != nil {
+		return err
+	}
+
+	ticket.MaterialIssueID = &issue.ID
+	if err := m.repo.UpdateTicket(ctx, ticket); err != nil {
+		return err
+	}
+
+	lines, err := m.woRepo.GetLineItems(ctx, wo.ID)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if line.ID == ticket.WOLineItemID {
+			line.IssuedQuantity += issue.Quantity
+			if err := m.woRepo.UpdateLineItem(ctx, line); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	// Link the exact lot to the batch for traceability
+	if err := m.traceRepo.Create(ctx, &entity.BatchTraceability{
+		WorkOrderID:       wo.ID,
+		WOMaterialIssueID: &issue.ID,
+		MaterialID:        issue.MaterialID,
+		MaterialLotID:     issue.LotID,
+		MaterialLotNumber: issue.LotNumber,
+		MaterialQuantity:  issue.Quantity,
+		MaterialUOMID:     issue.UOMID,
+		ProductID:         wo.ProductID,
+		TraceDate:         issue.IssueDate,
+	}); err != nil {
+		return err
+	}
+
+	m.eventPub.PublishMaterialIssued(event.MaterialIssuedEvent{
+		WOID:             wo.ID.String(),
+		IssueID:          issue.ID.String(),
+		IssueNumber:      issue.IssueNumber,
+		WeighingTicketID: ticket.ID.String(),
+		MaterialID:       issue.MaterialID.String(),
+		LotID:            issue.LotID.String(),
+		LotNumber:        issue.LotNumber,
+		Quantity:         issue.Quantity,
+		UOMID:            issue.UOMID.String(),
+	})
+
+	return nil
+}
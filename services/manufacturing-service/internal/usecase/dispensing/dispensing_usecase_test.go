@@ -0,0 +1,287 @@
+package dispensing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testutils"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/dispensing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTicket builds a ticket for a new material line added to the work order
+func newTicket(wo *entity.WorkOrder, critical bool) *entity.WeighingTicket {
+	line := entity.WOLineItem{ID: uuid.New(), WorkOrderID: wo.ID, MaterialID: uuid.New(), PlannedQuantity: 10, IsCritical: critical}
+	wo.Items = append(wo.Items, line)
+	return &entity.WeighingTicket{
+		ID:             uuid.New(),
+		TicketNumber:   "WT-2026-0001",
+		WorkOrderID:    wo.ID,
+		WOLineItemID:   line.ID,
+		MaterialID:     line.MaterialID,
+		TargetQuantity: 10,
+		MinQuantity:    9.9,
+		MaxQuantity:    10.1,
+		IsCritical:     critical,
+		Status:         entity.WeighingTicketStatusPending,
+	}
+}
+
+// calibratedScale is a registered scale in service with calibration in date
+func calibratedScale() *entity.Equipment {
+	due := time.Now().AddDate(0, 6, 0)
+	return &entity.Equipment{
+		ID:                  uuid.New(),
+		Code:                "SCALE-01",
+		EquipmentType:       entity.EquipmentTypeScale,
+		Status:              entity.EquipmentStatusActive,
+		RequiresCalibration: true,
+		CalibrationDueDate:  &due,
+	}
+}
+
+func TestRecordScaleReadingUseCase_Execute_OutOfTolerance(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockDispensingRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	equipmentRepo := new(testmocks.MockEquipmentRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	warehouse := new(testmocks.MockWarehouseClient)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := dispensing.NewRecordScaleReadingUseCase(repo, woRepo, equipmentRepo, traceRepo, warehouse, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	ticket := newTicket(wo, false)
+
+	repo.On("GetTicketByID", ctx, ticket.ID).Return(ticket, nil)
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	equipmentRepo.On("GetByCode", ctx, "SCALE-01").Return(calibratedScale(), nil)
+	lotID := uuid.New()
+	warehouse.On("GetLot", ctx, lotID).Return(&wms.Lot{ID: lotID, LotNumber: "LOT-001", MaterialID: ticket.MaterialID}, nil)
+	repo.On("CreateReading", ctx, mock.AnythingOfType("*entity.WeighingReading")).Return(nil)
+
+	// Act
+	_, err := uc.Execute(ctx, dispensing.RecordScaleReadingInput{
+		TicketID:    ticket.ID,
+		ScaleID:     "SCALE-01",
+		LotID:       lotID,
+		LotNumber:   "LOT-001",
+		GrossWeight: 10.5,
+		ReadBy:      uuid.New(),
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrWeighingOutOfTolerance, err)
+	repo.AssertCalled(t, "CreateReading", ctx, mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishMaterialIssued", mock.Anything)
+}
+
+func TestRecordScaleReadingUseCase_Execute_PostsMaterialIssue(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockDispensingRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	equipmentRepo := new(testmocks.MockEquipmentRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	warehouse := new(testmocks.MockWarehouseClient)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := dispensing.NewRecordScaleReadingUseCase(repo, woRepo, equipmentRepo, traceRepo, warehouse, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusReleased).Build()
+	ticket := newTicket(wo, false)
+	lotID := uuid.New()
+
+	repo.On("GetTicketByID", ctx, ticket.ID).Return(ticket, nil)
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	equipmentRepo.On("GetByCode", ctx, "SCALE-01").Return(calibratedScale(), nil)
+	warehouse.On("GetLot", ctx, lotID).Return(&wms.Lot{ID: lotID, LotNumber: "LOT-001", MaterialID: ticket.MaterialID}, nil)
+	repo.On("CreateReading", ctx, mock.AnythingOfType("*entity.WeighingReading")).Return(nil)
+	repo.On("UpdateTicket", ctx, ticket).Return(nil)
+	woRepo.On("GenerateIssueNumber", ctx).Return("ISS-2026-0001", nil)
+	woRepo.On("CreateMaterialIssue", ctx, mock.MatchedBy(func(issue *entity.WOMaterialIssue) bool {
+		return issue.LotID == lotID
+	})).Return(nil)
+	woRepo.On("GetLineItems", ctx, wo.ID).Return([]*entity.WOLineItem{&wo.Items[0]}, nil)
+	woRepo.On("UpdateLineItem", ctx, mock.AnythingOfType("*entity.WOLineItem")).Return(nil)
+	traceRepo.On("Create", ctx, mock.AnythingOfType("*entity.BatchTraceability")).Return(nil)
+	eventPub.On("PublishMaterialIssued", mock.Anything).Return(nil)
+
+	// Act
+	result, err := uc.Execute(ctx, dispensing.RecordScaleReadingInput{
+		TicketID:    ticket.ID,
+		ScaleID:     "SCALE-01",
+		LotID:       lotID,
+		LotNumber:   "LOT-001",
+		GrossWeight: 10.55,
+		TareWeight:  0.5,
+		ReadBy:      uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.WeighingTicketStatusConfirmed, result.Status)
+	assert.Equal(t, lotID, *result.LotID)
+	assert.InDelta(t, 10.05, wo.Items[0].IssuedQuantity, 1e-9)
+	woRepo.AssertExpectations(t)
+	eventPub.AssertCalled(t, "PublishMaterialIssued", mock.Anything)
+}
+
+func TestRecordScaleReadingUseCase_Execute_ScaleCalibrationOverdue(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockDispensingRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	equipmentRepo := new(testmocks.MockEquipmentRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	warehouse := new(testmocks.MockWarehouseClient)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := dispensing.NewRecordScaleReadingUseCase(repo, woRepo, equipmentRepo, traceRepo, warehouse, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	ticket := newTicket(wo, false)
+	expired := time.Now().AddDate(0, 0, -3)
+	scale := &entity.Equipment{
+		ID:                  uuid.New(),
+		Code:                "SCALE-01",
+		EquipmentType:       entity.EquipmentTypeScale,
+		Status:              entity.EquipmentStatusActive,
+		RequiresCalibration: true,
+		CalibrationDueDate:  &expired,
+	}
+
+	repo.On("GetTicketByID", ctx, ticket.ID).Return(ticket, nil)
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	equipmentRepo.On("GetByCode", ctx, "SCALE-01").Return(scale, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, dispensing.RecordScaleReadingInput{
+		TicketID:    ticket.ID,
+		ScaleID:     "SCALE-01",
+		LotID:       uuid.New(),
+		GrossWeight: 10,
+		ReadBy:      uuid.New(),
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrEquipmentCalibrationOverdue, err)
+	repo.AssertNotCalled(t, "CreateReading", mock.Anything, mock.Anything)
+}
+
+func TestRecordScaleReadingUseCase_Execute_ScaleLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		scale    *entity.Equipment
+		repoErr  error
+		expected error
+	}{
+		{"unregistered scale is rejected", nil, nil, entity.ErrEquipmentNotFound},
+		{"database error is returned", nil, errors.New("connection reset"), errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := new(testmocks.MockDispensingRepository)
+			woRepo := new(testmocks.MockWorkOrderRepository)
+			equipmentRepo := new(testmocks.MockEquipmentRepository)
+			warehouse := new(testmocks.MockWarehouseClient)
+
+			uc := dispensing.NewRecordScaleReadingUseCase(repo, woRepo, equipmentRepo, new(testmocks.MockTraceabilityRepository), warehouse, new(testmocks.MockEventPublisher))
+
+			wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+			ticket := newTicket(wo, false)
+
+			repo.On("GetTicketByID", ctx, ticket.ID).Return(ticket, nil)
+			woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+			equipmentRepo.On("GetByCode", ctx, "SCALE-99").Return(tt.scale, tt.repoErr)
+
+			// Act
+			_, err := uc.Execute(ctx, dispensing.RecordScaleReadingInput{
+				TicketID:    ticket.ID,
+				ScaleID:     "SCALE-99",
+				LotID:       uuid.New(),
+				GrossWeight: 10,
+				ReadBy:      uuid.New(),
+			})
+
+			// Assert
+			assert.Equal(t, tt.expected, err)
+			warehouse.AssertNotCalled(t, "GetLot", mock.Anything, mock.Anything)
+			repo.AssertNotCalled(t, "CreateReading", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRecordScaleReadingUseCase_Execute_LotOfOtherMaterial(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockDispensingRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	equipmentRepo := new(testmocks.MockEquipmentRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	warehouse := new(testmocks.MockWarehouseClient)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := dispensing.NewRecordScaleReadingUseCase(repo, woRepo, equipmentRepo, traceRepo, warehouse, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	ticket := newTicket(wo, false)
+	lotID := uuid.New()
+
+	repo.On("GetTicketByID", ctx, ticket.ID).Return(ticket, nil)
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	equipmentRepo.On("GetByCode", ctx, "SCALE-01").Return(calibratedScale(), nil)
+	warehouse.On("GetLot", ctx, lotID).Return(&wms.Lot{ID: lotID, LotNumber: "LOT-009", MaterialID: uuid.New()}, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, dispensing.RecordScaleReadingInput{
+		TicketID:    ticket.ID,
+		ScaleID:     "SCALE-01",
+		LotID:       lotID,
+		GrossWeight: 10,
+		ReadBy:      uuid.New(),
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrWeighingLotMismatch, err)
+	repo.AssertNotCalled(t, "CreateReading", mock.Anything, mock.Anything)
+}
+
+func TestVerifyWeighingUseCase_Execute_SamePersonRejected(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockDispensingRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := dispensing.NewVerifyWeighingUseCase(repo, woRepo, traceRepo, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	ticket := newTicket(wo, true)
+	weigher := uuid.New()
+	ticket.RecordReading(&entity.WeighingReading{LotID: uuid.New(), GrossWeight: 10, ReadBy: weigher})
+
+	repo.On("GetTicketByID", ctx, ticket.ID).Return(ticket, nil)
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, ticket.ID, weigher)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, entity.WeighingTicketStatusWeighed, ticket.Status)
+	repo.AssertNotCalled(t, "UpdateTicket", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,404 @@
+package equipment
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing for equipment due-date alerts
+type EventPublisher interface {
+	PublishCalibrationDue(event event.EquipmentDueEvent) error
+	PublishMaintenanceDue(event event.EquipmentDueEvent) error
+}
+
+// RegisterEquipmentUseCase handles equipment registration
+type RegisterEquipmentUseCase struct {
+	repo        repository.EquipmentRepository
+	routingRepo repository.RoutingRepository
+}
+
+// NewRegisterEquipmentUseCase creates a new RegisterEquipmentUseCase
+func NewRegisterEquipmentUseCase(repo repository.EquipmentRepository, routingRepo repository.RoutingRepository) *RegisterEquipmentUseCase {
+	return &RegisterEquipmentUseCase{repo: repo, routingRepo: routingRepo}
+}
+
+// RegisterEquipmentInput is the input for registering equipment
+type RegisterEquipmentInput struct {
+	Code                    string
+	Name                    string
+	EquipmentType           entity.EquipmentType
+	ProductionLine          string
+	WorkCenterID            *uuid.UUID
+	Manufacturer            string
+	Model                   string
+	SerialNumber            string
+	RequiresCalibration     bool
+	CalibrationIntervalDays int
+	ResponsibleUserID       *uuid.UUID
+	Notes                   string
+	CreatedBy               uuid.UUID
+}
+
+// Execute registers a piece of equipment. Equipment placed in a work center
+// takes the work center's production line unless one is given.
+func (uc *RegisterEquipmentUseCase) Execute(ctx context.Context, input RegisterEquipmentInput) (*entity.Equipment, error) {
+	if !input.EquipmentType.IsValid() {
+		return nil, entity.ErrInvalidEquipmentType
+	}
+	existing, err := uc.repo.GetByCode(ctx, input.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, entity.ErrEquipmentCodeExists
+	}
+
+	productionLine := input.ProductionLine
+	if input.WorkCenterID != nil {
+		wc, err := uc.routingRepo.GetWorkCenterByID(ctx, *input.WorkCenterID)
+		if err != nil {
+			return nil, entity.ErrWorkCenterNotFound
+		}
+		if productionLine == "" {
+			productionLine = wc.ProductionLine
+		}
+	}
+
+	equipment := &entity.Equipment{
+		ID:                      uuid.New(),
+		Code:                    input.Code,
+		Name:                    input.Name,
+		EquipmentType:           input.EquipmentType,
+		ProductionLine:          productionLine,
+		WorkCenterID:            input.WorkCenterID,
+		Manufacturer:            input.Manufacturer,
+		Model:                   input.Model,
+		SerialNumber:            input.SerialNumber,
+		Status:                  entity.EquipmentStatusActive,
+		RequiresCalibration:     input.RequiresCalibration,
+		CalibrationIntervalDays: input.CalibrationIntervalDays,
+		ResponsibleUserID:       input.ResponsibleUserID,
+		Notes:                   input.Notes,
+		CreatedBy:               &input.CreatedBy,
+	}
+	if err := uc.repo.Create(ctx, equipment); err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
+
+// GetEquipmentUseCase handles getting equipment
+type GetEquipmentUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewGetEquipmentUseCase creates a new GetEquipmentUseCase
+func NewGetEquipmentUseCase(repo repository.EquipmentRepository) *GetEquipmentUseCase {
+	return &GetEquipmentUseCase{repo: repo}
+}
+
+// Execute gets equipment with its calibration history and maintenance plans
+func (uc *GetEquipmentUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Equipment, error) {
+	equipment, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrEquipmentNotFound
+	}
+	return equipment, nil
+}
+
+// ListEquipmentUseCase handles listing equipment
+type ListEquipmentUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewListEquipmentUseCase creates a new ListEquipmentUseCase
+func NewListEquipmentUseCase(repo repository.EquipmentRepository) *ListEquipmentUseCase {
+	return &ListEquipmentUseCase{repo: repo}
+}
+
+// Execute lists equipment
+func (uc *ListEquipmentUseCase) Execute(ctx context.Context, filter repository.EquipmentFilter) ([]*entity.Equipment, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// UpdateStatusUseCase handles taking equipment out of service and back
+type UpdateStatusUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewUpdateStatusUseCase creates a new UpdateStatusUseCase
+func NewUpdateStatusUseCase(repo repository.EquipmentRepository) *UpdateStatusUseCase {
+	return &UpdateStatusUseCase{repo: repo}
+}
+
+// Execute changes the equipment status
+func (uc *UpdateStatusUseCase) Execute(ctx context.Context, id uuid.UUID, status entity.EquipmentStatus) (*entity.Equipment, error) {
+	if !status.IsValid() {
+		return nil, entity.ErrInvalidEquipmentStatus
+	}
+	equipment, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrEquipmentNotFound
+	}
+	equipment.Status = status
+	if err := uc.repo.Update(ctx, equipment); err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
+
+// RecordCalibrationUseCase handles recording calibration certificates
+type RecordCalibrationUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewRecordCalibrationUseCase creates a new RecordCalibrationUseCase
+func NewRecordCalibrationUseCase(repo repository.EquipmentRepository) *RecordCalibrationUseCase {
+	return &RecordCalibrationUseCase{repo: repo}
+}
+
+// RecordCalibrationInput is the input for recording a calibration certificate
+type RecordCalibrationInput struct {
+	EquipmentID       uuid.UUID
+	CertificateNumber string
+	CalibrationDate   time.Time
+	DueDate           *time.Time // Defaults to the calibration date plus the equipment interval
+	Result            entity.CalibrationResult
+	CalibratedBy      string
+	CertificateURL    string
+	Notes             string
+	RecordedBy        uuid.UUID
+}
+
+// Execute records a calibration certificate and updates the equipment due date
+func (uc *RecordCalibrationUseCase) Execute(ctx context.Context, input RecordCalibrationInput) (*entity.CalibrationRecord, error) {
+	if input.Result != entity.CalibrationResultPass && input.Result != entity.CalibrationResultFail {
+		return nil, entity.ErrInvalidCalibration
+	}
+
+	equipment, err := uc.repo.GetByID(ctx, input.EquipmentID)
+	if err != nil {
+		return nil, entity.ErrEquipmentNotFound
+	}
+
+	dueDate := input.CalibrationDate
+	if input.DueDate != nil {
+		dueDate = *input.DueDate
+	} else if equipment.CalibrationIntervalDays > 0 {
+		dueDate = input.CalibrationDate.AddDate(0, 0, equipment.CalibrationIntervalDays)
+	}
+	if input.Result == entity.CalibrationResultPass && !dueDate.After(input.CalibrationDate) {
+		return nil, entity.ErrInvalidCalibration
+	}
+
+	record := &entity.CalibrationRecord{
+		ID:                uuid.New(),
+		EquipmentID:       equipment.ID,
+		CertificateNumber: input.CertificateNumber,
+		CalibrationDate:   input.CalibrationDate,
+		DueDate:           dueDate,
+		Result:            input.Result,
+		CalibratedBy:      input.CalibratedBy,
+		CertificateURL:    input.CertificateURL,
+		Notes:             input.Notes,
+		RecordedBy:        input.RecordedBy,
+	}
+	if err := uc.repo.CreateCalibration(ctx, record); err != nil {
+		return nil, err
+	}
+
+	equipment.ApplyCalibration(record)
+	if err := uc.repo.Update(ctx, equipment); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// CreateMaintenancePlanUseCase handles creating preventive maintenance plans
+type CreateMaintenancePlanUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewCreateMaintenancePlanUseCase creates a new CreateMaintenancePlanUseCase
+func NewCreateMaintenancePlanUseCase(repo repository.EquipmentRepository) *CreateMaintenancePlanUseCase {
+	return &CreateMaintenancePlanUseCase{repo: repo}
+}
+
+// CreateMaintenancePlanInput is the input for creating a maintenance plan
+type CreateMaintenancePlanInput struct {
+	EquipmentID  uuid.UUID
+	Name         string
+	Tasks        string
+	IntervalDays int
+	FirstDueDate time.Time
+}
+
+// Execute creates a preventive maintenance plan
+func (uc *CreateMaintenancePlanUseCase) Execute(ctx context.Context, input CreateMaintenancePlanInput) (*entity.MaintenancePlan, error) {
+	if input.IntervalDays <= 0 {
+		return nil, entity.ErrInvalidMaintenanceInterval
+	}
+	equipment, err := uc.repo.GetByID(ctx, input.EquipmentID)
+	if err != nil {
+		return nil, entity.ErrEquipmentNotFound
+	}
+
+	plan := &entity.MaintenancePlan{
+		ID:           uuid.New(),
+		EquipmentID:  equipment.ID,
+		Name:         input.Name,
+		Tasks:        input.Tasks,
+		IntervalDays: input.IntervalDays,
+		NextDueDate:  input.FirstDueDate,
+		IsActive:     true,
+	}
+	if err := uc.repo.CreateMaintenancePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// RecordMaintenanceUseCase handles recording performed maintenance
+type RecordMaintenanceUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewRecordMaintenanceUseCase creates a new RecordMaintenanceUseCase
+func NewRecordMaintenanceUseCase(repo repository.EquipmentRepository) *RecordMaintenanceUseCase {
+	return &RecordMaintenanceUseCase{repo: repo}
+}
+
+// RecordMaintenanceInput is the input for recording performed maintenance
+type RecordMaintenanceInput struct {
+	EquipmentID   uuid.UUID
+	PlanID        uuid.UUID
+	PerformedDate time.Time
+	PerformedBy   uuid.UUID
+	Notes         string
+}
+
+// Execute records performed maintenance and moves the plan to its next due date
+func (uc *RecordMaintenanceUseCase) Execute(ctx context.Context, input RecordMaintenanceInput) (*entity.MaintenancePlan, error) {
+	plan, err := uc.repo.GetMaintenancePlanByID(ctx, input.PlanID)
+	if err != nil || plan.EquipmentID != input.EquipmentID {
+		return nil, entity.ErrMaintenancePlanNotFound
+	}
+
+	record := &entity.MaintenanceRecord{
+		ID:            uuid.New(),
+		PlanID:        plan.ID,
+		EquipmentID:   plan.EquipmentID,
+		PerformedDate: input.PerformedDate,
+		PerformedBy:   input.PerformedBy,
+		Notes:         input.Notes,
+	}
+	if err := uc.repo.CreateMaintenanceRecord(ctx, record); err != nil {
+		return nil, err
+	}
+
+	plan.RecordPerformed(input.PerformedDate)
+	if err := uc.repo.UpdateMaintenancePlan(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// ListMaintenanceRecordsUseCase handles listing the maintenance history
+type ListMaintenanceRecordsUseCase struct {
+	repo repository.EquipmentRepository
+}
+
+// NewListMaintenanceRecordsUseCase creates a new ListMaintenanceRecordsUseCase
+func NewListMaintenanceRecordsUseCase(repo repository.EquipmentRepository) *ListMaintenanceRecordsUseCase {
+	return &ListMaintenanceRecordsUseCase{repo: repo}
+}
+
+// Execute lists the maintenance performed on a piece of equipment
+func (uc *ListMaintenanceRecordsUseCase) Execute(ctx context.Context, equipmentID uuid.UUID) ([]*entity.MaintenanceRecord, error) {
+	return uc.repo.GetMaintenanceRecords(ctx, equipmentID)
+}
+
+// SendDueAlertsUseCase publishes alerts for calibrations and preventive
+// maintenance that fall due within the lead time or are overdue
+type SendDueAlertsUseCase struct {
+	repo     repository.EquipmentRepository
+	eventPub EventPublisher
+	leadDays int
+	interval time.Duration
+}
+
+// NewSendDueAlertsUseCase creates a new SendDueAlertsUseCase.
+// Each due item is alerted at most once per interval until it is done.
+func NewSendDueAlertsUseCase(repo repository.EquipmentRepository, eventPub EventPublisher, leadDays int, interval time.Duration) *SendDueAlertsUseCase {
+	return &SendDueAlertsUseCase{repo: repo, eventPub: eventPub, leadDays: leadDays, interval: interval}
+}
+
+// Execute sends the alerts due at asOf and returns how many were sent
+func (uc *SendDueAlertsUseCase) Execute(ctx context.Context, asOf time.Time) (int, error) {
+	horizon := asOf.AddDate(0, 0, uc.leadDays)
+
+	sent := 0
+	equipment, err := uc.repo.GetCalibrationsDue(ctx, horizon)
+	if err != nil {
+		return 0, err
+	}
+	for _, eq := range equipment {
+		if !eq.CalibrationAlertDue(asOf, uc.leadDays, uc.interval) {
+			continue
+		}
+		if err := uc.eventPub.PublishCalibrationDue(dueEvent(eq, entity.EquipmentDueCalibration, *eq.CalibrationDueDate, asOf)); err != nil {
+			continue
+		}
+		eq.LastCalibrationAlertAt = &asOf
+		if err := uc.repo.Update(ctx, eq); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	plans, err := uc.repo.GetMaintenanceDue(ctx, horizon)
+	if err != nil {
+		return sent, err
+	}
+	for _, plan := range plans {
+		if plan.Equipment == nil || !plan.AlertDue(asOf, uc.leadDays, uc.interval) {
+			continue
+		}
+		dueEvt := dueEvent(plan.Equipment, entity.EquipmentDueMaintenance, plan.NextDueDate, asOf)
+		dueEvt.PlanID = plan.ID.String()
+		dueEvt.PlanName = plan.Name
+		if err := uc.eventPub.PublishMaintenanceDue(dueEvt); err != nil {
+			continue
+		}
+		plan.LastAlertAt = &asOf
+		if err := uc.repo.UpdateMaintenancePlan(ctx, plan); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+func dueEvent(eq *entity.Equipment, dueType entity.EquipmentDueType, dueDate time.Time, asOf time.Time) event.EquipmentDueEvent {
+	days := entity.DaysUntil(dueDate, asOf)
+	dueEvt := event.EquipmentDueEvent{
+		EquipmentID:    eq.ID.String(),
+		EquipmentCode:  eq.Code,
+		EquipmentName:  eq.Name,
+		EquipmentType:  string(eq.EquipmentType),
+		ProductionLine: eq.ProductionLine,
+		DueType:        string(dueType),
+		DueDate:        dueDate.Format("2006-01-02"),
+		DaysUntilDue:   days,
+		IsOverdue:      days < 0,
+	}
+	if eq.ResponsibleUserID != nil {
+		dueEvt.ResponsibleUserID = eq.ResponsibleUserID.String()
+	}
+	return dueEvt
+}
@@ -0,0 +1,168 @@
+package equipment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/equipment"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterEquipmentUseCase_Execute_TakesWorkCenterLine(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockEquipmentRepository)
+	routingRepo := new(testmocks.MockRoutingRepository)
+
+	uc := equipment.NewRegisterEquipmentUseCase(repo, routingRepo)
+
+	wc := &entity.WorkCenter{ID: uuid.New(), Code: "WC-MIX", ProductionLine: "LINE-A"}
+	repo.On("GetByCode", ctx, "MIX-01").Return(nil, nil)
+	routingRepo.On("GetWorkCenterByID", ctx, wc.ID).Return(wc, nil)
+	repo.On("Create", ctx, mock.AnythingOfType("*entity.Equipment")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, equipment.RegisterEquipmentInput{
+		Code:                    "MIX-01",
+		Name:                    "Vacuum mixer 500L",
+		EquipmentType:           entity.EquipmentTypeMixer,
+		WorkCenterID:            &wc.ID,
+		RequiresCalibration:     true,
+		CalibrationIntervalDays: 180,
+		CreatedBy:               uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "LINE-A", res.ProductionLine)
+	assert.Equal(t, entity.EquipmentStatusActive, res.Status)
+	assert.True(t, res.IsCalibrationOverdue(time.Now())) // Unusable until the first certificate is recorded
+}
+
+func TestRegisterEquipmentUseCase_Execute_DuplicateCode(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockEquipmentRepository)
+
+	uc := equipment.NewRegisterEquipmentUseCase(repo, new(testmocks.MockRoutingRepository))
+
+	repo.On("GetByCode", ctx, "SCALE-01").Return(&entity.Equipment{ID: uuid.New(), Code: "SCALE-01"}, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, equipment.RegisterEquipmentInput{Code: "SCALE-01", EquipmentType: entity.EquipmentTypeScale})
+
+	// Assert
+	assert.Equal(t, entity.ErrEquipmentCodeExists, err)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestRecordCalibrationUseCase_Execute_DefaultsDueDateFromInterval(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockEquipmentRepository)
+
+	uc := equipment.NewRecordCalibrationUseCase(repo)
+
+	eq := &entity.Equipment{ID: uuid.New(), Code: "SCALE-01", Status: entity.EquipmentStatusActive, RequiresCalibration: true, CalibrationIntervalDays: 365}
+	repo.On("GetByID", ctx, eq.ID).Return(eq, nil)
+	repo.On("CreateCalibration", ctx, mock.AnythingOfType("*entity.CalibrationRecord")).Return(nil)
+	repo.On("Update", ctx, eq).Return(nil)
+
+	calibrated := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	res, err := uc.Execute(ctx, equipment.RecordCalibrationInput{
+		EquipmentID:       eq.ID,
+		CertificateNumber: "VMI-2026-0311",
+		CalibrationDate:   calibrated,
+		Result:            entity.CalibrationResultPass,
+		CalibratedBy:      "Quatest 3",
+		RecordedBy:        uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC), res.DueDate)
+	assert.Equal(t, res.DueDate, *eq.CalibrationDueDate)
+	assert.Equal(t, calibrated, *eq.LastCalibrationDate)
+}
+
+func TestRecordCalibrationUseCase_Execute_PassWithoutDueDate(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockEquipmentRepository)
+
+	uc := equipment.NewRecordCalibrationUseCase(repo)
+
+	eq := &entity.Equipment{ID: uuid.New(), Status: entity.EquipmentStatusActive, RequiresCalibration: true} // No interval
+	repo.On("GetByID", ctx, eq.ID).Return(eq, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, equipment.RecordCalibrationInput{
+		EquipmentID:     eq.ID,
+		CalibrationDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Result:          entity.CalibrationResultPass,
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrInvalidCalibration, err)
+	repo.AssertNotCalled(t, "CreateCalibration", mock.Anything, mock.Anything)
+}
+
+func TestSendDueAlertsUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockEquipmentRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := equipment.NewSendDueAlertsUseCase(repo, eventPub, 14, 24*time.Hour)
+
+	asOf := time.Date(2026, 5, 10, 8, 0, 0, 0, time.UTC)
+	responsible := uuid.New()
+	calDue := time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC)
+	scale := &entity.Equipment{
+		ID:                  uuid.New(),
+		Code:                "SCALE-01",
+		EquipmentType:       entity.EquipmentTypeScale,
+		ProductionLine:      "LINE-A",
+		Status:              entity.EquipmentStatusActive,
+		RequiresCalibration: true,
+		CalibrationDueDate:  &calDue,
+		ResponsibleUserID:   &responsible,
+	}
+	recentlyAlerted := asOf.Add(-3 * time.Hour)
+	tankDue := time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)
+	tank := &entity.Equipment{ID: uuid.New(), Code: "TANK-02", Status: entity.EquipmentStatusActive, RequiresCalibration: true, CalibrationDueDate: &tankDue, LastCalibrationAlertAt: &recentlyAlerted}
+	mixer := &entity.Equipment{ID: uuid.New(), Code: "MIX-01", Status: entity.EquipmentStatusActive}
+	plan := &entity.MaintenancePlan{ID: uuid.New(), EquipmentID: mixer.ID, Name: "Seal replacement", NextDueDate: time.Date(2026, 5, 20, 0, 0, 0, 0, time.UTC), IsActive: true, Equipment: mixer}
+
+	horizon := asOf.AddDate(0, 0, 14)
+	repo.On("GetCalibrationsDue", ctx, horizon).Return([]*entity.Equipment{scale, tank}, nil)
+	repo.On("GetMaintenanceDue", ctx, horizon).Return([]*entity.MaintenancePlan{plan}, nil)
+	repo.On("Update", ctx, scale).Return(nil)
+	repo.On("UpdateMaintenancePlan", ctx, plan).Return(nil)
+	eventPub.On("PublishCalibrationDue", mock.Anything).Return(nil)
+	eventPub.On("PublishMaintenanceDue", mock.Anything).Return(nil)
+
+	// Act
+	sent, err := uc.Execute(ctx, asOf)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	eventPub.AssertCalled(t, "PublishCalibrationDue", mock.MatchedBy(func(e event.EquipmentDueEvent) bool {
+		return e.EquipmentCode == "SCALE-01" && e.IsOverdue && e.DaysUntilDue == -2 && e.ResponsibleUserID == responsible.String()
+	}))
+	eventPub.AssertCalled(t, "PublishMaintenanceDue", mock.MatchedBy(func(e event.EquipmentDueEvent) bool {
+		return e.PlanName == "Seal replacement" && e.DueType == "MAINTENANCE" && e.DaysUntilDue == 10
+	}))
+	eventPub.AssertNumberOfCalls(t, "PublishCalibrationDue", 1) // TANK-02 was alerted within the interval
+	assert.Equal(t, asOf, *scale.LastCalibrationAlertAt)
+	assert.Equal(t, asOf, *plan.LastAlertAt)
+}
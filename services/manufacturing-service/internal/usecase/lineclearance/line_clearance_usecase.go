@@ -0,0 +1,295 @@
+package lineclearance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/masterdata"
+	"github.com/google/uuid"
+)
+
+// MaterialClient reads material allergen flags from master-data-service
+type MaterialClient interface {
+	GetMaterial(ctx context.Context, id uuid.UUID) (*masterdata.Material, error)
+}
+
+// CreateLineClearanceUseCase opens the cleaning and line clearance checklist for a work order
+type CreateLineClearanceUseCase struct {
+	repo      repository.LineClearanceRepository
+	woRepo    repository.WorkOrderRepository
+	materials MaterialClient
+}
+
+// NewCreateLineClearanceUseCase creates a new CreateLineClearanceUseCase
+func NewCreateLineClearanceUseCase(repo repository.LineClearanceRepository, woRepo repository.WorkOrderRepository, materials MaterialClient) *CreateLineClearanceUseCase {
+	return &CreateLineClearanceUseCase{repo: repo, woRepo: woRepo, materials: materials}
+}
+
+// LineClearanceItemInput is an extra checklist item added to the standard template
+type LineClearanceItemInput struct {
+	Section     entity.LineClearanceSection
+	Description string
+}
+
+// CreateLineClearanceInput represents input for opening a line clearance
+type CreateLineClearanceInput struct {
+	WorkOrderID     uuid.UUID
+	CleaningLevel   entity.CleaningLevel // Optional; defaults to the required level
+	AdditionalItems []LineClearanceItemInput
+	Notes           string
+	CreatedBy       uuid.UUID
+}
+
+// Execute determines the required cleaning level from the previous work order
+// on the line and creates the checklist for it
+func (uc *CreateLineClearanceUseCase) Execute(ctx context.Context, input CreateLineClearanceInput) (*entity.LineClearance, error) {
+	wo, err := uc.woRepo.GetByID(ctx, input.WorkOrderID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	if wo.ProductionLine == "" {
+		return nil, entity.ErrLineClearanceNoLine
+	}
+	if wo.Status != entity.WOStatusPlanned && wo.Status != entity.WOStatusReleased {
+		return nil, entity.ErrLineClearanceWOStarted
+	}
+	for _, item := range input.AdditionalItems {
+		if !item.Section.IsValid() || strings.TrimSpace(item.Description) == "" {
+			return nil, entity.ErrInvalidLineClearanceItem
+		}
+	}
+
+	allergen := newAllergenChecker(uc.materials)
+	next := entity.LineProduct{ProductID: wo.ProductID}
+	if next.ContainsAllergen, err = allergen.containsAllergen(ctx, wo.Items); err != nil {
+		return nil, err
+	}
+
+	previousWO, err := uc.woRepo.GetLastStartedOnLine(ctx, wo.ProductionLine, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+	// A clearance outdated by another run on the line is replaced by a new one
+	if existing, err := uc.repo.GetByWorkOrder(ctx, wo.ID); err == nil && existing != nil && existing.FollowsLastRun(previousWO) {
+		return nil, entity.ErrLineClearanceExists
+	}
+	var previous *entity.LineProduct
+	if previousWO != nil {
+		previous = &entity.LineProduct{ProductID: previousWO.ProductID}
+		if previous.ContainsAllergen, err = allergen.containsAllergen(ctx, previousWO.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	required, reason := entity.DetermineCleaningLevel(previous, next)
+	level := input.CleaningLevel
+	if level == "" {
+		level = required
+	}
+	if !level.IsValid() {
+		return nil, entity.ErrInvalidCleaningLevel
+	}
+	if !level.Covers(required) {
+		return nil, entity.ErrCleaningLevelBelowRequired
+	}
+
+	number, err := uc.repo.GenerateClearanceNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	clearance := &entity.LineClearance{
+		ID:               uuid.New(),
+		ClearanceNumber:  number,
+		ProductionLine:   wo.ProductionLine,
+		WorkOrderID:      wo.ID,
+		ProductID:        wo.ProductID,
+		BatchNumber:      wo.BatchNumber,
+		ContainsAllergen: next.ContainsAllergen,
+		RequiredLevel:    required,
+		CleaningLevel:    level,
+		LevelReason:      reason,
+		Status:           entity.LineClearanceStatusOpen,
+		Notes:            input.Notes,
+		CreatedBy:        &input.CreatedBy,
+	}
+	if previousWO != nil {
+		clearance.PreviousWorkOrderID = &previousWO.ID
+		clearance.PreviousProductID = &previousWO.ProductID
+		clearance.PreviousBatchNumber = previousWO.BatchNumber
+		clearance.PreviousContainsAllergen = previous.ContainsAllergen
+	}
+
+	clearance.Items = entity.DefaultLineClearanceItems(level)
+	for _, extra := range input.AdditionalItems {
+		clearance.Items = append(clearance.Items, entity.LineClearanceItem{
+			Section:     extra.Section,
+			Sequence:    len(clearance.Items) + 1,
+			Description: strings.TrimSpace(extra.Description),
+		})
+	}
+	for i := range clearance.Items {
+		clearance.Items[i].ID = uuid.New()
+		clearance.Items[i].LineClearanceID = clearance.ID
+	}
+
+	if err := uc.repo.Create(ctx, clearance); err != nil {
+		return nil, err
+	}
+	return clearance, nil
+}
+
+// allergenChecker looks up material allergen flags, reading each material once
+type allergenChecker struct {
+	materials MaterialClient
+	cache     map[uuid.UUID]bool
+}
+
+func newAllergenChecker(materials MaterialClient) *allergenChecker {
+	return &allergenChecker{materials: materials, cache: make(map[uuid.UUID]bool)}
+}
+
+func (c *allergenChecker) containsAllergen(ctx context.Context, items []entity.WOLineItem) (bool, error) {
+	for _, item := range items {
+		isAllergen, ok := c.cache[item.MaterialID]
+		if !ok {
+			material, err := c.materials.GetMaterial(ctx, item.MaterialID)
+			if err != nil {
+				return false, fmt.Errorf("failed to read material %s: %w", item.MaterialID, err)
+			}
+			isAllergen = material.IsAllergen
+			c.cache[item.MaterialID] = isAllergen
+		}
+		if isAllergen {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetLineClearanceUseCase handles getting a line clearance
+type GetLineClearanceUseCase struct {
+	repo repository.LineClearanceRepository
+}
+
+// NewGetLineClearanceUseCase creates a new GetLineClearanceUseCase
+func NewGetLineClearanceUseCase(repo repository.LineClearanceRepository) *GetLineClearanceUseCase {
+	return &GetLineClearanceUseCase{repo: repo}
+}
+
+// Execute gets a line clearance with its checklist
+func (uc *GetLineClearanceUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.LineClearance, error) {
+	clearance, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrLineClearanceNotFound
+	}
+	return clearance, nil
+}
+
+// ListLineClearancesUseCase handles listing line clearances
+type ListLineClearancesUseCase struct {
+	repo repository.LineClearanceRepository
+}
+
+// NewListLineClearancesUseCase creates a new ListLineClearancesUseCase
+func NewListLineClearancesUseCase(repo repository.LineClearanceRepository) *ListLineClearancesUseCase {
+	return &ListLineClearancesUseCase{repo: repo}
+}
+
+// Execute lists line clearances
+func (uc *ListLineClearancesUseCase) Execute(ctx context.Context, filter repository.LineClearanceFilter) ([]*entity.LineClearance, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// CheckItemUseCase handles ticking off a checklist item
+type CheckItemUseCase struct {
+	repo repository.LineClearanceRepository
+}
+
+// NewCheckItemUseCase creates a new CheckItemUseCase
+func NewCheckItemUseCase(repo repository.LineClearanceRepository) *CheckItemUseCase {
+	return &CheckItemUseCase{repo: repo}
+}
+
+// CheckItemInput represents input for checking a checklist item
+type CheckItemInput struct {
+	ClearanceID uuid.UUID
+	ItemID      uuid.UUID
+	CheckedBy   uuid.UUID
+	Remarks     string
+}
+
+// Execute checks a checklist item
+func (uc *CheckItemUseCase) Execute(ctx context.Context, input CheckItemInput) (*entity.LineClearanceItem, error) {
+	clearance, err := uc.repo.GetByID(ctx, input.ClearanceID)
+	if err != nil {
+		return nil, entity.ErrLineClearanceNotFound
+	}
+
+	item, err := clearance.CheckItem(input.ItemID, input.CheckedBy, input.Remarks)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CompleteLineClearanceUseCase handles the performer's signature
+type CompleteLineClearanceUseCase struct {
+	repo repository.LineClearanceRepository
+}
+
+// NewCompleteLineClearanceUseCase creates a new CompleteLineClearanceUseCase
+func NewCompleteLineClearanceUseCase(repo repository.LineClearanceRepository) *CompleteLineClearanceUseCase {
+	return &CompleteLineClearanceUseCase{repo: repo}
+}
+
+// Execute signs off a fully checked line clearance
+func (uc *CompleteLineClearanceUseCase) Execute(ctx context.Context, id uuid.UUID, completedBy uuid.UUID) (*entity.LineClearance, error) {
+	clearance, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrLineClearanceNotFound
+	}
+
+	if err := clearance.Complete(completedBy); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, clearance); err != nil {
+		return nil, err
+	}
+	return clearance, nil
+}
+
+// VerifyLineClearanceUseCase handles QA verification
+type VerifyLineClearanceUseCase struct {
+	repo repository.LineClearanceRepository
+}
+
+// NewVerifyLineClearanceUseCase creates a new VerifyLineClearanceUseCase
+func NewVerifyLineClearanceUseCase(repo repository.LineClearanceRepository) *VerifyLineClearanceUseCase {
+	return &VerifyLineClearanceUseCase{repo: repo}
+}
+
+// Execute verifies a completed line clearance, releasing the line for the work order
+func (uc *VerifyLineClearanceUseCase) Execute(ctx context.Context, id uuid.UUID, verifiedBy uuid.UUID) (*entity.LineClearance, error) {
+	clearance, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrLineClearanceNotFound
+	}
+
+	if err := clearance.Verify(verifiedBy); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, clearance); err != nil {
+		return nil, err
+	}
+	return clearance, nil
+}
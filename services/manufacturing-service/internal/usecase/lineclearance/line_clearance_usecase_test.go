@@ -0,0 +1,130 @@
+package lineclearance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/masterdata"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/lineclearance"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateLineClearanceUseCase_Execute_AllergenChangeoverNeedsMajorClean(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockLineClearanceRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	materials := new(testmocks.MockMaterialClient)
+
+	uc := lineclearance.NewCreateLineClearanceUseCase(repo, woRepo, materials)
+
+	glycerin := uuid.New()
+	almondOil := uuid.New()
+	wo := &entity.WorkOrder{
+		ID: uuid.New(), ProductID: uuid.New(), BatchNumber: "BATCH-002", ProductionLine: "LINE-A", Status: entity.WOStatusReleased,
+		Items: []entity.WOLineItem{{MaterialID: glycerin}},
+	}
+	previous := &entity.WorkOrder{
+		ID: uuid.New(), ProductID: uuid.New(), BatchNumber: "BATCH-001", ProductionLine: "LINE-A",
+		Items: []entity.WOLineItem{{MaterialID: glycerin}, {MaterialID: almondOil}},
+	}
+
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	repo.On("GetByWorkOrder", ctx, wo.ID).Return(nil, errors.New("record not found"))
+	woRepo.On("GetLastStartedOnLine", ctx, "LINE-A", wo.ID).Return(previous, nil)
+	materials.On("GetMaterial", ctx, glycerin).Return(&masterdata.Material{ID: glycerin}, nil)
+	materials.On("GetMaterial", ctx, almondOil).Return(&masterdata.Material{ID: almondOil, IsAllergen: true}, nil)
+	repo.On("GenerateClearanceNumber", ctx).Return("LC-2026-0001", nil)
+	repo.On("Create", ctx, mock.AnythingOfType("*entity.LineClearance")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, lineclearance.CreateLineClearanceInput{
+		WorkOrderID: wo.ID,
+		AdditionalItems: []lineclearance.LineClearanceItemInput{
+			{Section: entity.LineClearanceSectionCleaning, Description: "Clean vacuum hopper"},
+		},
+		CreatedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CleaningLevelMajor, res.RequiredLevel)
+	assert.Equal(t, entity.CleaningLevelMajor, res.CleaningLevel)
+	assert.True(t, res.PreviousContainsAllergen)
+	assert.False(t, res.ContainsAllergen)
+	assert.Equal(t, "BATCH-001", res.PreviousBatchNumber)
+	assert.Equal(t, len(entity.DefaultLineClearanceItems(entity.CleaningLevelMajor))+1, len(res.Items))
+	assert.Equal(t, "Clean vacuum hopper", res.Items[len(res.Items)-1].Description)
+	materials.AssertNumberOfCalls(t, "GetMaterial", 2) // Glycerin is looked up once
+}
+
+func TestCreateLineClearanceUseCase_Execute_LevelBelowRequired(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockLineClearanceRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+
+	uc := lineclearance.NewCreateLineClearanceUseCase(repo, woRepo, new(testmocks.MockMaterialClient))
+
+	wo := &entity.WorkOrder{ID: uuid.New(), ProductID: uuid.New(), ProductionLine: "LINE-B", Status: entity.WOStatusPlanned}
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	repo.On("GetByWorkOrder", ctx, wo.ID).Return(nil, errors.New("record not found"))
+	woRepo.On("GetLastStartedOnLine", ctx, "LINE-B", wo.ID).Return(nil, nil) // First run on the line
+
+	// Act
+	_, err := uc.Execute(ctx, lineclearance.CreateLineClearanceInput{WorkOrderID: wo.ID, CleaningLevel: entity.CleaningLevelMinor})
+
+	// Assert
+	assert.Equal(t, entity.ErrCleaningLevelBelowRequired, err)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestVerifyLineClearanceUseCase_Execute_RequiresSecondPerson(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockLineClearanceRepository)
+
+	uc := lineclearance.NewVerifyLineClearanceUseCase(repo)
+
+	operator := uuid.New()
+	clearance := &entity.LineClearance{ID: uuid.New(), Status: entity.LineClearanceStatusCompleted, CompletedBy: &operator}
+	repo.On("GetByID", ctx, clearance.ID).Return(clearance, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, clearance.ID, operator)
+
+	// Assert
+	assert.Equal(t, entity.ErrLineClearanceSameSigner, err)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCreateLineClearanceUseCase_Execute_ReplacesOutdatedClearance(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockLineClearanceRepository)
+	woRepo := new(testmocks.MockWorkOrderRepository)
+
+	uc := lineclearance.NewCreateLineClearanceUseCase(repo, woRepo, new(testmocks.MockMaterialClient))
+
+	wo := &entity.WorkOrder{ID: uuid.New(), ProductID: uuid.New(), ProductionLine: "LINE-C", Status: entity.WOStatusReleased}
+	lastRun := &entity.WorkOrder{ID: uuid.New(), ProductID: uuid.New(), BatchNumber: "BATCH-009", ProductionLine: "LINE-C"}
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	woRepo.On("GetLastStartedOnLine", ctx, "LINE-C", wo.ID).Return(lastRun, nil)
+	// Cleared when nothing had run on the line yet
+	repo.On("GetByWorkOrder", ctx, wo.ID).Return(&entity.LineClearance{WorkOrderID: wo.ID, Status: entity.LineClearanceStatusVerified}, nil)
+	repo.On("GenerateClearanceNumber", ctx).Return("LC-2026-0002", nil)
+	repo.On("Create", ctx, mock.AnythingOfType("*entity.LineClearance")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, lineclearance.CreateLineClearanceInput{WorkOrderID: wo.ID})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, lastRun.ID, *res.PreviousWorkOrderID)
+	assert.Equal(t, entity.CleaningLevelStandard, res.RequiredLevel)
+}
@@ -115,12 +115,13 @@ func (uc *ListNCRsUseCase) Execute(ctx context.Context, filter repository.NCRFil
 
 // CloseNCRUseCase handles closing an NCR
 type CloseNCRUseCase struct {
-	repo repository.NCRRepository
+	repo     repository.NCRRepository
+	capaRepo repository.CAPARepository
 }
 
 // NewCloseNCRUseCase creates a new CloseNCRUseCase
-func NewCloseNCRUseCase(repo repository.NCRRepository) *CloseNCRUseCase {
-	return &CloseNCRUseCase{repo: repo}
+func NewCloseNCRUseCase(repo repository.NCRRepository, capaRepo repository.CAPARepository) *CloseNCRUseCase {
+	return &CloseNCRUseCase{repo: repo, capaRepo: capaRepo}
 }
 
 // CloseNCRInput is input for closing an NCR
@@ -146,6 +147,17 @@ func (uc *CloseNCRUseCase) Execute(ctx context.Context, input CloseNCRInput) (*e
 		return nil, entity.ErrNCRAlreadyClosed
 	}
 
+	// Critical actions of linked CAPAs must be done before the NCR is closed
+	if uc.capaRepo != nil {
+		openActions, err := uc.capaRepo.GetOpenCriticalActionsBySource(ctx, entity.CAPASourceNCR, ncr.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(openActions) > 0 {
+			return nil, entity.ErrNCROpenCriticalCAPA
+		}
+	}
+
 	ncr.RootCause = input.RootCause
 	ncr.CorrectiveAction = input.CorrectiveAction
 	ncr.PreventiveAction = input.PreventiveAction
@@ -0,0 +1,56 @@
+package ncr_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/ncr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCloseNCRUseCase_Execute_BlockedByOpenCriticalCAPAAction(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	ncrRepo := new(testmocks.MockNCRRepository)
+	capaRepo := new(testmocks.MockCAPARepository)
+
+	uc := ncr.NewCloseNCRUseCase(ncrRepo, capaRepo)
+
+	record := &entity.NCR{ID: uuid.New(), Status: entity.NCRStatusCorrectiveAction}
+	ncrRepo.On("GetByID", ctx, record.ID).Return(record, nil)
+	capaRepo.On("GetOpenCriticalActionsBySource", ctx, entity.CAPASourceNCR, record.ID).
+		Return([]*entity.CAPAAction{{ID: uuid.New(), IsCritical: true}}, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, ncr.CloseNCRInput{NCRID: record.ID, ClosedBy: uuid.New()})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrNCROpenCriticalCAPA, err)
+	ncrRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCloseNCRUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	ncrRepo := new(testmocks.MockNCRRepository)
+	capaRepo := new(testmocks.MockCAPARepository)
+
+	uc := ncr.NewCloseNCRUseCase(ncrRepo, capaRepo)
+
+	record := &entity.NCR{ID: uuid.New(), Status: entity.NCRStatusCorrectiveAction}
+	ncrRepo.On("GetByID", ctx, record.ID).Return(record, nil)
+	capaRepo.On("GetOpenCriticalActionsBySource", ctx, entity.CAPASourceNCR, record.ID).Return([]*entity.CAPAAction{}, nil)
+	ncrRepo.On("Update", ctx, record).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, ncr.CloseNCRInput{NCRID: record.ID, ClosureNotes: "CAPA-2026-0001 effective", ClosedBy: uuid.New()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.NCRStatusClosed, res.Status)
+}
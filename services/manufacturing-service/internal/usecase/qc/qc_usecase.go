@@ -2,11 +2,15 @@ package qc
 
 import (
 	"context"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
 	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 // GetCheckpointsUseCase handles getting QC checkpoints
@@ -30,15 +34,24 @@ type EventPublisher interface {
 	PublishQCFailed(event event.QCEvent) error
 }
 
+// SPCMonitor checks new numeric results against their control charts
+type SPCMonitor interface {
+	Execute(ctx context.Context, inspection *entity.QCInspection) ([]entity.SPCViolation, error)
+}
+
 // CreateInspectionUseCase handles creating QC inspections
 type CreateInspectionUseCase struct {
-	repo     repository.QCRepository
-	eventPub EventPublisher
+	repo         repository.QCRepository
+	samplingRepo repository.SamplingPlanRepository
+	tx           repository.Transactor
+	eventPub     EventPublisher
+	spcMonitor   SPCMonitor
+	logger       *zap.Logger
 }
 
-// NewCreateInspectionUseCase creates a new CreateInspectionUseCase
-func NewCreateInspectionUseCase(repo repository.QCRepository, eventPub EventPublisher) *CreateInspectionUseCase {
-	return &CreateInspectionUseCase{repo: repo, eventPub: eventPub}
+// NewCreateInspectionUseCase creates a new CreateInspectionUseCase. spcMonitor may be nil.
+func NewCreateInspectionUseCase(repo repository.QCRepository, samplingRepo repository.SamplingPlanRepository, tx repository.Transactor, eventPub EventPublisher, spcMonitor SPCMonitor, logger *zap.Logger) *CreateInspectionUseCase {
+	return &CreateInspectionUseCase{repo: repo, samplingRepo: samplingRepo, tx: tx, eventPub: eventPub, spcMonitor: spcMonitor, logger: logger}
 }
 
 // CreateInspectionInput is the input for creating an inspection
@@ -49,10 +62,12 @@ type CreateInspectionInput struct {
 	ReferenceID       uuid.UUID
 	ProductID         *uuid.UUID
 	MaterialID        *uuid.UUID
+	SupplierID        *uuid.UUID
 	LotID             *uuid.UUID
 	LotNumber         string
-	InspectedQuantity float64
-	SampleSize        *int
+	InspectedQuantity float64 // Lot quantity
+	SampleSize        *int    // Ignored when the checkpoint has a sampling plan
+	DefectCount       *int    // Nonconforming units found in the sample
 	InspectorID       uuid.UUID
 	InspectorName     string
 	Items             []CreateInspectionItemInput
@@ -68,67 +83,154 @@ type CreateInspectionItemInput struct {
 	MinValue      string
 	MaxValue      string
 	ActualValue   string
+	NumericValue  *float64 // Typed reading for numeric tests
 	UOM           string
-	Result        entity.ItemResult
+	IsCritical    bool              // Ignored when the test comes from the checkpoint
+	Result        entity.ItemResult // Optional for numeric tests with limits
 	Notes         string
 }
 
-// Execute creates a new inspection
+// Execute creates a new inspection. Items are evaluated against the checkpoint
+// specs (or the submitted limits when there is no matching template).
 func (uc *CreateInspectionUseCase) Execute(ctx context.Context, input CreateInspectionInput) (*entity.QCInspection, error) {
-	// Generate inspection number
-	inspNumber, err := uc.repo.GenerateInspectionNumber(ctx)
-	if err != nil {
-		return nil, err
+	var checkpoint *entity.QCCheckpoint
+	var templates []entity.TestItemTemplate
+	if input.CheckpointID != nil {
+		var err error
+		checkpoint, err = uc.repo.GetCheckpointByID(ctx, *input.CheckpointID)
+		if err != nil || checkpoint == nil {
+			return nil, entity.ErrQCCheckpointNotFound
+		}
+		if templates, err = checkpoint.TestItemTemplates(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Evaluate items before anything is stored
+	var evaluated []entity.QCInspectionItem
+	for _, item := range input.Items {
+		qcItem := entity.QCInspectionItem{
+			ItemNumber:    item.ItemNumber,
+			TestName:      item.TestName,
+			TestMethod:    item.TestMethod,
+			Specification: item.Specification,
+			TargetValue:   item.TargetValue,
+			MinValue:      item.MinValue,
+			MaxValue:      item.MaxValue,
+			ActualValue:   item.ActualValue,
+			NumericValue:  item.NumericValue,
+			UOM:           item.UOM,
+			IsCritical:    item.IsCritical,
+			Result:        item.Result,
+			Notes:         item.Notes,
+		}
+		if tpl := entity.FindTestItemTemplate(templates, item.TestName); tpl != nil {
+			qcItem.ApplySpec(tpl)
+		}
+		qcItem.Evaluate()
+		if qcItem.Result == "" {
+			return nil, entity.ErrQCItemResultRequired
+		}
+		evaluated = append(evaluated, qcItem)
+	}
+
+	// An inspection cannot pass without the critical tests of its checkpoint
+	for _, tpl := range templates {
+		if tpl.IsCritical && !hasTest(evaluated, tpl.Name) {
+			return nil, entity.ErrQCCriticalTestMissing
+		}
 	}
 
 	inspection := &entity.QCInspection{
-		InspectionNumber:  inspNumber,
+		InspectionDate:    time.Now(),
 		InspectionType:    input.InspectionType,
 		CheckpointID:      input.CheckpointID,
 		ReferenceType:     input.ReferenceType,
 		ReferenceID:       input.ReferenceID,
 		ProductID:         input.ProductID,
 		MaterialID:        input.MaterialID,
+		SupplierID:        input.SupplierID,
 		LotID:             input.LotID,
 		LotNumber:         input.LotNumber,
 		InspectedQuantity: input.InspectedQuantity,
 		SampleSize:        input.SampleSize,
+		DefectCount:       input.DefectCount,
 		Result:            entity.InspectionResultPending,
+		EvaluatedResult:   entity.InspectionResultPending,
 		InspectorID:       input.InspectorID,
 		InspectorName:     input.InspectorName,
 	}
 
-	if err := uc.repo.CreateInspection(ctx, inspection); err != nil {
+	// Sample size and Ac/Re come from the checkpoint's AQL plan
+	if checkpoint != nil && checkpoint.SamplingPlanID != nil && uc.samplingRepo != nil {
+		plan, state, err := loadSamplingState(ctx, uc.samplingRepo, *checkpoint.SamplingPlanID, inspection)
+		if err != nil {
+			return nil, err
+		}
+		if state.Severity == entity.InspectionSeverityDiscontinued {
+			return nil, entity.ErrSamplingDiscontinued
+		}
+		sample, err := entity.DetermineSamplePlan(lotQuantity(inspection.InspectedQuantity), plan.InspectionLevel, plan.AQL, state.Severity)
+		if err != nil {
+			return nil, err
+		}
+		inspection.ApplySamplePlan(plan.ID, sample)
+	}
+
+	// Generate inspection number
+	inspNumber, err := uc.repo.GenerateInspectionNumber(ctx)
+	if err != nil {
 		return nil, err
 	}
+	inspection.InspectionNumber = inspNumber
 
-	// Create inspection items
-	var items []*entity.QCInspectionItem
-	for _, item := range input.Items {
-		items = append(items, &entity.QCInspectionItem{
-			InspectionID:  inspection.ID,
-			ItemNumber:    item.ItemNumber,
-			TestName:      item.TestName,
-			TestMethod:    item.TestMethod,
-			Specification: item.Specification,
-			TargetValue:   item.TargetValue,
-			MinValue:      item.MinValue,
-			MaxValue:      item.MaxValue,
-			ActualValue:   item.ActualValue,
-			UOM:           item.UOM,
-			Result:        item.Result,
-			Notes:         item.Notes,
-		})
+	inspection.Items = evaluated
+	inspection.CalculateScore()
+	inspection.Items = nil // Items are created separately below
+
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateInspection(ctx, inspection); err != nil {
+			return err
+		}
+
+		// Create inspection items
+		var items []*entity.QCInspectionItem
+		for i := range evaluated {
+			evaluated[i].InspectionID = inspection.ID
+			items = append(items, &evaluated[i])
+		}
+		if len(items) > 0 {
+			return uc.repo.CreateInspectionItems(ctx, items)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if len(items) > 0 {
-		if err := uc.repo.CreateInspectionItems(ctx, items); err != nil {
-			return nil, err
+	inspection.Items = evaluated
+
+	// SPC alerts must never block recording the inspection
+	if uc.spcMonitor != nil {
+		if _, err := uc.spcMonitor.Execute(ctx, inspection); err != nil {
+			uc.logger.Warn("SPC check of inspection failed",
+				zap.String("inspection_number", inspection.InspectionNumber),
+				zap.Error(err))
 		}
 	}
 
 	return inspection, nil
 }
 
+// hasTest reports whether the items include the named test
+func hasTest(items []entity.QCInspectionItem, name string) bool {
+	for i := range items {
+		if strings.EqualFold(strings.TrimSpace(items[i].TestName), strings.TrimSpace(name)) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetInspectionUseCase handles getting an inspection
 type GetInspectionUseCase struct {
 	repo repository.QCRepository
@@ -161,13 +263,14 @@ func (uc *ListInspectionsUseCase) Execute(ctx context.Context, filter repository
 
 // ApproveInspectionUseCase handles approving/rejecting inspections
 type ApproveInspectionUseCase struct {
-	repo     repository.QCRepository
-	eventPub EventPublisher
+	repo         repository.QCRepository
+	samplingRepo repository.SamplingPlanRepository
+	eventPub     EventPublisher
 }
 
 // NewApproveInspectionUseCase creates a new ApproveInspectionUseCase
-func NewApproveInspectionUseCase(repo repository.QCRepository, eventPub EventPublisher) *ApproveInspectionUseCase {
-	return &ApproveInspectionUseCase{repo: repo, eventPub: eventPub}
+func NewApproveInspectionUseCase(repo repository.QCRepository, samplingRepo repository.SamplingPlanRepository, eventPub EventPublisher) *ApproveInspectionUseCase {
+	return &ApproveInspectionUseCase{repo: repo, samplingRepo: samplingRepo, eventPub: eventPub}
 }
 
 // ApproveInspectionInput is input for approving an inspection
@@ -176,6 +279,7 @@ type ApproveInspectionInput struct {
 	Result           entity.InspectionResult
 	AcceptedQuantity *float64
 	RejectedQuantity *float64
+	DefectCount      *int // Overrides the count recorded at creation
 	ApproverID       uuid.UUID
 	Notes            string
 }
@@ -194,8 +298,23 @@ func (uc *ApproveInspectionUseCase) Execute(ctx context.Context, input ApproveIn
 	inspection.AcceptedQuantity = input.AcceptedQuantity
 	inspection.RejectedQuantity = input.RejectedQuantity
 	inspection.Notes = input.Notes
+	if input.DefectCount != nil {
+		inspection.DefectCount = input.DefectCount
+	}
+	if inspection.SamplingPlanID != nil && inspection.DefectCount == nil {
+		return nil, entity.ErrDefectCountRequired
+	}
 
-	switch input.Result {
+	inspection.CalculateScore()
+
+	// A failed critical test or a defect count at the reject number cannot be
+	// overridden by the approver
+	result := input.Result
+	if inspection.HasCriticalFailure() || inspection.SamplingRejected() {
+		result = entity.InspectionResultFailed
+	}
+
+	switch result {
 	case entity.InspectionResultPassed:
 		inspection.Pass(input.ApproverID)
 	case entity.InspectionResultFailed:
@@ -204,12 +323,17 @@ func (uc *ApproveInspectionUseCase) Execute(ctx context.Context, input ApproveIn
 		inspection.ConditionalPass(input.ApproverID)
 	}
 
-	inspection.CalculateScore()
-
 	if err := uc.repo.UpdateInspection(ctx, inspection); err != nil {
 		return nil, err
 	}
 
+	// Feed the lot decision into the normal/tightened/reduced switching history
+	if inspection.SamplingPlanID != nil && uc.samplingRepo != nil {
+		if err := uc.recordLot(ctx, inspection, result != entity.InspectionResultFailed); err != nil {
+			return nil, err
+		}
+	}
+
 	// Publish event
 	qcEvent := event.QCEvent{
 		InspectionID:     inspection.ID.String(),
@@ -223,11 +347,58 @@ func (uc *ApproveInspectionUseCase) Execute(ctx context.Context, input ApproveIn
 		qcEvent.LotID = inspection.LotID.String()
 	}
 
-	if input.Result == entity.InspectionResultPassed {
+	if result == entity.InspectionResultPassed {
 		uc.eventPub.PublishQCPassed(qcEvent)
-	} else if input.Result == entity.InspectionResultFailed {
+	} else if result == entity.InspectionResultFailed {
 		uc.eventPub.PublishQCFailed(qcEvent)
 	}
 
 	return inspection, nil
 }
+
+func (uc *ApproveInspectionUseCase) recordLot(ctx context.Context, inspection *entity.QCInspection, accepted bool) error {
+	plan, state, err := loadSamplingState(ctx, uc.samplingRepo, *inspection.SamplingPlanID, inspection)
+	if err != nil {
+		return err
+	}
+
+	// Score the lot with the plan it was sampled under
+	severity := inspection.SamplingSeverity
+	if severity == "" {
+		severity = state.Severity
+	}
+	sample, err := entity.DetermineSamplePlan(lotQuantity(inspection.InspectedQuantity), plan.InspectionLevel, plan.AQL, severity)
+	if err != nil {
+		return err
+	}
+
+	state.RecordLot(sample, *inspection.DefectCount, accepted)
+	return uc.samplingRepo.SaveState(ctx, state)
+}
+
+// loadSamplingState returns the plan and the switching state of the inspection's
+// subject, starting a new state on normal inspection when there is no history
+func loadSamplingState(ctx context.Context, repo repository.SamplingPlanRepository, planID uuid.UUID, inspection *entity.QCInspection) (*entity.AQLSamplingPlan, *entity.SamplingState, error) {
+	plan, err := repo.GetByID(ctx, planID)
+	if err != nil || plan == nil {
+		return nil, nil, entity.ErrSamplingPlanNotFound
+	}
+	subjectID, ok := plan.SubjectFor(inspection)
+	if !ok {
+		return nil, nil, entity.ErrSamplingSubjectRequired
+	}
+
+	state, err := repo.GetState(ctx, plan.ID, plan.SubjectType, subjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == nil {
+		state = entity.NewSamplingState(plan.ID, plan.SubjectType, subjectID)
+	}
+	return plan, state, nil
+}
+
+// lotQuantity rounds a lot quantity up to whole units for the code letter table
+func lotQuantity(quantity float64) int {
+	return int(math.Ceil(quantity))
+}
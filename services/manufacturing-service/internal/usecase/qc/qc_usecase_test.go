@@ -2,6 +2,7 @@ package qc_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
@@ -10,6 +11,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestCreateInspectionUseCase_Execute_Success(t *testing.T) {
@@ -18,7 +21,7 @@ func TestCreateInspectionUseCase_Execute_Success(t *testing.T) {
 	qcRepo := new(testmocks.MockQCRepository)
 	eventPub := new(testmocks.MockEventPublisher)
 	
-	uc := qc.NewCreateInspectionUseCase(qcRepo, eventPub)
+	uc := qc.NewCreateInspectionUseCase(qcRepo, nil, testmocks.MockTransactor{}, eventPub, nil, zap.NewNop())
 
 	woID := uuid.New()
 	input := qc.CreateInspectionInput{
@@ -48,7 +51,7 @@ func TestApproveInspectionUseCase_Execute_FailureTriggersQCFailedEvent(t *testin
 	qcRepo := new(testmocks.MockQCRepository)
 	eventPub := new(testmocks.MockEventPublisher)
 	
-	uc := qc.NewApproveInspectionUseCase(qcRepo, eventPub)
+	uc := qc.NewApproveInspectionUseCase(qcRepo, nil, eventPub)
 
 	inspection := &entity.QCInspection{
 		ID:            uuid.New(),
@@ -77,3 +80,331 @@ func TestApproveInspectionUseCase_Execute_FailureTriggersQCFailedEvent(t *testin
 	
 	eventPub.AssertCalled(t, "PublishQCFailed", mock.Anything)
 }
+
+func TestCreateInspectionUseCase_Execute_EvaluatesAgainstCheckpoint(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewCreateInspectionUseCase(qcRepo, nil, testmocks.MockTransactor{}, eventPub, nil, zap.NewNop())
+
+	checkpoint := &entity.QCCheckpoint{
+		ID:             uuid.New(),
+		CheckpointType: entity.CheckpointTypeIPQC,
+		TestItems:      []byte(`[{"name":"Cap Torque","type":"NUMERIC","min":8,"max":12},{"name":"Seal Integrity","type":"PASS_FAIL","is_critical":true}]`),
+	}
+	torque := 13.0
+	input := qc.CreateInspectionInput{
+		InspectionType: entity.CheckpointTypeIPQC,
+		CheckpointID:   &checkpoint.ID,
+		ReferenceType:  entity.ReferenceTypeWorkOrder,
+		ReferenceID:    uuid.New(),
+		InspectorID:    uuid.New(),
+		Items: []qc.CreateInspectionItemInput{
+			// Caller-supplied limits are ignored in favour of the checkpoint spec
+			{ItemNumber: 1, TestName: "Cap Torque", MinValue: "0", MaxValue: "20", NumericValue: &torque, Result: entity.ItemResultPass},
+			{ItemNumber: 2, TestName: "Seal Integrity", Result: entity.ItemResultPass},
+		},
+	}
+
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+	qcRepo.On("GenerateInspectionNumber", ctx).Return("QC-2026-0002", nil)
+	qcRepo.On("CreateInspection", ctx, mock.AnythingOfType("*entity.QCInspection")).Return(nil)
+	qcRepo.On("CreateInspectionItems", ctx, mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.InspectionResultPending, res.Result)
+	assert.Equal(t, entity.InspectionResultFailed, res.EvaluatedResult)
+	assert.Equal(t, 50.0, *res.OverallScore)
+	assert.Equal(t, entity.ItemResultFail, res.Items[0].Result)
+	assert.True(t, res.Items[0].OutOfSpec)
+	assert.Equal(t, "12", res.Items[0].MaxValue)
+	assert.True(t, res.Items[1].IsCritical)
+	qcRepo.AssertCalled(t, "CreateInspectionItems", ctx, mock.MatchedBy(func(items []*entity.QCInspectionItem) bool {
+		return len(items) == 2 && items[0].InspectionID == res.ID && items[0].Result == entity.ItemResultFail
+	}))
+}
+
+func TestCreateInspectionUseCase_Execute_ResultRequiredWhenNotEvaluable(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewCreateInspectionUseCase(qcRepo, nil, testmocks.MockTransactor{}, eventPub, nil, zap.NewNop())
+
+	input := qc.CreateInspectionInput{
+		InspectionType: entity.CheckpointTypeFQC,
+		ReferenceType:  entity.ReferenceTypeWorkOrder,
+		ReferenceID:    uuid.New(),
+		InspectorID:    uuid.New(),
+		Items:          []qc.CreateInspectionItemInput{{ItemNumber: 1, TestName: "Appearance"}},
+	}
+
+	// Act
+	res, err := uc.Execute(ctx, input)
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrQCItemResultRequired, err)
+	qcRepo.AssertNotCalled(t, "CreateInspection", mock.Anything, mock.Anything)
+}
+
+func TestApproveInspectionUseCase_Execute_CriticalFailureForcesFailed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewApproveInspectionUseCase(qcRepo, nil, eventPub)
+
+	inspection := &entity.QCInspection{
+		ID:            uuid.New(),
+		ReferenceType: entity.ReferenceTypeWorkOrder,
+		ReferenceID:   uuid.New(),
+		Result:        entity.InspectionResultPending,
+		Items: []entity.QCInspectionItem{
+			{TestName: "Appearance", Result: entity.ItemResultPass},
+			{TestName: "Microbial Test", Result: entity.ItemResultFail, IsCritical: true},
+		},
+	}
+
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+	qcRepo.On("UpdateInspection", ctx, mock.Anything).Return(nil)
+	eventPub.On("PublishQCFailed", mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, qc.ApproveInspectionInput{
+		InspectionID: inspection.ID,
+		Result:       entity.InspectionResultConditional,
+		ApproverID:   uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.InspectionResultFailed, res.Result)
+	eventPub.AssertCalled(t, "PublishQCFailed", mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishQCPassed", mock.Anything)
+}
+
+func TestCreateInspectionUseCase_Execute_AppliesSamplingPlan(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	samplingRepo := new(testmocks.MockSamplingPlanRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewCreateInspectionUseCase(qcRepo, samplingRepo, testmocks.MockTransactor{}, eventPub, nil, zap.NewNop())
+
+	plan := &entity.AQLSamplingPlan{ID: uuid.New(), InspectionLevel: entity.InspectionLevelII, AQL: 2.5, SubjectType: entity.SamplingSubjectSupplier, IsActive: true}
+	checkpoint := &entity.QCCheckpoint{ID: uuid.New(), CheckpointType: entity.CheckpointTypeIQC, SamplingPlanID: &plan.ID}
+	supplierID := uuid.New()
+	tightened := &entity.SamplingState{ID: uuid.New(), SamplingPlanID: plan.ID, SubjectID: supplierID, Severity: entity.InspectionSeverityTightened}
+	defects := 9
+	input := qc.CreateInspectionInput{
+		InspectionType:    entity.CheckpointTypeIQC,
+		CheckpointID:      &checkpoint.ID,
+		ReferenceType:     entity.ReferenceTypeWorkOrder,
+		ReferenceID:       uuid.New(),
+		SupplierID:        &supplierID,
+		InspectedQuantity: 5000,
+		DefectCount:       &defects,
+		InspectorID:       uuid.New(),
+	}
+
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+	samplingRepo.On("GetByID", ctx, plan.ID).Return(plan, nil)
+	samplingRepo.On("GetState", ctx, plan.ID, entity.SamplingSubjectSupplier, supplierID).Return(tightened, nil)
+	qcRepo.On("GenerateInspectionNumber", ctx).Return("QC-2026-0003", nil)
+	qcRepo.On("CreateInspection", ctx, mock.AnythingOfType("*entity.QCInspection")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.InspectionSeverityTightened, res.SamplingSeverity)
+	assert.Equal(t, "L", res.SampleCodeLetter)
+	assert.Equal(t, 200, *res.SampleSize)
+	assert.Equal(t, 8, *res.AcceptNumber)
+	assert.Equal(t, entity.InspectionResultFailed, res.EvaluatedResult)
+}
+
+func TestCreateInspectionUseCase_Execute_DiscontinuedSupplierBlocked(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	samplingRepo := new(testmocks.MockSamplingPlanRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewCreateInspectionUseCase(qcRepo, samplingRepo, testmocks.MockTransactor{}, eventPub, nil, zap.NewNop())
+
+	plan := &entity.AQLSamplingPlan{ID: uuid.New(), InspectionLevel: entity.InspectionLevelII, AQL: 1.0, SubjectType: entity.SamplingSubjectSupplier, IsActive: true}
+	checkpoint := &entity.QCCheckpoint{ID: uuid.New(), CheckpointType: entity.CheckpointTypeIQC, SamplingPlanID: &plan.ID}
+	supplierID := uuid.New()
+	state := &entity.SamplingState{SamplingPlanID: plan.ID, SubjectID: supplierID, Severity: entity.InspectionSeverityDiscontinued}
+
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+	samplingRepo.On("GetByID", ctx, plan.ID).Return(plan, nil)
+	samplingRepo.On("GetState", ctx, plan.ID, entity.SamplingSubjectSupplier, supplierID).Return(state, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, qc.CreateInspectionInput{
+		InspectionType:    entity.CheckpointTypeIQC,
+		CheckpointID:      &checkpoint.ID,
+		ReferenceType:     entity.ReferenceTypeWorkOrder,
+		ReferenceID:       uuid.New(),
+		SupplierID:        &supplierID,
+		InspectedQuantity: 1000,
+		InspectorID:       uuid.New(),
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrSamplingDiscontinued, err)
+	qcRepo.AssertNotCalled(t, "CreateInspection", mock.Anything, mock.Anything)
+}
+
+func TestApproveInspectionUseCase_Execute_DefectsAtRejectNumberFailLot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	samplingRepo := new(testmocks.MockSamplingPlanRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewApproveInspectionUseCase(qcRepo, samplingRepo, eventPub)
+
+	plan := &entity.AQLSamplingPlan{ID: uuid.New(), InspectionLevel: entity.InspectionLevelII, AQL: 1.0, SubjectType: entity.SamplingSubjectSupplier, IsActive: true}
+	supplierID := uuid.New()
+	sample, _ := entity.DetermineSamplePlan(1000, plan.InspectionLevel, plan.AQL, entity.InspectionSeverityNormal)
+	inspection := &entity.QCInspection{
+		ID:                uuid.New(),
+		ReferenceType:     entity.ReferenceTypeWorkOrder,
+		ReferenceID:       uuid.New(),
+		SupplierID:        &supplierID,
+		InspectedQuantity: 1000,
+		Result:            entity.InspectionResultPending,
+		EvaluatedResult:   entity.InspectionResultPending,
+	}
+	inspection.ApplySamplePlan(plan.ID, sample)
+	defects := 3
+
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+	qcRepo.On("UpdateInspection", ctx, mock.Anything).Return(nil)
+	samplingRepo.On("GetByID", ctx, plan.ID).Return(plan, nil)
+	samplingRepo.On("GetState", ctx, plan.ID, entity.SamplingSubjectSupplier, supplierID).Return(nil, nil)
+	samplingRepo.On("SaveState", ctx, mock.AnythingOfType("*entity.SamplingState")).Return(nil)
+	eventPub.On("PublishQCFailed", mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, qc.ApproveInspectionInput{
+		InspectionID: inspection.ID,
+		Result:       entity.InspectionResultPassed,
+		DefectCount:  &defects,
+		ApproverID:   uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.InspectionResultFailed, res.Result)
+	eventPub.AssertCalled(t, "PublishQCFailed", mock.Anything)
+	samplingRepo.AssertCalled(t, "SaveState", ctx, mock.MatchedBy(func(s *entity.SamplingState) bool {
+		return s.SubjectID == supplierID && s.LotsInspected == 1 && s.RecentResults == "R"
+	}))
+}
+
+func TestApproveInspectionUseCase_Execute_DefectCountRequiredForSampledLot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewApproveInspectionUseCase(qcRepo, nil, eventPub)
+
+	planID := uuid.New()
+	inspection := &entity.QCInspection{ID: uuid.New(), Result: entity.InspectionResultPending, SamplingPlanID: &planID}
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, qc.ApproveInspectionInput{
+		InspectionID: inspection.ID,
+		Result:       entity.InspectionResultPassed,
+		ApproverID:   uuid.New(),
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrDefectCountRequired, err)
+	qcRepo.AssertNotCalled(t, "UpdateInspection", mock.Anything, mock.Anything)
+}
+
+func TestCreateInspectionUseCase_Execute_CriticalTestMissing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := qc.NewCreateInspectionUseCase(qcRepo, nil, testmocks.MockTransactor{}, eventPub, nil, zap.NewNop())
+
+	checkpoint := &entity.QCCheckpoint{
+		ID:             uuid.New(),
+		CheckpointType: entity.CheckpointTypeIPQC,
+		TestItems:      []byte(`[{"name":"Cap Torque","type":"NUMERIC","min":8,"max":12},{"name":"Seal Integrity","type":"PASS_FAIL","is_critical":true}]`),
+	}
+	torque := 10.0
+
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, qc.CreateInspectionInput{
+		InspectionType: entity.CheckpointTypeIPQC,
+		CheckpointID:   &checkpoint.ID,
+		ReferenceType:  entity.ReferenceTypeWorkOrder,
+		ReferenceID:    uuid.New(),
+		InspectorID:    uuid.New(),
+		Items:          []qc.CreateInspectionItemInput{{ItemNumber: 1, TestName: "Cap Torque", NumericValue: &torque}},
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrQCCriticalTestMissing, err)
+	qcRepo.AssertNotCalled(t, "CreateInspection", mock.Anything, mock.Anything)
+}
+
+// failingSPCMonitor fails every control chart check
+type failingSPCMonitor struct{}
+
+func (failingSPCMonitor) Execute(ctx context.Context, inspection *entity.QCInspection) ([]entity.SPCViolation, error) {
+	return nil, errors.New("control chart unavailable")
+}
+
+func TestCreateInspectionUseCase_Execute_SPCFailureIsLogged(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+	core, logs := observer.New(zap.WarnLevel)
+
+	uc := qc.NewCreateInspectionUseCase(qcRepo, nil, testmocks.MockTransactor{}, eventPub, failingSPCMonitor{}, zap.New(core))
+
+	qcRepo.On("GenerateInspectionNumber", ctx).Return("QC-2026-0003", nil)
+	qcRepo.On("CreateInspection", ctx, mock.AnythingOfType("*entity.QCInspection")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, qc.CreateInspectionInput{
+		InspectionType: entity.CheckpointTypeFQC,
+		ReferenceType:  entity.ReferenceTypeWorkOrder,
+		ReferenceID:    uuid.New(),
+		InspectorID:    uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+	assert.Equal(t, 1, logs.FilterMessage("SPC check of inspection failed").Len())
+}
@@ -0,0 +1,419 @@
+package recall
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/sales"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing for recalls
+type EventPublisher interface {
+	PublishRecallInitiated(event event.RecallInitiatedEvent) error
+}
+
+// WarehouseClient reads where recalled lots went in wms-service
+type WarehouseClient interface {
+	ListSalesIssuesByLot(ctx context.Context, lotID uuid.UUID) ([]wms.GoodsIssue, error)
+	ListStockByLot(ctx context.Context, lotID uuid.UUID) ([]wms.Stock, error)
+}
+
+// SalesClient reads customers and shipments in sales-service
+type SalesClient interface {
+	GetSalesOrder(ctx context.Context, id uuid.UUID) (*sales.SalesOrder, error)
+	GetCustomerContacts(ctx context.Context, customerID uuid.UUID) ([]sales.Contact, error)
+	ListShipmentsByOrder(ctx context.Context, salesOrderID uuid.UUID) ([]sales.Shipment, error)
+}
+
+// InitiateRecallUseCase traces a suspect material lot to customers and stock
+type InitiateRecallUseCase struct {
+	repo        repository.RecallRepository
+	traceRepo   repository.TraceabilityRepository
+	warehouse   WarehouseClient
+	salesClient SalesClient
+	eventPub    EventPublisher
+}
+
+// NewInitiateRecallUseCase creates a new InitiateRecallUseCase
+func NewInitiateRecallUseCase(
+	repo repository.RecallRepository,
+	traceRepo repository.TraceabilityRepository,
+	warehouse WarehouseClient,
+	salesClient SalesClient,
+	eventPub EventPublisher,
+) *InitiateRecallUseCase {
+	return &InitiateRecallUseCase{
+		repo:        repo,
+		traceRepo:   traceRepo,
+		warehouse:   warehouse,
+		salesClient: salesClient,
+		eventPub:    eventPub,
+	}
+}
+
+// InitiateRecallInput is the input for starting a recall
+type InitiateRecallInput struct {
+	MaterialLotID uuid.UUID
+	Mode          entity.RecallMode
+	Reason        string
+	InitiatedBy   uuid.UUID
+}
+
+// Execute starts the recall clock, builds the recall report and, for a live
+// recall, asks WMS to block the remaining stock
+func (uc *InitiateRecallUseCase) Execute(ctx context.Context, input InitiateRecallInput) (*entity.Recall, error) {
+	startedAt := time.Now()
+	if !input.Mode.IsValid() {
+		return nil, entity.ErrInvalidRecallMode
+	}
+
+	traces, err := uc.traceRepo.GetByMaterialLot(ctx, input.MaterialLotID)
+	if err != nil {
+		return nil, err
+	}
+	if len(traces) == 0 {
+		return nil, entity.ErrRecallLotNotUsed
+	}
+
+	recallNumber, err := uc.repo.GenerateRecallNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recall := &entity.Recall{
+		ID:                uuid.New(),
+		RecallNumber:      recallNumber,
+		Mode:              input.Mode,
+		Status:            entity.RecallStatusOpen,
+		Reason:            input.Reason,
+		MaterialLotID:     input.MaterialLotID,
+		MaterialLotNumber: traces[0].MaterialLotNumber,
+		MaterialID:        &traces[0].MaterialID,
+		SupplierLotNumber: traces[0].SupplierLotNumber,
+		StartedAt:         startedAt,
+		InitiatedBy:       input.InitiatedBy,
+	}
+
+	report, err := uc.buildReport(ctx, recall, traces)
+	if err != nil {
+		return nil, err
+	}
+	if err := recall.SetReport(report, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if input.Mode == entity.RecallModeLive {
+		if lotIDs := report.LotIDsToBlock(); len(lotIDs) > 0 && uc.eventPub != nil {
+			evt := event.RecallInitiatedEvent{
+				RecallID:     recall.ID.String(),
+				RecallNumber: recall.RecallNumber,
+				Reason:       recall.Reason,
+			}
+			for _, id := range lotIDs {
+				evt.LotIDs = append(evt.LotIDs, id.String())
+			}
+			if err := uc.eventPub.PublishRecallInitiated(evt); err == nil {
+				recall.StockBlocked = true
+			}
+		}
+	}
+
+	if err := uc.repo.Create(ctx, recall); err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
+
+// buildReport traces the material lot forward through every work order level,
+// then collects sales issues, customers and remaining stock of the lots
+func (uc *InitiateRecallUseCase) buildReport(ctx context.Context, recall *entity.Recall, traces []*entity.BatchTraceability) (*entity.RecallReport, error) {
+	materialLotID := recall.MaterialLotID
+	report := &entity.RecallReport{
+		MaterialLot: entity.RecallLot{
+			LotID:     &materialLotID,
+			LotNumber: recall.MaterialLotNumber,
+		},
+	}
+
+	lots, err := uc.traceForward(ctx, traces)
+	if err != nil {
+		return nil, err
+	}
+	report.Lots = lots
+
+	customers := make(map[uuid.UUID]*entity.RecallCustomer)
+	orders := make(map[uuid.UUID]*sales.SalesOrder)
+	shipments := make(map[uuid.UUID][]entity.RecallShipmentInfo)
+
+	stock, err := uc.stockOf(ctx, materialLotID, recall.MaterialLotNumber)
+	if err != nil {
+		return nil, err
+	}
+	report.Stock = append(report.Stock, stock...)
+
+	for i := range report.Lots {
+		lot := &report.Lots[i]
+		if lot.LotID == nil {
+			continue // Not received in WMS, nothing shipped or stocked yet
+		}
+
+		stock, err := uc.stockOf(ctx, *lot.LotID, lot.LotNumber)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stock {
+			lot.StockQuantity += s.Quantity
+		}
+		report.Stock = append(report.Stock, stock...)
+
+		issues, err := uc.warehouse.ListSalesIssuesByLot(ctx, *lot.LotID)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.Status == "CANCELLED" || issue.ReferenceID == nil {
+				continue
+			}
+			qty := 0.0
+			for _, line := range issue.LineItems {
+				qty += line.IssuedQty
+			}
+			if qty == 0 {
+				continue
+			}
+			lot.ShippedQuantity += qty
+
+			order, ok := orders[*issue.ReferenceID]
+			if !ok {
+				order, err = uc.salesClient.GetSalesOrder(ctx, *issue.ReferenceID)
+				if err != nil {
+					return nil, err
+				}
+				orders[order.ID] = order
+
+				orderShipments, err := uc.salesClient.ListShipmentsByOrder(ctx, order.ID)
+				if err != nil {
+					return nil, err
+				}
+				for _, s := range orderShipments {
+					shipments[order.ID] = append(shipments[order.ID], entity.RecallShipmentInfo{
+						ShipmentNumber: s.ShipmentNumber,
+						Status:         s.Status,
+						ShippedDate:    s.ShippedDate,
+						Carrier:        s.Carrier,
+						TrackingNumber: s.TrackingNumber,
+						RecipientName:  s.RecipientName,
+						RecipientPhone: s.RecipientPhone,
+					})
+				}
+			}
+
+			customer, ok := customers[order.CustomerID]
+			if !ok {
+				customer, err = uc.newCustomer(ctx, order)
+				if err != nil {
+					return nil, err
+				}
+				customers[order.CustomerID] = customer
+			}
+			customer.Deliveries = append(customer.Deliveries, entity.RecallDelivery{
+				LotID:            *lot.LotID,
+				LotNumber:        lot.LotNumber,
+				SalesOrderID:     order.ID,
+				SalesOrderNumber: order.SONumber,
+				GoodsIssueNumber: issue.IssueNumber,
+				IssueDate:        issue.IssueDate,
+				Quantity:         qty,
+				DeliveryAddress:  order.DeliveryAddress,
+				Shipments:        shipments[order.ID],
+			})
+			customer.TotalQuantity += qty
+		}
+	}
+
+	for _, c := range customers {
+		report.Customers = append(report.Customers, *c)
+	}
+	sort.Slice(report.Customers, func(i, j int) bool {
+		return report.Customers[i].TotalQuantity > report.Customers[j].TotalQuantity
+	})
+	return report, nil
+}
+
+// traceForward walks batch traceability level by level; a product lot that is
+// itself issued to another work order (bulk to filling, etc.) is followed further
+func (uc *InitiateRecallUseCase) traceForward(ctx context.Context, traces []*entity.BatchTraceability) ([]entity.RecallLot, error) {
+	var lots []entity.RecallLot
+	index := make(map[string]int) // product lot number -> position in lots
+
+	level := 1
+	for len(traces) > 0 {
+		var next []uuid.UUID
+		for _, t := range traces {
+			if t.ProductLotNumber == "" {
+				continue // Work order not yet completed
+			}
+			if i, ok := index[t.ProductLotNumber]; ok {
+				if lots[i].Level == level {
+					lots[i].QuantityUsed += t.MaterialQuantity
+				}
+				continue
+			}
+			productID := t.ProductID
+			woID := t.WorkOrderID
+			index[t.ProductLotNumber] = len(lots)
+			lots = append(lots, entity.RecallLot{
+				LotID:        t.ProductLotID,
+				LotNumber:    t.ProductLotNumber,
+				ProductID:    &productID,
+				WorkOrderID:  &woID,
+				Level:        level,
+				QuantityUsed: t.MaterialQuantity,
+				IsFinished:   true,
+			})
+			if t.ProductLotID != nil {
+				next = append(next, *t.ProductLotID)
+			}
+		}
+
+		traces = nil
+		for _, lotID := range next {
+			children, err := uc.traceRepo.GetByMaterialLot(ctx, lotID)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range children {
+				if i, ok := index[c.MaterialLotNumber]; ok {
+					lots[i].IsFinished = false
+				}
+			}
+			traces = append(traces, children...)
+		}
+		level++
+	}
+	return lots, nil
+}
+
+func (uc *InitiateRecallUseCase) stockOf(ctx context.Context, lotID uuid.UUID, lotNumber string) ([]entity.RecallStock, error) {
+	stock, err := uc.warehouse.ListStockByLot(ctx, lotID)
+	if err != nil {
+		return nil, err
+	}
+	var result []entity.RecallStock
+	for _, s := range stock {
+		line := entity.RecallStock{
+			LotID:       lotID,
+			LotNumber:   lotNumber,
+			WarehouseID: s.WarehouseID,
+			LocationID:  s.LocationID,
+			Quantity:    s.Quantity,
+			ReservedQty: s.ReservedQty,
+		}
+		if s.Location != nil {
+			line.LocationCode = s.Location.Code
+		}
+		result = append(result, line)
+	}
+	return result, nil
+}
+
+func (uc *InitiateRecallUseCase) newCustomer(ctx context.Context, order *sales.SalesOrder) (*entity.RecallCustomer, error) {
+	customer := &entity.RecallCustomer{CustomerID: order.CustomerID}
+	if order.Customer != nil {
+		customer.CustomerCode = order.Customer.CustomerCode
+		customer.CustomerName = order.Customer.Name
+		customer.Email = order.Customer.Email
+		customer.Phone = order.Customer.Phone
+	}
+
+	contacts, err := uc.salesClient.GetCustomerContacts(ctx, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range contacts {
+		phone := c.Phone
+		if phone == "" {
+			phone = c.Mobile
+		}
+		customer.Contacts = append(customer.Contacts, entity.RecallContact{
+			Name:      c.ContactName,
+			Position:  c.Position,
+			Email:     c.Email,
+			Phone:     phone,
+			IsPrimary: c.IsPrimary,
+		})
+	}
+	return customer, nil
+}
+
+// GetRecallUseCase handles getting a recall
+type GetRecallUseCase struct {
+	repo repository.RecallRepository
+}
+
+// NewGetRecallUseCase creates a new GetRecallUseCase
+func NewGetRecallUseCase(repo repository.RecallRepository) *GetRecallUseCase {
+	return &GetRecallUseCase{repo: repo}
+}
+
+// Execute gets a recall with its report
+func (uc *GetRecallUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	recall, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrRecallNotFound
+	}
+	return recall, nil
+}
+
+// ListRecallsUseCase handles listing recalls
+type ListRecallsUseCase struct {
+	repo repository.RecallRepository
+}
+
+// NewListRecallsUseCase creates a new ListRecallsUseCase
+func NewListRecallsUseCase(repo repository.RecallRepository) *ListRecallsUseCase {
+	return &ListRecallsUseCase{repo: repo}
+}
+
+// Execute lists recalls
+func (uc *ListRecallsUseCase) Execute(ctx context.Context, filter repository.RecallFilter) ([]*entity.Recall, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// CompleteRecallUseCase handles completing a recall
+type CompleteRecallUseCase struct {
+	repo repository.RecallRepository
+}
+
+// NewCompleteRecallUseCase creates a new CompleteRecallUseCase
+func NewCompleteRecallUseCase(repo repository.RecallRepository) *CompleteRecallUseCase {
+	return &CompleteRecallUseCase{repo: repo}
+}
+
+// CompleteRecallInput is the input for completing a recall
+type CompleteRecallInput struct {
+	RecallID    uuid.UUID
+	Notes       string
+	CompletedBy uuid.UUID
+}
+
+// Execute stops the recall clock, e.g. once all customers have been contacted
+func (uc *CompleteRecallUseCase) Execute(ctx context.Context, input CompleteRecallInput) (*entity.Recall, error) {
+	recall, err := uc.repo.GetByID(ctx, input.RecallID)
+	if err != nil {
+		return nil, entity.ErrRecallNotFound
+	}
+	if err := recall.Complete(input.CompletedBy, input.Notes, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, recall); err != nil {
+		return nil, err
+	}
+	return recall, nil
+}
@@ -0,0 +1,231 @@
+package recall_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/sales"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/wms"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/recall"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/workorder"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// recallFixture is a supplier lot made into a bulk lot, which is filled into
+// a finished lot that was shipped to one customer
+type recallFixture struct {
+	materialLotID uuid.UUID
+	bulkLotID     uuid.UUID
+	finishedLotID uuid.UUID
+	orderID       uuid.UUID
+	customerID    uuid.UUID
+
+	recallRepo  *testmocks.MockRecallRepository
+	traceRepo   *testmocks.MockTraceabilityRepository
+	warehouse   *testmocks.MockWarehouseClient
+	salesClient *testmocks.MockSalesClient
+	eventPub    *testmocks.MockEventPublisher
+}
+
+// completeWO completes a work order through the completion use case; the
+// traceability repository stamps the finished lot on the given material
+// traces the way the UPDATE in the database does
+func completeWO(ctx context.Context, batchNumber string, traces ...*entity.BatchTraceability) uuid.UUID {
+	wo := &entity.WorkOrder{ID: uuid.New(), WONumber: "WO-" + batchNumber, BatchNumber: batchNumber, ProductID: uuid.New(), UOMID: uuid.New(), Status: entity.WOStatusInProgress, PlannedQuantity: 200}
+	for _, t := range traces {
+		t.WorkOrderID = wo.ID
+		t.ProductID = wo.ProductID
+	}
+
+	repo := new(testmocks.MockWorkOrderRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+	repo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	repo.On("GetOutputs", ctx, wo.ID).Return([]*entity.WOOutput{}, nil)
+	repo.On("Update", ctx, wo).Return(nil)
+	traceRepo.On("UpdateProductLot", ctx, wo.ID, mock.Anything, batchNumber).Run(func(args mock.Arguments) {
+		lotID := args.Get(2).(uuid.UUID)
+		for _, t := range traces {
+			t.ProductLotID = &lotID
+			t.ProductLotNumber = args.String(3)
+		}
+	}).Return(nil)
+	eventPub.On("PublishWOCompleted", mock.Anything).Return(nil)
+
+	res, err := workorder.NewCompleteWOUseCase(repo, new(testmocks.MockBOMRepository), traceRepo, testmocks.MockTransactor{}, eventPub).
+		Execute(ctx, workorder.CompleteWOInput{WOID: wo.ID, ActualQuantity: 200, GoodQuantity: 200, UpdatedBy: uuid.New()})
+	if err != nil {
+		panic(err)
+	}
+	return *res.OutputLotID
+}
+
+func newRecallFixture(ctx context.Context) *recallFixture {
+	f := &recallFixture{
+		materialLotID: uuid.New(),
+		orderID:       uuid.New(),
+		customerID:    uuid.New(),
+		recallRepo:    new(testmocks.MockRecallRepository),
+		traceRepo:     new(testmocks.MockTraceabilityRepository),
+		warehouse:     new(testmocks.MockWarehouseClient),
+		salesClient:   new(testmocks.MockSalesClient),
+		eventPub:      new(testmocks.MockEventPublisher),
+	}
+
+	// The supplier lot went into a bulk batch, a second finished batch and a
+	// batch still in progress; the bulk was filled into a finished lot
+	toBulk := &entity.BatchTraceability{MaterialID: uuid.New(), MaterialLotID: f.materialLotID, MaterialLotNumber: "LOT-202601-0012", MaterialQuantity: 25, SupplierLotNumber: "SUP-A7"}
+	toFinished := &entity.BatchTraceability{MaterialID: uuid.New(), MaterialLotID: f.materialLotID, MaterialLotNumber: "LOT-202601-0012", MaterialQuantity: 4, SupplierLotNumber: "SUP-A7"}
+	inProgress := &entity.BatchTraceability{WorkOrderID: uuid.New(), MaterialID: uuid.New(), MaterialLotID: f.materialLotID, MaterialLotNumber: "LOT-202601-0012", MaterialQuantity: 6, SupplierLotNumber: "SUP-A7"}
+	f.bulkLotID = completeWO(ctx, "BULK-2026-0003", toBulk)
+	otherLotID := completeWO(ctx, "FG-2026-0040", toFinished)
+	bulkToFinished := &entity.BatchTraceability{MaterialLotID: f.bulkLotID, MaterialLotNumber: "BULK-2026-0003", MaterialQuantity: 200}
+	f.finishedLotID = completeWO(ctx, "FG-2026-0031", bulkToFinished)
+
+	f.traceRepo.On("GetByMaterialLot", ctx, f.materialLotID).Return([]*entity.BatchTraceability{toBulk, toFinished, inProgress}, nil)
+	f.traceRepo.On("GetByMaterialLot", ctx, f.bulkLotID).Return([]*entity.BatchTraceability{bulkToFinished}, nil)
+	f.traceRepo.On("GetByMaterialLot", ctx, otherLotID).Return([]*entity.BatchTraceability{}, nil)
+	f.traceRepo.On("GetByMaterialLot", ctx, f.finishedLotID).Return([]*entity.BatchTraceability{}, nil)
+
+	f.warehouse.On("ListStockByLot", ctx, otherLotID).Return([]wms.Stock{}, nil)
+	f.warehouse.On("ListSalesIssuesByLot", ctx, otherLotID).Return([]wms.GoodsIssue{}, nil)
+	f.warehouse.On("ListStockByLot", ctx, f.materialLotID).Return([]wms.Stock{{WarehouseID: uuid.New(), LocationID: uuid.New(), Quantity: 10}}, nil)
+	f.warehouse.On("ListStockByLot", ctx, f.bulkLotID).Return([]wms.Stock{}, nil)
+	f.warehouse.On("ListStockByLot", ctx, f.finishedLotID).Return([]wms.Stock{{WarehouseID: uuid.New(), LocationID: uuid.New(), Quantity: 300, ReservedQty: 50}}, nil)
+	f.warehouse.On("ListSalesIssuesByLot", ctx, f.bulkLotID).Return([]wms.GoodsIssue{}, nil)
+	f.warehouse.On("ListSalesIssuesByLot", ctx, f.finishedLotID).Return([]wms.GoodsIssue{
+		{IssueNumber: "GI-2026-0101", IssueType: "SALES", Status: "COMPLETED", ReferenceID: &f.orderID, LineItems: []wms.GoodsIssueLine{{LotID: &f.finishedLotID, IssuedQty: 120}}},
+		{IssueNumber: "GI-2026-0102", IssueType: "SALES", Status: "CANCELLED", ReferenceID: &f.orderID, LineItems: []wms.GoodsIssueLine{{LotID: &f.finishedLotID, IssuedQty: 60}}},
+	}, nil)
+
+	f.salesClient.On("GetSalesOrder", ctx, f.orderID).Return(&sales.SalesOrder{
+		ID: f.orderID, SONumber: "SO-2026-0077", CustomerID: f.customerID,
+		Customer: &sales.Customer{ID: f.customerID, CustomerCode: "KH001", Name: "Hasaki", Phone: "028 7300 6868"},
+	}, nil)
+	f.salesClient.On("GetCustomerContacts", ctx, f.customerID).Return([]sales.Contact{{ContactName: "Nguyen Van A", Mobile: "0901234567", IsPrimary: true}}, nil)
+	f.salesClient.On("ListShipmentsByOrder", ctx, f.orderID).Return([]sales.Shipment{{ShipmentNumber: "SHP-2026-0050", Status: "DELIVERED", Carrier: "GHN", TrackingNumber: "GHN123"}}, nil)
+
+	f.recallRepo.On("GenerateRecallNumber", ctx).Return("RCL-2026-0001", nil)
+	f.recallRepo.On("Create", ctx, mock.AnythingOfType("*entity.Recall")).Return(nil)
+	return f
+}
+
+func (f *recallFixture) useCase() *recall.InitiateRecallUseCase {
+	return recall.NewInitiateRecallUseCase(f.recallRepo, f.traceRepo, f.warehouse, f.salesClient, f.eventPub)
+}
+
+func TestInitiateRecallUseCase_Execute_LiveRecallTracesToCustomersAndBlocksStock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newRecallFixture(ctx)
+	f.eventPub.On("PublishRecallInitiated", mock.AnythingOfType("event.RecallInitiatedEvent")).Return(nil)
+
+	// Act
+	res, err := f.useCase().Execute(ctx, recall.InitiateRecallInput{
+		MaterialLotID: f.materialLotID,
+		Mode:          entity.RecallModeLive,
+		Reason:        "Supplier reported microbial contamination",
+		InitiatedBy:   uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "LOT-202601-0012", res.MaterialLotNumber)
+	assert.Equal(t, "SUP-A7", res.SupplierLotNumber)
+	assert.Equal(t, 3, res.AffectedLotCount)
+	assert.Equal(t, 1, res.CustomerCount)
+	assert.Equal(t, 120.0, res.ShippedQuantity)
+	assert.Equal(t, 310.0, res.StockQuantity)
+	assert.True(t, res.StockBlocked)
+
+	var report entity.RecallReport
+	assert.NoError(t, json.Unmarshal(res.Report, &report))
+	assert.False(t, report.Lots[0].IsFinished) // bulk was filled further
+	assert.Equal(t, 2, report.Lots[2].Level)
+	assert.True(t, report.Lots[2].IsFinished)
+	assert.Equal(t, 120.0, report.Lots[2].ShippedQuantity)
+	assert.Equal(t, "Hasaki", report.Customers[0].CustomerName)
+	assert.Equal(t, "0901234567", report.Customers[0].Contacts[0].Phone)
+	assert.Equal(t, "SO-2026-0077", report.Customers[0].Deliveries[0].SalesOrderNumber)
+	assert.Equal(t, "GHN123", report.Customers[0].Deliveries[0].Shipments[0].TrackingNumber)
+
+	f.eventPub.AssertCalled(t, "PublishRecallInitiated", event.RecallInitiatedEvent{
+		RecallID:     res.ID.String(),
+		RecallNumber: "RCL-2026-0001",
+		Reason:       "Supplier reported microbial contamination",
+		LotIDs:       []string{f.materialLotID.String(), f.finishedLotID.String()},
+	})
+}
+
+func TestInitiateRecallUseCase_Execute_MockRecallDoesNotBlock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newRecallFixture(ctx)
+
+	// Act
+	res, err := f.useCase().Execute(ctx, recall.InitiateRecallInput{
+		MaterialLotID: f.materialLotID,
+		Mode:          entity.RecallModeMock,
+		Reason:        "Annual mock recall",
+		InitiatedBy:   uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RecallStatusOpen, res.Status)
+	assert.False(t, res.StockBlocked)
+	assert.NotNil(t, res.TracedAt)
+	f.eventPub.AssertNotCalled(t, "PublishRecallInitiated", mock.Anything)
+}
+
+func TestInitiateRecallUseCase_Execute_LotNotUsed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newRecallFixture(ctx)
+	unusedLotID := uuid.New()
+	f.traceRepo.On("GetByMaterialLot", ctx, unusedLotID).Return([]*entity.BatchTraceability{}, nil)
+
+	// Act
+	res, err := f.useCase().Execute(ctx, recall.InitiateRecallInput{
+		MaterialLotID: unusedLotID,
+		Mode:          entity.RecallModeMock,
+		Reason:        "Annual mock recall",
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrRecallLotNotUsed, err)
+	f.recallRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCompleteRecallUseCase_Execute_StopsTheClock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	recallRepo := new(testmocks.MockRecallRepository)
+	uc := recall.NewCompleteRecallUseCase(recallRepo)
+
+	existing := &entity.Recall{
+		ID:        uuid.New(),
+		Mode:      entity.RecallModeMock,
+		Status:    entity.RecallStatusOpen,
+		StartedAt: time.Now().Add(-90 * time.Minute),
+	}
+	recallRepo.On("GetByID", ctx, existing.ID).Return(existing, nil)
+	recallRepo.On("Update", ctx, existing).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, recall.CompleteRecallInput{RecallID: existing.ID, Notes: "All customers reached", CompletedBy: uuid.New()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.RecallStatusCompleted, res.Status)
+	assert.InDelta(t, 5400, *res.DurationSeconds, 5)
+	assert.True(t, *res.WithinTarget)
+}
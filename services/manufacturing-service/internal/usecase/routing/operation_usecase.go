@@ -0,0 +1,322 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing interface for operations
+type EventPublisher interface {
+	PublishWOOperationCompleted(event event.OperationEvent) error
+}
+
+// GetWOOperationsUseCase handles listing the operations of a work order
+type GetWOOperationsUseCase struct {
+	opRepo repository.WOOperationRepository
+}
+
+// NewGetWOOperationsUseCase creates a new GetWOOperationsUseCase
+func NewGetWOOperationsUseCase(opRepo repository.WOOperationRepository) *GetWOOperationsUseCase {
+	return &GetWOOperationsUseCase{opRepo: opRepo}
+}
+
+// Execute gets the operations of a work order in sequence order
+func (uc *GetWOOperationsUseCase) Execute(ctx context.Context, woID uuid.UUID) ([]*entity.WOOperation, error) {
+	return uc.opRepo.GetByWorkOrder(ctx, woID)
+}
+
+// OperationActionInput is the input for start/pause/resume of an operation
+type OperationActionInput struct {
+	WOID        uuid.UUID
+	OperationID uuid.UUID
+	OperatorID  uuid.UUID
+	Notes       string
+}
+
+// loadOperation loads an operation and checks it belongs to an in-progress work order
+func loadOperation(ctx context.Context, woRepo repository.WorkOrderRepository, opRepo repository.WOOperationRepository, woID, opID uuid.UUID) (*entity.WorkOrder, *entity.WOOperation, error) {
+	wo, err := woRepo.GetByID(ctx, woID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, entity.ErrWONotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if wo.Status != entity.WOStatusInProgress {
+		return nil, nil, entity.ErrWONotInProgress
+	}
+
+	op, err := opRepo.GetByID(ctx, opID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, entity.ErrOperationNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if op.WorkOrderID != wo.ID {
+		return nil, nil, entity.ErrOperationNotFound
+	}
+	return wo, op, nil
+}
+
+// checkEquipment blocks work on a work center whose equipment is out of
+// service or overdue for calibration
+func checkEquipment(ctx context.Context, equipmentRepo repository.EquipmentRepository, workCenterID uuid.UUID, asOf time.Time) error {
+	equipment, err := equipmentRepo.GetByWorkCenter(ctx, workCenterID)
+	if err != nil {
+		return err
+	}
+	for _, eq := range equipment {
+		if err := eq.CheckUsable(asOf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveWithLog persists the operation and appends an execution log entry
+func saveWithLog(ctx context.Context, opRepo repository.WOOperationRepository, op *entity.WOOperation, action entity.OperationAction, operatorID uuid.UUID, notes string) error {
+	if err := opRepo.Update(ctx, op); err != nil {
+		return err
+	}
+	return opRepo.CreateLog(ctx, &entity.WOOperationLog{
+		WOOperationID: op.ID,
+		Action:        action,
+		OperatorID:    operatorID,
+		OccurredAt:    time.Now(),
+		Notes:         notes,
+	})
+}
+
+// StartOperationUseCase handles starting an operation
+type StartOperationUseCase struct {
+	woRepo        repository.WorkOrderRepository
+	opRepo        repository.WOOperationRepository
+	equipmentRepo repository.EquipmentRepository
+}
+
+// NewStartOperationUseCase creates a new StartOperationUseCase
+func NewStartOperationUseCase(woRepo repository.WorkOrderRepository, opRepo repository.WOOperationRepository, equipmentRepo repository.EquipmentRepository) *StartOperationUseCase {
+	return &StartOperationUseCase{woRepo: woRepo, opRepo: opRepo, equipmentRepo: equipmentRepo}
+}
+
+// Execute starts an operation once all preceding operations are completed
+func (uc *StartOperationUseCase) Execute(ctx context.Context, input OperationActionInput) (*entity.WOOperation, error) {
+	wo, op, err := loadOperation(ctx, uc.woRepo, uc.opRepo, input.WOID, input.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := uc.opRepo.GetByWorkOrder(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range ops {
+		if other.Sequence < op.Sequence && !other.IsDone() {
+			return nil, entity.ErrOperationOutOfSequence
+		}
+	}
+
+	if err := checkEquipment(ctx, uc.equipmentRepo, op.WorkCenterID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := op.Start(input.OperatorID); err != nil {
+		return nil, entity.ErrOperationInvalidState
+	}
+	if err := saveWithLog(ctx, uc.opRepo, op, entity.OperationActionStart, input.OperatorID, input.Notes); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// PauseOperationUseCase handles pausing an operation
+type PauseOperationUseCase struct {
+	woRepo repository.WorkOrderRepository
+	opRepo repository.WOOperationRepository
+}
+
+// NewPauseOperationUseCase creates a new PauseOperationUseCase
+func NewPauseOperationUseCase(woRepo repository.WorkOrderRepository, opRepo repository.WOOperationRepository) *PauseOperationUseCase {
+	return &PauseOperationUseCase{woRepo: woRepo, opRepo: opRepo}
+}
+
+// Execute pauses an operation
+func (uc *PauseOperationUseCase) Execute(ctx context.Context, input OperationActionInput) (*entity.WOOperation, error) {
+	_, op, err := loadOperation(ctx, uc.woRepo, uc.opRepo, input.WOID, input.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := op.Pause(); err != nil {
+		return nil, entity.ErrOperationInvalidState
+	}
+	if err := saveWithLog(ctx, uc.opRepo, op, entity.OperationActionPause, input.OperatorID, input.Notes); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// ResumeOperationUseCase handles resuming a paused operation
+type ResumeOperationUseCase struct {
+	woRepo        repository.WorkOrderRepository
+	opRepo        repository.WOOperationRepository
+	equipmentRepo repository.EquipmentRepository
+}
+
+// NewResumeOperationUseCase creates a new ResumeOperationUseCase
+func NewResumeOperationUseCase(woRepo repository.WorkOrderRepository, opRepo repository.WOOperationRepository, equipmentRepo repository.EquipmentRepository) *ResumeOperationUseCase {
+	return &ResumeOperationUseCase{woRepo: woRepo, opRepo: opRepo, equipmentRepo: equipmentRepo}
+}
+
+// Execute resumes an operation
+func (uc *ResumeOperationUseCase) Execute(ctx context.Context, input OperationActionInput) (*entity.WOOperation, error) {
+	_, op, err := loadOperation(ctx, uc.woRepo, uc.opRepo, input.WOID, input.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkEquipment(ctx, uc.equipmentRepo, op.WorkCenterID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := op.Resume(); err != nil {
+		return nil, entity.ErrOperationInvalidState
+	}
+	if err := saveWithLog(ctx, uc.opRepo, op, entity.OperationActionResume, input.OperatorID, input.Notes); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// CompleteOperationUseCase handles completing an operation
+type CompleteOperationUseCase struct {
+	woRepo   repository.WorkOrderRepository
+	opRepo   repository.WOOperationRepository
+	qcRepo   repository.QCRepository
+	tx       repository.Transactor
+	eventPub EventPublisher
+}
+
+// NewCompleteOperationUseCase creates a new CompleteOperationUseCase
+func NewCompleteOperationUseCase(woRepo repository.WorkOrderRepository, opRepo repository.WOOperationRepository, qcRepo repository.QCRepository, tx repository.Transactor, eventPub EventPublisher) *CompleteOperationUseCase {
+	return &CompleteOperationUseCase{
+		woRepo:   woRepo,
+		opRepo:   opRepo,
+		qcRepo:   qcRepo,
+		tx:       tx,
+		eventPub: eventPub,
+	}
+}
+
+// CompleteOperationInput is the input for completing an operation
+type CompleteOperationInput struct {
+	WOID        uuid.UUID
+	OperationID uuid.UUID
+	OperatorID  uuid.UUID
+	Parameters  []ParameterReadingInput
+	Notes       string
+}
+
+// ParameterReadingInput is a recorded process parameter value
+type ParameterReadingInput struct {
+	Name  string
+	Value float64
+	Unit  string
+}
+
+// Execute completes an operation, records its process parameters and
+// opens an IPQC inspection when the operation has a QC checkpoint
+func (uc *CompleteOperationUseCase) Execute(ctx context.Context, input CompleteOperationInput) (*entity.WOOperation, error) {
+	wo, op, err := loadOperation(ctx, uc.woRepo, uc.opRepo, input.WOID, input.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var readings []entity.ProcessParameterReading
+	for _, p := range input.Parameters {
+		readings = append(readings, entity.ProcessParameterReading{
+			Name:       p.Name,
+			Value:      p.Value,
+			Unit:       p.Unit,
+			RecordedBy: input.OperatorID,
+			RecordedAt: now,
+		})
+	}
+	if err := op.RecordParameters(readings); err != nil {
+		return nil, err
+	}
+
+	if err := op.Complete(input.OperatorID); err != nil {
+		return nil, entity.ErrOperationInvalidState
+	}
+	if input.Notes != "" {
+		op.Notes = input.Notes
+	}
+
+	// The IPQC inspection is only kept if the completed operation is saved
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if op.QCCheckpointID != nil {
+			inspection, err := uc.createInspection(ctx, wo, op, input.OperatorID)
+			if err != nil {
+				return err
+			}
+			op.QCInspectionID = &inspection.ID
+		}
+		return saveWithLog(ctx, uc.opRepo, op, entity.OperationActionComplete, input.OperatorID, input.Notes)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opEvent := event.OperationEvent{
+		WOID:          wo.ID.String(),
+		WONumber:      wo.WONumber,
+		OperationID:   op.ID.String(),
+		OperationCode: op.OperationCode,
+		Sequence:      op.Sequence,
+		ActualMinutes: op.ActualMinutes,
+		HasDeviation:  op.HasDeviation,
+	}
+	if op.QCInspectionID != nil {
+		opEvent.QCInspectionID = op.QCInspectionID.String()
+	}
+	uc.eventPub.PublishWOOperationCompleted(opEvent)
+
+	return op, nil
+}
+
+func (uc *CompleteOperationUseCase) createInspection(ctx context.Context, wo *entity.WorkOrder, op *entity.WOOperation, inspectorID uuid.UUID) (*entity.QCInspection, error) {
+	if _, err := uc.qcRepo.GetCheckpointByID(ctx, *op.QCCheckpointID); err != nil {
+		return nil, entity.ErrQCCheckpointNotFound
+	}
+
+	inspNumber, err := uc.qcRepo.GenerateInspectionNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &entity.QCInspection{
+		InspectionNumber:  inspNumber,
+		InspectionDate:    time.Now(),
+		InspectionType:    entity.CheckpointTypeIPQC,
+		CheckpointID:      op.QCCheckpointID,
+		ReferenceType:     entity.ReferenceTypeWorkOrder,
+		ReferenceID:       wo.ID,
+		ProductID:         &wo.ProductID,
+		LotNumber:         wo.BatchNumber,
+		InspectedQuantity: wo.PlannedQuantity,
+		Result:            entity.InspectionResultPending,
+		InspectorID:       inspectorID,
+		Notes:             "Triggered by operation " + op.OperationCode,
+	}
+	if err := uc.qcRepo.CreateInspection(ctx, inspection); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
@@ -0,0 +1,211 @@
+package routing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testutils"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/routing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartOperationUseCase_Execute_OutOfSequence(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	equipmentRepo := new(testmocks.MockEquipmentRepository)
+
+	uc := routing.NewStartOperationUseCase(woRepo, opRepo, equipmentRepo)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	weighing := &entity.WOOperation{ID: uuid.New(), WorkOrderID: wo.ID, Sequence: 10, Status: entity.WOOperationStatusPending}
+	mixing := &entity.WOOperation{ID: uuid.New(), WorkOrderID: wo.ID, Sequence: 20, Status: entity.WOOperationStatusPending}
+
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	opRepo.On("GetByID", ctx, mixing.ID).Return(mixing, nil)
+	opRepo.On("GetByWorkOrder", ctx, wo.ID).Return([]*entity.WOOperation{weighing, mixing}, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, routing.OperationActionInput{WOID: wo.ID, OperationID: mixing.ID, OperatorID: uuid.New()})
+
+	// Assert
+	assert.Equal(t, entity.ErrOperationOutOfSequence, err)
+	opRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestStartOperationUseCase_Execute_EquipmentCalibrationOverdue(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	equipmentRepo := new(testmocks.MockEquipmentRepository)
+
+	uc := routing.NewStartOperationUseCase(woRepo, opRepo, equipmentRepo)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	mixing := &entity.WOOperation{ID: uuid.New(), WorkOrderID: wo.ID, WorkCenterID: uuid.New(), Sequence: 10, Status: entity.WOOperationStatusPending}
+	mixer := &entity.Equipment{ID: uuid.New(), Code: "MIX-01", Status: entity.EquipmentStatusActive, RequiresCalibration: true} // Never calibrated
+
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	opRepo.On("GetByID", ctx, mixing.ID).Return(mixing, nil)
+	opRepo.On("GetByWorkOrder", ctx, wo.ID).Return([]*entity.WOOperation{mixing}, nil)
+	equipmentRepo.On("GetByWorkCenter", ctx, mixing.WorkCenterID).Return([]*entity.Equipment{mixer}, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, routing.OperationActionInput{WOID: wo.ID, OperationID: mixing.ID, OperatorID: uuid.New()})
+
+	// Assert
+	assert.Equal(t, entity.ErrEquipmentCalibrationOverdue, err)
+	assert.Equal(t, entity.WOOperationStatusPending, mixing.Status)
+	opRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCompleteOperationUseCase_Execute_TriggersIPQC(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := routing.NewCompleteOperationUseCase(woRepo, opRepo, qcRepo, testmocks.MockTransactor{}, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	checkpointID := uuid.New()
+	op := &entity.WOOperation{
+		ID:             uuid.New(),
+		WorkOrderID:    wo.ID,
+		Sequence:       20,
+		OperationCode:  "MIX",
+		Status:         entity.WOOperationStatusInProgress,
+		QCCheckpointID: &checkpointID,
+	}
+
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	opRepo.On("GetByID", ctx, op.ID).Return(op, nil)
+	opRepo.On("Update", ctx, op).Return(nil)
+	opRepo.On("CreateLog", ctx, mock.AnythingOfType("*entity.WOOperationLog")).Return(nil)
+	qcRepo.On("GetCheckpointByID", ctx, checkpointID).Return(&entity.QCCheckpoint{ID: checkpointID}, nil)
+	qcRepo.On("GenerateInspectionNumber", ctx).Return("QC-2026-0001", nil)
+	qcRepo.On("CreateInspection", ctx, mock.AnythingOfType("*entity.QCInspection")).Return(nil)
+	eventPub.On("PublishWOOperationCompleted", mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, routing.CompleteOperationInput{WOID: wo.ID, OperationID: op.ID, OperatorID: uuid.New()})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.WOOperationStatusCompleted, res.Status)
+	assert.NotNil(t, res.QCInspectionID)
+	qcRepo.AssertCalled(t, "CreateInspection", ctx, mock.MatchedBy(func(ins *entity.QCInspection) bool {
+		return ins.InspectionType == entity.CheckpointTypeIPQC && ins.ReferenceID == wo.ID
+	}))
+	eventPub.AssertExpectations(t)
+}
+
+func TestPauseOperationUseCase_Execute_LookupErrors(t *testing.T) {
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		woErr   error
+		opErr   error
+		wantErr error
+	}{
+		{"work order not found", repository.ErrNotFound, nil, entity.ErrWONotFound},
+		{"work order lookup failure", dbErr, nil, dbErr},
+		{"operation not found", nil, repository.ErrNotFound, entity.ErrOperationNotFound},
+		{"operation lookup failure", nil, dbErr, dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			woRepo := new(testmocks.MockWorkOrderRepository)
+			opRepo := new(testmocks.MockWOOperationRepository)
+
+			uc := routing.NewPauseOperationUseCase(woRepo, opRepo)
+
+			wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+			opID := uuid.New()
+			if tt.woErr != nil {
+				woRepo.On("GetByID", ctx, wo.ID).Return(nil, tt.woErr)
+			} else {
+				woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+			}
+			opRepo.On("GetByID", ctx, opID).Return(nil, tt.opErr)
+
+			// Act
+			_, err := uc.Execute(ctx, routing.OperationActionInput{WOID: wo.ID, OperationID: opID, OperatorID: uuid.New()})
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			opRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCompleteOperationUseCase_Execute_SaveFailureCreatesInspectionInTransaction(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+	tx := &recordingTransactor{}
+
+	uc := routing.NewCompleteOperationUseCase(woRepo, opRepo, qcRepo, tx, eventPub)
+
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	checkpointID := uuid.New()
+	op := &entity.WOOperation{
+		ID:             uuid.New(),
+		WorkOrderID:    wo.ID,
+		Sequence:       20,
+		OperationCode:  "MIX",
+		Status:         entity.WOOperationStatusInProgress,
+		QCCheckpointID: &checkpointID,
+	}
+
+	saveErr := errors.New("connection reset")
+	woRepo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	opRepo.On("GetByID", ctx, op.ID).Return(op, nil)
+	qcRepo.On("GetCheckpointByID", inTx(), checkpointID).Return(&entity.QCCheckpoint{ID: checkpointID}, nil)
+	qcRepo.On("GenerateInspectionNumber", inTx()).Return("QC-2026-0001", nil)
+	qcRepo.On("CreateInspection", inTx(), mock.AnythingOfType("*entity.QCInspection")).Return(nil)
+	opRepo.On("Update", inTx(), op).Return(saveErr)
+
+	// Act
+	_, err := uc.Execute(ctx, routing.CompleteOperationInput{WOID: wo.ID, OperationID: op.ID, OperatorID: uuid.New()})
+
+	// Assert
+	assert.Equal(t, saveErr, err)
+	assert.Equal(t, saveErr, tx.err)
+	qcRepo.AssertExpectations(t)
+	eventPub.AssertNotCalled(t, "PublishWOOperationCompleted", mock.Anything)
+}
+
+type txKey struct{}
+
+// recordingTransactor marks the context of the transaction and records
+// the error it would have rolled back on
+type recordingTransactor struct {
+	err error
+}
+
+func (r *recordingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	r.err = fn(context.WithValue(ctx, txKey{}, true))
+	return r.err
+}
+
+func inTx() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+}
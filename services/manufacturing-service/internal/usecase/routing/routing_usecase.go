@@ -0,0 +1,218 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// CreateWorkCenterUseCase handles work center creation
+type CreateWorkCenterUseCase struct {
+	repo repository.RoutingRepository
+}
+
+// NewCreateWorkCenterUseCase creates a new CreateWorkCenterUseCase
+func NewCreateWorkCenterUseCase(repo repository.RoutingRepository) *CreateWorkCenterUseCase {
+	return &CreateWorkCenterUseCase{repo: repo}
+}
+
+// CreateWorkCenterInput is the input for creating a work center
+type CreateWorkCenterInput struct {
+	Code           string
+	Name           string
+	Description    string
+	ProductionLine string
+	CostPerHour    float64
+}
+
+// Execute creates a new work center
+func (uc *CreateWorkCenterUseCase) Execute(ctx context.Context, input CreateWorkCenterInput) (*entity.WorkCenter, error) {
+	wc := &entity.WorkCenter{
+		Code:           input.Code,
+		Name:           input.Name,
+		Description:    input.Description,
+		ProductionLine: input.ProductionLine,
+		CostPerHour:    input.CostPerHour,
+		IsActive:       true,
+	}
+
+	if err := uc.repo.CreateWorkCenter(ctx, wc); err != nil {
+		return nil, err
+	}
+	return wc, nil
+}
+
+// ListWorkCentersUseCase handles listing work centers
+type ListWorkCentersUseCase struct {
+	repo repository.RoutingRepository
+}
+
+// NewListWorkCentersUseCase creates a new ListWorkCentersUseCase
+func NewListWorkCentersUseCase(repo repository.RoutingRepository) *ListWorkCentersUseCase {
+	return &ListWorkCentersUseCase{repo: repo}
+}
+
+// Execute lists active work centers
+func (uc *ListWorkCentersUseCase) Execute(ctx context.Context) ([]*entity.WorkCenter, error) {
+	return uc.repo.ListWorkCenters(ctx)
+}
+
+// CreateRoutingUseCase handles routing creation
+type CreateRoutingUseCase struct {
+	repo repository.RoutingRepository
+}
+
+// NewCreateRoutingUseCase creates a new CreateRoutingUseCase
+func NewCreateRoutingUseCase(repo repository.RoutingRepository) *CreateRoutingUseCase {
+	return &CreateRoutingUseCase{repo: repo}
+}
+
+// CreateRoutingInput is the input for creating a routing
+type CreateRoutingInput struct {
+	RoutingNumber string
+	ProductID     uuid.UUID
+	BOMID         *uuid.UUID
+	Version       int
+	Name          string
+	BaseQuantity  float64
+	Notes         string
+	Operations    []CreateRoutingOperationInput
+	CreatedBy     uuid.UUID
+}
+
+// CreateRoutingOperationInput is input for a routing operation
+type CreateRoutingOperationInput struct {
+	Sequence          int
+	OperationCode     string
+	Name              string
+	OperationType     entity.OperationType
+	WorkCenterID      uuid.UUID
+	SetupMinutes      float64
+	RunMinutes        float64
+	Instructions      string
+	ProcessParameters []entity.ProcessParameterSpec
+	QCCheckpointID    *uuid.UUID
+}
+
+// Execute creates a new routing in draft status
+func (uc *CreateRoutingUseCase) Execute(ctx context.Context, input CreateRoutingInput) (*entity.Routing, error) {
+	version := input.Version
+	if version == 0 {
+		version = 1
+	}
+
+	routing := &entity.Routing{
+		RoutingNumber: input.RoutingNumber,
+		ProductID:     input.ProductID,
+		BOMID:         input.BOMID,
+		Version:       version,
+		Name:          input.Name,
+		Status:        entity.RoutingStatusDraft,
+		BaseQuantity:  input.BaseQuantity,
+		Notes:         input.Notes,
+		CreatedBy:     &input.CreatedBy,
+		UpdatedBy:     &input.CreatedBy,
+	}
+
+	for i, op := range input.Operations {
+		if _, err := uc.repo.GetWorkCenterByID(ctx, op.WorkCenterID); err != nil {
+			return nil, entity.ErrWorkCenterNotFound
+		}
+
+		seq := op.Sequence
+		if seq == 0 {
+			seq = (i + 1) * 10
+		}
+
+		var params json.RawMessage
+		if len(op.ProcessParameters) > 0 {
+			data, err := json.Marshal(op.ProcessParameters)
+			if err != nil {
+				return nil, err
+			}
+			params = data
+		}
+
+		routing.Operations = append(routing.Operations, entity.RoutingOperation{
+			Sequence:          seq,
+			OperationCode:     op.OperationCode,
+			Name:              op.Name,
+			OperationType:     op.OperationType,
+			WorkCenterID:      op.WorkCenterID,
+			SetupMinutes:      op.SetupMinutes,
+			RunMinutes:        op.RunMinutes,
+			Instructions:      op.Instructions,
+			ProcessParameters: params,
+			QCCheckpointID:    op.QCCheckpointID,
+		})
+	}
+
+	if err := uc.repo.Create(ctx, routing); err != nil {
+		return nil, err
+	}
+	return routing, nil
+}
+
+// GetRoutingUseCase handles getting a routing
+type GetRoutingUseCase struct {
+	repo repository.RoutingRepository
+}
+
+// NewGetRoutingUseCase creates a new GetRoutingUseCase
+func NewGetRoutingUseCase(repo repository.RoutingRepository) *GetRoutingUseCase {
+	return &GetRoutingUseCase{repo: repo}
+}
+
+// Execute gets a routing by ID
+func (uc *GetRoutingUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Routing, error) {
+	return uc.repo.GetByID(ctx, id)
+}
+
+// ListRoutingsUseCase handles listing routings
+type ListRoutingsUseCase struct {
+	repo repository.RoutingRepository
+}
+
+// NewListRoutingsUseCase creates a new ListRoutingsUseCase
+func NewListRoutingsUseCase(repo repository.RoutingRepository) *ListRoutingsUseCase {
+	return &ListRoutingsUseCase{repo: repo}
+}
+
+// Execute lists routings
+func (uc *ListRoutingsUseCase) Execute(ctx context.Context, filter repository.RoutingFilter) ([]*entity.Routing, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// ActivateRoutingUseCase handles activating a routing
+type ActivateRoutingUseCase struct {
+	repo repository.RoutingRepository
+}
+
+// NewActivateRoutingUseCase creates a new ActivateRoutingUseCase
+func NewActivateRoutingUseCase(repo repository.RoutingRepository) *ActivateRoutingUseCase {
+	return &ActivateRoutingUseCase{repo: repo}
+}
+
+// Execute activates a routing; any previously active routing of the product becomes obsolete
+func (uc *ActivateRoutingUseCase) Execute(ctx context.Context, id uuid.UUID, updatedBy uuid.UUID) (*entity.Routing, error) {
+	routing, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrRoutingNotFound
+	}
+
+	if err := routing.Activate(); err != nil {
+		return nil, entity.ErrRoutingCannotActivate
+	}
+	routing.UpdatedBy = &updatedBy
+
+	if err := uc.repo.ObsoleteActiveForProduct(ctx, routing.ProductID, routing.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, routing); err != nil {
+		return nil, err
+	}
+	return routing, nil
+}
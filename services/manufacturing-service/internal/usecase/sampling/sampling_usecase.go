@@ -0,0 +1,201 @@
+package sampling
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// CreateSamplingPlanUseCase handles creating AQL sampling plans
+type CreateSamplingPlanUseCase struct {
+	repo repository.SamplingPlanRepository
+}
+
+// NewCreateSamplingPlanUseCase creates a new CreateSamplingPlanUseCase
+func NewCreateSamplingPlanUseCase(repo repository.SamplingPlanRepository) *CreateSamplingPlanUseCase {
+	return &CreateSamplingPlanUseCase{repo: repo}
+}
+
+// CreateSamplingPlanInput is the input for creating a sampling plan
+type CreateSamplingPlanInput struct {
+	PlanCode        string
+	Name            string
+	InspectionLevel entity.InspectionLevel // Defaults to II
+	AQL             float64
+	SubjectType     entity.SamplingSubjectType
+	Notes           string
+	CreatedBy       uuid.UUID
+}
+
+// Execute validates and stores a sampling plan
+func (uc *CreateSamplingPlanUseCase) Execute(ctx context.Context, input CreateSamplingPlanInput) (*entity.AQLSamplingPlan, error) {
+	plan := &entity.AQLSamplingPlan{
+		PlanCode:        input.PlanCode,
+		Name:            input.Name,
+		InspectionLevel: input.InspectionLevel,
+		AQL:             input.AQL,
+		SubjectType:     input.SubjectType,
+		IsActive:        true,
+		Notes:           input.Notes,
+		CreatedBy:       &input.CreatedBy,
+	}
+	if plan.InspectionLevel == "" {
+		plan.InspectionLevel = entity.InspectionLevelII
+	}
+	if err := plan.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(ctx, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// GetSamplingPlanUseCase handles getting a sampling plan
+type GetSamplingPlanUseCase struct {
+	repo repository.SamplingPlanRepository
+}
+
+// NewGetSamplingPlanUseCase creates a new GetSamplingPlanUseCase
+func NewGetSamplingPlanUseCase(repo repository.SamplingPlanRepository) *GetSamplingPlanUseCase {
+	return &GetSamplingPlanUseCase{repo: repo}
+}
+
+// Execute gets a sampling plan by ID
+func (uc *GetSamplingPlanUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.AQLSamplingPlan, error) {
+	plan, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrSamplingPlanNotFound
+	}
+	return plan, nil
+}
+
+// ListSamplingPlansUseCase handles listing sampling plans
+type ListSamplingPlansUseCase struct {
+	repo repository.SamplingPlanRepository
+}
+
+// NewListSamplingPlansUseCase creates a new ListSamplingPlansUseCase
+func NewListSamplingPlansUseCase(repo repository.SamplingPlanRepository) *ListSamplingPlansUseCase {
+	return &ListSamplingPlansUseCase{repo: repo}
+}
+
+// Execute lists sampling plans
+func (uc *ListSamplingPlansUseCase) Execute(ctx context.Context, activeOnly bool) ([]*entity.AQLSamplingPlan, error) {
+	return uc.repo.List(ctx, activeOnly)
+}
+
+// AssignSamplingPlanUseCase attaches a sampling plan to a QC checkpoint
+type AssignSamplingPlanUseCase struct {
+	repo   repository.SamplingPlanRepository
+	qcRepo repository.QCRepository
+}
+
+// NewAssignSamplingPlanUseCase creates a new AssignSamplingPlanUseCase
+func NewAssignSamplingPlanUseCase(repo repository.SamplingPlanRepository, qcRepo repository.QCRepository) *AssignSamplingPlanUseCase {
+	return &AssignSamplingPlanUseCase{repo: repo, qcRepo: qcRepo}
+}
+
+// Execute sets (or clears, with a nil plan) the checkpoint's sampling plan
+func (uc *AssignSamplingPlanUseCase) Execute(ctx context.Context, checkpointID uuid.UUID, planID *uuid.UUID) (*entity.QCCheckpoint, error) {
+	checkpoint, err := uc.qcRepo.GetCheckpointByID(ctx, checkpointID)
+	if err != nil || checkpoint == nil {
+		return nil, entity.ErrQCCheckpointNotFound
+	}
+	if planID != nil {
+		plan, err := uc.repo.GetByID(ctx, *planID)
+		if err != nil || plan == nil || !plan.IsActive {
+			return nil, entity.ErrSamplingPlanNotFound
+		}
+	}
+
+	if err := uc.repo.AssignToCheckpoint(ctx, checkpointID, planID); err != nil {
+		return nil, err
+	}
+	checkpoint.SamplingPlanID = planID
+	return checkpoint, nil
+}
+
+// DetermineSampleUseCase previews the sample size and Ac/Re for a lot
+type DetermineSampleUseCase struct {
+	repo repository.SamplingPlanRepository
+}
+
+// NewDetermineSampleUseCase creates a new DetermineSampleUseCase
+func NewDetermineSampleUseCase(repo repository.SamplingPlanRepository) *DetermineSampleUseCase {
+	return &DetermineSampleUseCase{repo: repo}
+}
+
+// DetermineSampleInput is the input for a sample size lookup
+type DetermineSampleInput struct {
+	PlanID      uuid.UUID
+	LotQuantity int
+	SubjectID   *uuid.UUID // Supplier/product/material; normal inspection when omitted
+}
+
+// Execute returns the sample plan under the subject's current switching severity
+func (uc *DetermineSampleUseCase) Execute(ctx context.Context, input DetermineSampleInput) (*entity.SamplePlan, error) {
+	plan, err := uc.repo.GetByID(ctx, input.PlanID)
+	if err != nil {
+		return nil, entity.ErrSamplingPlanNotFound
+	}
+
+	severity := entity.InspectionSeverityNormal
+	if input.SubjectID != nil {
+		state, err := uc.repo.GetState(ctx, plan.ID, plan.SubjectType, *input.SubjectID)
+		if err != nil {
+			return nil, err
+		}
+		if state != nil {
+			severity = state.Severity
+		}
+	}
+	if severity == entity.InspectionSeverityDiscontinued {
+		return nil, entity.ErrSamplingDiscontinued
+	}
+
+	return entity.DetermineSamplePlan(input.LotQuantity, plan.InspectionLevel, plan.AQL, severity)
+}
+
+// ListSamplingStatesUseCase lists the switching states of a plan
+type ListSamplingStatesUseCase struct {
+	repo repository.SamplingPlanRepository
+}
+
+// NewListSamplingStatesUseCase creates a new ListSamplingStatesUseCase
+func NewListSamplingStatesUseCase(repo repository.SamplingPlanRepository) *ListSamplingStatesUseCase {
+	return &ListSamplingStatesUseCase{repo: repo}
+}
+
+// Execute lists the supplier/product histories of a plan
+func (uc *ListSamplingStatesUseCase) Execute(ctx context.Context, planID uuid.UUID) ([]*entity.SamplingState, error) {
+	return uc.repo.ListStates(ctx, planID)
+}
+
+// ResumeSamplingUseCase restarts a discontinued subject on tightened inspection
+type ResumeSamplingUseCase struct {
+	repo repository.SamplingPlanRepository
+}
+
+// NewResumeSamplingUseCase creates a new ResumeSamplingUseCase
+func NewResumeSamplingUseCase(repo repository.SamplingPlanRepository) *ResumeSamplingUseCase {
+	return &ResumeSamplingUseCase{repo: repo}
+}
+
+// Execute resumes inspection after the supplier's corrective action is accepted
+func (uc *ResumeSamplingUseCase) Execute(ctx context.Context, stateID uuid.UUID) (*entity.SamplingState, error) {
+	state, err := uc.repo.GetStateByID(ctx, stateID)
+	if err != nil {
+		return nil, entity.ErrSamplingStateNotFound
+	}
+	if err := state.Resume(); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SaveState(ctx, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
@@ -0,0 +1,173 @@
+package spc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+const (
+	// monitorWindow is the number of recent readings used to compute limits when monitoring
+	monitorWindow = 25
+	// minMonitorSamples is the history needed before limits are trusted for alerts
+	minMonitorSamples = 8
+)
+
+// EventPublisher defines event publishing interface for SPC
+type EventPublisher interface {
+	PublishSPCViolation(event event.SPCViolationEvent) error
+}
+
+// GetSPCChartUseCase builds a control chart for a product and test item
+type GetSPCChartUseCase struct {
+	repo repository.QCRepository
+}
+
+// NewGetSPCChartUseCase creates a new GetSPCChartUseCase
+func NewGetSPCChartUseCase(repo repository.QCRepository) *GetSPCChartUseCase {
+	return &GetSPCChartUseCase{repo: repo}
+}
+
+// GetSPCChartInput is the input for building a control chart
+type GetSPCChartInput struct {
+	ProductID    uuid.UUID
+	TestName     string
+	ChartType    entity.SPCChartType // Defaults to XBAR_R
+	SubgroupSize int                 // X-bar/R only, defaults to 5
+	DateFrom     *string
+	DateTo       *string
+	Limit        int
+	LSL          *float64 // Defaults to the spec of the latest reading
+	USL          *float64
+}
+
+// SPCChartResult is a control chart with the data it was built from
+type SPCChartResult struct {
+	ProductID    uuid.UUID               `json:"product_id"`
+	TestName     string                  `json:"test_name"`
+	Chart        *entity.SPCChart        `json:"chart"`
+	Observations []entity.SPCObservation `json:"observations"`
+}
+
+// Execute loads the numeric results and computes limits, rule violations and capability
+func (uc *GetSPCChartUseCase) Execute(ctx context.Context, input GetSPCChartInput) (*SPCChartResult, error) {
+	if input.ChartType == "" {
+		input.ChartType = entity.SPCChartTypeXbarR
+	}
+	if !input.ChartType.IsValid() {
+		return nil, entity.ErrInvalidSPCChartType
+	}
+	if input.ChartType == entity.SPCChartTypeXbarR && input.SubgroupSize == 0 {
+		input.SubgroupSize = entity.SPCDefaultSubgroupSize
+	}
+
+	observations, err := uc.repo.GetNumericResults(ctx, repository.SPCFilter{
+		ProductID: input.ProductID,
+		TestName:  input.TestName,
+		DateFrom:  input.DateFrom,
+		DateTo:    input.DateTo,
+		Limit:     input.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var chart *entity.SPCChart
+	if input.ChartType == entity.SPCChartTypeIndividuals {
+		chart, err = entity.BuildIndividualsChart(observations)
+	} else {
+		chart, err = entity.BuildXbarRChart(observations, input.SubgroupSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lsl, usl := input.LSL, input.USL
+	if lsl == nil && usl == nil {
+		latest := observations[len(observations)-1]
+		lsl, usl = latest.MinLimit, latest.MaxLimit
+	}
+	chart.EvaluateCapability(lsl, usl)
+
+	return &SPCChartResult{
+		ProductID:    input.ProductID,
+		TestName:     input.TestName,
+		Chart:        chart,
+		Observations: observations,
+	}, nil
+}
+
+// MonitorSPCUseCase checks new numeric results against recent history
+type MonitorSPCUseCase struct {
+	repo     repository.QCRepository
+	eventPub EventPublisher
+}
+
+// NewMonitorSPCUseCase creates a new MonitorSPCUseCase
+func NewMonitorSPCUseCase(repo repository.QCRepository, eventPub EventPublisher) *MonitorSPCUseCase {
+	return &MonitorSPCUseCase{repo: repo, eventPub: eventPub}
+}
+
+// Execute plots each numeric item of the inspection on an individuals chart of the
+// latest readings and publishes the Western Electric violations raised by this inspection
+func (uc *MonitorSPCUseCase) Execute(ctx context.Context, inspection *entity.QCInspection) ([]entity.SPCViolation, error) {
+	if inspection.ProductID == nil {
+		return nil, nil
+	}
+
+	var violations []entity.SPCViolation
+	checked := make(map[string]bool)
+	for _, item := range inspection.Items {
+		key := strings.ToLower(item.TestName)
+		if item.NumericValue == nil || checked[key] {
+			continue
+		}
+		checked[key] = true
+
+		observations, err := uc.repo.GetNumericResults(ctx, repository.SPCFilter{
+			ProductID: *inspection.ProductID,
+			TestName:  item.TestName,
+			Limit:     monitorWindow,
+		})
+		if err != nil {
+			return violations, err
+		}
+		if len(observations) < minMonitorSamples {
+			continue
+		}
+
+		chart, err := entity.BuildIndividualsChart(observations)
+		if err != nil {
+			continue
+		}
+
+		for _, point := range chart.Points {
+			if point.InspectionID == nil || *point.InspectionID != inspection.ID {
+				continue
+			}
+			for _, v := range chart.ViolationsAt(point.Index) {
+				violations = append(violations, v)
+				uc.eventPub.PublishSPCViolation(event.SPCViolationEvent{
+					ProductID:        inspection.ProductID.String(),
+					TestName:         item.TestName,
+					InspectionID:     inspection.ID.String(),
+					InspectionNumber: inspection.InspectionNumber,
+					LotNumber:        inspection.LotNumber,
+					Rule:             string(v.Rule),
+					Description:      v.Description,
+					Value:            v.Value,
+					CenterLine:       chart.Limits.CenterLine,
+					UCL:              chart.Limits.UCL,
+					LCL:              chart.Limits.LCL,
+					OutOfSpec:        item.OutOfSpec,
+				})
+			}
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,130 @@
+package spc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/spc"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func history(values ...float64) []entity.SPCObservation {
+	var obs []entity.SPCObservation
+	for _, v := range values {
+		obs = append(obs, entity.SPCObservation{InspectionID: uuid.New(), Value: v})
+	}
+	return obs
+}
+
+func TestGetSPCChartUseCase_Execute_DefaultsSpecToLatestReading(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	uc := spc.NewGetSPCChartUseCase(qcRepo)
+
+	productID := uuid.New()
+	obs := history(5.5, 5.6, 5.4, 5.5, 5.7, 5.6)
+	lsl, usl := 5.0, 6.0
+	obs[len(obs)-1].MinLimit = &lsl
+	obs[len(obs)-1].MaxLimit = &usl
+
+	qcRepo.On("GetNumericResults", ctx, repository.SPCFilter{ProductID: productID, TestName: "pH"}).Return(obs, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, spc.GetSPCChartInput{
+		ProductID:    productID,
+		TestName:     "pH",
+		SubgroupSize: 3,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SPCChartTypeXbarR, res.Chart.ChartType)
+	assert.Len(t, res.Chart.Points, 2)
+	assert.Equal(t, &lsl, res.Chart.Capability.LSL)
+	assert.Equal(t, &usl, res.Chart.Capability.USL)
+	assert.NotNil(t, res.Chart.Capability.Cpk)
+}
+
+func TestGetSPCChartUseCase_Execute_InvalidChartType(t *testing.T) {
+	uc := spc.NewGetSPCChartUseCase(new(testmocks.MockQCRepository))
+
+	_, err := uc.Execute(context.Background(), spc.GetSPCChartInput{ChartType: "P_CHART"})
+
+	assert.Equal(t, entity.ErrInvalidSPCChartType, err)
+}
+
+func TestMonitorSPCUseCase_Execute_PublishesViolationForNewInspection(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+	uc := spc.NewMonitorSPCUseCase(qcRepo, eventPub)
+
+	productID := uuid.New()
+	inspection := &entity.QCInspection{
+		ID:               uuid.New(),
+		InspectionNumber: "QC-2026-0042",
+		ProductID:        &productID,
+		LotNumber:        "LOT-042",
+		Items: []entity.QCInspectionItem{
+			{TestName: "Viscosity", NumericValue: floatPtr(20)},
+			{TestName: "Appearance", Result: entity.ItemResultPass},
+		},
+	}
+
+	// Earlier run-rule violations must not be re-announced
+	obs := history(10, 10.2, 9.8, 10, 10.2, 9.8, 10, 10.1, 14, 10, 10.2, 9.8, 10, 10.1, 9.9, 10)
+	obs = append(obs, entity.SPCObservation{InspectionID: inspection.ID, Value: 20})
+
+	qcRepo.On("GetNumericResults", ctx, mock.MatchedBy(func(f repository.SPCFilter) bool {
+		return f.ProductID == productID && f.TestName == "Viscosity" && f.Limit > 0
+	})).Return(obs, nil)
+	eventPub.On("PublishSPCViolation", mock.Anything).Return(nil)
+
+	// Act
+	violations, err := uc.Execute(ctx, inspection)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, violations)
+	for _, v := range violations {
+		assert.Equal(t, len(obs)-1, v.PointIndex)
+	}
+	eventPub.AssertCalled(t, "PublishSPCViolation", mock.MatchedBy(func(e event.SPCViolationEvent) bool {
+		return e.InspectionID == inspection.ID.String() && e.TestName == "Viscosity" && e.LotNumber == "LOT-042"
+	}))
+	qcRepo.AssertNumberOfCalls(t, "GetNumericResults", 1)
+}
+
+func TestMonitorSPCUseCase_Execute_SkipsShortHistory(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	qcRepo := new(testmocks.MockQCRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+	uc := spc.NewMonitorSPCUseCase(qcRepo, eventPub)
+
+	productID := uuid.New()
+	inspection := &entity.QCInspection{
+		ID:        uuid.New(),
+		ProductID: &productID,
+		Items:     []entity.QCInspectionItem{{TestName: "pH", NumericValue: floatPtr(9)}},
+	}
+	qcRepo.On("GetNumericResults", ctx, mock.Anything).Return(history(5, 5.1, 9), nil)
+
+	// Act
+	violations, err := uc.Execute(ctx, inspection)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, violations)
+	eventPub.AssertNotCalled(t, "PublishSPCViolation", mock.Anything)
+}
+
+func floatPtr(v float64) *float64 { return &v }
@@ -0,0 +1,443 @@
+package stability
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
+	"github.com/google/uuid"
+)
+
+// ConditionInput is a storage condition of a new study
+type ConditionInput struct {
+	Name          string
+	ConditionType entity.StabilityConditionType
+	TemperatureC  float64
+	HumidityRH    *float64
+	PullMonths    []int // Overrides the study pull points, e.g. 0, 3, 6 for accelerated
+}
+
+// CreateStudyUseCase handles stability study creation
+type CreateStudyUseCase struct {
+	repo    repository.StabilityRepository
+	bomRepo repository.BOMRepository
+	qcRepo  repository.QCRepository
+}
+
+// NewCreateStudyUseCase creates a new CreateStudyUseCase
+func NewCreateStudyUseCase(repo repository.StabilityRepository, bomRepo repository.BOMRepository, qcRepo repository.QCRepository) *CreateStudyUseCase {
+	return &CreateStudyUseCase{repo: repo, bomRepo: bomRepo, qcRepo: qcRepo}
+}
+
+// CreateStudyInput is the input for creating a stability study
+type CreateStudyInput struct {
+	BOMID          uuid.UUID
+	WorkOrderID    *uuid.UUID
+	LotID          *uuid.UUID
+	LotNumber      string
+	CheckpointID   uuid.UUID
+	SampleQuantity float64
+	StartDate      time.Time
+	PullMonths     []int // e.g. 0, 1, 3, 6, 12
+	Conditions     []ConditionInput
+	Purpose        string
+	CreatedBy      uuid.UUID
+}
+
+// Execute creates a study and schedules a QC inspection for every pull point
+// of every condition
+func (uc *CreateStudyUseCase) Execute(ctx context.Context, input CreateStudyInput) (*entity.StabilityStudy, error) {
+	hasLongTerm := false
+	for _, c := range input.Conditions {
+		if !c.ConditionType.IsValid() {
+			return nil, entity.ErrInvalidStabilityCondition
+		}
+		if c.ConditionType == entity.StabilityConditionLongTerm {
+			hasLongTerm = true
+		}
+		if !validPullMonths(pullMonths(input.PullMonths, c.PullMonths)) {
+			return nil, entity.ErrInvalidStabilityPullPoints
+		}
+	}
+	if !hasLongTerm {
+		return nil, entity.ErrStabilityLongTermRequired
+	}
+
+	bom, err := uc.bomRepo.GetByID(ctx, input.BOMID)
+	if err != nil || bom == nil {
+		return nil, entity.ErrBOMNotFound
+	}
+	if _, err := uc.qcRepo.GetCheckpointByID(ctx, input.CheckpointID); err != nil {
+		return nil, entity.ErrQCCheckpointNotFound
+	}
+
+	studyNumber, err := uc.repo.GenerateStudyNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	study := &entity.StabilityStudy{
+		ID:             uuid.New(),
+		StudyNumber:    studyNumber,
+		ProductID:      bom.ProductID,
+		BOMID:          bom.ID,
+		BOMVersion:     bom.Version,
+		WorkOrderID:    input.WorkOrderID,
+		LotID:          input.LotID,
+		LotNumber:      input.LotNumber,
+		CheckpointID:   input.CheckpointID,
+		SampleQuantity: input.SampleQuantity,
+		StartDate:      input.StartDate,
+		Status:         entity.StabilityStudyStatusActive,
+		Purpose:        input.Purpose,
+		CreatedBy:      input.CreatedBy,
+	}
+
+	for _, c := range input.Conditions {
+		condition := entity.StabilityCondition{
+			ID:            uuid.New(),
+			StudyID:       study.ID,
+			Name:          c.Name,
+			ConditionType: c.ConditionType,
+			TemperatureC:  c.TemperatureC,
+			HumidityRH:    c.HumidityRH,
+		}
+		study.Conditions = append(study.Conditions, condition)
+
+		for _, month := range pullMonths(input.PullMonths, c.PullMonths) {
+			inspection, err := uc.scheduleInspection(ctx, study, &condition, month)
+			if err != nil {
+				return nil, err
+			}
+			study.PullPoints = append(study.PullPoints, entity.StabilityPullPoint{
+				ID:               uuid.New(),
+				StudyID:          study.ID,
+				ConditionID:      condition.ID,
+				Month:            month,
+				ScheduledDate:    study.PullDate(month),
+				Status:           entity.StabilityPullStatusScheduled,
+				InspectionID:     inspection.ID,
+				InspectionNumber: inspection.InspectionNumber,
+			})
+		}
+	}
+	sort.SliceStable(study.PullPoints, func(i, j int) bool {
+		return study.PullPoints[i].ScheduledDate.Before(study.PullPoints[j].ScheduledDate)
+	})
+
+	if err := uc.repo.Create(ctx, study); err != nil {
+		return nil, err
+	}
+	return study, nil
+}
+
+// scheduleInspection creates the pending QC inspection of a pull point, dated on the pull date
+func (uc *CreateStudyUseCase) scheduleInspection(ctx context.Context, study *entity.StabilityStudy, condition *entity.StabilityCondition, month int) (*entity.QCInspection, error) {
+	inspNumber, err := uc.qcRepo.GenerateInspectionNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &entity.QCInspection{
+		InspectionNumber:  inspNumber,
+		InspectionDate:    study.PullDate(month),
+		InspectionType:    entity.CheckpointTypeStability,
+		CheckpointID:      &study.CheckpointID,
+		ReferenceType:     entity.ReferenceTypeStabilityStudy,
+		ReferenceID:       study.ID,
+		ProductID:         &study.ProductID,
+		LotID:             study.LotID,
+		LotNumber:         study.LotNumber,
+		InspectedQuantity: study.SampleQuantity,
+		Result:            entity.InspectionResultPending,
+		EvaluatedResult:   entity.InspectionResultPending,
+		InspectorID:       study.CreatedBy,
+		Notes:             pullPointNote(study, condition, month),
+	}
+	if err := uc.qcRepo.CreateInspection(ctx, inspection); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+// GetStudyUseCase handles getting a stability study
+type GetStudyUseCase struct {
+	repo repository.StabilityRepository
+}
+
+// NewGetStudyUseCase creates a new GetStudyUseCase
+func NewGetStudyUseCase(repo repository.StabilityRepository) *GetStudyUseCase {
+	return &GetStudyUseCase{repo: repo}
+}
+
+// Execute gets a study by ID with its conditions and pull points
+func (uc *GetStudyUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.StabilityStudy, error) {
+	study, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrStabilityStudyNotFound
+	}
+	return study, nil
+}
+
+// ListStudiesUseCase handles listing stability studies
+type ListStudiesUseCase struct {
+	repo repository.StabilityRepository
+}
+
+// NewListStudiesUseCase creates a new ListStudiesUseCase
+func NewListStudiesUseCase(repo repository.StabilityRepository) *ListStudiesUseCase {
+	return &ListStudiesUseCase{repo: repo}
+}
+
+// Execute lists stability studies
+func (uc *ListStudiesUseCase) Execute(ctx context.Context, filter repository.StabilityFilter) ([]*entity.StabilityStudy, int64, error) {
+	return uc.repo.List(ctx, filter)
+}
+
+// RecordResultsUseCase records the test results of a pull point on its scheduled inspection
+type RecordResultsUseCase struct {
+	repo   repository.StabilityRepository
+	qcRepo repository.QCRepository
+}
+
+// NewRecordResultsUseCase creates a new RecordResultsUseCase
+func NewRecordResultsUseCase(repo repository.StabilityRepository, qcRepo repository.QCRepository) *RecordResultsUseCase {
+	return &RecordResultsUseCase{repo: repo, qcRepo: qcRepo}
+}
+
+// ResultItemInput is a test result of a pull point
+type ResultItemInput struct {
+	TestName      string
+	TestMethod    string
+	Specification string
+	MinValue      string // Used when the test is not on the checkpoint
+	MaxValue      string
+	ActualValue   string
+	NumericValue  *float64
+	UOM           string
+	Result        entity.ItemResult // Optional for numeric tests with limits
+	Notes         string
+}
+
+// RecordResultsInput is the input for recording pull point results
+type RecordResultsInput struct {
+	StudyID     uuid.UUID
+	PullPointID uuid.UUID
+	Items       []ResultItemInput
+	TestedBy    uuid.UUID
+	TesterName  string
+}
+
+// Execute evaluates the results against the study checkpoint specs and stores
+// them on the pull point inspection, which is then approved through QC
+func (uc *RecordResultsUseCase) Execute(ctx context.Context, input RecordResultsInput) (*entity.StabilityPullPoint, error) {
+	if len(input.Items) == 0 {
+		return nil, entity.ErrStabilityResultsRequired
+	}
+
+	study, err := uc.repo.GetByID(ctx, input.StudyID)
+	if err != nil {
+		return nil, entity.ErrStabilityStudyNotFound
+	}
+	if study.Status != entity.StabilityStudyStatusActive {
+		return nil, entity.ErrStabilityStudyClosed
+	}
+	pullPoint := study.PullPointByID(input.PullPointID)
+	if pullPoint == nil {
+		return nil, entity.ErrStabilityPullPointNotFound
+	}
+	if pullPoint.Status == entity.StabilityPullStatusTested {
+		return nil, entity.ErrStabilityPullPointTested
+	}
+
+	inspection, err := uc.qcRepo.GetInspectionByID(ctx, pullPoint.InspectionID)
+	if err != nil {
+		return nil, entity.ErrQCInspectionNotFound
+	}
+	if !inspection.IsPending() {
+		return nil, entity.ErrQCAlreadyApproved
+	}
+
+	var templates []entity.TestItemTemplate
+	if checkpoint, err := uc.qcRepo.GetCheckpointByID(ctx, study.CheckpointID); err == nil && checkpoint != nil {
+		if templates, err = checkpoint.TestItemTemplates(); err != nil {
+			return nil, err
+		}
+	}
+
+	var evaluated []entity.QCInspectionItem
+	for i, item := range input.Items {
+		qcItem := entity.QCInspectionItem{
+			InspectionID:  inspection.ID,
+			ItemNumber:    i + 1,
+			TestName:      item.TestName,
+			TestMethod:    item.TestMethod,
+			Specification: item.Specification,
+			MinValue:      item.MinValue,
+			MaxValue:      item.MaxValue,
+			ActualValue:   item.ActualValue,
+			NumericValue:  item.NumericValue,
+			UOM:           item.UOM,
+			Result:        item.Result,
+			Notes:         item.Notes,
+		}
+		if tpl := entity.FindTestItemTemplate(templates, item.TestName); tpl != nil {
+			qcItem.ApplySpec(tpl)
+		}
+		qcItem.Evaluate()
+		if qcItem.Result == "" {
+			return nil, entity.ErrQCItemResultRequired
+		}
+		evaluated = append(evaluated, qcItem)
+	}
+
+	now := time.Now()
+	inspection.InspectionDate = now
+	inspection.InspectorID = input.TestedBy
+	inspection.InspectorName = input.TesterName
+	inspection.Items = evaluated
+	inspection.CalculateScore()
+	inspection.Items = nil // Items are created separately below
+
+	if err := uc.qcRepo.UpdateInspection(ctx, inspection); err != nil {
+		return nil, err
+	}
+	var items []*entity.QCInspectionItem
+	for i := range evaluated {
+		items = append(items, &evaluated[i])
+	}
+	if err := uc.qcRepo.CreateInspectionItems(ctx, items); err != nil {
+		return nil, err
+	}
+
+	if err := pullPoint.MarkTested(input.TestedBy, now); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdatePullPoint(ctx, pullPoint); err != nil {
+		return nil, err
+	}
+	return pullPoint, nil
+}
+
+// EvaluateStudyUseCase trends the recorded results into a shelf-life recommendation
+type EvaluateStudyUseCase struct {
+	repo   repository.StabilityRepository
+	qcRepo repository.QCRepository
+}
+
+// NewEvaluateStudyUseCase creates a new EvaluateStudyUseCase
+func NewEvaluateStudyUseCase(repo repository.StabilityRepository, qcRepo repository.QCRepository) *EvaluateStudyUseCase {
+	return &EvaluateStudyUseCase{repo: repo, qcRepo: qcRepo}
+}
+
+// Execute evaluates the study and stores the recommendation
+func (uc *EvaluateStudyUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.StabilityStudy, error) {
+	study, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrStabilityStudyNotFound
+	}
+	if err := evaluate(ctx, uc.qcRepo, study, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, study); err != nil {
+		return nil, err
+	}
+	return study, nil
+}
+
+// CompleteStudyUseCase closes a study with a final evaluation
+type CompleteStudyUseCase struct {
+	repo   repository.StabilityRepository
+	qcRepo repository.QCRepository
+}
+
+// NewCompleteStudyUseCase creates a new CompleteStudyUseCase
+func NewCompleteStudyUseCase(repo repository.StabilityRepository, qcRepo repository.QCRepository) *CompleteStudyUseCase {
+	return &CompleteStudyUseCase{repo: repo, qcRepo: qcRepo}
+}
+
+// CompleteStudyInput is the input for completing a study
+type CompleteStudyInput struct {
+	StudyID     uuid.UUID
+	Notes       string
+	CompletedBy uuid.UUID
+}
+
+// Execute evaluates and completes the study
+func (uc *CompleteStudyUseCase) Execute(ctx context.Context, input CompleteStudyInput) (*entity.StabilityStudy, error) {
+	study, err := uc.repo.GetByID(ctx, input.StudyID)
+	if err != nil {
+		return nil, entity.ErrStabilityStudyNotFound
+	}
+	now := time.Now()
+	if err := study.Complete(input.CompletedBy, input.Notes, now); err != nil {
+		return nil, err
+	}
+	if err := evaluate(ctx, uc.qcRepo, study, now); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Update(ctx, study); err != nil {
+		return nil, err
+	}
+	return study, nil
+}
+
+// evaluate collects the results of the tested pull points and sets the study evaluation
+func evaluate(ctx context.Context, qcRepo repository.QCRepository, study *entity.StabilityStudy, now time.Time) error {
+	var readings []entity.StabilityReading
+	for _, p := range study.PullPoints {
+		if p.Status != entity.StabilityPullStatusTested {
+			continue
+		}
+		items, err := qcRepo.GetInspectionItems(ctx, p.InspectionID)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.Result == entity.ItemResultNA {
+				continue
+			}
+			readings = append(readings, entity.StabilityReading{
+				ConditionID:      p.ConditionID,
+				Month:            p.Month,
+				InspectionNumber: p.InspectionNumber,
+				TestName:         item.TestName,
+				Unit:             item.UOM,
+				Value:            item.NumericValue,
+				MinLimit:         item.MinLimit,
+				MaxLimit:         item.MaxLimit,
+				Failed:           item.Result == entity.ItemResultFail,
+			})
+		}
+	}
+	return study.SetEvaluation(entity.EvaluateStability(study.Conditions, readings), now)
+}
+
+// pullMonths returns the condition's own pull points or the study's
+func pullMonths(study, condition []int) []int {
+	if len(condition) > 0 {
+		return condition
+	}
+	return study
+}
+
+func validPullMonths(months []int) bool {
+	if len(months) == 0 {
+		return false
+	}
+	seen := make(map[int]bool)
+	for _, m := range months {
+		if m < 0 || seen[m] {
+			return false
+		}
+		seen[m] = true
+	}
+	return true
+}
+
+func pullPointNote(study *entity.StabilityStudy, condition *entity.StabilityCondition, month int) string {
+	return "Stability " + study.StudyNumber + " " + condition.Name + " T" + strconv.Itoa(month) + "M"
+}
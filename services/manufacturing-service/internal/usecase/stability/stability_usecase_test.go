@@ -0,0 +1,221 @@
+package stability_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
+	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/stability"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateStudyUseCase_Execute_SchedulesInspectionPerPullPoint(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stabilityRepo := new(testmocks.MockStabilityRepository)
+	bomRepo := new(testmocks.MockBOMRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := stability.NewCreateStudyUseCase(stabilityRepo, bomRepo, qcRepo)
+
+	bom := &entity.BOM{ID: uuid.New(), ProductID: uuid.New(), Version: 3}
+	checkpointID := uuid.New()
+	bomRepo.On("GetByID", ctx, bom.ID).Return(bom, nil)
+	qcRepo.On("GetCheckpointByID", ctx, checkpointID).Return(&entity.QCCheckpoint{ID: checkpointID}, nil)
+	stabilityRepo.On("GenerateStudyNumber", ctx).Return("STB-2026-0001", nil)
+	qcRepo.On("GenerateInspectionNumber", ctx).Return("QC-2026-0100", nil)
+	qcRepo.On("CreateInspection", ctx, mock.AnythingOfType("*entity.QCInspection")).Return(nil)
+	stabilityRepo.On("Create", ctx, mock.AnythingOfType("*entity.StabilityStudy")).Return(nil)
+
+	rh60, rh75 := 60.0, 75.0
+	startDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	res, err := uc.Execute(ctx, stability.CreateStudyInput{
+		BOMID:          bom.ID,
+		LotNumber:      "FG-2026-0015",
+		CheckpointID:   checkpointID,
+		SampleQuantity: 6,
+		StartDate:      startDate,
+		PullMonths:     []int{0, 1, 3, 6, 12},
+		Conditions: []stability.ConditionInput{
+			{Name: "25°C/60%RH", ConditionType: entity.StabilityConditionLongTerm, TemperatureC: 25, HumidityRH: &rh60},
+			{Name: "40°C/75%RH", ConditionType: entity.StabilityConditionAccelerated, TemperatureC: 40, HumidityRH: &rh75, PullMonths: []int{0, 3, 6}},
+		},
+		CreatedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "STB-2026-0001", res.StudyNumber)
+	assert.Equal(t, bom.ProductID, res.ProductID)
+	assert.Equal(t, 3, res.BOMVersion)
+	assert.Len(t, res.Conditions, 2)
+	assert.Len(t, res.PullPoints, 8)
+	qcRepo.AssertNumberOfCalls(t, "CreateInspection", 8)
+
+	last := res.PullPoints[len(res.PullPoints)-1]
+	assert.Equal(t, 12, last.Month)
+	assert.Equal(t, time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC), last.ScheduledDate)
+
+	scheduled := qcRepo.Calls[2].Arguments.Get(1).(*entity.QCInspection) // First CreateInspection
+	assert.Equal(t, entity.CheckpointTypeStability, scheduled.InspectionType)
+	assert.Equal(t, entity.ReferenceTypeStabilityStudy, scheduled.ReferenceType)
+	assert.Equal(t, res.ID, scheduled.ReferenceID)
+	assert.Equal(t, entity.InspectionResultPending, scheduled.Result)
+	assert.Equal(t, startDate, scheduled.InspectionDate)
+}
+
+func TestCreateStudyUseCase_Execute_RequiresLongTermCondition(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stabilityRepo := new(testmocks.MockStabilityRepository)
+	bomRepo := new(testmocks.MockBOMRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := stability.NewCreateStudyUseCase(stabilityRepo, bomRepo, qcRepo)
+
+	// Act
+	res, err := uc.Execute(ctx, stability.CreateStudyInput{
+		BOMID:      uuid.New(),
+		PullMonths: []int{0, 3, 6},
+		Conditions: []stability.ConditionInput{
+			{Name: "40°C/75%RH", ConditionType: entity.StabilityConditionAccelerated, TemperatureC: 40},
+		},
+	})
+
+	// Assert
+	assert.Nil(t, res)
+	assert.Equal(t, entity.ErrStabilityLongTermRequired, err)
+	qcRepo.AssertNotCalled(t, "CreateInspection", mock.Anything, mock.Anything)
+}
+
+func TestCreateStudyUseCase_Execute_RejectsDuplicatePullMonths(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	uc := stability.NewCreateStudyUseCase(new(testmocks.MockStabilityRepository), new(testmocks.MockBOMRepository), new(testmocks.MockQCRepository))
+
+	// Act
+	_, err := uc.Execute(ctx, stability.CreateStudyInput{
+		PullMonths: []int{0, 3, 3},
+		Conditions: []stability.ConditionInput{{Name: "25°C/60%RH", ConditionType: entity.StabilityConditionLongTerm}},
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrInvalidStabilityPullPoints, err)
+}
+
+func TestRecordResultsUseCase_Execute_EvaluatesAgainstCheckpoint(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stabilityRepo := new(testmocks.MockStabilityRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := stability.NewRecordResultsUseCase(stabilityRepo, qcRepo)
+
+	phMin, phMax := 5.0, 6.0
+	testItems, _ := json.Marshal([]entity.TestItemTemplate{{Name: "pH", Type: "NUMERIC", Min: &phMin, Max: &phMax}})
+	checkpoint := &entity.QCCheckpoint{ID: uuid.New(), TestItems: testItems}
+	inspection := &entity.QCInspection{ID: uuid.New(), Result: entity.InspectionResultPending}
+	pullPoint := entity.StabilityPullPoint{ID: uuid.New(), Month: 3, Status: entity.StabilityPullStatusScheduled, InspectionID: inspection.ID}
+	study := &entity.StabilityStudy{
+		ID:           uuid.New(),
+		Status:       entity.StabilityStudyStatusActive,
+		CheckpointID: checkpoint.ID,
+		PullPoints:   []entity.StabilityPullPoint{pullPoint},
+	}
+
+	stabilityRepo.On("GetByID", ctx, study.ID).Return(study, nil)
+	qcRepo.On("GetInspectionByID", ctx, inspection.ID).Return(inspection, nil)
+	qcRepo.On("GetCheckpointByID", ctx, checkpoint.ID).Return(checkpoint, nil)
+	qcRepo.On("UpdateInspection", ctx, inspection).Return(nil)
+	qcRepo.On("CreateInspectionItems", ctx, mock.Anything).Return(nil)
+	stabilityRepo.On("UpdatePullPoint", ctx, mock.AnythingOfType("*entity.StabilityPullPoint")).Return(nil)
+
+	ph := 6.3
+
+	// Act
+	res, err := uc.Execute(ctx, stability.RecordResultsInput{
+		StudyID:     study.ID,
+		PullPointID: pullPoint.ID,
+		Items:       []stability.ResultItemInput{{TestName: "pH", NumericValue: &ph}},
+		TestedBy:    uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.StabilityPullStatusTested, res.Status)
+	assert.NotNil(t, res.TestedAt)
+	assert.Equal(t, entity.InspectionResultFailed, inspection.EvaluatedResult)
+	assert.Equal(t, entity.InspectionResultPending, inspection.Result) // Still approved through QC
+	items := qcRepo.Calls[3].Arguments.Get(1).([]*entity.QCInspectionItem)
+	assert.Equal(t, entity.ItemResultFail, items[0].Result)
+	assert.Equal(t, inspection.ID, items[0].InspectionID)
+}
+
+func TestRecordResultsUseCase_Execute_AlreadyTested(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stabilityRepo := new(testmocks.MockStabilityRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := stability.NewRecordResultsUseCase(stabilityRepo, qcRepo)
+
+	pullPoint := entity.StabilityPullPoint{ID: uuid.New(), Status: entity.StabilityPullStatusTested}
+	study := &entity.StabilityStudy{ID: uuid.New(), Status: entity.StabilityStudyStatusActive, PullPoints: []entity.StabilityPullPoint{pullPoint}}
+	stabilityRepo.On("GetByID", ctx, study.ID).Return(study, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, stability.RecordResultsInput{
+		StudyID:     study.ID,
+		PullPointID: pullPoint.ID,
+		Items:       []stability.ResultItemInput{{TestName: "Appearance", Result: entity.ItemResultPass}},
+	})
+
+	// Assert
+	assert.Equal(t, entity.ErrStabilityPullPointTested, err)
+	qcRepo.AssertNotCalled(t, "UpdateInspection", mock.Anything, mock.Anything)
+}
+
+func TestEvaluateStudyUseCase_Execute_StoresRecommendation(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stabilityRepo := new(testmocks.MockStabilityRepository)
+	qcRepo := new(testmocks.MockQCRepository)
+
+	uc := stability.NewEvaluateStudyUseCase(stabilityRepo, qcRepo)
+
+	longTerm := entity.StabilityCondition{ID: uuid.New(), Name: "25°C/60%RH", ConditionType: entity.StabilityConditionLongTerm}
+	study := &entity.StabilityStudy{ID: uuid.New(), Status: entity.StabilityStudyStatusActive, Conditions: []entity.StabilityCondition{longTerm}}
+	phMin, phMax := 5.0, 6.0
+	for i, month := range []int{0, 3, 6} {
+		p := entity.StabilityPullPoint{ID: uuid.New(), ConditionID: longTerm.ID, Month: month, Status: entity.StabilityPullStatusTested, InspectionID: uuid.New()}
+		study.PullPoints = append(study.PullPoints, p)
+		v := 5.5 - float64(i)*0.01
+		qcRepo.On("GetInspectionItems", ctx, p.InspectionID).Return([]*entity.QCInspectionItem{
+			{TestName: "pH", NumericValue: &v, MinLimit: &phMin, MaxLimit: &phMax, Result: entity.ItemResultPass},
+		}, nil)
+	}
+	// Not yet tested
+	study.PullPoints = append(study.PullPoints, entity.StabilityPullPoint{ID: uuid.New(), ConditionID: longTerm.ID, Month: 12, Status: entity.StabilityPullStatusScheduled})
+
+	stabilityRepo.On("GetByID", ctx, study.ID).Return(study, nil)
+	stabilityRepo.On("Update", ctx, study).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, study.ID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 6, *res.RecommendedShelfLifeMonths) // No accelerated data, no extrapolation
+	assert.NotNil(t, res.EvaluatedAt)
+	var eval entity.StabilityEvaluation
+	assert.NoError(t, json.Unmarshal(res.Evaluation, &eval))
+	assert.Equal(t, 6, eval.CoveredMonths)
+	qcRepo.AssertNumberOfCalls(t, "GetInspectionItems", 3)
+}
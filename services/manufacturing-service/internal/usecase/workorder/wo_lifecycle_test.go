@@ -16,6 +16,7 @@ func TestWorkOrder_Lifecycle_And_Yield(t *testing.T) {
 	// Arrange
 	ctx := context.Background()
 	repo := new(testmocks.MockWorkOrderRepository)
+	routingRepo := new(testmocks.MockRoutingRepository)
 	eventPub := new(testmocks.MockEventPublisher)
 	
 	woID := uuid.New()
@@ -30,31 +31,35 @@ func TestWorkOrder_Lifecycle_And_Yield(t *testing.T) {
 
 	repo.On("GetByID", ctx, woID).Return(wo, nil)
 	repo.On("Update", ctx, mock.Anything).Return(nil)
+	repo.On("GetOutputs", ctx, woID).Return([]*entity.WOOutput{}, nil)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	traceRepo.On("UpdateProductLot", ctx, woID, mock.Anything, mock.Anything).Return(nil)
+	routingRepo.On("GetActiveForProduct", ctx, wo.ProductID).Return(nil, nil) // No routing
 	eventPub.On("PublishWOReleased", mock.Anything).Return(nil)
 	eventPub.On("PublishWOStarted", mock.Anything).Return(nil)
 	eventPub.On("PublishWOCompleted", mock.Anything).Return(nil)
 
 	// 1. RELEASE
-	releaseUC := workorder.NewReleaseWOUseCase(repo, eventPub)
+	releaseUC := workorder.NewReleaseWOUseCase(repo, routingRepo, new(testmocks.MockWOOperationRepository), testmocks.MockTransactor{}, eventPub)
 	res, err := releaseUC.Execute(ctx, woID, userID)
 	assert.NoError(t, err)
 	assert.Equal(t, entity.WOStatusReleased, res.Status)
 
 	// 2. START
-	startUC := workorder.NewStartWOUseCase(repo, eventPub)
+	startUC := workorder.NewStartWOUseCase(repo, new(testmocks.MockLineClearanceRepository), eventPub)
 	res, err = startUC.Execute(ctx, woID, userID)
 	assert.NoError(t, err)
 	assert.Equal(t, entity.WOStatusInProgress, res.Status)
 	assert.NotNil(t, res.ActualStartDate)
 
 	// 3. COMPLETE with YIELD
-	completeUC := workorder.NewCompleteWOUseCase(repo, eventPub)
+	completeUC := workorder.NewCompleteWOUseCase(repo, new(testmocks.MockBOMRepository), traceRepo, testmocks.MockTransactor{}, eventPub)
 	res, err = completeUC.Execute(ctx, workorder.CompleteWOInput{
-		WorkOrderID:      woID,
+		WOID:             woID,
 		ActualQuantity:   105,
 		GoodQuantity:     98,
 		RejectedQuantity: 7,
-		CompletedBy:      userID,
+		UpdatedBy:        userID,
 	})
 	
 	assert.NoError(t, err)
@@ -2,6 +2,7 @@ package workorder
 
 import (
 	"context"
+	"time"
 
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/repository"
@@ -15,6 +16,9 @@ type EventPublisher interface {
 	PublishWOReleased(event event.WOEvent) error
 	PublishWOStarted(event event.WOEvent) error
 	PublishWOCompleted(event event.WOCompletedEvent) error
+	PublishWOCancelled(event event.WOEvent) error
+	PublishWOBackflushed(event event.WOBackflushEvent) error
+	PublishMaterialReturned(event event.MaterialReturnedEvent) error
 }
 
 // CreateWOUseCase handles work order creation
@@ -47,11 +51,27 @@ type CreateWOInput struct {
 	Shift            string
 	Priority         entity.WOPriority
 	Notes            string
+	Outputs          []WOOutputInput
 	CreatedBy        uuid.UUID
 }
 
+// WOOutputInput declares a co-product or by-product of a work order
+type WOOutputInput struct {
+	OutputType      entity.WOOutputType
+	ProductID       uuid.UUID
+	PlannedQuantity float64
+	UOMID           uuid.UUID
+	Notes           string
+}
+
 // Execute creates a new work order
 func (uc *CreateWOUseCase) Execute(ctx context.Context, input CreateWOInput) (*entity.WorkOrder, error) {
+	for _, out := range input.Outputs {
+		if !out.OutputType.IsValid() || out.ProductID == input.ProductID || out.PlannedQuantity < 0 {
+			return nil, entity.ErrInvalidWOOutput
+		}
+	}
+
 	// Validate BOM exists and is approved
 	bom, err := uc.bomRepo.GetByID(ctx, input.BOMID)
 	if err != nil {
@@ -71,6 +91,7 @@ func (uc *CreateWOUseCase) Execute(ctx context.Context, input CreateWOInput) (*e
 		WONumber:        woNumber,
 		ProductID:       input.ProductID,
 		BOMID:           input.BOMID,
+		WOType:          entity.WOTypeStandard,
 		Status:          entity.WOStatusPlanned,
 		Priority:        input.Priority,
 		PlannedQuantity: input.PlannedQuantity,
@@ -107,6 +128,26 @@ func (uc *CreateWOUseCase) Execute(ctx context.Context, input CreateWOInput) (*e
 		return nil, err
 	}
 
+	if len(input.Outputs) > 0 {
+		var outputs []*entity.WOOutput
+		for _, out := range input.Outputs {
+			outputs = append(outputs, &entity.WOOutput{
+				WorkOrderID:     wo.ID,
+				OutputType:      out.OutputType,
+				ProductID:       out.ProductID,
+				PlannedQuantity: out.PlannedQuantity,
+				UOMID:           out.UOMID,
+				Notes:           out.Notes,
+			})
+		}
+		if err := uc.woRepo.CreateOutputs(ctx, outputs); err != nil {
+			return nil, err
+		}
+		for _, out := range outputs {
+			wo.Outputs = append(wo.Outputs, *out)
+		}
+	}
+
 	// Publish event
 	uc.eventPub.PublishWOCreated(event.WOEvent{
 		WOID:            wo.ID.String(),
@@ -121,6 +162,140 @@ func (uc *CreateWOUseCase) Execute(ctx context.Context, input CreateWOInput) (*e
 	return wo, nil
 }
 
+// CreateReworkWOUseCase handles creating a rework work order from an NCR
+type CreateReworkWOUseCase struct {
+	woRepo   repository.WorkOrderRepository
+	bomRepo  repository.BOMRepository
+	ncrRepo  repository.NCRRepository
+	eventPub EventPublisher
+}
+
+// NewCreateReworkWOUseCase creates a new CreateReworkWOUseCase
+func NewCreateReworkWOUseCase(woRepo repository.WorkOrderRepository, bomRepo repository.BOMRepository, ncrRepo repository.NCRRepository, eventPub EventPublisher) *CreateReworkWOUseCase {
+	return &CreateReworkWOUseCase{
+		woRepo:   woRepo,
+		bomRepo:  bomRepo,
+		ncrRepo:  ncrRepo,
+		eventPub: eventPub,
+	}
+}
+
+// CreateReworkWOInput is the input for creating a rework work order
+type CreateReworkWOInput struct {
+	NCRID          uuid.UUID
+	BOMID          uuid.UUID // BOM of the reworked product, kept for reference
+	Quantity       float64   // Defaults to the NCR rework quantity not yet planned on other rework WOs
+	UOMID          uuid.UUID
+	BatchNumber    string // Defaults to <rejected lot>-RW
+	ProductionLine string
+	Shift          string
+	Priority       entity.WOPriority
+	Notes          string
+	CreatedBy      uuid.UUID
+}
+
+// Execute creates a rework WO that consumes the rejected lot of the NCR and
+// produces a new lot of the same product
+func (uc *CreateReworkWOUseCase) Execute(ctx context.Context, input CreateReworkWOInput) (*entity.WorkOrder, error) {
+	ncr, err := uc.ncrRepo.GetByID(ctx, input.NCRID)
+	if err != nil {
+		return nil, entity.ErrNCRNotFound
+	}
+	reworkQty, err := ncr.ReworkQuantity()
+	if err != nil {
+		return nil, entity.ErrNCRNotReworkable
+	}
+
+	// Other rework WOs of the NCR already take part of the rejected lot
+	planned, err := uc.woRepo.GetPlannedReworkQuantity(ctx, ncr.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := reworkQty - planned
+	qty := input.Quantity
+	if qty <= 0 {
+		qty = remaining
+	}
+	if qty <= 0 || qty > remaining {
+		return nil, entity.ErrReworkQtyExceeded
+	}
+
+	bom, err := uc.bomRepo.GetByID(ctx, input.BOMID)
+	if err != nil {
+		return nil, entity.ErrBOMNotFound
+	}
+	if bom.ProductID != *ncr.ProductID {
+		return nil, entity.ErrReworkProductMismatch
+	}
+
+	uomID := input.UOMID
+	if uomID == uuid.Nil && ncr.UOMID != nil {
+		uomID = *ncr.UOMID
+	}
+	batchNumber := input.BatchNumber
+	if batchNumber == "" {
+		batchNumber = ncr.LotNumber + "-RW"
+	}
+	priority := input.Priority
+	if priority == "" {
+		priority = entity.WOPriorityNormal
+	}
+
+	woNumber, err := uc.woRepo.GenerateWONumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wo := &entity.WorkOrder{
+		WONumber:        woNumber,
+		ProductID:       *ncr.ProductID,
+		BOMID:           bom.ID,
+		WOType:          entity.WOTypeRework,
+		Status:          entity.WOStatusPlanned,
+		Priority:        priority,
+		PlannedQuantity: qty,
+		UOMID:           uomID,
+		BatchNumber:     batchNumber,
+		NCRID:           &ncr.ID,
+		SourceLotID:     ncr.LotID,
+		SourceLotNumber: ncr.LotNumber,
+		ProductionLine:  input.ProductionLine,
+		Shift:           input.Shift,
+		Notes:           input.Notes,
+		CreatedBy:       &input.CreatedBy,
+		UpdatedBy:       &input.CreatedBy,
+	}
+
+	if err := uc.woRepo.Create(ctx, wo); err != nil {
+		return nil, err
+	}
+
+	// The only planned input is the rejected lot itself
+	items := []*entity.WOLineItem{{
+		WorkOrderID:     wo.ID,
+		LineNumber:      1,
+		MaterialID:      wo.ProductID,
+		PlannedQuantity: qty,
+		UOMID:           uomID,
+		Notes:           "Rework of lot " + ncr.LotNumber + " (" + ncr.NCRNumber + ")",
+	}}
+	if err := uc.woRepo.CreateLineItems(ctx, items); err != nil {
+		return nil, err
+	}
+
+	uc.eventPub.PublishWOCreated(event.WOEvent{
+		WOID:            wo.ID.String(),
+		WONumber:        wo.WONumber,
+		ProductID:       wo.ProductID.String(),
+		BOMID:           wo.BOMID.String(),
+		BatchNumber:     wo.BatchNumber,
+		PlannedQuantity: wo.PlannedQuantity,
+		Status:          string(wo.Status),
+	})
+
+	return wo, nil
+}
+
 // GetWOUseCase handles getting a work order
 type GetWOUseCase struct {
 	repo repository.WorkOrderRepository
@@ -153,13 +328,22 @@ func (uc *ListWOsUseCase) Execute(ctx context.Context, filter repository.WOFilte
 
 // ReleaseWOUseCase handles releasing a work order
 type ReleaseWOUseCase struct {
-	repo     repository.WorkOrderRepository
-	eventPub EventPublisher
+	repo        repository.WorkOrderRepository
+	routingRepo repository.RoutingRepository
+	opRepo      repository.WOOperationRepository
+	tx          repository.Transactor
+	eventPub    EventPublisher
 }
 
 // NewReleaseWOUseCase creates a new ReleaseWOUseCase
-func NewReleaseWOUseCase(repo repository.WorkOrderRepository, eventPub EventPublisher) *ReleaseWOUseCase {
-	return &ReleaseWOUseCase{repo: repo, eventPub: eventPub}
+func NewReleaseWOUseCase(repo repository.WorkOrderRepository, routingRepo repository.RoutingRepository, opRepo repository.WOOperationRepository, tx repository.Transactor, eventPub EventPublisher) *ReleaseWOUseCase {
+	return &ReleaseWOUseCase{
+		repo:        repo,
+		routingRepo: routingRepo,
+		opRepo:      opRepo,
+		tx:          tx,
+		eventPub:    eventPub,
+	}
 }
 
 // Execute releases a work order
@@ -174,7 +358,26 @@ func (uc *ReleaseWOUseCase) Execute(ctx context.Context, woID uuid.UUID, updated
 	}
 	wo.UpdatedBy = &updatedBy
 
-	if err := uc.repo.Update(ctx, wo); err != nil {
+	// Shop-floor operations come from the active routing, if any
+	routing, err := uc.routingRepo.GetActiveForProduct(ctx, wo.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	var ops []*entity.WOOperation
+	if routing != nil {
+		ops = routing.BuildWOOperations(wo)
+	}
+
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Update(ctx, wo); err != nil {
+			return err
+		}
+		if len(ops) > 0 {
+			return uc.opRepo.CreateOperations(ctx, ops)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -193,22 +396,39 @@ func (uc *ReleaseWOUseCase) Execute(ctx context.Context, woID uuid.UUID, updated
 
 // StartWOUseCase handles starting a work order
 type StartWOUseCase struct {
-	repo     repository.WorkOrderRepository
-	eventPub EventPublisher
+	repo              repository.WorkOrderRepository
+	lineClearanceRepo repository.LineClearanceRepository
+	eventPub          EventPublisher
 }
 
 // NewStartWOUseCase creates a new StartWOUseCase
-func NewStartWOUseCase(repo repository.WorkOrderRepository, eventPub EventPublisher) *StartWOUseCase {
-	return &StartWOUseCase{repo: repo, eventPub: eventPub}
+func NewStartWOUseCase(repo repository.WorkOrderRepository, lineClearanceRepo repository.LineClearanceRepository, eventPub EventPublisher) *StartWOUseCase {
+	return &StartWOUseCase{repo: repo, lineClearanceRepo: lineClearanceRepo, eventPub: eventPub}
 }
 
-// Execute starts a work order
+// Execute starts a work order. A work order assigned to a production line
+// needs a verified line clearance for that line, taken after the last batch that ran on it.
 func (uc *StartWOUseCase) Execute(ctx context.Context, woID uuid.UUID, supervisorID uuid.UUID) (*entity.WorkOrder, error) {
 	wo, err := uc.repo.GetByID(ctx, woID)
 	if err != nil {
 		return nil, entity.ErrWONotFound
 	}
 
+	if wo.ProductionLine != "" {
+		clearance, err := uc.lineClearanceRepo.GetByWorkOrder(ctx, wo.ID)
+		if err != nil || !clearance.IsReleased() || clearance.ProductionLine != wo.ProductionLine {
+			return nil, entity.ErrLineClearanceRequired
+		}
+		// The cleaning level was decided against the previous run at clearance time
+		last, err := uc.repo.GetLastStartedOnLine(ctx, wo.ProductionLine, wo.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !clearance.FollowsLastRun(last) {
+			return nil, entity.ErrLineClearanceOutdated
+		}
+	}
+
 	if err := wo.Start(supervisorID); err != nil {
 		return nil, entity.ErrWOCannotStart
 	}
@@ -234,15 +454,19 @@ func (uc *StartWOUseCase) Execute(ctx context.Context, woID uuid.UUID, superviso
 // CompleteWOUseCase handles completing a work order
 type CompleteWOUseCase struct {
 	repo      repository.WorkOrderRepository
+	bomRepo   repository.BOMRepository
 	traceRepo repository.TraceabilityRepository
+	tx        repository.Transactor
 	eventPub  EventPublisher
 }
 
 // NewCompleteWOUseCase creates a new CompleteWOUseCase
-func NewCompleteWOUseCase(repo repository.WorkOrderRepository, traceRepo repository.TraceabilityRepository, eventPub EventPublisher) *CompleteWOUseCase {
+func NewCompleteWOUseCase(repo repository.WorkOrderRepository, bomRepo repository.BOMRepository, traceRepo repository.TraceabilityRepository, tx repository.Transactor, eventPub EventPublisher) *CompleteWOUseCase {
 	return &CompleteWOUseCase{
 		repo:      repo,
+		bomRepo:   bomRepo,
 		traceRepo: traceRepo,
+		tx:        tx,
 		eventPub:  eventPub,
 	}
 }
@@ -253,35 +477,409 @@ type CompleteWOInput struct {
 	ActualQuantity   float64
 	GoodQuantity     float64
 	RejectedQuantity float64
+	Backflush        bool // Consume BOM components at standard for the actual quantity
+	Outputs          []CompleteWOOutputInput
 	Notes            string
 	UpdatedBy        uuid.UUID
 }
 
-// Execute completes a work order
+// CompleteWOOutputInput records the produced quantity of a declared co-product or by-product
+type CompleteWOOutputInput struct {
+	OutputID  uuid.UUID
+	Quantity  float64
+	LotNumber string // Defaults to the WO batch number with a CP/BP suffix
+}
+
+// Execute completes a work order. The status change, rework lot consumption,
+// backflush and outputs are saved in one transaction; events go out after commit.
 func (uc *CompleteWOUseCase) Execute(ctx context.Context, input CompleteWOInput) (*entity.WorkOrder, error) {
 	wo, err := uc.repo.GetByID(ctx, input.WOID)
 	if err != nil {
 		return nil, entity.ErrWONotFound
 	}
 
+	// Validate declared outputs before anything is saved
+	outputs, err := uc.repo.GetOutputs(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+	declared := make(map[uuid.UUID]bool, len(outputs))
+	for _, out := range outputs {
+		declared[out.ID] = true
+	}
+	for _, in := range input.Outputs {
+		if !declared[in.OutputID] || in.Quantity < 0 {
+			return nil, entity.ErrInvalidWOOutput
+		}
+	}
+
 	if err := wo.Complete(input.ActualQuantity, input.GoodQuantity, input.RejectedQuantity); err != nil {
 		return nil, entity.ErrWOCannotComplete
 	}
 	wo.Notes = input.Notes
 	wo.UpdatedBy = &input.UpdatedBy
 
-	if err := uc.repo.Update(ctx, wo); err != nil {
+	var backflushed []event.BackflushMaterial
+	var returned []event.ReturnedLot
+	var reworkedQty float64
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Update(ctx, wo); err != nil {
+			return err
+		}
+		// Materials issued so far went into the finished lot
+		if err := uc.traceRepo.UpdateProductLot(ctx, wo.ID, *wo.OutputLotID, wo.BatchNumber); err != nil {
+			return err
+		}
+		if wo.IsRework() {
+			var err error
+			reworkedQty, err = uc.consumeReworkLot(ctx, wo, input.UpdatedBy)
+			if err != nil {
+				return err
+			}
+		}
+		if input.Backflush {
+			var err error
+			backflushed, returned, err = uc.backflush(ctx, wo, input.ActualQuantity, input.UpdatedBy)
+			if err != nil {
+				return err
+			}
+		}
+		return uc.recordOutputs(ctx, wo, outputs, input.Outputs)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	if len(backflushed) > 0 {
+		uc.eventPub.PublishWOBackflushed(event.WOBackflushEvent{
+			WOID:      wo.ID.String(),
+			WONumber:  wo.WONumber,
+			Materials: backflushed,
+		})
+	}
+	if len(returned) > 0 {
+		uc.eventPub.PublishMaterialReturned(event.MaterialReturnedEvent{
+			WOID:     wo.ID.String(),
+			WONumber: wo.WONumber,
+			Items:    returned,
+		})
+	}
+
+	wo.Outputs = wo.Outputs[:0]
+	for _, out := range outputs {
+		wo.Outputs = append(wo.Outputs, *out)
+	}
+
 	// This event triggers WMS to receive finished goods
-	uc.eventPub.PublishWOCompleted(event.WOCompletedEvent{
+	completed := event.WOCompletedEvent{
 		WOID:         wo.ID.String(),
 		WONumber:     wo.WONumber,
 		ProductID:    wo.ProductID.String(),
 		BatchNumber:  wo.BatchNumber,
 		GoodQuantity: input.GoodQuantity,
+		UOMID:        wo.UOMID.String(),
+		OutputLotID:  wo.OutputLotID.String(),
+	}
+	if wo.SourceLotID != nil && reworkedQty > 0 {
+		completed.SourceLotID = wo.SourceLotID.String()
+		completed.SourceQuantity = reworkedQty
+	}
+	for _, out := range outputs {
+		if out.ActualQuantity == nil || *out.ActualQuantity <= 0 {
+			continue
+		}
+		completed.Outputs = append(completed.Outputs, event.WOOutput{
+			OutputType: string(out.OutputType),
+			ProductID:  out.ProductID.String(),
+			LotID:      out.LotID.String(),
+			LotNumber:  out.LotNumber,
+			Quantity:   *out.ActualQuantity,
+			UOMID:      out.UOMID.String(),
+		})
+	}
+	uc.eventPub.PublishWOCompleted(completed)
+
+	return wo, nil
+}
+
+// consumeReworkLot issues the rejected lot to a rework WO, links it to the new
+// lot and returns the quantity consumed
+func (uc *CompleteWOUseCase) consumeReworkLot(ctx context.Context, wo *entity.WorkOrder, userID uuid.UUID) (float64, error) {
+	if wo.SourceLotID == nil {
+		return 0, nil
+	}
+	lines, err := uc.repo.GetLineItems(ctx, wo.ID)
+	if err != nil {
+		return 0, err
+	}
+	var line *entity.WOLineItem
+	for _, l := range lines {
+		if l.MaterialID == wo.ProductID {
+			line = l
+			break
+		}
+	}
+	if line == nil || line.GetOutstandingQuantity() <= 0 {
+		return 0, nil
+	}
+
+	issueNumber, err := uc.repo.GenerateIssueNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	issue := &entity.WOMaterialIssue{
+		WorkOrderID:  wo.ID,
+		WOLineItemID: &line.ID,
+		IssueNumber:  issueNumber,
+		IssueType:    entity.MaterialIssueTypeIssue,
+		IssueDate:    time.Now(),
+		MaterialID:   wo.ProductID,
+		LotID:        *wo.SourceLotID,
+		LotNumber:    wo.SourceLotNumber,
+		Quantity:     line.GetOutstandingQuantity(),
+		UOMID:        line.UOMID,
+		IssuedBy:     &userID,
+		Notes:        "Rejected lot consumed by rework",
+	}
+	if err := uc.repo.CreateMaterialIssue(ctx, issue); err != nil {
+		return 0, err
+	}
+
+	line.IssuedQuantity += issue.Quantity
+	line.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateLineItem(ctx, line); err != nil {
+		return 0, err
+	}
+
+	err = uc.traceRepo.Create(ctx, &entity.BatchTraceability{
+		WorkOrderID:       wo.ID,
+		WOMaterialIssueID: &issue.ID,
+		MaterialID:        issue.MaterialID,
+		MaterialLotID:     issue.LotID,
+		MaterialLotNumber: issue.LotNumber,
+		MaterialQuantity:  issue.Quantity,
+		MaterialUOMID:     issue.UOMID,
+		ProductID:         wo.ProductID,
+		ProductLotID:      wo.OutputLotID,
+		ProductLotNumber:  wo.BatchNumber,
+		TraceDate:         issue.IssueDate,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return issue.Quantity, nil
+}
+
+// recordOutputs records co-product and by-product quantities and lots, and links
+// every material lot of the WO to each produced output lot
+func (uc *CompleteWOUseCase) recordOutputs(ctx context.Context, wo *entity.WorkOrder, outputs []*entity.WOOutput, inputs []CompleteWOOutputInput) error {
+	byID := make(map[uuid.UUID]CompleteWOOutputInput, len(inputs))
+	for _, in := range inputs {
+		byID[in.OutputID] = in
+	}
+
+	var produced []*entity.WOOutput
+	seq := map[entity.WOOutputType]int{}
+	for _, out := range outputs {
+		seq[out.OutputType]++
+		in, ok := byID[out.ID]
+		if !ok {
+			continue
+		}
+
+		lotNumber := in.LotNumber
+		if lotNumber == "" {
+			lotNumber = entity.OutputLotNumber(wo.BatchNumber, out.OutputType, seq[out.OutputType])
+		}
+		if err := out.Record(in.Quantity, lotNumber); err != nil {
+			return err
+		}
+		if err := uc.repo.UpdateOutput(ctx, out); err != nil {
+			return err
+		}
+		if in.Quantity > 0 {
+			produced = append(produced, out)
+		}
+	}
+	if len(produced) == 0 {
+		return nil
+	}
+
+	traces, err := uc.traceRepo.GetByWorkOrder(ctx, wo.ID)
+	if err != nil {
+		return err
+	}
+	var outputTraces []*entity.BatchTraceability
+	for _, trace := range traces {
+		if trace.ProductID != wo.ProductID {
+			continue
+		}
+		for _, out := range produced {
+			outputTraces = append(outputTraces, trace.ForOutput(out))
+		}
+	}
+	if len(outputTraces) == 0 {
+		return nil
+	}
+	return uc.traceRepo.CreateBatch(ctx, outputTraces)
+}
+
+// backflush sets each line's consumption to the standard BOM quantity for the
+// actual output (scrap included). Shortfalls are consumed from stock by WMS
+// (FEFO); surplus issued lots are returned to the warehouse. It returns the
+// consumed materials and returned lots for the WMS events.
+func (uc *CompleteWOUseCase) backflush(ctx context.Context, wo *entity.WorkOrder, actualQty float64, userID uuid.UUID) ([]event.BackflushMaterial, []event.ReturnedLot, error) {
+	bom, err := uc.bomRepo.GetByID(ctx, wo.BOMID)
+	if err != nil {
+		return nil, nil, entity.ErrBOMNotFound
+	}
+	bomItems, err := uc.bomRepo.GetLineItems(ctx, bom.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	bomItemByID := make(map[uuid.UUID]*entity.BOMLineItem, len(bomItems))
+	for _, item := range bomItems {
+		bomItemByID[item.ID] = item
+	}
+
+	lines, err := uc.repo.GetLineItems(ctx, wo.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	issues, err := uc.repo.GetMaterialIssues(ctx, wo.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var backflushed []event.BackflushMaterial
+	var returned []event.ReturnedLot
+	for _, line := range lines {
+		if line.BOMLineItemID == nil {
+			continue
+		}
+		bomItem, ok := bomItemByID[*line.BOMLineItemID]
+		if !ok {
+			continue
+		}
+
+		standard := entity.StandardRequirement(bomItem, actualQty, bom.BatchSize)
+		qty, surplus := line.Backflush(standard)
+		if qty > 0 {
+			backflushed = append(backflushed, event.BackflushMaterial{
+				WOLineItemID: line.ID.String(),
+				MaterialID:   line.MaterialID.String(),
+				Quantity:     qty,
+				UOMID:        line.UOMID.String(),
+			})
+		}
+
+		var returns []*entity.WOMaterialIssue
+		if surplus > 0 {
+			returns = line.PlanReturns(issues, surplus)
+		}
+		for _, ret := range returns {
+			ret.IssueNumber, err = uc.repo.GenerateIssueNumber(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			ret.IssuedBy = &userID
+			ret.Notes = "Surplus returned on completion"
+			if err := uc.repo.CreateMaterialIssue(ctx, ret); err != nil {
+				return nil, nil, err
+			}
+			returned = append(returned, event.ReturnedLot{
+				IssueID:     ret.ID.String(),
+				IssueNumber: ret.IssueNumber,
+				MaterialID:  ret.MaterialID.String(),
+				LotID:       ret.LotID.String(),
+				LotNumber:   ret.LotNumber,
+				Quantity:    ret.Quantity,
+				UOMID:       ret.UOMID.String(),
+			})
+		}
+
+		if qty > 0 || len(returns) > 0 {
+			if err := uc.repo.UpdateLineItem(ctx, line); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return backflushed, returned, nil
+}
+
+// CancelWOUseCase handles cancelling a work order that has not started
+type CancelWOUseCase struct {
+	repo     repository.WorkOrderRepository
+	eventPub EventPublisher
+}
+
+// NewCancelWOUseCase creates a new CancelWOUseCase
+func NewCancelWOUseCase(repo repository.WorkOrderRepository, eventPub EventPublisher) *CancelWOUseCase {
+	return &CancelWOUseCase{repo: repo, eventPub: eventPub}
+}
+
+// Execute cancels a planned or released work order
+func (uc *CancelWOUseCase) Execute(ctx context.Context, woID uuid.UUID, updatedBy uuid.UUID) (*entity.WorkOrder, error) {
+	wo, err := uc.repo.GetByID(ctx, woID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+
+	if err := wo.Cancel(); err != nil {
+		return nil, entity.ErrWOCannotCancel
+	}
+	wo.UpdatedBy = &updatedBy
+
+	if err := uc.repo.Update(ctx, wo); err != nil {
+		return nil, err
+	}
+
+	uc.eventPub.PublishWOCancelled(event.WOEvent{
+		WOID:            wo.ID.String(),
+		WONumber:        wo.WONumber,
+		ProductID:       wo.ProductID.String(),
+		BOMID:           wo.BOMID.String(),
+		BatchNumber:     wo.BatchNumber,
+		PlannedQuantity: wo.PlannedQuantity,
+		Status:          string(wo.Status),
 	})
 
 	return wo, nil
 }
+
+// GetMaterialVarianceUseCase handles the actual-vs-standard material report
+type GetMaterialVarianceUseCase struct {
+	repo    repository.WorkOrderRepository
+	bomRepo repository.BOMRepository
+}
+
+// NewGetMaterialVarianceUseCase creates a new GetMaterialVarianceUseCase
+func NewGetMaterialVarianceUseCase(repo repository.WorkOrderRepository, bomRepo repository.BOMRepository) *GetMaterialVarianceUseCase {
+	return &GetMaterialVarianceUseCase{repo: repo, bomRepo: bomRepo}
+}
+
+// Execute builds the usage/price variance report of a work order
+func (uc *GetMaterialVarianceUseCase) Execute(ctx context.Context, woID uuid.UUID) (*entity.MaterialVarianceReport, error) {
+	wo, err := uc.repo.GetByID(ctx, woID)
+	if err != nil {
+		return nil, entity.ErrWONotFound
+	}
+	bom, err := uc.bomRepo.GetByID(ctx, wo.BOMID)
+	if err != nil {
+		return nil, entity.ErrBOMNotFound
+	}
+	bomItems, err := uc.bomRepo.GetLineItems(ctx, bom.ID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := uc.repo.GetLineItems(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := uc.repo.GetMaterialIssues(ctx, wo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.BuildMaterialVarianceReport(wo, bom, bomItems, lines, issues), nil
+}
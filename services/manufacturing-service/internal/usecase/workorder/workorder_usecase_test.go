@@ -2,9 +2,11 @@ package workorder_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/erp-cosmetics/manufacturing-service/internal/domain/entity"
+	"github.com/erp-cosmetics/manufacturing-service/internal/infrastructure/event"
 	"github.com/erp-cosmetics/manufacturing-service/internal/testmocks"
 	"github.com/erp-cosmetics/manufacturing-service/internal/testutils"
 	"github.com/erp-cosmetics/manufacturing-service/internal/usecase/workorder"
@@ -62,7 +64,7 @@ func TestStartWOUseCase_Execute_TriggersWMS(t *testing.T) {
 	repo := new(testmocks.MockWorkOrderRepository)
 	eventPub := new(testmocks.MockEventPublisher)
 	
-	uc := workorder.NewStartWOUseCase(repo, eventPub)
+	uc := workorder.NewStartWOUseCase(repo, new(testmocks.MockLineClearanceRepository), eventPub)
 	woID := uuid.New()
 	targetWO := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusReleased).Build()
 	
@@ -78,3 +80,355 @@ func TestStartWOUseCase_Execute_TriggersWMS(t *testing.T) {
 	assert.Equal(t, entity.WOStatusInProgress, res.Status)
 	eventPub.AssertCalled(t, "PublishWOStarted", mock.Anything)
 }
+
+func TestStartWOUseCase_Execute_RequiresVerifiedLineClearance(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	lcRepo := new(testmocks.MockLineClearanceRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewStartWOUseCase(repo, lcRepo, eventPub)
+	targetWO := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusReleased).Build()
+	targetWO.ProductionLine = "LINE-A"
+
+	repo.On("GetByID", ctx, targetWO.ID).Return(targetWO, nil)
+	lcRepo.On("GetByWorkOrder", ctx, targetWO.ID).Return(&entity.LineClearance{
+		WorkOrderID:    targetWO.ID,
+		ProductionLine: "LINE-A",
+		Status:         entity.LineClearanceStatusCompleted, // Signed but not yet verified by QA
+	}, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, targetWO.ID, uuid.New())
+
+	// Assert
+	assert.Equal(t, entity.ErrLineClearanceRequired, err)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishWOStarted", mock.Anything)
+}
+
+func TestStartWOUseCase_Execute_RejectsClearanceOutdatedByAnotherRun(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	lcRepo := new(testmocks.MockLineClearanceRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewStartWOUseCase(repo, lcRepo, eventPub)
+	targetWO := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusReleased).Build()
+	targetWO.ProductionLine = "LINE-A"
+
+	clearedAfter := uuid.New()
+	repo.On("GetByID", ctx, targetWO.ID).Return(targetWO, nil)
+	lcRepo.On("GetByWorkOrder", ctx, targetWO.ID).Return(&entity.LineClearance{
+		WorkOrderID:         targetWO.ID,
+		ProductionLine:      "LINE-A",
+		PreviousWorkOrderID: &clearedAfter,
+		Status:              entity.LineClearanceStatusVerified,
+	}, nil)
+	// Another batch started on the line after the clearance was verified
+	repo.On("GetLastStartedOnLine", ctx, "LINE-A", targetWO.ID).Return(&entity.WorkOrder{ID: uuid.New(), ProductionLine: "LINE-A"}, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, targetWO.ID, uuid.New())
+
+	// Assert
+	assert.Equal(t, entity.ErrLineClearanceOutdated, err)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishWOStarted", mock.Anything)
+}
+
+func TestReleaseWOUseCase_Execute_RoutingLookupFailureKeepsWOPlanned(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	routingRepo := new(testmocks.MockRoutingRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewReleaseWOUseCase(repo, routingRepo, opRepo, testmocks.MockTransactor{}, eventPub)
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusPlanned).Build()
+
+	dbErr := errors.New("connection reset")
+	repo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	routingRepo.On("GetActiveForProduct", ctx, wo.ProductID).Return(nil, dbErr)
+
+	// Act
+	_, err := uc.Execute(ctx, wo.ID, uuid.New())
+
+	// Assert
+	assert.Equal(t, dbErr, err)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishWOReleased", mock.Anything)
+}
+
+func TestReleaseWOUseCase_Execute_CreatesRoutingOperations(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	routingRepo := new(testmocks.MockRoutingRepository)
+	opRepo := new(testmocks.MockWOOperationRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewReleaseWOUseCase(repo, routingRepo, opRepo, testmocks.MockTransactor{}, eventPub)
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusPlanned).Build()
+	routing := &entity.Routing{
+		BaseQuantity: 100,
+		Operations: []entity.RoutingOperation{
+			{ID: uuid.New(), Sequence: 10, OperationCode: "MIX", RunMinutes: 60},
+			{ID: uuid.New(), Sequence: 20, OperationCode: "FILL", RunMinutes: 30},
+		},
+	}
+
+	repo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	routingRepo.On("GetActiveForProduct", ctx, wo.ProductID).Return(routing, nil)
+	repo.On("Update", ctx, mock.Anything).Return(nil)
+	opRepo.On("CreateOperations", ctx, mock.MatchedBy(func(ops []*entity.WOOperation) bool {
+		return len(ops) == 2 && ops[0].OperationCode == "MIX"
+	})).Return(nil)
+	eventPub.On("PublishWOReleased", mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, wo.ID, uuid.New())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.WOStatusReleased, res.Status)
+	opRepo.AssertExpectations(t)
+}
+
+func TestCompleteWOUseCase_Execute_BackflushFailurePublishesNothing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	bomRepo := new(testmocks.MockBOMRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewCompleteWOUseCase(repo, bomRepo, traceRepo, testmocks.MockTransactor{}, eventPub)
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+
+	repo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	repo.On("GetOutputs", ctx, wo.ID).Return([]*entity.WOOutput{}, nil)
+	repo.On("Update", ctx, mock.Anything).Return(nil)
+	traceRepo.On("UpdateProductLot", ctx, wo.ID, mock.Anything, wo.BatchNumber).Return(nil)
+	bomRepo.On("GetByID", ctx, wo.BOMID).Return(nil, errors.New("connection reset"))
+
+	// Act
+	_, err := uc.Execute(ctx, workorder.CompleteWOInput{
+		WOID:           wo.ID,
+		ActualQuantity: 100,
+		GoodQuantity:   100,
+		Backflush:      true,
+		UpdatedBy:      uuid.New(),
+	})
+
+	// Assert: the transaction is rolled back and WMS hears nothing
+	assert.Equal(t, entity.ErrBOMNotFound, err)
+	eventPub.AssertNotCalled(t, "PublishWOCompleted", mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishWOBackflushed", mock.Anything)
+}
+
+func TestCompleteWOUseCase_Execute_ReworkConsumesRejectedLot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewCompleteWOUseCase(repo, new(testmocks.MockBOMRepository), traceRepo, testmocks.MockTransactor{}, eventPub)
+	rejectedLot := uuid.New()
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	wo.WOType = entity.WOTypeRework
+	wo.BatchNumber = "B2026-0007-RW"
+	wo.SourceLotID = &rejectedLot
+	wo.SourceLotNumber = "B2026-0007"
+	line := &entity.WOLineItem{ID: uuid.New(), WorkOrderID: wo.ID, MaterialID: wo.ProductID, PlannedQuantity: 40, UOMID: wo.UOMID}
+
+	repo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	repo.On("GetOutputs", ctx, wo.ID).Return([]*entity.WOOutput{}, nil)
+	repo.On("Update", ctx, mock.Anything).Return(nil)
+	repo.On("GetLineItems", ctx, wo.ID).Return([]*entity.WOLineItem{line}, nil)
+	traceRepo.On("UpdateProductLot", ctx, wo.ID, mock.Anything, "B2026-0007-RW").Return(nil)
+	repo.On("GenerateIssueNumber", ctx).Return("ISS-2026-0010", nil)
+	repo.On("CreateMaterialIssue", ctx, mock.MatchedBy(func(issue *entity.WOMaterialIssue) bool {
+		return issue.LotID == rejectedLot && issue.Quantity == 40
+	})).Return(nil)
+	repo.On("UpdateLineItem", ctx, line).Return(nil)
+	traceRepo.On("Create", ctx, mock.MatchedBy(func(trace *entity.BatchTraceability) bool {
+		return trace.MaterialLotID == rejectedLot && trace.ProductLotID != nil && *trace.ProductLotID == *wo.OutputLotID &&
+			trace.ProductLotNumber == "B2026-0007-RW"
+	})).Return(nil)
+	eventPub.On("PublishWOCompleted", mock.Anything).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, workorder.CompleteWOInput{
+		WOID:           wo.ID,
+		ActualQuantity: 38,
+		GoodQuantity:   38,
+		UpdatedBy:      uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, line.IssuedQuantity)
+	repo.AssertExpectations(t)
+	traceRepo.AssertExpectations(t)
+	eventPub.AssertCalled(t, "PublishWOCompleted", mock.MatchedBy(func(e event.WOCompletedEvent) bool {
+		return e.OutputLotID == res.OutputLotID.String() && e.SourceLotID == rejectedLot.String() && e.SourceQuantity == 40
+	}))
+}
+
+func TestCompleteWOUseCase_Execute_RecordsCoAndByProductLots(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repo := new(testmocks.MockWorkOrderRepository)
+	traceRepo := new(testmocks.MockTraceabilityRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewCompleteWOUseCase(repo, new(testmocks.MockBOMRepository), traceRepo, testmocks.MockTransactor{}, eventPub)
+	wo := testutils.NewWorkOrderBuilder().WithStatus(entity.WOStatusInProgress).Build()
+	wo.BatchNumber = "B2026-0050"
+	coProduct := &entity.WOOutput{ID: uuid.New(), WorkOrderID: wo.ID, OutputType: entity.WOOutputTypeCoProduct, ProductID: uuid.New(), PlannedQuantity: 20}
+	byProduct := &entity.WOOutput{ID: uuid.New(), WorkOrderID: wo.ID, OutputType: entity.WOOutputTypeByProduct, ProductID: uuid.New()}
+	glycerinLot := uuid.New()
+	mainTrace := &entity.BatchTraceability{WorkOrderID: wo.ID, MaterialID: uuid.New(), MaterialLotID: glycerinLot, MaterialLotNumber: "ML-001", ProductID: wo.ProductID}
+
+	repo.On("GetByID", ctx, wo.ID).Return(wo, nil)
+	repo.On("GetOutputs", ctx, wo.ID).Return([]*entity.WOOutput{coProduct, byProduct}, nil)
+	repo.On("Update", ctx, mock.Anything).Return(nil)
+	repo.On("UpdateOutput", ctx, coProduct).Return(nil)
+	repo.On("UpdateOutput", ctx, byProduct).Return(nil)
+	traceRepo.On("UpdateProductLot", ctx, wo.ID, mock.Anything, "B2026-0050").Return(nil)
+	traceRepo.On("GetByWorkOrder", ctx, wo.ID).Return([]*entity.BatchTraceability{mainTrace}, nil)
+	traceRepo.On("CreateBatch", ctx, mock.MatchedBy(func(traces []*entity.BatchTraceability) bool {
+		if len(traces) != 2 {
+			return false
+		}
+		for _, trace := range traces {
+			if trace.MaterialLotID != glycerinLot || trace.ProductLotID == nil {
+				return false
+			}
+		}
+		return *traces[0].ProductLotID == *coProduct.LotID && traces[0].ProductLotNumber == "B2026-0050-CP1" &&
+			*traces[1].ProductLotID == *byProduct.LotID && traces[1].ProductLotNumber == "B2026-0050-BP1"
+	})).Return(nil)
+	eventPub.On("PublishWOCompleted", mock.Anything).Return(nil)
+
+	// Act
+	_, err := uc.Execute(ctx, workorder.CompleteWOInput{
+		WOID:           wo.ID,
+		ActualQuantity: 100,
+		GoodQuantity:   100,
+		Outputs: []workorder.CompleteWOOutputInput{
+			{OutputID: coProduct.ID, Quantity: 18},
+			{OutputID: byProduct.ID, Quantity: 3},
+		},
+		UpdatedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, coProduct.LotID)
+	assert.NotNil(t, byProduct.LotID)
+	traceRepo.AssertExpectations(t)
+	eventPub.AssertCalled(t, "PublishWOCompleted", mock.MatchedBy(func(e event.WOCompletedEvent) bool {
+		return len(e.Outputs) == 2 &&
+			e.Outputs[0].LotID == coProduct.LotID.String() && e.Outputs[0].Quantity == 18 &&
+			e.Outputs[1].LotID == byProduct.LotID.String() && e.Outputs[1].OutputType == string(entity.WOOutputTypeByProduct)
+	}))
+}
+
+func reworkNCR(quantity float64) *entity.NCR {
+	productID, lotID := uuid.New(), uuid.New()
+	disposition := entity.DispositionRework
+	return &entity.NCR{
+		ID:                  uuid.New(),
+		NCRNumber:           "NCR-2026-0007",
+		ProductID:           &productID,
+		LotID:               &lotID,
+		LotNumber:           "FG-2605-01",
+		Disposition:         &disposition,
+		DispositionQuantity: &quantity,
+	}
+}
+
+func TestCreateReworkWOUseCase_Execute_RemainingQuantity(t *testing.T) {
+	tests := []struct {
+		name      string
+		planned   float64
+		requested float64
+		wantQty   float64
+		wantErr   error
+	}{
+		{"defaults to the whole lot", 0, 0, 500, nil},
+		{"defaults to what other rework WOs left", 300, 0, 200, nil},
+		{"takes the rest", 300, 200, 200, nil},
+		{"exceeds what other rework WOs left", 300, 250, 0, entity.ErrReworkQtyExceeded},
+		{"lot already fully planned", 500, 0, 0, entity.ErrReworkQtyExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			woRepo := new(testmocks.MockWorkOrderRepository)
+			bomRepo := new(testmocks.MockBOMRepository)
+			ncrRepo := new(testmocks.MockNCRRepository)
+			eventPub := new(testmocks.MockEventPublisher)
+
+			uc := workorder.NewCreateReworkWOUseCase(woRepo, bomRepo, ncrRepo, eventPub)
+
+			ncr := reworkNCR(500)
+			bom := testutils.NewBOMBuilder().Build()
+			bom.ProductID = *ncr.ProductID
+
+			ncrRepo.On("GetByID", ctx, ncr.ID).Return(ncr, nil)
+			woRepo.On("GetPlannedReworkQuantity", ctx, ncr.ID).Return(tt.planned, nil)
+			bomRepo.On("GetByID", ctx, bom.ID).Return(bom, nil)
+			woRepo.On("GenerateWONumber", ctx).Return("WO-2026-0042", nil)
+			woRepo.On("Create", ctx, mock.AnythingOfType("*entity.WorkOrder")).Return(nil)
+			woRepo.On("CreateLineItems", ctx, mock.Anything).Return(nil)
+			eventPub.On("PublishWOCreated", mock.Anything).Return(nil)
+
+			// Act
+			res, err := uc.Execute(ctx, workorder.CreateReworkWOInput{NCRID: ncr.ID, BOMID: bom.ID, Quantity: tt.requested, CreatedBy: uuid.New()})
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				woRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantQty, res.PlannedQuantity)
+			assert.Equal(t, entity.WOTypeRework, res.WOType)
+		})
+	}
+}
+
+func TestCreateReworkWOUseCase_Execute_BOMOfAnotherProduct(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	woRepo := new(testmocks.MockWorkOrderRepository)
+	bomRepo := new(testmocks.MockBOMRepository)
+	ncrRepo := new(testmocks.MockNCRRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := workorder.NewCreateReworkWOUseCase(woRepo, bomRepo, ncrRepo, eventPub)
+
+	ncr := reworkNCR(500)
+	bom := testutils.NewBOMBuilder().Build() // Of another product
+
+	ncrRepo.On("GetByID", ctx, ncr.ID).Return(ncr, nil)
+	woRepo.On("GetPlannedReworkQuantity", ctx, ncr.ID).Return(0.0, nil)
+	bomRepo.On("GetByID", ctx, bom.ID).Return(bom, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, workorder.CreateReworkWOInput{NCRID: ncr.ID, BOMID: bom.ID, CreatedBy: uuid.New()})
+
+	// Assert
+	assert.Equal(t, entity.ErrReworkProductMismatch, err)
+	woRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,14 @@
+-- Work Centers table
+CREATE TABLE IF NOT EXISTS work_centers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    production_line VARCHAR(50),
+    cost_per_hour DECIMAL(18,2) DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_work_centers_is_active ON work_centers(is_active);
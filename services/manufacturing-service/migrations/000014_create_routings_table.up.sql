@@ -0,0 +1,23 @@
+-- Routings table
+CREATE TABLE IF NOT EXISTS routings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    routing_number VARCHAR(50) NOT NULL UNIQUE,
+    product_id UUID NOT NULL,
+    bom_id UUID REFERENCES boms(id),
+    version INTEGER DEFAULT 1,
+    name VARCHAR(200) NOT NULL,
+    status VARCHAR(30) NOT NULL DEFAULT 'DRAFT', -- DRAFT, ACTIVE, OBSOLETE
+    base_quantity DECIMAL(15,4) NOT NULL, -- Quantity the run times are expressed for
+    notes TEXT,
+    
+    -- Audit
+    created_by UUID,
+    updated_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT chk_routing_status CHECK (status IN ('DRAFT', 'ACTIVE', 'OBSOLETE'))
+);
+
+CREATE INDEX idx_routings_product_id ON routings(product_id);
+CREATE INDEX idx_routings_status ON routings(status);
@@ -0,0 +1,27 @@
+-- Routing Operations table
+CREATE TABLE IF NOT EXISTS routing_operations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    routing_id UUID NOT NULL REFERENCES routings(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL,
+    operation_code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    operation_type VARCHAR(20) NOT NULL, -- WEIGHING, MIXING, FILLING, PACKING, OTHER
+    work_center_id UUID NOT NULL REFERENCES work_centers(id),
+    
+    -- Standard times (minutes) for routing base quantity
+    setup_minutes DECIMAL(10,2) DEFAULT 0,
+    run_minutes DECIMAL(10,2) DEFAULT 0,
+    
+    instructions TEXT,
+    process_parameters JSONB, -- [{name, unit, target, min, max, is_critical}]
+    qc_checkpoint_id UUID REFERENCES qc_checkpoints(id), -- IPQC triggered on completion
+    
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT uq_routing_operation_sequence UNIQUE (routing_id, sequence),
+    CONSTRAINT chk_operation_type CHECK (operation_type IN ('WEIGHING', 'MIXING', 'FILLING', 'PACKING', 'OTHER'))
+);
+
+CREATE INDEX idx_routing_operations_routing_id ON routing_operations(routing_id);
+CREATE INDEX idx_routing_operations_work_center_id ON routing_operations(work_center_id);
@@ -0,0 +1,42 @@
+-- Work Order Operations table
+CREATE TABLE IF NOT EXISTS wo_operations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    work_order_id UUID NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    routing_operation_id UUID REFERENCES routing_operations(id),
+    sequence INTEGER NOT NULL,
+    operation_code VARCHAR(20) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    operation_type VARCHAR(20) NOT NULL,
+    work_center_id UUID NOT NULL REFERENCES work_centers(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, IN_PROGRESS, PAUSED, COMPLETED
+    
+    -- Times (minutes)
+    standard_minutes DECIMAL(10,2) DEFAULT 0,
+    actual_minutes DECIMAL(10,2) DEFAULT 0,
+    actual_start_at TIMESTAMP,
+    actual_end_at TIMESTAMP,
+    last_resumed_at TIMESTAMP,
+    
+    started_by UUID,
+    completed_by UUID,
+    
+    -- Critical process parameters
+    parameter_specs JSONB,
+    parameter_readings JSONB,
+    has_deviation BOOLEAN DEFAULT false,
+    
+    -- QC
+    qc_checkpoint_id UUID REFERENCES qc_checkpoints(id),
+    qc_inspection_id UUID REFERENCES qc_inspections(id),
+    
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT uq_wo_operation_sequence UNIQUE (work_order_id, sequence),
+    CONSTRAINT chk_wo_operation_status CHECK (status IN ('PENDING', 'IN_PROGRESS', 'PAUSED', 'COMPLETED'))
+);
+
+CREATE INDEX idx_wo_operations_work_order_id ON wo_operations(work_order_id);
+CREATE INDEX idx_wo_operations_work_center_id ON wo_operations(work_center_id);
+CREATE INDEX idx_wo_operations_status ON wo_operations(status);
@@ -0,0 +1,15 @@
+-- Work Order Operation Logs table (who did what and when)
+CREATE TABLE IF NOT EXISTS wo_operation_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wo_operation_id UUID NOT NULL REFERENCES wo_operations(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL, -- START, PAUSE, RESUME, COMPLETE
+    operator_id UUID NOT NULL,
+    occurred_at TIMESTAMP NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT chk_operation_action CHECK (action IN ('START', 'PAUSE', 'RESUME', 'COMPLETE'))
+);
+
+CREATE INDEX idx_wo_operation_logs_operation_id ON wo_operation_logs(wo_operation_id);
+CREATE INDEX idx_wo_operation_logs_operator_id ON wo_operation_logs(operator_id);
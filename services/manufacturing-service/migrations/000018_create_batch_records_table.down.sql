@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trg_batch_records_immutable ON batch_records;
+DROP FUNCTION IF EXISTS prevent_batch_record_modification();
+DROP TABLE IF EXISTS batch_records;
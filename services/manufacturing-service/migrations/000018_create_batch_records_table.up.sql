@@ -0,0 +1,47 @@
+-- Electronic Batch Records table (eBMR, immutable once generated)
+CREATE TABLE IF NOT EXISTS batch_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    record_number VARCHAR(30) UNIQUE NOT NULL,
+    batch_number VARCHAR(50) NOT NULL,
+    work_order_id UUID NOT NULL REFERENCES work_orders(id),
+    version INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT', -- DRAFT, RELEASED, SUPERSEDED
+    
+    -- Snapshot
+    content JSONB NOT NULL,
+    content_hash VARCHAR(64) NOT NULL, -- SHA-256 of canonical content
+    generated_by UUID NOT NULL,
+    generated_at TIMESTAMP NOT NULL,
+    
+    -- Release
+    released_by UUID,
+    released_at TIMESTAMP,
+    
+    -- Audit
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT uq_batch_record_version UNIQUE (batch_number, version),
+    CONSTRAINT chk_batch_record_status CHECK (status IN ('DRAFT', 'RELEASED', 'SUPERSEDED'))
+);
+
+CREATE INDEX idx_batch_records_batch_number ON batch_records(batch_number);
+CREATE INDEX idx_batch_records_work_order_id ON batch_records(work_order_id);
+CREATE INDEX idx_batch_records_status ON batch_records(status);
+
+-- Content is immutable; released records cannot be changed at all
+CREATE OR REPLACE FUNCTION prevent_batch_record_modification() RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status = 'RELEASED' THEN
+        RAISE EXCEPTION 'batch record % is released and cannot be modified', OLD.record_number;
+    END IF;
+    IF NEW.content IS DISTINCT FROM OLD.content OR NEW.content_hash IS DISTINCT FROM OLD.content_hash THEN
+        RAISE EXCEPTION 'batch record % content is immutable', OLD.record_number;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_batch_records_immutable
+    BEFORE UPDATE ON batch_records
+    FOR EACH ROW EXECUTE FUNCTION prevent_batch_record_modification();
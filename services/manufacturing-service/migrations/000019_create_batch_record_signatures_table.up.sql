@@ -0,0 +1,17 @@
+-- Batch Record Signatures table (electronic sign-offs)
+CREATE TABLE IF NOT EXISTS batch_record_signatures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    batch_record_id UUID NOT NULL REFERENCES batch_records(id),
+    role VARCHAR(20) NOT NULL, -- PRODUCTION, QC, QA
+    signer_id UUID NOT NULL,
+    signer_name VARCHAR(100),
+    meaning VARCHAR(200),
+    content_hash VARCHAR(64) NOT NULL, -- hash of the content that was signed
+    signed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT uq_batch_record_signature_role UNIQUE (batch_record_id, role),
+    CONSTRAINT chk_batch_record_signature_role CHECK (role IN ('PRODUCTION', 'QC', 'QA'))
+);
+
+CREATE INDEX idx_batch_record_signatures_record_id ON batch_record_signatures(batch_record_id);
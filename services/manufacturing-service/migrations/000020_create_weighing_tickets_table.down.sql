@@ -0,0 +1 @@
+DROP TABLE IF EXISTS weighing_tickets;
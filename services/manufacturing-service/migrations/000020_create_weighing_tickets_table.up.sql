@@ -0,0 +1,41 @@
+-- Weighing Tickets table (dispensing per WO line)
+CREATE TABLE IF NOT EXISTS weighing_tickets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_number VARCHAR(30) UNIQUE NOT NULL,
+    work_order_id UUID NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    wo_line_item_id UUID NOT NULL REFERENCES wo_line_items(id),
+    line_number INTEGER NOT NULL,
+    material_id UUID NOT NULL,
+    uom_id UUID NOT NULL,
+    
+    -- Target and tolerance (BOM min/max scaled to WO quantity)
+    target_quantity DECIMAL(15,4) NOT NULL,
+    min_quantity DECIMAL(15,4) NOT NULL,
+    max_quantity DECIMAL(15,4) NOT NULL,
+    is_critical BOOLEAN DEFAULT false, -- requires second-person verification
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING', -- PENDING, WEIGHED, CONFIRMED, CANCELLED
+    
+    -- Accepted reading
+    lot_id UUID,
+    lot_number VARCHAR(50),
+    actual_quantity DECIMAL(15,4),
+    scale_id VARCHAR(50),
+    weighed_by UUID,
+    weighed_at TIMESTAMP,
+    verified_by UUID,
+    verified_at TIMESTAMP,
+    material_issue_id UUID REFERENCES wo_material_issues(id),
+    
+    -- Audit
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT chk_weighing_ticket_status CHECK (status IN ('PENDING', 'WEIGHED', 'CONFIRMED', 'CANCELLED')),
+    CONSTRAINT chk_weighing_ticket_tolerance CHECK (min_quantity <= target_quantity AND target_quantity <= max_quantity),
+    CONSTRAINT chk_weighing_ticket_verifier CHECK (verified_by IS NULL OR verified_by <> weighed_by)
+);
+
+CREATE INDEX idx_weighing_tickets_work_order_id ON weighing_tickets(work_order_id);
+CREATE INDEX idx_weighing_tickets_wo_line_item_id ON weighing_tickets(wo_line_item_id);
+CREATE INDEX idx_weighing_tickets_status ON weighing_tickets(status);
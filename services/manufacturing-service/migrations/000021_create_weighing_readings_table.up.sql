@@ -0,0 +1,17 @@
+-- Weighing Readings table (every scale reading, accepted or rejected)
+CREATE TABLE IF NOT EXISTS weighing_readings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    weighing_ticket_id UUID NOT NULL REFERENCES weighing_tickets(id) ON DELETE CASCADE,
+    scale_id VARCHAR(50) NOT NULL,
+    lot_id UUID NOT NULL,
+    lot_number VARCHAR(50) NOT NULL,
+    gross_weight DECIMAL(15,4) NOT NULL,
+    tare_weight DECIMAL(15,4) DEFAULT 0,
+    net_weight DECIMAL(15,4) NOT NULL,
+    in_tolerance BOOLEAN NOT NULL,
+    read_by UUID NOT NULL,
+    read_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_weighing_readings_ticket_id ON weighing_readings(weighing_ticket_id);
+CREATE INDEX idx_weighing_readings_lot_id ON weighing_readings(lot_id);
@@ -0,0 +1,3 @@
+ALTER TABLE wo_line_items
+    DROP COLUMN IF EXISTS backflushed_quantity,
+    DROP COLUMN IF EXISTS returned_quantity;
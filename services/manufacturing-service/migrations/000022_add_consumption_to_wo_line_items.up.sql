@@ -0,0 +1,5 @@
+-- Backflush and surplus return quantities on WO lines
+-- consumed = issued_quantity + backflushed_quantity - returned_quantity
+ALTER TABLE wo_line_items
+    ADD COLUMN IF NOT EXISTS backflushed_quantity DECIMAL(15,4) DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS returned_quantity DECIMAL(15,4) DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE wo_material_issues
+    DROP CONSTRAINT IF EXISTS chk_wo_material_issue_type,
+    DROP COLUMN IF EXISTS issue_type,
+    DROP COLUMN IF EXISTS unit_cost;
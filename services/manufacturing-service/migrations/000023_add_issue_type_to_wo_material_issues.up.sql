@@ -0,0 +1,5 @@
+-- Issue direction and actual lot cost on WO material issues
+ALTER TABLE wo_material_issues
+    ADD COLUMN IF NOT EXISTS issue_type VARCHAR(20) NOT NULL DEFAULT 'ISSUE', -- ISSUE, RETURN
+    ADD COLUMN IF NOT EXISTS unit_cost DECIMAL(18,4) DEFAULT 0, -- 0 = unknown, valued at standard
+    ADD CONSTRAINT chk_wo_material_issue_type CHECK (issue_type IN ('ISSUE', 'RETURN'));
@@ -0,0 +1,2 @@
+ALTER TABLE weighing_tickets DROP COLUMN IF EXISTS unit_cost;
+ALTER TABLE weighing_readings DROP COLUMN IF EXISTS unit_cost;
@@ -0,0 +1,3 @@
+-- Lot unit cost captured at weighing, carried to the material issue
+ALTER TABLE weighing_readings ADD COLUMN IF NOT EXISTS unit_cost DECIMAL(18,4) DEFAULT 0;
+ALTER TABLE weighing_tickets ADD COLUMN IF NOT EXISTS unit_cost DECIMAL(18,4) DEFAULT 0;
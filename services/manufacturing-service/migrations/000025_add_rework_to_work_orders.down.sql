@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_work_orders_source_lot_id;
+DROP INDEX IF EXISTS idx_work_orders_ncr_id;
+
+ALTER TABLE work_orders
+    DROP CONSTRAINT IF EXISTS chk_wo_rework_source,
+    DROP CONSTRAINT IF EXISTS chk_wo_type,
+    DROP COLUMN IF EXISTS source_lot_number,
+    DROP COLUMN IF EXISTS source_lot_id,
+    DROP COLUMN IF EXISTS ncr_id,
+    DROP COLUMN IF EXISTS wo_type;
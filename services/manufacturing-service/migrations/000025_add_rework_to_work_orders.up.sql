@@ -0,0 +1,11 @@
+-- Rework work orders: reprocess the rejected lot of an NCR into a new lot
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS wo_type VARCHAR(20) NOT NULL DEFAULT 'STANDARD', -- STANDARD, REWORK
+    ADD COLUMN IF NOT EXISTS ncr_id UUID REFERENCES ncrs(id),
+    ADD COLUMN IF NOT EXISTS source_lot_id UUID, -- Rejected WMS lot consumed by the rework
+    ADD COLUMN IF NOT EXISTS source_lot_number VARCHAR(50),
+    ADD CONSTRAINT chk_wo_type CHECK (wo_type IN ('STANDARD', 'REWORK')),
+    ADD CONSTRAINT chk_wo_rework_source CHECK (wo_type <> 'REWORK' OR (ncr_id IS NOT NULL AND source_lot_id IS NOT NULL));
+
+CREATE INDEX idx_work_orders_ncr_id ON work_orders(ncr_id);
+CREATE INDEX idx_work_orders_source_lot_id ON work_orders(source_lot_id);
@@ -0,0 +1,26 @@
+-- Co-products and by-products of a work order, each produced into its own lot
+CREATE TABLE IF NOT EXISTS wo_outputs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    work_order_id UUID NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    output_type VARCHAR(20) NOT NULL, -- CO_PRODUCT, BY_PRODUCT
+    product_id UUID NOT NULL,
+    planned_quantity DECIMAL(15,4) DEFAULT 0,
+    actual_quantity DECIMAL(15,4),
+    yield_percentage DECIMAL(7,2), -- actual / planned
+    uom_id UUID NOT NULL,
+    
+    -- Output lot (WMS lot id filled in after receipt)
+    lot_id UUID,
+    lot_number VARCHAR(50),
+    
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    CONSTRAINT chk_wo_output_type CHECK (output_type IN ('CO_PRODUCT', 'BY_PRODUCT')),
+    CONSTRAINT chk_wo_output_quantity CHECK (planned_quantity >= 0 AND (actual_quantity IS NULL OR actual_quantity >= 0))
+);
+
+CREATE INDEX idx_wo_outputs_work_order_id ON wo_outputs(work_order_id);
+CREATE INDEX idx_wo_outputs_product_id ON wo_outputs(product_id);
+CREATE INDEX idx_wo_outputs_lot_number ON wo_outputs(lot_number);
@@ -0,0 +1,17 @@
+UPDATE qc_checkpoints
+SET test_items = jsonb_set(test_items, '{5}', (test_items->5) - 'is_critical')
+WHERE code = 'FQC-FINISH' AND test_items->5->>'name' = 'Microbial Test';
+
+DROP INDEX IF EXISTS idx_qc_inspection_items_out_of_spec;
+
+ALTER TABLE qc_inspections
+    DROP COLUMN IF EXISTS evaluated_result;
+
+ALTER TABLE qc_inspection_items
+    DROP COLUMN IF EXISTS test_type,
+    DROP COLUMN IF EXISTS min_limit,
+    DROP COLUMN IF EXISTS max_limit,
+    DROP COLUMN IF EXISTS numeric_value,
+    DROP COLUMN IF EXISTS is_critical,
+    DROP COLUMN IF EXISTS out_of_spec,
+    DROP COLUMN IF EXISTS entered_result;
@@ -0,0 +1,19 @@
+-- Server-side evaluation of QC inspection items against checkpoint specs
+ALTER TABLE qc_inspection_items
+    ADD COLUMN IF NOT EXISTS test_type VARCHAR(20) DEFAULT 'PASS_FAIL', -- PASS_FAIL, NUMERIC
+    ADD COLUMN IF NOT EXISTS min_limit DECIMAL(18,6),
+    ADD COLUMN IF NOT EXISTS max_limit DECIMAL(18,6),
+    ADD COLUMN IF NOT EXISTS numeric_value DECIMAL(18,6),
+    ADD COLUMN IF NOT EXISTS is_critical BOOLEAN DEFAULT false,
+    ADD COLUMN IF NOT EXISTS out_of_spec BOOLEAN DEFAULT false,
+    ADD COLUMN IF NOT EXISTS entered_result VARCHAR(20); -- result as submitted by the inspector
+
+ALTER TABLE qc_inspections
+    ADD COLUMN IF NOT EXISTS evaluated_result VARCHAR(20) DEFAULT 'PENDING'; -- derived from item evaluation
+
+CREATE INDEX idx_qc_inspection_items_out_of_spec ON qc_inspection_items(inspection_id) WHERE out_of_spec;
+
+-- Microbial limits are critical for finished product release
+UPDATE qc_checkpoints
+SET test_items = jsonb_set(test_items, '{5,is_critical}', 'true'::jsonb)
+WHERE code = 'FQC-FINISH' AND test_items->5->>'name' = 'Microbial Test';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_qc_inspection_items_numeric_test;
+DROP INDEX IF EXISTS idx_qc_inspections_product_date;
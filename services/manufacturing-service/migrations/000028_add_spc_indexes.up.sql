@@ -0,0 +1,8 @@
+-- Indexes for SPC charting of numeric QC results per product and test item
+CREATE INDEX idx_qc_inspections_product_date ON qc_inspections(product_id, inspection_date);
+CREATE INDEX idx_qc_inspection_items_numeric_test ON qc_inspection_items(LOWER(test_name), inspection_id) WHERE numeric_value IS NOT NULL;
+
+-- Inspections created before inspection_date was set by the service
+UPDATE qc_inspections
+SET inspection_date = created_at
+WHERE inspection_date < '1900-01-01';
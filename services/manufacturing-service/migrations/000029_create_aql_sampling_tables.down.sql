@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS idx_qc_inspections_sampling_plan_id;
+
+ALTER TABLE qc_inspections
+    DROP COLUMN IF EXISTS defect_count,
+    DROP COLUMN IF EXISTS reject_number,
+    DROP COLUMN IF EXISTS accept_number,
+    DROP COLUMN IF EXISTS sample_code_letter,
+    DROP COLUMN IF EXISTS sampling_severity,
+    DROP COLUMN IF EXISTS sampling_plan_id,
+    DROP COLUMN IF EXISTS supplier_id;
+
+ALTER TABLE qc_checkpoints
+    DROP COLUMN IF EXISTS sampling_plan_id;
+
+DROP TABLE IF EXISTS sampling_states;
+DROP TABLE IF EXISTS aql_sampling_plans;
@@ -0,0 +1,48 @@
+-- ISO 2859-1 AQL sampling plans attached to QC checkpoints
+CREATE TABLE IF NOT EXISTS aql_sampling_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    plan_code VARCHAR(30) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    inspection_level VARCHAR(5) NOT NULL DEFAULT 'II', -- S-1..S-4, I, II, III
+    aql DECIMAL(6,3) NOT NULL,
+    subject_type VARCHAR(20) NOT NULL, -- SUPPLIER, PRODUCT, MATERIAL: history used for switching
+    is_active BOOLEAN DEFAULT true,
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_aql_sampling_subject CHECK (subject_type IN ('SUPPLIER', 'PRODUCT', 'MATERIAL'))
+);
+
+-- Normal/tightened/reduced switching state per plan and supplier/product
+CREATE TABLE IF NOT EXISTS sampling_states (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sampling_plan_id UUID NOT NULL REFERENCES aql_sampling_plans(id),
+    subject_type VARCHAR(20) NOT NULL,
+    subject_id UUID NOT NULL,
+    severity VARCHAR(20) NOT NULL DEFAULT 'NORMAL', -- NORMAL, TIGHTENED, REDUCED, DISCONTINUED
+    recent_results VARCHAR(10), -- A/R of the last lots, newest last
+    consecutive_accepted INTEGER DEFAULT 0,
+    switching_score INTEGER DEFAULT 0,
+    tightened_rejections INTEGER DEFAULT 0,
+    lots_inspected INTEGER DEFAULT 0,
+    last_switched_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_sampling_states_plan_subject ON sampling_states(sampling_plan_id, subject_type, subject_id);
+
+ALTER TABLE qc_checkpoints
+    ADD COLUMN IF NOT EXISTS sampling_plan_id UUID REFERENCES aql_sampling_plans(id);
+
+ALTER TABLE qc_inspections
+    ADD COLUMN IF NOT EXISTS supplier_id UUID,
+    ADD COLUMN IF NOT EXISTS sampling_plan_id UUID REFERENCES aql_sampling_plans(id),
+    ADD COLUMN IF NOT EXISTS sampling_severity VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS sample_code_letter VARCHAR(2),
+    ADD COLUMN IF NOT EXISTS accept_number INTEGER,
+    ADD COLUMN IF NOT EXISTS reject_number INTEGER,
+    ADD COLUMN IF NOT EXISTS defect_count INTEGER;
+
+CREATE INDEX idx_qc_inspections_sampling_plan_id ON qc_inspections(sampling_plan_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS supplier_coa_values;
+DROP TABLE IF EXISTS supplier_coas;
+DROP TABLE IF EXISTS certificates_of_analysis;
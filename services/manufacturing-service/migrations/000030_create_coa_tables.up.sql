@@ -0,0 +1,66 @@
+-- Certificates of Analysis issued for finished lots from approved FQC inspections
+CREATE TABLE IF NOT EXISTS certificates_of_analysis (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    coa_number VARCHAR(30) UNIQUE NOT NULL, -- COA-YYYY-XXXX
+    inspection_id UUID NOT NULL REFERENCES qc_inspections(id),
+    inspection_number VARCHAR(30),
+    product_id UUID,
+    lot_id UUID NOT NULL, -- WMS lot
+    lot_number VARCHAR(50),
+    work_order_id UUID REFERENCES work_orders(id),
+    quantity DECIMAL(15,4),
+    results JSONB NOT NULL, -- Snapshot of certified test results
+    conclusion VARCHAR(30) NOT NULL, -- CONFORMS, CONFORMS_CONDITIONALLY
+    approved_at TIMESTAMP,
+    issued_by UUID NOT NULL,
+    issued_by_name VARCHAR(100),
+    issued_at TIMESTAMP NOT NULL,
+    pdf_file_id UUID, -- file-service
+    json_file_id UUID, -- file-service
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_coas_lot_id ON certificates_of_analysis(lot_id);
+CREATE INDEX idx_coas_product_id ON certificates_of_analysis(product_id);
+CREATE INDEX idx_coas_inspection_id ON certificates_of_analysis(inspection_id);
+
+-- Supplier CoAs captured at goods receipt and compared with IQC specs
+CREATE TABLE IF NOT EXISTS supplier_coas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    grn_id UUID NOT NULL, -- WMS GRN
+    grn_number VARCHAR(30),
+    grn_line_item_id UUID,
+    supplier_id UUID NOT NULL,
+    material_id UUID NOT NULL,
+    lot_id UUID,
+    supplier_lot_number VARCHAR(50),
+    certificate_number VARCHAR(50),
+    issue_date DATE,
+    checkpoint_id UUID NOT NULL REFERENCES qc_checkpoints(id),
+    document_file_id UUID, -- Scanned certificate in file-service
+    status VARCHAR(20) NOT NULL, -- CONFORMING, NON_CONFORMING, INCOMPLETE
+    notes TEXT,
+    received_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_supplier_coas_grn_id ON supplier_coas(grn_id);
+CREATE INDEX idx_supplier_coas_lot_id ON supplier_coas(lot_id);
+CREATE INDEX idx_supplier_coas_material_supplier ON supplier_coas(material_id, supplier_id);
+
+CREATE TABLE IF NOT EXISTS supplier_coa_values (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    supplier_coa_id UUID NOT NULL REFERENCES supplier_coas(id) ON DELETE CASCADE,
+    test_name VARCHAR(100) NOT NULL,
+    reported_value VARCHAR(100),
+    numeric_value DECIMAL(18,6),
+    uom VARCHAR(20),
+    specification VARCHAR(200),
+    min_limit DECIMAL(18,6),
+    max_limit DECIMAL(18,6),
+    result VARCHAR(20) NOT NULL, -- PASS, FAIL, N/A
+    out_of_spec BOOLEAN DEFAULT false,
+    missing BOOLEAN DEFAULT false -- Specified by us, not reported by the supplier
+);
+
+CREATE INDEX idx_supplier_coa_values_coa_id ON supplier_coa_values(supplier_coa_id);
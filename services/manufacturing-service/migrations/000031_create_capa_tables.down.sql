@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS capa_links;
+DROP TABLE IF EXISTS capa_actions;
+DROP TABLE IF EXISTS capas;
@@ -0,0 +1,58 @@
+-- CAPA (corrective and preventive action) records linked to NCRs,
+-- supplier evaluations and customer complaints
+CREATE TABLE IF NOT EXISTS capas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    capa_number VARCHAR(30) UNIQUE NOT NULL, -- CAPA-YYYY-XXXX
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    root_cause TEXT,
+    status VARCHAR(30) DEFAULT 'OPEN', -- OPEN, IN_PROGRESS, PENDING_VERIFICATION, CLOSED
+    owner_id UUID NOT NULL,
+    due_date DATE,
+    effectiveness_criteria TEXT,
+    verification_due_date DATE,
+    is_effective BOOLEAN,
+    verified_by UUID,
+    verified_at TIMESTAMP,
+    verification_notes TEXT,
+    closed_at TIMESTAMP,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_capas_status ON capas(status);
+CREATE INDEX idx_capas_owner_id ON capas(owner_id);
+
+CREATE TABLE IF NOT EXISTS capa_actions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    capa_id UUID NOT NULL REFERENCES capas(id) ON DELETE CASCADE,
+    action_type VARCHAR(20) NOT NULL, -- CORRECTIVE, PREVENTIVE
+    description TEXT NOT NULL,
+    owner_id UUID NOT NULL,
+    due_date DATE NOT NULL,
+    is_critical BOOLEAN DEFAULT false, -- Blocks closing linked NCRs while open
+    status VARCHAR(20) DEFAULT 'OPEN', -- OPEN, COMPLETED, CANCELLED
+    completed_at TIMESTAMP,
+    completed_by UUID,
+    completion_notes TEXT,
+    last_reminded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_capa_actions_capa_id ON capa_actions(capa_id);
+CREATE INDEX idx_capa_actions_open_due ON capa_actions(due_date) WHERE status = 'OPEN';
+
+CREATE TABLE IF NOT EXISTS capa_links (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    capa_id UUID NOT NULL REFERENCES capas(id) ON DELETE CASCADE,
+    source_type VARCHAR(30) NOT NULL, -- NCR, SUPPLIER_EVALUATION, CUSTOMER_COMPLAINT
+    source_id UUID NOT NULL,
+    source_reference VARCHAR(50),
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(capa_id, source_type, source_id)
+);
+
+CREATE INDEX idx_capa_links_source ON capa_links(source_type, source_id);
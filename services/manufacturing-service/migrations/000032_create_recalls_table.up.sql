@@ -0,0 +1,31 @@
+-- Product recalls and mock recalls traced from a suspect supplier material lot
+CREATE TABLE IF NOT EXISTS recalls (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    recall_number VARCHAR(30) UNIQUE NOT NULL, -- RCL-YYYY-XXXX
+    mode VARCHAR(10) NOT NULL, -- LIVE, MOCK
+    status VARCHAR(20) DEFAULT 'OPEN', -- OPEN, COMPLETED
+    reason TEXT NOT NULL,
+    material_lot_id UUID NOT NULL,
+    material_lot_number VARCHAR(50),
+    material_id UUID,
+    supplier_lot_number VARCHAR(100),
+    affected_lot_count INTEGER DEFAULT 0,
+    customer_count INTEGER DEFAULT 0,
+    shipped_quantity DECIMAL(15,4) DEFAULT 0,
+    stock_quantity DECIMAL(15,4) DEFAULT 0,
+    report JSONB NOT NULL, -- lots, customers with contacts, stock to block
+    stock_blocked BOOLEAN DEFAULT false,
+    started_at TIMESTAMP NOT NULL,
+    traced_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    duration_seconds BIGINT,
+    within_target BOOLEAN, -- mock recalls only
+    completion_notes TEXT,
+    initiated_by UUID NOT NULL,
+    completed_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_recalls_material_lot_id ON recalls(material_lot_id);
+CREATE INDEX idx_recalls_mode_status ON recalls(mode, status);
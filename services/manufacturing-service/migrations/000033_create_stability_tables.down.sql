@@ -0,0 +1,13 @@
+ALTER TABLE qc_checkpoints
+    DROP CONSTRAINT IF EXISTS chk_checkpoint_type,
+    ADD CONSTRAINT chk_checkpoint_type CHECK (checkpoint_type IN ('IQC', 'IPQC', 'FQC'));
+
+ALTER TABLE qc_inspections
+    DROP CONSTRAINT IF EXISTS chk_inspection_type,
+    DROP CONSTRAINT IF EXISTS chk_reference_type,
+    ADD CONSTRAINT chk_inspection_type CHECK (inspection_type IN ('IQC', 'IPQC', 'FQC')),
+    ADD CONSTRAINT chk_reference_type CHECK (reference_type IN ('WORK_ORDER', 'GRN', 'LOT'));
+
+DROP TABLE IF EXISTS stability_pull_points;
+DROP TABLE IF EXISTS stability_conditions;
+DROP TABLE IF EXISTS stability_studies;
@@ -0,0 +1,75 @@
+-- Stability studies: storage conditions and pull points with scheduled QC inspections
+CREATE TABLE IF NOT EXISTS stability_studies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    study_number VARCHAR(30) UNIQUE NOT NULL, -- STB-YYYY-XXXX
+    product_id UUID NOT NULL,
+    bom_id UUID NOT NULL REFERENCES boms(id),
+    bom_version INTEGER NOT NULL,
+    work_order_id UUID REFERENCES work_orders(id),
+    lot_id UUID,
+    lot_number VARCHAR(50) NOT NULL,
+    checkpoint_id UUID NOT NULL REFERENCES qc_checkpoints(id),
+    sample_quantity DECIMAL(15,4) NOT NULL, -- Units pulled per pull point
+    start_date DATE NOT NULL,
+    status VARCHAR(20) DEFAULT 'ACTIVE', -- ACTIVE, COMPLETED
+    purpose TEXT,
+    recommended_shelf_life_months INTEGER,
+    evaluation JSONB, -- trends and extrapolation limits behind the recommendation
+    evaluated_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    completed_by UUID,
+    notes TEXT,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_stability_status CHECK (status IN ('ACTIVE', 'COMPLETED'))
+);
+
+CREATE INDEX idx_stability_studies_product_id ON stability_studies(product_id);
+CREATE INDEX idx_stability_studies_bom_id ON stability_studies(bom_id);
+
+CREATE TABLE IF NOT EXISTS stability_conditions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    study_id UUID NOT NULL REFERENCES stability_studies(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL, -- e.g. 25°C/60%RH
+    condition_type VARCHAR(20) NOT NULL, -- LONG_TERM, INTERMEDIATE, ACCELERATED
+    temperature_c DECIMAL(5,2) NOT NULL,
+    humidity_rh DECIMAL(5,2),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT chk_stability_condition_type CHECK (condition_type IN ('LONG_TERM', 'INTERMEDIATE', 'ACCELERATED'))
+);
+
+CREATE INDEX idx_stability_conditions_study_id ON stability_conditions(study_id);
+
+CREATE TABLE IF NOT EXISTS stability_pull_points (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    study_id UUID NOT NULL REFERENCES stability_studies(id) ON DELETE CASCADE,
+    condition_id UUID NOT NULL REFERENCES stability_conditions(id) ON DELETE CASCADE,
+    month INTEGER NOT NULL, -- 0 = initial
+    scheduled_date DATE NOT NULL,
+    status VARCHAR(20) DEFAULT 'SCHEDULED', -- SCHEDULED, TESTED
+    inspection_id UUID NOT NULL REFERENCES qc_inspections(id),
+    inspection_number VARCHAR(30),
+    tested_at TIMESTAMP,
+    tested_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_stability_pull_point UNIQUE (condition_id, month)
+);
+
+CREATE INDEX idx_stability_pull_points_study_id ON stability_pull_points(study_id);
+CREATE INDEX idx_stability_pull_points_scheduled ON stability_pull_points(status, scheduled_date);
+
+-- Pull point inspections reference the study
+ALTER TABLE qc_inspections
+    DROP CONSTRAINT IF EXISTS chk_inspection_type,
+    DROP CONSTRAINT IF EXISTS chk_reference_type,
+    ADD CONSTRAINT chk_inspection_type CHECK (inspection_type IN ('IQC', 'IPQC', 'FQC', 'STABILITY')),
+    ADD CONSTRAINT chk_reference_type CHECK (reference_type IN ('WORK_ORDER', 'GRN', 'LOT', 'STABILITY_STUDY'));
+
+ALTER TABLE qc_checkpoints
+    DROP CONSTRAINT IF EXISTS chk_checkpoint_type,
+    ADD CONSTRAINT chk_checkpoint_type CHECK (checkpoint_type IN ('IQC', 'IPQC', 'FQC', 'STABILITY'));
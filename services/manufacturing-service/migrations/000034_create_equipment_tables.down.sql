@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS equipment_maintenance_records;
+DROP TABLE IF EXISTS equipment_maintenance_plans;
+DROP TABLE IF EXISTS equipment_calibrations;
+DROP TABLE IF EXISTS equipment;
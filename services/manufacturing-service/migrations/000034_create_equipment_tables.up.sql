@@ -0,0 +1,76 @@
+-- Equipment registry with calibration certificates and preventive maintenance plans.
+-- Scales are matched to weighing readings by code (weighing_readings.scale_id).
+CREATE TABLE IF NOT EXISTS equipment (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(30) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    equipment_type VARCHAR(20) NOT NULL, -- SCALE, MIXER, HOMOGENIZER, FILLING_LINE, TANK, OTHER
+    production_line VARCHAR(50),
+    work_center_id UUID REFERENCES work_centers(id),
+    manufacturer VARCHAR(100),
+    model VARCHAR(100),
+    serial_number VARCHAR(100),
+    status VARCHAR(20) DEFAULT 'ACTIVE', -- ACTIVE, OUT_OF_SERVICE, RETIRED
+    requires_calibration BOOLEAN DEFAULT true,
+    calibration_interval_days INTEGER DEFAULT 0,
+    last_calibration_date DATE,
+    calibration_due_date DATE,
+    last_calibration_alert_at TIMESTAMP,
+    responsible_user_id UUID,
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_equipment_type CHECK (equipment_type IN ('SCALE', 'MIXER', 'HOMOGENIZER', 'FILLING_LINE', 'TANK', 'OTHER')),
+    CONSTRAINT chk_equipment_status CHECK (status IN ('ACTIVE', 'OUT_OF_SERVICE', 'RETIRED'))
+);
+
+CREATE INDEX idx_equipment_work_center_id ON equipment(work_center_id);
+CREATE INDEX idx_equipment_production_line ON equipment(production_line);
+CREATE INDEX idx_equipment_calibration_due ON equipment(calibration_due_date) WHERE requires_calibration = true;
+
+CREATE TABLE IF NOT EXISTS equipment_calibrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    equipment_id UUID NOT NULL REFERENCES equipment(id) ON DELETE CASCADE,
+    certificate_number VARCHAR(50) NOT NULL,
+    calibration_date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    result VARCHAR(10) NOT NULL, -- PASS, FAIL
+    calibrated_by VARCHAR(200), -- Lab or service provider
+    certificate_url VARCHAR(500),
+    notes TEXT,
+    recorded_by UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_calibration_result CHECK (result IN ('PASS', 'FAIL'))
+);
+
+CREATE INDEX idx_equipment_calibrations_equipment_id ON equipment_calibrations(equipment_id);
+
+CREATE TABLE IF NOT EXISTS equipment_maintenance_plans (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    equipment_id UUID NOT NULL REFERENCES equipment(id) ON DELETE CASCADE,
+    name VARCHAR(200) NOT NULL,
+    tasks TEXT,
+    interval_days INTEGER NOT NULL CHECK (interval_days > 0),
+    last_performed_date DATE,
+    next_due_date DATE NOT NULL,
+    is_active BOOLEAN DEFAULT true,
+    last_alert_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_equipment_maintenance_plans_equipment_id ON equipment_maintenance_plans(equipment_id);
+CREATE INDEX idx_equipment_maintenance_plans_due ON equipment_maintenance_plans(next_due_date) WHERE is_active = true;
+
+CREATE TABLE IF NOT EXISTS equipment_maintenance_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    plan_id UUID NOT NULL REFERENCES equipment_maintenance_plans(id) ON DELETE CASCADE,
+    equipment_id UUID NOT NULL REFERENCES equipment(id) ON DELETE CASCADE,
+    performed_date DATE NOT NULL,
+    performed_by UUID NOT NULL,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_equipment_maintenance_records_equipment_id ON equipment_maintenance_records(equipment_id);
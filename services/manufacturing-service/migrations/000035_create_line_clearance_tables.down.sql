@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_work_orders_line_started;
+DROP TABLE IF EXISTS line_clearance_items;
+DROP TABLE IF EXISTS line_clearances;
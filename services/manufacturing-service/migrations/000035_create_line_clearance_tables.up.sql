@@ -0,0 +1,51 @@
+-- Cleaning and line clearance records. A work order on a production line can only
+-- start once its clearance is completed by the performer and verified by QA.
+CREATE TABLE IF NOT EXISTS line_clearances (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    clearance_number VARCHAR(30) UNIQUE NOT NULL,
+    production_line VARCHAR(50) NOT NULL,
+    work_order_id UUID NOT NULL UNIQUE REFERENCES work_orders(id),
+    product_id UUID NOT NULL,
+    batch_number VARCHAR(50),
+    contains_allergen BOOLEAN DEFAULT false,
+    previous_work_order_id UUID REFERENCES work_orders(id),
+    previous_product_id UUID,
+    previous_batch_number VARCHAR(50),
+    previous_contains_allergen BOOLEAN DEFAULT false,
+    required_level VARCHAR(20) NOT NULL, -- MINOR, STANDARD, MAJOR
+    cleaning_level VARCHAR(20) NOT NULL, -- Performed level, never below required
+    level_reason VARCHAR(200),
+    status VARCHAR(20) DEFAULT 'OPEN', -- OPEN, COMPLETED, VERIFIED
+    completed_by UUID,
+    completed_at TIMESTAMP,
+    verified_by UUID,
+    verified_at TIMESTAMP,
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_line_clearance_required_level CHECK (required_level IN ('MINOR', 'STANDARD', 'MAJOR')),
+    CONSTRAINT chk_line_clearance_cleaning_level CHECK (cleaning_level IN ('MINOR', 'STANDARD', 'MAJOR')),
+    CONSTRAINT chk_line_clearance_status CHECK (status IN ('OPEN', 'COMPLETED', 'VERIFIED')),
+    CONSTRAINT chk_line_clearance_signers CHECK (verified_by IS NULL OR verified_by <> completed_by)
+);
+
+CREATE INDEX idx_line_clearances_production_line ON line_clearances(production_line);
+CREATE INDEX idx_line_clearances_status ON line_clearances(status);
+
+CREATE TABLE IF NOT EXISTS line_clearance_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    line_clearance_id UUID NOT NULL REFERENCES line_clearances(id) ON DELETE CASCADE,
+    section VARCHAR(20) NOT NULL, -- CLEANING, CLEARANCE
+    sequence INTEGER NOT NULL,
+    description VARCHAR(300) NOT NULL,
+    is_checked BOOLEAN DEFAULT false,
+    checked_by UUID,
+    checked_at TIMESTAMP,
+    remarks TEXT,
+    CONSTRAINT chk_line_clearance_item_section CHECK (section IN ('CLEANING', 'CLEARANCE'))
+);
+
+CREATE INDEX idx_line_clearance_items_clearance_id ON line_clearance_items(line_clearance_id);
+
+CREATE INDEX idx_work_orders_line_started ON work_orders(production_line, actual_start_date DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS wo_cost_lines;
+DROP TABLE IF EXISTS wo_costs;
+DROP TABLE IF EXISTS line_overhead_rates;
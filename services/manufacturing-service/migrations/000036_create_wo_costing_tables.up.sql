@@ -0,0 +1,60 @@
+-- Overhead absorption rate per production line, charged per operation hour
+CREATE TABLE IF NOT EXISTS line_overhead_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    production_line VARCHAR(50) UNIQUE NOT NULL,
+    rate_per_hour DECIMAL(18,2) NOT NULL,
+    notes TEXT,
+    updated_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_line_overhead_rate CHECK (rate_per_hour >= 0)
+);
+
+-- Actual cost of a completed work order with its variance against the BOM standard
+CREATE TABLE IF NOT EXISTS wo_costs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    work_order_id UUID NOT NULL UNIQUE REFERENCES work_orders(id),
+    wo_number VARCHAR(30) NOT NULL,
+    product_id UUID NOT NULL,
+    bom_id UUID NOT NULL,
+    batch_number VARCHAR(50),
+    output_lot_id UUID,
+    output_quantity DECIMAL(15,4) NOT NULL, -- Good quantity
+    material_cost DECIMAL(18,2) DEFAULT 0,
+    labor_cost DECIMAL(18,2) DEFAULT 0,
+    overhead_cost DECIMAL(18,2) DEFAULT 0,
+    total_cost DECIMAL(18,2) DEFAULT 0,
+    unit_cost DECIMAL(18,4) DEFAULT 0,
+    labor_hours DECIMAL(10,2) DEFAULT 0,
+    standard_material_cost DECIMAL(18,2) DEFAULT 0,
+    standard_labor_cost DECIMAL(18,2) DEFAULT 0,
+    standard_overhead_cost DECIMAL(18,2) DEFAULT 0,
+    standard_total_cost DECIMAL(18,2) DEFAULT 0,
+    standard_unit_cost DECIMAL(18,4) DEFAULT 0,
+    material_variance DECIMAL(18,2) DEFAULT 0,
+    labor_variance DECIMAL(18,2) DEFAULT 0,
+    overhead_variance DECIMAL(18,2) DEFAULT 0,
+    total_variance DECIMAL(18,2) DEFAULT 0,
+    variance_percent DECIMAL(8,2) DEFAULT 0,
+    calculated_by UUID,
+    calculated_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS wo_cost_lines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wo_cost_id UUID NOT NULL REFERENCES wo_costs(id) ON DELETE CASCADE,
+    cost_element VARCHAR(20) NOT NULL, -- MATERIAL, LABOR, OVERHEAD
+    reference_id UUID, -- WO line item or WO operation
+    reference VARCHAR(100),
+    quantity DECIMAL(15,4),
+    rate DECIMAL(18,4),
+    amount DECIMAL(18,2),
+    CONSTRAINT chk_wo_cost_line_element CHECK (cost_element IN ('MATERIAL', 'LABOR', 'OVERHEAD'))
+);
+
+-- Indexes
+CREATE INDEX idx_wo_costs_product ON wo_costs(product_id);
+CREATE INDEX idx_wo_costs_batch ON wo_costs(batch_number);
+CREATE INDEX idx_wo_cost_lines_cost ON wo_cost_lines(wo_cost_id);
@@ -17,6 +17,7 @@ import (
 	// entity import removed - using SQL migrations instead of auto-migrate
 	"github.com/erp-cosmetics/marketing-service/internal/infrastructure/event"
 	"github.com/erp-cosmetics/marketing-service/internal/infrastructure/persistence/postgres"
+	"github.com/erp-cosmetics/marketing-service/internal/infrastructure/subscriber"
 	campaignuc "github.com/erp-cosmetics/marketing-service/internal/usecase/campaign"
 	koluc "github.com/erp-cosmetics/marketing-service/internal/usecase/kol"
 	sampleuc "github.com/erp-cosmetics/marketing-service/internal/usecase/sample"
@@ -85,6 +86,7 @@ func main() {
 	sampleRequestRepo := postgres.NewSampleRequestRepository(db)
 	sampleShipmentRepo := postgres.NewSampleShipmentRepository(db)
 	kolPostRepo := postgres.NewKOLPostRepository(db)
+	transactor := postgres.NewTransactor(db)
 
 	// Initialize use cases - KOL
 	createKOLUC := koluc.NewCreateKOLUseCase(kolRepo, publisher)
@@ -99,6 +101,7 @@ func main() {
 	listCampaignsUC := campaignuc.NewListCampaignsUseCase(campaignRepo)
 	launchCampaignUC := campaignuc.NewLaunchCampaignUseCase(campaignRepo, publisher)
 	updateCampaignUC := campaignuc.NewUpdateCampaignUseCase(campaignRepo)
+	recordPromotionUsageUC := campaignuc.NewRecordPromotionUsageUseCase(campaignRepo, transactor)
 
 	// Initialize use cases - Sample
 	createSampleRequestUC := sampleuc.NewCreateSampleRequestUseCase(sampleRequestRepo, kolRepo, publisher)
@@ -123,6 +126,12 @@ func main() {
 		sampleShipmentRepo,
 	)
 
+	// Start event subscriber
+	eventSub := subscriber.NewEventSubscriber(nc, zapLogger, recordPromotionUsageUC)
+	if err := eventSub.Start(); err != nil {
+		zapLogger.Warn("Failed to start event subscriber", zap.Error(err))
+	}
+
 	// Create HTTP router
 	router := httpdelivery.NewRouter(kolHandler, campaignHandler, sampleHandler)
 
@@ -180,6 +189,7 @@ func main() {
 	defer cancel()
 
 	zapLogger.Info("Shutting down servers...")
+	eventSub.Stop()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		zapLogger.Error("HTTP server shutdown error", zap.Error(err))
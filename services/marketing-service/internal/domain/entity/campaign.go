@@ -134,3 +134,20 @@ func (c *Campaign) IsActive() bool {
 		now.After(c.StartDate) && 
 		now.Before(c.EndDate)
 }
+
+// PromotionUsage records a sales promotion charged to a campaign for an order,
+// so that a redelivered event is not counted twice
+type PromotionUsage struct {
+	SOID        uuid.UUID  `json:"so_id" gorm:"type:uuid;primary_key"`
+	PromotionID uuid.UUID  `json:"promotion_id" gorm:"type:uuid;primary_key"`
+	CampaignID  uuid.UUID  `json:"campaign_id" gorm:"type:uuid;not null"`
+	Cost        float64    `json:"cost" gorm:"type:decimal(18,2)"`
+	OrderAmount float64    `json:"order_amount" gorm:"type:decimal(18,2)"`
+	ReleasedAt  *time.Time `json:"released_at"` // The order was cancelled
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (PromotionUsage) TableName() string {
+	return "campaign_promotion_usages"
+}
@@ -33,6 +33,11 @@ type CampaignRepository interface {
 	// Performance updates
 	UpdatePerformance(ctx context.Context, id uuid.UUID, impressions, reach, engagement, conversions int, revenue float64) error
 	IncrementSpent(ctx context.Context, id uuid.UUID, amount float64) error
+	IncrementResults(ctx context.Context, id uuid.UUID, conversions int, revenue float64) error
+
+	// Promotion usage - report false when the usage was already recorded or released
+	RecordPromotionUsage(ctx context.Context, usage *entity.PromotionUsage) (bool, error)
+	ReleasePromotionUsage(ctx context.Context, soID, promotionID uuid.UUID) (bool, error)
 	
 	// Code generation
 	GenerateCampaignCode(ctx context.Context, prefix string) (string, error)
@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// Transactor runs several repository calls in one database transaction
+type Transactor interface {
+	// WithinTransaction runs fn with a context that carries the transaction;
+	// repositories called with that context join it. Nested calls join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
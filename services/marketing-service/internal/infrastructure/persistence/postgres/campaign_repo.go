@@ -22,12 +22,12 @@ func NewCampaignRepository(db *gorm.DB) *CampaignRepository {
 }
 
 func (r *CampaignRepository) Create(ctx context.Context, campaign *entity.Campaign) error {
-	return r.db.WithContext(ctx).Create(campaign).Error
+	return conn(ctx, r.db).Create(campaign).Error
 }
 
 func (r *CampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Campaign, error) {
 	var campaign entity.Campaign
-	err := r.db.WithContext(ctx).First(&campaign, "id = ?", id).Error
+	err := conn(ctx, r.db).First(&campaign, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +36,7 @@ func (r *CampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity
 
 func (r *CampaignRepository) GetByCode(ctx context.Context, code string) (*entity.Campaign, error) {
 	var campaign entity.Campaign
-	err := r.db.WithContext(ctx).First(&campaign, "campaign_code = ?", code).Error
+	err := conn(ctx, r.db).First(&campaign, "campaign_code = ?", code).Error
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +47,7 @@ func (r *CampaignRepository) List(ctx context.Context, filter *repository.Campai
 	var campaigns []*entity.Campaign
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Campaign{})
+	query := conn(ctx, r.db).Model(&entity.Campaign{})
 
 	if filter.Search != "" {
 		search := "%" + filter.Search + "%"
@@ -81,20 +81,20 @@ func (r *CampaignRepository) List(ctx context.Context, filter *repository.Campai
 
 func (r *CampaignRepository) Update(ctx context.Context, campaign *entity.Campaign) error {
 	campaign.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(campaign).Error
+	return conn(ctx, r.db).Save(campaign).Error
 }
 
 func (r *CampaignRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Campaign{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.Campaign{}, "id = ?", id).Error
 }
 
 func (r *CampaignRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.CampaignStatus) error {
-	return r.db.WithContext(ctx).Model(&entity.Campaign{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.Campaign{}).Where("id = ?", id).
 		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error
 }
 
 func (r *CampaignRepository) UpdatePerformance(ctx context.Context, id uuid.UUID, impressions, reach, engagement, conversions int, revenue float64) error {
-	return r.db.WithContext(ctx).Model(&entity.Campaign{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.Campaign{}).Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"impressions":       impressions,
 			"reach":             reach,
@@ -106,14 +106,45 @@ func (r *CampaignRepository) UpdatePerformance(ctx context.Context, id uuid.UUID
 }
 
 func (r *CampaignRepository) IncrementSpent(ctx context.Context, id uuid.UUID, amount float64) error {
-	return r.db.WithContext(ctx).Model(&entity.Campaign{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.Campaign{}).Where("id = ?", id).
 		UpdateColumn("spent", gorm.Expr("spent + ?", amount)).Error
 }
 
+func (r *CampaignRepository) IncrementResults(ctx context.Context, id uuid.UUID, conversions int, revenue float64) error {
+	return conn(ctx, r.db).Model(&entity.Campaign{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"conversions":       gorm.Expr("conversions + ?", conversions),
+			"revenue_generated": gorm.Expr("revenue_generated + ?", revenue),
+		}).Error
+}
+
+// RecordPromotionUsage stores the usage of a promotion on an order, or takes
+// back its release when the promotion is applied to the order again
+func (r *CampaignRepository) RecordPromotionUsage(ctx context.Context, usage *entity.PromotionUsage) (bool, error) {
+	res := conn(ctx, r.db).Exec(`
+		INSERT INTO campaign_promotion_usages (so_id, promotion_id, campaign_id, cost, order_amount)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (so_id, promotion_id) DO UPDATE
+		SET campaign_id = EXCLUDED.campaign_id, cost = EXCLUDED.cost, order_amount = EXCLUDED.order_amount,
+			released_at = NULL, updated_at = CURRENT_TIMESTAMP
+		WHERE campaign_promotion_usages.released_at IS NOT NULL`,
+		usage.SOID, usage.PromotionID, usage.CampaignID, usage.Cost, usage.OrderAmount)
+	return res.RowsAffected > 0, res.Error
+}
+
+// ReleasePromotionUsage marks the usage of a promotion on an order as released
+func (r *CampaignRepository) ReleasePromotionUsage(ctx context.Context, soID, promotionID uuid.UUID) (bool, error) {
+	now := time.Now()
+	res := conn(ctx, r.db).Model(&entity.PromotionUsage{}).
+		Where("so_id = ? AND promotion_id = ? AND released_at IS NULL", soID, promotionID).
+		Updates(map[string]interface{}{"released_at": now, "updated_at": now})
+	return res.RowsAffected > 0, res.Error
+}
+
 func (r *CampaignRepository) GenerateCampaignCode(ctx context.Context, prefix string) (string, error) {
 	year := time.Now().Year()
 	var count int64
-	r.db.WithContext(ctx).Model(&entity.Campaign{}).
+	conn(ctx, r.db).Model(&entity.Campaign{}).
 		Where("campaign_code LIKE ?", fmt.Sprintf("CAMP-%s-%d-%%", prefix, year)).
 		Count(&count)
 	return fmt.Sprintf("CAMP-%s-%d-%04d", prefix, year, count+1), nil
@@ -122,7 +153,7 @@ func (r *CampaignRepository) GenerateCampaignCode(ctx context.Context, prefix st
 func (r *CampaignRepository) GetActiveCampaigns(ctx context.Context) ([]*entity.Campaign, error) {
 	var campaigns []*entity.Campaign
 	now := time.Now()
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("status = ? AND start_date <= ? AND end_date >= ?", entity.CampaignStatusActive, now, now).
 		Find(&campaigns).Error
 	return campaigns, err
@@ -139,12 +170,12 @@ func NewKOLCollaborationRepository(db *gorm.DB) *KOLCollaborationRepository {
 }
 
 func (r *KOLCollaborationRepository) Create(ctx context.Context, collab *entity.KOLCollaboration) error {
-	return r.db.WithContext(ctx).Create(collab).Error
+	return conn(ctx, r.db).Create(collab).Error
 }
 
 func (r *KOLCollaborationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.KOLCollaboration, error) {
 	var collab entity.KOLCollaboration
-	err := r.db.WithContext(ctx).Preload("Campaign").Preload("KOL").First(&collab, "id = ?", id).Error
+	err := conn(ctx, r.db).Preload("Campaign").Preload("KOL").First(&collab, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -155,7 +186,7 @@ func (r *KOLCollaborationRepository) List(ctx context.Context, filter *repositor
 	var collabs []*entity.KOLCollaboration
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.KOLCollaboration{})
+	query := conn(ctx, r.db).Model(&entity.KOLCollaboration{})
 
 	if filter.CampaignID != nil {
 		query = query.Where("campaign_id = ?", filter.CampaignID)
@@ -182,25 +213,25 @@ func (r *KOLCollaborationRepository) List(ctx context.Context, filter *repositor
 
 func (r *KOLCollaborationRepository) Update(ctx context.Context, collab *entity.KOLCollaboration) error {
 	collab.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(collab).Error
+	return conn(ctx, r.db).Save(collab).Error
 }
 
 func (r *KOLCollaborationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.KOLCollaboration{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.KOLCollaboration{}, "id = ?", id).Error
 }
 
 func (r *KOLCollaborationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.CollaborationStatus) error {
-	return r.db.WithContext(ctx).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
 		Updates(map[string]interface{}{"status": status, "updated_at": time.Now()}).Error
 }
 
 func (r *KOLCollaborationRepository) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status entity.PaymentStatus, amount float64) error {
-	return r.db.WithContext(ctx).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
 		Updates(map[string]interface{}{"payment_status": status, "paid_amount": amount, "updated_at": time.Now()}).Error
 }
 
 func (r *KOLCollaborationRepository) UpdatePerformance(ctx context.Context, id uuid.UUID, impressions, engagement, reach int) error {
-	return r.db.WithContext(ctx).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
 		Updates(map[string]interface{}{
 			"total_impressions": impressions,
 			"total_engagement":  engagement,
@@ -210,19 +241,19 @@ func (r *KOLCollaborationRepository) UpdatePerformance(ctx context.Context, id u
 }
 
 func (r *KOLCollaborationRepository) IncrementPostCount(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
+	return conn(ctx, r.db).Model(&entity.KOLCollaboration{}).Where("id = ?", id).
 		UpdateColumn("actual_posts", gorm.Expr("actual_posts + 1")).Error
 }
 
 func (r *KOLCollaborationRepository) GetByKOL(ctx context.Context, kolID uuid.UUID) ([]*entity.KOLCollaboration, error) {
 	var collabs []*entity.KOLCollaboration
-	err := r.db.WithContext(ctx).Preload("Campaign").Where("kol_id = ?", kolID).Find(&collabs).Error
+	err := conn(ctx, r.db).Preload("Campaign").Where("kol_id = ?", kolID).Find(&collabs).Error
 	return collabs, err
 }
 
 func (r *KOLCollaborationRepository) GetByKOLAndCampaign(ctx context.Context, kolID, campaignID uuid.UUID) (*entity.KOLCollaboration, error) {
 	var collab entity.KOLCollaboration
-	err := r.db.WithContext(ctx).First(&collab, "kol_id = ? AND campaign_id = ?", kolID, campaignID).Error
+	err := conn(ctx, r.db).First(&collab, "kol_id = ? AND campaign_id = ?", kolID, campaignID).Error
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +263,7 @@ func (r *KOLCollaborationRepository) GetByKOLAndCampaign(ctx context.Context, ko
 func (r *KOLCollaborationRepository) GenerateCode(ctx context.Context) (string, error) {
 	year := time.Now().Year()
 	var count int64
-	r.db.WithContext(ctx).Model(&entity.KOLCollaboration{}).
+	conn(ctx, r.db).Model(&entity.KOLCollaboration{}).
 		Where("collaboration_code LIKE ?", fmt.Sprintf("COLLAB-%d-%%", year)).
 		Count(&count)
 	return fmt.Sprintf("COLLAB-%d-%04d", year, count+1), nil
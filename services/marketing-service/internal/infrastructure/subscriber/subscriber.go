@@ -0,0 +1,139 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+
+	campaignuc "github.com/erp-cosmetics/marketing-service/internal/usecase/campaign"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// EventSubscriber handles incoming events from other services
+type EventSubscriber struct {
+	nc                   *nats.Conn
+	logger               *zap.Logger
+	recordPromotionUsage *campaignuc.RecordPromotionUsageUseCase
+	subscriptions        []*nats.Subscription
+}
+
+// NewEventSubscriber creates a new event subscriber
+func NewEventSubscriber(
+	nc *nats.Conn,
+	logger *zap.Logger,
+	recordPromotionUsage *campaignuc.RecordPromotionUsageUseCase,
+) *EventSubscriber {
+	return &EventSubscriber{
+		nc:                   nc,
+		logger:               logger,
+		recordPromotionUsage: recordPromotionUsage,
+	}
+}
+
+// Start begins listening for events
+func (s *EventSubscriber) Start() error {
+	if s.nc == nil {
+		s.logger.Warn("NATS not connected, skipping event subscriptions")
+		return nil
+	}
+
+	// Subscribe to sales promotion events
+	sub1, err := s.nc.Subscribe("sales.promotion.applied", s.handlePromotionApplied)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub1)
+
+	sub2, err := s.nc.Subscribe("sales.promotion.released", s.handlePromotionReleased)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub2)
+
+	s.logger.Info("Event subscriber started",
+		zap.Int("subscriptions", len(s.subscriptions)),
+	)
+
+	return nil
+}
+
+// Stop stops all subscriptions
+func (s *EventSubscriber) Stop() {
+	for _, sub := range s.subscriptions {
+		sub.Unsubscribe()
+	}
+	s.logger.Info("Event subscriber stopped")
+}
+
+// PromotionUsageEvent represents a promotion applied to or released from a sales order
+type PromotionUsageEvent struct {
+	SOID          string  `json:"so_id"`
+	SONumber      string  `json:"so_number"`
+	PromotionID   string  `json:"promotion_id"`
+	PromotionCode string  `json:"promotion_code"`
+	CampaignID    string  `json:"campaign_id"`
+	VoucherCode   string  `json:"voucher_code"`
+	Cost          float64 `json:"cost"`
+	OrderAmount   float64 `json:"order_amount"`
+}
+
+// handlePromotionApplied handles promotion applied events - charges the campaign
+func (s *EventSubscriber) handlePromotionApplied(msg *nats.Msg) {
+	s.handlePromotionUsage(msg, false)
+}
+
+// handlePromotionReleased handles promotion released events - reverses the charge
+func (s *EventSubscriber) handlePromotionReleased(msg *nats.Msg) {
+	s.handlePromotionUsage(msg, true)
+}
+
+func (s *EventSubscriber) handlePromotionUsage(msg *nats.Msg, released bool) {
+	var event PromotionUsageEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal promotion usage event", zap.Error(err))
+		return
+	}
+
+	// Promotions outside a campaign have nothing to charge
+	if event.CampaignID == "" {
+		return
+	}
+	campaignID, err := uuid.Parse(event.CampaignID)
+	if err != nil {
+		s.logger.Error("Invalid campaign ID in promotion usage event", zap.String("campaign_id", event.CampaignID))
+		return
+	}
+	soID, err := uuid.Parse(event.SOID)
+	if err != nil {
+		s.logger.Error("Invalid sales order ID in promotion usage event", zap.String("so_id", event.SOID))
+		return
+	}
+	promotionID, err := uuid.Parse(event.PromotionID)
+	if err != nil {
+		s.logger.Error("Invalid promotion ID in promotion usage event", zap.String("promotion_id", event.PromotionID))
+		return
+	}
+
+	s.logger.Info("Received promotion usage event",
+		zap.String("so_number", event.SONumber),
+		zap.String("promotion_code", event.PromotionCode),
+		zap.Bool("released", released),
+	)
+
+	input := &campaignuc.PromotionUsageInput{
+		SOID:        soID,
+		PromotionID: promotionID,
+		CampaignID:  campaignID,
+		Cost:        event.Cost,
+		OrderAmount: event.OrderAmount,
+		Released:    released,
+	}
+	if err := s.recordPromotionUsage.Execute(context.Background(), input); err != nil {
+		s.logger.Error("Failed to record promotion usage on campaign",
+			zap.String("campaign_id", event.CampaignID),
+			zap.String("so_number", event.SONumber),
+			zap.Error(err),
+		)
+	}
+}
@@ -188,6 +188,75 @@ func (uc *UpdateCampaignUseCase) Execute(ctx context.Context, id uuid.UUID, inpu
 	return campaign, nil
 }
 
+// PromotionUsageInput represents a sales promotion used or given back on an order
+type PromotionUsageInput struct {
+	SOID        uuid.UUID
+	PromotionID uuid.UUID
+	CampaignID  uuid.UUID
+	Cost        float64
+	OrderAmount float64 // Zero when the order was already counted for the campaign
+	Released    bool    // The order was cancelled
+}
+
+// RecordPromotionUsageUseCase charges sales promotion costs to campaigns
+type RecordPromotionUsageUseCase struct {
+	repo repository.CampaignRepository
+	tx   repository.Transactor
+}
+
+// NewRecordPromotionUsageUseCase creates a new use case
+func NewRecordPromotionUsageUseCase(repo repository.CampaignRepository, tx repository.Transactor) *RecordPromotionUsageUseCase {
+	return &RecordPromotionUsageUseCase{repo: repo, tx: tx}
+}
+
+// Execute adds the promotion cost to the campaign spend and the order to its
+// conversions and revenue, or takes them back when the order was cancelled.
+// Usage is recorded once per order and promotion, so a redelivered event
+// changes nothing.
+func (uc *RecordPromotionUsageUseCase) Execute(ctx context.Context, input *PromotionUsageInput) error {
+	if _, err := uc.repo.GetByID(ctx, input.CampaignID); err != nil {
+		return err
+	}
+
+	cost, revenue := input.Cost, input.OrderAmount
+	conversions := 0
+	if revenue > 0 {
+		conversions = 1
+	}
+	if input.Released {
+		cost, revenue, conversions = -cost, -revenue, -conversions
+	}
+
+	return uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var recorded bool
+		var err error
+		if input.Released {
+			recorded, err = uc.repo.ReleasePromotionUsage(ctx, input.SOID, input.PromotionID)
+		} else {
+			recorded, err = uc.repo.RecordPromotionUsage(ctx, &entity.PromotionUsage{
+				SOID:        input.SOID,
+				PromotionID: input.PromotionID,
+				CampaignID:  input.CampaignID,
+				Cost:        input.Cost,
+				OrderAmount: input.OrderAmount,
+			})
+		}
+		if err != nil || !recorded {
+			return err
+		}
+
+		if cost != 0 {
+			if err := uc.repo.IncrementSpent(ctx, input.CampaignID, cost); err != nil {
+				return err
+			}
+		}
+		if conversions != 0 {
+			return uc.repo.IncrementResults(ctx, input.CampaignID, conversions, revenue)
+		}
+		return nil
+	})
+}
+
 // Custom errors
 var (
 	ErrCampaignCannotBeLaunched = &CampaignError{Message: "campaign cannot be launched"}
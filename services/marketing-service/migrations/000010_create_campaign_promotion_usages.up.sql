@@ -0,0 +1,18 @@
+-- Sales promotions charged to campaigns, one row per order and promotion so
+-- that redelivered usage events are counted once
+CREATE TABLE campaign_promotion_usages (
+    so_id UUID NOT NULL,
+    promotion_id UUID NOT NULL,
+    campaign_id UUID REFERENCES campaigns(id) NOT NULL,
+
+    cost DECIMAL(18,2) DEFAULT 0,
+    order_amount DECIMAL(18,2) DEFAULT 0,
+
+    released_at TIMESTAMP, -- Set when the order was cancelled
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (so_id, promotion_id)
+);
+
+CREATE INDEX idx_campaign_promotion_usages_campaign ON campaign_promotion_usages(campaign_id);
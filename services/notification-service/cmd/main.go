@@ -117,6 +117,7 @@ func main() {
 		templateRepo,
 		userNotificationRepo,
 		notificationRepo,
+		alertRuleRepo,
 	)
 	if err := eventSubscriber.SubscribeAll(); err != nil {
 		logger.Error("Failed to subscribe to events", zap.Error(err))
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,8 @@ const (
 	RuleTypeCertExpiry      = "CERT_EXPIRY"
 	RuleTypeApprovalPending = "APPROVAL_PENDING"
 	RuleTypeTempOutOfRange  = "TEMP_OUT_OF_RANGE"
+	RuleTypeSPCViolation    = "SPC_VIOLATION"
+	RuleTypeEquipmentDue    = "EQUIPMENT_DUE"
 )
 
 // AlertRule represents a configurable alert rule
@@ -122,3 +125,19 @@ func (r *AlertRule) RequiresEmail() bool {
 func (r *AlertRule) RequiresInApp() bool {
 	return r.NotificationType == NotificationTypeInApp || r.NotificationType == NotificationTypeBoth
 }
+
+// RecipientUserIDs returns the user IDs listed directly in the rule recipients
+func (r *AlertRule) RecipientUserIDs() []uuid.UUID {
+	var recipients []map[string]string
+	if err := json.Unmarshal(r.Recipients, &recipients); err != nil {
+		return nil
+	}
+
+	var ids []uuid.UUID
+	for _, recipient := range recipients {
+		if id, err := uuid.Parse(recipient["user_id"]); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
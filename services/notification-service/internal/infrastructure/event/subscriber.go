@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/erp-cosmetics/notification-service/internal/domain/entity"
 	"github.com/erp-cosmetics/notification-service/internal/domain/repository"
@@ -30,10 +31,15 @@ const (
 	SubjectCertExpiring = "supplier.certification.expiring"
 
 	// Manufacturing events
-	SubjectQCFailed = "manufacturing.qc.failed"
+	SubjectQCFailed       = "manufacturing.qc.failed"
+	SubjectSPCViolation   = "manufacturing.qc.spc.violation"
+	SubjectCAPAOverdue    = "manufacturing.capa.action.overdue"
+	SubjectCalibrationDue = "manufacturing.equipment.calibration.due"
+	SubjectMaintenanceDue = "manufacturing.equipment.maintenance.due"
 
 	// Sales events
-	SubjectOrderConfirmed = "sales.order.confirmed"
+	SubjectOrderConfirmed   = "sales.order.confirmed"
+	SubjectOrderBackordered = "sales.order.backordered"
 )
 
 // Subscriber handles event subscriptions
@@ -43,6 +49,7 @@ type Subscriber struct {
 	templateRepo         repository.TemplateRepository
 	userNotificationRepo repository.UserNotificationRepository
 	notificationRepo     repository.NotificationRepository
+	alertRuleRepo        repository.AlertRuleRepository
 }
 
 // NewSubscriber creates a new event subscriber
@@ -52,6 +59,7 @@ func NewSubscriber(
 	templateRepo repository.TemplateRepository,
 	userNotificationRepo repository.UserNotificationRepository,
 	notificationRepo repository.NotificationRepository,
+	alertRuleRepo repository.AlertRuleRepository,
 ) *Subscriber {
 	return &Subscriber{
 		client:               client,
@@ -59,6 +67,7 @@ func NewSubscriber(
 		templateRepo:         templateRepo,
 		userNotificationRepo: userNotificationRepo,
 		notificationRepo:     notificationRepo,
+		alertRuleRepo:        alertRuleRepo,
 	}
 }
 
@@ -74,7 +83,12 @@ func (s *Subscriber) SubscribeAll() error {
 		{SubjectPRSubmitted, s.handlePRSubmitted},
 		{SubjectPOCreated, s.handlePOCreated},
 		{SubjectQCFailed, s.handleQCFailed},
+		{SubjectSPCViolation, s.handleSPCViolation},
+		{SubjectCAPAOverdue, s.handleCAPAActionOverdue},
+		{SubjectCalibrationDue, s.handleEquipmentDue},
+		{SubjectMaintenanceDue, s.handleEquipmentDue},
 		{SubjectOrderConfirmed, s.handleOrderConfirmed},
+		{SubjectOrderBackordered, s.handleOrderBackordered},
 	}
 
 	for _, sub := range subscriptions {
@@ -154,6 +168,50 @@ type QCFailedData struct {
 	ManagerID       string `json:"manager_id"`
 }
 
+type SPCViolationData struct {
+	ProductID        string  `json:"product_id"`
+	TestName         string  `json:"test_name"`
+	InspectionID     string  `json:"inspection_id"`
+	InspectionNumber string  `json:"inspection_number"`
+	LotNumber        string  `json:"lot_number"`
+	Rule             string  `json:"rule"`
+	Description      string  `json:"description"`
+	Value            float64 `json:"value"`
+	CenterLine       float64 `json:"center_line"`
+	UCL              float64 `json:"ucl"`
+	LCL              float64 `json:"lcl"`
+	OutOfSpec        bool    `json:"out_of_spec"`
+}
+
+type CAPAActionOverdueData struct {
+	CAPAID      string `json:"capa_id"`
+	CAPANumber  string `json:"capa_number"`
+	CAPATitle   string `json:"capa_title"`
+	CAPAOwnerID string `json:"capa_owner_id"`
+	ActionID    string `json:"action_id"`
+	ActionType  string `json:"action_type"`
+	Description string `json:"description"`
+	OwnerID     string `json:"owner_id"`
+	DueDate     string `json:"due_date"`
+	DaysOverdue int    `json:"days_overdue"`
+	IsCritical  bool   `json:"is_critical"`
+}
+
+type EquipmentDueData struct {
+	EquipmentID       string `json:"equipment_id"`
+	EquipmentCode     string `json:"equipment_code"`
+	EquipmentName     string `json:"equipment_name"`
+	EquipmentType     string `json:"equipment_type"`
+	ProductionLine    string `json:"production_line"`
+	DueType           string `json:"due_type"` // CALIBRATION, MAINTENANCE
+	PlanID            string `json:"plan_id"`
+	PlanName          string `json:"plan_name"`
+	DueDate           string `json:"due_date"`
+	DaysUntilDue      int    `json:"days_until_due"`
+	IsOverdue         bool   `json:"is_overdue"`
+	ResponsibleUserID string `json:"responsible_user_id"`
+}
+
 func (s *Subscriber) handleStockLowAlert(msg []byte) error {
 	var data StockLowAlertData
 	if err := json.Unmarshal(msg, &data); err != nil {
@@ -304,6 +362,183 @@ func (s *Subscriber) handleQCFailed(msg []byte) error {
 	return nil
 }
 
+func (s *Subscriber) handleSPCViolation(msg []byte) error {
+	var data SPCViolationData
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	rules, err := s.alertRuleRepo.ListByType(ctx, entity.RuleTypeSPCViolation)
+	if err != nil {
+		return err
+	}
+
+	// Warn QA recipients of the active SPC alert rules
+	for _, rule := range rules {
+		if !rule.IsActive || !rule.RequiresInApp() {
+			continue
+		}
+		for _, userID := range rule.RecipientUserIDs() {
+			notification := &entity.UserNotification{
+				UserID:           userID,
+				Title:            "SPC Rule Violation",
+				Message:          formatSPCViolationMessage(data),
+				NotificationType: entity.UserNotifTypeWarning,
+				Category:         entity.CategoryAlert,
+				LinkURL:          "/manufacturing/spc?product_id=" + data.ProductID + "&test_name=" + url.QueryEscape(data.TestName),
+				EntityType:       "QC_INSPECTION",
+			}
+			if data.OutOfSpec {
+				notification.NotificationType = entity.UserNotifTypeError
+			}
+
+			if inspectionUUID, err := uuid.Parse(data.InspectionID); err == nil {
+				notification.EntityID = &inspectionUUID
+			}
+
+			if err := s.userNotificationRepo.Create(ctx, notification); err != nil {
+				s.logger.Error("Failed to create SPC violation notification",
+					zap.String("user_id", userID.String()),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	s.logger.Info("SPC violation notification processed",
+		zap.String("inspection", data.InspectionNumber),
+		zap.String("test_name", data.TestName),
+		zap.String("rule", data.Rule),
+	)
+
+	return nil
+}
+
+func (s *Subscriber) handleCAPAActionOverdue(msg []byte) error {
+	var data CAPAActionOverdueData
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return err
+	}
+
+	// Remind the action owner and the CAPA owner
+	recipients := []string{data.OwnerID}
+	if data.CAPAOwnerID != "" && data.CAPAOwnerID != data.OwnerID {
+		recipients = append(recipients, data.CAPAOwnerID)
+	}
+
+	ctx := context.Background()
+	for _, recipient := range recipients {
+		userID, err := uuid.Parse(recipient)
+		if err != nil {
+			continue
+		}
+
+		notification := &entity.UserNotification{
+			UserID:           userID,
+			Title:            "CAPA Action Overdue",
+			Message:          formatCAPAOverdueMessage(data),
+			NotificationType: entity.UserNotifTypeWarning,
+			Category:         entity.CategoryAlert,
+			LinkURL:          "/manufacturing/capas/" + data.CAPAID,
+			EntityType:       "CAPA",
+		}
+		if data.IsCritical {
+			notification.NotificationType = entity.UserNotifTypeError
+		}
+
+		if capaUUID, err := uuid.Parse(data.CAPAID); err == nil {
+			notification.EntityID = &capaUUID
+		}
+
+		if err := s.userNotificationRepo.Create(ctx, notification); err != nil {
+			s.logger.Error("Failed to create CAPA overdue notification",
+				zap.String("user_id", recipient),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.logger.Info("CAPA action overdue notification processed",
+		zap.String("capa", data.CAPANumber),
+		zap.String("action_id", data.ActionID),
+		zap.Int("days_overdue", data.DaysOverdue),
+	)
+
+	return nil
+}
+
+func (s *Subscriber) handleEquipmentDue(msg []byte) error {
+	var data EquipmentDueData
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	rules, err := s.alertRuleRepo.ListByType(ctx, entity.RuleTypeEquipmentDue)
+	if err != nil {
+		return err
+	}
+
+	// Alert the person responsible for the equipment and the recipients of the active rules
+	recipients := make(map[uuid.UUID]bool)
+	if userID, err := uuid.Parse(data.ResponsibleUserID); err == nil {
+		recipients[userID] = true
+	}
+	for _, rule := range rules {
+		if !rule.IsActive || !rule.RequiresInApp() {
+			continue
+		}
+		for _, userID := range rule.RecipientUserIDs() {
+			recipients[userID] = true
+		}
+	}
+
+	title := "Calibration Due"
+	switch {
+	case data.DueType == "MAINTENANCE" && data.IsOverdue:
+		title = "Preventive Maintenance Overdue"
+	case data.DueType == "MAINTENANCE":
+		title = "Preventive Maintenance Due"
+	case data.IsOverdue:
+		title = "Calibration Overdue"
+	}
+
+	for userID := range recipients {
+		notification := &entity.UserNotification{
+			UserID:           userID,
+			Title:            title,
+			Message:          formatEquipmentDueMessage(data),
+			NotificationType: entity.UserNotifTypeWarning,
+			Category:         entity.CategoryAlert,
+			LinkURL:          "/manufacturing/equipment/" + data.EquipmentID,
+			EntityType:       "EQUIPMENT",
+		}
+		if data.IsOverdue {
+			notification.NotificationType = entity.UserNotifTypeError
+		}
+
+		if equipmentUUID, err := uuid.Parse(data.EquipmentID); err == nil {
+			notification.EntityID = &equipmentUUID
+		}
+
+		if err := s.userNotificationRepo.Create(ctx, notification); err != nil {
+			s.logger.Error("Failed to create equipment due notification",
+				zap.String("user_id", userID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+
+	s.logger.Info("Equipment due notification processed",
+		zap.String("equipment", data.EquipmentCode),
+		zap.String("due_type", data.DueType),
+		zap.Int("days_until_due", data.DaysUntilDue),
+	)
+
+	return nil
+}
+
 func (s *Subscriber) handleOrderConfirmed(msg []byte) error {
 	var eventData map[string]interface{}
 	if err := json.Unmarshal(msg, &eventData); err != nil {
@@ -314,6 +549,66 @@ func (s *Subscriber) handleOrderConfirmed(msg []byte) error {
 	return nil
 }
 
+type OrderBackorderedData struct {
+	SOID          string `json:"so_id"`
+	SONumber      string `json:"so_number"`
+	CustomerName  string `json:"customer_name"`
+	CustomerEmail string `json:"customer_email"`
+	Status        string `json:"status"` // BACKORDERED, ALLOCATED, RESCHEDULED
+	Lines         []struct {
+		ProductCode    string  `json:"product_code"`
+		ProductName    string  `json:"product_name"`
+		Quantity       float64 `json:"quantity"`
+		BackorderedQty float64 `json:"backordered_qty"`
+		ExpectedDate   string  `json:"expected_date"`
+	} `json:"lines"`
+}
+
+func (s *Subscriber) handleOrderBackordered(msg []byte) error {
+	var data OrderBackorderedData
+	if err := json.Unmarshal(msg, &data); err != nil {
+		return err
+	}
+
+	// Customers without an email address are informed by their sales rep
+	if data.CustomerEmail == "" {
+		s.logger.Warn("Order backordered notification skipped, customer has no email",
+			zap.String("so_number", data.SONumber),
+		)
+		return nil
+	}
+
+	subject := "Order " + data.SONumber + " backordered"
+	switch data.Status {
+	case "ALLOCATED":
+		subject = "Order " + data.SONumber + " backordered items now available"
+	case "RESCHEDULED":
+		subject = "Order " + data.SONumber + " new expected delivery date"
+	}
+
+	notification := &entity.Notification{
+		NotificationType: entity.NotificationTypeEmail,
+		RecipientEmail:   data.CustomerEmail,
+		Subject:          subject,
+		Body:             formatOrderBackorderedMessage(data),
+		Priority:         entity.PriorityNormal,
+	}
+	if metadata, err := json.Marshal(map[string]string{"so_id": data.SOID, "status": data.Status}); err == nil {
+		notification.Metadata = metadata
+	}
+
+	if err := s.notificationRepo.Create(context.Background(), notification); err != nil {
+		return err
+	}
+
+	s.logger.Info("Order backordered notification processed",
+		zap.String("so_number", data.SONumber),
+		zap.String("status", data.Status),
+	)
+
+	return nil
+}
+
 // Helper functions
 func formatLowStockMessage(data StockLowAlertData) string {
 	shortage := data.ReorderPoint - data.CurrentQuantity
@@ -341,3 +636,67 @@ func formatQCFailedMessage(data QCFailedData) string {
 		data.FailedItems, data.InspectorName,
 	)
 }
+
+func formatSPCViolationMessage(data SPCViolationData) string {
+	return fmt.Sprintf(
+		"%s on %s (inspection %s, lot %s): %s. Value: %.4g, CL: %.4g, UCL: %.4g, LCL: %.4g",
+		data.Rule, data.TestName, data.InspectionNumber, data.LotNumber,
+		data.Description, data.Value, data.CenterLine, data.UCL, data.LCL,
+	)
+}
+
+func formatCAPAOverdueMessage(data CAPAActionOverdueData) string {
+	msg := fmt.Sprintf(
+		"%s action on %s (%s) is %d day(s) overdue (due %s): %s",
+		data.ActionType, data.CAPANumber, data.CAPATitle,
+		data.DaysOverdue, data.DueDate, data.Description,
+	)
+	if data.IsCritical {
+		msg += ". Critical action - linked NCRs cannot be closed until it is completed"
+	}
+	return msg
+}
+
+func formatEquipmentDueMessage(data EquipmentDueData) string {
+	what := "Calibration"
+	if data.DueType == "MAINTENANCE" {
+		what = "Maintenance \"" + data.PlanName + "\""
+	}
+	when := fmt.Sprintf("is due in %d day(s)", data.DaysUntilDue)
+	if data.IsOverdue {
+		when = fmt.Sprintf("is %d day(s) overdue", -data.DaysUntilDue)
+	}
+	msg := fmt.Sprintf("%s of %s (%s) %s (due %s)", what, data.EquipmentCode, data.EquipmentName, when, data.DueDate)
+	if data.ProductionLine != "" {
+		msg += ", line " + data.ProductionLine
+	}
+	if data.IsOverdue && data.DueType == "CALIBRATION" {
+		msg += ". The equipment is blocked for production until it is recalibrated"
+	}
+	return msg
+}
+
+func formatOrderBackorderedMessage(data OrderBackorderedData) string {
+	var msg string
+	switch data.Status {
+	case "ALLOCATED":
+		msg = fmt.Sprintf("Dear %s,\n\nStock has arrived for the backordered items of your order %s:\n", data.CustomerName, data.SONumber)
+	case "RESCHEDULED":
+		msg = fmt.Sprintf("Dear %s,\n\nThe expected date of the backordered items of your order %s has changed:\n", data.CustomerName, data.SONumber)
+	default:
+		msg = fmt.Sprintf("Dear %s,\n\nSome items of your order %s are not in stock and have been backordered:\n", data.CustomerName, data.SONumber)
+	}
+
+	for _, line := range data.Lines {
+		switch {
+		case line.BackorderedQty <= 0:
+			msg += fmt.Sprintf("- %s (%s): all %.0f reserved for your order\n", line.ProductName, line.ProductCode, line.Quantity)
+		case line.ExpectedDate != "":
+			msg += fmt.Sprintf("- %s (%s): %.0f of %.0f backordered, expected %s\n", line.ProductName, line.ProductCode, line.BackorderedQty, line.Quantity, line.ExpectedDate)
+		default:
+			msg += fmt.Sprintf("- %s (%s): %.0f of %.0f backordered\n", line.ProductName, line.ProductCode, line.BackorderedQty, line.Quantity)
+		}
+	}
+
+	return msg + "\nThe rest of your order is being prepared as usual."
+}
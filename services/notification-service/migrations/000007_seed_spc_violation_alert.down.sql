@@ -0,0 +1,2 @@
+DELETE FROM alert_rules WHERE rule_code = 'SPC_VIOLATION_ALERT';
+DELETE FROM notification_templates WHERE template_code = 'SPC_VIOLATION';
@@ -0,0 +1,15 @@
+-- SPC rule violation alert raised by manufacturing-service
+INSERT INTO notification_templates (template_code, name, notification_type, subject_template, body_template, variables) VALUES
+('SPC_VIOLATION', 'SPC Rule Violation', 'IN_APP',
+'SPC Violation: {{.TestName}} - {{.InspectionNumber}}',
+'{{.Rule}} on {{.TestName}} (inspection {{.InspectionNumber}}, lot {{.LotNumber}}): {{.Description}}. Value: {{.Value}}, CL: {{.CenterLine}}, UCL: {{.UCL}}, LCL: {{.LCL}}',
+'["Rule", "TestName", "InspectionNumber", "LotNumber", "Description", "Value", "CenterLine", "UCL", "LCL"]')
+ON CONFLICT (template_code) DO NOTHING;
+
+-- Add {"user_id": "..."} recipients to route the alert to QA users
+INSERT INTO alert_rules (rule_code, name, description, rule_type, conditions, notification_type, recipients) VALUES
+('SPC_VIOLATION_ALERT', 'SPC Rule Violation', 'Warn QA when a numeric QC result breaks a Western Electric rule', 'SPC_VIOLATION',
+'{}',
+'IN_APP',
+'[{"role": "QC_MANAGER"}]')
+ON CONFLICT (rule_code) DO NOTHING;
@@ -0,0 +1,2 @@
+DELETE FROM alert_rules WHERE rule_code = 'EQUIPMENT_DUE_ALERT';
+DELETE FROM notification_templates WHERE template_code = 'EQUIPMENT_DUE';
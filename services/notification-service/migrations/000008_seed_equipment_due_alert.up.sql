@@ -0,0 +1,16 @@
+-- Equipment calibration / preventive maintenance due alert raised by manufacturing-service
+INSERT INTO notification_templates (template_code, name, notification_type, subject_template, body_template, variables) VALUES
+('EQUIPMENT_DUE', 'Equipment Calibration/Maintenance Due', 'IN_APP',
+'{{.DueType}} due: {{.EquipmentCode}} - {{.DueDate}}',
+'{{.DueType}} of {{.EquipmentCode}} ({{.EquipmentName}}, line {{.ProductionLine}}) is due on {{.DueDate}}. Days until due: {{.DaysUntilDue}}',
+'["DueType", "EquipmentCode", "EquipmentName", "ProductionLine", "DueDate", "DaysUntilDue"]')
+ON CONFLICT (template_code) DO NOTHING;
+
+-- The equipment's responsible user is always notified; add {"user_id": "..."}
+-- recipients to also route the alert to maintenance / QA users
+INSERT INTO alert_rules (rule_code, name, description, rule_type, conditions, notification_type, recipients) VALUES
+('EQUIPMENT_DUE_ALERT', 'Equipment Calibration/Maintenance Due', 'Warn before equipment calibration or preventive maintenance falls due', 'EQUIPMENT_DUE',
+'{}',
+'IN_APP',
+'[{"role": "PRODUCTION_MANAGER"}]')
+ON CONFLICT (rule_code) DO NOTHING;
@@ -85,6 +85,7 @@ func main() {
 	cancelPOUC := po.NewCancelPOUseCase(poRepo, eventPub)
 	closePOUC := po.NewClosePOUseCase(poRepo, eventPub)
 	getPOReceiptsUC := po.NewGetPOReceiptsUseCase(poRepo)
+	listIncomingSupplyUC := po.NewListIncomingSupplyUseCase(poRepo)
 	updateReceivedQtyUC := po.NewUpdateReceivedQtyUseCase(poRepo, eventPub)
 
 	// Initialize event subscriber
@@ -95,7 +96,7 @@ func main() {
 
 	// Initialize handlers
 	prHandler := handler.NewPRHandler(createPRUC, getPRUC, listPRsUC, submitPRUC, approvePRUC, rejectPRUC)
-	poHandler := handler.NewPOHandler(createPOFromPRUC, getPOUC, listPOsUC, confirmPOUC, cancelPOUC, closePOUC, getPOReceiptsUC)
+	poHandler := handler.NewPOHandler(createPOFromPRUC, getPOUC, listPOsUC, confirmPOUC, cancelPOUC, closePOUC, getPOReceiptsUC, listIncomingSupplyUC)
 	healthHandler := handler.NewHealthHandler()
 
 	// Setup router
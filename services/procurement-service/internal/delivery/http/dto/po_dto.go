@@ -229,3 +229,13 @@ func ToPOReceiptResponses(receipts []*entity.POReceipt) []POReceiptResponse {
 	}
 	return responses
 }
+
+// IncomingSupplyResponse represents a pending PO quantity of a material
+type IncomingSupplyResponse struct {
+	POID         string  `json:"po_id"`
+	PONumber     string  `json:"po_number"`
+	Status       string  `json:"status"`
+	MaterialID   string  `json:"material_id"`
+	PendingQty   float64 `json:"pending_qty"`
+	ExpectedDate *string `json:"expected_date,omitempty"`
+}
@@ -21,6 +21,7 @@ type POHandler struct {
 	cancelPO        *po.CancelPOUseCase
 	closePO         *po.ClosePOUseCase
 	getPOReceipts   *po.GetPOReceiptsUseCase
+	listIncoming    *po.ListIncomingSupplyUseCase
 }
 
 // NewPOHandler creates a new PO handler
@@ -32,6 +33,7 @@ func NewPOHandler(
 	cancelPO *po.CancelPOUseCase,
 	closePO *po.ClosePOUseCase,
 	getPOReceipts *po.GetPOReceiptsUseCase,
+	listIncoming *po.ListIncomingSupplyUseCase,
 ) *POHandler {
 	return &POHandler{
 		createPOFromPR: createPOFromPR,
@@ -41,6 +43,7 @@ func NewPOHandler(
 		cancelPO:       cancelPO,
 		closePO:        closePO,
 		getPOReceipts:  getPOReceipts,
+		listIncoming:   listIncoming,
 	}
 }
 
@@ -226,3 +229,36 @@ func (h *POHandler) GetReceipts(c *gin.Context) {
 
 	response.Success(c, dto.ToPOReceiptResponses(receipts))
 }
+
+// ListIncoming handles GET /api/v1/purchase-orders/incoming
+func (h *POHandler) ListIncoming(c *gin.Context) {
+	materialID, err := uuid.Parse(c.Query("material_id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid material ID"))
+		return
+	}
+
+	supply, err := h.listIncoming.Execute(c.Request.Context(), materialID)
+	if err != nil {
+		response.Error(c, err)
+		return
+	}
+
+	responses := make([]dto.IncomingSupplyResponse, 0, len(supply))
+	for _, s := range supply {
+		resp := dto.IncomingSupplyResponse{
+			POID:       s.POID.String(),
+			PONumber:   s.PONumber,
+			Status:     string(s.Status),
+			MaterialID: s.MaterialID.String(),
+			PendingQty: s.PendingQty,
+		}
+		if s.ExpectedDate != nil {
+			date := s.ExpectedDate.Format("2006-01-02")
+			resp.ExpectedDate = &date
+		}
+		responses = append(responses, resp)
+	}
+
+	response.Success(c, responses)
+}
@@ -36,6 +36,7 @@ func SetupRouter(
 		pos := v1.Group("/purchase-orders")
 		{
 			pos.GET("", poHandler.List)
+			pos.GET("/incoming", poHandler.ListIncoming)
 			pos.GET("/:id", poHandler.Get)
 			pos.POST("/:id/confirm", poHandler.Confirm)
 			pos.POST("/:id/cancel", poHandler.Cancel)
@@ -31,6 +31,7 @@ type PORepository interface {
 	// List
 	List(ctx context.Context, filter *POFilter) ([]*entity.PurchaseOrder, int64, error)
 	GetBySupplierID(ctx context.Context, supplierID uuid.UUID) ([]*entity.PurchaseOrder, error)
+	GetOpenByMaterial(ctx context.Context, materialID uuid.UUID) ([]*entity.PurchaseOrder, error)
 	
 	// Code generation
 	GetNextPONumber(ctx context.Context) (string, error)
@@ -109,6 +109,21 @@ func (r *poRepository) GetBySupplierID(ctx context.Context, supplierID uuid.UUID
 	return pos, err
 }
 
+// GetOpenByMaterial returns confirmed POs still to be received for a material,
+// with only the material's pending lines loaded
+func (r *poRepository) GetOpenByMaterial(ctx context.Context, materialID uuid.UUID) ([]*entity.PurchaseOrder, error) {
+	var pos []*entity.PurchaseOrder
+	err := r.db.WithContext(ctx).
+		Preload("LineItems", "material_id = ? AND pending_qty > 0", materialID).
+		Where("status IN ? AND deleted_at IS NULL", []entity.POStatus{entity.POStatusConfirmed, entity.POStatusPartiallyReceived}).
+		Where("id IN (?)", r.db.Model(&entity.POLineItem{}).
+			Select("po_id").
+			Where("material_id = ? AND pending_qty > 0", materialID)).
+		Order("expected_delivery_date").
+		Find(&pos).Error
+	return pos, err
+}
+
 func (r *poRepository) GetNextPONumber(ctx context.Context) (string, error) {
 	var count int64
 	year := time.Now().Year()
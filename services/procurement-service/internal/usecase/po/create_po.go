@@ -188,3 +188,55 @@ func NewListPOsUseCase(poRepo repository.PORepository) *ListPOsUseCase {
 func (uc *ListPOsUseCase) Execute(ctx context.Context, filter *repository.POFilter) ([]*entity.PurchaseOrder, int64, error) {
 	return uc.poRepo.List(ctx, filter)
 }
+
+// IncomingSupply represents a quantity of a material still to be received on a PO
+type IncomingSupply struct {
+	POID         uuid.UUID
+	PONumber     string
+	Status       entity.POStatus
+	MaterialID   uuid.UUID
+	PendingQty   float64
+	ExpectedDate *time.Time
+}
+
+// ListIncomingSupplyUseCase handles listing incoming supply of a material
+type ListIncomingSupplyUseCase struct {
+	poRepo repository.PORepository
+}
+
+// NewListIncomingSupplyUseCase creates a new use case
+func NewListIncomingSupplyUseCase(poRepo repository.PORepository) *ListIncomingSupplyUseCase {
+	return &ListIncomingSupplyUseCase{poRepo: poRepo}
+}
+
+// Execute lists the pending lines of open POs for a material. A line without
+// its own expected date falls back to the PO expected delivery date.
+func (uc *ListIncomingSupplyUseCase) Execute(ctx context.Context, materialID uuid.UUID) ([]*IncomingSupply, error) {
+	pos, err := uc.poRepo.GetOpenByMaterial(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	var supply []*IncomingSupply
+	for _, po := range pos {
+		for _, line := range po.LineItems {
+			if line.Status == entity.POLineStatusCancelled || line.PendingQty <= 0 {
+				continue
+			}
+			expected := line.ExpectedDate
+			if expected == nil {
+				expected = po.ExpectedDeliveryDate
+			}
+			supply = append(supply, &IncomingSupply{
+				POID:         po.ID,
+				PONumber:     po.PONumber,
+				Status:       po.Status,
+				MaterialID:   line.MaterialID,
+				PendingQty:   line.PendingQty,
+				ExpectedDate: expected,
+			})
+		}
+	}
+
+	return supply, nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,11 +15,22 @@ import (
 	httpdelivery "github.com/erp-cosmetics/sales-service/internal/delivery/http"
 	"github.com/erp-cosmetics/sales-service/internal/delivery/http/handler"
 	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/carrier"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/einvoice"
 	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/masterdata"
 	postgresrepo "github.com/erp-cosmetics/sales-service/internal/infrastructure/persistence/postgres"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/subscriber"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/wms"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/availability"
 	"github.com/erp-cosmetics/sales-service/internal/usecase/customer"
+	einvoiceuc "github.com/erp-cosmetics/sales-service/internal/usecase/e_invoice"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/promotion"
 	"github.com/erp-cosmetics/sales-service/internal/usecase/quotation"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/receivable"
 	salesorder "github.com/erp-cosmetics/sales-service/internal/usecase/sales_order"
+	salesreturn "github.com/erp-cosmetics/sales-service/internal/usecase/sales_return"
 	"github.com/erp-cosmetics/sales-service/internal/usecase/shipment"
 	"github.com/nats-io/nats.go"
 	"go.uber.org/zap"
@@ -73,7 +85,30 @@ func main() {
 	quotationRepo := postgresrepo.NewQuotationRepository(db)
 	salesOrderRepo := postgresrepo.NewSalesOrderRepository(db)
 	shipmentRepo := postgresrepo.NewShipmentRepository(db)
-	// returnRepo := postgresrepo.NewReturnRepository(db) // For future use
+	returnRepo := postgresrepo.NewReturnRepository(db)
+	creditNoteRepo := postgresrepo.NewCreditNoteRepository(db)
+	priceListRepo := postgresrepo.NewPriceListRepository(db)
+	promotionRepo := postgresrepo.NewPromotionRepository(db)
+	invoiceRepo := postgresrepo.NewInvoiceRepository(db)
+	paymentRepo := postgresrepo.NewPaymentRepository(db)
+	einvoiceRepo := postgresrepo.NewEInvoiceRepository(db)
+	creditHoldRepo := postgresrepo.NewCreditHoldRepository(db)
+	transactor := postgresrepo.NewTransactor(db)
+
+	// Initialize e-invoice provider and signer
+	einvoiceProvider, einvoiceSigner := initEInvoice(cfg, zapLogger)
+	einvoiceBuilder := einvoice.NewBuilder(einvoice.SellerInfo{
+		Name:        cfg.SellerName,
+		TaxCode:     cfg.SellerTaxCode,
+		Address:     cfg.SellerAddress,
+		Phone:       cfg.SellerPhone,
+		Email:       cfg.SellerEmail,
+		BankAccount: cfg.SellerBankAccount,
+		BankName:    cfg.SellerBankName,
+	}, einvoiceProvider.TaxCode())
+
+	// Initialize shipping carriers
+	carriers := initCarriers(cfg, zapLogger)
 
 	// Initialize use cases - Customer
 	createCustomerUC := customer.NewCreateCustomerUseCase(customerRepo, eventPublisher)
@@ -81,23 +116,50 @@ func main() {
 	listCustomersUC := customer.NewListCustomersUseCase(customerRepo)
 	updateCustomerUC := customer.NewUpdateCustomerUseCase(customerRepo)
 	deleteCustomerUC := customer.NewDeleteCustomerUseCase(customerRepo)
-	checkCreditUC := customer.NewCheckCreditUseCase(customerRepo)
+	checkCreditUC := customer.NewCheckCreditUseCase(customerRepo, invoiceRepo)
+
+	// Initialize use cases - Pricing
+	createPriceListUC := pricing.NewCreatePriceListUseCase(priceListRepo)
+	getPriceListUC := pricing.NewGetPriceListUseCase(priceListRepo)
+	listPriceListsUC := pricing.NewListPriceListsUseCase(priceListRepo)
+	addPriceListItemUC := pricing.NewAddPriceListItemUseCase(priceListRepo)
+	deactivatePriceListUC := pricing.NewDeactivatePriceListUseCase(priceListRepo)
+	resolvePricesUC := pricing.NewResolvePricesUseCase(priceListRepo, customerRepo, masterdata.NewClient(cfg.MasterDataServiceURL))
+
+	// Initialize use cases - Availability
+	promiseDatesUC := availability.NewPromiseDatesUseCase(wms.NewClient(cfg.WMSServiceURL))
+
+	// Initialize use cases - Promotion
+	createPromotionUC := promotion.NewCreatePromotionUseCase(promotionRepo)
+	getPromotionUC := promotion.NewGetPromotionUseCase(promotionRepo)
+	listPromotionsUC := promotion.NewListPromotionsUseCase(promotionRepo)
+	deactivatePromotionUC := promotion.NewDeactivatePromotionUseCase(promotionRepo)
+	createVoucherUC := promotion.NewCreateVoucherUseCase(promotionRepo)
+	listVouchersUC := promotion.NewListVouchersUseCase(promotionRepo)
+	applyPromotionsUC := promotion.NewApplyPromotionsUseCase(promotionRepo, resolvePricesUC, eventPublisher)
 
 	// Initialize use cases - Quotation
-	createQuotationUC := quotation.NewCreateQuotationUseCase(quotationRepo, customerRepo)
+	createQuotationUC := quotation.NewCreateQuotationUseCase(quotationRepo, customerRepo, resolvePricesUC, promiseDatesUC)
 	getQuotationUC := quotation.NewGetQuotationUseCase(quotationRepo)
 	listQuotationsUC := quotation.NewListQuotationsUseCase(quotationRepo)
 	sendQuotationUC := quotation.NewSendQuotationUseCase(quotationRepo, eventPublisher)
 	convertToOrderUC := quotation.NewConvertToOrderUseCase(quotationRepo, salesOrderRepo, customerRepo, eventPublisher)
 
 	// Initialize use cases - Sales Order
-	createOrderUC := salesorder.NewCreateOrderUseCase(salesOrderRepo, customerRepo, eventPublisher)
+	createOrderUC := salesorder.NewCreateOrderUseCase(salesOrderRepo, customerRepo, resolvePricesUC, applyPromotionsUC, promiseDatesUC, transactor, eventPublisher)
 	getOrderUC := salesorder.NewGetOrderUseCase(salesOrderRepo)
 	listOrdersUC := salesorder.NewListOrdersUseCase(salesOrderRepo)
-	confirmOrderUC := salesorder.NewConfirmOrderUseCase(salesOrderRepo, customerRepo, eventPublisher, cfg.EnableCreditCheck)
-	cancelOrderUC := salesorder.NewCancelOrderUseCase(salesOrderRepo, customerRepo, eventPublisher)
+	confirmOrderUC := salesorder.NewConfirmOrderUseCase(salesOrderRepo, customerRepo, creditHoldRepo, checkCreditUC, transactor, eventPublisher, cfg.EnableCreditCheck)
+	cancelOrderUC := salesorder.NewCancelOrderUseCase(salesOrderRepo, customerRepo, creditHoldRepo, applyPromotionsUC, transactor, eventPublisher)
 	shipOrderUC := salesorder.NewShipOrderUseCase(salesOrderRepo, eventPublisher)
 	deliverOrderUC := salesorder.NewDeliverOrderUseCase(salesOrderRepo, eventPublisher)
+	approveCreditHoldUC := salesorder.NewApproveCreditHoldUseCase(creditHoldRepo, salesOrderRepo, confirmOrderUC, transactor, eventPublisher)
+	rejectCreditHoldUC := salesorder.NewRejectCreditHoldUseCase(creditHoldRepo, salesOrderRepo, transactor, eventPublisher)
+	getCreditHoldUC := salesorder.NewGetCreditHoldUseCase(creditHoldRepo)
+	listCreditHoldsUC := salesorder.NewListCreditHoldsUseCase(creditHoldRepo)
+	updateBackordersUC := salesorder.NewUpdateBackordersUseCase(salesOrderRepo, eventPublisher)
+	amendOrderUC := salesorder.NewAmendOrderUseCase(salesOrderRepo, customerRepo, shipmentRepo, resolvePricesUC, applyPromotionsUC, checkCreditUC, transactor, eventPublisher, cfg.EnableCreditCheck)
+	getAmendmentsUC := salesorder.NewGetAmendmentsUseCase(salesOrderRepo)
 
 	// Initialize use cases - Shipment
 	createShipmentUC := shipment.NewCreateShipmentUseCase(shipmentRepo, salesOrderRepo, eventPublisher)
@@ -105,6 +167,45 @@ func main() {
 	listShipmentsUC := shipment.NewListShipmentsUseCase(shipmentRepo)
 	shipShipmentUC := shipment.NewShipShipmentUseCase(shipmentRepo, salesOrderRepo, eventPublisher)
 	deliverShipmentUC := shipment.NewDeliverShipmentUseCase(shipmentRepo, salesOrderRepo, eventPublisher)
+	quoteRatesUC := shipment.NewQuoteRatesUseCase(shipmentRepo, carriers, carrier.Address{
+		Name:    cfg.SellerName,
+		Phone:   cfg.SellerPhone,
+		Address: cfg.SellerAddress,
+	})
+	bookCarrierUC := shipment.NewBookCarrierUseCase(shipmentRepo, quoteRatesUC, carriers)
+	applyTrackingUC := shipment.NewApplyTrackingUseCase(shipmentRepo, shipShipmentUC, deliverShipmentUC)
+	pollTrackingUC := shipment.NewPollTrackingUseCase(shipmentRepo, carriers, applyTrackingUC)
+	getTrackingEventsUC := shipment.NewGetTrackingEventsUseCase(shipmentRepo)
+	receiveTrackingWebhookUC := shipment.NewReceiveTrackingWebhookUseCase(carriers, applyTrackingUC)
+
+	// Initialize use cases - Receivable
+	createInvoiceUC := receivable.NewCreateInvoiceUseCase(invoiceRepo, shipmentRepo, salesOrderRepo, customerRepo, creditNoteRepo, transactor, eventPublisher)
+	getInvoiceUC := receivable.NewGetInvoiceUseCase(invoiceRepo)
+	listInvoicesUC := receivable.NewListInvoicesUseCase(invoiceRepo)
+	recordPaymentUC := receivable.NewRecordPaymentUseCase(paymentRepo, invoiceRepo, salesOrderRepo, customerRepo, creditNoteRepo, transactor, eventPublisher)
+	allocatePaymentUC := receivable.NewAllocatePaymentUseCase(paymentRepo, invoiceRepo, salesOrderRepo, creditNoteRepo, transactor)
+	getPaymentUC := receivable.NewGetPaymentUseCase(paymentRepo)
+	listPaymentsUC := receivable.NewListPaymentsUseCase(paymentRepo)
+	applyCreditNoteUC := receivable.NewApplyCreditNoteUseCase(creditNoteRepo, invoiceRepo, salesOrderRepo)
+	listCreditNotesUC := receivable.NewListCreditNotesUseCase(creditNoteRepo)
+	getStatementUC := receivable.NewGetStatementUseCase(customerRepo, invoiceRepo, paymentRepo, creditNoteRepo)
+	agingReportUC := receivable.NewAgingReportUseCase(invoiceRepo)
+
+	// Initialize use cases - E-Invoice
+	issueEInvoiceUC := einvoiceuc.NewIssueEInvoiceUseCase(einvoiceRepo, invoiceRepo, salesOrderRepo, einvoiceBuilder, einvoiceSigner, einvoiceProvider, cfg.EInvoiceSeries, eventPublisher)
+	cancelEInvoiceUC := einvoiceuc.NewCancelEInvoiceUseCase(einvoiceRepo, einvoiceProvider, eventPublisher)
+	getEInvoiceUC := einvoiceuc.NewGetEInvoiceUseCase(einvoiceRepo)
+	listEInvoicesUC := einvoiceuc.NewListEInvoicesUseCase(einvoiceRepo)
+
+	// Initialize use cases - Return
+	createReturnUC := salesreturn.NewCreateReturnUseCase(returnRepo, salesOrderRepo, shipmentRepo, eventPublisher)
+	getReturnUC := salesreturn.NewGetReturnUseCase(returnRepo)
+	listReturnsUC := salesreturn.NewListReturnsUseCase(returnRepo)
+	approveReturnUC := salesreturn.NewApproveReturnUseCase(returnRepo)
+	rejectReturnUC := salesreturn.NewRejectReturnUseCase(returnRepo)
+	receiveReturnUC := salesreturn.NewReceiveReturnUseCase(returnRepo, eventPublisher)
+	inspectReturnUC := salesreturn.NewInspectReturnUseCase(returnRepo)
+	completeReturnUC := salesreturn.NewCompleteReturnUseCase(returnRepo, salesOrderRepo, customerRepo, creditNoteRepo, applyCreditNoteUC, transactor, eventPublisher)
 
 	// Initialize HTTP handlers
 	customerHandler := handler.NewCustomerHandler(
@@ -135,6 +236,8 @@ func main() {
 		cancelOrderUC,
 		shipOrderUC,
 		deliverOrderUC,
+		amendOrderUC,
+		getAmendmentsUC,
 		salesOrderRepo,
 	)
 
@@ -144,14 +247,85 @@ func main() {
 		listShipmentsUC,
 		shipShipmentUC,
 		deliverShipmentUC,
+		quoteRatesUC,
+		bookCarrierUC,
+		pollTrackingUC,
+		getTrackingEventsUC,
+		receiveTrackingWebhookUC,
+	)
+
+	priceListHandler := handler.NewPriceListHandler(
+		createPriceListUC,
+		getPriceListUC,
+		listPriceListsUC,
+		addPriceListItemUC,
+		deactivatePriceListUC,
+		resolvePricesUC,
+	)
+
+	promotionHandler := handler.NewPromotionHandler(
+		createPromotionUC,
+		getPromotionUC,
+		listPromotionsUC,
+		deactivatePromotionUC,
+		createVoucherUC,
+		listVouchersUC,
+	)
+
+	returnHandler := handler.NewReturnHandler(
+		createReturnUC,
+		getReturnUC,
+		listReturnsUC,
+		approveReturnUC,
+		rejectReturnUC,
+		receiveReturnUC,
+		inspectReturnUC,
+		completeReturnUC,
+	)
+
+	receivableHandler := handler.NewReceivableHandler(
+		createInvoiceUC,
+		getInvoiceUC,
+		listInvoicesUC,
+		recordPaymentUC,
+		allocatePaymentUC,
+		getPaymentUC,
+		listPaymentsUC,
+		applyCreditNoteUC,
+		listCreditNotesUC,
+		getStatementUC,
+		agingReportUC,
+	)
+
+	einvoiceHandler := handler.NewEInvoiceHandler(
+		issueEInvoiceUC,
+		cancelEInvoiceUC,
+		getEInvoiceUC,
+		listEInvoicesUC,
+	)
+
+	creditHoldHandler := handler.NewCreditHoldHandler(
+		approveCreditHoldUC,
+		rejectCreditHoldUC,
+		getCreditHoldUC,
+		listCreditHoldsUC,
 	)
 
+	availabilityHandler := handler.NewAvailabilityHandler(promiseDatesUC)
+
 	// Create HTTP router
 	router := httpdelivery.NewRouter(
 		customerHandler,
 		quotationHandler,
 		salesOrderHandler,
 		shipmentHandler,
+		returnHandler,
+		priceListHandler,
+		promotionHandler,
+		receivableHandler,
+		einvoiceHandler,
+		creditHoldHandler,
+		availabilityHandler,
 	)
 
 	// Create HTTP server
@@ -190,12 +364,28 @@ func main() {
 		zap.String("grpc_port", cfg.GRPCPort),
 	)
 
+	// Start event subscriber
+	eventSub := subscriber.NewEventSubscriber(nc, zapLogger, updateBackordersUC)
+	if err := eventSub.Start(); err != nil {
+		zapLogger.Warn("Failed to start event subscriber", zap.Error(err))
+	}
+
+	// Poll carriers for tracking updates
+	pollCtx, stopPolling := context.WithCancel(context.Background())
+	if cfg.CarrierPollMinutes > 0 {
+		go runTrackingPoller(pollCtx, pollTrackingUC, time.Duration(cfg.CarrierPollMinutes)*time.Minute, zapLogger)
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	zapLogger.Info("Shutting down servers...")
 
+	// Stop event subscriber and tracking poller
+	eventSub.Stop()
+	stopPolling()
+
 	// Shutdown gRPC server
 	grpcServer.GracefulStop()
 	zapLogger.Info("gRPC server stopped")
@@ -251,6 +441,77 @@ func initLogger(level string) *zap.Logger {
 	return logger
 }
 
+// initEInvoice creates the e-invoice provider and the signer of the seller's
+// certificate. Without a certificate the local provider signs with a
+// throwaway self-signed one.
+func initEInvoice(cfg *config.Config, logger *zap.Logger) (einvoice.Provider, *einvoice.Signer) {
+	var provider einvoice.Provider
+	switch cfg.EInvoiceProvider {
+	case "local":
+		provider = einvoice.NewLocalProvider(logger)
+	default:
+		logger.Fatal("Unsupported e-invoice provider", zap.String("provider", cfg.EInvoiceProvider))
+	}
+
+	if cfg.EInvoiceCertFile != "" {
+		signer, err := einvoice.NewSigner(cfg.EInvoiceCertFile, cfg.EInvoiceKeyFile)
+		if err != nil {
+			logger.Fatal("Failed to load e-invoice certificate", zap.Error(err))
+		}
+		return provider, signer
+	}
+
+	if provider.Name() != "local" {
+		logger.Warn("E-invoice certificate not configured, e-invoices cannot be issued")
+		return provider, nil
+	}
+	logger.Warn("E-invoice certificate not configured, signing with a self-signed certificate")
+	signer, err := einvoice.NewSelfSignedSigner(cfg.SellerName)
+	if err != nil {
+		logger.Fatal("Failed to create self-signed e-invoice certificate", zap.Error(err))
+	}
+	return provider, signer
+}
+
+// initCarriers creates the shipping carriers listed in CARRIERS
+func initCarriers(cfg *config.Config, logger *zap.Logger) *carrier.Registry {
+	var carriers []carrier.Carrier
+	for _, name := range strings.Split(cfg.Carriers, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "local":
+			carriers = append(carriers, carrier.NewLocalCarrier(logger, cfg.CarrierWebhookSecret))
+		default:
+			logger.Fatal("Unsupported carrier", zap.String("carrier", name))
+		}
+	}
+	if cfg.CarrierWebhookSecret == "" && len(carriers) > 0 {
+		logger.Warn("Carrier webhook secret not configured, webhooks are rejected")
+	}
+	return carrier.NewRegistry(carriers...)
+}
+
+// runTrackingPoller tracks booked shipments with their carriers every
+// interval until ctx is cancelled
+func runTrackingPoller(ctx context.Context, uc *shipment.PollTrackingUseCase, interval time.Duration, logger *zap.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tracked, err := uc.Execute(ctx)
+			if err != nil {
+				logger.Warn("Failed to track some shipments", zap.Int("tracked", tracked), zap.Error(err))
+				continue
+			}
+			logger.Debug("Shipments tracked", zap.Int("tracked", tracked))
+		}
+	}
+}
+
 func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 	dsn := cfg.GetDSN()
 
@@ -277,8 +538,26 @@ func initDatabase(cfg *config.Config) (*gorm.DB, error) {
 		&entity.SalesOrder{},
 		&entity.SOLineItem{},
 		&entity.Shipment{},
+		&entity.ShipmentLineItem{},
 		&entity.Return{},
 		&entity.ReturnLineItem{},
+		&entity.CreditNote{},
+		&entity.PriceList{},
+		&entity.PriceListItem{},
+		&entity.Promotion{},
+		&entity.PromotionBundleItem{},
+		&entity.PromotionTier{},
+		&entity.Voucher{},
+		&entity.OrderPromotion{},
+		&entity.Invoice{},
+		&entity.InvoiceLineItem{},
+		&entity.Payment{},
+		&entity.InvoiceAllocation{},
+		&entity.EInvoice{},
+		&entity.CreditHold{},
+		&entity.SOAmendment{},
+		&entity.SOLineAmendment{},
+		&entity.ShipmentTrackingEvent{},
 	); err != nil {
 		return nil, err
 	}
@@ -34,6 +34,28 @@ type Config struct {
 	EnableCreditCheck    bool `mapstructure:"ENABLE_CREDIT_CHECK"`
 	AutoReserveOnConfirm bool `mapstructure:"AUTO_RESERVE_ON_CONFIRM"`
 	AllowNegativeStock   bool `mapstructure:"ALLOW_NEGATIVE_STOCK"`
+
+	// E-invoice (Decree 123/Circular 78)
+	EInvoiceProvider  string `mapstructure:"EINVOICE_PROVIDER"`
+	EInvoiceSeries    string `mapstructure:"EINVOICE_SERIES"` // Empty uses C<yy>TAA of the issue year
+	EInvoiceCertFile  string `mapstructure:"EINVOICE_CERT_FILE"`
+	EInvoiceKeyFile   string `mapstructure:"EINVOICE_KEY_FILE"`
+	SellerName        string `mapstructure:"SELLER_NAME"`
+	SellerTaxCode     string `mapstructure:"SELLER_TAX_CODE"`
+	SellerAddress     string `mapstructure:"SELLER_ADDRESS"`
+	SellerPhone       string `mapstructure:"SELLER_PHONE"`
+	SellerEmail       string `mapstructure:"SELLER_EMAIL"`
+	SellerBankAccount string `mapstructure:"SELLER_BANK_ACCOUNT"`
+	SellerBankName    string `mapstructure:"SELLER_BANK_NAME"`
+
+	// Shipping carriers, sending from the seller's name, phone and address
+	Carriers             string `mapstructure:"CARRIERS"`               // Comma-separated
+	CarrierWebhookSecret string `mapstructure:"CARRIER_WEBHOOK_SECRET"` // Webhooks are rejected when empty
+	CarrierPollMinutes   int    `mapstructure:"CARRIER_POLL_MINUTES"`   // Zero tracks by webhook only
+
+	// Services
+	WMSServiceURL        string `mapstructure:"WMS_SERVICE_URL"`         // Available-to-promise delivery dates
+	MasterDataServiceURL string `mapstructure:"MASTER_DATA_SERVICE_URL"` // Standard prices of products without a list price
 }
 
 // LoadConfig loads configuration from environment
@@ -58,6 +80,22 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("ENABLE_CREDIT_CHECK", true)
 	viper.SetDefault("AUTO_RESERVE_ON_CONFIRM", true)
 	viper.SetDefault("ALLOW_NEGATIVE_STOCK", false)
+	viper.SetDefault("EINVOICE_PROVIDER", "local")
+	viper.SetDefault("EINVOICE_SERIES", "")
+	viper.SetDefault("EINVOICE_CERT_FILE", "")
+	viper.SetDefault("EINVOICE_KEY_FILE", "")
+	viper.SetDefault("SELLER_NAME", "")
+	viper.SetDefault("SELLER_TAX_CODE", "")
+	viper.SetDefault("SELLER_ADDRESS", "")
+	viper.SetDefault("SELLER_PHONE", "")
+	viper.SetDefault("SELLER_EMAIL", "")
+	viper.SetDefault("SELLER_BANK_ACCOUNT", "")
+	viper.SetDefault("SELLER_BANK_NAME", "")
+	viper.SetDefault("CARRIERS", "local")
+	viper.SetDefault("CARRIER_WEBHOOK_SECRET", "")
+	viper.SetDefault("CARRIER_POLL_MINUTES", 30)
+	viper.SetDefault("WMS_SERVICE_URL", "http://localhost:8086")
+	viper.SetDefault("MASTER_DATA_SERVICE_URL", "http://localhost:8083")
 
 	// Read config file (optional)
 
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// creditManagerRoles may approve or reject orders held for credit review
+var creditManagerRoles = []string{"Credit Manager", "Super Admin"}
+
+// currentUser returns the authenticated user, set in the context by an auth
+// middleware or forwarded by the API Gateway in the X-User-ID header
+func currentUser(c *gin.Context) (uuid.UUID, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		userID = c.GetHeader("X-User-ID")
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// hasAnyRole reports whether the user holds one of the given roles, as
+// forwarded by the API Gateway in the X-User-Roles header
+func hasAnyRole(c *gin.Context, roles ...string) bool {
+	for _, held := range strings.Split(c.GetHeader("X-User-Roles"), ",") {
+		held = strings.TrimSpace(held)
+		for _, role := range roles {
+			if strings.EqualFold(held, role) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/erp-cosmetics/sales-service/internal/usecase/availability"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AvailabilityHandler handles delivery date quoting HTTP requests
+type AvailabilityHandler struct {
+	promiseDates *availability.PromiseDatesUseCase
+}
+
+// NewAvailabilityHandler creates a new availability handler
+func NewAvailabilityHandler(promiseDates *availability.PromiseDatesUseCase) *AvailabilityHandler {
+	return &AvailabilityHandler{promiseDates: promiseDates}
+}
+
+// PromiseDatesRequest represents a delivery date lookup for quotation or order entry
+type PromiseDatesRequest struct {
+	Items []struct {
+		ProductID uuid.UUID `json:"product_id" binding:"required"`
+		Quantity  float64   `json:"quantity" binding:"required,gt=0"`
+	} `json:"items" binding:"required,min=1,dive"`
+}
+
+// PromiseDates handles POST /availability/promise
+func (h *AvailabilityHandler) PromiseDates(c *gin.Context) {
+	var req PromiseDatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	items := make([]availability.PromiseQueryItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = availability.PromiseQueryItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	result, err := h.promiseDates.Execute(c.Request.Context(), items)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, result)
+}
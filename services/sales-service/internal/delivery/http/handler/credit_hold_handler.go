@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	salesorder "github.com/erp-cosmetics/sales-service/internal/usecase/sales_order"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreditHoldHandler handles credit hold HTTP requests
+type CreditHoldHandler struct {
+	approveCreditHold *salesorder.ApproveCreditHoldUseCase
+	rejectCreditHold  *salesorder.RejectCreditHoldUseCase
+	getCreditHold     *salesorder.GetCreditHoldUseCase
+	listCreditHolds   *salesorder.ListCreditHoldsUseCase
+}
+
+// NewCreditHoldHandler creates a new credit hold handler
+func NewCreditHoldHandler(
+	approveCreditHold *salesorder.ApproveCreditHoldUseCase,
+	rejectCreditHold *salesorder.RejectCreditHoldUseCase,
+	getCreditHold *salesorder.GetCreditHoldUseCase,
+	listCreditHolds *salesorder.ListCreditHoldsUseCase,
+) *CreditHoldHandler {
+	return &CreditHoldHandler{
+		approveCreditHold: approveCreditHold,
+		rejectCreditHold:  rejectCreditHold,
+		getCreditHold:     getCreditHold,
+		listCreditHolds:   listCreditHolds,
+	}
+}
+
+// ListCreditHolds handles GET /credit-holds
+func (h *CreditHoldHandler) ListCreditHolds(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.CreditHoldFilter{
+		Status: entity.CreditHoldStatus(c.Query("status")),
+		Page:   page,
+		Limit:  limit,
+	}
+
+	if customerID := c.Query("customer_id"); customerID != "" {
+		if id, err := uuid.Parse(customerID); err == nil {
+			filter.CustomerID = &id
+		}
+	}
+	if orderID := c.Query("sales_order_id"); orderID != "" {
+		if id, err := uuid.Parse(orderID); err == nil {
+			filter.SalesOrderID = &id
+		}
+	}
+
+	results, total, err := h.listCreditHolds.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// GetCreditHold handles GET /credit-holds/:id
+func (h *CreditHoldHandler) GetCreditHold(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid credit hold ID"))
+		return
+	}
+
+	result, err := h.getCreditHold.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("credit hold"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ReviewCreditHoldRequest represents approve or reject credit hold request
+type ReviewCreditHoldRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ApproveCreditHold handles PATCH /credit-holds/:id/approve
+func (h *CreditHoldHandler) ApproveCreditHold(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid credit hold ID"))
+		return
+	}
+
+	var req ReviewCreditHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+	if !hasAnyRole(c, creditManagerRoles...) {
+		response.Error(c, errors.Forbidden("only a credit manager can review credit holds"))
+		return
+	}
+
+	result, err := h.approveCreditHold.Execute(c.Request.Context(), id, req.Reason, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RejectCreditHold handles PATCH /credit-holds/:id/reject
+func (h *CreditHoldHandler) RejectCreditHold(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid credit hold ID"))
+		return
+	}
+
+	var req ReviewCreditHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+	if !hasAnyRole(c, creditManagerRoles...) {
+		response.Error(c, errors.Forbidden("only a credit manager can review credit holds"))
+		return
+	}
+
+	result, err := h.rejectCreditHold.Execute(c.Request.Context(), id, req.Reason, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	einvoiceuc "github.com/erp-cosmetics/sales-service/internal/usecase/e_invoice"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// EInvoiceHandler handles e-invoice HTTP requests
+type EInvoiceHandler struct {
+	issueEInvoice  *einvoiceuc.IssueEInvoiceUseCase
+	cancelEInvoice *einvoiceuc.CancelEInvoiceUseCase
+	getEInvoice    *einvoiceuc.GetEInvoiceUseCase
+	listEInvoices  *einvoiceuc.ListEInvoicesUseCase
+}
+
+// NewEInvoiceHandler creates a new e-invoice handler
+func NewEInvoiceHandler(
+	issueEInvoice *einvoiceuc.IssueEInvoiceUseCase,
+	cancelEInvoice *einvoiceuc.CancelEInvoiceUseCase,
+	getEInvoice *einvoiceuc.GetEInvoiceUseCase,
+	listEInvoices *einvoiceuc.ListEInvoicesUseCase,
+) *EInvoiceHandler {
+	return &EInvoiceHandler{
+		issueEInvoice:  issueEInvoice,
+		cancelEInvoice: cancelEInvoice,
+		getEInvoice:    getEInvoice,
+		listEInvoices:  listEInvoices,
+	}
+}
+
+// IssueEInvoice handles POST /invoices/:id/e-invoice
+func (h *EInvoiceHandler) IssueEInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid invoice ID"))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.issueEInvoice.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// GetEInvoice handles GET /e-invoices/:id
+func (h *EInvoiceHandler) GetEInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid e-invoice ID"))
+		return
+	}
+
+	result, err := h.getEInvoice.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("e-invoice"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// DownloadXML handles GET /e-invoices/:id/xml
+func (h *EInvoiceHandler) DownloadXML(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid e-invoice ID"))
+		return
+	}
+
+	result, err := h.getEInvoice.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("e-invoice"))
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s_%08d.xml", result.TemplateCode, result.Series, result.Number)
+	c.Header("Content-Disposition", "attachment; filename="+filename)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", []byte(result.XMLContent))
+}
+
+// ListEInvoices handles GET /e-invoices
+func (h *EInvoiceHandler) ListEInvoices(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.EInvoiceFilter{
+		Status:   entity.EInvoiceStatus(c.Query("status")),
+		DateFrom: c.Query("date_from"),
+		DateTo:   c.Query("date_to"),
+		Page:     page,
+		Limit:    limit,
+	}
+
+	if invoiceID := c.Query("invoice_id"); invoiceID != "" {
+		if id, err := uuid.Parse(invoiceID); err == nil {
+			filter.InvoiceID = &id
+		}
+	}
+
+	results, total, err := h.listEInvoices.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// CancelEInvoiceRequest represents cancel e-invoice request
+type CancelEInvoiceRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CancelEInvoice handles PATCH /e-invoices/:id/cancel
+func (h *EInvoiceHandler) CancelEInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid e-invoice ID"))
+		return
+	}
+
+	var req CancelEInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.cancelEInvoice.Execute(c.Request.Context(), id, req.Reason, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PriceListHandler handles price list and pricing HTTP requests
+type PriceListHandler struct {
+	createPriceList     *pricing.CreatePriceListUseCase
+	getPriceList        *pricing.GetPriceListUseCase
+	listPriceLists      *pricing.ListPriceListsUseCase
+	addPriceListItem    *pricing.AddPriceListItemUseCase
+	deactivatePriceList *pricing.DeactivatePriceListUseCase
+	resolvePrices       *pricing.ResolvePricesUseCase
+}
+
+// NewPriceListHandler creates a new price list handler
+func NewPriceListHandler(
+	createPriceList *pricing.CreatePriceListUseCase,
+	getPriceList *pricing.GetPriceListUseCase,
+	listPriceLists *pricing.ListPriceListsUseCase,
+	addPriceListItem *pricing.AddPriceListItemUseCase,
+	deactivatePriceList *pricing.DeactivatePriceListUseCase,
+	resolvePrices *pricing.ResolvePricesUseCase,
+) *PriceListHandler {
+	return &PriceListHandler{
+		createPriceList:     createPriceList,
+		getPriceList:        getPriceList,
+		listPriceLists:      listPriceLists,
+		addPriceListItem:    addPriceListItem,
+		deactivatePriceList: deactivatePriceList,
+		resolvePrices:       resolvePrices,
+	}
+}
+
+// PriceListItemRequest represents a product price request
+type PriceListItemRequest struct {
+	ProductID   uuid.UUID `json:"product_id" binding:"required"`
+	ProductCode string    `json:"product_code"`
+	MinQuantity float64   `json:"min_quantity" binding:"gte=0"`
+	UnitPrice   float64   `json:"unit_price" binding:"required,gt=0"`
+}
+
+// CreatePriceListRequest represents create price list request
+type CreatePriceListRequest struct {
+	Code            string                 `json:"code" binding:"required"`
+	Name            string                 `json:"name" binding:"required"`
+	ListType        string                 `json:"list_type"`
+	CustomerGroupID *uuid.UUID             `json:"customer_group_id"`
+	CustomerID      *uuid.UUID             `json:"customer_id"`
+	Channel         string                 `json:"channel"`
+	Currency        string                 `json:"currency"`
+	CampaignID      *uuid.UUID             `json:"campaign_id"`
+	Priority        int                    `json:"priority"`
+	ValidFrom       string                 `json:"valid_from" binding:"required"`
+	ValidTo         string                 `json:"valid_to"`
+	Notes           string                 `json:"notes"`
+	Items           []PriceListItemRequest `json:"items" binding:"dive"`
+}
+
+// CreatePriceList handles POST /price-lists
+func (h *PriceListHandler) CreatePriceList(c *gin.Context) {
+	var req CreatePriceListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	validFrom, err := time.Parse("2006-01-02", req.ValidFrom)
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid valid_from"))
+		return
+	}
+	var validTo *time.Time
+	if req.ValidTo != "" {
+		t, err := time.Parse("2006-01-02", req.ValidTo)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid valid_to"))
+			return
+		}
+		validTo = &t
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &pricing.CreatePriceListInput{
+		Code:            req.Code,
+		Name:            req.Name,
+		ListType:        entity.PriceListType(req.ListType),
+		CustomerGroupID: req.CustomerGroupID,
+		CustomerID:      req.CustomerID,
+		Channel:         entity.SalesChannel(req.Channel),
+		Currency:        req.Currency,
+		CampaignID:      req.CampaignID,
+		Priority:        req.Priority,
+		ValidFrom:       validFrom,
+		ValidTo:         validTo,
+		Notes:           req.Notes,
+		CreatedBy:       &userID,
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, pricing.PriceListItemInput{
+			ProductID:   item.ProductID,
+			ProductCode: item.ProductCode,
+			MinQuantity: item.MinQuantity,
+			UnitPrice:   item.UnitPrice,
+		})
+	}
+
+	result, err := h.createPriceList.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// GetPriceList handles GET /price-lists/:id
+func (h *PriceListHandler) GetPriceList(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid price list ID"))
+		return
+	}
+
+	result, err := h.getPriceList.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("price list"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListPriceLists handles GET /price-lists
+func (h *PriceListHandler) ListPriceLists(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.PriceListFilter{
+		Search:     c.Query("search"),
+		ListType:   entity.PriceListType(c.Query("list_type")),
+		Channel:    entity.SalesChannel(c.Query("channel")),
+		ActiveOnly: c.Query("active_only") == "true",
+		Page:       page,
+		Limit:      limit,
+	}
+
+	if groupID := c.Query("customer_group_id"); groupID != "" {
+		if id, err := uuid.Parse(groupID); err == nil {
+			filter.CustomerGroupID = &id
+		}
+	}
+	if customerID := c.Query("customer_id"); customerID != "" {
+		if id, err := uuid.Parse(customerID); err == nil {
+			filter.CustomerID = &id
+		}
+	}
+	if campaignID := c.Query("campaign_id"); campaignID != "" {
+		if id, err := uuid.Parse(campaignID); err == nil {
+			filter.CampaignID = &id
+		}
+	}
+
+	results, total, err := h.listPriceLists.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// AddPriceListItem handles POST /price-lists/:id/items
+func (h *PriceListHandler) AddPriceListItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid price list ID"))
+		return
+	}
+
+	var req PriceListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	input := &pricing.PriceListItemInput{
+		ProductID:   req.ProductID,
+		ProductCode: req.ProductCode,
+		MinQuantity: req.MinQuantity,
+		UnitPrice:   req.UnitPrice,
+	}
+
+	result, err := h.addPriceListItem.Execute(c.Request.Context(), id, input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// DeactivatePriceList handles PATCH /price-lists/:id/deactivate
+func (h *PriceListHandler) DeactivatePriceList(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid price list ID"))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.deactivatePriceList.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ResolvePricesRequest represents a price lookup request
+type ResolvePricesRequest struct {
+	CustomerID uuid.UUID `json:"customer_id" binding:"required"`
+	Channel    string    `json:"channel"`
+	Date       string    `json:"date"`
+	Items      []struct {
+		ProductID uuid.UUID `json:"product_id" binding:"required"`
+		Quantity  float64   `json:"quantity" binding:"required,gt=0"`
+	} `json:"items" binding:"required,min=1,dive"`
+}
+
+// ResolvePrices handles POST /pricing/resolve
+func (h *PriceListHandler) ResolvePrices(c *gin.Context) {
+	var req ResolvePricesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	date := time.Now()
+	if req.Date != "" {
+		date, _ = time.Parse("2006-01-02", req.Date)
+	}
+
+	query := &pricing.PriceQuery{
+		CustomerID: req.CustomerID,
+		Channel:    entity.SalesChannel(req.Channel),
+		Date:       date,
+	}
+	for _, item := range req.Items {
+		query.Items = append(query.Items, pricing.PriceQueryItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	result, err := h.resolvePrices.Execute(c.Request.Context(), query)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
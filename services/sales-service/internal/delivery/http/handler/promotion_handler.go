@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/promotion"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PromotionHandler handles promotion and voucher HTTP requests
+type PromotionHandler struct {
+	createPromotion     *promotion.CreatePromotionUseCase
+	getPromotion        *promotion.GetPromotionUseCase
+	listPromotions      *promotion.ListPromotionsUseCase
+	deactivatePromotion *promotion.DeactivatePromotionUseCase
+	createVoucher       *promotion.CreateVoucherUseCase
+	listVouchers        *promotion.ListVouchersUseCase
+}
+
+// NewPromotionHandler creates a new promotion handler
+func NewPromotionHandler(
+	createPromotion *promotion.CreatePromotionUseCase,
+	getPromotion *promotion.GetPromotionUseCase,
+	listPromotions *promotion.ListPromotionsUseCase,
+	deactivatePromotion *promotion.DeactivatePromotionUseCase,
+	createVoucher *promotion.CreateVoucherUseCase,
+	listVouchers *promotion.ListVouchersUseCase,
+) *PromotionHandler {
+	return &PromotionHandler{
+		createPromotion:     createPromotion,
+		getPromotion:        getPromotion,
+		listPromotions:      listPromotions,
+		deactivatePromotion: deactivatePromotion,
+		createVoucher:       createVoucher,
+		listVouchers:        listVouchers,
+	}
+}
+
+// CreatePromotionRequest represents create promotion request
+type CreatePromotionRequest struct {
+	Code            string     `json:"code" binding:"required"`
+	Name            string     `json:"name" binding:"required"`
+	PromotionType   string     `json:"promotion_type" binding:"required"`
+	CampaignID      *uuid.UUID `json:"campaign_id"`
+	CustomerGroupID *uuid.UUID `json:"customer_group_id"`
+	Channel         string     `json:"channel"`
+	ValidFrom       string     `json:"valid_from" binding:"required"`
+	ValidTo         string     `json:"valid_to"`
+	Priority        int        `json:"priority"`
+	RequiresVoucher bool       `json:"requires_voucher"`
+	BuyProductID    *uuid.UUID `json:"buy_product_id"`
+	BuyQuantity     float64    `json:"buy_quantity"`
+	FreeProductID   *uuid.UUID `json:"free_product_id"` // Omit to give the bought product
+	FreeProductCode string     `json:"free_product_code"`
+	FreeProductName string     `json:"free_product_name"`
+	FreeQuantity    float64    `json:"free_quantity"`
+	MaxApplications int        `json:"max_applications"`
+	BundlePrice     float64    `json:"bundle_price"`
+	BundleItems     []struct {
+		ProductID   uuid.UUID `json:"product_id" binding:"required"`
+		ProductCode string    `json:"product_code"`
+		Quantity    float64   `json:"quantity" binding:"required,gt=0"`
+	} `json:"bundle_items" binding:"dive"`
+	Tiers []struct {
+		MinAmount       float64 `json:"min_amount" binding:"gte=0"`
+		DiscountPercent float64 `json:"discount_percent" binding:"gte=0,lte=100"`
+		DiscountAmount  float64 `json:"discount_amount" binding:"gte=0"`
+	} `json:"tiers" binding:"dive"`
+	Budget float64 `json:"budget" binding:"gte=0"`
+	Notes  string  `json:"notes"`
+}
+
+// CreatePromotion handles POST /promotions
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	var req CreatePromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	validFrom, err := time.Parse("2006-01-02", req.ValidFrom)
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid valid_from"))
+		return
+	}
+	var validTo *time.Time
+	if req.ValidTo != "" {
+		t, err := time.Parse("2006-01-02", req.ValidTo)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid valid_to"))
+			return
+		}
+		validTo = &t
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &promotion.CreatePromotionInput{
+		Code:            req.Code,
+		Name:            req.Name,
+		PromotionType:   entity.PromotionType(req.PromotionType),
+		CampaignID:      req.CampaignID,
+		CustomerGroupID: req.CustomerGroupID,
+		Channel:         entity.SalesChannel(req.Channel),
+		ValidFrom:       validFrom,
+		ValidTo:         validTo,
+		Priority:        req.Priority,
+		RequiresVoucher: req.RequiresVoucher,
+		BuyProductID:    req.BuyProductID,
+		BuyQuantity:     req.BuyQuantity,
+		FreeProductID:   req.FreeProductID,
+		FreeProductCode: req.FreeProductCode,
+		FreeProductName: req.FreeProductName,
+		FreeQuantity:    req.FreeQuantity,
+		MaxApplications: req.MaxApplications,
+		BundlePrice:     req.BundlePrice,
+		Budget:          req.Budget,
+		Notes:           req.Notes,
+		CreatedBy:       &userID,
+	}
+	for _, item := range req.BundleItems {
+		input.BundleItems = append(input.BundleItems, promotion.BundleItemInput{
+			ProductID:   item.ProductID,
+			ProductCode: item.ProductCode,
+			Quantity:    item.Quantity,
+		})
+	}
+	for _, tier := range req.Tiers {
+		input.Tiers = append(input.Tiers, promotion.TierInput{
+			MinAmount:       tier.MinAmount,
+			DiscountPercent: tier.DiscountPercent,
+			DiscountAmount:  tier.DiscountAmount,
+		})
+	}
+
+	result, err := h.createPromotion.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// GetPromotion handles GET /promotions/:id
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid promotion ID"))
+		return
+	}
+
+	result, err := h.getPromotion.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("promotion"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListPromotions handles GET /promotions
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.PromotionFilter{
+		Search:        c.Query("search"),
+		PromotionType: entity.PromotionType(c.Query("promotion_type")),
+		ActiveOnly:    c.Query("active_only") == "true",
+		Page:          page,
+		Limit:         limit,
+	}
+
+	if campaignID := c.Query("campaign_id"); campaignID != "" {
+		if id, err := uuid.Parse(campaignID); err == nil {
+			filter.CampaignID = &id
+		}
+	}
+
+	results, total, err := h.listPromotions.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// DeactivatePromotion handles PATCH /promotions/:id/deactivate
+func (h *PromotionHandler) DeactivatePromotion(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid promotion ID"))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.deactivatePromotion.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CreateVoucherRequest represents create voucher request
+type CreateVoucherRequest struct {
+	Code       string     `json:"code" binding:"required"`
+	CustomerID *uuid.UUID `json:"customer_id"`
+	MaxUses    *int       `json:"max_uses" binding:"omitempty,gte=0"` // Defaults to single-use; 0 is unlimited
+	ValidTo    string     `json:"valid_to"`
+}
+
+// CreateVoucher handles POST /promotions/:id/vouchers
+func (h *PromotionHandler) CreateVoucher(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid promotion ID"))
+		return
+	}
+
+	var req CreateVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	maxUses := 1
+	if req.MaxUses != nil {
+		maxUses = *req.MaxUses
+	}
+	var validTo *time.Time
+	if req.ValidTo != "" {
+		t, err := time.Parse("2006-01-02", req.ValidTo)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid valid_to"))
+			return
+		}
+		validTo = &t
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &promotion.CreateVoucherInput{
+		PromotionID: id,
+		Code:        req.Code,
+		CustomerID:  req.CustomerID,
+		MaxUses:     maxUses,
+		ValidTo:     validTo,
+		CreatedBy:   &userID,
+	}
+
+	result, err := h.createVoucher.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// ListVouchers handles GET /promotions/:id/vouchers
+func (h *PromotionHandler) ListVouchers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid promotion ID"))
+		return
+	}
+
+	results, err := h.listVouchers.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, results)
+}
@@ -49,7 +49,7 @@ type QuotationItemRequest struct {
 	ProductName     string     `json:"product_name"`
 	Quantity        float64    `json:"quantity" binding:"required,gt=0"`
 	UomID           *uuid.UUID `json:"uom_id"`
-	UnitPrice       float64    `json:"unit_price" binding:"required,gte=0"`
+	UnitPrice       *float64   `json:"unit_price" binding:"omitempty,gte=0"` // Omit to price from the price lists; zero is a free line
 	DiscountPercent float64    `json:"discount_percent"`
 	TaxPercent      float64    `json:"tax_percent"`
 	Notes           string     `json:"notes"`
@@ -60,6 +60,7 @@ type CreateQuotationRequest struct {
 	CustomerID         uuid.UUID              `json:"customer_id" binding:"required"`
 	QuotationDate      string                 `json:"quotation_date" binding:"required"`
 	ValidUntil         string                 `json:"valid_until" binding:"required"`
+	Channel            string                 `json:"channel"`
 	DiscountPercent    float64                `json:"discount_percent"`
 	DiscountAmount     float64                `json:"discount_amount"`
 	TaxPercent         float64                `json:"tax_percent"`
@@ -98,6 +99,7 @@ func (h *QuotationHandler) CreateQuotation(c *gin.Context) {
 		CustomerID:         req.CustomerID,
 		QuotationDate:      quotationDate,
 		ValidUntil:         validUntil,
+		Channel:            entity.SalesChannel(req.Channel),
 		DiscountPercent:    req.DiscountPercent,
 		DiscountAmount:     req.DiscountAmount,
 		TaxPercent:         req.TaxPercent,
@@ -0,0 +1,411 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/receivable"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReceivableHandler handles invoice, payment and customer ledger HTTP requests
+type ReceivableHandler struct {
+	createInvoice   *receivable.CreateInvoiceUseCase
+	getInvoice      *receivable.GetInvoiceUseCase
+	listInvoices    *receivable.ListInvoicesUseCase
+	recordPayment   *receivable.RecordPaymentUseCase
+	allocatePayment *receivable.AllocatePaymentUseCase
+	getPayment      *receivable.GetPaymentUseCase
+	listPayments    *receivable.ListPaymentsUseCase
+	applyCreditNote *receivable.ApplyCreditNoteUseCase
+	listCreditNotes *receivable.ListCreditNotesUseCase
+	getStatement    *receivable.GetStatementUseCase
+	agingReport     *receivable.AgingReportUseCase
+}
+
+// NewReceivableHandler creates a new receivable handler
+func NewReceivableHandler(
+	createInvoice *receivable.CreateInvoiceUseCase,
+	getInvoice *receivable.GetInvoiceUseCase,
+	listInvoices *receivable.ListInvoicesUseCase,
+	recordPayment *receivable.RecordPaymentUseCase,
+	allocatePayment *receivable.AllocatePaymentUseCase,
+	getPayment *receivable.GetPaymentUseCase,
+	listPayments *receivable.ListPaymentsUseCase,
+	applyCreditNote *receivable.ApplyCreditNoteUseCase,
+	listCreditNotes *receivable.ListCreditNotesUseCase,
+	getStatement *receivable.GetStatementUseCase,
+	agingReport *receivable.AgingReportUseCase,
+) *ReceivableHandler {
+	return &ReceivableHandler{
+		createInvoice:   createInvoice,
+		getInvoice:      getInvoice,
+		listInvoices:    listInvoices,
+		recordPayment:   recordPayment,
+		allocatePayment: allocatePayment,
+		getPayment:      getPayment,
+		listPayments:    listPayments,
+		applyCreditNote: applyCreditNote,
+		listCreditNotes: listCreditNotes,
+		getStatement:    getStatement,
+		agingReport:     agingReport,
+	}
+}
+
+// CreateInvoiceRequest represents create invoice request
+type CreateInvoiceRequest struct {
+	ShipmentID  uuid.UUID `json:"shipment_id" binding:"required"`
+	InvoiceDate string    `json:"invoice_date"` // Defaults to today
+	Notes       string    `json:"notes"`
+}
+
+// CreateInvoice handles POST /invoices
+func (h *ReceivableHandler) CreateInvoice(c *gin.Context) {
+	var req CreateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	var invoiceDate *time.Time
+	if req.InvoiceDate != "" {
+		t, err := time.Parse("2006-01-02", req.InvoiceDate)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid invoice_date"))
+			return
+		}
+		invoiceDate = &t
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &receivable.CreateInvoiceInput{
+		ShipmentID:  req.ShipmentID,
+		InvoiceDate: invoiceDate,
+		Notes:       req.Notes,
+		CreatedBy:   userID,
+	}
+
+	result, err := h.createInvoice.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// GetInvoice handles GET /invoices/:id
+func (h *ReceivableHandler) GetInvoice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid invoice ID"))
+		return
+	}
+
+	result, err := h.getInvoice.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("invoice"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListInvoices handles GET /invoices
+func (h *ReceivableHandler) ListInvoices(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.InvoiceFilter{
+		Status:      entity.InvoiceStatus(c.Query("status")),
+		OverdueOnly: c.Query("overdue_only") == "true",
+		DateFrom:    c.Query("date_from"),
+		DateTo:      c.Query("date_to"),
+		Page:        page,
+		Limit:       limit,
+	}
+
+	if customerID := c.Query("customer_id"); customerID != "" {
+		if id, err := uuid.Parse(customerID); err == nil {
+			filter.CustomerID = &id
+		}
+	}
+	if salesOrderID := c.Query("sales_order_id"); salesOrderID != "" {
+		if id, err := uuid.Parse(salesOrderID); err == nil {
+			filter.SalesOrderID = &id
+		}
+	}
+
+	results, total, err := h.listInvoices.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// AllocationRequest represents part of a payment settling one invoice
+type AllocationRequest struct {
+	InvoiceID uuid.UUID `json:"invoice_id" binding:"required"`
+	Amount    float64   `json:"amount" binding:"required,gt=0"`
+}
+
+// RecordPaymentRequest represents record payment request
+type RecordPaymentRequest struct {
+	CustomerID    uuid.UUID           `json:"customer_id" binding:"required"`
+	PaymentDate   string              `json:"payment_date"` // Defaults to today
+	Amount        float64             `json:"amount" binding:"required,gt=0"`
+	PaymentMethod string              `json:"payment_method"`
+	Reference     string              `json:"reference"`
+	Notes         string              `json:"notes"`
+	Allocations   []AllocationRequest `json:"allocations" binding:"dive"` // Omit to allocate to the oldest invoices first
+}
+
+// RecordPayment handles POST /payments
+func (h *ReceivableHandler) RecordPayment(c *gin.Context) {
+	var req RecordPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	var paymentDate *time.Time
+	if req.PaymentDate != "" {
+		t, err := time.Parse("2006-01-02", req.PaymentDate)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid payment_date"))
+			return
+		}
+		paymentDate = &t
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &receivable.RecordPaymentInput{
+		CustomerID:    req.CustomerID,
+		PaymentDate:   paymentDate,
+		Amount:        req.Amount,
+		PaymentMethod: entity.PaymentMethod(req.PaymentMethod),
+		Reference:     req.Reference,
+		Notes:         req.Notes,
+		Allocations:   allocationInputs(req.Allocations),
+		CreatedBy:     userID,
+	}
+
+	result, err := h.recordPayment.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// AllocatePaymentRequest represents allocate payment request
+type AllocatePaymentRequest struct {
+	Allocations []AllocationRequest `json:"allocations" binding:"dive"` // Omit to allocate to the oldest invoices first
+}
+
+// AllocatePayment handles POST /payments/:id/allocate
+func (h *ReceivableHandler) AllocatePayment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid payment ID"))
+		return
+	}
+
+	var req AllocatePaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.allocatePayment.Execute(c.Request.Context(), id, allocationInputs(req.Allocations), userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetPayment handles GET /payments/:id
+func (h *ReceivableHandler) GetPayment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid payment ID"))
+		return
+	}
+
+	result, err := h.getPayment.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("payment"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListPayments handles GET /payments
+func (h *ReceivableHandler) ListPayments(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.PaymentFilter{
+		DateFrom: c.Query("date_from"),
+		DateTo:   c.Query("date_to"),
+		Page:     page,
+		Limit:    limit,
+	}
+
+	if customerID := c.Query("customer_id"); customerID != "" {
+		if id, err := uuid.Parse(customerID); err == nil {
+			filter.CustomerID = &id
+		}
+	}
+
+	results, total, err := h.listPayments.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// ListCreditNotes handles GET /credit-notes?customer_id=
+func (h *ReceivableHandler) ListCreditNotes(c *gin.Context) {
+	customerID, err := uuid.Parse(c.Query("customer_id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid customer_id"))
+		return
+	}
+
+	results, err := h.listCreditNotes.Execute(c.Request.Context(), customerID)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, results)
+}
+
+// ApplyCreditNote handles POST /credit-notes/:id/apply
+func (h *ReceivableHandler) ApplyCreditNote(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid credit note ID"))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.applyCreditNote.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetStatement handles GET /customers/:id/statement?from=&to=
+func (h *ReceivableHandler) GetStatement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid customer ID"))
+		return
+	}
+
+	// Defaults to the current month
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := now
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			response.Error(c, errors.BadRequest("invalid from"))
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			response.Error(c, errors.BadRequest("invalid to"))
+			return
+		}
+	}
+
+	result, err := h.getStatement.Execute(c.Request.Context(), id, from, to)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// GetAgingReport handles GET /reports/ar-aging?as_of=&customer_id=
+func (h *ReceivableHandler) GetAgingReport(c *gin.Context) {
+	asOf := time.Now()
+	if v := c.Query("as_of"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid as_of"))
+			return
+		}
+		asOf = t
+	}
+
+	var customerID *uuid.UUID
+	if v := c.Query("customer_id"); v != "" {
+		if id, err := uuid.Parse(v); err == nil {
+			customerID = &id
+		}
+	}
+
+	result, err := h.agingReport.Execute(c.Request.Context(), asOf, customerID)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// allocationInputs maps allocation requests to use case input
+func allocationInputs(reqs []AllocationRequest) []receivable.AllocationInput {
+	inputs := make([]receivable.AllocationInput, len(reqs))
+	for i, req := range reqs {
+		inputs[i] = receivable.AllocationInput{
+			InvoiceID: req.InvoiceID,
+			Amount:    req.Amount,
+		}
+	}
+	return inputs
+}
@@ -0,0 +1,342 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/sales_return"
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReturnHandler handles sales return (RMA) HTTP requests
+type ReturnHandler struct {
+	createReturn   *sales_return.CreateReturnUseCase
+	getReturn      *sales_return.GetReturnUseCase
+	listReturns    *sales_return.ListReturnsUseCase
+	approveReturn  *sales_return.ApproveReturnUseCase
+	rejectReturn   *sales_return.RejectReturnUseCase
+	receiveReturn  *sales_return.ReceiveReturnUseCase
+	inspectReturn  *sales_return.InspectReturnUseCase
+	completeReturn *sales_return.CompleteReturnUseCase
+}
+
+// NewReturnHandler creates a new return handler
+func NewReturnHandler(
+	createReturn *sales_return.CreateReturnUseCase,
+	getReturn *sales_return.GetReturnUseCase,
+	listReturns *sales_return.ListReturnsUseCase,
+	approveReturn *sales_return.ApproveReturnUseCase,
+	rejectReturn *sales_return.RejectReturnUseCase,
+	receiveReturn *sales_return.ReceiveReturnUseCase,
+	inspectReturn *sales_return.InspectReturnUseCase,
+	completeReturn *sales_return.CompleteReturnUseCase,
+) *ReturnHandler {
+	return &ReturnHandler{
+		createReturn:   createReturn,
+		getReturn:      getReturn,
+		listReturns:    listReturns,
+		approveReturn:  approveReturn,
+		rejectReturn:   rejectReturn,
+		receiveReturn:  receiveReturn,
+		inspectReturn:  inspectReturn,
+		completeReturn: completeReturn,
+	}
+}
+
+// CreateReturnRequest represents create return request
+type CreateReturnRequest struct {
+	SalesOrderID uuid.UUID                 `json:"sales_order_id" binding:"required"`
+	ShipmentID   uuid.UUID                 `json:"shipment_id" binding:"required"`
+	ReturnDate   string                    `json:"return_date"`
+	ReturnReason string                    `json:"return_reason" binding:"required"`
+	ReturnType   string                    `json:"return_type"`
+	Notes        string                    `json:"notes"`
+	Items        []CreateReturnItemRequest `json:"items" binding:"required,min=1"`
+}
+
+// CreateReturnItemRequest represents a returned order line
+type CreateReturnItemRequest struct {
+	SOLineItemID uuid.UUID `json:"so_line_item_id" binding:"required"`
+	Quantity     float64   `json:"quantity" binding:"required,gt=0"`
+	LotNumber    string    `json:"lot_number"`
+	Reason       string    `json:"reason"`
+}
+
+// CreateReturn handles POST /returns
+func (h *ReturnHandler) CreateReturn(c *gin.Context) {
+	var req CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	returnDate := time.Now()
+	if req.ReturnDate != "" {
+		returnDate, _ = time.Parse("2006-01-02", req.ReturnDate)
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &sales_return.CreateReturnInput{
+		SalesOrderID: req.SalesOrderID,
+		ShipmentID:   req.ShipmentID,
+		ReturnDate:   returnDate,
+		ReturnReason: req.ReturnReason,
+		ReturnType:   entity.ReturnType(req.ReturnType),
+		Notes:        req.Notes,
+		CreatedBy:    &userID,
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, sales_return.ReturnItemInput{
+			SOLineItemID: item.SOLineItemID,
+			Quantity:     item.Quantity,
+			LotNumber:    item.LotNumber,
+			Reason:       item.Reason,
+		})
+	}
+
+	result, err := h.createReturn.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Created(c, result)
+}
+
+// GetReturn handles GET /returns/:id
+func (h *ReturnHandler) GetReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid return ID"))
+		return
+	}
+
+	result, err := h.getReturn.Execute(c.Request.Context(), id)
+	if err != nil {
+		response.Error(c, errors.NotFound("return"))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListReturns handles GET /returns
+func (h *ReturnHandler) ListReturns(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	filter := &repository.ReturnFilter{
+		Status:     entity.ReturnStatus(c.Query("status")),
+		ReturnType: entity.ReturnType(c.Query("return_type")),
+		DateFrom:   c.Query("date_from"),
+		DateTo:     c.Query("date_to"),
+		Page:       page,
+		Limit:      limit,
+	}
+
+	if orderID := c.Query("sales_order_id"); orderID != "" {
+		if id, err := uuid.Parse(orderID); err == nil {
+			filter.SalesOrderID = &id
+		}
+	}
+
+	results, total, err := h.listReturns.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	meta := response.NewMeta(page, limit, total)
+	response.SuccessWithMeta(c, results, meta)
+}
+
+// ApproveReturn handles PATCH /returns/:id/approve
+func (h *ReturnHandler) ApproveReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid return ID"))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.approveReturn.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// RejectReturnRequest represents reject return request
+type RejectReturnRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// RejectReturn handles PATCH /returns/:id/reject
+func (h *ReturnHandler) RejectReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid return ID"))
+		return
+	}
+
+	var req RejectReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.rejectReturn.Execute(c.Request.Context(), id, userID, req.Reason)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ReceiveReturnRequest represents receive return request
+type ReceiveReturnRequest struct {
+	Items []ReceiveReturnItemRequest `json:"items"`
+}
+
+// ReceiveReturnItemRequest records the lot number read from a returned line
+type ReceiveReturnItemRequest struct {
+	LineItemID uuid.UUID `json:"line_item_id" binding:"required"`
+	LotNumber  string    `json:"lot_number"`
+}
+
+// ReceiveReturn handles PATCH /returns/:id/receive
+func (h *ReturnHandler) ReceiveReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid return ID"))
+		return
+	}
+
+	var req ReceiveReturnRequest
+	c.ShouldBindJSON(&req)
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &sales_return.ReceiveReturnInput{
+		ReturnID: id,
+		Lots:     make(map[uuid.UUID]string, len(req.Items)),
+		UserID:   userID,
+	}
+	for _, item := range req.Items {
+		input.Lots[item.LineItemID] = item.LotNumber
+	}
+
+	result, err := h.receiveReturn.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// InspectReturnRequest represents inspect return request
+type InspectReturnRequest struct {
+	Items []InspectReturnItemRequest `json:"items" binding:"required,min=1"`
+}
+
+// InspectReturnItemRequest represents the inspection outcome of a return line
+type InspectReturnItemRequest struct {
+	LineItemID uuid.UUID `json:"line_item_id" binding:"required"`
+	Condition  string    `json:"condition" binding:"required"`
+	Action     string    `json:"action" binding:"required"`
+	Notes      string    `json:"notes"`
+}
+
+// InspectReturn handles PATCH /returns/:id/inspect
+func (h *ReturnHandler) InspectReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid return ID"))
+		return
+	}
+
+	var req InspectReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	input := &sales_return.InspectReturnInput{
+		ReturnID: id,
+		UserID:   userID,
+	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, sales_return.InspectItemInput{
+			LineItemID: item.LineItemID,
+			Condition:  entity.ItemCondition(item.Condition),
+			Action:     entity.ItemAction(item.Action),
+			Notes:      item.Notes,
+		})
+	}
+
+	result, err := h.inspectReturn.Execute(c.Request.Context(), input)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// CompleteReturn handles PATCH /returns/:id/complete
+func (h *ReturnHandler) CompleteReturn(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid return ID"))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, err := h.completeReturn.Execute(c.Request.Context(), id, userID)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
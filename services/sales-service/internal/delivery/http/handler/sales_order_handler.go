@@ -23,6 +23,8 @@ type SalesOrderHandler struct {
 	cancelOrder  *salesorder.CancelOrderUseCase
 	shipOrder    *salesorder.ShipOrderUseCase
 	deliverOrder *salesorder.DeliverOrderUseCase
+	amendOrder   *salesorder.AmendOrderUseCase
+	amendments   *salesorder.GetAmendmentsUseCase
 	orderRepo    repository.SalesOrderRepository
 }
 
@@ -35,6 +37,8 @@ func NewSalesOrderHandler(
 	cancelOrder *salesorder.CancelOrderUseCase,
 	shipOrder *salesorder.ShipOrderUseCase,
 	deliverOrder *salesorder.DeliverOrderUseCase,
+	amendOrder *salesorder.AmendOrderUseCase,
+	amendments *salesorder.GetAmendmentsUseCase,
 	orderRepo repository.SalesOrderRepository,
 ) *SalesOrderHandler {
 	return &SalesOrderHandler{
@@ -45,6 +49,8 @@ func NewSalesOrderHandler(
 		cancelOrder:  cancelOrder,
 		shipOrder:    shipOrder,
 		deliverOrder: deliverOrder,
+		amendOrder:   amendOrder,
+		amendments:   amendments,
 		orderRepo:    orderRepo,
 	}
 }
@@ -56,7 +62,7 @@ type OrderItemRequest struct {
 	ProductName     string     `json:"product_name"`
 	Quantity        float64    `json:"quantity" binding:"required,gt=0"`
 	UomID           *uuid.UUID `json:"uom_id"`
-	UnitPrice       float64    `json:"unit_price" binding:"required,gte=0"`
+	UnitPrice       *float64   `json:"unit_price" binding:"omitempty,gte=0"` // Omit to price from the price lists; zero is a free line
 	DiscountPercent float64    `json:"discount_percent"`
 	TaxPercent      float64    `json:"tax_percent"`
 	Notes           string     `json:"notes"`
@@ -70,11 +76,13 @@ type CreateOrderRequest struct {
 	DeliveryDate    string             `json:"delivery_date"`
 	DeliveryAddress string             `json:"delivery_address"`
 	BillingAddress  string             `json:"billing_address"`
+	Channel         string             `json:"channel"`
 	DiscountPercent float64            `json:"discount_percent"`
 	TaxPercent      float64            `json:"tax_percent"`
 	PaymentMethod   string             `json:"payment_method"`
 	Notes           string             `json:"notes"`
 	Items           []OrderItemRequest `json:"items" binding:"required,dive"`
+	VoucherCodes    []string           `json:"voucher_codes"`
 }
 
 // CreateOrder handles POST /sales-orders
@@ -123,11 +131,13 @@ func (h *SalesOrderHandler) CreateOrder(c *gin.Context) {
 		DeliveryDate:    deliveryDate,
 		DeliveryAddress: req.DeliveryAddress,
 		BillingAddress:  req.BillingAddress,
+		Channel:         entity.SalesChannel(req.Channel),
 		DiscountPercent: req.DiscountPercent,
 		TaxPercent:      req.TaxPercent,
 		PaymentMethod:   paymentMethod,
 		Notes:           req.Notes,
 		Items:           items,
+		VoucherCodes:    req.VoucherCodes,
 	}
 
 	result, err := h.createOrder.Execute(c.Request.Context(), input)
@@ -228,8 +238,11 @@ func (h *SalesOrderHandler) ConfirmOrder(c *gin.Context) {
 		return
 	}
 
-	// TODO: Get user ID from JWT token
-	userID := uuid.New()
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
 
 	result, err := h.confirmOrder.Execute(c.Request.Context(), id, userID)
 	if err != nil {
@@ -256,8 +269,11 @@ func (h *SalesOrderHandler) CancelOrder(c *gin.Context) {
 	var req CancelOrderRequest
 	c.ShouldBindJSON(&req)
 
-	// TODO: Get user ID from JWT token
-	userID := uuid.New()
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
 
 	result, err := h.cancelOrder.Execute(c.Request.Context(), id, userID, req.Reason)
 	if err != nil {
@@ -301,3 +317,114 @@ func (h *SalesOrderHandler) DeliverOrder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"success": true, "data": result})
 }
+
+// AmendLineRequest represents a changed, added or removed order line
+type AmendLineRequest struct {
+	LineID          *uuid.UUID `json:"line_id"` // Omit to add a line
+	ProductID       uuid.UUID  `json:"product_id"`
+	ProductCode     string     `json:"product_code"`
+	ProductName     string     `json:"product_name"`
+	Quantity        float64    `json:"quantity" binding:"gte=0"` // Zero removes the line
+	UomID           *uuid.UUID `json:"uom_id"`
+	UnitPrice       *float64   `json:"unit_price" binding:"omitempty,gte=0"` // Omit to keep the price, or to price added lines from the price lists
+	DiscountPercent float64    `json:"discount_percent"`
+	TaxPercent      float64    `json:"tax_percent"`
+	Notes           string     `json:"notes"`
+}
+
+// AmendOrderRequest represents amend order request
+type AmendOrderRequest struct {
+	DeliveryDate string             `json:"delivery_date"`
+	Reason       string             `json:"reason" binding:"required"`
+	Lines        []AmendLineRequest `json:"lines" binding:"dive"`
+}
+
+// AmendOrder handles PATCH /sales-orders/:id/amend
+func (h *SalesOrderHandler) AmendOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid order ID"))
+		return
+	}
+
+	var req AmendOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	var deliveryDate *time.Time
+	if req.DeliveryDate != "" {
+		t, err := time.Parse("2006-01-02", req.DeliveryDate)
+		if err != nil {
+			response.Error(c, errors.BadRequest("invalid delivery date"))
+			return
+		}
+		deliveryDate = &t
+	}
+
+	lines := make([]salesorder.AmendLineInput, len(req.Lines))
+	for i, line := range req.Lines {
+		if line.LineID == nil && line.ProductID == uuid.Nil {
+			response.Error(c, errors.BadRequest("product_id is required for added lines"))
+			return
+		}
+		lines[i] = salesorder.AmendLineInput{
+			LineID:          line.LineID,
+			ProductID:       line.ProductID,
+			ProductCode:     line.ProductCode,
+			ProductName:     line.ProductName,
+			UomID:           line.UomID,
+			Quantity:        line.Quantity,
+			UnitPrice:       line.UnitPrice,
+			DiscountPercent: line.DiscountPercent,
+			TaxPercent:      line.TaxPercent,
+			Notes:           line.Notes,
+		}
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	result, _, err := h.amendOrder.Execute(c.Request.Context(), &salesorder.AmendOrderInput{
+		OrderID:      id,
+		DeliveryDate: deliveryDate,
+		Reason:       req.Reason,
+		Lines:        lines,
+		AmendedBy:    userID,
+	})
+	if err != nil {
+		if err == salesorder.ErrOrderNotFound {
+			response.Error(c, errors.NotFound("order"))
+			return
+		}
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListAmendments handles GET /sales-orders/:id/amendments
+func (h *SalesOrderHandler) ListAmendments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid order ID"))
+		return
+	}
+
+	results, err := h.amendments.Execute(c.Request.Context(), id)
+	if err != nil {
+		if err == salesorder.ErrOrderNotFound {
+			response.Error(c, errors.NotFound("order"))
+			return
+		}
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, results)
+}
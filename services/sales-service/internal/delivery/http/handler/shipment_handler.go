@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"io"
 	"strconv"
 
 	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
 	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/carrier"
 	"github.com/erp-cosmetics/sales-service/internal/usecase/shipment"
 	"github.com/erp-cosmetics/shared/pkg/errors"
 	"github.com/erp-cosmetics/shared/pkg/response"
@@ -19,6 +21,11 @@ type ShipmentHandler struct {
 	listShipments   *shipment.ListShipmentsUseCase
 	shipShipment    *shipment.ShipShipmentUseCase
 	deliverShipment *shipment.DeliverShipmentUseCase
+	quoteRates      *shipment.QuoteRatesUseCase
+	bookCarrier     *shipment.BookCarrierUseCase
+	pollTracking    *shipment.PollTrackingUseCase
+	trackingEvents  *shipment.GetTrackingEventsUseCase
+	receiveWebhook  *shipment.ReceiveTrackingWebhookUseCase
 }
 
 // NewShipmentHandler creates a new shipment handler
@@ -28,6 +35,11 @@ func NewShipmentHandler(
 	listShipments *shipment.ListShipmentsUseCase,
 	shipShipment *shipment.ShipShipmentUseCase,
 	deliverShipment *shipment.DeliverShipmentUseCase,
+	quoteRates *shipment.QuoteRatesUseCase,
+	bookCarrier *shipment.BookCarrierUseCase,
+	pollTracking *shipment.PollTrackingUseCase,
+	trackingEvents *shipment.GetTrackingEventsUseCase,
+	receiveWebhook *shipment.ReceiveTrackingWebhookUseCase,
 ) *ShipmentHandler {
 	return &ShipmentHandler{
 		createShipment:  createShipment,
@@ -35,6 +47,11 @@ func NewShipmentHandler(
 		listShipments:   listShipments,
 		shipShipment:    shipShipment,
 		deliverShipment: deliverShipment,
+		quoteRates:      quoteRates,
+		bookCarrier:     bookCarrier,
+		pollTracking:    pollTracking,
+		trackingEvents:  trackingEvents,
+		receiveWebhook:  receiveWebhook,
 	}
 }
 
@@ -49,6 +66,10 @@ type CreateShipmentRequest struct {
 	RecipientPhone  string    `json:"recipient_phone"`
 	DeliveryAddress string    `json:"delivery_address"`
 	Notes           string    `json:"notes"`
+	Items           []struct {
+		SOLineItemID uuid.UUID `json:"so_line_item_id" binding:"required"`
+		Quantity     float64   `json:"quantity" binding:"required,gt=0"`
+	} `json:"items" binding:"dive"` // Omit to ship everything left on the order
 }
 
 // CreateShipment handles POST /shipments
@@ -70,6 +91,12 @@ func (h *ShipmentHandler) CreateShipment(c *gin.Context) {
 		DeliveryAddress: req.DeliveryAddress,
 		Notes:           req.Notes,
 	}
+	for _, item := range req.Items {
+		input.Items = append(input.Items, shipment.ShipmentItemInput{
+			SOLineItemID: item.SOLineItemID,
+			Quantity:     item.Quantity,
+		})
+	}
 
 	result, err := h.createShipment.Execute(c.Request.Context(), input)
 	if err != nil {
@@ -175,3 +202,168 @@ func (h *ShipmentHandler) DeliverShipment(c *gin.Context) {
 
 	response.Success(c, result)
 }
+
+// ParcelRequest represents the parcel of a shipment
+type ParcelRequest struct {
+	WeightKg      float64 `json:"weight_kg" binding:"gte=0"`
+	DeclaredValue float64 `json:"declared_value" binding:"gte=0"`
+}
+
+// QuoteRatesRequest represents quote carrier rates request
+type QuoteRatesRequest struct {
+	Carrier string `json:"carrier"` // Omit to quote all carriers
+	ParcelRequest
+}
+
+// QuoteRates handles POST /shipments/:id/rates
+func (h *ShipmentHandler) QuoteRates(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid shipment ID"))
+		return
+	}
+
+	var req QuoteRatesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	results, err := h.quoteRates.Execute(c.Request.Context(), id, req.Carrier, shipment.ParcelInput{
+		WeightKg:      req.WeightKg,
+		DeclaredValue: req.DeclaredValue,
+	})
+	if err != nil {
+		if err == shipment.ErrShipmentNotFound {
+			response.Error(c, errors.NotFound("shipment"))
+			return
+		}
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, results)
+}
+
+// BookCarrierRequest represents create carrier label request
+type BookCarrierRequest struct {
+	Carrier     string `json:"carrier"`      // Omit to shop all carriers
+	ServiceCode string `json:"service_code"` // Omit to pick by strategy
+	Strategy    string `json:"strategy" binding:"omitempty,oneof=CHEAPEST FASTEST"`
+	ParcelRequest
+}
+
+// BookCarrier handles POST /shipments/:id/label
+func (h *ShipmentHandler) BookCarrier(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid shipment ID"))
+		return
+	}
+
+	var req BookCarrierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	userID, ok := currentUser(c)
+	if !ok {
+		response.Error(c, errors.Unauthorized("authentication required"))
+		return
+	}
+
+	strategy := carrier.StrategyCheapest
+	if req.Strategy != "" {
+		strategy = carrier.Strategy(req.Strategy)
+	}
+
+	result, err := h.bookCarrier.Execute(c.Request.Context(), &shipment.BookCarrierInput{
+		ShipmentID:  id,
+		Carrier:     req.Carrier,
+		ServiceCode: req.ServiceCode,
+		Strategy:    strategy,
+		Parcel: shipment.ParcelInput{
+			WeightKg:      req.WeightKg,
+			DeclaredValue: req.DeclaredValue,
+		},
+		UpdatedBy: &userID,
+	})
+	if err != nil {
+		if err == shipment.ErrShipmentNotFound {
+			response.Error(c, errors.NotFound("shipment"))
+			return
+		}
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// TrackShipment handles POST /shipments/:id/track
+func (h *ShipmentHandler) TrackShipment(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid shipment ID"))
+		return
+	}
+
+	result, err := h.pollTracking.ExecuteShipment(c.Request.Context(), id)
+	if err != nil {
+		if err == shipment.ErrShipmentNotFound {
+			response.Error(c, errors.NotFound("shipment"))
+			return
+		}
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// ListTrackingEvents handles GET /shipments/:id/tracking
+func (h *ShipmentHandler) ListTrackingEvents(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("invalid shipment ID"))
+		return
+	}
+
+	results, err := h.trackingEvents.Execute(c.Request.Context(), id)
+	if err != nil {
+		if err == shipment.ErrShipmentNotFound {
+			response.Error(c, errors.NotFound("shipment"))
+			return
+		}
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, results)
+}
+
+// ReceiveTrackingWebhook handles POST /carriers/:carrier/webhook
+func (h *ShipmentHandler) ReceiveTrackingWebhook(c *gin.Context) {
+	payload, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	results, err := h.receiveWebhook.Execute(c.Request.Context(), c.Param("carrier"), payload, c.GetHeader("X-Carrier-Signature"))
+	if err != nil {
+		if err == carrier.ErrUnknownCarrier {
+			response.Error(c, errors.NotFound("carrier"))
+			return
+		}
+		if err == carrier.ErrInvalidSignature || err == carrier.ErrWebhookNotConfigured {
+			response.Error(c, errors.Unauthorized(err.Error()))
+			return
+		}
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	response.Success(c, gin.H{"shipments_updated": len(results)})
+}
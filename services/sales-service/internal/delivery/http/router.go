@@ -12,6 +12,13 @@ func NewRouter(
 	quotationHandler *handler.QuotationHandler,
 	salesOrderHandler *handler.SalesOrderHandler,
 	shipmentHandler *handler.ShipmentHandler,
+	returnHandler *handler.ReturnHandler,
+	priceListHandler *handler.PriceListHandler,
+	promotionHandler *handler.PromotionHandler,
+	receivableHandler *handler.ReceivableHandler,
+	einvoiceHandler *handler.EInvoiceHandler,
+	creditHoldHandler *handler.CreditHoldHandler,
+	availabilityHandler *handler.AvailabilityHandler,
 ) *gin.Engine {
 	router := gin.New()
 
@@ -52,6 +59,7 @@ func NewRouter(
 			customers.GET("/:id/contacts", customerHandler.GetContacts)
 			customers.POST("/:id/contacts", customerHandler.CreateContact)
 			customers.GET("/:id/credit-check", customerHandler.CheckCredit)
+			customers.GET("/:id/statement", receivableHandler.GetStatement)
 		}
 
 		// Quotations
@@ -65,6 +73,31 @@ func NewRouter(
 			quotations.POST("/:id/convert-to-order", quotationHandler.ConvertToOrder)
 		}
 
+		// Price Lists
+		priceLists := v1.Group("/price-lists")
+		{
+			priceLists.GET("", priceListHandler.ListPriceLists)
+			priceLists.POST("", priceListHandler.CreatePriceList)
+			priceLists.GET("/:id", priceListHandler.GetPriceList)
+			priceLists.POST("/:id/items", priceListHandler.AddPriceListItem)
+			priceLists.PATCH("/:id/deactivate", priceListHandler.DeactivatePriceList)
+		}
+		v1.POST("/pricing/resolve", priceListHandler.ResolvePrices)
+
+		// Delivery date quoting
+		v1.POST("/availability/promise", availabilityHandler.PromiseDates)
+
+		// Promotions
+		promotions := v1.Group("/promotions")
+		{
+			promotions.GET("", promotionHandler.ListPromotions)
+			promotions.POST("", promotionHandler.CreatePromotion)
+			promotions.GET("/:id", promotionHandler.GetPromotion)
+			promotions.PATCH("/:id/deactivate", promotionHandler.DeactivatePromotion)
+			promotions.GET("/:id/vouchers", promotionHandler.ListVouchers)
+			promotions.POST("/:id/vouchers", promotionHandler.CreateVoucher)
+		}
+
 		// Sales Orders
 		orders := v1.Group("/sales-orders")
 		{
@@ -76,6 +109,17 @@ func NewRouter(
 			orders.PATCH("/:id/ship", salesOrderHandler.ShipOrder)
 			orders.PATCH("/:id/deliver", salesOrderHandler.DeliverOrder)
 			orders.PATCH("/:id/cancel", salesOrderHandler.CancelOrder)
+			orders.PATCH("/:id/amend", salesOrderHandler.AmendOrder)
+			orders.GET("/:id/amendments", salesOrderHandler.ListAmendments)
+		}
+
+		// Credit Holds
+		creditHolds := v1.Group("/credit-holds")
+		{
+			creditHolds.GET("", creditHoldHandler.ListCreditHolds)
+			creditHolds.GET("/:id", creditHoldHandler.GetCreditHold)
+			creditHolds.PATCH("/:id/approve", creditHoldHandler.ApproveCreditHold)
+			creditHolds.PATCH("/:id/reject", creditHoldHandler.RejectCreditHold)
 		}
 
 		// Shipments
@@ -86,7 +130,61 @@ func NewRouter(
 			shipments.GET("/:id", shipmentHandler.GetShipment)
 			shipments.PATCH("/:id/ship", shipmentHandler.ShipShipment)
 			shipments.PATCH("/:id/deliver", shipmentHandler.DeliverShipment)
+			shipments.POST("/:id/rates", shipmentHandler.QuoteRates)
+			shipments.POST("/:id/label", shipmentHandler.BookCarrier)
+			shipments.POST("/:id/track", shipmentHandler.TrackShipment)
+			shipments.GET("/:id/tracking", shipmentHandler.ListTrackingEvents)
 		}
+
+		// Carrier tracking webhooks
+		v1.POST("/carriers/:carrier/webhook", shipmentHandler.ReceiveTrackingWebhook)
+
+		// Returns (RMA)
+		returns := v1.Group("/returns")
+		{
+			returns.GET("", returnHandler.ListReturns)
+			returns.POST("", returnHandler.CreateReturn)
+			returns.GET("/:id", returnHandler.GetReturn)
+			returns.PATCH("/:id/approve", returnHandler.ApproveReturn)
+			returns.PATCH("/:id/reject", returnHandler.RejectReturn)
+			returns.PATCH("/:id/receive", returnHandler.ReceiveReturn)
+			returns.PATCH("/:id/inspect", returnHandler.InspectReturn)
+			returns.PATCH("/:id/complete", returnHandler.CompleteReturn)
+		}
+
+		// Invoices
+		invoices := v1.Group("/invoices")
+		{
+			invoices.GET("", receivableHandler.ListInvoices)
+			invoices.POST("", receivableHandler.CreateInvoice)
+			invoices.GET("/:id", receivableHandler.GetInvoice)
+			invoices.POST("/:id/e-invoice", einvoiceHandler.IssueEInvoice)
+		}
+
+		// E-Invoices
+		einvoices := v1.Group("/e-invoices")
+		{
+			einvoices.GET("", einvoiceHandler.ListEInvoices)
+			einvoices.GET("/:id", einvoiceHandler.GetEInvoice)
+			einvoices.GET("/:id/xml", einvoiceHandler.DownloadXML)
+			einvoices.PATCH("/:id/cancel", einvoiceHandler.CancelEInvoice)
+		}
+
+		// Payments
+		payments := v1.Group("/payments")
+		{
+			payments.GET("", receivableHandler.ListPayments)
+			payments.POST("", receivableHandler.RecordPayment)
+			payments.GET("/:id", receivableHandler.GetPayment)
+			payments.POST("/:id/allocate", receivableHandler.AllocatePayment)
+		}
+
+		// Credit Notes
+		v1.GET("/credit-notes", receivableHandler.ListCreditNotes)
+		v1.POST("/credit-notes/:id/apply", receivableHandler.ApplyCreditNote)
+
+		// Reports
+		v1.GET("/reports/ar-aging", receivableHandler.GetAgingReport)
 	}
 
 	return router
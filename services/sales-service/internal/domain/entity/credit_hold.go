@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditHoldStatus represents credit hold status
+type CreditHoldStatus string
+
+const (
+	CreditHoldStatusPending  CreditHoldStatus = "PENDING"
+	CreditHoldStatusApproved CreditHoldStatus = "APPROVED" // Released, the order was confirmed
+	CreditHoldStatusRejected CreditHoldStatus = "REJECTED" // The order went back to draft
+)
+
+// CreditHold records a sales order held on confirmation for a credit
+// review, with the customer's credit position at the time and the credit
+// manager's decision
+type CreditHold struct {
+	ID              uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SalesOrderID    uuid.UUID        `json:"sales_order_id" gorm:"type:uuid;not null"`
+	SalesOrder      *SalesOrder      `json:"sales_order,omitempty" gorm:"foreignKey:SalesOrderID"`
+	CustomerID      uuid.UUID        `json:"customer_id" gorm:"type:uuid;not null"`
+	Customer        *Customer        `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	OrderAmount     float64          `json:"order_amount" gorm:"type:decimal(18,2);default:0"`
+	CreditLimit     float64          `json:"credit_limit" gorm:"type:decimal(18,2);default:0"`
+	CurrentBalance  float64          `json:"current_balance" gorm:"type:decimal(18,2);default:0"` // Open AR plus unbilled orders
+	AvailableCredit float64          `json:"available_credit" gorm:"type:decimal(18,2);default:0"`
+	OverdueAmount   float64          `json:"overdue_amount" gorm:"type:decimal(18,2);default:0"`
+	OverdueInvoices int64            `json:"overdue_invoices" gorm:"default:0"`
+	OverLimit       bool             `json:"over_limit" gorm:"default:false"`
+	Reason          string           `json:"reason" gorm:"type:text"`
+	Status          CreditHoldStatus `json:"status" gorm:"type:varchar(20);default:'PENDING'"`
+	HeldAt          time.Time        `json:"held_at" gorm:"type:timestamp;not null"`
+	HeldBy          *uuid.UUID       `json:"held_by" gorm:"type:uuid"` // User whose confirmation was held
+	ReviewedAt      *time.Time       `json:"reviewed_at" gorm:"type:timestamp"`
+	ReviewedBy      *uuid.UUID       `json:"reviewed_by" gorm:"type:uuid"`
+	ReviewReason    string           `json:"review_reason" gorm:"type:text"`
+	CreatedAt       time.Time        `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time        `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (CreditHold) TableName() string {
+	return "credit_holds"
+}
+
+// IsPending checks if the hold is waiting for a decision
+func (h *CreditHold) IsPending() bool {
+	return h.Status == CreditHoldStatusPending
+}
+
+// Approve releases the hold
+func (h *CreditHold) Approve(userID uuid.UUID, reason string) {
+	h.review(CreditHoldStatusApproved, userID, reason)
+}
+
+// Reject rejects the hold
+func (h *CreditHold) Reject(userID uuid.UUID, reason string) {
+	h.review(CreditHoldStatusRejected, userID, reason)
+}
+
+func (h *CreditHold) review(status CreditHoldStatus, userID uuid.UUID, reason string) {
+	now := time.Now()
+	h.Status = status
+	h.ReviewedAt = &now
+	h.ReviewedBy = &userID
+	h.ReviewReason = reason
+	h.UpdatedAt = now
+}
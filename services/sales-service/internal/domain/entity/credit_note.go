@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreditNoteType represents how a credit note is settled
+type CreditNoteType string
+
+const (
+	CreditNoteTypeRefund CreditNoteType = "REFUND" // Paid back to the customer
+	CreditNoteTypeCredit CreditNoteType = "CREDIT" // Offset against the customer balance
+)
+
+// CreditNote represents money owed back to a customer, issued when a return completes
+type CreditNote struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreditNoteNumber string         `json:"credit_note_number" gorm:"type:varchar(20);unique;not null"`
+	NoteType         CreditNoteType `json:"note_type" gorm:"type:varchar(20);not null"`
+	CustomerID       uuid.UUID      `json:"customer_id" gorm:"type:uuid;not null"`
+	SalesOrderID     uuid.UUID      `json:"sales_order_id" gorm:"type:uuid;not null"`
+	ReturnID         *uuid.UUID     `json:"return_id" gorm:"type:uuid"`
+	IssueDate        time.Time      `json:"issue_date" gorm:"type:date;not null"`
+	Amount           float64        `json:"amount" gorm:"type:decimal(18,2);not null"`
+	AppliedAmount    float64        `json:"applied_amount" gorm:"type:decimal(18,2);default:0"` // Allocated to invoices
+	Reason           string         `json:"reason" gorm:"type:varchar(200)"`
+	CreatedBy        *uuid.UUID     `json:"created_by" gorm:"type:uuid"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (CreditNote) TableName() string {
+	return "credit_notes"
+}
+
+// UnappliedAmount returns the credit not yet allocated to invoices. Refunds
+// are paid out and leave nothing to apply.
+func (cn *CreditNote) UnappliedAmount() float64 {
+	if cn.NoteType != CreditNoteTypeCredit {
+		return 0
+	}
+	return roundAmount(cn.Amount - cn.AppliedAmount)
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -77,6 +79,19 @@ func (c *Customer) GetAvailableCredit() float64 {
 	return c.CreditLimit - c.CurrentBalance
 }
 
+// AllocationPriority returns the customer tier used to allocate backordered
+// stock, lower is served first
+func (c *Customer) AllocationPriority() int {
+	switch c.CustomerType {
+	case CustomerTypeDistributor:
+		return 1
+	case CustomerTypeWholesale:
+		return 2
+	default:
+		return 3
+	}
+}
+
 // CanPlaceOrder checks if customer can place an order of given amount
 func (c *Customer) CanPlaceOrder(orderAmount float64) bool {
 	if c.Status != CustomerStatusActive {
@@ -85,6 +100,21 @@ func (c *Customer) CanPlaceOrder(orderAmount float64) bool {
 	return c.GetAvailableCredit() >= orderAmount
 }
 
+// PaymentTermDays returns the days of credit given by the payment terms,
+// e.g. 30 for "Net 30". Cash terms give none and unknown terms default to 30.
+func (c *Customer) PaymentTermDays() int {
+	terms := strings.ToUpper(strings.TrimSpace(c.PaymentTerms))
+	var days int
+	if _, err := fmt.Sscanf(terms, "NET %d", &days); err == nil && days >= 0 {
+		return days
+	}
+	switch terms {
+	case "COD", "CASH", "IMMEDIATE", "PREPAID", "DUE ON RECEIPT":
+		return 0
+	}
+	return 30
+}
+
 // Block blocks the customer
 func (c *Customer) Block() {
 	c.Status = CustomerStatusBlocked
@@ -0,0 +1,94 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EInvoiceStatus represents e-invoice status
+type EInvoiceStatus string
+
+const (
+	EInvoiceStatusSigned    EInvoiceStatus = "SIGNED"    // Built and signed, not yet accepted by the provider
+	EInvoiceStatusIssued    EInvoiceStatus = "ISSUED"    // Accepted with a tax authority code
+	EInvoiceStatusFailed    EInvoiceStatus = "FAILED"    // Rejected by the provider, can be issued again
+	EInvoiceStatusCancelled EInvoiceStatus = "CANCELLED" // Cancelled with the tax authority
+)
+
+// EInvoice represents the electronic invoice (hóa đơn điện tử) issued for a
+// sales invoice under Decree 123/2020 and Circular 78/2021
+type EInvoice struct {
+	ID               uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	InvoiceID        uuid.UUID      `json:"invoice_id" gorm:"type:uuid;not null"`
+	Invoice          *Invoice       `json:"invoice,omitempty" gorm:"foreignKey:InvoiceID"`
+	TemplateCode     string         `json:"template_code" gorm:"type:varchar(1);not null"` // KHMSHDon, 1 for VAT invoices
+	Series           string         `json:"series" gorm:"type:varchar(6);not null"`        // KHHDon, e.g. C26TAA
+	Number           int            `json:"number" gorm:"not null"`                        // SHDon, sequential within the series
+	IssueDate        time.Time      `json:"issue_date" gorm:"type:date;not null"`
+	SellerTaxCode    string         `json:"seller_tax_code" gorm:"type:varchar(14);not null"`
+	BuyerTaxCode     string         `json:"buyer_tax_code" gorm:"type:varchar(14)"`
+	BuyerName        string         `json:"buyer_name" gorm:"type:varchar(400)"`
+	Currency         string         `json:"currency" gorm:"type:varchar(3);default:'VND'"`
+	AmountBeforeTax  float64        `json:"amount_before_tax" gorm:"type:decimal(18,2);default:0"`
+	TaxAmount        float64        `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	TotalAmount      float64        `json:"total_amount" gorm:"type:decimal(18,2);default:0"`
+	XMLContent       string         `json:"-" gorm:"type:text"` // Signed XML
+	Provider         string         `json:"provider" gorm:"type:varchar(50)"`
+	ProviderRef      string         `json:"provider_ref" gorm:"type:varchar(100)"`
+	TaxAuthorityCode string         `json:"tax_authority_code" gorm:"type:varchar(34)"` // MCCQT
+	LookupCode       string         `json:"lookup_code" gorm:"type:varchar(50)"`        // For the buyer to look the invoice up
+	Status           EInvoiceStatus `json:"status" gorm:"type:varchar(20);default:'SIGNED'"`
+	ErrorMessage     string         `json:"error_message" gorm:"type:text"`
+	IssuedAt         *time.Time     `json:"issued_at" gorm:"type:timestamp"`
+	IssuedBy         *uuid.UUID     `json:"issued_by" gorm:"type:uuid"`
+	CancelledAt      *time.Time     `json:"cancelled_at" gorm:"type:timestamp"`
+	CancelledBy      *uuid.UUID     `json:"cancelled_by" gorm:"type:uuid"`
+	CancelReason     string         `json:"cancel_reason" gorm:"type:text"`
+	CreatedAt        time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt        time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (EInvoice) TableName() string {
+	return "e_invoices"
+}
+
+// CanBeIssued checks if the e-invoice can be sent to the provider
+func (e *EInvoice) CanBeIssued() bool {
+	return e.Status == EInvoiceStatusSigned || e.Status == EInvoiceStatusFailed
+}
+
+// CanBeCancelled checks if the e-invoice can be cancelled
+func (e *EInvoice) CanBeCancelled() bool {
+	return e.Status == EInvoiceStatusIssued
+}
+
+// MarkIssued records the provider's acceptance of the e-invoice
+func (e *EInvoice) MarkIssued(providerRef, taxAuthorityCode, lookupCode string, userID uuid.UUID) {
+	now := time.Now()
+	e.Status = EInvoiceStatusIssued
+	e.ProviderRef = providerRef
+	e.TaxAuthorityCode = taxAuthorityCode
+	e.LookupCode = lookupCode
+	e.ErrorMessage = ""
+	e.IssuedAt = &now
+	e.IssuedBy = &userID
+	e.UpdatedAt = now
+}
+
+// MarkFailed records the provider's rejection of the e-invoice
+func (e *EInvoice) MarkFailed(message string) {
+	e.Status = EInvoiceStatusFailed
+	e.ErrorMessage = message
+	e.UpdatedAt = time.Now()
+}
+
+// Cancel cancels the e-invoice
+func (e *EInvoice) Cancel(reason string, userID uuid.UUID) {
+	now := time.Now()
+	e.Status = EInvoiceStatusCancelled
+	e.CancelReason = reason
+	e.CancelledAt = &now
+	e.CancelledBy = &userID
+	e.UpdatedAt = now
+}
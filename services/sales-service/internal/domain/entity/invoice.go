@@ -0,0 +1,212 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InvoiceStatus represents invoice status
+type InvoiceStatus string
+
+const (
+	InvoiceStatusOpen          InvoiceStatus = "OPEN"
+	InvoiceStatusPartiallyPaid InvoiceStatus = "PARTIALLY_PAID"
+	InvoiceStatusPaid          InvoiceStatus = "PAID"
+	InvoiceStatusCancelled     InvoiceStatus = "CANCELLED"
+)
+
+// Invoice represents a customer invoice for the goods of one shipment
+type Invoice struct {
+	ID             uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	InvoiceNumber  string        `json:"invoice_number" gorm:"type:varchar(20);unique;not null"`
+	CustomerID     uuid.UUID     `json:"customer_id" gorm:"type:uuid;not null"`
+	Customer       *Customer     `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	SalesOrderID   uuid.UUID     `json:"sales_order_id" gorm:"type:uuid;not null"`
+	ShipmentID     *uuid.UUID    `json:"shipment_id" gorm:"type:uuid"`
+	InvoiceDate    time.Time     `json:"invoice_date" gorm:"type:date;not null"`
+	DueDate        time.Time     `json:"due_date" gorm:"type:date;not null"`
+	Currency       string        `json:"currency" gorm:"type:varchar(3);default:'VND'"`
+	Subtotal       float64       `json:"subtotal" gorm:"type:decimal(18,2);default:0"`        // Sum of line totals, line tax included
+	DiscountAmount float64       `json:"discount_amount" gorm:"type:decimal(18,2);default:0"` // Share of the order and promotion discounts
+	TaxAmount      float64       `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`      // Share of the order-level tax
+	TotalAmount    float64       `json:"total_amount" gorm:"type:decimal(18,2);default:0"`
+	PaidAmount     float64       `json:"paid_amount" gorm:"type:decimal(18,2);default:0"`
+	CreditedAmount float64       `json:"credited_amount" gorm:"type:decimal(18,2);default:0"`
+	Status         InvoiceStatus `json:"status" gorm:"type:varchar(20);default:'OPEN'"`
+	Notes          string        `json:"notes" gorm:"type:text"`
+	CreatedBy      *uuid.UUID    `json:"created_by" gorm:"type:uuid"`
+	CreatedAt      time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time     `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	LineItems []InvoiceLineItem `json:"line_items,omitempty" gorm:"foreignKey:InvoiceID"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// CalculateTotals totals the lines and takes the order-level discount and tax
+// in proportion to the invoiced share of the order
+func (inv *Invoice) CalculateTotals(order *SalesOrder) {
+	inv.Subtotal = 0
+	for _, item := range inv.LineItems {
+		inv.Subtotal += item.LineTotal
+	}
+
+	ratio := 0.0
+	if order.Subtotal > 0 {
+		ratio = inv.Subtotal / order.Subtotal
+	}
+	inv.Subtotal = roundAmount(inv.Subtotal)
+	inv.DiscountAmount = roundAmount((order.DiscountAmount + order.PromotionDiscount) * ratio)
+	inv.TaxAmount = roundAmount(order.TaxAmount * ratio)
+	inv.TotalAmount = inv.Subtotal - inv.DiscountAmount + inv.TaxAmount
+}
+
+// Balance returns the amount still owed on the invoice
+func (inv *Invoice) Balance() float64 {
+	if inv.Status == InvoiceStatusCancelled {
+		return 0
+	}
+	return roundAmount(inv.TotalAmount - inv.PaidAmount - inv.CreditedAmount)
+}
+
+// IsOpen checks if the invoice still has a balance to collect
+func (inv *Invoice) IsOpen() bool {
+	return inv.Status == InvoiceStatusOpen || inv.Status == InvoiceStatusPartiallyPaid
+}
+
+// DaysOverdue returns the days past the due date, or 0 when not yet due
+func (inv *Invoice) DaysOverdue(asOf time.Time) int {
+	days := int(dateOnly(asOf).Sub(dateOnly(inv.DueDate)).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// Age returns the days since the invoice date
+func (inv *Invoice) Age(asOf time.Time) int {
+	days := int(dateOnly(asOf).Sub(dateOnly(inv.InvoiceDate)).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// ApplyPayment settles part of the invoice with a payment
+func (inv *Invoice) ApplyPayment(amount float64) {
+	inv.PaidAmount = roundAmount(inv.PaidAmount + amount)
+	inv.refreshStatus()
+}
+
+// ApplyCredit settles part of the invoice with a credit note
+func (inv *Invoice) ApplyCredit(amount float64) {
+	inv.CreditedAmount = roundAmount(inv.CreditedAmount + amount)
+	inv.refreshStatus()
+}
+
+func (inv *Invoice) refreshStatus() {
+	switch {
+	case inv.Balance() <= 0:
+		inv.Status = InvoiceStatusPaid
+	case inv.PaidAmount > 0 || inv.CreditedAmount > 0:
+		inv.Status = InvoiceStatusPartiallyPaid
+	default:
+		inv.Status = InvoiceStatusOpen
+	}
+	inv.UpdatedAt = time.Now()
+}
+
+// InvoiceLineItem represents an invoiced quantity of an order line
+type InvoiceLineItem struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	InvoiceID      uuid.UUID `json:"invoice_id" gorm:"type:uuid;not null"`
+	SOLineItemID   uuid.UUID `json:"so_line_item_id" gorm:"type:uuid;not null"`
+	ProductID      uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode    string    `json:"product_code" gorm:"type:varchar(50)"`
+	ProductName    string    `json:"product_name" gorm:"type:varchar(200)"`
+	Quantity       float64   `json:"quantity" gorm:"type:decimal(18,3);not null"`
+	UnitPrice      float64   `json:"unit_price" gorm:"type:decimal(18,2);not null"`
+	DiscountAmount float64   `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	TaxPercent     float64   `json:"tax_percent" gorm:"type:decimal(5,2);default:0"`
+	TaxAmount      float64   `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	LineTotal      float64   `json:"line_total" gorm:"type:decimal(18,2);default:0"`
+}
+
+func (InvoiceLineItem) TableName() string {
+	return "invoice_line_items"
+}
+
+// NewInvoiceLineItem invoices a quantity of an order line, taking the line
+// discount and tax in proportion to the quantity
+func NewInvoiceLineItem(line *SOLineItem, quantity float64) InvoiceLineItem {
+	ratio := 0.0
+	if line.Quantity > 0 {
+		ratio = quantity / line.Quantity
+	}
+	return InvoiceLineItem{
+		SOLineItemID:   line.ID,
+		ProductID:      line.ProductID,
+		ProductCode:    line.ProductCode,
+		ProductName:    line.ProductName,
+		Quantity:       quantity,
+		UnitPrice:      line.UnitPrice,
+		DiscountAmount: roundAmount(line.DiscountAmount * ratio),
+		TaxPercent:     line.TaxPercent,
+		TaxAmount:      roundAmount(line.TaxAmount * ratio),
+		LineTotal:      roundAmount(line.LineTotal * ratio),
+	}
+}
+
+// Payment represents money received from a customer
+type Payment struct {
+	ID              uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PaymentNumber   string        `json:"payment_number" gorm:"type:varchar(20);unique;not null"`
+	CustomerID      uuid.UUID     `json:"customer_id" gorm:"type:uuid;not null"`
+	Customer        *Customer     `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
+	PaymentDate     time.Time     `json:"payment_date" gorm:"type:date;not null"`
+	Amount          float64       `json:"amount" gorm:"type:decimal(18,2);not null"`
+	AllocatedAmount float64       `json:"allocated_amount" gorm:"type:decimal(18,2);default:0"`
+	PaymentMethod   PaymentMethod `json:"payment_method" gorm:"type:varchar(20);default:'BANK_TRANSFER'"`
+	Reference       string        `json:"reference" gorm:"type:varchar(100)"` // Bank transaction or receipt number
+	Notes           string        `json:"notes" gorm:"type:text"`
+	CreatedBy       *uuid.UUID    `json:"created_by" gorm:"type:uuid"`
+	CreatedAt       time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time     `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	Allocations []InvoiceAllocation `json:"allocations,omitempty" gorm:"foreignKey:PaymentID"`
+}
+
+func (Payment) TableName() string {
+	return "payments"
+}
+
+// UnallocatedAmount returns the part of the payment held on account
+func (p *Payment) UnallocatedAmount() float64 {
+	return roundAmount(p.Amount - p.AllocatedAmount)
+}
+
+// InvoiceAllocation records a payment or credit note settling an invoice
+type InvoiceAllocation struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	InvoiceID    uuid.UUID  `json:"invoice_id" gorm:"type:uuid;not null"`
+	PaymentID    *uuid.UUID `json:"payment_id" gorm:"type:uuid"`
+	CreditNoteID *uuid.UUID `json:"credit_note_id" gorm:"type:uuid"`
+	Amount       float64    `json:"amount" gorm:"type:decimal(18,2);not null"`
+	CreatedBy    *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (InvoiceAllocation) TableName() string {
+	return "invoice_allocations"
+}
+
+// roundAmount rounds a money amount to the stored two decimals
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SalesChannel represents the channel an order is sold through
+type SalesChannel string
+
+const (
+	SalesChannelDirect      SalesChannel = "DIRECT"      // B2B sales team
+	SalesChannelDistributor SalesChannel = "DISTRIBUTOR" // Distributors and agents
+	SalesChannelRetail      SalesChannel = "RETAIL"      // Own stores and counters
+	SalesChannelOnline      SalesChannel = "ONLINE"      // E-commerce
+)
+
+// IsValid returns true if the channel is one of the known sales channels
+func (c SalesChannel) IsValid() bool {
+	switch c {
+	case SalesChannelDirect, SalesChannelDistributor, SalesChannelRetail, SalesChannelOnline:
+		return true
+	}
+	return false
+}
+
+// PriceListType represents the kind of price list
+type PriceListType string
+
+const (
+	PriceListTypeStandard  PriceListType = "STANDARD"  // Base prices, for everyone or one customer group
+	PriceListTypeContract  PriceListType = "CONTRACT"  // Negotiated prices for one customer
+	PriceListTypePromotion PriceListType = "PROMOTION" // Prices of a marketing campaign
+)
+
+// PriceSource records which rule produced a line's unit price
+type PriceSource string
+
+const (
+	PriceSourceManual        PriceSource = "MANUAL"
+	PriceSourceStandard      PriceSource = "STANDARD"
+	PriceSourceCustomerGroup PriceSource = "CUSTOMER_GROUP"
+	PriceSourceContract      PriceSource = "CONTRACT"
+	PriceSourcePromotion     PriceSource = "PROMOTION"
+	PriceSourceProduct       PriceSource = "PRODUCT" // Standard price of the product master, when no list prices it
+)
+
+// PriceList represents a set of product prices valid for a period
+type PriceList struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code            string         `json:"code" gorm:"type:varchar(20);unique;not null"`
+	Name            string         `json:"name" gorm:"type:varchar(200);not null"`
+	ListType        PriceListType  `json:"list_type" gorm:"type:varchar(20);not null;default:'STANDARD'"`
+	CustomerGroupID *uuid.UUID     `json:"customer_group_id" gorm:"type:uuid"`
+	CustomerGroup   *CustomerGroup `json:"customer_group,omitempty" gorm:"foreignKey:CustomerGroupID"`
+	CustomerID      *uuid.UUID     `json:"customer_id" gorm:"type:uuid"`
+	Channel         SalesChannel   `json:"channel" gorm:"type:varchar(20)"` // Empty applies to every channel
+	Currency        string         `json:"currency" gorm:"type:varchar(3);default:'VND'"`
+	CampaignID      *uuid.UUID     `json:"campaign_id" gorm:"type:uuid"` // Marketing campaign of a promotion
+	Priority        int            `json:"priority" gorm:"default:0"`
+	ValidFrom       time.Time      `json:"valid_from" gorm:"type:date;not null"`
+	ValidTo         *time.Time     `json:"valid_to" gorm:"type:date"`
+	IsActive        bool           `json:"is_active" gorm:"default:true"`
+	Notes           string         `json:"notes" gorm:"type:text"`
+	CreatedBy       *uuid.UUID     `json:"created_by" gorm:"type:uuid"`
+	UpdatedBy       *uuid.UUID     `json:"updated_by" gorm:"type:uuid"`
+	CreatedAt       time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time      `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	Items []PriceListItem `json:"items,omitempty" gorm:"foreignKey:PriceListID"`
+}
+
+func (PriceList) TableName() string {
+	return "price_lists"
+}
+
+// IsValidOn checks if the price list is active on the given date
+func (pl *PriceList) IsValidOn(date time.Time) bool {
+	if !pl.IsActive {
+		return false
+	}
+	day := dateOnly(date)
+	if day.Before(dateOnly(pl.ValidFrom)) {
+		return false
+	}
+	return pl.ValidTo == nil || !day.After(dateOnly(*pl.ValidTo))
+}
+
+// AppliesTo checks if the price list may price an order of the customer
+// through the given channel
+func (pl *PriceList) AppliesTo(customer *Customer, channel SalesChannel) bool {
+	if pl.Currency != "" && customer.Currency != "" && pl.Currency != customer.Currency {
+		return false
+	}
+	if pl.Channel != "" && pl.Channel != channel {
+		return false
+	}
+	if pl.CustomerID != nil && *pl.CustomerID != customer.ID {
+		return false
+	}
+	if pl.CustomerGroupID != nil && (customer.CustomerGroupID == nil || *pl.CustomerGroupID != *customer.CustomerGroupID) {
+		return false
+	}
+	return true
+}
+
+// PriceFor returns the quantity break that prices the given quantity of a
+// product: the item with the highest minimum quantity not above it
+func (pl *PriceList) PriceFor(productID uuid.UUID, quantity float64) *PriceListItem {
+	var best *PriceListItem
+	for i := range pl.Items {
+		item := &pl.Items[i]
+		if item.ProductID != productID || item.MinQuantity > quantity {
+			continue
+		}
+		if best == nil || item.MinQuantity > best.MinQuantity {
+			best = item
+		}
+	}
+	return best
+}
+
+// Source returns the price source recorded on lines priced by this list
+func (pl *PriceList) Source() PriceSource {
+	switch pl.ListType {
+	case PriceListTypeContract:
+		return PriceSourceContract
+	case PriceListTypePromotion:
+		return PriceSourcePromotion
+	}
+	if pl.CustomerGroupID != nil {
+		return PriceSourceCustomerGroup
+	}
+	return PriceSourceStandard
+}
+
+// PriceListItem represents a product price with an optional quantity break
+type PriceListItem struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PriceListID uuid.UUID `json:"price_list_id" gorm:"type:uuid;not null"`
+	ProductID   uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode string    `json:"product_code" gorm:"type:varchar(50)"`
+	MinQuantity float64   `json:"min_quantity" gorm:"type:decimal(18,3);default:0"`
+	UnitPrice   float64   `json:"unit_price" gorm:"type:decimal(18,2);not null"`
+	CreatedAt   time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (PriceListItem) TableName() string {
+	return "price_list_items"
+}
+
+// dateOnly truncates a time to midnight of its calendar day
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,196 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PromotionType represents the kind of promotion rule
+type PromotionType string
+
+const (
+	PromotionTypeBuyXGetY   PromotionType = "BUY_X_GET_Y" // Free goods for buying a quantity of a product
+	PromotionTypeBundle     PromotionType = "BUNDLE"      // Fixed price for a set of products
+	PromotionTypeCartTiered PromotionType = "CART_TIERED" // Order discount by order value tier
+)
+
+// Promotion represents a promotion rule evaluated when orders are created
+type Promotion struct {
+	ID              uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code            string        `json:"code" gorm:"type:varchar(30);unique;not null"`
+	Name            string        `json:"name" gorm:"type:varchar(200);not null"`
+	PromotionType   PromotionType `json:"promotion_type" gorm:"type:varchar(20);not null"`
+	CampaignID      *uuid.UUID    `json:"campaign_id" gorm:"type:uuid"` // Marketing campaign charged with the cost
+	CustomerGroupID *uuid.UUID    `json:"customer_group_id" gorm:"type:uuid"`
+	Channel         SalesChannel  `json:"channel" gorm:"type:varchar(20)"` // Empty applies to every channel
+	ValidFrom       time.Time     `json:"valid_from" gorm:"type:date;not null"`
+	ValidTo         *time.Time    `json:"valid_to" gorm:"type:date"`
+	Priority        int           `json:"priority" gorm:"default:0"`
+	RequiresVoucher bool          `json:"requires_voucher" gorm:"default:false"`
+	IsActive        bool          `json:"is_active" gorm:"default:true"`
+
+	// Buy X get Y
+	BuyProductID    *uuid.UUID `json:"buy_product_id,omitempty" gorm:"type:uuid"`
+	BuyQuantity     float64    `json:"buy_quantity,omitempty" gorm:"type:decimal(18,3);default:0"`
+	FreeProductID   *uuid.UUID `json:"free_product_id,omitempty" gorm:"type:uuid"`
+	FreeProductCode string     `json:"free_product_code,omitempty" gorm:"type:varchar(50)"`
+	FreeProductName string     `json:"free_product_name,omitempty" gorm:"type:varchar(200)"`
+	FreeQuantity    float64    `json:"free_quantity,omitempty" gorm:"type:decimal(18,3);default:0"`
+	MaxApplications int        `json:"max_applications,omitempty" gorm:"default:0"` // Per order; 0 is unlimited
+
+	// Bundle
+	BundlePrice float64 `json:"bundle_price,omitempty" gorm:"type:decimal(18,2);default:0"` // Per set, before tax
+
+	// Budget
+	Budget     float64 `json:"budget" gorm:"type:decimal(18,2);default:0"` // 0 is unlimited
+	UsedBudget float64 `json:"used_budget" gorm:"type:decimal(18,2);default:0"`
+	UsageCount int     `json:"usage_count" gorm:"default:0"`
+
+	Notes     string     `json:"notes" gorm:"type:text"`
+	CreatedBy *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	UpdatedBy *uuid.UUID `json:"updated_by" gorm:"type:uuid"`
+	CreatedAt time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	BundleItems []PromotionBundleItem `json:"bundle_items,omitempty" gorm:"foreignKey:PromotionID"`
+	Tiers       []PromotionTier       `json:"tiers,omitempty" gorm:"foreignKey:PromotionID"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// IsValidOn checks if the promotion runs on the given date
+func (p *Promotion) IsValidOn(date time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	day := dateOnly(date)
+	if day.Before(dateOnly(p.ValidFrom)) {
+		return false
+	}
+	return p.ValidTo == nil || !day.After(dateOnly(*p.ValidTo))
+}
+
+// AppliesTo checks if the promotion is open to the customer through the channel
+func (p *Promotion) AppliesTo(customer *Customer, channel SalesChannel) bool {
+	if p.Channel != "" && p.Channel != channel {
+		return false
+	}
+	if p.CustomerGroupID != nil && (customer.CustomerGroupID == nil || *p.CustomerGroupID != *customer.CustomerGroupID) {
+		return false
+	}
+	return true
+}
+
+// HasBudgetFor checks if the remaining budget covers the given cost
+func (p *Promotion) HasBudgetFor(cost float64) bool {
+	return p.Budget == 0 || p.UsedBudget+cost <= p.Budget
+}
+
+// TierFor returns the tier reached by an order value: the tier with the
+// highest minimum amount not above it
+func (p *Promotion) TierFor(amount float64) *PromotionTier {
+	var best *PromotionTier
+	for i := range p.Tiers {
+		tier := &p.Tiers[i]
+		if tier.MinAmount > amount {
+			continue
+		}
+		if best == nil || tier.MinAmount > best.MinAmount {
+			best = tier
+		}
+	}
+	return best
+}
+
+// PromotionBundleItem represents a product and quantity in one bundle set
+type PromotionBundleItem struct {
+	ID          uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PromotionID uuid.UUID `json:"promotion_id" gorm:"type:uuid;not null"`
+	ProductID   uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode string    `json:"product_code" gorm:"type:varchar(50)"`
+	Quantity    float64   `json:"quantity" gorm:"type:decimal(18,3);not null"`
+}
+
+func (PromotionBundleItem) TableName() string {
+	return "promotion_bundle_items"
+}
+
+// PromotionTier represents a cart discount tier
+type PromotionTier struct {
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	PromotionID     uuid.UUID `json:"promotion_id" gorm:"type:uuid;not null"`
+	MinAmount       float64   `json:"min_amount" gorm:"type:decimal(18,2);default:0"`
+	DiscountPercent float64   `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
+	DiscountAmount  float64   `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+}
+
+func (PromotionTier) TableName() string {
+	return "promotion_tiers"
+}
+
+// DiscountOn returns the tier discount on an order value
+func (t *PromotionTier) DiscountOn(amount float64) float64 {
+	discount := amount*t.DiscountPercent/100 + t.DiscountAmount
+	if discount > amount {
+		return amount
+	}
+	return discount
+}
+
+// Voucher represents a code that unlocks a promotion
+type Voucher struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Code        string     `json:"code" gorm:"type:varchar(30);unique;not null"`
+	PromotionID uuid.UUID  `json:"promotion_id" gorm:"type:uuid;not null"`
+	Promotion   *Promotion `json:"promotion,omitempty" gorm:"foreignKey:PromotionID"`
+	CustomerID  *uuid.UUID `json:"customer_id" gorm:"type:uuid"` // Restricts the voucher to one customer
+	MaxUses     int        `json:"max_uses" gorm:"default:1"`    // 1 is single-use; 0 is unlimited
+	UsedCount   int        `json:"used_count" gorm:"default:0"`
+	ValidTo     *time.Time `json:"valid_to" gorm:"type:date"`
+	IsActive    bool       `json:"is_active" gorm:"default:true"`
+	CreatedBy   *uuid.UUID `json:"created_by" gorm:"type:uuid"`
+	CreatedAt   time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (Voucher) TableName() string {
+	return "vouchers"
+}
+
+// CanBeUsedBy checks if the customer may redeem the voucher on the given date
+func (v *Voucher) CanBeUsedBy(customerID uuid.UUID, date time.Time) bool {
+	if !v.IsActive {
+		return false
+	}
+	if v.CustomerID != nil && *v.CustomerID != customerID {
+		return false
+	}
+	if v.ValidTo != nil && dateOnly(date).After(dateOnly(*v.ValidTo)) {
+		return false
+	}
+	return v.MaxUses == 0 || v.UsedCount < v.MaxUses
+}
+
+// OrderPromotion records a promotion applied to a sales order and its cost
+type OrderPromotion struct {
+	ID            uuid.UUID     `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SalesOrderID  uuid.UUID     `json:"sales_order_id" gorm:"type:uuid;not null"`
+	PromotionID   uuid.UUID     `json:"promotion_id" gorm:"type:uuid;not null"`
+	PromotionCode string        `json:"promotion_code" gorm:"type:varchar(30)"`
+	PromotionType PromotionType `json:"promotion_type" gorm:"type:varchar(20)"`
+	CampaignID    *uuid.UUID    `json:"campaign_id" gorm:"type:uuid"`
+	VoucherID     *uuid.UUID    `json:"voucher_id" gorm:"type:uuid"`
+	VoucherCode   string        `json:"voucher_code" gorm:"type:varchar(30)"`
+	Description   string        `json:"description" gorm:"type:varchar(200)"`
+	Cost          float64       `json:"cost" gorm:"type:decimal(18,2);default:0"` // Discount given plus value of free goods
+	ReleasedAt    *time.Time    `json:"released_at" gorm:"type:timestamp"`        // Set when the order is cancelled
+	CreatedAt     time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (OrderPromotion) TableName() string {
+	return "so_promotions"
+}
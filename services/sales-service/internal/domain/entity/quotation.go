@@ -26,6 +26,7 @@ type Quotation struct {
 	Customer           *Customer            `json:"customer,omitempty" gorm:"foreignKey:CustomerID"`
 	QuotationDate      time.Time            `json:"quotation_date" gorm:"type:date;not null"`
 	ValidUntil         time.Time            `json:"valid_until" gorm:"type:date;not null"`
+	Channel            SalesChannel         `json:"channel" gorm:"type:varchar(20);default:'DIRECT'"`
 	Subtotal           float64              `json:"subtotal" gorm:"type:decimal(18,2);default:0"`
 	DiscountPercent    float64              `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
 	DiscountAmount     float64              `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
@@ -115,23 +116,26 @@ func (q *Quotation) CalculateTotals() {
 
 // QuotationLineItem represents a line item in quotation
 type QuotationLineItem struct {
-	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	QuotationID     uuid.UUID  `json:"quotation_id" gorm:"type:uuid;not null"`
-	LineNumber      int        `json:"line_number" gorm:"not null"`
-	ProductID       uuid.UUID  `json:"product_id" gorm:"type:uuid;not null"`
-	ProductCode     string     `json:"product_code" gorm:"type:varchar(50)"`
-	ProductName     string     `json:"product_name" gorm:"type:varchar(200)"`
-	Quantity        float64    `json:"quantity" gorm:"type:decimal(18,3);not null"`
-	UomID           *uuid.UUID `json:"uom_id" gorm:"type:uuid"`
-	UnitPrice       float64    `json:"unit_price" gorm:"type:decimal(18,2);not null"`
-	DiscountPercent float64    `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
-	DiscountAmount  float64    `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
-	TaxPercent      float64    `json:"tax_percent" gorm:"type:decimal(5,2);default:10"`
-	TaxAmount       float64    `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
-	LineTotal       float64    `json:"line_total" gorm:"type:decimal(18,2);default:0"`
-	Notes           string     `json:"notes" gorm:"type:text"`
-	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt       time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ID              uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	QuotationID     uuid.UUID   `json:"quotation_id" gorm:"type:uuid;not null"`
+	LineNumber      int         `json:"line_number" gorm:"not null"`
+	ProductID       uuid.UUID   `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode     string      `json:"product_code" gorm:"type:varchar(50)"`
+	ProductName     string      `json:"product_name" gorm:"type:varchar(200)"`
+	Quantity        float64     `json:"quantity" gorm:"type:decimal(18,3);not null"`
+	PromiseDate     *time.Time  `json:"promise_date" gorm:"type:date"` // Earliest delivery date quoted by WMS
+	UomID           *uuid.UUID  `json:"uom_id" gorm:"type:uuid"`
+	UnitPrice       float64     `json:"unit_price" gorm:"type:decimal(18,2);not null"`
+	PriceSource     PriceSource `json:"price_source" gorm:"type:varchar(20);default:'MANUAL'"`
+	PriceListID     *uuid.UUID  `json:"price_list_id" gorm:"type:uuid"`
+	DiscountPercent float64     `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
+	DiscountAmount  float64     `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	TaxPercent      float64     `json:"tax_percent" gorm:"type:decimal(5,2);default:10"`
+	TaxAmount       float64     `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	LineTotal       float64     `json:"line_total" gorm:"type:decimal(18,2);default:0"`
+	Notes           string      `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time   `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt       time.Time   `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
 func (QuotationLineItem) TableName() string {
@@ -41,10 +41,19 @@ const (
 type ItemAction string
 
 const (
-	ItemActionRefund   ItemAction = "REFUND"
-	ItemActionExchange ItemAction = "EXCHANGE"
-	ItemActionCredit   ItemAction = "CREDIT"
-	ItemActionDispose  ItemAction = "DISPOSE"
+	ItemActionRefund   ItemAction = "REFUND"   // Legacy: settlement follows the return type
+	ItemActionExchange ItemAction = "EXCHANGE" // Replacement shipped instead of a refund
+	ItemActionCredit   ItemAction = "CREDIT"   // Legacy: settlement follows the return type
+	ItemActionDispose  ItemAction = "DISPOSE"  // Written off, then refunded or credited
+	ItemActionRestock  ItemAction = "RESTOCK"  // Back to sellable stock, then refunded or credited
+)
+
+// ItemDisposition is what the warehouse does with a returned item
+type ItemDisposition string
+
+const (
+	ItemDispositionRestock ItemDisposition = "RESTOCK"
+	ItemDispositionDispose ItemDisposition = "DISPOSE"
 )
 
 // Return represents a sales return
@@ -62,6 +71,9 @@ type Return struct {
 	Subtotal     float64       `json:"subtotal" gorm:"type:decimal(18,2);default:0"`
 	RefundAmount float64       `json:"refund_amount" gorm:"type:decimal(18,2);default:0"`
 	Notes        string        `json:"notes" gorm:"type:text"`
+	ExchangeSOID *uuid.UUID    `json:"exchange_so_id" gorm:"type:uuid"` // Replacement order for exchanged items
+	ReceivedAt   *time.Time    `json:"received_at" gorm:"type:timestamp"`
+	InspectedAt  *time.Time    `json:"inspected_at" gorm:"type:timestamp"`
 	ApprovedBy   *uuid.UUID    `json:"approved_by" gorm:"type:uuid"`
 	ApprovedAt   *time.Time    `json:"approved_at" gorm:"type:timestamp"`
 	CompletedAt  *time.Time    `json:"completed_at" gorm:"type:timestamp"`
@@ -71,13 +83,39 @@ type Return struct {
 	UpdatedAt    time.Time     `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 
 	// Relations
-	LineItems []ReturnLineItem `json:"line_items,omitempty" gorm:"foreignKey:ReturnID"`
+	LineItems  []ReturnLineItem `json:"line_items,omitempty" gorm:"foreignKey:ReturnID"`
+	CreditNote *CreditNote      `json:"credit_note,omitempty" gorm:"foreignKey:ReturnID"`
 }
 
 func (Return) TableName() string {
 	return "returns"
 }
 
+// CanBeApproved checks if return can be approved
+func (r *Return) CanBeApproved() bool {
+	return r.Status == ReturnStatusPending
+}
+
+// CanBeRejected checks if return can be rejected
+func (r *Return) CanBeRejected() bool {
+	return r.Status == ReturnStatusPending || r.Status == ReturnStatusApproved
+}
+
+// CanBeReceived checks if returned goods can be received
+func (r *Return) CanBeReceived() bool {
+	return r.Status == ReturnStatusApproved
+}
+
+// CanBeInspected checks if returned goods can be inspected
+func (r *Return) CanBeInspected() bool {
+	return r.Status == ReturnStatusReceived
+}
+
+// CanBeCompleted checks if return can be completed
+func (r *Return) CanBeCompleted() bool {
+	return r.Status == ReturnStatusInspected
+}
+
 // Approve approves the return
 func (r *Return) Approve(userID uuid.UUID) {
 	now := time.Now()
@@ -89,14 +127,18 @@ func (r *Return) Approve(userID uuid.UUID) {
 
 // MarkReceived marks return as received
 func (r *Return) MarkReceived() {
+	now := time.Now()
 	r.Status = ReturnStatusReceived
-	r.UpdatedAt = time.Now()
+	r.ReceivedAt = &now
+	r.UpdatedAt = now
 }
 
 // MarkInspected marks return as inspected
 func (r *Return) MarkInspected() {
+	now := time.Now()
 	r.Status = ReturnStatusInspected
-	r.UpdatedAt = time.Now()
+	r.InspectedAt = &now
+	r.UpdatedAt = now
 }
 
 // Complete completes the return
@@ -113,13 +155,18 @@ func (r *Return) Reject() {
 	r.UpdatedAt = time.Now()
 }
 
-// CalculateTotals calculates return totals
+// CalculateTotals calculates return totals. Exchanged items are replaced
+// rather than refunded, so they are left out of the refund amount.
 func (r *Return) CalculateTotals() {
 	r.Subtotal = 0
+	r.RefundAmount = 0
 	for _, item := range r.LineItems {
-		r.Subtotal += item.Quantity * item.UnitPrice
+		amount := item.Quantity * item.UnitPrice
+		r.Subtotal += amount
+		if item.Action != ItemActionExchange {
+			r.RefundAmount += amount
+		}
 	}
-	r.RefundAmount = r.Subtotal
 }
 
 // ReturnLineItem represents a line item in return
@@ -131,13 +178,29 @@ type ReturnLineItem struct {
 	ProductCode  string        `json:"product_code" gorm:"type:varchar(50)"`
 	ProductName  string        `json:"product_name" gorm:"type:varchar(200)"`
 	Quantity     float64       `json:"quantity" gorm:"type:decimal(18,3);not null"`
-	UnitPrice    float64       `json:"unit_price" gorm:"type:decimal(18,2);not null"`
+	UnitPrice    float64       `json:"unit_price" gorm:"type:decimal(18,2);not null"` // Net price charged per unit
+	LotNumber    string        `json:"lot_number" gorm:"type:varchar(50)"`           // Original lot, resolved by WMS when empty
 	Reason       string        `json:"reason" gorm:"type:varchar(200)"`
 	Condition    ItemCondition `json:"condition" gorm:"type:varchar(20);default:'GOOD'"`
-	Action       ItemAction    `json:"action" gorm:"type:varchar(20);default:'REFUND'"`
+	Action       ItemAction    `json:"action" gorm:"type:varchar(20);default:'RESTOCK'"`
+	Notes        string        `json:"notes" gorm:"type:text"` // Inspection findings
 	CreatedAt    time.Time     `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
 func (ReturnLineItem) TableName() string {
 	return "return_line_items"
 }
+
+// Disposition returns what the warehouse does with the item: exchanged
+// items go back to stock only when they are in good condition
+func (li *ReturnLineItem) Disposition() ItemDisposition {
+	switch li.Action {
+	case ItemActionDispose:
+		return ItemDispositionDispose
+	case ItemActionExchange:
+		if li.Condition != ItemConditionGood {
+			return ItemDispositionDispose
+		}
+	}
+	return ItemDispositionRestock
+}
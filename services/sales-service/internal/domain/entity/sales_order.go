@@ -11,6 +11,7 @@ type SOStatus string
 
 const (
 	SOStatusDraft            SOStatus = "DRAFT"
+	SOStatusOnCreditHold     SOStatus = "ON_CREDIT_HOLD"
 	SOStatusConfirmed        SOStatus = "CONFIRMED"
 	SOStatusProcessing       SOStatus = "PROCESSING"
 	SOStatusPartiallyShipped SOStatus = "PARTIALLY_SHIPPED"
@@ -50,13 +51,16 @@ type SalesOrder struct {
 	DeliveryDate       *time.Time    `json:"delivery_date" gorm:"type:date"`
 	DeliveryAddress    string        `json:"delivery_address" gorm:"type:text"`
 	BillingAddress     string        `json:"billing_address" gorm:"type:text"`
+	Channel            SalesChannel  `json:"channel" gorm:"type:varchar(20);default:'DIRECT'"`
 	Subtotal           float64       `json:"subtotal" gorm:"type:decimal(18,2);default:0"`
 	DiscountPercent    float64       `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
 	DiscountAmount     float64       `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	PromotionDiscount  float64       `json:"promotion_discount" gorm:"type:decimal(18,2);default:0"`
 	TaxPercent         float64       `json:"tax_percent" gorm:"type:decimal(5,2);default:10"`
 	TaxAmount          float64       `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
 	TotalAmount        float64       `json:"total_amount" gorm:"type:decimal(18,2);default:0"`
 	Status             SOStatus      `json:"status" gorm:"type:varchar(20);default:'DRAFT'"`
+	Version            int           `json:"version" gorm:"not null;default:1"` // Raised by each amendment
 	PaymentMethod      PaymentMethod `json:"payment_method" gorm:"type:varchar(20);default:'BANK_TRANSFER'"`
 	PaymentStatus      PaymentStatus `json:"payment_status" gorm:"type:varchar(20);default:'PENDING'"`
 	ConfirmedAt        *time.Time    `json:"confirmed_at" gorm:"type:timestamp"`
@@ -73,7 +77,8 @@ type SalesOrder struct {
 	UpdatedAt          time.Time     `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 
 	// Relations
-	LineItems []SOLineItem `json:"line_items,omitempty" gorm:"foreignKey:SalesOrderID"`
+	LineItems  []SOLineItem     `json:"line_items,omitempty" gorm:"foreignKey:SalesOrderID"`
+	Promotions []OrderPromotion `json:"promotions,omitempty" gorm:"foreignKey:SalesOrderID"`
 }
 
 func (SalesOrder) TableName() string {
@@ -87,7 +92,19 @@ func (so *SalesOrder) CanBeConfirmed() bool {
 
 // CanBeCancelled checks if order can be cancelled
 func (so *SalesOrder) CanBeCancelled() bool {
-	return so.Status == SOStatusDraft || so.Status == SOStatusConfirmed
+	return so.Status == SOStatusDraft || so.Status == SOStatusOnCreditHold || so.Status == SOStatusConfirmed
+}
+
+// CanBeAmended checks if a confirmed order can still be changed
+func (so *SalesOrder) CanBeAmended() bool {
+	return so.Status == SOStatusConfirmed || so.Status == SOStatusProcessing || so.Status == SOStatusPartiallyShipped
+}
+
+// Amend raises the order version
+func (so *SalesOrder) Amend(userID uuid.UUID) {
+	so.Version++
+	so.UpdatedBy = &userID
+	so.UpdatedAt = time.Now()
 }
 
 // CanBeShipped checks if order can be shipped
@@ -95,6 +112,23 @@ func (so *SalesOrder) CanBeShipped() bool {
 	return so.Status == SOStatusConfirmed || so.Status == SOStatusProcessing || so.Status == SOStatusPartiallyShipped
 }
 
+// IsOnCreditHold checks if order is waiting for a credit review
+func (so *SalesOrder) IsOnCreditHold() bool {
+	return so.Status == SOStatusOnCreditHold
+}
+
+// PlaceOnCreditHold holds the order for a credit review instead of confirming it
+func (so *SalesOrder) PlaceOnCreditHold() {
+	so.Status = SOStatusOnCreditHold
+	so.UpdatedAt = time.Now()
+}
+
+// ReleaseToDraft returns a held order to draft after its credit hold is rejected
+func (so *SalesOrder) ReleaseToDraft() {
+	so.Status = SOStatusDraft
+	so.UpdatedAt = time.Now()
+}
+
 // Confirm confirms the sales order
 func (so *SalesOrder) Confirm(userID uuid.UUID) {
 	now := time.Now()
@@ -159,7 +193,7 @@ func (so *SalesOrder) CalculateTotals() {
 	}
 
 	// Calculate tax
-	taxableAmount := so.Subtotal - so.DiscountAmount
+	taxableAmount := so.Subtotal - so.DiscountAmount - so.PromotionDiscount
 	so.TaxAmount = taxableAmount * so.TaxPercent / 100
 
 	// Calculate total
@@ -195,27 +229,55 @@ func (so *SalesOrder) IsFullyShipped() bool {
 	return true
 }
 
+// ShippedQuantityOf returns the shipped quantity of a line. Shipments cover the
+// whole order, so a shipped order without per-line quantities counts as fully shipped.
+func (so *SalesOrder) ShippedQuantityOf(li *SOLineItem) float64 {
+	if li.ShippedQuantity == 0 && (so.Status == SOStatusShipped || so.Status == SOStatusDelivered) {
+		return li.Quantity
+	}
+	return li.ShippedQuantity
+}
+
+// NetUnitPrice returns what the customer was charged per unit of a line,
+// after line and order discounts and tax
+func (so *SalesOrder) NetUnitPrice(li *SOLineItem) float64 {
+	if li.Quantity <= 0 {
+		return 0
+	}
+	price := li.LineTotal / li.Quantity
+	if so.Subtotal > 0 {
+		price *= so.TotalAmount / so.Subtotal
+	}
+	return price
+}
+
 // SOLineItem represents a line item in sales order
 type SOLineItem struct {
-	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	SalesOrderID    uuid.UUID  `json:"sales_order_id" gorm:"type:uuid;not null"`
-	LineNumber      int        `json:"line_number" gorm:"not null"`
-	ProductID       uuid.UUID  `json:"product_id" gorm:"type:uuid;not null"`
-	ProductCode     string     `json:"product_code" gorm:"type:varchar(50)"`
-	ProductName     string     `json:"product_name" gorm:"type:varchar(200)"`
-	Quantity        float64    `json:"quantity" gorm:"type:decimal(18,3);not null"`
-	ShippedQuantity float64    `json:"shipped_quantity" gorm:"type:decimal(18,3);default:0"`
-	UomID           *uuid.UUID `json:"uom_id" gorm:"type:uuid"`
-	UnitPrice       float64    `json:"unit_price" gorm:"type:decimal(18,2);not null"`
-	DiscountPercent float64    `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
-	DiscountAmount  float64    `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
-	TaxPercent      float64    `json:"tax_percent" gorm:"type:decimal(5,2);default:10"`
-	TaxAmount       float64    `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
-	LineTotal       float64    `json:"line_total" gorm:"type:decimal(18,2);default:0"`
-	ReservationID   *uuid.UUID `json:"reservation_id" gorm:"type:uuid"`
-	Notes           string     `json:"notes" gorm:"type:text"`
-	CreatedAt       time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt       time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+	ID                  uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SalesOrderID        uuid.UUID   `json:"sales_order_id" gorm:"type:uuid;not null"`
+	LineNumber          int         `json:"line_number" gorm:"not null"`
+	ProductID           uuid.UUID   `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode         string      `json:"product_code" gorm:"type:varchar(50)"`
+	ProductName         string      `json:"product_name" gorm:"type:varchar(200)"`
+	Quantity            float64     `json:"quantity" gorm:"type:decimal(18,3);not null"`
+	ShippedQuantity     float64     `json:"shipped_quantity" gorm:"type:decimal(18,3);default:0"`
+	BackorderedQuantity float64     `json:"backordered_quantity" gorm:"type:decimal(18,3);default:0"` // Waiting for stock in WMS
+	ExpectedDate        *time.Time  `json:"expected_date" gorm:"type:date"`                           // Of the backordered quantity
+	PromiseDate         *time.Time  `json:"promise_date" gorm:"type:date"`                            // Earliest delivery date quoted by WMS at entry
+	UomID               *uuid.UUID  `json:"uom_id" gorm:"type:uuid"`
+	UnitPrice           float64     `json:"unit_price" gorm:"type:decimal(18,2);not null"`
+	PriceSource         PriceSource `json:"price_source" gorm:"type:varchar(20);default:'MANUAL'"`
+	PriceListID         *uuid.UUID  `json:"price_list_id" gorm:"type:uuid"`
+	PromotionID         *uuid.UUID  `json:"promotion_id" gorm:"type:uuid"` // Free goods or bundle promotion
+	DiscountPercent     float64     `json:"discount_percent" gorm:"type:decimal(5,2);default:0"`
+	DiscountAmount      float64     `json:"discount_amount" gorm:"type:decimal(18,2);default:0"`
+	TaxPercent          float64     `json:"tax_percent" gorm:"type:decimal(5,2);default:10"`
+	TaxAmount           float64     `json:"tax_amount" gorm:"type:decimal(18,2);default:0"`
+	LineTotal           float64     `json:"line_total" gorm:"type:decimal(18,2);default:0"`
+	ReservationID       *uuid.UUID  `json:"reservation_id" gorm:"type:uuid"`
+	Notes               string      `json:"notes" gorm:"type:text"`
+	CreatedAt           time.Time   `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt           time.Time   `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
 }
 
 func (SOLineItem) TableName() string {
@@ -249,6 +311,11 @@ func (li *SOLineItem) IsFullyShipped() bool {
 	return li.ShippedQuantity >= li.Quantity
 }
 
+// IsFreeGoods checks if the line was added by a buy X get Y promotion
+func (li *SOLineItem) IsFreeGoods() bool {
+	return li.PromotionID != nil && li.DiscountPercent == 100
+}
+
 // AddShippedQuantity adds to shipped quantity
 func (li *SOLineItem) AddShippedQuantity(qty float64) {
 	li.ShippedQuantity += qty
@@ -31,6 +31,10 @@ type Shipment struct {
 	Carrier               string          `json:"carrier" gorm:"type:varchar(100)"`
 	TrackingNumber        string          `json:"tracking_number" gorm:"type:varchar(100)"`
 	ShippingMethod        string          `json:"shipping_method" gorm:"type:varchar(50)"`
+	CarrierService        string          `json:"carrier_service" gorm:"type:varchar(50)"`       // Service code booked with the carrier
+	LabelURL              string          `json:"label_url" gorm:"type:text"`
+	TrackingStatus        string          `json:"tracking_status" gorm:"type:varchar(30)"`       // Last status reported by the carrier
+	TrackingUpdatedAt     *time.Time      `json:"tracking_updated_at" gorm:"type:timestamp"`
 	ShippingCost          float64         `json:"shipping_cost" gorm:"type:decimal(18,2);default:0"`
 	Status                ShipmentStatus  `json:"status" gorm:"type:varchar(20);default:'PENDING'"`
 	RecipientName         string          `json:"recipient_name" gorm:"type:varchar(200)"`
@@ -41,12 +45,48 @@ type Shipment struct {
 	UpdatedBy             *uuid.UUID      `json:"updated_by" gorm:"type:uuid"`
 	CreatedAt             time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt             time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+
+	// Relations
+	LineItems []ShipmentLineItem `json:"line_items,omitempty" gorm:"foreignKey:ShipmentID"`
 }
 
 func (Shipment) TableName() string {
 	return "shipments"
 }
 
+// ShipmentLineItem represents the quantity of an order line in a shipment.
+// Shipments without lines cover the whole order.
+type ShipmentLineItem struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ShipmentID   uuid.UUID `json:"shipment_id" gorm:"type:uuid;not null"`
+	SOLineItemID uuid.UUID `json:"so_line_item_id" gorm:"type:uuid;not null"`
+	ProductID    uuid.UUID `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode  string    `json:"product_code" gorm:"type:varchar(50)"`
+	ProductName  string    `json:"product_name" gorm:"type:varchar(200)"`
+	Quantity     float64   `json:"quantity" gorm:"type:decimal(18,3);not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (ShipmentLineItem) TableName() string {
+	return "shipment_line_items"
+}
+
+// ShipmentTrackingEvent represents a scan of a shipment reported by its carrier
+type ShipmentTrackingEvent struct {
+	ID             uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ShipmentID     uuid.UUID `json:"shipment_id" gorm:"type:uuid;not null;uniqueIndex:idx_shipment_tracking_event"`
+	TrackingNumber string    `json:"tracking_number" gorm:"type:varchar(100)"`
+	Status         string    `json:"status" gorm:"type:varchar(30);not null;uniqueIndex:idx_shipment_tracking_event"`
+	Description    string    `json:"description" gorm:"type:text"`
+	Location       string    `json:"location" gorm:"type:varchar(200)"`
+	OccurredAt     time.Time `json:"occurred_at" gorm:"type:timestamp;not null;uniqueIndex:idx_shipment_tracking_event"`
+	CreatedAt      time.Time `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (ShipmentTrackingEvent) TableName() string {
+	return "shipment_tracking_events"
+}
+
 // MarkPicked marks shipment as picked
 func (s *Shipment) MarkPicked() {
 	s.Status = ShipmentStatusPicked
@@ -94,6 +134,11 @@ func (s *Shipment) CanBeShipped() bool {
 	return s.Status == ShipmentStatusPending || s.Status == ShipmentStatusPicked || s.Status == ShipmentStatusPacked
 }
 
+// IsShipped checks if the goods have left the warehouse
+func (s *Shipment) IsShipped() bool {
+	return s.Status == ShipmentStatusShipped || s.Status == ShipmentStatusInTransit || s.Status == ShipmentStatusDelivered
+}
+
 // CanBeDelivered checks if shipment can be marked as delivered
 func (s *Shipment) CanBeDelivered() bool {
 	return s.Status == ShipmentStatusShipped || s.Status == ShipmentStatusInTransit
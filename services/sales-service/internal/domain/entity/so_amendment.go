@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LineChangeType represents how an amendment changed an order line
+type LineChangeType string
+
+const (
+	LineChangeAdded   LineChangeType = "ADDED"
+	LineChangeChanged LineChangeType = "CHANGED"
+	LineChangeRemoved LineChangeType = "REMOVED"
+)
+
+// SOAmendment records a change to a confirmed sales order. Each amendment
+// raises the order version.
+type SOAmendment struct {
+	ID              uuid.UUID         `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SalesOrderID    uuid.UUID         `json:"sales_order_id" gorm:"type:uuid;not null"`
+	Version         int               `json:"version" gorm:"not null"` // Order version after the amendment
+	Reason          string            `json:"reason" gorm:"type:text"`
+	OldDeliveryDate *time.Time        `json:"old_delivery_date" gorm:"type:date"`
+	NewDeliveryDate *time.Time        `json:"new_delivery_date" gorm:"type:date"`
+	OldTotalAmount  float64           `json:"old_total_amount" gorm:"type:decimal(18,2);default:0"`
+	NewTotalAmount  float64           `json:"new_total_amount" gorm:"type:decimal(18,2);default:0"`
+	AmendedAt       time.Time         `json:"amended_at" gorm:"type:timestamp;not null"`
+	AmendedBy       *uuid.UUID        `json:"amended_by" gorm:"type:uuid"`
+	CreatedAt       time.Time         `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	Lines           []SOLineAmendment `json:"lines,omitempty" gorm:"foreignKey:AmendmentID"`
+}
+
+func (SOAmendment) TableName() string {
+	return "so_amendments"
+}
+
+// SOLineAmendment records the change of one order line in an amendment. The
+// line itself is deleted when removed, its history is kept.
+type SOLineAmendment struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	AmendmentID  uuid.UUID      `json:"amendment_id" gorm:"type:uuid;not null"`
+	SOLineItemID uuid.UUID      `json:"so_line_item_id" gorm:"type:uuid;not null"`
+	Version      int            `json:"version" gorm:"not null"`
+	LineNumber   int            `json:"line_number"`
+	ProductID    uuid.UUID      `json:"product_id" gorm:"type:uuid;not null"`
+	ProductCode  string         `json:"product_code" gorm:"type:varchar(50)"`
+	ChangeType   LineChangeType `json:"change_type" gorm:"type:varchar(20);not null"`
+	OldQuantity  float64        `json:"old_quantity" gorm:"type:decimal(18,3);default:0"`
+	NewQuantity  float64        `json:"new_quantity" gorm:"type:decimal(18,3);default:0"`
+	OldUnitPrice float64        `json:"old_unit_price" gorm:"type:decimal(18,2);default:0"`
+	NewUnitPrice float64        `json:"new_unit_price" gorm:"type:decimal(18,2);default:0"`
+	OldLineTotal float64        `json:"old_line_total" gorm:"type:decimal(18,2);default:0"`
+	NewLineTotal float64        `json:"new_line_total" gorm:"type:decimal(18,2);default:0"`
+	CreatedAt    time.Time      `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (SOLineAmendment) TableName() string {
+	return "so_line_amendments"
+}
+
+// DeltaQuantity returns the quantity added to the line, negative if reduced
+func (l *SOLineAmendment) DeltaQuantity() float64 {
+	return l.NewQuantity - l.OldQuantity
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// CreditHoldFilter defines filter options for credit holds
+type CreditHoldFilter struct {
+	CustomerID   *uuid.UUID
+	SalesOrderID *uuid.UUID
+	Status       entity.CreditHoldStatus
+	Page         int
+	Limit        int
+}
+
+// CreditHoldRepository defines credit hold repository interface
+type CreditHoldRepository interface {
+	Create(ctx context.Context, hold *entity.CreditHold) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditHold, error)
+	Update(ctx context.Context, hold *entity.CreditHold) error
+	List(ctx context.Context, filter *CreditHoldFilter) ([]*entity.CreditHold, int64, error)
+
+	// GetPendingByOrder returns the hold of a sales order waiting for a decision
+	GetPendingByOrder(ctx context.Context, orderID uuid.UUID) (*entity.CreditHold, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// CreditNoteRepository defines credit note repository interface
+type CreditNoteRepository interface {
+	Create(ctx context.Context, note *entity.CreditNote) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditNote, error)
+	Update(ctx context.Context, note *entity.CreditNote) error
+	GetByReturn(ctx context.Context, returnID uuid.UUID) (*entity.CreditNote, error)
+	GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.CreditNote, error)
+
+	// Number generation
+	GetNextCreditNoteNumber(ctx context.Context) (string, error)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// EInvoiceFilter defines filter options for e-invoices
+type EInvoiceFilter struct {
+	InvoiceID *uuid.UUID
+	Status    entity.EInvoiceStatus
+	DateFrom  string
+	DateTo    string
+	Page      int
+	Limit     int
+}
+
+// EInvoiceRepository defines e-invoice repository interface
+type EInvoiceRepository interface {
+	Create(ctx context.Context, einvoice *entity.EInvoice) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error)
+	Update(ctx context.Context, einvoice *entity.EInvoice) error
+	List(ctx context.Context, filter *EInvoiceFilter) ([]*entity.EInvoice, int64, error)
+
+	// GetActiveByInvoice returns the e-invoice of a sales invoice that is not cancelled
+	GetActiveByInvoice(ctx context.Context, invoiceID uuid.UUID) (*entity.EInvoice, error)
+
+	// Number generation within a template and series
+	GetNextNumber(ctx context.Context, templateCode, series string) (int, error)
+}
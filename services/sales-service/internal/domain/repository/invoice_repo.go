@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// InvoiceFilter defines filter options for invoices
+type InvoiceFilter struct {
+	CustomerID   *uuid.UUID
+	SalesOrderID *uuid.UUID
+	Status       entity.InvoiceStatus
+	OverdueOnly  bool
+	DateFrom     string
+	DateTo       string
+	Page         int
+	Limit        int
+}
+
+// ARSummary represents the receivable position of a customer
+type ARSummary struct {
+	OpenInvoices    float64 // Balance of open invoices
+	OverdueAmount   float64 // Part of the open balance past due
+	OverdueInvoices int64
+	UnappliedCredit float64 // Unallocated payments and credit notes
+	UnbilledOrders  float64 // Confirmed orders not yet invoiced
+}
+
+// OpenAR returns what the customer owes on invoices net of unapplied credit
+func (s *ARSummary) OpenAR() float64 {
+	return s.OpenInvoices - s.UnappliedCredit
+}
+
+// InvoiceRepository defines invoice repository interface
+type InvoiceRepository interface {
+	// CRUD
+	Create(ctx context.Context, invoice *entity.Invoice) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error)
+	Update(ctx context.Context, invoice *entity.Invoice) error
+	List(ctx context.Context, filter *InvoiceFilter) ([]*entity.Invoice, int64, error)
+
+	// Number generation
+	GetNextInvoiceNumber(ctx context.Context) (string, error)
+
+	// By source document
+	GetByShipment(ctx context.Context, shipmentID uuid.UUID) (*entity.Invoice, error)
+	GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Invoice, error)
+
+	// By customer
+	GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Invoice, error)
+	GetOpenByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Invoice, error)
+	GetOpen(ctx context.Context, customerID *uuid.UUID) ([]*entity.Invoice, error)
+	GetSummary(ctx context.Context, customerID uuid.UUID, asOf time.Time) (*ARSummary, error)
+
+	// Allocations
+	CreateAllocation(ctx context.Context, allocation *entity.InvoiceAllocation) error
+}
+
+// PaymentFilter defines filter options for payments
+type PaymentFilter struct {
+	CustomerID *uuid.UUID
+	DateFrom   string
+	DateTo     string
+	Page       int
+	Limit      int
+}
+
+// PaymentRepository defines payment repository interface
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entity.Payment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error)
+	Update(ctx context.Context, payment *entity.Payment) error
+	List(ctx context.Context, filter *PaymentFilter) ([]*entity.Payment, int64, error)
+	GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Payment, error)
+
+	// Number generation
+	GetNextPaymentNumber(ctx context.Context) (string, error)
+}
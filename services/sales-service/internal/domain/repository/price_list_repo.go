@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// PriceListFilter defines filter options for price lists
+type PriceListFilter struct {
+	Search          string
+	ListType        entity.PriceListType
+	CustomerGroupID *uuid.UUID
+	CustomerID      *uuid.UUID
+	CampaignID      *uuid.UUID
+	Channel         entity.SalesChannel
+	ActiveOnly      bool
+	Page            int
+	Limit           int
+}
+
+// PriceListRepository defines price list repository interface
+type PriceListRepository interface {
+	// CRUD
+	Create(ctx context.Context, priceList *entity.PriceList) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceList, error)
+	GetByCode(ctx context.Context, code string) (*entity.PriceList, error)
+	Update(ctx context.Context, priceList *entity.PriceList) error
+	List(ctx context.Context, filter *PriceListFilter) ([]*entity.PriceList, int64, error)
+
+	// Items
+	CreateItem(ctx context.Context, item *entity.PriceListItem) error
+
+	// Pricing: active lists valid on the date, with only the items of the given products loaded
+	GetValidForProducts(ctx context.Context, date time.Time, productIDs []uuid.UUID) ([]*entity.PriceList, error)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// PromotionFilter defines filter options for promotions
+type PromotionFilter struct {
+	Search        string
+	PromotionType entity.PromotionType
+	CampaignID    *uuid.UUID
+	ActiveOnly    bool
+	Page          int
+	Limit         int
+}
+
+// PromotionRepository defines promotion repository interface
+type PromotionRepository interface {
+	// CRUD
+	Create(ctx context.Context, promotion *entity.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error)
+	GetByCode(ctx context.Context, code string) (*entity.Promotion, error)
+	Update(ctx context.Context, promotion *entity.Promotion) error
+	List(ctx context.Context, filter *PromotionFilter) ([]*entity.Promotion, int64, error)
+
+	// Evaluation: active promotions valid on the date that apply without a voucher
+	GetAutomatic(ctx context.Context, date time.Time) ([]*entity.Promotion, error)
+
+	// Usage tracking: reports false when the budget does not cover the cost
+	AddUsage(ctx context.Context, id uuid.UUID, cost float64, uses int) (bool, error)
+
+	// Vouchers
+	CreateVoucher(ctx context.Context, voucher *entity.Voucher) error
+	GetVoucherByCode(ctx context.Context, code string) (*entity.Voucher, error)
+	GetVouchers(ctx context.Context, promotionID uuid.UUID) ([]*entity.Voucher, error)
+	AddVoucherUse(ctx context.Context, id uuid.UUID, uses int) (bool, error) // False when the voucher is used up
+
+	// Order promotions
+	GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderPromotion, error)
+	MarkOrderPromotionsReleased(ctx context.Context, orderID uuid.UUID) error
+	MarkOrderPromotionReleased(ctx context.Context, id uuid.UUID) error
+}
@@ -45,6 +45,10 @@ type SalesOrderRepository interface {
 	UpdateShippedQuantity(ctx context.Context, lineItemID uuid.UUID, shippedQty float64) error
 	UpdateLineItemReservation(ctx context.Context, lineItemID uuid.UUID, reservationID uuid.UUID) error
 
+	// Amendments
+	CreateAmendment(ctx context.Context, amendment *entity.SOAmendment) error
+	GetAmendments(ctx context.Context, orderID uuid.UUID) ([]*entity.SOAmendment, error)
+
 	// By customer
 	GetByCustomer(ctx context.Context, customerID uuid.UUID, limit int) ([]*entity.SalesOrder, error)
 	GetPendingOrdersByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.SalesOrder, error)
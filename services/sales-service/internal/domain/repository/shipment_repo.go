@@ -38,6 +38,11 @@ type ShipmentRepository interface {
 
 	// By tracking number
 	GetByTrackingNumber(ctx context.Context, trackingNumber string) (*entity.Shipment, error)
+
+	// Carrier tracking
+	GetTrackable(ctx context.Context) ([]*entity.Shipment, error)
+	AddTrackingEvent(ctx context.Context, event *entity.ShipmentTrackingEvent) (bool, error)
+	GetTrackingEvents(ctx context.Context, shipmentID uuid.UUID) ([]*entity.ShipmentTrackingEvent, error)
 }
 
 // ReturnFilter defines filter options for returns
@@ -65,6 +70,7 @@ type ReturnRepository interface {
 
 	// Line items
 	CreateLineItem(ctx context.Context, item *entity.ReturnLineItem) error
+	UpdateLineItem(ctx context.Context, item *entity.ReturnLineItem) error
 	GetLineItems(ctx context.Context, returnID uuid.UUID) ([]*entity.ReturnLineItem, error)
 
 	// Status updates
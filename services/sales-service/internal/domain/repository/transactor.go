@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by lookups of a record that does not exist
+var ErrNotFound = errors.New("record not found")
+
+// Transactor runs several repository calls in one database transaction
+type Transactor interface {
+	// WithinTransaction runs fn with a context that carries the transaction;
+	// repositories called with that context join it. Nested calls join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
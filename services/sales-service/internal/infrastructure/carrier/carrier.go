@@ -0,0 +1,158 @@
+package carrier
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrUnknownCarrier = errors.New("carrier is not configured")
+	ErrNoRates        = errors.New("no carrier service quoted for the shipment")
+)
+
+// Address represents the sender or the recipient of a parcel
+type Address struct {
+	Name    string
+	Phone   string
+	Address string
+}
+
+// Parcel represents what is handed over to the carrier
+type Parcel struct {
+	WeightKg      float64
+	DeclaredValue float64 // Insured value
+	CODAmount     float64 // Collected from the recipient on delivery
+}
+
+// RateRequest represents a request for the services and prices of a carrier
+type RateRequest struct {
+	ShipFrom Address
+	ShipTo   Address
+	Parcel   Parcel
+}
+
+// Rate represents a quoted carrier service
+type Rate struct {
+	Carrier           string    `json:"carrier"`
+	ServiceCode       string    `json:"service_code"`
+	ServiceName       string    `json:"service_name"`
+	Amount            float64   `json:"amount"`
+	Currency          string    `json:"currency"`
+	TransitDays       int       `json:"transit_days"`
+	EstimatedDelivery time.Time `json:"estimated_delivery"`
+}
+
+// LabelRequest represents the booking of a carrier service for a shipment
+type LabelRequest struct {
+	Reference   string // Shipment number, printed on the label
+	ServiceCode string
+	ShipFrom    Address
+	ShipTo      Address
+	Parcel      Parcel
+}
+
+// Label represents a booked parcel
+type Label struct {
+	TrackingNumber    string
+	ServiceCode       string
+	Amount            float64
+	LabelURL          string
+	EstimatedDelivery time.Time
+}
+
+// TrackingStatus represents the status of a parcel reported by its carrier
+type TrackingStatus string
+
+const (
+	StatusLabelCreated   TrackingStatus = "LABEL_CREATED"
+	StatusPickedUp       TrackingStatus = "PICKED_UP"
+	StatusInTransit      TrackingStatus = "IN_TRANSIT"
+	StatusOutForDelivery TrackingStatus = "OUT_FOR_DELIVERY"
+	StatusDelivered      TrackingStatus = "DELIVERED"
+	StatusFailedAttempt  TrackingStatus = "FAILED_ATTEMPT" // Delivery is attempted again
+	StatusReturned       TrackingStatus = "RETURNED"       // Returned to the sender
+	StatusException      TrackingStatus = "EXCEPTION"
+)
+
+// TrackingEvent represents a scan of a parcel
+type TrackingEvent struct {
+	TrackingNumber string
+	Status         TrackingStatus
+	Description    string
+	Location       string
+	OccurredAt     time.Time
+}
+
+// Carrier defines the adapter to a shipping carrier
+type Carrier interface {
+	// Name identifies the carrier on shipments
+	Name() string
+	// Rates quotes the services of the carrier for a parcel
+	Rates(ctx context.Context, req *RateRequest) ([]Rate, error)
+	// CreateLabel books a service and returns the tracking number
+	CreateLabel(ctx context.Context, req *LabelRequest) (*Label, error)
+	// Track returns the tracking events of a parcel so far
+	Track(ctx context.Context, trackingNumber string) ([]TrackingEvent, error)
+	// ParseWebhook verifies and decodes a tracking webhook of the carrier
+	ParseWebhook(payload []byte, signature string) ([]TrackingEvent, error)
+}
+
+// Registry holds the configured carriers by name
+type Registry struct {
+	carriers []Carrier
+}
+
+// NewRegistry creates a registry of carriers
+func NewRegistry(carriers ...Carrier) *Registry {
+	return &Registry{carriers: carriers}
+}
+
+// Get returns the carrier of a name
+func (r *Registry) Get(name string) (Carrier, error) {
+	for _, c := range r.carriers {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, ErrUnknownCarrier
+}
+
+// All returns the configured carriers
+func (r *Registry) All() []Carrier {
+	return r.carriers
+}
+
+// Strategy decides which quoted rate is booked
+type Strategy string
+
+const (
+	StrategyCheapest Strategy = "CHEAPEST"
+	StrategyFastest  Strategy = "FASTEST"
+)
+
+// Select returns the cheapest or the fastest rate. Ties go to the faster of
+// the cheapest rates, or the cheaper of the fastest.
+func Select(rates []Rate, strategy Strategy) (*Rate, error) {
+	if len(rates) == 0 {
+		return nil, ErrNoRates
+	}
+
+	sorted := make([]Rate, len(rates))
+	copy(sorted, rates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if strategy == StrategyFastest {
+			if !a.EstimatedDelivery.Equal(b.EstimatedDelivery) {
+				return a.EstimatedDelivery.Before(b.EstimatedDelivery)
+			}
+			return a.Amount < b.Amount
+		}
+		if a.Amount != b.Amount {
+			return a.Amount < b.Amount
+		}
+		return a.EstimatedDelivery.Before(b.EstimatedDelivery)
+	})
+	return &sorted[0], nil
+}
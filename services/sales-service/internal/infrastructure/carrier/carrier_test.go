@@ -0,0 +1,125 @@
+package carrier_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/carrier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestSelect(t *testing.T) {
+	day := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	economy := carrier.Rate{ServiceCode: "ECONOMY", Amount: 18000, EstimatedDelivery: day.AddDate(0, 0, 5)}
+	standard := carrier.Rate{ServiceCode: "STANDARD", Amount: 25000, EstimatedDelivery: day.AddDate(0, 0, 3)}
+	express := carrier.Rate{ServiceCode: "EXPRESS", Amount: 45000, EstimatedDelivery: day.AddDate(0, 0, 1)}
+	cheapExpress := carrier.Rate{ServiceCode: "CHEAP-EXPRESS", Amount: 30000, EstimatedDelivery: day.AddDate(0, 0, 1)}
+	slowEconomy := carrier.Rate{ServiceCode: "SLOW-ECONOMY", Amount: 18000, EstimatedDelivery: day.AddDate(0, 0, 7)}
+
+	tests := []struct {
+		name     string
+		rates    []carrier.Rate
+		strategy carrier.Strategy
+		expected string
+	}{
+		{"Cheapest", []carrier.Rate{standard, express, economy}, carrier.StrategyCheapest, "ECONOMY"},
+		{"Cheapest by default", []carrier.Rate{standard, express, economy}, "", "ECONOMY"},
+		{"Fastest", []carrier.Rate{economy, express, standard}, carrier.StrategyFastest, "EXPRESS"},
+		{"Cheapest tie goes to the faster", []carrier.Rate{slowEconomy, economy}, carrier.StrategyCheapest, "ECONOMY"},
+		{"Fastest tie goes to the cheaper", []carrier.Rate{express, cheapExpress}, carrier.StrategyFastest, "CHEAP-EXPRESS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := carrier.Select(tt.rates, tt.strategy)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rate.ServiceCode)
+		})
+	}
+}
+
+func TestSelect_NoRates(t *testing.T) {
+	_, err := carrier.Select(nil, carrier.StrategyCheapest)
+
+	assert.Equal(t, carrier.ErrNoRates, err)
+}
+
+func TestLocalCarrier_Rates(t *testing.T) {
+	c := carrier.NewLocalCarrier(zap.NewNop(), "secret")
+
+	rates, err := c.Rates(context.Background(), &carrier.RateRequest{Parcel: carrier.Parcel{WeightKg: 2.5}})
+
+	require.NoError(t, err)
+	require.Len(t, rates, 3)
+	// Charged for 3 kg started
+	assert.Equal(t, "ECONOMY", rates[0].ServiceCode)
+	assert.Equal(t, 18000.0+2*3000, rates[0].Amount)
+	assert.Equal(t, "EXPRESS", rates[2].ServiceCode)
+	assert.Equal(t, 45000.0+2*9000, rates[2].Amount)
+}
+
+func TestLocalCarrier_TrackMovesParcelOn(t *testing.T) {
+	ctx := context.Background()
+	c := carrier.NewLocalCarrier(zap.NewNop(), "secret")
+	label, err := c.CreateLabel(ctx, &carrier.LabelRequest{Reference: "SHP-2605-0001", ServiceCode: "STANDARD"})
+	require.NoError(t, err)
+
+	var events []carrier.TrackingEvent
+	for i := 0; i < 6; i++ {
+		events, err = c.Track(ctx, label.TrackingNumber)
+		require.NoError(t, err)
+	}
+
+	// Delivered parcels stay delivered
+	assert.Len(t, events, 5)
+	assert.Equal(t, carrier.StatusLabelCreated, events[0].Status)
+	assert.Equal(t, carrier.StatusPickedUp, events[1].Status)
+	assert.Equal(t, carrier.StatusDelivered, events[4].Status)
+}
+
+func TestLocalCarrier_ParseWebhook(t *testing.T) {
+	payload := []byte(`{"tracking_number":"LC2605040900000001","events":[{"status":"PICKED_UP","location":"Hub","occurred_at":"2026-05-04T09:00:00Z"}]}`)
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		wantErr   error
+	}{
+		{"Signed", "secret", sign("secret", payload), nil},
+		{"Wrong signature", "secret", sign("other", payload), carrier.ErrInvalidSignature},
+		{"Unsigned", "secret", "", carrier.ErrInvalidSignature},
+		{"No secret configured", "", sign("", payload), carrier.ErrWebhookNotConfigured},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := carrier.NewLocalCarrier(zap.NewNop(), tt.secret)
+
+			events, err := c.ParseWebhook(payload, tt.signature)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, events)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, "LC2605040900000001", events[0].TrackingNumber)
+			assert.Equal(t, carrier.StatusPickedUp, events[0].Status)
+		})
+	}
+}
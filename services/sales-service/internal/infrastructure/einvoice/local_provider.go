@@ -0,0 +1,93 @@
+package einvoice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAlreadyCancelled = errors.New("e-invoice is already cancelled with the provider")
+	ErrSellerMismatch   = errors.New("e-invoice seller does not match the request")
+)
+
+type localProvider struct {
+	logger *zap.Logger
+	mu     sync.Mutex
+	issued map[string]bool
+}
+
+// NewLocalProvider creates a stub provider for development and testing. It
+// verifies the signature and answers like a provider would, without
+// contacting the tax authority.
+func NewLocalProvider(logger *zap.Logger) Provider {
+	return &localProvider{
+		logger: logger,
+		issued: make(map[string]bool),
+	}
+}
+
+func (p *localProvider) Name() string {
+	return "local"
+}
+
+func (p *localProvider) TaxCode() string {
+	return ""
+}
+
+func (p *localProvider) Issue(ctx context.Context, req *IssueRequest) (*IssueResult, error) {
+	doc, err := Parse(req.XML)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(doc); err != nil {
+		return nil, err
+	}
+	if doc.DLHDon.NDHDon.NBan.MST != req.SellerTaxCode {
+		return nil, ErrSellerMismatch
+	}
+
+	// Codes derived from the document so that re-issuing answers the same
+	sum := sha256.Sum256(req.XML)
+	code := strings.ToUpper(hex.EncodeToString(sum[:]))
+	result := &IssueResult{
+		ProviderRef:      fmt.Sprintf("LOCAL-%s-%s-%d", req.TemplateCode, req.Series, req.Number),
+		TaxAuthorityCode: "00" + code[:32],
+		LookupCode:       code[32:44],
+		IssuedAt:         time.Now(),
+	}
+
+	p.mu.Lock()
+	p.issued[result.ProviderRef] = true
+	p.mu.Unlock()
+
+	p.logger.Info("E-invoice issued by local provider",
+		zap.String("provider_ref", result.ProviderRef),
+		zap.String("tax_authority_code", result.TaxAuthorityCode),
+	)
+	return result, nil
+}
+
+func (p *localProvider) Cancel(ctx context.Context, req *CancelRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Invoices issued before a restart are unknown to the stub and accepted
+	if issued, known := p.issued[req.ProviderRef]; known && !issued {
+		return ErrAlreadyCancelled
+	}
+	p.issued[req.ProviderRef] = false
+
+	p.logger.Info("E-invoice cancelled by local provider",
+		zap.String("provider_ref", req.ProviderRef),
+		zap.String("reason", req.Reason),
+	)
+	return nil
+}
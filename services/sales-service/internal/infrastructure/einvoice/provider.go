@@ -0,0 +1,49 @@
+package einvoice
+
+import (
+	"context"
+	"time"
+)
+
+// IssueRequest represents a signed e-invoice sent to the provider
+type IssueRequest struct {
+	SellerTaxCode string
+	TemplateCode  string
+	Series        string
+	Number        int
+	IssueDate     time.Time
+	XML           []byte
+}
+
+// IssueResult represents the provider's acceptance of an e-invoice
+type IssueResult struct {
+	ProviderRef      string // The provider's ID of the e-invoice
+	TaxAuthorityCode string // MCCQT assigned by the tax authority
+	LookupCode       string // For the buyer to look the invoice up on the provider portal
+	IssuedAt         time.Time
+}
+
+// CancelRequest represents the cancellation of an issued e-invoice, notified
+// to the tax authority on form 04/SS-HDDT
+type CancelRequest struct {
+	ProviderRef      string
+	SellerTaxCode    string
+	TemplateCode     string
+	Series           string
+	Number           int
+	TaxAuthorityCode string
+	Reason           string
+}
+
+// Provider defines the adapter to a licensed e-invoice service provider that
+// forwards e-invoices to the tax authority
+type Provider interface {
+	// Name identifies the provider on stored e-invoices
+	Name() string
+	// TaxCode returns the provider's tax code, printed on the invoice
+	TaxCode() string
+	// Issue submits a signed e-invoice and returns its tax authority code
+	Issue(ctx context.Context, req *IssueRequest) (*IssueResult, error)
+	// Cancel cancels an issued e-invoice
+	Cancel(ctx context.Context, req *CancelRequest) error
+}
@@ -0,0 +1,274 @@
+package einvoice
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+)
+
+// XML signature algorithms
+const (
+	xmlDSigNS          = "http://www.w3.org/2000/09/xmldsig#"
+	c14nAlgorithm      = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	rsaSHA256Algorithm = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	sha256Algorithm    = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+var (
+	ErrNoPrivateKey     = errors.New("no private key found in key file")
+	ErrNoCertificate    = errors.New("no certificate found in certificate file")
+	ErrUnsupportedKey   = errors.New("only RSA signing keys are supported")
+	ErrKeyMismatch      = errors.New("private key does not match the certificate")
+	ErrSignatureInvalid = errors.New("e-invoice signature is invalid")
+)
+
+// Signature is an enveloped XML signature (XMLDSig) over the invoice data
+type Signature struct {
+	XMLName        xml.Name   `xml:"Signature"`
+	Xmlns          string     `xml:"xmlns,attr"`
+	SignedInfo     SignedInfo `xml:"SignedInfo"`
+	SignatureValue string     `xml:"SignatureValue"`
+	KeyInfo        KeyInfo    `xml:"KeyInfo"`
+}
+
+// SignedInfo is the signed part of a signature
+type SignedInfo struct {
+	Xmlns                  string    `xml:"xmlns,attr,omitempty"` // Set only while canonicalizing
+	CanonicalizationMethod Algorithm `xml:"CanonicalizationMethod"`
+	SignatureMethod        Algorithm `xml:"SignatureMethod"`
+	Reference              Reference `xml:"Reference"`
+}
+
+// Algorithm names the algorithm of a signature step
+type Algorithm struct {
+	Algorithm string `xml:"Algorithm,attr"`
+}
+
+// Reference digests the signed element
+type Reference struct {
+	URI          string      `xml:"URI,attr"`
+	Transforms   []Algorithm `xml:"Transforms>Transform"`
+	DigestMethod Algorithm   `xml:"DigestMethod"`
+	DigestValue  string      `xml:"DigestValue"`
+}
+
+// KeyInfo carries the signing certificate
+type KeyInfo struct {
+	X509Data X509Data `xml:"X509Data"`
+}
+
+// X509Data identifies the signing certificate
+type X509Data struct {
+	X509SubjectName string `xml:"X509SubjectName"`
+	X509Certificate string `xml:"X509Certificate"`
+}
+
+// Signer signs e-invoices with the seller's digital signature certificate
+type Signer struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+// NewSigner loads the PEM encoded certificate and RSA private key of the seller
+func NewSigner(certFile, keyFile string) (*Signer, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, ErrNoCertificate
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, ErrNoPrivateKey
+	}
+	key, err := parsePrivateKey(keyBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok || !pub.Equal(&key.PublicKey) {
+		return nil, ErrKeyMismatch
+	}
+
+	return &Signer{cert: cert, key: key}, nil
+}
+
+// NewSelfSignedSigner creates a signer with a throwaway self-signed
+// certificate, for development against the local provider only
+func NewSelfSignedSigner(commonName string) (*Signer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName, Country: []string{"VN"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{cert: cert, key: key}, nil
+}
+
+// Sign signs the invoice data of a document with the seller's certificate
+func (s *Signer) Sign(doc *HDon) error {
+	data, err := canonicalXML(&doc.DLHDon)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(data)
+
+	signedInfo := SignedInfo{
+		CanonicalizationMethod: Algorithm{Algorithm: c14nAlgorithm},
+		SignatureMethod:        Algorithm{Algorithm: rsaSHA256Algorithm},
+		Reference: Reference{
+			URI:          "#" + doc.DLHDon.ID,
+			Transforms:   []Algorithm{{Algorithm: c14nAlgorithm}},
+			DigestMethod: Algorithm{Algorithm: sha256Algorithm},
+			DigestValue:  base64.StdEncoding.EncodeToString(digest[:]),
+		},
+	}
+	signedDigest, err := signedInfoDigest(signedInfo)
+	if err != nil {
+		return err
+	}
+	value, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, signedDigest)
+	if err != nil {
+		return err
+	}
+
+	doc.DSCKS.NBan.Signature = &Signature{
+		Xmlns:          xmlDSigNS,
+		SignedInfo:     signedInfo,
+		SignatureValue: base64.StdEncoding.EncodeToString(value),
+		KeyInfo: KeyInfo{
+			X509Data: X509Data{
+				X509SubjectName: s.cert.Subject.String(),
+				X509Certificate: base64.StdEncoding.EncodeToString(s.cert.Raw),
+			},
+		},
+	}
+	return nil
+}
+
+// Verify checks the seller's signature of a document against the certificate
+// it carries
+func Verify(doc *HDon) error {
+	sig := doc.DSCKS.NBan.Signature
+	if sig == nil {
+		return ErrSignatureInvalid
+	}
+
+	data, err := canonicalXML(&doc.DLHDon)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(data)
+	if sig.SignedInfo.Reference.DigestValue != base64.StdEncoding.EncodeToString(digest[:]) {
+		return ErrSignatureInvalid
+	}
+
+	der, err := base64.StdEncoding.DecodeString(sig.KeyInfo.X509Data.X509Certificate)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrUnsupportedKey
+	}
+
+	value, err := base64.StdEncoding.DecodeString(sig.SignatureValue)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	signedDigest, err := signedInfoDigest(sig.SignedInfo)
+	if err != nil {
+		return err
+	}
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, signedDigest, value); err != nil {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// signedInfoDigest digests the canonical SignedInfo, which carries the
+// signature namespace it inherits from Signature
+func signedInfoDigest(signedInfo SignedInfo) ([]byte, error) {
+	signedInfo.Xmlns = xmlDSigNS
+	data, err := canonicalXML(&signedInfo)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(data)
+	return digest[:], nil
+}
+
+// canonicalXML serializes an element in canonical XML (C14N 1.0). The
+// encoder already writes start and end tags for empty elements and no
+// namespace prefixes, so only its escaping of quotes, tabs and line feeds
+// in text differs. Attribute values written here never contain them.
+func canonicalXML(v interface{}) ([]byte, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	for escaped, char := range map[string]string{"&#34;": `"`, "&#39;": "'", "&#x9;": "\t", "&#xA;": "\n"} {
+		data = bytes.ReplaceAll(data, []byte(escaped), []byte(char))
+	}
+	return data, nil
+}
+
+// parsePrivateKey parses a PKCS#1 or PKCS#8 RSA private key
+func parsePrivateKey(block *pem.Block) (*rsa.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+	return key, nil
+}
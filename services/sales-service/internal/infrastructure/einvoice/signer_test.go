@@ -0,0 +1,172 @@
+package einvoice_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/einvoice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildDocument() *einvoice.HDon {
+	builder := einvoice.NewBuilder(einvoice.SellerInfo{
+		Name:    "Công ty TNHH Mỹ phẩm Hoa & Lá",
+		TaxCode: "0312345678",
+		Address: "12 Nguyễn Huệ, Quận 1, TP. Hồ Chí Minh",
+	}, "0101234567")
+
+	einv := &entity.EInvoice{
+		TemplateCode: "1",
+		Series:       "C26TAA",
+		Number:       42,
+		IssueDate:    time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC),
+		Currency:     "VND",
+		BuyerName:    `Nhà thuốc "An Khang"`,
+		BuyerTaxCode: "0309876543",
+	}
+	invoice := &entity.Invoice{
+		LineItems: []entity.InvoiceLineItem{
+			{ProductCode: "SRM-001", ProductName: "Sữa rửa mặt <dịu nhẹ> 150ml", Quantity: 10, UnitPrice: 185000, TaxPercent: 10, TaxAmount: 185000, LineTotal: 2035000},
+			{ProductCode: "KCN-050", ProductName: "Kem chống nắng SPF50", Quantity: 4, UnitPrice: 320000, TaxPercent: 8, TaxAmount: 102400, LineTotal: 1382400},
+		},
+	}
+	order := &entity.SalesOrder{BillingAddress: "45 Lê Lợi, Quận 1"}
+
+	return builder.Build(einv, invoice, order)
+}
+
+func TestSigner_SignAndVerify_RoundTrip(t *testing.T) {
+	// Arrange
+	signer, err := einvoice.NewSelfSignedSigner("Hoa & Lá Cosmetics")
+	require.NoError(t, err)
+	doc := buildDocument()
+
+	// Act
+	require.NoError(t, signer.Sign(doc))
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	parsed, err := einvoice.Parse(data)
+	require.NoError(t, err)
+
+	// Assert
+	assert.NoError(t, einvoice.Verify(parsed))
+	sig := parsed.DSCKS.NBan.Signature
+	assert.Equal(t, "#data", sig.SignedInfo.Reference.URI)
+	assert.Contains(t, sig.KeyInfo.X509Data.X509SubjectName, "Hoa & Lá Cosmetics")
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	signer, err := einvoice.NewSelfSignedSigner("Hoa & Lá Cosmetics")
+	require.NoError(t, err)
+
+	other, err := einvoice.NewSelfSignedSigner("Someone Else")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, data []byte, doc *einvoice.HDon) []byte
+	}{
+		{"amount changed after signing", func(_ *testing.T, data []byte, _ *einvoice.HDon) []byte {
+			return bytes.Replace(data, []byte("<SLuong>10</SLuong>"), []byte("<SLuong>1</SLuong>"), 1)
+		}},
+		{"buyer changed after signing", func(_ *testing.T, data []byte, _ *einvoice.HDon) []byte {
+			return bytes.Replace(data, []byte("0309876543"), []byte("0309876544"), 1)
+		}},
+		{"certificate swapped", func(t *testing.T, _ []byte, doc *einvoice.HDon) []byte {
+			signed := *doc.DSCKS.NBan.Signature
+			require.NoError(t, other.Sign(doc))
+			doc.DSCKS.NBan.Signature.SignedInfo = signed.SignedInfo
+			doc.DSCKS.NBan.Signature.SignatureValue = signed.SignatureValue
+			data, err := doc.Bytes()
+			require.NoError(t, err)
+			return data
+		}},
+		{"signature removed", func(t *testing.T, _ []byte, doc *einvoice.HDon) []byte {
+			doc.DSCKS.NBan.Signature = nil
+			data, err := doc.Bytes()
+			require.NoError(t, err)
+			return data
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			doc := buildDocument()
+			require.NoError(t, signer.Sign(doc))
+			data, err := doc.Bytes()
+			require.NoError(t, err)
+
+			// Act
+			parsed, err := einvoice.Parse(tt.tamper(t, data, doc))
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, einvoice.ErrSignatureInvalid, einvoice.Verify(parsed))
+		})
+	}
+}
+
+// writeCertificate writes a self-signed certificate of the key and the key
+// itself as PEM files
+func writeCertificate(t *testing.T, certKey, fileKey *rsa.PrivateKey) (string, string) {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Hoa & Lá Cosmetics", Country: []string{"VN"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(1, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &certKey.PublicKey, certKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "seller.crt")
+	keyFile := filepath.Join(dir, "seller.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(fileKey)}), 0o600))
+	return certFile, keyFile
+}
+
+func TestNewSigner_FromFiles(t *testing.T) {
+	// Arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	certFile, keyFile := writeCertificate(t, key, key)
+
+	// Act
+	signer, err := einvoice.NewSigner(certFile, keyFile)
+	require.NoError(t, err)
+	doc := buildDocument()
+	require.NoError(t, signer.Sign(doc))
+
+	// Assert
+	assert.NoError(t, einvoice.Verify(doc))
+}
+
+func TestNewSigner_KeyMismatch(t *testing.T) {
+	// Arrange
+	certKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	certFile, keyFile := writeCertificate(t, certKey, otherKey)
+
+	// Act
+	_, err = einvoice.NewSigner(certFile, keyFile)
+
+	// Assert
+	assert.Equal(t, einvoice.ErrKeyMismatch, err)
+}
@@ -0,0 +1,92 @@
+package einvoice
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var digitWords = []string{"không", "một", "hai", "ba", "bốn", "năm", "sáu", "bảy", "tám", "chín"}
+
+// groupUnits name the three-digit groups below a billion; each further
+// three groups repeat them followed by "tỷ"
+var groupUnits = []string{"", "nghìn", "triệu"}
+
+// AmountInWords reads an amount in Vietnamese, as printed on invoices, e.g.
+// 1250000 VND is "Một triệu hai trăm năm mươi nghìn đồng"
+func AmountInWords(amount float64, currency string) string {
+	n := int64(math.Round(math.Abs(amount)))
+
+	var groups []int64
+	for n > 0 {
+		groups = append(groups, n%1000)
+		n /= 1000
+	}
+
+	var words []string
+	for i := len(groups) - 1; i >= 0; i-- {
+		if groups[i] == 0 {
+			continue
+		}
+		words = append(words, readGroup(groups[i], i < len(groups)-1))
+		if unit := groupUnits[i%3]; unit != "" {
+			words = append(words, unit)
+		}
+		for j := 0; j < i/3; j++ {
+			words = append(words, "tỷ")
+		}
+	}
+	if len(words) == 0 {
+		words = append(words, digitWords[0])
+	}
+
+	if currency == "" || currency == "VND" {
+		words = append(words, "đồng")
+	} else {
+		words = append(words, currency)
+	}
+	return capitalize(strings.Join(words, " "))
+}
+
+// readGroup reads a three-digit group. Groups after the leading one are read
+// in full, e.g. 5 is "không trăm linh năm".
+func readGroup(n int64, full bool) string {
+	hundreds, tens, units := n/100, n/10%10, n%10
+
+	var words []string
+	if hundreds > 0 || full {
+		words = append(words, digitWords[hundreds], "trăm")
+	}
+
+	switch {
+	case tens == 0:
+		if units > 0 && len(words) > 0 {
+			words = append(words, "linh")
+		}
+	case tens == 1:
+		words = append(words, "mười")
+	default:
+		words = append(words, digitWords[tens], "mươi")
+	}
+
+	switch {
+	case units == 0:
+	case units == 1 && tens > 1:
+		words = append(words, "mốt")
+	case units == 4 && tens > 1:
+		words = append(words, "tư")
+	case units == 5 && tens > 0:
+		words = append(words, "lăm")
+	default:
+		words = append(words, digitWords[units])
+	}
+
+	return strings.Join(words, " ")
+}
+
+// capitalize upper-cases the first letter
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
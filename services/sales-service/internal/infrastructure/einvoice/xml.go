@@ -0,0 +1,351 @@
+package einvoice
+
+import (
+	"encoding/xml"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+)
+
+const (
+	// SchemaVersion is the version of the tax authority XML schema the
+	// documents follow (Decision 1450/QD-TCT, amended by 1510/QD-TCT)
+	SchemaVersion = "2.0.1"
+
+	// dataID is the Id of the signed invoice data element
+	dataID = "data"
+)
+
+// Goods and service line kinds (TChat)
+const (
+	lineKindGoods    = 1
+	lineKindDiscount = 3
+)
+
+// HDon is the root of a VAT e-invoice document
+type HDon struct {
+	XMLName xml.Name `xml:"HDon"`
+	DLHDon  DLHDon   `xml:"DLHDon"`
+	DSCKS   DSCKS    `xml:"DSCKS"`
+}
+
+// DLHDon holds the invoice data covered by the seller's signature
+type DLHDon struct {
+	ID      string  `xml:"Id,attr"`
+	TTChung TTChung `xml:"TTChung"`
+	NDHDon  NDHDon  `xml:"NDHDon"`
+}
+
+// TTChung holds the general invoice information
+type TTChung struct {
+	PBan     string `xml:"PBan"`              // Schema version
+	THDon    string `xml:"THDon"`             // Invoice name
+	KHMSHDon string `xml:"KHMSHDon"`          // Template code
+	KHHDon   string `xml:"KHHDon"`            // Series
+	SHDon    int    `xml:"SHDon"`             // Invoice number
+	NLap     string `xml:"NLap"`              // Issue date
+	DVTTe    string `xml:"DVTTe"`             // Currency
+	TGia     string `xml:"TGia"`              // Exchange rate to VND
+	HTTToan  string `xml:"HTTToan"`           // Payment method
+	MSTTCGP  string `xml:"MSTTCGP,omitempty"` // Tax code of the e-invoice provider
+}
+
+// NDHDon holds the invoice content
+type NDHDon struct {
+	NBan    NBan    `xml:"NBan"`
+	NMua    NMua    `xml:"NMua"`
+	DSHHDVu DSHHDVu `xml:"DSHHDVu"`
+	TToan   TToan   `xml:"TToan"`
+}
+
+// NBan describes the seller
+type NBan struct {
+	Ten      string `xml:"Ten"`
+	MST      string `xml:"MST"`
+	DChi     string `xml:"DChi"`
+	SDThoai  string `xml:"SDThoai,omitempty"`
+	DCTDTu   string `xml:"DCTDTu,omitempty"`
+	STKNHang string `xml:"STKNHang,omitempty"`
+	TNHang   string `xml:"TNHang,omitempty"`
+}
+
+// NMua describes the buyer
+type NMua struct {
+	Ten     string `xml:"Ten"`
+	MST     string `xml:"MST,omitempty"`
+	DChi    string `xml:"DChi"`
+	MKHang  string `xml:"MKHang,omitempty"`
+	SDThoai string `xml:"SDThoai,omitempty"`
+	DCTDTu  string `xml:"DCTDTu,omitempty"`
+}
+
+// DSHHDVu lists the goods and services
+type DSHHDVu struct {
+	HHDVu []HHDVu `xml:"HHDVu"`
+}
+
+// HHDVu is one goods or service line
+type HHDVu struct {
+	TChat   int    `xml:"TChat"`            // Line kind
+	STT     int    `xml:"STT"`              // Line number
+	MHHDVu  string `xml:"MHHDVu,omitempty"` // Product code
+	THHDVu  string `xml:"THHDVu"`           // Product name
+	DVTinh  string `xml:"DVTinh,omitempty"` // Unit of measure
+	SLuong  string `xml:"SLuong,omitempty"` // Quantity
+	DGia    string `xml:"DGia,omitempty"`   // Unit price
+	STCKhau string `xml:"STCKhau"`          // Discount amount
+	ThTien  string `xml:"ThTien"`           // Amount before tax
+	TSuat   string `xml:"TSuat"`            // VAT rate
+}
+
+// TToan holds the invoice totals
+type TToan struct {
+	THTTLTSuat THTTLTSuat `xml:"THTTLTSuat"`
+	TgTCThue   string     `xml:"TgTCThue"`  // Total before tax
+	TgTThue    string     `xml:"TgTThue"`   // Total VAT
+	TTCKTMai   string     `xml:"TTCKTMai"`  // Total commercial discount
+	TgTTTBSo   string     `xml:"TgTTTBSo"`  // Total payable
+	TgTTTBChu  string     `xml:"TgTTTBChu"` // Total payable in words
+}
+
+// THTTLTSuat is the VAT breakdown by rate
+type THTTLTSuat struct {
+	LTSuat []LTSuat `xml:"LTSuat"`
+}
+
+// LTSuat totals one VAT rate
+type LTSuat struct {
+	TSuat  string `xml:"TSuat"`  // VAT rate
+	ThTien string `xml:"ThTien"` // Amount before tax
+	TThue  string `xml:"TThue"`  // VAT amount
+}
+
+// DSCKS holds the signatures
+type DSCKS struct {
+	NBan SellerSignature `xml:"NBan"`
+}
+
+// SellerSignature holds the seller's signature
+type SellerSignature struct {
+	Signature *Signature `xml:"Signature,omitempty"`
+}
+
+// Bytes serializes the document with its XML declaration. The document is
+// not indented, since whitespace inside the signed data would change it.
+func (d *HDon) Bytes() ([]byte, error) {
+	body, err := xml.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// Parse reads a document serialized with Bytes
+func Parse(data []byte) (*HDon, error) {
+	var doc HDon
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// SellerInfo identifies the seller on issued e-invoices
+type SellerInfo struct {
+	Name        string
+	TaxCode     string
+	Address     string
+	Phone       string
+	Email       string
+	BankAccount string
+	BankName    string
+}
+
+// Builder builds e-invoice documents from sales invoices
+type Builder struct {
+	seller          SellerInfo
+	providerTaxCode string
+}
+
+// NewBuilder creates a new e-invoice builder
+func NewBuilder(seller SellerInfo, providerTaxCode string) *Builder {
+	return &Builder{seller: seller, providerTaxCode: providerTaxCode}
+}
+
+// Seller returns the seller the builder issues for
+func (b *Builder) Seller() SellerInfo {
+	return b.seller
+}
+
+// Build builds the unsigned document of an e-invoice. Lines carry their own
+// VAT rate; the order-level discount share is shown as a commercial discount
+// line and spread over the rates, and the order-level tax share is added to
+// the rate of the order.
+func (b *Builder) Build(einv *entity.EInvoice, invoice *entity.Invoice, order *entity.SalesOrder) *HDon {
+	doc := &HDon{}
+	doc.DLHDon.ID = dataID
+	doc.DLHDon.TTChung = TTChung{
+		PBan:     SchemaVersion,
+		THDon:    "Hóa đơn giá trị gia tăng",
+		KHMSHDon: einv.TemplateCode,
+		KHHDon:   einv.Series,
+		SHDon:    einv.Number,
+		NLap:     einv.IssueDate.Format("2006-01-02"),
+		DVTTe:    einv.Currency,
+		TGia:     "1",
+		HTTToan:  paymentMethod(order.PaymentMethod),
+		MSTTCGP:  b.providerTaxCode,
+	}
+
+	content := &doc.DLHDon.NDHDon
+	content.NBan = NBan{
+		Ten:      b.seller.Name,
+		MST:      b.seller.TaxCode,
+		DChi:     b.seller.Address,
+		SDThoai:  b.seller.Phone,
+		DCTDTu:   b.seller.Email,
+		STKNHang: b.seller.BankAccount,
+		TNHang:   b.seller.BankName,
+	}
+	content.NMua = NMua{
+		Ten:  einv.BuyerName,
+		MST:  einv.BuyerTaxCode,
+		DChi: order.BillingAddress,
+	}
+	if content.NMua.DChi == "" {
+		content.NMua.DChi = order.DeliveryAddress
+	}
+	if customer := invoice.Customer; customer != nil {
+		content.NMua.MKHang = customer.CustomerCode
+		content.NMua.SDThoai = customer.Phone
+		content.NMua.DCTDTu = customer.Email
+	}
+
+	// Goods lines, totalled by VAT rate
+	rates := make(map[string]*rateTotal)
+	rateOf := func(percent float64) *rateTotal {
+		key := taxRate(percent)
+		if rates[key] == nil {
+			rates[key] = &rateTotal{percent: percent}
+		}
+		return rates[key]
+	}
+
+	beforeTax := 0.0
+	for i, item := range invoice.LineItems {
+		amount := roundAmount(item.LineTotal - item.TaxAmount)
+		content.DSHHDVu.HHDVu = append(content.DSHHDVu.HHDVu, HHDVu{
+			TChat:   lineKindGoods,
+			STT:     i + 1,
+			MHHDVu:  item.ProductCode,
+			THHDVu:  item.ProductName,
+			SLuong:  number(item.Quantity),
+			DGia:    number(item.UnitPrice),
+			STCKhau: number(item.DiscountAmount),
+			ThTien:  number(amount),
+			TSuat:   taxRate(item.TaxPercent),
+		})
+		rate := rateOf(item.TaxPercent)
+		rate.amount += amount
+		rate.tax += item.TaxAmount
+		beforeTax += amount
+	}
+
+	// Order-level discount, spread over the rates in proportion to their amounts
+	if invoice.DiscountAmount > 0 {
+		content.DSHHDVu.HHDVu = append(content.DSHHDVu.HHDVu, HHDVu{
+			TChat:   lineKindDiscount,
+			STT:     len(content.DSHHDVu.HHDVu) + 1,
+			THHDVu:  "Chiết khấu thương mại",
+			STCKhau: number(invoice.DiscountAmount),
+			ThTien:  number(invoice.DiscountAmount),
+			TSuat:   taxRate(order.TaxPercent),
+		})
+		remaining := invoice.DiscountAmount
+		keys := sortedRates(rates)
+		for i, key := range keys {
+			share := remaining
+			if i < len(keys)-1 && beforeTax > 0 {
+				share = roundAmount(invoice.DiscountAmount * rates[key].amount / beforeTax)
+			}
+			rates[key].amount -= share
+			remaining = roundAmount(remaining - share)
+		}
+	}
+
+	// Order-level tax
+	if invoice.TaxAmount > 0 {
+		rateOf(order.TaxPercent).tax += invoice.TaxAmount
+	}
+
+	totalAmount, totalTax := 0.0, 0.0
+	for _, key := range sortedRates(rates) {
+		rate := rates[key]
+		content.TToan.THTTLTSuat.LTSuat = append(content.TToan.THTTLTSuat.LTSuat, LTSuat{
+			TSuat:  key,
+			ThTien: number(roundAmount(rate.amount)),
+			TThue:  number(roundAmount(rate.tax)),
+		})
+		totalAmount += rate.amount
+		totalTax += rate.tax
+	}
+	totalAmount, totalTax = roundAmount(totalAmount), roundAmount(totalTax)
+
+	content.TToan.TgTCThue = number(totalAmount)
+	content.TToan.TgTThue = number(totalTax)
+	content.TToan.TTCKTMai = number(invoice.DiscountAmount)
+	content.TToan.TgTTTBSo = number(roundAmount(totalAmount + totalTax))
+	content.TToan.TgTTTBChu = AmountInWords(totalAmount+totalTax, einv.Currency)
+
+	einv.AmountBeforeTax = totalAmount
+	einv.TaxAmount = totalTax
+	einv.TotalAmount = roundAmount(totalAmount + totalTax)
+	return doc
+}
+
+// paymentMethod maps an order payment method to the invoice payment method
+func paymentMethod(method entity.PaymentMethod) string {
+	switch method {
+	case entity.PaymentMethodCash, entity.PaymentMethodCOD:
+		return "TM"
+	case entity.PaymentMethodBankTransfer:
+		return "CK"
+	default:
+		return "TM/CK"
+	}
+}
+
+// taxRate formats a VAT percent as an invoice rate, e.g. 10%
+func taxRate(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', -1, 64) + "%"
+}
+
+// number formats an amount or quantity without exponent
+func number(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
+
+// rateTotal totals the amounts of one VAT rate
+type rateTotal struct {
+	percent float64
+	amount  float64
+	tax     float64
+}
+
+// sortedRates returns the VAT rates highest first
+func sortedRates(rates map[string]*rateTotal) []string {
+	keys := make([]string, 0, len(rates))
+	for key := range rates {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return rates[keys[i]].percent > rates[keys[j]].percent
+	})
+	return keys
+}
+
+// roundAmount rounds a money amount to two decimals
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
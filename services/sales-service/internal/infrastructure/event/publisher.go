@@ -95,11 +95,13 @@ func (p *Publisher) PublishQuotationSent(event *QuotationSentEvent) {
 
 // OrderLineItem represents a line item in order event
 type OrderLineItem struct {
+	LineID      string  `json:"line_id,omitempty"`
 	ProductID   string  `json:"product_id"`
 	ProductCode string  `json:"product_code"`
 	ProductName string  `json:"product_name"`
 	Quantity    float64 `json:"quantity"`
 	UnitPrice   float64 `json:"unit_price"`
+	UomID       string  `json:"uom_id,omitempty"`
 }
 
 // OrderCreatedEvent represents order created event
@@ -123,6 +125,8 @@ type OrderConfirmedEvent struct {
 	SOID           string          `json:"so_id"`
 	SONumber       string          `json:"so_number"`
 	CustomerID     string          `json:"customer_id"`
+	CustomerPriority int           `json:"customer_priority"` // Allocation tier of backorders, lower first
+	SODate         string          `json:"so_date"`
 	DeliveryDate   string          `json:"delivery_date"`
 	DeliveryAddress string         `json:"delivery_address"`
 	TotalAmount    float64         `json:"total_amount"`
@@ -201,3 +205,231 @@ func (p *Publisher) PublishReturnCreated(event *ReturnCreatedEvent) {
 	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
 	p.publish("sales.return.created", event)
 }
+
+// ReturnLineItem represents a returned line in return events
+type ReturnLineItem struct {
+	ProductID   string  `json:"product_id"`
+	ProductCode string  `json:"product_code"`
+	LotNumber   string  `json:"lot_number"`
+	Quantity    float64 `json:"quantity"`
+	Disposition string  `json:"disposition,omitempty"` // RESTOCK or DISPOSE, completed returns only
+}
+
+// ReturnReceivedEvent represents returned goods received - WMS puts them into the returns location
+type ReturnReceivedEvent struct {
+	ReturnID     string           `json:"return_id"`
+	ReturnNumber string           `json:"return_number"`
+	SOID         string           `json:"so_id"`
+	CustomerID   string           `json:"customer_id"`
+	Items        []ReturnLineItem `json:"items"`
+	Timestamp    string           `json:"timestamp"`
+}
+
+// PublishReturnReceived publishes return received event
+func (p *Publisher) PublishReturnReceived(event *ReturnReceivedEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.return.received", event)
+}
+
+// ReturnCompletedEvent represents a settled return - WMS restocks or disposes of the goods
+type ReturnCompletedEvent struct {
+	ReturnID         string           `json:"return_id"`
+	ReturnNumber     string           `json:"return_number"`
+	SOID             string           `json:"so_id"`
+	CustomerID       string           `json:"customer_id"`
+	ReturnType       string           `json:"return_type"`
+	RefundAmount     float64          `json:"refund_amount"`
+	CreditNoteNumber string           `json:"credit_note_number,omitempty"`
+	CreditNoteType   string           `json:"credit_note_type,omitempty"`
+	ExchangeSOID     string           `json:"exchange_so_id,omitempty"`
+	Items            []ReturnLineItem `json:"items"`
+	Timestamp        string           `json:"timestamp"`
+}
+
+// PublishReturnCompleted publishes return completed event
+func (p *Publisher) PublishReturnCompleted(event *ReturnCompletedEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.return.completed", event)
+}
+
+// PromotionUsageEvent represents a promotion applied to or released from an
+// order - Marketing books the cost and revenue against the campaign
+type PromotionUsageEvent struct {
+	SOID          string  `json:"so_id"`
+	SONumber      string  `json:"so_number"`
+	CustomerID    string  `json:"customer_id"`
+	PromotionID   string  `json:"promotion_id"`
+	PromotionCode string  `json:"promotion_code"`
+	CampaignID    string  `json:"campaign_id,omitempty"`
+	VoucherCode   string  `json:"voucher_code,omitempty"`
+	Cost          float64 `json:"cost"`
+	OrderAmount   float64 `json:"order_amount"`
+	Timestamp     string  `json:"timestamp"`
+}
+
+// PublishPromotionApplied publishes promotion applied event
+func (p *Publisher) PublishPromotionApplied(event *PromotionUsageEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.promotion.applied", event)
+}
+
+// PublishPromotionReleased publishes promotion released event
+func (p *Publisher) PublishPromotionReleased(event *PromotionUsageEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.promotion.released", event)
+}
+
+// InvoiceCreatedEvent represents an invoice raised for a shipment
+type InvoiceCreatedEvent struct {
+	InvoiceID     string  `json:"invoice_id"`
+	InvoiceNumber string  `json:"invoice_number"`
+	SOID          string  `json:"so_id"`
+	CustomerID    string  `json:"customer_id"`
+	ShipmentID    string  `json:"shipment_id,omitempty"`
+	InvoiceDate   string  `json:"invoice_date"`
+	DueDate       string  `json:"due_date"`
+	TotalAmount   float64 `json:"total_amount"`
+	Timestamp     string  `json:"timestamp"`
+}
+
+// PublishInvoiceCreated publishes invoice created event
+func (p *Publisher) PublishInvoiceCreated(event *InvoiceCreatedEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.invoice.created", event)
+}
+
+// PaymentReceivedEvent represents a customer payment recorded against receivables
+type PaymentReceivedEvent struct {
+	PaymentID       string  `json:"payment_id"`
+	PaymentNumber   string  `json:"payment_number"`
+	CustomerID      string  `json:"customer_id"`
+	PaymentDate     string  `json:"payment_date"`
+	PaymentMethod   string  `json:"payment_method"`
+	Amount          float64 `json:"amount"`
+	AllocatedAmount float64 `json:"allocated_amount"`
+	Timestamp       string  `json:"timestamp"`
+}
+
+// PublishPaymentReceived publishes payment received event
+func (p *Publisher) PublishPaymentReceived(event *PaymentReceivedEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.payment.received", event)
+}
+
+// EInvoiceEvent represents an e-invoice issued to or cancelled with the tax authority
+type EInvoiceEvent struct {
+	EInvoiceID       string  `json:"einvoice_id"`
+	InvoiceID        string  `json:"invoice_id"`
+	TemplateCode     string  `json:"template_code"`
+	Series           string  `json:"series"`
+	Number           int     `json:"number"`
+	BuyerTaxCode     string  `json:"buyer_tax_code"`
+	TotalAmount      float64 `json:"total_amount"`
+	TaxAuthorityCode string  `json:"tax_authority_code"`
+	Reason           string  `json:"reason,omitempty"`
+	Timestamp        string  `json:"timestamp"`
+}
+
+// PublishEInvoiceIssued publishes e-invoice issued event
+func (p *Publisher) PublishEInvoiceIssued(event *EInvoiceEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.einvoice.issued", event)
+}
+
+// PublishEInvoiceCancelled publishes e-invoice cancelled event
+func (p *Publisher) PublishEInvoiceCancelled(event *EInvoiceEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.einvoice.cancelled", event)
+}
+
+// CreditHoldEvent represents a sales order held for, or released from, a credit review
+type CreditHoldEvent struct {
+	HoldID          string  `json:"hold_id"`
+	SOID            string  `json:"so_id"`
+	SONumber        string  `json:"so_number"`
+	CustomerID      string  `json:"customer_id"`
+	OrderAmount     float64 `json:"order_amount"`
+	AvailableCredit float64 `json:"available_credit"`
+	OverdueAmount   float64 `json:"overdue_amount"`
+	Reason          string  `json:"reason"`
+	Status          string  `json:"status"`
+	ReviewedBy      string  `json:"reviewed_by,omitempty"`
+	ReviewReason    string  `json:"review_reason,omitempty"`
+	Timestamp       string  `json:"timestamp"`
+}
+
+// PublishOrderCreditHold publishes order placed on credit hold event
+func (p *Publisher) PublishOrderCreditHold(event *CreditHoldEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.order.credit_hold", event)
+}
+
+// PublishOrderCreditReviewed publishes credit hold approved or rejected event
+func (p *Publisher) PublishOrderCreditReviewed(event *CreditHoldEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.order.credit_reviewed", event)
+}
+
+// OrderBackorderedEvent represents order lines backordered, allocated or
+// rescheduled in WMS - notification service informs the customer
+type OrderBackorderedEvent struct {
+	SOID          string                 `json:"so_id"`
+	SONumber      string                 `json:"so_number"`
+	CustomerID    string                 `json:"customer_id"`
+	CustomerName  string                 `json:"customer_name"`
+	CustomerEmail string                 `json:"customer_email"`
+	Status        string                 `json:"status"` // BACKORDERED, ALLOCATED, RESCHEDULED
+	Lines         []BackorderedLineEvent `json:"lines"`
+	Timestamp     string                 `json:"timestamp"`
+}
+
+// BackorderedLineEvent represents a backordered order line
+type BackorderedLineEvent struct {
+	LineID         string  `json:"line_id"`
+	ProductCode    string  `json:"product_code"`
+	ProductName    string  `json:"product_name"`
+	Quantity       float64 `json:"quantity"`
+	BackorderedQty float64 `json:"backordered_qty"`
+	ExpectedDate   string  `json:"expected_date,omitempty"`
+}
+
+// PublishOrderBackordered publishes order backordered event
+func (p *Publisher) PublishOrderBackordered(event *OrderBackorderedEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.order.backordered", event)
+}
+
+// OrderAmendedEvent represents a confirmed order amendment - WMS reserves
+// or releases the quantity changes
+type OrderAmendedEvent struct {
+	SOID             string             `json:"so_id"`
+	SONumber         string             `json:"so_number"`
+	CustomerID       string             `json:"customer_id"`
+	CustomerPriority int                `json:"customer_priority"`
+	SODate           string             `json:"so_date"`
+	Version          int                `json:"version"`
+	DeliveryDate     string             `json:"delivery_date"`
+	OldTotalAmount   float64            `json:"old_total_amount"`
+	TotalAmount      float64            `json:"total_amount"`
+	Reason           string             `json:"reason"`
+	Lines            []AmendedLineEvent `json:"lines"`
+	Timestamp        string             `json:"timestamp"`
+}
+
+// AmendedLineEvent represents a changed order line
+type AmendedLineEvent struct {
+	LineID        string  `json:"line_id"`
+	ProductID     string  `json:"product_id"`
+	ProductCode   string  `json:"product_code"`
+	UomID         string  `json:"uom_id,omitempty"`
+	ChangeType    string  `json:"change_type"` // ADDED, CHANGED, REMOVED
+	OldQuantity   float64 `json:"old_quantity"`
+	NewQuantity   float64 `json:"new_quantity"`
+	DeltaQuantity float64 `json:"delta_quantity"` // Negative if reduced
+}
+
+// PublishOrderAmended publishes order amended event
+func (p *Publisher) PublishOrderAmended(event *OrderAmendedEvent) {
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	p.publish("sales.order.amended", event)
+}
@@ -0,0 +1,63 @@
+package masterdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client reads products from master-data-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new master-data-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Product is the product master header
+type Product struct {
+	ID            uuid.UUID `json:"id"`
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	StandardPrice float64   `json:"standard_price"`
+	Currency      string    `json:"currency"`
+	Status        string    `json:"status"`
+}
+
+// GetProduct returns a product by ID
+func (c *Client) GetProduct(ctx context.Context, id uuid.UUID) (*Product, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/products/"+id.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("master-data-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool    `json:"success"`
+		Data    Product `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode master-data-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return nil, fmt.Errorf("master-data-service returned status %d for product %s", resp.StatusCode, id)
+	}
+	return &result.Data, nil
+}
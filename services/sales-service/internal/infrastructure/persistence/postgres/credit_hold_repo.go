@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type creditHoldRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditHoldRepository creates a new credit hold repository
+func NewCreditHoldRepository(db *gorm.DB) repository.CreditHoldRepository {
+	return &creditHoldRepository{db: db}
+}
+
+func (r *creditHoldRepository) Create(ctx context.Context, hold *entity.CreditHold) error {
+	return conn(ctx, r.db).Omit("SalesOrder", "Customer").Create(hold).Error
+}
+
+func (r *creditHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditHold, error) {
+	var hold entity.CreditHold
+	err := conn(ctx, r.db).
+		Preload("SalesOrder").
+		Preload("Customer").
+		First(&hold, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *creditHoldRepository) Update(ctx context.Context, hold *entity.CreditHold) error {
+	hold.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Omit("SalesOrder", "Customer").Save(hold).Error
+}
+
+func (r *creditHoldRepository) List(ctx context.Context, filter *repository.CreditHoldFilter) ([]*entity.CreditHold, int64, error) {
+	var holds []*entity.CreditHold
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.CreditHold{})
+
+	// Apply filters
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.SalesOrderID != nil {
+		query = query.Where("sales_order_id = ?", filter.SalesOrderID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.Limit)
+	}
+
+	// Get results
+	err := query.Preload("Customer").Order("held_at DESC").Find(&holds).Error
+	return holds, total, err
+}
+
+func (r *creditHoldRepository) GetPendingByOrder(ctx context.Context, orderID uuid.UUID) (*entity.CreditHold, error) {
+	var hold entity.CreditHold
+	err := conn(ctx, r.db).
+		Where("sales_order_id = ? AND status = ?", orderID, entity.CreditHoldStatusPending).
+		First(&hold).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type creditNoteRepository struct {
+	db *gorm.DB
+}
+
+// NewCreditNoteRepository creates a new credit note repository
+func NewCreditNoteRepository(db *gorm.DB) repository.CreditNoteRepository {
+	return &creditNoteRepository{db: db}
+}
+
+func (r *creditNoteRepository) Create(ctx context.Context, note *entity.CreditNote) error {
+	return conn(ctx, r.db).Create(note).Error
+}
+
+func (r *creditNoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditNote, error) {
+	var note entity.CreditNote
+	err := conn(ctx, r.db).First(&note, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (r *creditNoteRepository) Update(ctx context.Context, note *entity.CreditNote) error {
+	return conn(ctx, r.db).Save(note).Error
+}
+
+func (r *creditNoteRepository) GetByReturn(ctx context.Context, returnID uuid.UUID) (*entity.CreditNote, error) {
+	var note entity.CreditNote
+	err := conn(ctx, r.db).First(&note, "return_id = ?", returnID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (r *creditNoteRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.CreditNote, error) {
+	var notes []*entity.CreditNote
+	err := conn(ctx, r.db).
+		Where("customer_id = ?", customerID).
+		Order("issue_date DESC, created_at DESC").
+		Find(&notes).Error
+	return notes, err
+}
+
+func (r *creditNoteRepository) GetNextCreditNoteNumber(ctx context.Context) (string, error) {
+	year := time.Now().Year()
+	var count int64
+	conn(ctx, r.db).
+		Model(&entity.CreditNote{}).
+		Where("EXTRACT(YEAR FROM issue_date) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("CN-%d-%04d", year, count+1), nil
+}
@@ -21,12 +21,12 @@ func NewCustomerRepository(db *gorm.DB) repository.CustomerRepository {
 }
 
 func (r *customerRepository) Create(ctx context.Context, customer *entity.Customer) error {
-	return r.db.WithContext(ctx).Create(customer).Error
+	return conn(ctx, r.db).Create(customer).Error
 }
 
 func (r *customerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Customer, error) {
 	var customer entity.Customer
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("CustomerGroup").
 		Preload("Addresses").
 		Preload("Contacts").
@@ -39,7 +39,7 @@ func (r *customerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity
 
 func (r *customerRepository) GetByCode(ctx context.Context, code string) (*entity.Customer, error) {
 	var customer entity.Customer
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("CustomerGroup").
 		First(&customer, "customer_code = ?", code).Error
 	if err != nil {
@@ -50,18 +50,18 @@ func (r *customerRepository) GetByCode(ctx context.Context, code string) (*entit
 
 func (r *customerRepository) Update(ctx context.Context, customer *entity.Customer) error {
 	customer.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(customer).Error
+	return conn(ctx, r.db).Save(customer).Error
 }
 
 func (r *customerRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Customer{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.Customer{}, "id = ?", id).Error
 }
 
 func (r *customerRepository) List(ctx context.Context, filter *repository.CustomerFilter) ([]*entity.Customer, int64, error) {
 	var customers []*entity.Customer
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Customer{})
+	query := conn(ctx, r.db).Model(&entity.Customer{})
 
 	// Apply filters
 	if filter.Search != "" {
@@ -99,12 +99,12 @@ func (r *customerRepository) List(ctx context.Context, filter *repository.Custom
 
 func (r *customerRepository) GetNextCustomerCode(ctx context.Context) (string, error) {
 	var count int64
-	r.db.WithContext(ctx).Model(&entity.Customer{}).Count(&count)
+	conn(ctx, r.db).Model(&entity.Customer{}).Count(&count)
 	return fmt.Sprintf("CUST-%04d", count+1), nil
 }
 
 func (r *customerRepository) UpdateBalance(ctx context.Context, customerID uuid.UUID, amount float64) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.Customer{}).
 		Where("id = ?", customerID).
 		Update("current_balance", gorm.Expr("current_balance + ?", amount)).
@@ -113,7 +113,7 @@ func (r *customerRepository) UpdateBalance(ctx context.Context, customerID uuid.
 
 func (r *customerRepository) GetAvailableCredit(ctx context.Context, customerID uuid.UUID) (float64, error) {
 	var customer entity.Customer
-	err := r.db.WithContext(ctx).Select("credit_limit", "current_balance").First(&customer, "id = ?", customerID).Error
+	err := conn(ctx, r.db).Select("credit_limit", "current_balance").First(&customer, "id = ?", customerID).Error
 	if err != nil {
 		return 0, err
 	}
@@ -122,27 +122,27 @@ func (r *customerRepository) GetAvailableCredit(ctx context.Context, customerID
 
 // Address operations
 func (r *customerRepository) CreateAddress(ctx context.Context, address *entity.CustomerAddress) error {
-	return r.db.WithContext(ctx).Create(address).Error
+	return conn(ctx, r.db).Create(address).Error
 }
 
 func (r *customerRepository) GetAddresses(ctx context.Context, customerID uuid.UUID) ([]*entity.CustomerAddress, error) {
 	var addresses []*entity.CustomerAddress
-	err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).Find(&addresses).Error
+	err := conn(ctx, r.db).Where("customer_id = ?", customerID).Find(&addresses).Error
 	return addresses, err
 }
 
 func (r *customerRepository) UpdateAddress(ctx context.Context, address *entity.CustomerAddress) error {
 	address.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(address).Error
+	return conn(ctx, r.db).Save(address).Error
 }
 
 func (r *customerRepository) DeleteAddress(ctx context.Context, addressID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.CustomerAddress{}, "id = ?", addressID).Error
+	return conn(ctx, r.db).Delete(&entity.CustomerAddress{}, "id = ?", addressID).Error
 }
 
 func (r *customerRepository) GetDefaultAddress(ctx context.Context, customerID uuid.UUID, addressType entity.AddressType) (*entity.CustomerAddress, error) {
 	var address entity.CustomerAddress
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("customer_id = ? AND (address_type = ? OR address_type = 'BOTH') AND is_default = true", customerID, addressType).
 		First(&address).Error
 	if err != nil {
@@ -153,27 +153,27 @@ func (r *customerRepository) GetDefaultAddress(ctx context.Context, customerID u
 
 // Contact operations
 func (r *customerRepository) CreateContact(ctx context.Context, contact *entity.CustomerContact) error {
-	return r.db.WithContext(ctx).Create(contact).Error
+	return conn(ctx, r.db).Create(contact).Error
 }
 
 func (r *customerRepository) GetContacts(ctx context.Context, customerID uuid.UUID) ([]*entity.CustomerContact, error) {
 	var contacts []*entity.CustomerContact
-	err := r.db.WithContext(ctx).Where("customer_id = ?", customerID).Find(&contacts).Error
+	err := conn(ctx, r.db).Where("customer_id = ?", customerID).Find(&contacts).Error
 	return contacts, err
 }
 
 func (r *customerRepository) UpdateContact(ctx context.Context, contact *entity.CustomerContact) error {
 	contact.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(contact).Error
+	return conn(ctx, r.db).Save(contact).Error
 }
 
 func (r *customerRepository) DeleteContact(ctx context.Context, contactID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.CustomerContact{}, "id = ?", contactID).Error
+	return conn(ctx, r.db).Delete(&entity.CustomerContact{}, "id = ?", contactID).Error
 }
 
 func (r *customerRepository) GetPrimaryContact(ctx context.Context, customerID uuid.UUID) (*entity.CustomerContact, error) {
 	var contact entity.CustomerContact
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("customer_id = ? AND is_primary = true", customerID).
 		First(&contact).Error
 	if err != nil {
@@ -193,12 +193,12 @@ func NewCustomerGroupRepository(db *gorm.DB) repository.CustomerGroupRepository
 }
 
 func (r *customerGroupRepository) Create(ctx context.Context, group *entity.CustomerGroup) error {
-	return r.db.WithContext(ctx).Create(group).Error
+	return conn(ctx, r.db).Create(group).Error
 }
 
 func (r *customerGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CustomerGroup, error) {
 	var group entity.CustomerGroup
-	err := r.db.WithContext(ctx).First(&group, "id = ?", id).Error
+	err := conn(ctx, r.db).First(&group, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +207,7 @@ func (r *customerGroupRepository) GetByID(ctx context.Context, id uuid.UUID) (*e
 
 func (r *customerGroupRepository) GetByCode(ctx context.Context, code string) (*entity.CustomerGroup, error) {
 	var group entity.CustomerGroup
-	err := r.db.WithContext(ctx).First(&group, "code = ?", code).Error
+	err := conn(ctx, r.db).First(&group, "code = ?", code).Error
 	if err != nil {
 		return nil, err
 	}
@@ -216,16 +216,16 @@ func (r *customerGroupRepository) GetByCode(ctx context.Context, code string) (*
 
 func (r *customerGroupRepository) Update(ctx context.Context, group *entity.CustomerGroup) error {
 	group.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(group).Error
+	return conn(ctx, r.db).Save(group).Error
 }
 
 func (r *customerGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.CustomerGroup{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.CustomerGroup{}, "id = ?", id).Error
 }
 
 func (r *customerGroupRepository) List(ctx context.Context, activeOnly bool) ([]*entity.CustomerGroup, error) {
 	var groups []*entity.CustomerGroup
-	query := r.db.WithContext(ctx)
+	query := conn(ctx, r.db)
 	if activeOnly {
 		query = query.Where("is_active = true")
 	}
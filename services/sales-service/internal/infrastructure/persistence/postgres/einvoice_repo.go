@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type einvoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewEInvoiceRepository creates a new e-invoice repository
+func NewEInvoiceRepository(db *gorm.DB) repository.EInvoiceRepository {
+	return &einvoiceRepository{db: db}
+}
+
+func (r *einvoiceRepository) Create(ctx context.Context, einvoice *entity.EInvoice) error {
+	return conn(ctx, r.db).Omit("Invoice").Create(einvoice).Error
+}
+
+func (r *einvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	var einvoice entity.EInvoice
+	err := conn(ctx, r.db).
+		Preload("Invoice").
+		First(&einvoice, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &einvoice, nil
+}
+
+func (r *einvoiceRepository) Update(ctx context.Context, einvoice *entity.EInvoice) error {
+	einvoice.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Omit("Invoice").Save(einvoice).Error
+}
+
+func (r *einvoiceRepository) List(ctx context.Context, filter *repository.EInvoiceFilter) ([]*entity.EInvoice, int64, error) {
+	var einvoices []*entity.EInvoice
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.EInvoice{})
+
+	// Apply filters
+	if filter.InvoiceID != nil {
+		query = query.Where("invoice_id = ?", filter.InvoiceID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.DateFrom != "" {
+		query = query.Where("issue_date >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query = query.Where("issue_date <= ?", filter.DateTo)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.Limit)
+	}
+
+	// Get results
+	err := query.Order("issue_date DESC, series, number DESC").Find(&einvoices).Error
+	return einvoices, total, err
+}
+
+func (r *einvoiceRepository) GetActiveByInvoice(ctx context.Context, invoiceID uuid.UUID) (*entity.EInvoice, error) {
+	var einvoice entity.EInvoice
+	err := conn(ctx, r.db).
+		Where("invoice_id = ? AND status <> ?", invoiceID, entity.EInvoiceStatusCancelled).
+		First(&einvoice).Error
+	if err != nil {
+		return nil, err
+	}
+	return &einvoice, nil
+}
+
+func (r *einvoiceRepository) GetNextNumber(ctx context.Context, templateCode, series string) (int, error) {
+	var last int
+	err := conn(ctx, r.db).
+		Model(&entity.EInvoice{}).
+		Select("COALESCE(MAX(number), 0)").
+		Where("template_code = ? AND series = ?", templateCode, series).
+		Scan(&last).Error
+	if err != nil {
+		return 0, err
+	}
+	return last + 1, nil
+}
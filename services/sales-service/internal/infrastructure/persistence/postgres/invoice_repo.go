@@ -0,0 +1,306 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// openInvoiceStatuses are the statuses of invoices with a balance to collect
+var openInvoiceStatuses = []entity.InvoiceStatus{entity.InvoiceStatusOpen, entity.InvoiceStatusPartiallyPaid}
+
+type invoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository creates a new invoice repository
+func NewInvoiceRepository(db *gorm.DB) repository.InvoiceRepository {
+	return &invoiceRepository{db: db}
+}
+
+func (r *invoiceRepository) Create(ctx context.Context, invoice *entity.Invoice) error {
+	return conn(ctx, r.db).Create(invoice).Error
+}
+
+func (r *invoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := conn(ctx, r.db).
+		Preload("Customer").
+		Preload("LineItems").
+		First(&invoice, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *invoiceRepository) Update(ctx context.Context, invoice *entity.Invoice) error {
+	invoice.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Omit("Customer", "LineItems").Save(invoice).Error
+}
+
+func (r *invoiceRepository) List(ctx context.Context, filter *repository.InvoiceFilter) ([]*entity.Invoice, int64, error) {
+	var invoices []*entity.Invoice
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.Invoice{})
+
+	// Apply filters
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.SalesOrderID != nil {
+		query = query.Where("sales_order_id = ?", filter.SalesOrderID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.OverdueOnly {
+		query = query.Where("status IN ? AND due_date < CURRENT_DATE", openInvoiceStatuses)
+	}
+	if filter.DateFrom != "" {
+		query = query.Where("invoice_date >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query = query.Where("invoice_date <= ?", filter.DateTo)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.Limit)
+	}
+
+	// Get results
+	err := query.Preload("Customer").Order("invoice_date DESC, invoice_number DESC").Find(&invoices).Error
+	return invoices, total, err
+}
+
+func (r *invoiceRepository) GetNextInvoiceNumber(ctx context.Context) (string, error) {
+	year := time.Now().Year()
+	var count int64
+	conn(ctx, r.db).
+		Model(&entity.Invoice{}).
+		Where("EXTRACT(YEAR FROM invoice_date) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("INV-%d-%04d", year, count+1), nil
+}
+
+func (r *invoiceRepository) GetByShipment(ctx context.Context, shipmentID uuid.UUID) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := conn(ctx, r.db).
+		Where("status <> ?", entity.InvoiceStatusCancelled).
+		First(&invoice, "shipment_id = ?", shipmentID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *invoiceRepository) GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Invoice, error) {
+	var invoices []*entity.Invoice
+	err := conn(ctx, r.db).
+		Preload("LineItems").
+		Where("sales_order_id = ? AND status <> ?", salesOrderID, entity.InvoiceStatusCancelled).
+		Order("invoice_date ASC, invoice_number ASC").
+		Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *invoiceRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Invoice, error) {
+	var invoices []*entity.Invoice
+	err := conn(ctx, r.db).
+		Where("customer_id = ? AND status <> ?", customerID, entity.InvoiceStatusCancelled).
+		Order("invoice_date ASC, invoice_number ASC").
+		Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *invoiceRepository) GetOpenByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Invoice, error) {
+	var invoices []*entity.Invoice
+	err := conn(ctx, r.db).
+		Where("customer_id = ? AND status IN ?", customerID, openInvoiceStatuses).
+		Order("due_date ASC, invoice_number ASC").
+		Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *invoiceRepository) GetOpen(ctx context.Context, customerID *uuid.UUID) ([]*entity.Invoice, error) {
+	var invoices []*entity.Invoice
+	query := conn(ctx, r.db).
+		Preload("Customer").
+		Where("status IN ?", openInvoiceStatuses)
+	if customerID != nil {
+		query = query.Where("customer_id = ?", customerID)
+	}
+	err := query.Order("customer_id, due_date ASC").Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *invoiceRepository) GetSummary(ctx context.Context, customerID uuid.UUID, asOf time.Time) (*repository.ARSummary, error) {
+	var summary repository.ARSummary
+
+	// Open and overdue invoice balances
+	err := conn(ctx, r.db).
+		Model(&entity.Invoice{}).
+		Select(`COALESCE(SUM(total_amount - paid_amount - credited_amount), 0) AS open_invoices,
+			COALESCE(SUM(CASE WHEN due_date < ? THEN total_amount - paid_amount - credited_amount ELSE 0 END), 0) AS overdue_amount,
+			COUNT(CASE WHEN due_date < ? THEN 1 END) AS overdue_invoices`, asOf, asOf).
+		Where("customer_id = ? AND status IN ?", customerID, openInvoiceStatuses).
+		Scan(&summary).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Payments and credit notes held on account
+	var unallocated, unapplied float64
+	err = conn(ctx, r.db).
+		Model(&entity.Payment{}).
+		Select("COALESCE(SUM(amount - allocated_amount), 0)").
+		Where("customer_id = ?", customerID).
+		Scan(&unallocated).Error
+	if err != nil {
+		return nil, err
+	}
+	err = conn(ctx, r.db).
+		Model(&entity.CreditNote{}).
+		Select("COALESCE(SUM(amount - applied_amount), 0)").
+		Where("customer_id = ? AND note_type = ?", customerID, entity.CreditNoteTypeCredit).
+		Scan(&unapplied).Error
+	if err != nil {
+		return nil, err
+	}
+	summary.UnappliedCredit = unallocated + unapplied
+
+	// Confirmed orders less what has been invoiced on them
+	err = conn(ctx, r.db).Raw(`
+		SELECT COALESCE(SUM(GREATEST(so.total_amount - COALESCE(inv.invoiced, 0), 0)), 0)
+		FROM sales_orders so
+		LEFT JOIN (
+			SELECT sales_order_id, SUM(total_amount) AS invoiced
+			FROM invoices
+			WHERE status <> ?
+			GROUP BY sales_order_id
+		) inv ON inv.sales_order_id = so.id
+		WHERE so.customer_id = ? AND so.status IN ?`,
+		entity.InvoiceStatusCancelled, customerID,
+		[]entity.SOStatus{
+			entity.SOStatusConfirmed,
+			entity.SOStatusProcessing,
+			entity.SOStatusPartiallyShipped,
+			entity.SOStatusShipped,
+			entity.SOStatusDelivered,
+		}).
+		Scan(&summary.UnbilledOrders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
+
+func (r *invoiceRepository) CreateAllocation(ctx context.Context, allocation *entity.InvoiceAllocation) error {
+	return conn(ctx, r.db).Create(allocation).Error
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+// NewPaymentRepository creates a new payment repository
+func NewPaymentRepository(db *gorm.DB) repository.PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	return conn(ctx, r.db).Create(payment).Error
+}
+
+func (r *paymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	var payment entity.Payment
+	err := conn(ctx, r.db).
+		Preload("Customer").
+		Preload("Allocations").
+		First(&payment, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
+	payment.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Omit("Customer", "Allocations").Save(payment).Error
+}
+
+func (r *paymentRepository) List(ctx context.Context, filter *repository.PaymentFilter) ([]*entity.Payment, int64, error) {
+	var payments []*entity.Payment
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.Payment{})
+
+	// Apply filters
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.DateFrom != "" {
+		query = query.Where("payment_date >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query = query.Where("payment_date <= ?", filter.DateTo)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.Limit)
+	}
+
+	// Get results
+	err := query.Preload("Customer").Order("payment_date DESC, payment_number DESC").Find(&payments).Error
+	return payments, total, err
+}
+
+func (r *paymentRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Payment, error) {
+	var payments []*entity.Payment
+	err := conn(ctx, r.db).
+		Where("customer_id = ?", customerID).
+		Order("payment_date ASC, payment_number ASC").
+		Find(&payments).Error
+	return payments, err
+}
+
+func (r *paymentRepository) GetNextPaymentNumber(ctx context.Context) (string, error) {
+	year := time.Now().Year()
+	var count int64
+	conn(ctx, r.db).
+		Model(&entity.Payment{}).
+		Where("EXTRACT(YEAR FROM payment_date) = ?", year).
+		Count(&count)
+	return fmt.Sprintf("PAY-%d-%04d", year, count+1), nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type priceListRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceListRepository creates a new price list repository
+func NewPriceListRepository(db *gorm.DB) repository.PriceListRepository {
+	return &priceListRepository{db: db}
+}
+
+func (r *priceListRepository) Create(ctx context.Context, priceList *entity.PriceList) error {
+	return conn(ctx, r.db).Create(priceList).Error
+}
+
+func (r *priceListRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceList, error) {
+	var priceList entity.PriceList
+	err := conn(ctx, r.db).
+		Preload("CustomerGroup").
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("product_code ASC, min_quantity ASC")
+		}).
+		First(&priceList, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &priceList, nil
+}
+
+func (r *priceListRepository) GetByCode(ctx context.Context, code string) (*entity.PriceList, error) {
+	var priceList entity.PriceList
+	err := conn(ctx, r.db).First(&priceList, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &priceList, nil
+}
+
+func (r *priceListRepository) Update(ctx context.Context, priceList *entity.PriceList) error {
+	priceList.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Omit("Items", "CustomerGroup").Save(priceList).Error
+}
+
+func (r *priceListRepository) List(ctx context.Context, filter *repository.PriceListFilter) ([]*entity.PriceList, int64, error) {
+	var priceLists []*entity.PriceList
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.PriceList{})
+
+	// Apply filters
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ?", search, search)
+	}
+	if filter.ListType != "" {
+		query = query.Where("list_type = ?", filter.ListType)
+	}
+	if filter.CustomerGroupID != nil {
+		query = query.Where("customer_group_id = ?", filter.CustomerGroupID)
+	}
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", filter.CustomerID)
+	}
+	if filter.CampaignID != nil {
+		query = query.Where("campaign_id = ?", filter.CampaignID)
+	}
+	if filter.Channel != "" {
+		query = query.Where("channel = ?", filter.Channel)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.Limit)
+	}
+
+	// Get results
+	err := query.Preload("CustomerGroup").Order("valid_from DESC, code ASC").Find(&priceLists).Error
+	return priceLists, total, err
+}
+
+func (r *priceListRepository) CreateItem(ctx context.Context, item *entity.PriceListItem) error {
+	return conn(ctx, r.db).Create(item).Error
+}
+
+func (r *priceListRepository) GetValidForProducts(ctx context.Context, date time.Time, productIDs []uuid.UUID) ([]*entity.PriceList, error) {
+	var priceLists []*entity.PriceList
+	day := date.Format("2006-01-02")
+	err := conn(ctx, r.db).
+		Where("is_active = ? AND valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", true, day, day).
+		Where("id IN (?)", r.db.Model(&entity.PriceListItem{}).Select("price_list_id").Where("product_id IN ?", productIDs)).
+		Preload("Items", "product_id IN ?", productIDs).
+		Order("priority DESC, valid_from DESC").
+		Find(&priceLists).Error
+	return priceLists, err
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type promotionRepository struct {
+	db *gorm.DB
+}
+
+// NewPromotionRepository creates a new promotion repository
+func NewPromotionRepository(db *gorm.DB) repository.PromotionRepository {
+	return &promotionRepository{db: db}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	return conn(ctx, r.db).Create(promotion).Error
+}
+
+func (r *promotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	err := conn(ctx, r.db).
+		Preload("BundleItems").
+		Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_amount ASC")
+		}).
+		First(&promotion, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) GetByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	var promotion entity.Promotion
+	err := conn(ctx, r.db).First(&promotion, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *promotionRepository) Update(ctx context.Context, promotion *entity.Promotion) error {
+	promotion.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Omit("BundleItems", "Tiers").Save(promotion).Error
+}
+
+func (r *promotionRepository) List(ctx context.Context, filter *repository.PromotionFilter) ([]*entity.Promotion, int64, error) {
+	var promotions []*entity.Promotion
+	var total int64
+
+	query := conn(ctx, r.db).Model(&entity.Promotion{})
+
+	// Apply filters
+	if filter.Search != "" {
+		search := "%" + filter.Search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ?", search, search)
+	}
+	if filter.PromotionType != "" {
+		query = query.Where("promotion_type = ?", filter.PromotionType)
+	}
+	if filter.CampaignID != nil {
+		query = query.Where("campaign_id = ?", filter.CampaignID)
+	}
+	if filter.ActiveOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Apply pagination
+	if filter.Limit > 0 {
+		offset := (filter.Page - 1) * filter.Limit
+		if offset < 0 {
+			offset = 0
+		}
+		query = query.Offset(offset).Limit(filter.Limit)
+	}
+
+	// Get results
+	err := query.Order("valid_from DESC, code ASC").Find(&promotions).Error
+	return promotions, total, err
+}
+
+func (r *promotionRepository) GetAutomatic(ctx context.Context, date time.Time) ([]*entity.Promotion, error) {
+	var promotions []*entity.Promotion
+	day := date.Format("2006-01-02")
+	err := conn(ctx, r.db).
+		Preload("BundleItems").
+		Preload("Tiers").
+		Where("is_active = ? AND requires_voucher = ?", true, false).
+		Where("valid_from <= ? AND (valid_to IS NULL OR valid_to >= ?)", day, day).
+		Order("priority DESC, created_at ASC").
+		Find(&promotions).Error
+	return promotions, err
+}
+
+// AddUsage books usage against the promotion and reports whether it was
+// booked. A cost is only booked while the budget covers it, so concurrent
+// orders cannot overspend it.
+func (r *promotionRepository) AddUsage(ctx context.Context, id uuid.UUID, cost float64, uses int) (bool, error) {
+	query := conn(ctx, r.db).
+		Model(&entity.Promotion{}).
+		Where("id = ?", id)
+	if cost > 0 {
+		query = query.Where("budget = 0 OR used_budget + ? <= budget", cost)
+	}
+	result := query.Updates(map[string]interface{}{
+		"used_budget": gorm.Expr("used_budget + ?", cost),
+		"usage_count": gorm.Expr("usage_count + ?", uses),
+		"updated_at":  time.Now(),
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *promotionRepository) CreateVoucher(ctx context.Context, voucher *entity.Voucher) error {
+	return conn(ctx, r.db).Create(voucher).Error
+}
+
+func (r *promotionRepository) GetVoucherByCode(ctx context.Context, code string) (*entity.Voucher, error) {
+	var voucher entity.Voucher
+	err := conn(ctx, r.db).
+		Preload("Promotion").
+		Preload("Promotion.BundleItems").
+		Preload("Promotion.Tiers").
+		First(&voucher, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *promotionRepository) GetVouchers(ctx context.Context, promotionID uuid.UUID) ([]*entity.Voucher, error) {
+	var vouchers []*entity.Voucher
+	err := conn(ctx, r.db).
+		Where("promotion_id = ?", promotionID).
+		Order("created_at DESC").
+		Find(&vouchers).Error
+	return vouchers, err
+}
+
+// AddVoucherUse books uses of the voucher and reports whether they were
+// booked. Uses are only booked while the voucher has uses left.
+func (r *promotionRepository) AddVoucherUse(ctx context.Context, id uuid.UUID, uses int) (bool, error) {
+	query := conn(ctx, r.db).
+		Model(&entity.Voucher{}).
+		Where("id = ?", id)
+	if uses > 0 {
+		query = query.Where("max_uses = 0 OR used_count + ? <= max_uses", uses)
+	}
+	result := query.Updates(map[string]interface{}{
+		"used_count": gorm.Expr("used_count + ?", uses),
+		"updated_at": time.Now(),
+	})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *promotionRepository) GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderPromotion, error) {
+	var promotions []*entity.OrderPromotion
+	err := conn(ctx, r.db).
+		Where("sales_order_id = ?", orderID).
+		Order("created_at ASC").
+		Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *promotionRepository) MarkOrderPromotionsReleased(ctx context.Context, orderID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&entity.OrderPromotion{}).
+		Where("sales_order_id = ? AND released_at IS NULL", orderID).
+		Update("released_at", time.Now()).Error
+}
+
+func (r *promotionRepository) MarkOrderPromotionReleased(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&entity.OrderPromotion{}).
+		Where("id = ? AND released_at IS NULL", id).
+		Update("released_at", time.Now()).Error
+}
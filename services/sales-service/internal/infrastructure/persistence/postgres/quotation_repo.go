@@ -21,12 +21,12 @@ func NewQuotationRepository(db *gorm.DB) repository.QuotationRepository {
 }
 
 func (r *quotationRepository) Create(ctx context.Context, quotation *entity.Quotation) error {
-	return r.db.WithContext(ctx).Create(quotation).Error
+	return conn(ctx, r.db).Create(quotation).Error
 }
 
 func (r *quotationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Quotation, error) {
 	var quotation entity.Quotation
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Customer").
 		Preload("LineItems", func(db *gorm.DB) *gorm.DB {
 			return db.Order("line_number ASC")
@@ -40,7 +40,7 @@ func (r *quotationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entit
 
 func (r *quotationRepository) GetByNumber(ctx context.Context, number string) (*entity.Quotation, error) {
 	var quotation entity.Quotation
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Customer").
 		Preload("LineItems").
 		First(&quotation, "quotation_number = ?", number).Error
@@ -52,18 +52,18 @@ func (r *quotationRepository) GetByNumber(ctx context.Context, number string) (*
 
 func (r *quotationRepository) Update(ctx context.Context, quotation *entity.Quotation) error {
 	quotation.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(quotation).Error
+	return conn(ctx, r.db).Save(quotation).Error
 }
 
 func (r *quotationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Quotation{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.Quotation{}, "id = ?", id).Error
 }
 
 func (r *quotationRepository) List(ctx context.Context, filter *repository.QuotationFilter) ([]*entity.Quotation, int64, error) {
 	var quotations []*entity.Quotation
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Quotation{})
+	query := conn(ctx, r.db).Model(&entity.Quotation{})
 
 	// Apply filters
 	if filter.CustomerID != nil {
@@ -105,7 +105,7 @@ func (r *quotationRepository) List(ctx context.Context, filter *repository.Quota
 func (r *quotationRepository) GetNextQuotationNumber(ctx context.Context) (string, error) {
 	year := time.Now().Year()
 	var count int64
-	r.db.WithContext(ctx).
+	conn(ctx, r.db).
 		Model(&entity.Quotation{}).
 		Where("EXTRACT(YEAR FROM quotation_date) = ?", year).
 		Count(&count)
@@ -114,21 +114,21 @@ func (r *quotationRepository) GetNextQuotationNumber(ctx context.Context) (strin
 
 // Line items
 func (r *quotationRepository) CreateLineItem(ctx context.Context, item *entity.QuotationLineItem) error {
-	return r.db.WithContext(ctx).Create(item).Error
+	return conn(ctx, r.db).Create(item).Error
 }
 
 func (r *quotationRepository) UpdateLineItem(ctx context.Context, item *entity.QuotationLineItem) error {
 	item.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(item).Error
+	return conn(ctx, r.db).Save(item).Error
 }
 
 func (r *quotationRepository) DeleteLineItem(ctx context.Context, itemID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.QuotationLineItem{}, "id = ?", itemID).Error
+	return conn(ctx, r.db).Delete(&entity.QuotationLineItem{}, "id = ?", itemID).Error
 }
 
 func (r *quotationRepository) GetLineItems(ctx context.Context, quotationID uuid.UUID) ([]*entity.QuotationLineItem, error) {
 	var items []*entity.QuotationLineItem
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("quotation_id = ?", quotationID).
 		Order("line_number ASC").
 		Find(&items).Error
@@ -136,7 +136,7 @@ func (r *quotationRepository) GetLineItems(ctx context.Context, quotationID uuid
 }
 
 func (r *quotationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.QuotationStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.Quotation{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -148,7 +148,7 @@ func (r *quotationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, st
 func (r *quotationRepository) GetExpiringQuotations(ctx context.Context, days int) ([]*entity.Quotation, error) {
 	var quotations []*entity.Quotation
 	threshold := time.Now().AddDate(0, 0, days)
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("valid_until <= ? AND status IN ('DRAFT', 'SENT')", threshold).
 		Preload("Customer").
 		Find(&quotations).Error
@@ -156,7 +156,7 @@ func (r *quotationRepository) GetExpiringQuotations(ctx context.Context, days in
 }
 
 func (r *quotationRepository) MarkExpiredQuotations(ctx context.Context) (int64, error) {
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&entity.Quotation{}).
 		Where("valid_until < ? AND status IN ('DRAFT', 'SENT')", time.Now()).
 		Updates(map[string]interface{}{
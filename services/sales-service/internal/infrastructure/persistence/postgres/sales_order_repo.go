@@ -21,17 +21,18 @@ func NewSalesOrderRepository(db *gorm.DB) repository.SalesOrderRepository {
 }
 
 func (r *salesOrderRepository) Create(ctx context.Context, order *entity.SalesOrder) error {
-	return r.db.WithContext(ctx).Create(order).Error
+	return conn(ctx, r.db).Create(order).Error
 }
 
 func (r *salesOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.SalesOrder, error) {
 	var order entity.SalesOrder
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Customer").
 		Preload("Quotation").
 		Preload("LineItems", func(db *gorm.DB) *gorm.DB {
 			return db.Order("line_number ASC")
 		}).
+		Preload("Promotions").
 		First(&order, "id = ?", id).Error
 	if err != nil {
 		return nil, err
@@ -41,7 +42,7 @@ func (r *salesOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 
 func (r *salesOrderRepository) GetByNumber(ctx context.Context, number string) (*entity.SalesOrder, error) {
 	var order entity.SalesOrder
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("Customer").
 		Preload("LineItems").
 		First(&order, "so_number = ?", number).Error
@@ -53,18 +54,18 @@ func (r *salesOrderRepository) GetByNumber(ctx context.Context, number string) (
 
 func (r *salesOrderRepository) Update(ctx context.Context, order *entity.SalesOrder) error {
 	order.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(order).Error
+	return conn(ctx, r.db).Save(order).Error
 }
 
 func (r *salesOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.SalesOrder{}, "id = ?", id).Error
+	return conn(ctx, r.db).Delete(&entity.SalesOrder{}, "id = ?", id).Error
 }
 
 func (r *salesOrderRepository) List(ctx context.Context, filter *repository.SalesOrderFilter) ([]*entity.SalesOrder, int64, error) {
 	var orders []*entity.SalesOrder
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.SalesOrder{})
+	query := conn(ctx, r.db).Model(&entity.SalesOrder{})
 
 	// Apply filters
 	if filter.CustomerID != nil {
@@ -105,7 +106,7 @@ func (r *salesOrderRepository) List(ctx context.Context, filter *repository.Sale
 func (r *salesOrderRepository) GetNextSONumber(ctx context.Context) (string, error) {
 	year := time.Now().Year()
 	var count int64
-	r.db.WithContext(ctx).
+	conn(ctx, r.db).
 		Model(&entity.SalesOrder{}).
 		Where("EXTRACT(YEAR FROM so_date) = ?", year).
 		Count(&count)
@@ -114,21 +115,21 @@ func (r *salesOrderRepository) GetNextSONumber(ctx context.Context) (string, err
 
 // Line items
 func (r *salesOrderRepository) CreateLineItem(ctx context.Context, item *entity.SOLineItem) error {
-	return r.db.WithContext(ctx).Create(item).Error
+	return conn(ctx, r.db).Create(item).Error
 }
 
 func (r *salesOrderRepository) UpdateLineItem(ctx context.Context, item *entity.SOLineItem) error {
 	item.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(item).Error
+	return conn(ctx, r.db).Save(item).Error
 }
 
 func (r *salesOrderRepository) DeleteLineItem(ctx context.Context, itemID uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.SOLineItem{}, "id = ?", itemID).Error
+	return conn(ctx, r.db).Delete(&entity.SOLineItem{}, "id = ?", itemID).Error
 }
 
 func (r *salesOrderRepository) GetLineItems(ctx context.Context, orderID uuid.UUID) ([]*entity.SOLineItem, error) {
 	var items []*entity.SOLineItem
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("sales_order_id = ?", orderID).
 		Order("line_number ASC").
 		Find(&items).Error
@@ -136,7 +137,7 @@ func (r *salesOrderRepository) GetLineItems(ctx context.Context, orderID uuid.UU
 }
 
 func (r *salesOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.SOStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.SalesOrder{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -146,7 +147,7 @@ func (r *salesOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, s
 }
 
 func (r *salesOrderRepository) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status entity.PaymentStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.SalesOrder{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -156,7 +157,7 @@ func (r *salesOrderRepository) UpdatePaymentStatus(ctx context.Context, id uuid.
 }
 
 func (r *salesOrderRepository) UpdateShippedQuantity(ctx context.Context, lineItemID uuid.UUID, shippedQty float64) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.SOLineItem{}).
 		Where("id = ?", lineItemID).
 		Updates(map[string]interface{}{
@@ -166,7 +167,7 @@ func (r *salesOrderRepository) UpdateShippedQuantity(ctx context.Context, lineIt
 }
 
 func (r *salesOrderRepository) UpdateLineItemReservation(ctx context.Context, lineItemID uuid.UUID, reservationID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.SOLineItem{}).
 		Where("id = ?", lineItemID).
 		Updates(map[string]interface{}{
@@ -175,9 +176,25 @@ func (r *salesOrderRepository) UpdateLineItemReservation(ctx context.Context, li
 		}).Error
 }
 
+func (r *salesOrderRepository) CreateAmendment(ctx context.Context, amendment *entity.SOAmendment) error {
+	return conn(ctx, r.db).Create(amendment).Error
+}
+
+func (r *salesOrderRepository) GetAmendments(ctx context.Context, orderID uuid.UUID) ([]*entity.SOAmendment, error) {
+	var amendments []*entity.SOAmendment
+	err := conn(ctx, r.db).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("line_number ASC")
+		}).
+		Where("sales_order_id = ?", orderID).
+		Order("version DESC").
+		Find(&amendments).Error
+	return amendments, err
+}
+
 func (r *salesOrderRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID, limit int) ([]*entity.SalesOrder, error) {
 	var orders []*entity.SalesOrder
-	query := r.db.WithContext(ctx).
+	query := conn(ctx, r.db).
 		Where("customer_id = ?", customerID).
 		Order("created_at DESC")
 	if limit > 0 {
@@ -189,7 +206,7 @@ func (r *salesOrderRepository) GetByCustomer(ctx context.Context, customerID uui
 
 func (r *salesOrderRepository) GetPendingOrdersByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.SalesOrder, error) {
 	var orders []*entity.SalesOrder
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("customer_id = ? AND status NOT IN ('DELIVERED', 'CANCELLED')", customerID).
 		Find(&orders).Error
 	return orders, err
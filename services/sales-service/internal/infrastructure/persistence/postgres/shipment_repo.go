@@ -9,6 +9,7 @@ import (
 	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type shipmentRepository struct {
@@ -21,13 +22,14 @@ func NewShipmentRepository(db *gorm.DB) repository.ShipmentRepository {
 }
 
 func (r *shipmentRepository) Create(ctx context.Context, shipment *entity.Shipment) error {
-	return r.db.WithContext(ctx).Create(shipment).Error
+	return conn(ctx, r.db).Create(shipment).Error
 }
 
 func (r *shipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Shipment, error) {
 	var shipment entity.Shipment
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("SalesOrder").
+		Preload("LineItems").
 		First(&shipment, "id = ?", id).Error
 	if err != nil {
 		return nil, err
@@ -37,7 +39,7 @@ func (r *shipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity
 
 func (r *shipmentRepository) GetByNumber(ctx context.Context, number string) (*entity.Shipment, error) {
 	var shipment entity.Shipment
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("SalesOrder").
 		First(&shipment, "shipment_number = ?", number).Error
 	if err != nil {
@@ -48,14 +50,14 @@ func (r *shipmentRepository) GetByNumber(ctx context.Context, number string) (*e
 
 func (r *shipmentRepository) Update(ctx context.Context, shipment *entity.Shipment) error {
 	shipment.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(shipment).Error
+	return conn(ctx, r.db).Save(shipment).Error
 }
 
 func (r *shipmentRepository) List(ctx context.Context, filter *repository.ShipmentFilter) ([]*entity.Shipment, int64, error) {
 	var shipments []*entity.Shipment
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Shipment{})
+	query := conn(ctx, r.db).Model(&entity.Shipment{})
 
 	// Apply filters
 	if filter.SalesOrderID != nil {
@@ -96,7 +98,7 @@ func (r *shipmentRepository) List(ctx context.Context, filter *repository.Shipme
 func (r *shipmentRepository) GetNextShipmentNumber(ctx context.Context) (string, error) {
 	year := time.Now().Year()
 	var count int64
-	r.db.WithContext(ctx).
+	conn(ctx, r.db).
 		Model(&entity.Shipment{}).
 		Where("EXTRACT(YEAR FROM created_at) = ?", year).
 		Count(&count)
@@ -104,7 +106,7 @@ func (r *shipmentRepository) GetNextShipmentNumber(ctx context.Context) (string,
 }
 
 func (r *shipmentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.ShipmentStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.Shipment{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -115,7 +117,8 @@ func (r *shipmentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, sta
 
 func (r *shipmentRepository) GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Shipment, error) {
 	var shipments []*entity.Shipment
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
+		Preload("LineItems").
 		Where("sales_order_id = ?", salesOrderID).
 		Order("created_at DESC").
 		Find(&shipments).Error
@@ -124,7 +127,7 @@ func (r *shipmentRepository) GetBySalesOrder(ctx context.Context, salesOrderID u
 
 func (r *shipmentRepository) GetByTrackingNumber(ctx context.Context, trackingNumber string) (*entity.Shipment, error) {
 	var shipment entity.Shipment
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		First(&shipment, "tracking_number = ?", trackingNumber).Error
 	if err != nil {
 		return nil, err
@@ -132,6 +135,35 @@ func (r *shipmentRepository) GetByTrackingNumber(ctx context.Context, trackingNu
 	return &shipment, nil
 }
 
+// GetTrackable returns the shipments booked with a carrier that are not
+// delivered or returned yet
+func (r *shipmentRepository) GetTrackable(ctx context.Context) ([]*entity.Shipment, error) {
+	var shipments []*entity.Shipment
+	err := conn(ctx, r.db).
+		Where("carrier <> '' AND tracking_number <> ''").
+		Where("status NOT IN ?", []entity.ShipmentStatus{entity.ShipmentStatusDelivered, entity.ShipmentStatusReturned}).
+		Order("created_at ASC").
+		Find(&shipments).Error
+	return shipments, err
+}
+
+// AddTrackingEvent records a tracking event and reports whether it is new
+func (r *shipmentRepository) AddTrackingEvent(ctx context.Context, event *entity.ShipmentTrackingEvent) (bool, error) {
+	result := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(event)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *shipmentRepository) GetTrackingEvents(ctx context.Context, shipmentID uuid.UUID) ([]*entity.ShipmentTrackingEvent, error) {
+	var events []*entity.ShipmentTrackingEvent
+	err := conn(ctx, r.db).
+		Where("shipment_id = ?", shipmentID).
+		Order("occurred_at ASC").
+		Find(&events).Error
+	return events, err
+}
+
 // Return Repository
 type returnRepository struct {
 	db *gorm.DB
@@ -143,14 +175,15 @@ func NewReturnRepository(db *gorm.DB) repository.ReturnRepository {
 }
 
 func (r *returnRepository) Create(ctx context.Context, ret *entity.Return) error {
-	return r.db.WithContext(ctx).Create(ret).Error
+	return conn(ctx, r.db).Create(ret).Error
 }
 
 func (r *returnRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Return, error) {
 	var ret entity.Return
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("SalesOrder").
 		Preload("LineItems").
+		Preload("CreditNote").
 		First(&ret, "id = ?", id).Error
 	if err != nil {
 		return nil, err
@@ -160,7 +193,7 @@ func (r *returnRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.R
 
 func (r *returnRepository) GetByNumber(ctx context.Context, number string) (*entity.Return, error) {
 	var ret entity.Return
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Preload("SalesOrder").
 		Preload("LineItems").
 		First(&ret, "return_number = ?", number).Error
@@ -172,14 +205,14 @@ func (r *returnRepository) GetByNumber(ctx context.Context, number string) (*ent
 
 func (r *returnRepository) Update(ctx context.Context, ret *entity.Return) error {
 	ret.UpdatedAt = time.Now()
-	return r.db.WithContext(ctx).Save(ret).Error
+	return conn(ctx, r.db).Save(ret).Error
 }
 
 func (r *returnRepository) List(ctx context.Context, filter *repository.ReturnFilter) ([]*entity.Return, int64, error) {
 	var returns []*entity.Return
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Return{})
+	query := conn(ctx, r.db).Model(&entity.Return{})
 
 	// Apply filters
 	if filter.SalesOrderID != nil {
@@ -220,7 +253,7 @@ func (r *returnRepository) List(ctx context.Context, filter *repository.ReturnFi
 func (r *returnRepository) GetNextReturnNumber(ctx context.Context) (string, error) {
 	year := time.Now().Year()
 	var count int64
-	r.db.WithContext(ctx).
+	conn(ctx, r.db).
 		Model(&entity.Return{}).
 		Where("EXTRACT(YEAR FROM return_date) = ?", year).
 		Count(&count)
@@ -228,19 +261,23 @@ func (r *returnRepository) GetNextReturnNumber(ctx context.Context) (string, err
 }
 
 func (r *returnRepository) CreateLineItem(ctx context.Context, item *entity.ReturnLineItem) error {
-	return r.db.WithContext(ctx).Create(item).Error
+	return conn(ctx, r.db).Create(item).Error
+}
+
+func (r *returnRepository) UpdateLineItem(ctx context.Context, item *entity.ReturnLineItem) error {
+	return conn(ctx, r.db).Save(item).Error
 }
 
 func (r *returnRepository) GetLineItems(ctx context.Context, returnID uuid.UUID) ([]*entity.ReturnLineItem, error) {
 	var items []*entity.ReturnLineItem
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("return_id = ?", returnID).
 		Find(&items).Error
 	return items, err
 }
 
 func (r *returnRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.ReturnStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&entity.Return{}).
 		Where("id = ?", id).
 		Updates(map[string]interface{}{
@@ -251,7 +288,7 @@ func (r *returnRepository) UpdateStatus(ctx context.Context, id uuid.UUID, statu
 
 func (r *returnRepository) GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Return, error) {
 	var returns []*entity.Return
-	err := r.db.WithContext(ctx).
+	err := conn(ctx, r.db).
 		Where("sales_order_id = ?", salesOrderID).
 		Order("created_at DESC").
 		Find(&returns).Error
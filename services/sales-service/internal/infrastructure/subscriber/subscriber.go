@@ -0,0 +1,132 @@
+package subscriber
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	salesorder "github.com/erp-cosmetics/sales-service/internal/usecase/sales_order"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// EventSubscriber handles incoming events from other services
+type EventSubscriber struct {
+	nc                 *nats.Conn
+	logger             *zap.Logger
+	updateBackordersUC *salesorder.UpdateBackordersUseCase
+	subscriptions      []*nats.Subscription
+}
+
+// NewEventSubscriber creates a new event subscriber
+func NewEventSubscriber(
+	nc *nats.Conn,
+	logger *zap.Logger,
+	updateBackordersUC *salesorder.UpdateBackordersUseCase,
+) *EventSubscriber {
+	return &EventSubscriber{
+		nc:                 nc,
+		logger:             logger,
+		updateBackordersUC: updateBackordersUC,
+	}
+}
+
+// Start begins listening for events
+func (s *EventSubscriber) Start() error {
+	if s.nc == nil {
+		s.logger.Warn("NATS not connected, skipping event subscriptions")
+		return nil
+	}
+
+	// Subscribe to WMS backorder events
+	backorders := []struct {
+		subject string
+		status  string
+	}{
+		{"wms.backorder.created", salesorder.BackorderStatusBackordered},
+		{"wms.backorder.allocated", salesorder.BackorderStatusAllocated},
+		{"wms.backorder.rescheduled", salesorder.BackorderStatusRescheduled},
+	}
+	for _, b := range backorders {
+		status := b.status
+		sub, err := s.nc.Subscribe(b.subject, func(msg *nats.Msg) {
+			s.handleBackorder(msg, status)
+		})
+		if err != nil {
+			return err
+		}
+		s.subscriptions = append(s.subscriptions, sub)
+	}
+
+	s.logger.Info("Event subscriber started",
+		zap.Int("subscriptions", len(s.subscriptions)),
+	)
+
+	return nil
+}
+
+// Stop stops all subscriptions
+func (s *EventSubscriber) Stop() {
+	for _, sub := range s.subscriptions {
+		sub.Unsubscribe()
+	}
+	s.logger.Info("Event subscriber stopped")
+}
+
+// BackorderEvent represents backorders of a sales order created, allocated or rescheduled in WMS
+type BackorderEvent struct {
+	SOID     string `json:"so_id"`
+	SONumber string `json:"so_number"`
+	Lines    []struct {
+		LineID         string  `json:"line_id"`
+		BackorderedQty float64 `json:"backordered_qty"`
+		ExpectedDate   string  `json:"expected_date"`
+	} `json:"lines"`
+}
+
+// handleBackorder handles WMS backorder events - records backordered quantities on the order lines
+func (s *EventSubscriber) handleBackorder(msg *nats.Msg, status string) {
+	var event BackorderEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal backorder event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received backorder event",
+		zap.String("so_number", event.SONumber),
+		zap.String("status", status),
+	)
+
+	soID, err := uuid.Parse(event.SOID)
+	if err != nil {
+		s.logger.Error("Invalid sales order ID in backorder event", zap.Error(err))
+		return
+	}
+
+	input := &salesorder.BackorderUpdateInput{
+		SalesOrderID: soID,
+		Status:       status,
+	}
+	for _, line := range event.Lines {
+		lineID, err := uuid.Parse(line.LineID)
+		if err != nil {
+			continue // Not raised from an order line
+		}
+		update := salesorder.BackorderLineInput{
+			LineID:         lineID,
+			BackorderedQty: line.BackorderedQty,
+		}
+		if expected, err := time.Parse("2006-01-02", line.ExpectedDate); err == nil {
+			update.ExpectedDate = &expected
+		}
+		input.Lines = append(input.Lines, update)
+	}
+
+	if _, err := s.updateBackordersUC.Execute(context.Background(), input); err != nil {
+		s.logger.Error("Failed to update backordered order lines",
+			zap.String("so_number", event.SONumber),
+			zap.Error(err),
+		)
+	}
+}
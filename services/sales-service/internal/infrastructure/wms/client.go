@@ -0,0 +1,80 @@
+package wms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client quotes available-to-promise dates from wms-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new wms-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// ATPLine is a quantity of a product to promise
+type ATPLine struct {
+	MaterialID uuid.UUID `json:"material_id"`
+	Quantity   float64   `json:"quantity"`
+}
+
+// Promise is the earliest date a line can be delivered from stock or
+// planned supply
+type Promise struct {
+	MaterialID       uuid.UUID `json:"material_id"`
+	RequestedQty     float64   `json:"requested_qty"`
+	AvailableNow     float64   `json:"available_now"`
+	PromiseDate      time.Time `json:"promise_date"`
+	CapableToPromise bool      `json:"capable_to_promise"` // Beyond planned supply, promised at the replenishment lead time
+	SupplyIncomplete bool      `json:"supply_incomplete"`
+}
+
+// PromiseLines returns a promise per line, in the order of the lines
+func (c *Client) PromiseLines(ctx context.Context, lines []ATPLine) ([]Promise, error) {
+	body, err := json.Marshal(map[string]interface{}{"lines": lines})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/atp", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("wms-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool      `json:"success"`
+		Data    []Promise `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode wms-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return nil, fmt.Errorf("wms-service returned status %d", resp.StatusCode)
+	}
+	if len(result.Data) != len(lines) {
+		return nil, fmt.Errorf("wms-service returned %d promises for %d lines", len(result.Data), len(lines))
+	}
+	return result.Data, nil
+}
@@ -0,0 +1,804 @@
+package testmocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/masterdata"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/wms"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCustomerRepository
+type MockCustomerRepository struct {
+	mock.Mock
+}
+
+func (m *MockCustomerRepository) Create(ctx context.Context, customer *entity.Customer) error {
+	args := m.Called(ctx, customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Customer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) GetByCode(ctx context.Context, code string) (*entity.Customer, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Customer), args.Error(1)
+}
+
+func (m *MockCustomerRepository) Update(ctx context.Context, customer *entity.Customer) error {
+	args := m.Called(ctx, customer)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) List(ctx context.Context, filter *repository.CustomerFilter) ([]*entity.Customer, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Customer), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCustomerRepository) GetNextCustomerCode(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCustomerRepository) UpdateBalance(ctx context.Context, customerID uuid.UUID, amount float64) error {
+	args := m.Called(ctx, customerID, amount)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetAvailableCredit(ctx context.Context, customerID uuid.UUID) (float64, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+func (m *MockCustomerRepository) CreateAddress(ctx context.Context, address *entity.CustomerAddress) error {
+	args := m.Called(ctx, address)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetAddresses(ctx context.Context, customerID uuid.UUID) ([]*entity.CustomerAddress, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.CustomerAddress), args.Error(1)
+}
+
+func (m *MockCustomerRepository) UpdateAddress(ctx context.Context, address *entity.CustomerAddress) error {
+	args := m.Called(ctx, address)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) DeleteAddress(ctx context.Context, addressID uuid.UUID) error {
+	args := m.Called(ctx, addressID)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetDefaultAddress(ctx context.Context, customerID uuid.UUID, addressType entity.AddressType) (*entity.CustomerAddress, error) {
+	args := m.Called(ctx, customerID, addressType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerAddress), args.Error(1)
+}
+
+func (m *MockCustomerRepository) CreateContact(ctx context.Context, contact *entity.CustomerContact) error {
+	args := m.Called(ctx, contact)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetContacts(ctx context.Context, customerID uuid.UUID) ([]*entity.CustomerContact, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.CustomerContact), args.Error(1)
+}
+
+func (m *MockCustomerRepository) UpdateContact(ctx context.Context, contact *entity.CustomerContact) error {
+	args := m.Called(ctx, contact)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) DeleteContact(ctx context.Context, contactID uuid.UUID) error {
+	args := m.Called(ctx, contactID)
+	return args.Error(0)
+}
+
+func (m *MockCustomerRepository) GetPrimaryContact(ctx context.Context, customerID uuid.UUID) (*entity.CustomerContact, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CustomerContact), args.Error(1)
+}
+
+// MockPriceListRepository
+type MockPriceListRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceListRepository) Create(ctx context.Context, priceList *entity.PriceList) error {
+	args := m.Called(ctx, priceList)
+	return args.Error(0)
+}
+
+func (m *MockPriceListRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PriceList), args.Error(1)
+}
+
+func (m *MockPriceListRepository) GetByCode(ctx context.Context, code string) (*entity.PriceList, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PriceList), args.Error(1)
+}
+
+func (m *MockPriceListRepository) Update(ctx context.Context, priceList *entity.PriceList) error {
+	args := m.Called(ctx, priceList)
+	return args.Error(0)
+}
+
+func (m *MockPriceListRepository) List(ctx context.Context, filter *repository.PriceListFilter) ([]*entity.PriceList, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.PriceList), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPriceListRepository) CreateItem(ctx context.Context, item *entity.PriceListItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockPriceListRepository) GetValidForProducts(ctx context.Context, date time.Time, productIDs []uuid.UUID) ([]*entity.PriceList, error) {
+	args := m.Called(ctx, date, productIDs)
+	return args.Get(0).([]*entity.PriceList), args.Error(1)
+}
+
+// MockQuotationRepository
+type MockQuotationRepository struct {
+	mock.Mock
+}
+
+func (m *MockQuotationRepository) Create(ctx context.Context, quotation *entity.Quotation) error {
+	args := m.Called(ctx, quotation)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Quotation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Quotation), args.Error(1)
+}
+
+func (m *MockQuotationRepository) GetByNumber(ctx context.Context, number string) (*entity.Quotation, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Quotation), args.Error(1)
+}
+
+func (m *MockQuotationRepository) Update(ctx context.Context, quotation *entity.Quotation) error {
+	args := m.Called(ctx, quotation)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) List(ctx context.Context, filter *repository.QuotationFilter) ([]*entity.Quotation, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Quotation), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockQuotationRepository) GetNextQuotationNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockQuotationRepository) CreateLineItem(ctx context.Context, item *entity.QuotationLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) UpdateLineItem(ctx context.Context, item *entity.QuotationLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) DeleteLineItem(ctx context.Context, itemID uuid.UUID) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) GetLineItems(ctx context.Context, quotationID uuid.UUID) ([]*entity.QuotationLineItem, error) {
+	args := m.Called(ctx, quotationID)
+	return args.Get(0).([]*entity.QuotationLineItem), args.Error(1)
+}
+
+func (m *MockQuotationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.QuotationStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockQuotationRepository) GetExpiringQuotations(ctx context.Context, days int) ([]*entity.Quotation, error) {
+	args := m.Called(ctx, days)
+	return args.Get(0).([]*entity.Quotation), args.Error(1)
+}
+
+func (m *MockQuotationRepository) MarkExpiredQuotations(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockPromotionRepository
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) Create(ctx context.Context, promotion *entity.Promotion) error {
+	args := m.Called(ctx, promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetByCode(ctx context.Context, code string) (*entity.Promotion, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) Update(ctx context.Context, promotion *entity.Promotion) error {
+	args := m.Called(ctx, promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) List(ctx context.Context, filter *repository.PromotionFilter) ([]*entity.Promotion, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Promotion), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPromotionRepository) GetAutomatic(ctx context.Context, date time.Time) ([]*entity.Promotion, error) {
+	args := m.Called(ctx, date)
+	return args.Get(0).([]*entity.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) AddUsage(ctx context.Context, id uuid.UUID, cost float64, uses int) (bool, error) {
+	args := m.Called(ctx, id, cost, uses)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPromotionRepository) CreateVoucher(ctx context.Context, voucher *entity.Voucher) error {
+	args := m.Called(ctx, voucher)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) GetVoucherByCode(ctx context.Context, code string) (*entity.Voucher, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Voucher), args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetVouchers(ctx context.Context, promotionID uuid.UUID) ([]*entity.Voucher, error) {
+	args := m.Called(ctx, promotionID)
+	return args.Get(0).([]*entity.Voucher), args.Error(1)
+}
+
+func (m *MockPromotionRepository) AddVoucherUse(ctx context.Context, id uuid.UUID, uses int) (bool, error) {
+	args := m.Called(ctx, id, uses)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetOrderPromotions(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderPromotion, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]*entity.OrderPromotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) MarkOrderPromotionsReleased(ctx context.Context, orderID uuid.UUID) error {
+	args := m.Called(ctx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) MarkOrderPromotionReleased(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// MockSalesOrderRepository
+type MockSalesOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockSalesOrderRepository) Create(ctx context.Context, order *entity.SalesOrder) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.SalesOrder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SalesOrder), args.Error(1)
+}
+
+func (m *MockSalesOrderRepository) GetByNumber(ctx context.Context, number string) (*entity.SalesOrder, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SalesOrder), args.Error(1)
+}
+
+func (m *MockSalesOrderRepository) Update(ctx context.Context, order *entity.SalesOrder) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) List(ctx context.Context, filter *repository.SalesOrderFilter) ([]*entity.SalesOrder, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.SalesOrder), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSalesOrderRepository) GetNextSONumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSalesOrderRepository) CreateLineItem(ctx context.Context, item *entity.SOLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) UpdateLineItem(ctx context.Context, item *entity.SOLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) DeleteLineItem(ctx context.Context, itemID uuid.UUID) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) GetLineItems(ctx context.Context, orderID uuid.UUID) ([]*entity.SOLineItem, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]*entity.SOLineItem), args.Error(1)
+}
+
+func (m *MockSalesOrderRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.SOStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) UpdatePaymentStatus(ctx context.Context, id uuid.UUID, status entity.PaymentStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) UpdateShippedQuantity(ctx context.Context, lineItemID uuid.UUID, shippedQty float64) error {
+	args := m.Called(ctx, lineItemID, shippedQty)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) UpdateLineItemReservation(ctx context.Context, lineItemID uuid.UUID, reservationID uuid.UUID) error {
+	args := m.Called(ctx, lineItemID, reservationID)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) CreateAmendment(ctx context.Context, amendment *entity.SOAmendment) error {
+	args := m.Called(ctx, amendment)
+	return args.Error(0)
+}
+
+func (m *MockSalesOrderRepository) GetAmendments(ctx context.Context, orderID uuid.UUID) ([]*entity.SOAmendment, error) {
+	args := m.Called(ctx, orderID)
+	return args.Get(0).([]*entity.SOAmendment), args.Error(1)
+}
+
+func (m *MockSalesOrderRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID, limit int) ([]*entity.SalesOrder, error) {
+	args := m.Called(ctx, customerID, limit)
+	return args.Get(0).([]*entity.SalesOrder), args.Error(1)
+}
+
+func (m *MockSalesOrderRepository) GetPendingOrdersByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.SalesOrder, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.SalesOrder), args.Error(1)
+}
+
+// MockShipmentRepository
+type MockShipmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockShipmentRepository) Create(ctx context.Context, shipment *entity.Shipment) error {
+	args := m.Called(ctx, shipment)
+	return args.Error(0)
+}
+
+func (m *MockShipmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Shipment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) GetByNumber(ctx context.Context, number string) (*entity.Shipment, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) Update(ctx context.Context, shipment *entity.Shipment) error {
+	args := m.Called(ctx, shipment)
+	return args.Error(0)
+}
+
+func (m *MockShipmentRepository) List(ctx context.Context, filter *repository.ShipmentFilter) ([]*entity.Shipment, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Shipment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockShipmentRepository) GetNextShipmentNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockShipmentRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.ShipmentStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockShipmentRepository) GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Shipment, error) {
+	args := m.Called(ctx, salesOrderID)
+	return args.Get(0).([]*entity.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) GetByTrackingNumber(ctx context.Context, trackingNumber string) (*entity.Shipment, error) {
+	args := m.Called(ctx, trackingNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) GetTrackable(ctx context.Context) ([]*entity.Shipment, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*entity.Shipment), args.Error(1)
+}
+
+func (m *MockShipmentRepository) AddTrackingEvent(ctx context.Context, event *entity.ShipmentTrackingEvent) (bool, error) {
+	args := m.Called(ctx, event)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockShipmentRepository) GetTrackingEvents(ctx context.Context, shipmentID uuid.UUID) ([]*entity.ShipmentTrackingEvent, error) {
+	args := m.Called(ctx, shipmentID)
+	return args.Get(0).([]*entity.ShipmentTrackingEvent), args.Error(1)
+}
+
+// MockInvoiceRepository
+type MockInvoiceRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceRepository) Create(ctx context.Context, invoice *entity.Invoice) error {
+	args := m.Called(ctx, invoice)
+	return args.Error(0)
+}
+
+func (m *MockInvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) Update(ctx context.Context, invoice *entity.Invoice) error {
+	args := m.Called(ctx, invoice)
+	return args.Error(0)
+}
+
+func (m *MockInvoiceRepository) List(ctx context.Context, filter *repository.InvoiceFilter) ([]*entity.Invoice, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Invoice), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockInvoiceRepository) GetNextInvoiceNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetByShipment(ctx context.Context, shipmentID uuid.UUID) (*entity.Invoice, error) {
+	args := m.Called(ctx, shipmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Invoice, error) {
+	args := m.Called(ctx, salesOrderID)
+	return args.Get(0).([]*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Invoice, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetOpenByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Invoice, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetOpen(ctx context.Context, customerID *uuid.UUID) ([]*entity.Invoice, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) GetSummary(ctx context.Context, customerID uuid.UUID, asOf time.Time) (*repository.ARSummary, error) {
+	args := m.Called(ctx, customerID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.ARSummary), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) CreateAllocation(ctx context.Context, allocation *entity.InvoiceAllocation) error {
+	args := m.Called(ctx, allocation)
+	return args.Error(0)
+}
+
+// MockPaymentRepository
+type MockPaymentRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
+	args := m.Called(ctx, payment)
+	return args.Error(0)
+}
+
+func (m *MockPaymentRepository) List(ctx context.Context, filter *repository.PaymentFilter) ([]*entity.Payment, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Payment), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPaymentRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.Payment, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.Payment), args.Error(1)
+}
+
+func (m *MockPaymentRepository) GetNextPaymentNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockCreditHoldRepository
+type MockCreditHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditHoldRepository) Create(ctx context.Context, hold *entity.CreditHold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func (m *MockCreditHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditHold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CreditHold), args.Error(1)
+}
+
+func (m *MockCreditHoldRepository) Update(ctx context.Context, hold *entity.CreditHold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func (m *MockCreditHoldRepository) List(ctx context.Context, filter *repository.CreditHoldFilter) ([]*entity.CreditHold, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.CreditHold), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCreditHoldRepository) GetPendingByOrder(ctx context.Context, orderID uuid.UUID) (*entity.CreditHold, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CreditHold), args.Error(1)
+}
+
+// MockReturnRepository
+type MockReturnRepository struct {
+	mock.Mock
+}
+
+func (m *MockReturnRepository) Create(ctx context.Context, ret *entity.Return) error {
+	args := m.Called(ctx, ret)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Return, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Return), args.Error(1)
+}
+
+func (m *MockReturnRepository) GetByNumber(ctx context.Context, number string) (*entity.Return, error) {
+	args := m.Called(ctx, number)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Return), args.Error(1)
+}
+
+func (m *MockReturnRepository) Update(ctx context.Context, ret *entity.Return) error {
+	args := m.Called(ctx, ret)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) List(ctx context.Context, filter *repository.ReturnFilter) ([]*entity.Return, int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Return), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReturnRepository) GetNextReturnNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockReturnRepository) CreateLineItem(ctx context.Context, item *entity.ReturnLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) UpdateLineItem(ctx context.Context, item *entity.ReturnLineItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) GetLineItems(ctx context.Context, returnID uuid.UUID) ([]*entity.ReturnLineItem, error) {
+	args := m.Called(ctx, returnID)
+	return args.Get(0).([]*entity.ReturnLineItem), args.Error(1)
+}
+
+func (m *MockReturnRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status entity.ReturnStatus) error {
+	args := m.Called(ctx, id, status)
+	return args.Error(0)
+}
+
+func (m *MockReturnRepository) GetBySalesOrder(ctx context.Context, salesOrderID uuid.UUID) ([]*entity.Return, error) {
+	args := m.Called(ctx, salesOrderID)
+	return args.Get(0).([]*entity.Return), args.Error(1)
+}
+
+// MockCreditNoteRepository
+type MockCreditNoteRepository struct {
+	mock.Mock
+}
+
+func (m *MockCreditNoteRepository) Create(ctx context.Context, note *entity.CreditNote) error {
+	args := m.Called(ctx, note)
+	return args.Error(0)
+}
+
+func (m *MockCreditNoteRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.CreditNote, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CreditNote), args.Error(1)
+}
+
+func (m *MockCreditNoteRepository) Update(ctx context.Context, note *entity.CreditNote) error {
+	args := m.Called(ctx, note)
+	return args.Error(0)
+}
+
+func (m *MockCreditNoteRepository) GetByReturn(ctx context.Context, returnID uuid.UUID) (*entity.CreditNote, error) {
+	args := m.Called(ctx, returnID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CreditNote), args.Error(1)
+}
+
+func (m *MockCreditNoteRepository) GetByCustomer(ctx context.Context, customerID uuid.UUID) ([]*entity.CreditNote, error) {
+	args := m.Called(ctx, customerID)
+	return args.Get(0).([]*entity.CreditNote), args.Error(1)
+}
+
+func (m *MockCreditNoteRepository) GetNextCreditNoteNumber(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+// MockProductCatalog
+type MockProductCatalog struct {
+	mock.Mock
+}
+
+func (m *MockProductCatalog) GetProduct(ctx context.Context, id uuid.UUID) (*masterdata.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*masterdata.Product), args.Error(1)
+}
+
+// MockWarehouseClient
+type MockWarehouseClient struct {
+	mock.Mock
+}
+
+func (m *MockWarehouseClient) PromiseLines(ctx context.Context, lines []wms.ATPLine) ([]wms.Promise, error) {
+	args := m.Called(ctx, lines)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]wms.Promise), args.Error(1)
+}
+
+// MockTransactor runs the function directly, without a transaction
+type MockTransactor struct{}
+
+func (MockTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
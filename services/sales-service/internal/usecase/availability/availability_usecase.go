@@ -0,0 +1,90 @@
+package availability
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/wms"
+	"github.com/google/uuid"
+)
+
+// WarehouseClient promises quantities from stock and planned supply
+type WarehouseClient interface {
+	PromiseLines(ctx context.Context, lines []wms.ATPLine) ([]wms.Promise, error)
+}
+
+// PromiseQueryItem represents a product and quantity to promise
+type PromiseQueryItem struct {
+	ProductID uuid.UUID
+	Quantity  float64
+}
+
+// LinePromise represents the earliest delivery date of a line
+type LinePromise struct {
+	ProductID        uuid.UUID `json:"product_id"`
+	Quantity         float64   `json:"quantity"`
+	AvailableNow     float64   `json:"available_now"`
+	PromiseDate      time.Time `json:"promise_date"`
+	CapableToPromise bool      `json:"capable_to_promise"`
+	SupplyIncomplete bool      `json:"supply_incomplete"`
+}
+
+// PromiseDatesUseCase quotes delivery dates for quotation and order lines
+type PromiseDatesUseCase struct {
+	warehouse WarehouseClient
+}
+
+// NewPromiseDatesUseCase creates a new use case
+func NewPromiseDatesUseCase(warehouse WarehouseClient) *PromiseDatesUseCase {
+	return &PromiseDatesUseCase{warehouse: warehouse}
+}
+
+// Execute promises the items in order; items of the same product are
+// promised one after the other
+func (uc *PromiseDatesUseCase) Execute(ctx context.Context, items []PromiseQueryItem) ([]*LinePromise, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	lines := make([]wms.ATPLine, len(items))
+	for i, item := range items {
+		lines[i] = wms.ATPLine{MaterialID: item.ProductID, Quantity: item.Quantity}
+	}
+
+	promises, err := uc.warehouse.PromiseLines(ctx, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*LinePromise, len(promises))
+	for i, promise := range promises {
+		result[i] = &LinePromise{
+			ProductID:        items[i].ProductID,
+			Quantity:         items[i].Quantity,
+			AvailableNow:     promise.AvailableNow,
+			PromiseDate:      promise.PromiseDate,
+			CapableToPromise: promise.CapableToPromise,
+			SupplyIncomplete: promise.SupplyIncomplete,
+		}
+	}
+	return result, nil
+}
+
+// PromiseDates returns the promise date of each item, or nil dates when WMS
+// cannot be reached so that entry is never blocked by it
+func (uc *PromiseDatesUseCase) PromiseDates(ctx context.Context, items []PromiseQueryItem) []*time.Time {
+	dates := make([]*time.Time, len(items))
+	if uc == nil {
+		return dates
+	}
+
+	promises, err := uc.Execute(ctx, items)
+	if err != nil {
+		return dates
+	}
+	for i, promise := range promises {
+		date := promise.PromiseDate
+		dates[i] = &date
+	}
+	return dates
+}
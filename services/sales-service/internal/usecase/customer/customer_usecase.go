@@ -2,6 +2,7 @@ package customer
 
 import (
 	"context"
+	"time"
 
 	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
 	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
@@ -195,36 +196,53 @@ func (uc *DeleteCustomerUseCase) Execute(ctx context.Context, id uuid.UUID) erro
 // CheckCreditUseCase handles credit limit checking
 type CheckCreditUseCase struct {
 	customerRepo repository.CustomerRepository
+	invoiceRepo  repository.InvoiceRepository
 }
 
 // NewCheckCreditUseCase creates a new use case
-func NewCheckCreditUseCase(repo repository.CustomerRepository) *CheckCreditUseCase {
-	return &CheckCreditUseCase{customerRepo: repo}
+func NewCheckCreditUseCase(repo repository.CustomerRepository, invoiceRepo repository.InvoiceRepository) *CheckCreditUseCase {
+	return &CheckCreditUseCase{customerRepo: repo, invoiceRepo: invoiceRepo}
 }
 
 // CreditCheckResult represents credit check result
 type CreditCheckResult struct {
 	CustomerID        uuid.UUID
 	CreditLimit       float64
-	CurrentBalance    float64
+	CurrentBalance    float64 // Open AR plus unbilled orders
+	OpenAR            float64 // Open invoices less unapplied payments and credit notes
+	UnbilledOrders    float64
+	OverdueAmount     float64
+	OverdueInvoices   int64
 	AvailableCredit   float64
 	RequestedAmount   float64
 	WithinLimit       bool
 }
 
-// Execute checks if customer can place order of given amount
+// Execute checks if customer can place order of given amount against the
+// receivables ledger: open invoices net of unapplied credit, plus confirmed
+// orders not yet invoiced
 func (uc *CheckCreditUseCase) Execute(ctx context.Context, customerID uuid.UUID, orderAmount float64) (*CreditCheckResult, error) {
 	customer, err := uc.customerRepo.GetByID(ctx, customerID)
 	if err != nil {
 		return nil, err
 	}
 
-	availableCredit := customer.CreditLimit - customer.CurrentBalance
+	summary, err := uc.invoiceRepo.GetSummary(ctx, customerID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	balance := summary.OpenAR() + summary.UnbilledOrders
+	availableCredit := customer.CreditLimit - balance
 
 	return &CreditCheckResult{
 		CustomerID:      customerID,
 		CreditLimit:     customer.CreditLimit,
-		CurrentBalance:  customer.CurrentBalance,
+		CurrentBalance:  balance,
+		OpenAR:          summary.OpenAR(),
+		UnbilledOrders:  summary.UnbilledOrders,
+		OverdueAmount:   summary.OverdueAmount,
+		OverdueInvoices: summary.OverdueInvoices,
 		AvailableCredit: availableCredit,
 		RequestedAmount: orderAmount,
 		WithinLimit:     availableCredit >= orderAmount,
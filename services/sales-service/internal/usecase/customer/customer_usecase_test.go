@@ -0,0 +1,75 @@
+package customer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/customer"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckCreditUseCase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		summary     *repository.ARSummary
+		amount      float64
+		balance     float64
+		available   float64
+		withinLimit bool
+	}{
+		{"no receivables", &repository.ARSummary{}, 50000000, 0, 100000000, true},
+		{"order uses the last of the limit", &repository.ARSummary{OpenInvoices: 60000000, UnbilledOrders: 20000000}, 20000000, 80000000, 20000000, true},
+		{"unbilled orders count against the limit", &repository.ARSummary{OpenInvoices: 60000000, UnbilledOrders: 30000000}, 20000000, 90000000, 10000000, false},
+		{"unapplied credit frees up the limit", &repository.ARSummary{OpenInvoices: 90000000, UnappliedCredit: 30000000}, 40000000, 60000000, 40000000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			customerRepo := new(testmocks.MockCustomerRepository)
+			invoiceRepo := new(testmocks.MockInvoiceRepository)
+			uc := customer.NewCheckCreditUseCase(customerRepo, invoiceRepo)
+
+			c := &entity.Customer{ID: uuid.New(), CreditLimit: 100000000}
+			customerRepo.On("GetByID", ctx, c.ID).Return(c, nil)
+			invoiceRepo.On("GetSummary", ctx, c.ID, mock.AnythingOfType("time.Time")).Return(tt.summary, nil)
+
+			// Act
+			res, err := uc.Execute(ctx, c.ID, tt.amount)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.balance, res.CurrentBalance)
+			assert.Equal(t, tt.available, res.AvailableCredit)
+			assert.Equal(t, tt.withinLimit, res.WithinLimit)
+		})
+	}
+}
+
+func TestCheckCreditUseCase_Execute_ReportsOverdueInvoices(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	customerRepo := new(testmocks.MockCustomerRepository)
+	invoiceRepo := new(testmocks.MockInvoiceRepository)
+	uc := customer.NewCheckCreditUseCase(customerRepo, invoiceRepo)
+
+	c := &entity.Customer{ID: uuid.New(), CreditLimit: 100000000}
+	customerRepo.On("GetByID", ctx, c.ID).Return(c, nil)
+	invoiceRepo.On("GetSummary", ctx, c.ID, mock.AnythingOfType("time.Time")).
+		Return(&repository.ARSummary{OpenInvoices: 10000000, OverdueAmount: 4000000, OverdueInvoices: 2}, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, c.ID, 1000000)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, res.WithinLimit)
+	assert.Equal(t, int64(2), res.OverdueInvoices)
+	assert.Equal(t, 4000000.0, res.OverdueAmount)
+}
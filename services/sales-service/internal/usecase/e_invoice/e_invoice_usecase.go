@@ -0,0 +1,313 @@
+package e_invoice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/einvoice"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEInvoiceNotFound      = errors.New("e-invoice not found")
+	ErrInvoiceNotFound       = errors.New("invoice not found")
+	ErrInvoiceCancelled      = errors.New("invoice is cancelled")
+	ErrAlreadyIssued         = errors.New("invoice already has an e-invoice")
+	ErrEInvoiceCannotCancel  = errors.New("only issued e-invoices can be cancelled")
+	ErrCancelReasonRequired  = errors.New("cancellation reason is required")
+	ErrSellerNotConfigured   = errors.New("seller tax code is not configured")
+	ErrInvalidSellerTaxCode  = errors.New("seller tax code is invalid")
+	ErrInvalidBuyerTaxCode   = errors.New("customer tax code is invalid")
+	ErrSignerNotConfigured   = errors.New("e-invoice signing certificate is not configured")
+	ErrProviderNotConfigured = errors.New("e-invoice provider is not configured")
+)
+
+// taxCodePattern matches a Vietnamese tax code: 10 digits, with a 3-digit
+// suffix for dependent units
+var taxCodePattern = regexp.MustCompile(`^\d{10}(-\d{3})?$`)
+
+// VAT invoice template code (KHMSHDon)
+const TemplateVAT = "1"
+
+// DefaultSeries returns the series of e-invoices with a tax authority code
+// registered by the seller for a year, e.g. C26TAA
+func DefaultSeries(year int) string {
+	return fmt.Sprintf("C%02dTAA", year%100)
+}
+
+// IssueEInvoiceUseCase handles issuing e-invoices for sales invoices
+type IssueEInvoiceUseCase struct {
+	einvoiceRepo repository.EInvoiceRepository
+	invoiceRepo  repository.InvoiceRepository
+	orderRepo    repository.SalesOrderRepository
+	builder      *einvoice.Builder
+	signer       *einvoice.Signer
+	provider     einvoice.Provider
+	series       string
+	eventPub     *event.Publisher
+}
+
+// NewIssueEInvoiceUseCase creates a new use case. An empty series uses the
+// default series of the issue year.
+func NewIssueEInvoiceUseCase(
+	einvoiceRepo repository.EInvoiceRepository,
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.SalesOrderRepository,
+	builder *einvoice.Builder,
+	signer *einvoice.Signer,
+	provider einvoice.Provider,
+	series string,
+	eventPub *event.Publisher,
+) *IssueEInvoiceUseCase {
+	return &IssueEInvoiceUseCase{
+		einvoiceRepo: einvoiceRepo,
+		invoiceRepo:  invoiceRepo,
+		orderRepo:    orderRepo,
+		builder:      builder,
+		signer:       signer,
+		provider:     provider,
+		series:       series,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute builds the e-invoice XML of a sales invoice, signs it with the
+// seller's certificate and submits it to the provider for a tax authority
+// code. An e-invoice the provider rejected is rebuilt and submitted again
+// under its number.
+func (uc *IssueEInvoiceUseCase) Execute(ctx context.Context, invoiceID uuid.UUID, userID uuid.UUID) (*entity.EInvoice, error) {
+	if uc.signer == nil {
+		return nil, ErrSignerNotConfigured
+	}
+	if uc.provider == nil {
+		return nil, ErrProviderNotConfigured
+	}
+
+	seller := uc.builder.Seller()
+	if seller.TaxCode == "" {
+		return nil, ErrSellerNotConfigured
+	}
+	if !taxCodePattern.MatchString(seller.TaxCode) {
+		return nil, ErrInvalidSellerTaxCode
+	}
+
+	invoice, err := uc.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, ErrInvoiceNotFound
+	}
+	if invoice.Status == entity.InvoiceStatusCancelled {
+		return nil, ErrInvoiceCancelled
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, invoice.SalesOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buyerName, buyerTaxCode string
+	if invoice.Customer != nil {
+		buyerName = invoice.Customer.Name
+		buyerTaxCode = invoice.Customer.TaxCode
+	}
+	if buyerTaxCode != "" && !taxCodePattern.MatchString(buyerTaxCode) {
+		return nil, ErrInvalidBuyerTaxCode
+	}
+
+	einv, err := uc.einvoiceRepo.GetActiveByInvoice(ctx, invoice.ID)
+	if err == nil && !einv.CanBeIssued() {
+		return nil, ErrAlreadyIssued
+	}
+	isNew := err != nil
+	if isNew {
+		series := uc.series
+		if series == "" {
+			series = DefaultSeries(time.Now().Year())
+		}
+		number, err := uc.einvoiceRepo.GetNextNumber(ctx, TemplateVAT, series)
+		if err != nil {
+			return nil, err
+		}
+		einv = &entity.EInvoice{
+			InvoiceID:    invoice.ID,
+			TemplateCode: TemplateVAT,
+			Series:       series,
+			Number:       number,
+		}
+	}
+
+	einv.IssueDate = time.Now()
+	einv.SellerTaxCode = seller.TaxCode
+	einv.BuyerTaxCode = buyerTaxCode
+	einv.BuyerName = buyerName
+	einv.Currency = invoice.Currency
+	if einv.Currency == "" {
+		einv.Currency = "VND"
+	}
+	einv.Provider = uc.provider.Name()
+	einv.Status = entity.EInvoiceStatusSigned
+
+	// Build and sign
+	doc := uc.builder.Build(einv, invoice, order)
+	if err := uc.signer.Sign(doc); err != nil {
+		return nil, err
+	}
+	data, err := doc.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	einv.XMLContent = string(data)
+
+	if isNew {
+		err = uc.einvoiceRepo.Create(ctx, einv)
+	} else {
+		err = uc.einvoiceRepo.Update(ctx, einv)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Submit to the provider
+	result, err := uc.provider.Issue(ctx, &einvoice.IssueRequest{
+		SellerTaxCode: einv.SellerTaxCode,
+		TemplateCode:  einv.TemplateCode,
+		Series:        einv.Series,
+		Number:        einv.Number,
+		IssueDate:     einv.IssueDate,
+		XML:           data,
+	})
+	if err != nil {
+		einv.MarkFailed(err.Error())
+		if updateErr := uc.einvoiceRepo.Update(ctx, einv); updateErr != nil {
+			return nil, updateErr
+		}
+		return nil, err
+	}
+
+	einv.MarkIssued(result.ProviderRef, result.TaxAuthorityCode, result.LookupCode, userID)
+	if err := uc.einvoiceRepo.Update(ctx, einv); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	if uc.eventPub != nil {
+		uc.eventPub.PublishEInvoiceIssued(eventOf(einv))
+	}
+
+	return einv, nil
+}
+
+// CancelEInvoiceUseCase handles cancelling issued e-invoices
+type CancelEInvoiceUseCase struct {
+	einvoiceRepo repository.EInvoiceRepository
+	provider     einvoice.Provider
+	eventPub     *event.Publisher
+}
+
+// NewCancelEInvoiceUseCase creates a new use case
+func NewCancelEInvoiceUseCase(
+	einvoiceRepo repository.EInvoiceRepository,
+	provider einvoice.Provider,
+	eventPub *event.Publisher,
+) *CancelEInvoiceUseCase {
+	return &CancelEInvoiceUseCase{
+		einvoiceRepo: einvoiceRepo,
+		provider:     provider,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute cancels an issued e-invoice with the tax authority. The sales
+// invoice can then be issued again under a new number.
+func (uc *CancelEInvoiceUseCase) Execute(ctx context.Context, id uuid.UUID, reason string, userID uuid.UUID) (*entity.EInvoice, error) {
+	if reason == "" {
+		return nil, ErrCancelReasonRequired
+	}
+	if uc.provider == nil {
+		return nil, ErrProviderNotConfigured
+	}
+
+	einv, err := uc.einvoiceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrEInvoiceNotFound
+	}
+	if !einv.CanBeCancelled() {
+		return nil, ErrEInvoiceCannotCancel
+	}
+
+	err = uc.provider.Cancel(ctx, &einvoice.CancelRequest{
+		ProviderRef:      einv.ProviderRef,
+		SellerTaxCode:    einv.SellerTaxCode,
+		TemplateCode:     einv.TemplateCode,
+		Series:           einv.Series,
+		Number:           einv.Number,
+		TaxAuthorityCode: einv.TaxAuthorityCode,
+		Reason:           reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	einv.Cancel(reason, userID)
+	if err := uc.einvoiceRepo.Update(ctx, einv); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	if uc.eventPub != nil {
+		cancelled := eventOf(einv)
+		cancelled.Reason = reason
+		uc.eventPub.PublishEInvoiceCancelled(cancelled)
+	}
+
+	return einv, nil
+}
+
+// GetEInvoiceUseCase handles getting an e-invoice
+type GetEInvoiceUseCase struct {
+	einvoiceRepo repository.EInvoiceRepository
+}
+
+// NewGetEInvoiceUseCase creates a new use case
+func NewGetEInvoiceUseCase(einvoiceRepo repository.EInvoiceRepository) *GetEInvoiceUseCase {
+	return &GetEInvoiceUseCase{einvoiceRepo: einvoiceRepo}
+}
+
+// Execute gets an e-invoice by ID
+func (uc *GetEInvoiceUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.EInvoice, error) {
+	return uc.einvoiceRepo.GetByID(ctx, id)
+}
+
+// ListEInvoicesUseCase handles listing e-invoices
+type ListEInvoicesUseCase struct {
+	einvoiceRepo repository.EInvoiceRepository
+}
+
+// NewListEInvoicesUseCase creates a new use case
+func NewListEInvoicesUseCase(einvoiceRepo repository.EInvoiceRepository) *ListEInvoicesUseCase {
+	return &ListEInvoicesUseCase{einvoiceRepo: einvoiceRepo}
+}
+
+// Execute lists e-invoices with filters
+func (uc *ListEInvoicesUseCase) Execute(ctx context.Context, filter *repository.EInvoiceFilter) ([]*entity.EInvoice, int64, error) {
+	return uc.einvoiceRepo.List(ctx, filter)
+}
+
+// eventOf maps an e-invoice to its event
+func eventOf(einv *entity.EInvoice) *event.EInvoiceEvent {
+	return &event.EInvoiceEvent{
+		EInvoiceID:       einv.ID.String(),
+		InvoiceID:        einv.InvoiceID.String(),
+		TemplateCode:     einv.TemplateCode,
+		Series:           einv.Series,
+		Number:           einv.Number,
+		BuyerTaxCode:     einv.BuyerTaxCode,
+		TotalAmount:      einv.TotalAmount,
+		TaxAuthorityCode: einv.TaxAuthorityCode,
+	}
+}
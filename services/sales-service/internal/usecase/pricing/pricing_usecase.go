@@ -0,0 +1,394 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/masterdata"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPriceListNotFound   = errors.New("price list not found")
+	ErrPriceListCodeExists = errors.New("price list code already exists")
+	ErrInvalidListType     = errors.New("list type must be STANDARD, CONTRACT or PROMOTION")
+	ErrContractCustomer    = errors.New("contract price list requires a customer")
+	ErrPromotionCampaign   = errors.New("promotion price list requires a campaign")
+	ErrInvalidValidity     = errors.New("valid_to must not be before valid_from")
+	ErrInvalidPrice        = errors.New("unit price must be positive")
+	ErrInvalidMinQuantity  = errors.New("minimum quantity must not be negative")
+	ErrNoPrice             = errors.New("no price found for product")
+	ErrInvalidChannel      = errors.New("channel must be DIRECT, DISTRIBUTOR, RETAIL or ONLINE")
+)
+
+// ProductCatalog reads the product master
+type ProductCatalog interface {
+	GetProduct(ctx context.Context, id uuid.UUID) (*masterdata.Product, error)
+}
+
+// CreatePriceListInput represents input for creating a price list
+type CreatePriceListInput struct {
+	Code            string
+	Name            string
+	ListType        entity.PriceListType
+	CustomerGroupID *uuid.UUID
+	CustomerID      *uuid.UUID
+	Channel         entity.SalesChannel
+	Currency        string
+	CampaignID      *uuid.UUID
+	Priority        int
+	ValidFrom       time.Time
+	ValidTo         *time.Time
+	Notes           string
+	Items           []PriceListItemInput
+	CreatedBy       *uuid.UUID
+}
+
+// PriceListItemInput represents a product price of a price list
+type PriceListItemInput struct {
+	ProductID   uuid.UUID
+	ProductCode string
+	MinQuantity float64
+	UnitPrice   float64
+}
+
+// CreatePriceListUseCase handles price list creation
+type CreatePriceListUseCase struct {
+	priceListRepo repository.PriceListRepository
+}
+
+// NewCreatePriceListUseCase creates a new use case
+func NewCreatePriceListUseCase(repo repository.PriceListRepository) *CreatePriceListUseCase {
+	return &CreatePriceListUseCase{priceListRepo: repo}
+}
+
+// Execute creates a price list with its items
+func (uc *CreatePriceListUseCase) Execute(ctx context.Context, input *CreatePriceListInput) (*entity.PriceList, error) {
+	if input.ListType == "" {
+		input.ListType = entity.PriceListTypeStandard
+	}
+	switch input.ListType {
+	case entity.PriceListTypeStandard:
+		if input.CustomerID != nil {
+			return nil, ErrInvalidListType
+		}
+	case entity.PriceListTypeContract:
+		if input.CustomerID == nil {
+			return nil, ErrContractCustomer
+		}
+	case entity.PriceListTypePromotion:
+		if input.CampaignID == nil {
+			return nil, ErrPromotionCampaign
+		}
+	default:
+		return nil, ErrInvalidListType
+	}
+	if input.Channel != "" && !input.Channel.IsValid() {
+		return nil, ErrInvalidChannel
+	}
+	if input.ValidTo != nil && input.ValidTo.Before(input.ValidFrom) {
+		return nil, ErrInvalidValidity
+	}
+	if _, err := uc.priceListRepo.GetByCode(ctx, input.Code); err == nil {
+		return nil, ErrPriceListCodeExists
+	}
+
+	currency := input.Currency
+	if currency == "" {
+		currency = "VND"
+	}
+
+	priceList := &entity.PriceList{
+		Code:            input.Code,
+		Name:            input.Name,
+		ListType:        input.ListType,
+		CustomerGroupID: input.CustomerGroupID,
+		CustomerID:      input.CustomerID,
+		Channel:         input.Channel,
+		Currency:        currency,
+		CampaignID:      input.CampaignID,
+		Priority:        input.Priority,
+		ValidFrom:       input.ValidFrom,
+		ValidTo:         input.ValidTo,
+		IsActive:        true,
+		Notes:           input.Notes,
+		CreatedBy:       input.CreatedBy,
+	}
+	for _, item := range input.Items {
+		if err := validateItem(&item); err != nil {
+			return nil, err
+		}
+		priceList.Items = append(priceList.Items, entity.PriceListItem{
+			ProductID:   item.ProductID,
+			ProductCode: item.ProductCode,
+			MinQuantity: item.MinQuantity,
+			UnitPrice:   item.UnitPrice,
+		})
+	}
+
+	if err := uc.priceListRepo.Create(ctx, priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+// validateItem checks a price list item input
+func validateItem(item *PriceListItemInput) error {
+	if item.UnitPrice <= 0 {
+		return ErrInvalidPrice
+	}
+	if item.MinQuantity < 0 {
+		return ErrInvalidMinQuantity
+	}
+	return nil
+}
+
+// GetPriceListUseCase handles getting a price list
+type GetPriceListUseCase struct {
+	priceListRepo repository.PriceListRepository
+}
+
+// NewGetPriceListUseCase creates a new use case
+func NewGetPriceListUseCase(repo repository.PriceListRepository) *GetPriceListUseCase {
+	return &GetPriceListUseCase{priceListRepo: repo}
+}
+
+// Execute gets a price list by ID
+func (uc *GetPriceListUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.PriceList, error) {
+	return uc.priceListRepo.GetByID(ctx, id)
+}
+
+// ListPriceListsUseCase handles listing price lists
+type ListPriceListsUseCase struct {
+	priceListRepo repository.PriceListRepository
+}
+
+// NewListPriceListsUseCase creates a new use case
+func NewListPriceListsUseCase(repo repository.PriceListRepository) *ListPriceListsUseCase {
+	return &ListPriceListsUseCase{priceListRepo: repo}
+}
+
+// Execute lists price lists with filters
+func (uc *ListPriceListsUseCase) Execute(ctx context.Context, filter *repository.PriceListFilter) ([]*entity.PriceList, int64, error) {
+	return uc.priceListRepo.List(ctx, filter)
+}
+
+// AddPriceListItemUseCase handles adding a product price to a price list
+type AddPriceListItemUseCase struct {
+	priceListRepo repository.PriceListRepository
+}
+
+// NewAddPriceListItemUseCase creates a new use case
+func NewAddPriceListItemUseCase(repo repository.PriceListRepository) *AddPriceListItemUseCase {
+	return &AddPriceListItemUseCase{priceListRepo: repo}
+}
+
+// Execute adds a product price or quantity break to a price list
+func (uc *AddPriceListItemUseCase) Execute(ctx context.Context, priceListID uuid.UUID, input *PriceListItemInput) (*entity.PriceListItem, error) {
+	if _, err := uc.priceListRepo.GetByID(ctx, priceListID); err != nil {
+		return nil, ErrPriceListNotFound
+	}
+	if err := validateItem(input); err != nil {
+		return nil, err
+	}
+
+	item := &entity.PriceListItem{
+		PriceListID: priceListID,
+		ProductID:   input.ProductID,
+		ProductCode: input.ProductCode,
+		MinQuantity: input.MinQuantity,
+		UnitPrice:   input.UnitPrice,
+	}
+	if err := uc.priceListRepo.CreateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeactivatePriceListUseCase handles retiring a price list
+type DeactivatePriceListUseCase struct {
+	priceListRepo repository.PriceListRepository
+}
+
+// NewDeactivatePriceListUseCase creates a new use case
+func NewDeactivatePriceListUseCase(repo repository.PriceListRepository) *DeactivatePriceListUseCase {
+	return &DeactivatePriceListUseCase{priceListRepo: repo}
+}
+
+// Execute deactivates a price list so it no longer prices new orders
+func (uc *DeactivatePriceListUseCase) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.PriceList, error) {
+	priceList, err := uc.priceListRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrPriceListNotFound
+	}
+
+	priceList.IsActive = false
+	priceList.UpdatedBy = &userID
+	if err := uc.priceListRepo.Update(ctx, priceList); err != nil {
+		return nil, err
+	}
+	return priceList, nil
+}
+
+// PriceQuery represents the products to price for a customer
+type PriceQuery struct {
+	CustomerID uuid.UUID
+	Channel    entity.SalesChannel
+	Date       time.Time
+	Items      []PriceQueryItem
+}
+
+// PriceQueryItem represents a product and quantity to price
+type PriceQueryItem struct {
+	ProductID uuid.UUID
+	Quantity  float64
+}
+
+// ResolvedPrice represents the price of a product and the rule that produced it
+type ResolvedPrice struct {
+	ProductID     uuid.UUID          `json:"product_id"`
+	Quantity      float64            `json:"quantity"`
+	UnitPrice     float64            `json:"unit_price"`
+	Source        entity.PriceSource `json:"price_source"`
+	PriceListID   *uuid.UUID         `json:"price_list_id"`
+	PriceListCode string             `json:"price_list_code"`
+	CampaignID    *uuid.UUID         `json:"campaign_id,omitempty"`
+	MinQuantity   float64            `json:"min_quantity"`
+}
+
+// ResolvePricesUseCase is the pricing engine used by quotations and orders
+type ResolvePricesUseCase struct {
+	priceListRepo repository.PriceListRepository
+	customerRepo  repository.CustomerRepository
+	products      ProductCatalog
+}
+
+// NewResolvePricesUseCase creates a new use case
+func NewResolvePricesUseCase(priceListRepo repository.PriceListRepository, customerRepo repository.CustomerRepository, products ProductCatalog) *ResolvePricesUseCase {
+	return &ResolvePricesUseCase{
+		priceListRepo: priceListRepo,
+		customerRepo:  customerRepo,
+		products:      products,
+	}
+}
+
+// Execute prices the queried products for a customer
+func (uc *ResolvePricesUseCase) Execute(ctx context.Context, query *PriceQuery) ([]*ResolvedPrice, error) {
+	customer, err := uc.customerRepo.GetByID(ctx, query.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.Resolve(ctx, customer, query.Channel, query.Date, query.Items)
+}
+
+// Resolve prices each item from the price lists that apply to the customer,
+// channel and currency on the date:
+//   - a customer contract price always wins
+//   - otherwise the customer group list, else the standard list, gives the base
+//     price, and a campaign promotion replaces it when lower
+//
+// Within each kind the list with the highest priority wins, and within a list
+// the quantity break with the highest minimum quantity not above the ordered
+// quantity. A product no list prices falls back to the standard price of the
+// product master.
+func (uc *ResolvePricesUseCase) Resolve(ctx context.Context, customer *entity.Customer, channel entity.SalesChannel, date time.Time, items []PriceQueryItem) ([]*ResolvedPrice, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	if channel == "" {
+		channel = entity.SalesChannelDirect
+	}
+	if !channel.IsValid() {
+		return nil, ErrInvalidChannel
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	productIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		productIDs[i] = item.ProductID
+	}
+	lists, err := uc.priceListRepo.GetValidForProducts(ctx, date, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lists are ordered by priority; keep those that apply to this customer
+	var applicable []*entity.PriceList
+	for _, list := range lists {
+		if list.IsValidOn(date) && list.AppliesTo(customer, channel) {
+			applicable = append(applicable, list)
+		}
+	}
+
+	results := make([]*ResolvedPrice, len(items))
+	for i, item := range items {
+		price := resolveItem(applicable, item)
+		if price == nil {
+			if price, err = uc.standardPrice(ctx, customer, item); err != nil {
+				return nil, err
+			}
+		}
+		results[i] = price
+	}
+	return results, nil
+}
+
+// standardPrice prices an item at the standard price of the product master,
+// when set in the customer's currency
+func (uc *ResolvePricesUseCase) standardPrice(ctx context.Context, customer *entity.Customer, item PriceQueryItem) (*ResolvedPrice, error) {
+	product, err := uc.products.GetProduct(ctx, item.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if product.StandardPrice <= 0 || (product.Currency != "" && customer.Currency != "" && product.Currency != customer.Currency) {
+		return nil, fmt.Errorf("%w %s", ErrNoPrice, item.ProductID)
+	}
+	return &ResolvedPrice{
+		ProductID: item.ProductID,
+		Quantity:  item.Quantity,
+		UnitPrice: product.StandardPrice,
+		Source:    entity.PriceSourceProduct,
+	}, nil
+}
+
+// resolveItem applies the precedence rules to one product
+func resolveItem(lists []*entity.PriceList, item PriceQueryItem) *ResolvedPrice {
+	best := make(map[entity.PriceSource]*ResolvedPrice)
+	for _, list := range lists {
+		source := list.Source()
+		if best[source] != nil {
+			continue
+		}
+		if listItem := list.PriceFor(item.ProductID, item.Quantity); listItem != nil {
+			listID := list.ID
+			best[source] = &ResolvedPrice{
+				ProductID:     item.ProductID,
+				Quantity:      item.Quantity,
+				UnitPrice:     listItem.UnitPrice,
+				Source:        source,
+				PriceListID:   &listID,
+				PriceListCode: list.Code,
+				CampaignID:    list.CampaignID,
+				MinQuantity:   listItem.MinQuantity,
+			}
+		}
+	}
+
+	if contract := best[entity.PriceSourceContract]; contract != nil {
+		return contract
+	}
+	base := best[entity.PriceSourceCustomerGroup]
+	if base == nil {
+		base = best[entity.PriceSourceStandard]
+	}
+	if promo := best[entity.PriceSourcePromotion]; promo != nil && (base == nil || promo.UnitPrice < base.UnitPrice) {
+		return promo
+	}
+	return base
+}
@@ -0,0 +1,210 @@
+package pricing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/masterdata"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var orderDate = time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+
+func priceList(listType entity.PriceListType, productID uuid.UUID, prices ...float64) *entity.PriceList {
+	list := &entity.PriceList{
+		ID:        uuid.New(),
+		Code:      "PL-" + string(listType),
+		ListType:  listType,
+		Currency:  "VND",
+		ValidFrom: orderDate.AddDate(0, -1, 0),
+		IsActive:  true,
+	}
+	// Quantity breaks of 0, 10, 20... for each price given
+	for i, price := range prices {
+		list.Items = append(list.Items, entity.PriceListItem{ProductID: productID, MinQuantity: float64(i * 10), UnitPrice: price})
+	}
+	return list
+}
+
+func TestResolvePricesUseCase_Resolve_Precedence(t *testing.T) {
+	customer := &entity.Customer{ID: uuid.New(), Currency: "VND"}
+	groupID := uuid.New()
+	customer.CustomerGroupID = &groupID
+	productID := uuid.New()
+
+	standard := priceList(entity.PriceListTypeStandard, productID, 100000, 90000)
+	group := priceList(entity.PriceListTypeStandard, productID, 95000)
+	group.CustomerGroupID = &groupID
+	promotion := priceList(entity.PriceListTypePromotion, productID, 92000)
+	contract := priceList(entity.PriceListTypeContract, productID, 98000)
+	contract.CustomerID = &customer.ID
+
+	tests := []struct {
+		name     string
+		lists    []*entity.PriceList
+		quantity float64
+		price    float64
+		source   entity.PriceSource
+	}{
+		{"standard list", []*entity.PriceList{standard}, 5, 100000, entity.PriceSourceStandard},
+		{"quantity break", []*entity.PriceList{standard}, 10, 90000, entity.PriceSourceStandard},
+		{"customer group over standard", []*entity.PriceList{standard, group}, 5, 95000, entity.PriceSourceCustomerGroup},
+		{"lower promotion replaces base", []*entity.PriceList{group, promotion}, 5, 92000, entity.PriceSourcePromotion},
+		{"higher promotion is ignored", []*entity.PriceList{standard, promotion}, 10, 90000, entity.PriceSourceStandard},
+		{"contract always wins", []*entity.PriceList{standard, group, promotion, contract}, 5, 98000, entity.PriceSourceContract},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			priceListRepo := new(testmocks.MockPriceListRepository)
+			products := new(testmocks.MockProductCatalog)
+
+			uc := pricing.NewResolvePricesUseCase(priceListRepo, new(testmocks.MockCustomerRepository), products)
+
+			priceListRepo.On("GetValidForProducts", ctx, orderDate, []uuid.UUID{productID}).Return(tt.lists, nil)
+
+			// Act
+			res, err := uc.Resolve(ctx, customer, entity.SalesChannelDirect, orderDate, []pricing.PriceQueryItem{
+				{ProductID: productID, Quantity: tt.quantity},
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.price, res[0].UnitPrice)
+			assert.Equal(t, tt.source, res[0].Source)
+			products.AssertNotCalled(t, "GetProduct", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestResolvePricesUseCase_Resolve_FallsBackToStandardPrice(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	priceListRepo := new(testmocks.MockPriceListRepository)
+	products := new(testmocks.MockProductCatalog)
+
+	uc := pricing.NewResolvePricesUseCase(priceListRepo, new(testmocks.MockCustomerRepository), products)
+
+	customer := &entity.Customer{ID: uuid.New(), Currency: "VND"}
+	listed, unlisted := uuid.New(), uuid.New()
+	priceListRepo.On("GetValidForProducts", ctx, orderDate, []uuid.UUID{listed, unlisted}).
+		Return([]*entity.PriceList{priceList(entity.PriceListTypeStandard, listed, 100000)}, nil)
+	products.On("GetProduct", ctx, unlisted).Return(&masterdata.Product{ID: unlisted, StandardPrice: 150000, Currency: "VND"}, nil)
+
+	// Act
+	res, err := uc.Resolve(ctx, customer, entity.SalesChannelDirect, orderDate, []pricing.PriceQueryItem{
+		{ProductID: listed, Quantity: 1},
+		{ProductID: unlisted, Quantity: 2},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 100000.0, res[0].UnitPrice)
+	assert.Equal(t, entity.PriceSourceStandard, res[0].Source)
+	assert.Equal(t, 150000.0, res[1].UnitPrice)
+	assert.Equal(t, entity.PriceSourceProduct, res[1].Source)
+	assert.Nil(t, res[1].PriceListID)
+	products.AssertNumberOfCalls(t, "GetProduct", 1)
+}
+
+func TestResolvePricesUseCase_Resolve_NoPrice(t *testing.T) {
+	productID := uuid.New()
+
+	tests := []struct {
+		name    string
+		product *masterdata.Product
+	}{
+		{"no standard price", &masterdata.Product{ID: productID, Currency: "VND"}},
+		{"standard price in another currency", &masterdata.Product{ID: productID, StandardPrice: 10, Currency: "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			priceListRepo := new(testmocks.MockPriceListRepository)
+			products := new(testmocks.MockProductCatalog)
+
+			uc := pricing.NewResolvePricesUseCase(priceListRepo, new(testmocks.MockCustomerRepository), products)
+
+			priceListRepo.On("GetValidForProducts", ctx, orderDate, []uuid.UUID{productID}).Return([]*entity.PriceList{}, nil)
+			products.On("GetProduct", ctx, productID).Return(tt.product, nil)
+
+			// Act
+			_, err := uc.Resolve(ctx, &entity.Customer{ID: uuid.New(), Currency: "VND"}, "", orderDate, []pricing.PriceQueryItem{
+				{ProductID: productID, Quantity: 1},
+			})
+
+			// Assert
+			assert.True(t, errors.Is(err, pricing.ErrNoPrice))
+		})
+	}
+}
+
+func TestResolvePricesUseCase_Resolve_CatalogError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	priceListRepo := new(testmocks.MockPriceListRepository)
+	products := new(testmocks.MockProductCatalog)
+
+	uc := pricing.NewResolvePricesUseCase(priceListRepo, new(testmocks.MockCustomerRepository), products)
+
+	productID := uuid.New()
+	unavailable := errors.New("master-data-service request failed")
+	priceListRepo.On("GetValidForProducts", ctx, orderDate, []uuid.UUID{productID}).Return([]*entity.PriceList{}, nil)
+	products.On("GetProduct", ctx, productID).Return(nil, unavailable)
+
+	// Act
+	_, err := uc.Resolve(ctx, &entity.Customer{ID: uuid.New()}, entity.SalesChannelOnline, orderDate, []pricing.PriceQueryItem{
+		{ProductID: productID, Quantity: 1},
+	})
+
+	// Assert
+	assert.Equal(t, unavailable, err)
+}
+
+func TestResolvePricesUseCase_Resolve_InvalidChannel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	priceListRepo := new(testmocks.MockPriceListRepository)
+
+	uc := pricing.NewResolvePricesUseCase(priceListRepo, new(testmocks.MockCustomerRepository), new(testmocks.MockProductCatalog))
+
+	// Act
+	_, err := uc.Resolve(ctx, &entity.Customer{ID: uuid.New()}, "WHOLESALE", orderDate, []pricing.PriceQueryItem{
+		{ProductID: uuid.New(), Quantity: 1},
+	})
+
+	// Assert
+	assert.Equal(t, pricing.ErrInvalidChannel, err)
+	priceListRepo.AssertNotCalled(t, "GetValidForProducts", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreatePriceListUseCase_Execute_InvalidChannel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	priceListRepo := new(testmocks.MockPriceListRepository)
+
+	uc := pricing.NewCreatePriceListUseCase(priceListRepo)
+
+	// Act
+	_, err := uc.Execute(ctx, &pricing.CreatePriceListInput{
+		Code:      "PL-WHOLESALE",
+		Name:      "Wholesale",
+		Channel:   "WHOLESALE",
+		ValidFrom: orderDate,
+	})
+
+	// Assert
+	assert.Equal(t, pricing.ErrInvalidChannel, err)
+	priceListRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
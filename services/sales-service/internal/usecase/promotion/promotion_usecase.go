@@ -0,0 +1,790 @@
+package promotion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrPromotionNotFound    = errors.New("promotion not found")
+	ErrPromotionCodeExists  = errors.New("promotion code already exists")
+	ErrInvalidPromotionType = errors.New("promotion type must be BUY_X_GET_Y, BUNDLE or CART_TIERED")
+	ErrInvalidBuyXGetY      = errors.New("buy X get Y requires a buy product, buy quantity and free quantity")
+	ErrInvalidBundle        = errors.New("bundle requires at least two items and a bundle price")
+	ErrInvalidTiers         = errors.New("cart discount requires tiers with a discount")
+	ErrInvalidValidity      = errors.New("valid_to must not be before valid_from")
+	ErrVoucherCodeExists    = errors.New("voucher code already exists")
+	ErrVoucherNotFound      = errors.New("voucher not found")
+	ErrVoucherNotUsable     = errors.New("voucher is inactive, expired, used up or not valid for this customer")
+	ErrVoucherNotApplicable = errors.New("order does not qualify for the voucher")
+	ErrBudgetExhausted      = errors.New("promotion budget is exhausted")
+)
+
+// CreatePromotionInput represents input for creating a promotion
+type CreatePromotionInput struct {
+	Code            string
+	Name            string
+	PromotionType   entity.PromotionType
+	CampaignID      *uuid.UUID
+	CustomerGroupID *uuid.UUID
+	Channel         entity.SalesChannel
+	ValidFrom       time.Time
+	ValidTo         *time.Time
+	Priority        int
+	RequiresVoucher bool
+	BuyProductID    *uuid.UUID
+	BuyQuantity     float64
+	FreeProductID   *uuid.UUID
+	FreeProductCode string
+	FreeProductName string
+	FreeQuantity    float64
+	MaxApplications int
+	BundlePrice     float64
+	BundleItems     []BundleItemInput
+	Tiers           []TierInput
+	Budget          float64
+	Notes           string
+	CreatedBy       *uuid.UUID
+}
+
+// BundleItemInput represents a product in a bundle set
+type BundleItemInput struct {
+	ProductID   uuid.UUID
+	ProductCode string
+	Quantity    float64
+}
+
+// TierInput represents a cart discount tier
+type TierInput struct {
+	MinAmount       float64
+	DiscountPercent float64
+	DiscountAmount  float64
+}
+
+// CreatePromotionUseCase handles promotion creation
+type CreatePromotionUseCase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewCreatePromotionUseCase creates a new use case
+func NewCreatePromotionUseCase(repo repository.PromotionRepository) *CreatePromotionUseCase {
+	return &CreatePromotionUseCase{promotionRepo: repo}
+}
+
+// Execute creates a promotion rule
+func (uc *CreatePromotionUseCase) Execute(ctx context.Context, input *CreatePromotionInput) (*entity.Promotion, error) {
+	switch input.PromotionType {
+	case entity.PromotionTypeBuyXGetY:
+		if input.BuyProductID == nil || input.BuyQuantity <= 0 || input.FreeQuantity <= 0 {
+			return nil, ErrInvalidBuyXGetY
+		}
+		if input.FreeProductID == nil {
+			input.FreeProductID = input.BuyProductID
+		}
+	case entity.PromotionTypeBundle:
+		if len(input.BundleItems) < 2 || input.BundlePrice <= 0 {
+			return nil, ErrInvalidBundle
+		}
+		for _, item := range input.BundleItems {
+			if item.Quantity <= 0 {
+				return nil, ErrInvalidBundle
+			}
+		}
+	case entity.PromotionTypeCartTiered:
+		if len(input.Tiers) == 0 {
+			return nil, ErrInvalidTiers
+		}
+		for _, tier := range input.Tiers {
+			if tier.DiscountPercent <= 0 && tier.DiscountAmount <= 0 {
+				return nil, ErrInvalidTiers
+			}
+		}
+	default:
+		return nil, ErrInvalidPromotionType
+	}
+	if input.ValidTo != nil && input.ValidTo.Before(input.ValidFrom) {
+		return nil, ErrInvalidValidity
+	}
+	if _, err := uc.promotionRepo.GetByCode(ctx, input.Code); err == nil {
+		return nil, ErrPromotionCodeExists
+	}
+
+	promotion := &entity.Promotion{
+		Code:            input.Code,
+		Name:            input.Name,
+		PromotionType:   input.PromotionType,
+		CampaignID:      input.CampaignID,
+		CustomerGroupID: input.CustomerGroupID,
+		Channel:         input.Channel,
+		ValidFrom:       input.ValidFrom,
+		ValidTo:         input.ValidTo,
+		Priority:        input.Priority,
+		RequiresVoucher: input.RequiresVoucher,
+		IsActive:        true,
+		Budget:          input.Budget,
+		Notes:           input.Notes,
+		CreatedBy:       input.CreatedBy,
+	}
+	switch input.PromotionType {
+	case entity.PromotionTypeBuyXGetY:
+		promotion.BuyProductID = input.BuyProductID
+		promotion.BuyQuantity = input.BuyQuantity
+		promotion.FreeProductID = input.FreeProductID
+		promotion.FreeProductCode = input.FreeProductCode
+		promotion.FreeProductName = input.FreeProductName
+		promotion.FreeQuantity = input.FreeQuantity
+		promotion.MaxApplications = input.MaxApplications
+	case entity.PromotionTypeBundle:
+		promotion.BundlePrice = input.BundlePrice
+		for _, item := range input.BundleItems {
+			promotion.BundleItems = append(promotion.BundleItems, entity.PromotionBundleItem{
+				ProductID:   item.ProductID,
+				ProductCode: item.ProductCode,
+				Quantity:    item.Quantity,
+			})
+		}
+	case entity.PromotionTypeCartTiered:
+		for _, tier := range input.Tiers {
+			promotion.Tiers = append(promotion.Tiers, entity.PromotionTier{
+				MinAmount:       tier.MinAmount,
+				DiscountPercent: tier.DiscountPercent,
+				DiscountAmount:  tier.DiscountAmount,
+			})
+		}
+	}
+
+	if err := uc.promotionRepo.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// GetPromotionUseCase handles getting a promotion
+type GetPromotionUseCase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewGetPromotionUseCase creates a new use case
+func NewGetPromotionUseCase(repo repository.PromotionRepository) *GetPromotionUseCase {
+	return &GetPromotionUseCase{promotionRepo: repo}
+}
+
+// Execute gets a promotion by ID
+func (uc *GetPromotionUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Promotion, error) {
+	return uc.promotionRepo.GetByID(ctx, id)
+}
+
+// ListPromotionsUseCase handles listing promotions
+type ListPromotionsUseCase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewListPromotionsUseCase creates a new use case
+func NewListPromotionsUseCase(repo repository.PromotionRepository) *ListPromotionsUseCase {
+	return &ListPromotionsUseCase{promotionRepo: repo}
+}
+
+// Execute lists promotions with filters
+func (uc *ListPromotionsUseCase) Execute(ctx context.Context, filter *repository.PromotionFilter) ([]*entity.Promotion, int64, error) {
+	return uc.promotionRepo.List(ctx, filter)
+}
+
+// DeactivatePromotionUseCase handles ending a promotion early
+type DeactivatePromotionUseCase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewDeactivatePromotionUseCase creates a new use case
+func NewDeactivatePromotionUseCase(repo repository.PromotionRepository) *DeactivatePromotionUseCase {
+	return &DeactivatePromotionUseCase{promotionRepo: repo}
+}
+
+// Execute deactivates a promotion so new orders no longer get it
+func (uc *DeactivatePromotionUseCase) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Promotion, error) {
+	promotion, err := uc.promotionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrPromotionNotFound
+	}
+
+	promotion.IsActive = false
+	promotion.UpdatedBy = &userID
+	if err := uc.promotionRepo.Update(ctx, promotion); err != nil {
+		return nil, err
+	}
+	return promotion, nil
+}
+
+// CreateVoucherInput represents input for creating a voucher
+type CreateVoucherInput struct {
+	PromotionID uuid.UUID
+	Code        string
+	CustomerID  *uuid.UUID
+	MaxUses     int
+	ValidTo     *time.Time
+	CreatedBy   *uuid.UUID
+}
+
+// CreateVoucherUseCase handles voucher creation
+type CreateVoucherUseCase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewCreateVoucherUseCase creates a new use case
+func NewCreateVoucherUseCase(repo repository.PromotionRepository) *CreateVoucherUseCase {
+	return &CreateVoucherUseCase{promotionRepo: repo}
+}
+
+// Execute creates a voucher code for a promotion
+func (uc *CreateVoucherUseCase) Execute(ctx context.Context, input *CreateVoucherInput) (*entity.Voucher, error) {
+	if _, err := uc.promotionRepo.GetByID(ctx, input.PromotionID); err != nil {
+		return nil, ErrPromotionNotFound
+	}
+	if _, err := uc.promotionRepo.GetVoucherByCode(ctx, input.Code); err == nil {
+		return nil, ErrVoucherCodeExists
+	}
+
+	voucher := &entity.Voucher{
+		Code:        input.Code,
+		PromotionID: input.PromotionID,
+		CustomerID:  input.CustomerID,
+		MaxUses:     input.MaxUses,
+		ValidTo:     input.ValidTo,
+		IsActive:    true,
+		CreatedBy:   input.CreatedBy,
+	}
+	if err := uc.promotionRepo.CreateVoucher(ctx, voucher); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+// ListVouchersUseCase handles listing the vouchers of a promotion
+type ListVouchersUseCase struct {
+	promotionRepo repository.PromotionRepository
+}
+
+// NewListVouchersUseCase creates a new use case
+func NewListVouchersUseCase(repo repository.PromotionRepository) *ListVouchersUseCase {
+	return &ListVouchersUseCase{promotionRepo: repo}
+}
+
+// Execute lists the vouchers of a promotion
+func (uc *ListVouchersUseCase) Execute(ctx context.Context, promotionID uuid.UUID) ([]*entity.Voucher, error) {
+	return uc.promotionRepo.GetVouchers(ctx, promotionID)
+}
+
+// ApplyPromotionsUseCase is the promotion engine run on order creation
+type ApplyPromotionsUseCase struct {
+	promotionRepo repository.PromotionRepository
+	pricing       *pricing.ResolvePricesUseCase
+	eventPub      *event.Publisher
+}
+
+// NewApplyPromotionsUseCase creates a new use case
+func NewApplyPromotionsUseCase(
+	promotionRepo repository.PromotionRepository,
+	pricing *pricing.ResolvePricesUseCase,
+	eventPub *event.Publisher,
+) *ApplyPromotionsUseCase {
+	return &ApplyPromotionsUseCase{
+		promotionRepo: promotionRepo,
+		pricing:       pricing,
+		eventPub:      eventPub,
+	}
+}
+
+// candidate is a promotion considered for an order, unlocked by a voucher or not
+type candidate struct {
+	promotion *entity.Promotion
+	voucher   *entity.Voucher
+}
+
+// Apply evaluates the promotions on a new, priced order and records them in
+// order.Promotions:
+//   - bundles discount the bundled lines down to the bundle price
+//   - buy X get Y adds free goods lines, which WMS reserves with the order
+//   - the best automatic cart tier, then every voucher cart tier, go to the
+//     order's promotion discount
+//
+// Promotions without budget left are skipped; a voucher the order does not
+// qualify for is an error.
+func (uc *ApplyPromotionsUseCase) Apply(ctx context.Context, customer *entity.Customer, order *entity.SalesOrder, voucherCodes []string) error {
+	candidates, err := uc.candidates(ctx, customer, order, voucherCodes, true)
+	if err != nil {
+		return err
+	}
+
+	applied, unused, err := uc.apply(ctx, customer, order, candidates)
+	if err != nil {
+		return err
+	}
+	if len(unused) > 0 {
+		return fmt.Errorf("%w: %s", ErrVoucherNotApplicable, unused[0].Code)
+	}
+
+	order.Promotions = applied
+	return nil
+}
+
+// Reapply evaluates the promotions of an amended order again: free goods are
+// earned on the new quantities and cart tiers on the new total, with the
+// vouchers the order was placed with. A voucher the order no longer
+// qualifies for is given back. Bundle discounts are part of the line prices
+// and stay as given.
+//
+// The free goods lines of the order are replaced by the ones earned, without
+// IDs, for the caller to match. The budgets and voucher uses booked by the
+// order are released and the new promotions booked, so run it in the
+// transaction that amends the order and publish both once committed.
+func (uc *ApplyPromotionsUseCase) Reapply(ctx context.Context, customer *entity.Customer, order *entity.SalesOrder) ([]entity.OrderPromotion, []entity.OrderPromotion, error) {
+	promotions, err := uc.promotionRepo.GetOrderPromotions(ctx, order.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var kept, released []entity.OrderPromotion
+	var voucherCodes []string
+	for _, op := range promotions {
+		if op.ReleasedAt != nil || op.PromotionType == entity.PromotionTypeBundle {
+			kept = append(kept, *op)
+			continue
+		}
+		if err := uc.release(ctx, op); err != nil {
+			return nil, nil, err
+		}
+		if err := uc.promotionRepo.MarkOrderPromotionReleased(ctx, op.ID); err != nil {
+			return nil, nil, err
+		}
+		now := time.Now()
+		op.ReleasedAt = &now
+		kept = append(kept, *op)
+		released = append(released, *op)
+		if op.VoucherCode != "" {
+			voucherCodes = append(voucherCodes, op.VoucherCode)
+		}
+	}
+
+	lines := order.LineItems[:0:0]
+	for _, li := range order.LineItems {
+		if !li.IsFreeGoods() {
+			lines = append(lines, li)
+		}
+	}
+	order.LineItems = lines
+	order.PromotionDiscount = 0
+
+	// Budgets are read after the release, so the order competes for what it gave back
+	candidates, err := uc.candidates(ctx, customer, order, voucherCodes, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	unbundled := candidates[:0]
+	for _, c := range candidates {
+		if c.promotion.PromotionType != entity.PromotionTypeBundle {
+			unbundled = append(unbundled, c)
+		}
+	}
+
+	applied, _, err := uc.apply(ctx, customer, order, unbundled)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := uc.book(ctx, applied); err != nil {
+		return nil, nil, err
+	}
+
+	order.Promotions = append(kept, applied...)
+	return released, applied, nil
+}
+
+// apply evaluates the candidates on the order and returns the promotions
+// applied and the vouchers the order does not qualify for
+func (uc *ApplyPromotionsUseCase) apply(ctx context.Context, customer *entity.Customer, order *entity.SalesOrder, candidates []*candidate) ([]entity.OrderPromotion, []*entity.Voucher, error) {
+	var applied []entity.OrderPromotion
+	used := make(map[*entity.Voucher]bool)
+	record := func(c *candidate, cost float64, description string) {
+		c.promotion.UsedBudget += cost
+		op := entity.OrderPromotion{
+			PromotionID:   c.promotion.ID,
+			PromotionCode: c.promotion.Code,
+			PromotionType: c.promotion.PromotionType,
+			CampaignID:    c.promotion.CampaignID,
+			Description:   description,
+			Cost:          cost,
+		}
+		if c.voucher != nil {
+			op.VoucherID = &c.voucher.ID
+			op.VoucherCode = c.voucher.Code
+			used[c.voucher] = true
+		}
+		applied = append(applied, op)
+	}
+
+	// Line promotions first so cart tiers see the discounted lines
+	bundled := make(map[uuid.UUID]float64)
+	for _, c := range candidates {
+		if c.promotion.PromotionType != entity.PromotionTypeBundle {
+			continue
+		}
+		cost, description := applyBundle(order, c.promotion, bundled)
+		if cost > 0 {
+			record(c, cost, description)
+		}
+	}
+	for _, c := range candidates {
+		if c.promotion.PromotionType != entity.PromotionTypeBuyXGetY {
+			continue
+		}
+		cost, description, err := uc.applyBuyXGetY(ctx, customer, order, c.promotion)
+		if err != nil {
+			return nil, nil, err
+		}
+		if description != "" {
+			record(c, cost, description)
+		}
+	}
+
+	order.CalculateTotals()
+	base := order.Subtotal - order.DiscountAmount
+	var best *candidate
+	var bestTier *entity.PromotionTier
+	bestDiscount := 0.0
+	for _, c := range candidates {
+		if c.promotion.PromotionType != entity.PromotionTypeCartTiered || c.voucher != nil {
+			continue
+		}
+		if tier := c.promotion.TierFor(base); tier != nil {
+			discount := tier.DiscountOn(base)
+			if discount > bestDiscount && c.promotion.HasBudgetFor(discount) {
+				best, bestTier, bestDiscount = c, tier, discount
+			}
+		}
+	}
+	if best != nil {
+		order.PromotionDiscount += bestDiscount
+		base -= bestDiscount
+		record(best, bestDiscount, tierDescription(bestTier))
+	}
+	for _, c := range candidates {
+		if c.promotion.PromotionType != entity.PromotionTypeCartTiered || c.voucher == nil {
+			continue
+		}
+		tier := c.promotion.TierFor(base)
+		if tier == nil {
+			continue
+		}
+		discount := tier.DiscountOn(base)
+		if !c.promotion.HasBudgetFor(discount) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrBudgetExhausted, c.promotion.Code)
+		}
+		order.PromotionDiscount += discount
+		base -= discount
+		record(c, discount, tierDescription(tier))
+	}
+
+	var unused []*entity.Voucher
+	for _, c := range candidates {
+		if c.voucher != nil && !used[c.voucher] {
+			unused = append(unused, c.voucher)
+		}
+	}
+	return applied, unused, nil
+}
+
+// candidates loads the automatic promotions open to the order and the
+// promotions unlocked by its vouchers, highest priority first. Unless strict,
+// vouchers that cannot be used are left out instead of failing.
+func (uc *ApplyPromotionsUseCase) candidates(ctx context.Context, customer *entity.Customer, order *entity.SalesOrder, voucherCodes []string, strict bool) ([]*candidate, error) {
+	promotions, err := uc.promotionRepo.GetAutomatic(ctx, order.SODate)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*candidate
+	seen := make(map[uuid.UUID]bool)
+	for _, p := range promotions {
+		if p.IsValidOn(order.SODate) && p.AppliesTo(customer, order.Channel) {
+			candidates = append(candidates, &candidate{promotion: p})
+			seen[p.ID] = true
+		}
+	}
+
+	for _, code := range voucherCodes {
+		voucher, err := uc.promotionRepo.GetVoucherByCode(ctx, code)
+		if err != nil {
+			if !strict {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s", ErrVoucherNotFound, code)
+		}
+		if !voucher.CanBeUsedBy(customer.ID, order.SODate) {
+			if !strict {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s", ErrVoucherNotUsable, code)
+		}
+		p := voucher.Promotion
+		if p == nil || seen[p.ID] || !p.IsValidOn(order.SODate) || !p.AppliesTo(customer, order.Channel) {
+			if !strict {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s", ErrVoucherNotApplicable, code)
+		}
+		candidates = append(candidates, &candidate{promotion: p, voucher: voucher})
+		seen[p.ID] = true
+	}
+	return candidates, nil
+}
+
+// paidLine returns the first paid line of a product and the paid quantity of
+// the product on the order
+func paidLine(order *entity.SalesOrder, productID uuid.UUID) (*entity.SOLineItem, float64) {
+	var first *entity.SOLineItem
+	quantity := 0.0
+	for i := range order.LineItems {
+		li := &order.LineItems[i]
+		if li.ProductID != productID || li.IsFreeGoods() {
+			continue
+		}
+		if first == nil {
+			first = li
+		}
+		quantity += li.Quantity
+	}
+	return first, quantity
+}
+
+// applyBundle discounts as many complete bundle sets as the order holds, not
+// counting quantities already used by other bundles. The discount is spread
+// over the bundled lines by their share of the set value.
+func applyBundle(order *entity.SalesOrder, p *entity.Promotion, bundled map[uuid.UUID]float64) (float64, string) {
+	sets := -1.0
+	setValue := 0.0
+	lines := make([]*entity.SOLineItem, len(p.BundleItems))
+	for i, item := range p.BundleItems {
+		line, quantity := paidLine(order, item.ProductID)
+		if line == nil {
+			return 0, ""
+		}
+		n := math.Floor((quantity-bundled[item.ProductID])/item.Quantity + 1e-9)
+		if sets < 0 || n < sets {
+			sets = n
+		}
+		lines[i] = line
+		setValue += line.UnitPrice * (1 - line.DiscountPercent/100) * item.Quantity
+	}
+	if sets <= 0 || setValue <= p.BundlePrice {
+		return 0, ""
+	}
+
+	cost := sets * (setValue - p.BundlePrice)
+	if !p.HasBudgetFor(cost) {
+		return 0, ""
+	}
+	for i, item := range p.BundleItems {
+		line := lines[i]
+		unit := line.UnitPrice * (1 - line.DiscountPercent/100)
+		share := cost * unit * item.Quantity / setValue
+		line.DiscountAmount = line.Quantity*line.UnitPrice*line.DiscountPercent/100 + share
+		line.DiscountPercent = 0
+		line.PromotionID = &p.ID
+		line.CalculateLineTotal()
+		bundled[item.ProductID] += sets * item.Quantity
+	}
+	return cost, fmt.Sprintf("%g x %s at %.0f", sets, p.Name, p.BundlePrice)
+}
+
+// applyBuyXGetY adds the free goods earned by the paid quantity of the buy
+// product. Free goods are valued at the order price of the product, else the
+// price list price, to cost the promotion.
+func (uc *ApplyPromotionsUseCase) applyBuyXGetY(ctx context.Context, customer *entity.Customer, order *entity.SalesOrder, p *entity.Promotion) (float64, string, error) {
+	if p.BuyProductID == nil || p.FreeProductID == nil || p.BuyQuantity <= 0 {
+		return 0, "", nil
+	}
+	buyLine, bought := paidLine(order, *p.BuyProductID)
+	applications := int(math.Floor(bought/p.BuyQuantity + 1e-9))
+	if p.MaxApplications > 0 && applications > p.MaxApplications {
+		applications = p.MaxApplications
+	}
+	if buyLine == nil || applications == 0 {
+		return 0, "", nil
+	}
+	quantity := float64(applications) * p.FreeQuantity
+
+	line := entity.SOLineItem{
+		LineNumber:      len(order.LineItems) + 1,
+		ProductID:       *p.FreeProductID,
+		ProductCode:     p.FreeProductCode,
+		ProductName:     p.FreeProductName,
+		Quantity:        quantity,
+		PriceSource:     entity.PriceSourcePromotion,
+		DiscountPercent: 100,
+		TaxPercent:      0,
+		PromotionID:     &p.ID,
+		Notes:           "Free goods - " + p.Code,
+	}
+	if freeLine, _ := paidLine(order, *p.FreeProductID); freeLine != nil {
+		line.UnitPrice = freeLine.UnitPrice
+		line.UomID = freeLine.UomID
+		if line.ProductCode == "" {
+			line.ProductCode = freeLine.ProductCode
+			line.ProductName = freeLine.ProductName
+		}
+	} else {
+		prices, err := uc.pricing.Resolve(ctx, customer, order.Channel, order.SODate, []pricing.PriceQueryItem{
+			{ProductID: *p.FreeProductID, Quantity: quantity},
+		})
+		if err != nil && !errors.Is(err, pricing.ErrNoPrice) {
+			return 0, "", err
+		}
+		if len(prices) > 0 {
+			line.UnitPrice = prices[0].UnitPrice
+			line.PriceListID = prices[0].PriceListID
+		}
+	}
+
+	cost := quantity * line.UnitPrice
+	if !p.HasBudgetFor(cost) {
+		return 0, "", nil
+	}
+	line.CalculateLineTotal()
+	order.LineItems = append(order.LineItems, line)
+	return cost, fmt.Sprintf("%g free %s", quantity, line.ProductCode), nil
+}
+
+// tierDescription describes a cart discount tier
+func tierDescription(tier *entity.PromotionTier) string {
+	if tier.DiscountPercent > 0 && tier.DiscountAmount > 0 {
+		return fmt.Sprintf("%g%% + %.0f off orders from %.0f", tier.DiscountPercent, tier.DiscountAmount, tier.MinAmount)
+	}
+	if tier.DiscountPercent > 0 {
+		return fmt.Sprintf("%g%% off orders from %.0f", tier.DiscountPercent, tier.MinAmount)
+	}
+	return fmt.Sprintf("%.0f off orders from %.0f", tier.DiscountAmount, tier.MinAmount)
+}
+
+// RecordUsage books the promotions of a created order against their budgets
+// and vouchers. It fails if another order used up a budget or voucher since
+// the promotions were applied; run it in the transaction that creates the
+// order so the order is not kept without its promotions booked.
+func (uc *ApplyPromotionsUseCase) RecordUsage(ctx context.Context, order *entity.SalesOrder) error {
+	return uc.book(ctx, order.Promotions)
+}
+
+// book adds the cost and voucher use of each promotion to its budget
+func (uc *ApplyPromotionsUseCase) book(ctx context.Context, promotions []entity.OrderPromotion) error {
+	for _, op := range promotions {
+		booked, err := uc.promotionRepo.AddUsage(ctx, op.PromotionID, op.Cost, 1)
+		if err != nil {
+			return err
+		}
+		if !booked {
+			return fmt.Errorf("%w: %s", ErrBudgetExhausted, op.PromotionCode)
+		}
+		if op.VoucherID != nil {
+			booked, err := uc.promotionRepo.AddVoucherUse(ctx, *op.VoucherID, 1)
+			if err != nil {
+				return err
+			}
+			if !booked {
+				return fmt.Errorf("%w: %s", ErrVoucherNotUsable, op.VoucherCode)
+			}
+		}
+	}
+	return nil
+}
+
+// PublishUsage reports the promotions of a created order to marketing, once
+// their usage is committed
+func (uc *ApplyPromotionsUseCase) PublishUsage(order *entity.SalesOrder) {
+	uc.PublishApplied(order, order.Promotions)
+}
+
+// PublishApplied reports promotions applied to an order to marketing
+func (uc *ApplyPromotionsUseCase) PublishApplied(order *entity.SalesOrder, applied []entity.OrderPromotion) {
+	uc.publish(order, applied, uc.eventPub.PublishPromotionApplied)
+}
+
+// Release gives the budget and voucher uses of a cancelled order back and
+// returns the promotions released. Run it in the transaction that cancels the
+// order and publish the release once committed.
+func (uc *ApplyPromotionsUseCase) Release(ctx context.Context, order *entity.SalesOrder) ([]entity.OrderPromotion, error) {
+	promotions, err := uc.promotionRepo.GetOrderPromotions(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var released []entity.OrderPromotion
+	for _, op := range promotions {
+		if op.ReleasedAt != nil {
+			continue
+		}
+		if err := uc.release(ctx, op); err != nil {
+			return nil, err
+		}
+		released = append(released, *op)
+	}
+	if len(released) == 0 {
+		return nil, nil
+	}
+	if err := uc.promotionRepo.MarkOrderPromotionsReleased(ctx, order.ID); err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// release gives the cost and voucher use of a promotion back to its budget
+func (uc *ApplyPromotionsUseCase) release(ctx context.Context, op *entity.OrderPromotion) error {
+	if _, err := uc.promotionRepo.AddUsage(ctx, op.PromotionID, -op.Cost, -1); err != nil {
+		return err
+	}
+	if op.VoucherID != nil {
+		if _, err := uc.promotionRepo.AddVoucherUse(ctx, *op.VoucherID, -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PublishRelease reports the promotions released from an order to marketing
+func (uc *ApplyPromotionsUseCase) PublishRelease(order *entity.SalesOrder, released []entity.OrderPromotion) {
+	uc.publish(order, released, uc.eventPub.PublishPromotionReleased)
+}
+
+// publish sends one usage event per promotion. The order amount is attributed
+// once per campaign so an order with several promotions of a campaign counts
+// as one conversion.
+func (uc *ApplyPromotionsUseCase) publish(order *entity.SalesOrder, promotions []entity.OrderPromotion, send func(*event.PromotionUsageEvent)) {
+	if uc.eventPub == nil {
+		return
+	}
+	attributed := make(map[uuid.UUID]bool)
+	for _, op := range promotions {
+		usage := &event.PromotionUsageEvent{
+			SOID:          order.ID.String(),
+			SONumber:      order.SONumber,
+			CustomerID:    order.CustomerID.String(),
+			PromotionID:   op.PromotionID.String(),
+			PromotionCode: op.PromotionCode,
+			VoucherCode:   op.VoucherCode,
+			Cost:          op.Cost,
+		}
+		if op.CampaignID != nil {
+			usage.CampaignID = op.CampaignID.String()
+			if !attributed[*op.CampaignID] {
+				usage.OrderAmount = order.TotalAmount
+				attributed[*op.CampaignID] = true
+			}
+		}
+		send(usage)
+	}
+}
@@ -0,0 +1,286 @@
+package promotion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/promotion"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newApplyPromotionsUseCase(promotionRepo *testmocks.MockPromotionRepository) *promotion.ApplyPromotionsUseCase {
+	resolver := pricing.NewResolvePricesUseCase(new(testmocks.MockPriceListRepository), new(testmocks.MockCustomerRepository), new(testmocks.MockProductCatalog))
+	return promotion.NewApplyPromotionsUseCase(promotionRepo, resolver, nil)
+}
+
+func voucherOrder() *entity.SalesOrder {
+	voucherID := uuid.New()
+	return &entity.SalesOrder{
+		ID: uuid.New(),
+		Promotions: []entity.OrderPromotion{
+			{PromotionID: uuid.New(), PromotionCode: "SUMMER-10", Cost: 50000, VoucherID: &voucherID, VoucherCode: "VIP-001"},
+		},
+	}
+}
+
+func TestApplyPromotionsUseCase_RecordUsage_BooksBudgetAndVoucher(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	promotionRepo := new(testmocks.MockPromotionRepository)
+	uc := newApplyPromotionsUseCase(promotionRepo)
+
+	order := voucherOrder()
+	op := order.Promotions[0]
+	promotionRepo.On("AddUsage", ctx, op.PromotionID, 50000.0, 1).Return(true, nil)
+	promotionRepo.On("AddVoucherUse", ctx, *op.VoucherID, 1).Return(true, nil)
+
+	// Act
+	err := uc.RecordUsage(ctx, order)
+
+	// Assert
+	assert.NoError(t, err)
+	promotionRepo.AssertExpectations(t)
+}
+
+func TestApplyPromotionsUseCase_RecordUsage_UsedUpSinceApplied(t *testing.T) {
+	tests := []struct {
+		name          string
+		budgetBooked  bool
+		voucherBooked bool
+		wantErr       error
+	}{
+		{"budget spent by another order", false, true, promotion.ErrBudgetExhausted},
+		{"voucher redeemed by another order", true, false, promotion.ErrVoucherNotUsable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			promotionRepo := new(testmocks.MockPromotionRepository)
+			uc := newApplyPromotionsUseCase(promotionRepo)
+
+			order := voucherOrder()
+			op := order.Promotions[0]
+			promotionRepo.On("AddUsage", ctx, op.PromotionID, op.Cost, 1).Return(tt.budgetBooked, nil)
+			promotionRepo.On("AddVoucherUse", ctx, *op.VoucherID, 1).Return(tt.voucherBooked, nil).Maybe()
+
+			// Act
+			err := uc.RecordUsage(ctx, order)
+
+			// Assert
+			assert.True(t, errors.Is(err, tt.wantErr))
+		})
+	}
+}
+
+func TestApplyPromotionsUseCase_Apply_VoucherLimits(t *testing.T) {
+	date := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	customerID := uuid.New()
+
+	tests := []struct {
+		name      string
+		maxUses   int
+		usedCount int
+		owner     *uuid.UUID
+		wantErr   error
+	}{
+		{"single use left", 1, 0, nil, nil},
+		{"unlimited", 0, 40, nil, nil},
+		{"used up", 3, 3, nil, promotion.ErrVoucherNotUsable},
+		{"other customer", 1, 0, func() *uuid.UUID { id := uuid.New(); return &id }(), promotion.ErrVoucherNotUsable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			promotionRepo := new(testmocks.MockPromotionRepository)
+			uc := newApplyPromotionsUseCase(promotionRepo)
+
+			promo := &entity.Promotion{
+				ID:              uuid.New(),
+				Code:            "VIP-TIER",
+				PromotionType:   entity.PromotionTypeCartTiered,
+				ValidFrom:       date.AddDate(0, -1, 0),
+				RequiresVoucher: true,
+				IsActive:        true,
+				Tiers:           []entity.PromotionTier{{MinAmount: 0, DiscountPercent: 10}},
+			}
+			voucher := &entity.Voucher{
+				ID:          uuid.New(),
+				Code:        "VIP-001",
+				PromotionID: promo.ID,
+				Promotion:   promo,
+				CustomerID:  tt.owner,
+				MaxUses:     tt.maxUses,
+				UsedCount:   tt.usedCount,
+				IsActive:    true,
+			}
+			promotionRepo.On("GetAutomatic", ctx, date).Return([]*entity.Promotion{}, nil)
+			promotionRepo.On("GetVoucherByCode", ctx, "VIP-001").Return(voucher, nil)
+
+			order := &entity.SalesOrder{
+				CustomerID: customerID,
+				SODate:     date,
+				Channel:    entity.SalesChannelDirect,
+				LineItems:  []entity.SOLineItem{{ProductID: uuid.New(), Quantity: 10, UnitPrice: 100000}},
+			}
+			order.LineItems[0].CalculateLineTotal()
+
+			// Act
+			err := uc.Apply(ctx, &entity.Customer{ID: customerID}, order, []string{"VIP-001"})
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, order.Promotions, 1)
+			assert.Equal(t, voucher.ID, *order.Promotions[0].VoucherID)
+			assert.Equal(t, 100000.0, order.Promotions[0].Cost)
+		})
+	}
+}
+
+func TestApplyPromotionsUseCase_Release_GivesUsesBack(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	promotionRepo := new(testmocks.MockPromotionRepository)
+	uc := newApplyPromotionsUseCase(promotionRepo)
+
+	order := voucherOrder()
+	op := order.Promotions[0]
+	promotionRepo.On("GetOrderPromotions", ctx, order.ID).Return([]*entity.OrderPromotion{&op}, nil)
+	promotionRepo.On("AddUsage", ctx, op.PromotionID, -op.Cost, -1).Return(true, nil)
+	promotionRepo.On("AddVoucherUse", ctx, *op.VoucherID, -1).Return(true, nil)
+	promotionRepo.On("MarkOrderPromotionsReleased", ctx, order.ID).Return(nil)
+
+	// Act
+	released, err := uc.Release(ctx, order)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, released, 1)
+	promotionRepo.AssertExpectations(t)
+}
+
+func TestApplyPromotionsUseCase_Reapply_EarnsFreeGoodsAgain(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	promotionRepo := new(testmocks.MockPromotionRepository)
+	uc := newApplyPromotionsUseCase(promotionRepo)
+
+	date := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	productID := uuid.New()
+	promo := &entity.Promotion{
+		ID:            uuid.New(),
+		Code:          "BUY2GET1",
+		PromotionType: entity.PromotionTypeBuyXGetY,
+		ValidFrom:     date.AddDate(0, -1, 0),
+		IsActive:      true,
+		BuyProductID:  &productID,
+		BuyQuantity:   2,
+		FreeProductID: &productID,
+		FreeQuantity:  1,
+		Budget:        1000000,
+		UsedBudget:    200000, // After the order gave its 500,000 back
+	}
+	order := &entity.SalesOrder{
+		ID:      uuid.New(),
+		SODate:  date,
+		Channel: entity.SalesChannelDirect,
+		LineItems: []entity.SOLineItem{
+			{ID: uuid.New(), ProductID: productID, Quantity: 6, UnitPrice: 100000}, // Reduced from 10
+			{ID: uuid.New(), ProductID: productID, Quantity: 5, UnitPrice: 100000, DiscountPercent: 100, PromotionID: &promo.ID},
+		},
+	}
+	freeGoods := &entity.OrderPromotion{ID: uuid.New(), PromotionID: promo.ID, PromotionType: entity.PromotionTypeBuyXGetY, Cost: 500000}
+	bundle := &entity.OrderPromotion{ID: uuid.New(), PromotionID: uuid.New(), PromotionType: entity.PromotionTypeBundle, Cost: 80000}
+
+	promotionRepo.On("GetOrderPromotions", ctx, order.ID).Return([]*entity.OrderPromotion{freeGoods, bundle}, nil)
+	promotionRepo.On("AddUsage", ctx, promo.ID, -500000.0, -1).Return(true, nil)
+	promotionRepo.On("MarkOrderPromotionReleased", ctx, freeGoods.ID).Return(nil)
+	promotionRepo.On("GetAutomatic", ctx, date).Return([]*entity.Promotion{promo}, nil)
+	promotionRepo.On("AddUsage", ctx, promo.ID, 300000.0, 1).Return(true, nil)
+
+	// Act
+	released, applied, err := uc.Reapply(ctx, &entity.Customer{ID: uuid.New()}, order)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, released, 1)
+	assert.Equal(t, freeGoods.ID, released[0].ID)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, 300000.0, applied[0].Cost)
+
+	// The earned free goods replace the old line, for the caller to match
+	assert.Len(t, order.LineItems, 2)
+	assert.Equal(t, uuid.Nil, order.LineItems[1].ID)
+	assert.Equal(t, 3.0, order.LineItems[1].Quantity)
+
+	// The bundle stays booked
+	assert.Len(t, order.Promotions, 3)
+	promotionRepo.AssertExpectations(t)
+}
+
+func TestApplyPromotionsUseCase_Reapply_VoucherNoLongerQualifies(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	promotionRepo := new(testmocks.MockPromotionRepository)
+	uc := newApplyPromotionsUseCase(promotionRepo)
+
+	date := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	customerID := uuid.New()
+	promo := &entity.Promotion{
+		ID:              uuid.New(),
+		Code:            "VIP-TIER",
+		PromotionType:   entity.PromotionTypeCartTiered,
+		ValidFrom:       date.AddDate(0, -1, 0),
+		RequiresVoucher: true,
+		IsActive:        true,
+		Tiers:           []entity.PromotionTier{{MinAmount: 1000000, DiscountPercent: 10}},
+	}
+	voucher := &entity.Voucher{ID: uuid.New(), Code: "VIP-001", PromotionID: promo.ID, Promotion: promo, MaxUses: 1, IsActive: true}
+	order := &entity.SalesOrder{
+		ID:                uuid.New(),
+		CustomerID:        customerID,
+		SODate:            date,
+		Channel:           entity.SalesChannelDirect,
+		LineItems:         []entity.SOLineItem{{ID: uuid.New(), ProductID: uuid.New(), Quantity: 6, UnitPrice: 100000}},
+		PromotionDiscount: 100000,
+	}
+	order.LineItems[0].CalculateLineTotal()
+	booked := &entity.OrderPromotion{
+		ID:            uuid.New(),
+		PromotionID:   promo.ID,
+		PromotionType: entity.PromotionTypeCartTiered,
+		VoucherID:     &voucher.ID,
+		VoucherCode:   voucher.Code,
+		Cost:          100000,
+	}
+
+	promotionRepo.On("GetOrderPromotions", ctx, order.ID).Return([]*entity.OrderPromotion{booked}, nil)
+	promotionRepo.On("AddUsage", ctx, promo.ID, -100000.0, -1).Return(true, nil)
+	promotionRepo.On("AddVoucherUse", ctx, voucher.ID, -1).Return(true, nil)
+	promotionRepo.On("MarkOrderPromotionReleased", ctx, booked.ID).Return(nil)
+	promotionRepo.On("GetAutomatic", ctx, date).Return([]*entity.Promotion{}, nil)
+	promotionRepo.On("GetVoucherByCode", ctx, "VIP-001").Return(voucher, nil)
+
+	// Act
+	released, applied, err := uc.Reapply(ctx, &entity.Customer{ID: customerID}, order)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, released, 1)
+	assert.Empty(t, applied)
+	assert.Equal(t, 0.0, order.PromotionDiscount)
+	promotionRepo.AssertExpectations(t)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
 	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
 	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/availability"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
 	"github.com/google/uuid"
 )
 
@@ -23,6 +25,7 @@ type CreateQuotationInput struct {
 	CustomerID         uuid.UUID
 	QuotationDate      time.Time
 	ValidUntil         time.Time
+	Channel            entity.SalesChannel
 	DiscountPercent    float64
 	DiscountAmount     float64
 	TaxPercent         float64
@@ -39,7 +42,7 @@ type QuotationItemInput struct {
 	ProductName     string
 	Quantity        float64
 	UomID           *uuid.UUID
-	UnitPrice       float64
+	UnitPrice       *float64 // Nil resolves the price from the price lists
 	DiscountPercent float64
 	TaxPercent      float64
 	Notes           string
@@ -49,20 +52,44 @@ type QuotationItemInput struct {
 type CreateQuotationUseCase struct {
 	quotationRepo repository.QuotationRepository
 	customerRepo  repository.CustomerRepository
+	pricing       *pricing.ResolvePricesUseCase
+	promises      *availability.PromiseDatesUseCase
 }
 
 // NewCreateQuotationUseCase creates a new use case
-func NewCreateQuotationUseCase(quotationRepo repository.QuotationRepository, customerRepo repository.CustomerRepository) *CreateQuotationUseCase {
+func NewCreateQuotationUseCase(quotationRepo repository.QuotationRepository, customerRepo repository.CustomerRepository, pricing *pricing.ResolvePricesUseCase, promises *availability.PromiseDatesUseCase) *CreateQuotationUseCase {
 	return &CreateQuotationUseCase{
 		quotationRepo: quotationRepo,
 		customerRepo:  customerRepo,
+		pricing:       pricing,
+		promises:      promises,
 	}
 }
 
 // Execute creates a new quotation
 func (uc *CreateQuotationUseCase) Execute(ctx context.Context, input *CreateQuotationInput) (*entity.Quotation, error) {
 	// Verify customer exists
-	_, err := uc.customerRepo.GetByID(ctx, input.CustomerID)
+	customer, err := uc.customerRepo.GetByID(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := input.Channel
+	if channel == "" {
+		channel = entity.SalesChannelDirect
+	}
+	if !channel.IsValid() {
+		return nil, pricing.ErrInvalidChannel
+	}
+
+	// Resolve prices of lines entered without one
+	var toPrice []pricing.PriceQueryItem
+	for _, item := range input.Items {
+		if item.UnitPrice == nil {
+			toPrice = append(toPrice, pricing.PriceQueryItem{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
+	}
+	prices, err := uc.pricing.Resolve(ctx, customer, channel, input.QuotationDate, toPrice)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +105,7 @@ func (uc *CreateQuotationUseCase) Execute(ctx context.Context, input *CreateQuot
 		CustomerID:         input.CustomerID,
 		QuotationDate:      input.QuotationDate,
 		ValidUntil:         input.ValidUntil,
+		Channel:            channel,
 		DiscountPercent:    input.DiscountPercent,
 		DiscountAmount:     input.DiscountAmount,
 		TaxPercent:         input.TaxPercent,
@@ -96,15 +124,33 @@ func (uc *CreateQuotationUseCase) Execute(ctx context.Context, input *CreateQuot
 			ProductName:     item.ProductName,
 			Quantity:        item.Quantity,
 			UomID:           item.UomID,
-			UnitPrice:       item.UnitPrice,
+			PriceSource:     entity.PriceSourceManual,
 			DiscountPercent: item.DiscountPercent,
 			TaxPercent:      item.TaxPercent,
 			Notes:           item.Notes,
 		}
+		if item.UnitPrice != nil {
+			lineItem.UnitPrice = *item.UnitPrice
+		} else {
+			price := prices[0]
+			prices = prices[1:]
+			lineItem.UnitPrice = price.UnitPrice
+			lineItem.PriceSource = price.Source
+			lineItem.PriceListID = price.PriceListID
+		}
 		lineItem.CalculateLineTotal()
 		quotation.LineItems = append(quotation.LineItems, lineItem)
 	}
 
+	// Quote the earliest delivery date of each line
+	toPromise := make([]availability.PromiseQueryItem, len(quotation.LineItems))
+	for i, item := range quotation.LineItems {
+		toPromise[i] = availability.PromiseQueryItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	for i, date := range uc.promises.PromiseDates(ctx, toPromise) {
+		quotation.LineItems[i].PromiseDate = date
+	}
+
 	// Calculate totals
 	quotation.CalculateTotals()
 
@@ -252,6 +298,7 @@ func (uc *ConvertToOrderUseCase) Execute(ctx context.Context, input *ConvertToOr
 		SODate:          time.Now(),
 		DeliveryDate:    input.DeliveryDate,
 		DeliveryAddress: input.DeliveryAddress,
+		Channel:         quotation.Channel,
 		Subtotal:        quotation.Subtotal,
 		DiscountPercent: quotation.DiscountPercent,
 		DiscountAmount:  quotation.DiscountAmount,
@@ -273,8 +320,11 @@ func (uc *ConvertToOrderUseCase) Execute(ctx context.Context, input *ConvertToOr
 			ProductCode:     item.ProductCode,
 			ProductName:     item.ProductName,
 			Quantity:        item.Quantity,
+			PromiseDate:     item.PromiseDate,
 			UomID:           item.UomID,
 			UnitPrice:       item.UnitPrice,
+			PriceSource:     item.PriceSource,
+			PriceListID:     item.PriceListID,
 			DiscountPercent: item.DiscountPercent,
 			DiscountAmount:  item.DiscountAmount,
 			TaxPercent:      item.TaxPercent,
@@ -0,0 +1,82 @@
+package quotation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/masterdata"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/quotation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateQuotationUseCase_Execute_ExplicitZeroPriceIsKept(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	quotationRepo := new(testmocks.MockQuotationRepository)
+	customerRepo := new(testmocks.MockCustomerRepository)
+	priceListRepo := new(testmocks.MockPriceListRepository)
+	products := new(testmocks.MockProductCatalog)
+
+	resolver := pricing.NewResolvePricesUseCase(priceListRepo, customerRepo, products)
+	uc := quotation.NewCreateQuotationUseCase(quotationRepo, customerRepo, resolver, nil)
+
+	customer := &entity.Customer{ID: uuid.New(), Currency: "VND"}
+	sample, priced := uuid.New(), uuid.New()
+	date := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	free := 0.0
+
+	customerRepo.On("GetByID", ctx, customer.ID).Return(customer, nil)
+	priceListRepo.On("GetValidForProducts", ctx, date, []uuid.UUID{priced}).Return([]*entity.PriceList{}, nil)
+	products.On("GetProduct", ctx, priced).Return(&masterdata.Product{ID: priced, StandardPrice: 250000, Currency: "VND"}, nil)
+	quotationRepo.On("GetNextQuotationNumber", ctx).Return("QT-2605-0001", nil)
+	quotationRepo.On("Create", ctx, mock.AnythingOfType("*entity.Quotation")).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, &quotation.CreateQuotationInput{
+		CustomerID:    customer.ID,
+		QuotationDate: date,
+		ValidUntil:    date.AddDate(0, 0, 30),
+		Items: []quotation.QuotationItemInput{
+			{ProductID: sample, Quantity: 5, UnitPrice: &free},
+			{ProductID: priced, Quantity: 2},
+		},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, res.LineItems[0].UnitPrice)
+	assert.Equal(t, entity.PriceSourceManual, res.LineItems[0].PriceSource)
+	assert.Equal(t, 250000.0, res.LineItems[1].UnitPrice)
+	assert.Equal(t, entity.PriceSourceProduct, res.LineItems[1].PriceSource)
+	products.AssertNotCalled(t, "GetProduct", ctx, sample)
+}
+
+func TestCreateQuotationUseCase_Execute_InvalidChannel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	quotationRepo := new(testmocks.MockQuotationRepository)
+	customerRepo := new(testmocks.MockCustomerRepository)
+
+	resolver := pricing.NewResolvePricesUseCase(new(testmocks.MockPriceListRepository), customerRepo, new(testmocks.MockProductCatalog))
+	uc := quotation.NewCreateQuotationUseCase(quotationRepo, customerRepo, resolver, nil)
+
+	customer := &entity.Customer{ID: uuid.New()}
+	customerRepo.On("GetByID", ctx, customer.ID).Return(customer, nil)
+
+	// Act
+	_, err := uc.Execute(ctx, &quotation.CreateQuotationInput{
+		CustomerID: customer.ID,
+		Channel:    "direct",
+		Items:      []quotation.QuotationItemInput{{ProductID: uuid.New(), Quantity: 1}},
+	})
+
+	// Assert
+	assert.Equal(t, pricing.ErrInvalidChannel, err)
+	quotationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,963 @@
+package receivable
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrShipmentNotFound     = errors.New("shipment not found")
+	ErrShipmentNotShipped   = errors.New("shipment has not been shipped")
+	ErrShipmentInvoiced     = errors.New("shipment is already invoiced")
+	ErrNothingToInvoice     = errors.New("nothing left to invoice on the shipment")
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrInvalidAmount        = errors.New("amount must be greater than zero")
+	ErrInvoiceCustomer      = errors.New("invoice belongs to another customer")
+	ErrInvoiceNotOpen       = errors.New("invoice has no open balance")
+	ErrAllocationExceeds    = errors.New("allocation exceeds the invoice balance")
+	ErrOverAllocated        = errors.New("allocations exceed the amount available")
+	ErrDuplicateAllocation  = errors.New("invoice is allocated more than once")
+	ErrCreditNoteNotFound   = errors.New("credit note not found")
+	ErrCreditNoteNotCredit  = errors.New("only CREDIT notes can be applied to invoices")
+	ErrNothingToApply       = errors.New("nothing left to apply")
+	ErrInvalidStatementDate = errors.New("statement end date is before its start date")
+)
+
+// AllocationInput represents part of a payment settling one invoice
+type AllocationInput struct {
+	InvoiceID uuid.UUID
+	Amount    float64
+}
+
+// allocation is a validated amount to settle on an invoice
+type allocation struct {
+	invoice *entity.Invoice
+	amount  float64
+}
+
+// allocator settles invoices with payments and credit notes and keeps the
+// payment status of the orders behind them in step
+type allocator struct {
+	invoiceRepo    repository.InvoiceRepository
+	orderRepo      repository.SalesOrderRepository
+	creditNoteRepo repository.CreditNoteRepository
+}
+
+// plan validates explicit allocations against the customer's open invoices,
+// or spreads the available amount over them oldest due date first
+func (a *allocator) plan(ctx context.Context, customerID uuid.UUID, inputs []AllocationInput, available float64) ([]allocation, error) {
+	var plan []allocation
+
+	if len(inputs) == 0 {
+		invoices, err := a.invoiceRepo.GetOpenByCustomer(ctx, customerID)
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range invoices {
+			if available <= 0 {
+				break
+			}
+			amount := math.Min(available, inv.Balance())
+			if amount <= 0 {
+				continue
+			}
+			plan = append(plan, allocation{invoice: inv, amount: amount})
+			available = roundAmount(available - amount)
+		}
+		return plan, nil
+	}
+
+	total := 0.0
+	seen := make(map[uuid.UUID]bool, len(inputs))
+	for _, in := range inputs {
+		if in.Amount <= 0 {
+			return nil, ErrInvalidAmount
+		}
+		// Each allocation is checked against the invoice balance on its own
+		if seen[in.InvoiceID] {
+			return nil, ErrDuplicateAllocation
+		}
+		seen[in.InvoiceID] = true
+		inv, err := a.invoiceRepo.GetByID(ctx, in.InvoiceID)
+		if err != nil {
+			return nil, ErrInvoiceNotFound
+		}
+		if inv.CustomerID != customerID {
+			return nil, ErrInvoiceCustomer
+		}
+		if !inv.IsOpen() {
+			return nil, ErrInvoiceNotOpen
+		}
+		if in.Amount > inv.Balance() {
+			return nil, ErrAllocationExceeds
+		}
+		total += in.Amount
+		plan = append(plan, allocation{invoice: inv, amount: in.Amount})
+	}
+	if roundAmount(total) > available {
+		return nil, ErrOverAllocated
+	}
+	return plan, nil
+}
+
+// allocate records one allocation and settles the invoice with it
+func (a *allocator) allocate(ctx context.Context, inv *entity.Invoice, amount float64, paymentID, creditNoteID *uuid.UUID, userID uuid.UUID) error {
+	record := &entity.InvoiceAllocation{
+		InvoiceID:    inv.ID,
+		PaymentID:    paymentID,
+		CreditNoteID: creditNoteID,
+		Amount:       amount,
+		CreatedBy:    &userID,
+	}
+	if err := a.invoiceRepo.CreateAllocation(ctx, record); err != nil {
+		return err
+	}
+
+	if paymentID != nil {
+		inv.ApplyPayment(amount)
+	} else {
+		inv.ApplyCredit(amount)
+	}
+	return a.invoiceRepo.Update(ctx, inv)
+}
+
+// applyPayment settles the planned invoices with the payment
+func (a *allocator) applyPayment(ctx context.Context, payment *entity.Payment, plan []allocation, userID uuid.UUID) error {
+	orderIDs := make(map[uuid.UUID]bool)
+	for _, alloc := range plan {
+		if err := a.allocate(ctx, alloc.invoice, alloc.amount, &payment.ID, nil, userID); err != nil {
+			return err
+		}
+		payment.AllocatedAmount = roundAmount(payment.AllocatedAmount + alloc.amount)
+		orderIDs[alloc.invoice.SalesOrderID] = true
+	}
+	return a.refreshPaymentStatus(ctx, orderIDs)
+}
+
+// applyCreditNote settles open invoices with the unapplied part of a credit
+// note, invoices of the returned order first. Credit left over stays on
+// account for later invoices.
+func (a *allocator) applyCreditNote(ctx context.Context, note *entity.CreditNote, userID uuid.UUID) error {
+	available := note.UnappliedAmount()
+	if available <= 0 {
+		return nil
+	}
+
+	invoices, err := a.invoiceRepo.GetOpenByCustomer(ctx, note.CustomerID)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(invoices, func(i, j int) bool {
+		return invoices[i].SalesOrderID == note.SalesOrderID && invoices[j].SalesOrderID != note.SalesOrderID
+	})
+
+	orderIDs := make(map[uuid.UUID]bool)
+	for _, inv := range invoices {
+		if available <= 0 {
+			break
+		}
+		amount := math.Min(available, inv.Balance())
+		if amount <= 0 {
+			continue
+		}
+		if err := a.allocate(ctx, inv, amount, nil, &note.ID, userID); err != nil {
+			return err
+		}
+		note.AppliedAmount = roundAmount(note.AppliedAmount + amount)
+		available = roundAmount(available - amount)
+		orderIDs[inv.SalesOrderID] = true
+	}
+	if len(orderIDs) == 0 {
+		return nil
+	}
+
+	if err := a.creditNoteRepo.Update(ctx, note); err != nil {
+		return err
+	}
+	return a.refreshPaymentStatus(ctx, orderIDs)
+}
+
+// refreshPaymentStatus derives the payment status of each order from its
+// invoices: PAID once fully invoiced and settled, PARTIAL once anything is
+// settled, PENDING otherwise
+func (a *allocator) refreshPaymentStatus(ctx context.Context, orderIDs map[uuid.UUID]bool) error {
+	for orderID := range orderIDs {
+		order, err := a.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		invoices, err := a.invoiceRepo.GetBySalesOrder(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		invoiced := invoicedQuantities(invoices)
+		fullyInvoiced := len(invoices) > 0
+		for _, line := range order.LineItems {
+			if invoiced[line.ID] < line.Quantity {
+				fullyInvoiced = false
+				break
+			}
+		}
+
+		open, settled := 0.0, 0.0
+		for _, inv := range invoices {
+			open += inv.Balance()
+			settled += inv.PaidAmount + inv.CreditedAmount
+		}
+
+		status := entity.PaymentStatusPending
+		switch {
+		case fullyInvoiced && roundAmount(open) <= 0:
+			status = entity.PaymentStatusPaid
+		case settled > 0:
+			status = entity.PaymentStatusPartial
+		}
+		if status == order.PaymentStatus {
+			continue
+		}
+		if err := a.orderRepo.UpdatePaymentStatus(ctx, orderID, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateInvoiceInput represents input for invoicing a shipment
+type CreateInvoiceInput struct {
+	ShipmentID  uuid.UUID
+	InvoiceDate *time.Time // Defaults to today
+	Notes       string
+	CreatedBy   uuid.UUID
+}
+
+// CreateInvoiceUseCase handles invoicing shipped goods
+type CreateInvoiceUseCase struct {
+	invoiceRepo  repository.InvoiceRepository
+	shipmentRepo repository.ShipmentRepository
+	orderRepo    repository.SalesOrderRepository
+	customerRepo repository.CustomerRepository
+	allocator    *allocator
+	tx           repository.Transactor
+	eventPub     *event.Publisher
+}
+
+// NewCreateInvoiceUseCase creates a new use case
+func NewCreateInvoiceUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	shipmentRepo repository.ShipmentRepository,
+	orderRepo repository.SalesOrderRepository,
+	customerRepo repository.CustomerRepository,
+	creditNoteRepo repository.CreditNoteRepository,
+	tx repository.Transactor,
+	eventPub *event.Publisher,
+) *CreateInvoiceUseCase {
+	return &CreateInvoiceUseCase{
+		invoiceRepo:  invoiceRepo,
+		shipmentRepo: shipmentRepo,
+		orderRepo:    orderRepo,
+		customerRepo: customerRepo,
+		allocator:    &allocator{invoiceRepo: invoiceRepo, orderRepo: orderRepo, creditNoteRepo: creditNoteRepo},
+		tx:           tx,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute invoices the goods of a shipment. Each shipment is invoiced once,
+// so partially shipped orders are billed shipment by shipment. Unapplied
+// credit notes of the order are applied to the new invoice.
+func (uc *CreateInvoiceUseCase) Execute(ctx context.Context, input *CreateInvoiceInput) (*entity.Invoice, error) {
+	shipment, err := uc.shipmentRepo.GetByID(ctx, input.ShipmentID)
+	if err != nil {
+		return nil, ErrShipmentNotFound
+	}
+	if !shipment.IsShipped() {
+		return nil, ErrShipmentNotShipped
+	}
+	if _, err := uc.invoiceRepo.GetByShipment(ctx, shipment.ID); err == nil {
+		return nil, ErrShipmentInvoiced
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, shipment.SalesOrderID)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := uc.customerRepo.GetByID(ctx, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	quantities, err := uc.invoiceQuantities(ctx, shipment, order)
+	if err != nil {
+		return nil, err
+	}
+
+	invoiceDate := time.Now()
+	if input.InvoiceDate != nil {
+		invoiceDate = *input.InvoiceDate
+	}
+
+	number, err := uc.invoiceRepo.GetNextInvoiceNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := &entity.Invoice{
+		InvoiceNumber: number,
+		CustomerID:    order.CustomerID,
+		SalesOrderID:  order.ID,
+		ShipmentID:    &shipment.ID,
+		InvoiceDate:   invoiceDate,
+		DueDate:       invoiceDate.AddDate(0, 0, customer.PaymentTermDays()),
+		Currency:      customer.Currency,
+		Status:        entity.InvoiceStatusOpen,
+		Notes:         input.Notes,
+		CreatedBy:     &input.CreatedBy,
+	}
+	for i := range order.LineItems {
+		line := &order.LineItems[i]
+		if qty := quantities[line.ID]; qty > 0 {
+			invoice.LineItems = append(invoice.LineItems, entity.NewInvoiceLineItem(line, qty))
+		}
+	}
+	if len(invoice.LineItems) == 0 {
+		return nil, ErrNothingToInvoice
+	}
+	invoice.CalculateTotals(order)
+
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.invoiceRepo.Create(ctx, invoice); err != nil {
+			return err
+		}
+
+		// Apply credit held on account from returns of this order
+		notes, err := uc.allocator.creditNoteRepo.GetByCustomer(ctx, order.CustomerID)
+		if err != nil {
+			return err
+		}
+		for _, note := range notes {
+			if note.SalesOrderID != order.ID || note.UnappliedAmount() <= 0 {
+				continue
+			}
+			if err := uc.allocator.applyCreditNote(ctx, note, input.CreatedBy); err != nil {
+				return err
+			}
+		}
+		return uc.allocator.refreshPaymentStatus(ctx, map[uuid.UUID]bool{order.ID: true})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Publish event after commit
+	if uc.eventPub != nil {
+		uc.eventPub.PublishInvoiceCreated(&event.InvoiceCreatedEvent{
+			InvoiceID:     invoice.ID.String(),
+			InvoiceNumber: invoice.InvoiceNumber,
+			SOID:          order.ID.String(),
+			CustomerID:    order.CustomerID.String(),
+			ShipmentID:    shipment.ID.String(),
+			InvoiceDate:   invoice.InvoiceDate.Format("2006-01-02"),
+			DueDate:       invoice.DueDate.Format("2006-01-02"),
+			TotalAmount:   invoice.TotalAmount,
+		})
+	}
+
+	return uc.invoiceRepo.GetByID(ctx, invoice.ID)
+}
+
+// invoiceQuantities returns the quantity to invoice per order line. Shipments
+// created before shipment lines cover whatever is not invoiced yet.
+func (uc *CreateInvoiceUseCase) invoiceQuantities(ctx context.Context, shipment *entity.Shipment, order *entity.SalesOrder) (map[uuid.UUID]float64, error) {
+	quantities := make(map[uuid.UUID]float64)
+	if len(shipment.LineItems) > 0 {
+		for _, item := range shipment.LineItems {
+			quantities[item.SOLineItemID] += item.Quantity
+		}
+		return quantities, nil
+	}
+
+	invoices, err := uc.invoiceRepo.GetBySalesOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	invoiced := invoicedQuantities(invoices)
+	for _, line := range order.LineItems {
+		quantities[line.ID] = line.Quantity - invoiced[line.ID]
+	}
+	return quantities, nil
+}
+
+// GetInvoiceUseCase handles getting an invoice
+type GetInvoiceUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// NewGetInvoiceUseCase creates a new use case
+func NewGetInvoiceUseCase(invoiceRepo repository.InvoiceRepository) *GetInvoiceUseCase {
+	return &GetInvoiceUseCase{invoiceRepo: invoiceRepo}
+}
+
+// Execute gets an invoice by ID
+func (uc *GetInvoiceUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Invoice, error) {
+	return uc.invoiceRepo.GetByID(ctx, id)
+}
+
+// ListInvoicesUseCase handles listing invoices
+type ListInvoicesUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// NewListInvoicesUseCase creates a new use case
+func NewListInvoicesUseCase(invoiceRepo repository.InvoiceRepository) *ListInvoicesUseCase {
+	return &ListInvoicesUseCase{invoiceRepo: invoiceRepo}
+}
+
+// Execute lists invoices with filters
+func (uc *ListInvoicesUseCase) Execute(ctx context.Context, filter *repository.InvoiceFilter) ([]*entity.Invoice, int64, error) {
+	return uc.invoiceRepo.List(ctx, filter)
+}
+
+// RecordPaymentInput represents input for recording a customer payment
+type RecordPaymentInput struct {
+	CustomerID    uuid.UUID
+	PaymentDate   *time.Time // Defaults to today
+	Amount        float64
+	PaymentMethod entity.PaymentMethod
+	Reference     string
+	Notes         string
+	Allocations   []AllocationInput // Empty allocates to the oldest invoices first
+	CreatedBy     uuid.UUID
+}
+
+// RecordPaymentUseCase handles recording customer payments
+type RecordPaymentUseCase struct {
+	paymentRepo  repository.PaymentRepository
+	customerRepo repository.CustomerRepository
+	allocator    *allocator
+	tx           repository.Transactor
+	eventPub     *event.Publisher
+}
+
+// NewRecordPaymentUseCase creates a new use case
+func NewRecordPaymentUseCase(
+	paymentRepo repository.PaymentRepository,
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.SalesOrderRepository,
+	customerRepo repository.CustomerRepository,
+	creditNoteRepo repository.CreditNoteRepository,
+	tx repository.Transactor,
+	eventPub *event.Publisher,
+) *RecordPaymentUseCase {
+	return &RecordPaymentUseCase{
+		paymentRepo:  paymentRepo,
+		customerRepo: customerRepo,
+		allocator:    &allocator{invoiceRepo: invoiceRepo, orderRepo: orderRepo, creditNoteRepo: creditNoteRepo},
+		tx:           tx,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute records a payment, reduces the customer balance and allocates the
+// payment to invoices. Any amount not allocated is held on account.
+func (uc *RecordPaymentUseCase) Execute(ctx context.Context, input *RecordPaymentInput) (*entity.Payment, error) {
+	if input.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if _, err := uc.customerRepo.GetByID(ctx, input.CustomerID); err != nil {
+		return nil, err
+	}
+
+	paymentDate := time.Now()
+	if input.PaymentDate != nil {
+		paymentDate = *input.PaymentDate
+	}
+	method := input.PaymentMethod
+	if method == "" {
+		method = entity.PaymentMethodBankTransfer
+	}
+
+	payment := &entity.Payment{
+		CustomerID:    input.CustomerID,
+		PaymentDate:   paymentDate,
+		Amount:        input.Amount,
+		PaymentMethod: method,
+		Reference:     input.Reference,
+		Notes:         input.Notes,
+		CreatedBy:     &input.CreatedBy,
+	}
+
+	// Plan inside the transaction so the invoice balances are the ones settled
+	err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		plan, err := uc.allocator.plan(ctx, input.CustomerID, input.Allocations, input.Amount)
+		if err != nil {
+			return err
+		}
+
+		if payment.PaymentNumber, err = uc.paymentRepo.GetNextPaymentNumber(ctx); err != nil {
+			return err
+		}
+		if err := uc.paymentRepo.Create(ctx, payment); err != nil {
+			return err
+		}
+		if err := uc.customerRepo.UpdateBalance(ctx, input.CustomerID, -input.Amount); err != nil {
+			return err
+		}
+
+		if err := uc.allocator.applyPayment(ctx, payment, plan, input.CreatedBy); err != nil {
+			return err
+		}
+		return uc.paymentRepo.Update(ctx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Publish event after commit
+	if uc.eventPub != nil {
+		uc.eventPub.PublishPaymentReceived(&event.PaymentReceivedEvent{
+			PaymentID:       payment.ID.String(),
+			PaymentNumber:   payment.PaymentNumber,
+			CustomerID:      payment.CustomerID.String(),
+			PaymentDate:     payment.PaymentDate.Format("2006-01-02"),
+			PaymentMethod:   string(payment.PaymentMethod),
+			Amount:          payment.Amount,
+			AllocatedAmount: payment.AllocatedAmount,
+		})
+	}
+
+	return uc.paymentRepo.GetByID(ctx, payment.ID)
+}
+
+// AllocatePaymentUseCase handles allocating money held on account to invoices
+type AllocatePaymentUseCase struct {
+	paymentRepo repository.PaymentRepository
+	allocator   *allocator
+	tx          repository.Transactor
+}
+
+// NewAllocatePaymentUseCase creates a new use case
+func NewAllocatePaymentUseCase(
+	paymentRepo repository.PaymentRepository,
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.SalesOrderRepository,
+	creditNoteRepo repository.CreditNoteRepository,
+	tx repository.Transactor,
+) *AllocatePaymentUseCase {
+	return &AllocatePaymentUseCase{
+		paymentRepo: paymentRepo,
+		allocator:   &allocator{invoiceRepo: invoiceRepo, orderRepo: orderRepo, creditNoteRepo: creditNoteRepo},
+		tx:          tx,
+	}
+}
+
+// Execute allocates the unallocated part of a payment, to the given invoices
+// or to the oldest invoices first
+func (uc *AllocatePaymentUseCase) Execute(ctx context.Context, id uuid.UUID, allocations []AllocationInput, userID uuid.UUID) (*entity.Payment, error) {
+	err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		payment, err := uc.paymentRepo.GetByID(ctx, id)
+		if err != nil {
+			return ErrPaymentNotFound
+		}
+		available := payment.UnallocatedAmount()
+		if available <= 0 {
+			return ErrNothingToApply
+		}
+
+		plan, err := uc.allocator.plan(ctx, payment.CustomerID, allocations, available)
+		if err != nil {
+			return err
+		}
+		if len(plan) == 0 {
+			return ErrInvoiceNotOpen
+		}
+
+		if err := uc.allocator.applyPayment(ctx, payment, plan, userID); err != nil {
+			return err
+		}
+		return uc.paymentRepo.Update(ctx, payment)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.paymentRepo.GetByID(ctx, id)
+}
+
+// GetPaymentUseCase handles getting a payment
+type GetPaymentUseCase struct {
+	paymentRepo repository.PaymentRepository
+}
+
+// NewGetPaymentUseCase creates a new use case
+func NewGetPaymentUseCase(paymentRepo repository.PaymentRepository) *GetPaymentUseCase {
+	return &GetPaymentUseCase{paymentRepo: paymentRepo}
+}
+
+// Execute gets a payment by ID
+func (uc *GetPaymentUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Payment, error) {
+	return uc.paymentRepo.GetByID(ctx, id)
+}
+
+// ListPaymentsUseCase handles listing payments
+type ListPaymentsUseCase struct {
+	paymentRepo repository.PaymentRepository
+}
+
+// NewListPaymentsUseCase creates a new use case
+func NewListPaymentsUseCase(paymentRepo repository.PaymentRepository) *ListPaymentsUseCase {
+	return &ListPaymentsUseCase{paymentRepo: paymentRepo}
+}
+
+// Execute lists payments with filters
+func (uc *ListPaymentsUseCase) Execute(ctx context.Context, filter *repository.PaymentFilter) ([]*entity.Payment, int64, error) {
+	return uc.paymentRepo.List(ctx, filter)
+}
+
+// ApplyCreditNoteUseCase handles applying credit notes to open invoices
+type ApplyCreditNoteUseCase struct {
+	creditNoteRepo repository.CreditNoteRepository
+	allocator      *allocator
+}
+
+// NewApplyCreditNoteUseCase creates a new use case
+func NewApplyCreditNoteUseCase(
+	creditNoteRepo repository.CreditNoteRepository,
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.SalesOrderRepository,
+) *ApplyCreditNoteUseCase {
+	return &ApplyCreditNoteUseCase{
+		creditNoteRepo: creditNoteRepo,
+		allocator:      &allocator{invoiceRepo: invoiceRepo, orderRepo: orderRepo, creditNoteRepo: creditNoteRepo},
+	}
+}
+
+// Execute applies the unapplied part of a credit note to open invoices
+func (uc *ApplyCreditNoteUseCase) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.CreditNote, error) {
+	note, err := uc.creditNoteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrCreditNoteNotFound
+	}
+	if note.NoteType != entity.CreditNoteTypeCredit {
+		return nil, ErrCreditNoteNotCredit
+	}
+	if note.UnappliedAmount() <= 0 {
+		return nil, ErrNothingToApply
+	}
+
+	if err := uc.Apply(ctx, note, userID); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// Apply applies a newly issued credit note to open invoices, invoices of the
+// returned order first. Refund notes are paid out and are left alone.
+func (uc *ApplyCreditNoteUseCase) Apply(ctx context.Context, note *entity.CreditNote, userID uuid.UUID) error {
+	return uc.allocator.applyCreditNote(ctx, note, userID)
+}
+
+// ListCreditNotesUseCase handles listing the credit notes of a customer
+type ListCreditNotesUseCase struct {
+	creditNoteRepo repository.CreditNoteRepository
+}
+
+// NewListCreditNotesUseCase creates a new use case
+func NewListCreditNotesUseCase(creditNoteRepo repository.CreditNoteRepository) *ListCreditNotesUseCase {
+	return &ListCreditNotesUseCase{creditNoteRepo: creditNoteRepo}
+}
+
+// Execute lists the credit notes of a customer
+func (uc *ListCreditNotesUseCase) Execute(ctx context.Context, customerID uuid.UUID) ([]*entity.CreditNote, error) {
+	return uc.creditNoteRepo.GetByCustomer(ctx, customerID)
+}
+
+// Statement entry types
+const (
+	EntryInvoice    = "INVOICE"
+	EntryPayment    = "PAYMENT"
+	EntryCreditNote = "CREDIT_NOTE"
+	EntryRefund     = "REFUND"
+)
+
+// StatementEntry represents one document on a customer ledger
+type StatementEntry struct {
+	Date       time.Time  `json:"date"`
+	EntryType  string     `json:"entry_type"`
+	Reference  string     `json:"reference"`
+	DocumentID uuid.UUID  `json:"document_id"`
+	DueDate    *time.Time `json:"due_date,omitempty"`
+	Debit      float64    `json:"debit"`
+	Credit     float64    `json:"credit"`
+	Balance    float64    `json:"balance"` // Running balance after the entry
+}
+
+// AgingBuckets represents open balances by age since the invoice date
+type AgingBuckets struct {
+	Days0To30  float64 `json:"days_0_30"`
+	Days31To60 float64 `json:"days_31_60"`
+	Days61To90 float64 `json:"days_61_90"`
+	Over90     float64 `json:"over_90"`
+	Total      float64 `json:"total"`
+	Overdue    float64 `json:"overdue"` // Part of the total past its due date
+}
+
+// add puts the open balance of an invoice in its bucket
+func (b *AgingBuckets) add(inv *entity.Invoice, asOf time.Time) {
+	balance := inv.Balance()
+	if balance <= 0 {
+		return
+	}
+
+	switch age := inv.Age(asOf); {
+	case age <= 30:
+		b.Days0To30 = roundAmount(b.Days0To30 + balance)
+	case age <= 60:
+		b.Days31To60 = roundAmount(b.Days31To60 + balance)
+	case age <= 90:
+		b.Days61To90 = roundAmount(b.Days61To90 + balance)
+	default:
+		b.Over90 = roundAmount(b.Over90 + balance)
+	}
+	b.Total = roundAmount(b.Total + balance)
+	if inv.DaysOverdue(asOf) > 0 {
+		b.Overdue = roundAmount(b.Overdue + balance)
+	}
+}
+
+// Statement represents a customer ledger over a period
+type Statement struct {
+	CustomerID     uuid.UUID         `json:"customer_id"`
+	CustomerCode   string            `json:"customer_code"`
+	CustomerName   string            `json:"customer_name"`
+	From           time.Time         `json:"from"`
+	To             time.Time         `json:"to"`
+	OpeningBalance float64           `json:"opening_balance"`
+	TotalDebit     float64           `json:"total_debit"`
+	TotalCredit    float64           `json:"total_credit"`
+	ClosingBalance float64           `json:"closing_balance"`
+	Entries        []StatementEntry  `json:"entries"`
+	OpenInvoices   []*entity.Invoice `json:"open_invoices"`
+	Aging          AgingBuckets      `json:"aging"`
+}
+
+// GetStatementUseCase handles customer statements
+type GetStatementUseCase struct {
+	customerRepo   repository.CustomerRepository
+	invoiceRepo    repository.InvoiceRepository
+	paymentRepo    repository.PaymentRepository
+	creditNoteRepo repository.CreditNoteRepository
+}
+
+// NewGetStatementUseCase creates a new use case
+func NewGetStatementUseCase(
+	customerRepo repository.CustomerRepository,
+	invoiceRepo repository.InvoiceRepository,
+	paymentRepo repository.PaymentRepository,
+	creditNoteRepo repository.CreditNoteRepository,
+) *GetStatementUseCase {
+	return &GetStatementUseCase{
+		customerRepo:   customerRepo,
+		invoiceRepo:    invoiceRepo,
+		paymentRepo:    paymentRepo,
+		creditNoteRepo: creditNoteRepo,
+	}
+}
+
+// Execute builds the ledger of a customer between two dates: invoices and
+// refunds paid out are debits, payments and credit notes are credits. Entries
+// before the period are carried in the opening balance.
+func (uc *GetStatementUseCase) Execute(ctx context.Context, customerID uuid.UUID, from, to time.Time) (*Statement, error) {
+	if to.Before(from) {
+		return nil, ErrInvalidStatementDate
+	}
+
+	customer, err := uc.customerRepo.GetByID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	invoices, err := uc.invoiceRepo.GetByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := uc.paymentRepo.GetByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	notes, err := uc.creditNoteRepo.GetByCustomer(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []StatementEntry
+	for _, inv := range invoices {
+		dueDate := inv.DueDate
+		entries = append(entries, StatementEntry{
+			Date:       inv.InvoiceDate,
+			EntryType:  EntryInvoice,
+			Reference:  inv.InvoiceNumber,
+			DocumentID: inv.ID,
+			DueDate:    &dueDate,
+			Debit:      inv.TotalAmount,
+		})
+	}
+	for _, p := range payments {
+		entries = append(entries, StatementEntry{
+			Date:       p.PaymentDate,
+			EntryType:  EntryPayment,
+			Reference:  p.PaymentNumber,
+			DocumentID: p.ID,
+			Credit:     p.Amount,
+		})
+	}
+	for _, note := range notes {
+		// A refund credits the return and pays the money straight back
+		entries = append(entries, StatementEntry{
+			Date:       note.IssueDate,
+			EntryType:  EntryCreditNote,
+			Reference:  note.CreditNoteNumber,
+			DocumentID: note.ID,
+			Credit:     note.Amount,
+		})
+		if note.NoteType == entity.CreditNoteTypeRefund {
+			entries = append(entries, StatementEntry{
+				Date:       note.IssueDate,
+				EntryType:  EntryRefund,
+				Reference:  note.CreditNoteNumber,
+				DocumentID: note.ID,
+				Debit:      note.Amount,
+			})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return dateOnly(entries[i].Date).Before(dateOnly(entries[j].Date))
+	})
+
+	statement := &Statement{
+		CustomerID:   customer.ID,
+		CustomerCode: customer.CustomerCode,
+		CustomerName: customer.Name,
+		From:         from,
+		To:           to,
+		Entries:      []StatementEntry{},
+		OpenInvoices: []*entity.Invoice{},
+	}
+
+	start, end := dateOnly(from), dateOnly(to)
+	balance := 0.0
+	for _, e := range entries {
+		date := dateOnly(e.Date)
+		if date.After(end) {
+			continue
+		}
+		balance = roundAmount(balance + e.Debit - e.Credit)
+		if date.Before(start) {
+			statement.OpeningBalance = balance
+			continue
+		}
+		e.Balance = balance
+		statement.TotalDebit = roundAmount(statement.TotalDebit + e.Debit)
+		statement.TotalCredit = roundAmount(statement.TotalCredit + e.Credit)
+		statement.Entries = append(statement.Entries, e)
+	}
+	statement.ClosingBalance = balance
+
+	for _, inv := range invoices {
+		if inv.IsOpen() {
+			statement.OpenInvoices = append(statement.OpenInvoices, inv)
+			statement.Aging.add(inv, to)
+		}
+	}
+
+	return statement, nil
+}
+
+// CustomerAging represents the aged open balance of one customer
+type CustomerAging struct {
+	CustomerID   uuid.UUID `json:"customer_id"`
+	CustomerCode string    `json:"customer_code"`
+	CustomerName string    `json:"customer_name"`
+	AgingBuckets
+}
+
+// AgingReport represents open receivables by age
+type AgingReport struct {
+	AsOf      time.Time        `json:"as_of"`
+	Customers []*CustomerAging `json:"customers"`
+	Totals    AgingBuckets     `json:"totals"`
+}
+
+// AgingReportUseCase handles the AR aging report
+type AgingReportUseCase struct {
+	invoiceRepo repository.InvoiceRepository
+}
+
+// NewAgingReportUseCase creates a new use case
+func NewAgingReportUseCase(invoiceRepo repository.InvoiceRepository) *AgingReportUseCase {
+	return &AgingReportUseCase{invoiceRepo: invoiceRepo}
+}
+
+// Execute ages open invoices into 0-30, 31-60, 61-90 and 90+ day buckets by
+// invoice date, per customer, optionally for one customer only
+func (uc *AgingReportUseCase) Execute(ctx context.Context, asOf time.Time, customerID *uuid.UUID) (*AgingReport, error) {
+	invoices, err := uc.invoiceRepo.GetOpen(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AgingReport{AsOf: asOf, Customers: []*CustomerAging{}}
+	byCustomer := make(map[uuid.UUID]*CustomerAging)
+	for _, inv := range invoices {
+		row, ok := byCustomer[inv.CustomerID]
+		if !ok {
+			row = &CustomerAging{CustomerID: inv.CustomerID}
+			if inv.Customer != nil {
+				row.CustomerCode = inv.Customer.CustomerCode
+				row.CustomerName = inv.Customer.Name
+			}
+			byCustomer[inv.CustomerID] = row
+			report.Customers = append(report.Customers, row)
+		}
+		row.add(inv, asOf)
+		report.Totals.add(inv, asOf)
+	}
+
+	// Largest balances first
+	sort.SliceStable(report.Customers, func(i, j int) bool {
+		return report.Customers[i].Total > report.Customers[j].Total
+	})
+
+	return report, nil
+}
+
+// invoicedQuantities sums the invoiced quantity per order line
+func invoicedQuantities(invoices []*entity.Invoice) map[uuid.UUID]float64 {
+	quantities := make(map[uuid.UUID]float64)
+	for _, inv := range invoices {
+		for _, item := range inv.LineItems {
+			quantities[item.SOLineItemID] += item.Quantity
+		}
+	}
+	return quantities
+}
+
+// dateOnly truncates a time to its date
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// roundAmount rounds a money amount to two decimals
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
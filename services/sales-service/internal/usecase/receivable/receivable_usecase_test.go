@@ -0,0 +1,306 @@
+package receivable_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/receivable"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type txKey struct{}
+
+// recordingTransactor marks the context of the transaction so tests can
+// check which calls ran inside it
+type recordingTransactor struct{}
+
+func (recordingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+func inTx() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+}
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+type createInvoiceFixture struct {
+	invoiceRepo    *testmocks.MockInvoiceRepository
+	shipmentRepo   *testmocks.MockShipmentRepository
+	orderRepo      *testmocks.MockSalesOrderRepository
+	customerRepo   *testmocks.MockCustomerRepository
+	creditNoteRepo *testmocks.MockCreditNoteRepository
+	order          *entity.SalesOrder
+	shipment       *entity.Shipment
+	uc             *receivable.CreateInvoiceUseCase
+}
+
+// newCreateInvoiceFixture sets up an order of two lines of which the
+// shipment carries 4 of the 10 units of the first line
+func newCreateInvoiceFixture(ctx context.Context) *createInvoiceFixture {
+	f := &createInvoiceFixture{
+		invoiceRepo:    new(testmocks.MockInvoiceRepository),
+		shipmentRepo:   new(testmocks.MockShipmentRepository),
+		orderRepo:      new(testmocks.MockSalesOrderRepository),
+		customerRepo:   new(testmocks.MockCustomerRepository),
+		creditNoteRepo: new(testmocks.MockCreditNoteRepository),
+	}
+	f.uc = receivable.NewCreateInvoiceUseCase(f.invoiceRepo, f.shipmentRepo, f.orderRepo, f.customerRepo, f.creditNoteRepo, recordingTransactor{}, nil)
+
+	customer := &entity.Customer{ID: uuid.New(), PaymentTerms: "NET 30", Currency: "VND"}
+	f.order = &entity.SalesOrder{
+		ID:            uuid.New(),
+		CustomerID:    customer.ID,
+		Subtotal:      1500000,
+		TotalAmount:   1500000,
+		PaymentStatus: entity.PaymentStatusPending,
+		LineItems: []entity.SOLineItem{
+			{ID: uuid.New(), Quantity: 10, UnitPrice: 100000, LineTotal: 1000000},
+			{ID: uuid.New(), Quantity: 5, UnitPrice: 100000, LineTotal: 500000},
+		},
+	}
+	f.shipment = &entity.Shipment{
+		ID:           uuid.New(),
+		SalesOrderID: f.order.ID,
+		Status:       entity.ShipmentStatusShipped,
+		LineItems:    []entity.ShipmentLineItem{{SOLineItemID: f.order.LineItems[0].ID, Quantity: 4}},
+	}
+
+	f.shipmentRepo.On("GetByID", ctx, f.shipment.ID).Return(f.shipment, nil)
+	f.orderRepo.On("GetByID", mock.Anything, f.order.ID).Return(f.order, nil)
+	f.customerRepo.On("GetByID", ctx, customer.ID).Return(customer, nil)
+	return f
+}
+
+func TestCreateInvoiceUseCase_Execute_InvoicesPartialShipment(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateInvoiceFixture(ctx)
+	var created *entity.Invoice
+	f.invoiceRepo.On("GetByShipment", ctx, f.shipment.ID).Return(nil, repository.ErrNotFound)
+	f.invoiceRepo.On("GetNextInvoiceNumber", ctx).Return("INV-0001", nil)
+	f.invoiceRepo.On("Create", inTx(), mock.AnythingOfType("*entity.Invoice")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Invoice) }).Return(nil)
+	f.creditNoteRepo.On("GetByCustomer", inTx(), f.order.CustomerID).Return([]*entity.CreditNote{}, nil)
+	f.invoiceRepo.On("GetBySalesOrder", inTx(), f.order.ID).Return([]*entity.Invoice{{
+		TotalAmount: 400000,
+		LineItems:   []entity.InvoiceLineItem{{SOLineItemID: f.order.LineItems[0].ID, Quantity: 4}},
+	}}, nil)
+	f.invoiceRepo.On("GetByID", ctx, mock.Anything).Return(&entity.Invoice{}, nil)
+	invoiceDate := date(5, 4)
+
+	// Act
+	_, err := f.uc.Execute(ctx, &receivable.CreateInvoiceInput{ShipmentID: f.shipment.ID, InvoiceDate: &invoiceDate})
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.NotNil(t, created) && assert.Len(t, created.LineItems, 1) {
+		assert.Equal(t, f.order.LineItems[0].ID, created.LineItems[0].SOLineItemID)
+		assert.Equal(t, 4.0, created.LineItems[0].Quantity)
+		assert.Equal(t, 400000.0, created.TotalAmount)
+		assert.Equal(t, date(6, 3), created.DueDate)
+	}
+	f.orderRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateInvoiceUseCase_Execute_ShipmentLookupFails(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateInvoiceFixture(ctx)
+	f.invoiceRepo.On("GetByShipment", ctx, f.shipment.ID).Return(nil, errors.New("connection reset"))
+
+	// Act
+	_, err := f.uc.Execute(ctx, &receivable.CreateInvoiceInput{ShipmentID: f.shipment.ID})
+
+	// Assert
+	assert.EqualError(t, err, "connection reset")
+	f.invoiceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateInvoiceUseCase_Execute_ShipmentAlreadyInvoiced(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateInvoiceFixture(ctx)
+	f.invoiceRepo.On("GetByShipment", ctx, f.shipment.ID).Return(&entity.Invoice{ID: uuid.New()}, nil)
+
+	// Act
+	_, err := f.uc.Execute(ctx, &receivable.CreateInvoiceInput{ShipmentID: f.shipment.ID})
+
+	// Assert
+	assert.Equal(t, receivable.ErrShipmentInvoiced, err)
+}
+
+type paymentFixture struct {
+	paymentRepo  *testmocks.MockPaymentRepository
+	invoiceRepo  *testmocks.MockInvoiceRepository
+	orderRepo    *testmocks.MockSalesOrderRepository
+	customerRepo *testmocks.MockCustomerRepository
+	customer     *entity.Customer
+	uc           *receivable.RecordPaymentUseCase
+}
+
+func newPaymentFixture(ctx context.Context) *paymentFixture {
+	f := &paymentFixture{
+		paymentRepo:  new(testmocks.MockPaymentRepository),
+		invoiceRepo:  new(testmocks.MockInvoiceRepository),
+		orderRepo:    new(testmocks.MockSalesOrderRepository),
+		customerRepo: new(testmocks.MockCustomerRepository),
+		customer:     &entity.Customer{ID: uuid.New()},
+	}
+	f.uc = receivable.NewRecordPaymentUseCase(f.paymentRepo, f.invoiceRepo, f.orderRepo, f.customerRepo, new(testmocks.MockCreditNoteRepository), recordingTransactor{}, nil)
+	f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil)
+	return f
+}
+
+// openInvoice returns an unpaid invoice of its own order
+func (f *paymentFixture) openInvoice(amount float64, dueDate time.Time) *entity.Invoice {
+	return &entity.Invoice{
+		ID:           uuid.New(),
+		CustomerID:   f.customer.ID,
+		SalesOrderID: uuid.New(),
+		DueDate:      dueDate,
+		TotalAmount:  amount,
+		Status:       entity.InvoiceStatusOpen,
+	}
+}
+
+func TestRecordPaymentUseCase_Execute_AllocatesOldestFirst(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newPaymentFixture(ctx)
+	oldest := f.openInvoice(300000, date(4, 15))
+	newer := f.openInvoice(500000, date(5, 15))
+	newest := f.openInvoice(200000, date(6, 15))
+
+	var payment *entity.Payment
+	f.invoiceRepo.On("GetOpenByCustomer", inTx(), f.customer.ID).Return([]*entity.Invoice{oldest, newer, newest}, nil)
+	f.paymentRepo.On("GetNextPaymentNumber", inTx()).Return("PAY-0001", nil)
+	f.paymentRepo.On("Create", inTx(), mock.AnythingOfType("*entity.Payment")).
+		Run(func(args mock.Arguments) { payment = args.Get(1).(*entity.Payment) }).Return(nil)
+	f.customerRepo.On("UpdateBalance", inTx(), f.customer.ID, -600000.0).Return(nil)
+	f.invoiceRepo.On("CreateAllocation", inTx(), mock.AnythingOfType("*entity.InvoiceAllocation")).Return(nil)
+	f.invoiceRepo.On("Update", inTx(), mock.AnythingOfType("*entity.Invoice")).Return(nil)
+	for _, inv := range []*entity.Invoice{oldest, newer} {
+		f.orderRepo.On("GetByID", inTx(), inv.SalesOrderID).Return(&entity.SalesOrder{ID: inv.SalesOrderID}, nil)
+		f.invoiceRepo.On("GetBySalesOrder", inTx(), inv.SalesOrderID).Return([]*entity.Invoice{inv}, nil)
+	}
+	f.orderRepo.On("UpdatePaymentStatus", inTx(), oldest.SalesOrderID, entity.PaymentStatusPaid).Return(nil)
+	f.orderRepo.On("UpdatePaymentStatus", inTx(), newer.SalesOrderID, entity.PaymentStatusPartial).Return(nil)
+	f.paymentRepo.On("Update", inTx(), mock.AnythingOfType("*entity.Payment")).Return(nil)
+	f.paymentRepo.On("GetByID", ctx, mock.Anything).Return(&entity.Payment{}, nil)
+
+	// Act
+	_, err := f.uc.Execute(ctx, &receivable.RecordPaymentInput{CustomerID: f.customer.ID, Amount: 600000})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.InvoiceStatusPaid, oldest.Status)
+	assert.Equal(t, 300000.0, newer.PaidAmount)
+	assert.Equal(t, entity.InvoiceStatusPartiallyPaid, newer.Status)
+	assert.Equal(t, 0.0, newest.PaidAmount)
+	assert.Equal(t, 600000.0, payment.AllocatedAmount)
+	f.invoiceRepo.AssertNumberOfCalls(t, "CreateAllocation", 2)
+	f.orderRepo.AssertExpectations(t)
+}
+
+func TestRecordPaymentUseCase_Execute_RejectsInvalidAllocations(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts [2]float64
+		same    bool
+		wantErr error
+	}{
+		{"allocations exceed the payment", [2]float64{300000, 300000}, false, receivable.ErrOverAllocated},
+		{"allocation exceeds the invoice balance", [2]float64{450000, 50000}, false, receivable.ErrAllocationExceeds},
+		{"invoice allocated twice", [2]float64{200000, 200000}, true, receivable.ErrDuplicateAllocation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			f := newPaymentFixture(ctx)
+			first := f.openInvoice(400000, date(4, 15))
+			second := f.openInvoice(400000, date(5, 15))
+			if tt.same {
+				second = first
+			}
+			f.invoiceRepo.On("GetByID", inTx(), first.ID).Return(first, nil)
+			f.invoiceRepo.On("GetByID", inTx(), second.ID).Return(second, nil)
+
+			// Act
+			_, err := f.uc.Execute(ctx, &receivable.RecordPaymentInput{
+				CustomerID: f.customer.ID,
+				Amount:     500000,
+				Allocations: []receivable.AllocationInput{
+					{InvoiceID: first.ID, Amount: tt.amounts[0]},
+					{InvoiceID: second.ID, Amount: tt.amounts[1]},
+				},
+			})
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			f.paymentRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			f.customerRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAgingReportUseCase_Execute_Buckets(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	invoiceRepo := new(testmocks.MockInvoiceRepository)
+	uc := receivable.NewAgingReportUseCase(invoiceRepo)
+
+	asOf := date(5, 31)
+	small := &entity.Customer{ID: uuid.New(), CustomerCode: "KH-001"}
+	large := &entity.Customer{ID: uuid.New(), CustomerCode: "KH-002"}
+	invoice := func(c *entity.Customer, age int, total, paid float64) *entity.Invoice {
+		invoiceDate := asOf.AddDate(0, 0, -age)
+		return &entity.Invoice{
+			CustomerID:  c.ID,
+			Customer:    c,
+			InvoiceDate: invoiceDate,
+			DueDate:     invoiceDate.AddDate(0, 0, 30),
+			TotalAmount: total,
+			PaidAmount:  paid,
+			Status:      entity.InvoiceStatusOpen,
+		}
+	}
+	invoiceRepo.On("GetOpen", ctx, (*uuid.UUID)(nil)).Return([]*entity.Invoice{
+		invoice(small, 10, 100000, 0),
+		invoice(small, 30, 50000, 20000),
+		invoice(large, 31, 200000, 0),
+		invoice(large, 75, 300000, 0),
+		invoice(large, 91, 400000, 100000),
+	}, nil)
+
+	// Act
+	report, err := uc.Execute(ctx, asOf, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, receivable.AgingBuckets{
+		Days0To30:  130000,
+		Days31To60: 200000,
+		Days61To90: 300000,
+		Over90:     300000,
+		Total:      930000,
+		Overdue:    800000,
+	}, report.Totals)
+	if assert.Len(t, report.Customers, 2) {
+		assert.Equal(t, "KH-002", report.Customers[0].CustomerCode)
+		assert.Equal(t, 800000.0, report.Customers[0].Total)
+		assert.Equal(t, 130000.0, report.Customers[1].Days0To30)
+		assert.Equal(t, 0.0, report.Customers[1].Overdue)
+	}
+}
@@ -3,11 +3,17 @@ package sales_order
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
 	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
 	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/availability"
+	customeruc "github.com/erp-cosmetics/sales-service/internal/usecase/customer"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/promotion"
 	"github.com/google/uuid"
 )
 
@@ -17,6 +23,17 @@ var (
 	ErrOrderCannotCancel   = errors.New("order cannot be cancelled")
 	ErrOrderCannotShip     = errors.New("order cannot be shipped")
 	ErrInsufficientCredit  = errors.New("insufficient credit limit")
+	ErrCustomerNotActive   = errors.New("customer is not active")
+	ErrCreditHoldNotFound  = errors.New("credit hold not found")
+	ErrCreditHoldReviewed  = errors.New("credit hold has already been reviewed")
+	ErrOrderNotOnCreditHold = errors.New("order is not on credit hold")
+	ErrReviewReasonRequired = errors.New("review reason is required")
+	ErrInvalidBackorderStatus = errors.New("invalid backorder status")
+	ErrOrderCannotAmend     = errors.New("order cannot be amended")
+	ErrAmendmentEmpty       = errors.New("amendment does not change the order")
+	ErrAmendsPickedQuantity = errors.New("amendment changes picked or shipped quantities")
+	ErrOrderLineNotFound    = errors.New("order line not found")
+	ErrAmendsFreeGoods      = errors.New("free goods follow the promotions and cannot be amended")
 )
 
 // CreateOrderInput represents input for creating sales order
@@ -27,11 +44,13 @@ type CreateOrderInput struct {
 	DeliveryDate    *time.Time
 	DeliveryAddress string
 	BillingAddress  string
+	Channel         entity.SalesChannel
 	DiscountPercent float64
 	TaxPercent      float64
 	PaymentMethod   entity.PaymentMethod
 	Notes           string
 	Items           []OrderItemInput
+	VoucherCodes    []string
 	CreatedBy       *uuid.UUID
 }
 
@@ -42,7 +61,7 @@ type OrderItemInput struct {
 	ProductName     string
 	Quantity        float64
 	UomID           *uuid.UUID
-	UnitPrice       float64
+	UnitPrice       *float64 // Nil resolves the price from the price lists
 	DiscountPercent float64
 	TaxPercent      float64
 	Notes           string
@@ -52,6 +71,10 @@ type OrderItemInput struct {
 type CreateOrderUseCase struct {
 	orderRepo    repository.SalesOrderRepository
 	customerRepo repository.CustomerRepository
+	pricing      *pricing.ResolvePricesUseCase
+	promotions   *promotion.ApplyPromotionsUseCase
+	promises     *availability.PromiseDatesUseCase
+	tx           repository.Transactor
 	eventPub     *event.Publisher
 }
 
@@ -59,11 +82,19 @@ type CreateOrderUseCase struct {
 func NewCreateOrderUseCase(
 	orderRepo repository.SalesOrderRepository,
 	customerRepo repository.CustomerRepository,
+	pricing *pricing.ResolvePricesUseCase,
+	promotions *promotion.ApplyPromotionsUseCase,
+	promises *availability.PromiseDatesUseCase,
+	tx repository.Transactor,
 	eventPub *event.Publisher,
 ) *CreateOrderUseCase {
 	return &CreateOrderUseCase{
 		orderRepo:    orderRepo,
 		customerRepo: customerRepo,
+		pricing:      pricing,
+		promotions:   promotions,
+		promises:     promises,
+		tx:           tx,
 		eventPub:     eventPub,
 	}
 }
@@ -71,7 +102,27 @@ func NewCreateOrderUseCase(
 // Execute creates a new sales order
 func (uc *CreateOrderUseCase) Execute(ctx context.Context, input *CreateOrderInput) (*entity.SalesOrder, error) {
 	// Verify customer exists
-	_, err := uc.customerRepo.GetByID(ctx, input.CustomerID)
+	customer, err := uc.customerRepo.GetByID(ctx, input.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	channel := input.Channel
+	if channel == "" {
+		channel = entity.SalesChannelDirect
+	}
+	if !channel.IsValid() {
+		return nil, pricing.ErrInvalidChannel
+	}
+
+	// Resolve prices of lines entered without one
+	var toPrice []pricing.PriceQueryItem
+	for _, item := range input.Items {
+		if item.UnitPrice == nil {
+			toPrice = append(toPrice, pricing.PriceQueryItem{ProductID: item.ProductID, Quantity: item.Quantity})
+		}
+	}
+	prices, err := uc.pricing.Resolve(ctx, customer, channel, input.SODate, toPrice)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +141,7 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, input *CreateOrderInp
 		DeliveryDate:    input.DeliveryDate,
 		DeliveryAddress: input.DeliveryAddress,
 		BillingAddress:  input.BillingAddress,
+		Channel:         channel,
 		DiscountPercent: input.DiscountPercent,
 		TaxPercent:      input.TaxPercent,
 		PaymentMethod:   input.PaymentMethod,
@@ -108,21 +160,53 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, input *CreateOrderInp
 			ProductName:     item.ProductName,
 			Quantity:        item.Quantity,
 			UomID:           item.UomID,
-			UnitPrice:       item.UnitPrice,
+			PriceSource:     entity.PriceSourceManual,
 			DiscountPercent: item.DiscountPercent,
 			TaxPercent:      item.TaxPercent,
 			Notes:           item.Notes,
 		}
+		if item.UnitPrice != nil {
+			lineItem.UnitPrice = *item.UnitPrice
+		} else {
+			price := prices[0]
+			prices = prices[1:]
+			lineItem.UnitPrice = price.UnitPrice
+			lineItem.PriceSource = price.Source
+			lineItem.PriceListID = price.PriceListID
+		}
 		lineItem.CalculateLineTotal()
 		order.LineItems = append(order.LineItems, lineItem)
 	}
 
+	// Apply automatic promotions and vouchers
+	if err := uc.promotions.Apply(ctx, customer, order, input.VoucherCodes); err != nil {
+		return nil, err
+	}
+
+	// Quote the earliest delivery date of each line, free goods included
+	toPromise := make([]availability.PromiseQueryItem, len(order.LineItems))
+	for i, item := range order.LineItems {
+		toPromise[i] = availability.PromiseQueryItem{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	for i, date := range uc.promises.PromiseDates(ctx, toPromise) {
+		order.LineItems[i].PromiseDate = date
+	}
+
 	// Calculate totals
 	order.CalculateTotals()
 
-	if err := uc.orderRepo.Create(ctx, order); err != nil {
+	// Book the promotions with the order, so a budget or voucher used up by a
+	// concurrent order rejects this one instead of being overspent
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+		return uc.promotions.RecordUsage(ctx, order)
+	})
+	if err != nil {
 		return nil, err
 	}
+	uc.promotions.PublishUsage(order)
 
 	// Publish event
 	if uc.eventPub != nil {
@@ -180,9 +264,12 @@ func (uc *ListOrdersUseCase) Execute(ctx context.Context, filter *repository.Sal
 
 // ConfirmOrderUseCase handles confirming sales order
 type ConfirmOrderUseCase struct {
-	orderRepo       repository.SalesOrderRepository
-	customerRepo    repository.CustomerRepository
-	eventPub        *event.Publisher
+	orderRepo         repository.SalesOrderRepository
+	customerRepo      repository.CustomerRepository
+	holdRepo          repository.CreditHoldRepository
+	checkCredit       *customeruc.CheckCreditUseCase
+	tx                repository.Transactor
+	eventPub          *event.Publisher
 	enableCreditCheck bool
 }
 
@@ -190,18 +277,26 @@ type ConfirmOrderUseCase struct {
 func NewConfirmOrderUseCase(
 	orderRepo repository.SalesOrderRepository,
 	customerRepo repository.CustomerRepository,
+	holdRepo repository.CreditHoldRepository,
+	checkCredit *customeruc.CheckCreditUseCase,
+	tx repository.Transactor,
 	eventPub *event.Publisher,
 	enableCreditCheck bool,
 ) *ConfirmOrderUseCase {
 	return &ConfirmOrderUseCase{
-		orderRepo:       orderRepo,
-		customerRepo:    customerRepo,
-		eventPub:        eventPub,
+		orderRepo:         orderRepo,
+		customerRepo:      customerRepo,
+		holdRepo:          holdRepo,
+		checkCredit:       checkCredit,
+		tx:                tx,
+		eventPub:          eventPub,
 		enableCreditCheck: enableCreditCheck,
 	}
 }
 
-// Execute confirms a sales order
+// Execute confirms a sales order. With credit checks enabled, an order over
+// the customer's available credit, or of a customer with overdue invoices,
+// is placed on credit hold for a credit manager to review instead.
 func (uc *ConfirmOrderUseCase) Execute(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) (*entity.SalesOrder, error) {
 	order, err := uc.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
@@ -212,66 +307,337 @@ func (uc *ConfirmOrderUseCase) Execute(ctx context.Context, orderID uuid.UUID, u
 		return nil, ErrOrderCannotConfirm
 	}
 
-	// Check credit limit
+	customer, err := uc.customerRepo.GetByID(ctx, order.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer.Status != entity.CustomerStatusActive {
+		return nil, ErrCustomerNotActive
+	}
+
+	// Check credit against the receivables ledger
 	if uc.enableCreditCheck {
-		customer, err := uc.customerRepo.GetByID(ctx, order.CustomerID)
+		credit, err := uc.checkCredit.Execute(ctx, order.CustomerID, order.TotalAmount)
 		if err != nil {
 			return nil, err
 		}
 
-		if !customer.CanPlaceOrder(order.TotalAmount) {
-			return nil, ErrInsufficientCredit
+		if !credit.WithinLimit || credit.OverdueInvoices > 0 {
+			return uc.hold(ctx, order, credit, userID)
 		}
+	}
 
-		// Update customer balance
-		if err := uc.customerRepo.UpdateBalance(ctx, customer.ID, order.TotalAmount); err != nil {
-			return nil, err
-		}
+	if err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return uc.confirm(ctx, order, userID)
+	}); err != nil {
+		return nil, err
+	}
+	if err := uc.publishConfirmed(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// confirm confirms an order that passed the credit check or whose credit
+// hold was approved. Run it in a transaction and publish the confirmation
+// once committed.
+func (uc *ConfirmOrderUseCase) confirm(ctx context.Context, order *entity.SalesOrder, userID uuid.UUID) error {
+	// Update customer balance, released again on cancel
+	if err := uc.customerRepo.UpdateBalance(ctx, order.CustomerID, order.TotalAmount); err != nil {
+		return err
 	}
 
 	// Confirm order
 	order.Confirm(userID)
 
-	if err := uc.orderRepo.Update(ctx, order); err != nil {
-		return nil, err
-	}
+	return uc.orderRepo.Update(ctx, order)
+}
 
+// publishConfirmed asks WMS to reserve the stock of a confirmed order
+func (uc *ConfirmOrderUseCase) publishConfirmed(ctx context.Context, order *entity.SalesOrder) error {
 	// Publish event -> WMS will reserve stock
 	if uc.eventPub != nil {
+		customer := order.Customer
+		if customer == nil {
+			var err error
+			if customer, err = uc.customerRepo.GetByID(ctx, order.CustomerID); err != nil {
+				return err
+			}
+		}
+
 		deliveryDate := ""
 		if order.DeliveryDate != nil {
 			deliveryDate = order.DeliveryDate.Format("2006-01-02")
 		}
-		
+
 		items := make([]event.OrderLineItem, len(order.LineItems))
 		for i, item := range order.LineItems {
 			items[i] = event.OrderLineItem{
+				LineID:      item.ID.String(),
 				ProductID:   item.ProductID.String(),
 				ProductCode: item.ProductCode,
 				ProductName: item.ProductName,
 				Quantity:    item.Quantity,
 				UnitPrice:   item.UnitPrice,
 			}
+			if item.UomID != nil {
+				items[i].UomID = item.UomID.String()
+			}
 		}
-		
+
 		uc.eventPub.PublishOrderConfirmed(&event.OrderConfirmedEvent{
-			SOID:            order.ID.String(),
-			SONumber:        order.SONumber,
-			CustomerID:      order.CustomerID.String(),
-			DeliveryDate:    deliveryDate,
+			SOID:             order.ID.String(),
+			SONumber:         order.SONumber,
+			CustomerID:       order.CustomerID.String(),
+			CustomerPriority: customer.AllocationPriority(), // Who gets stock first if lines are backordered
+			SODate:           order.SODate.Format("2006-01-02"),
+			DeliveryDate:     deliveryDate,
 			DeliveryAddress: order.DeliveryAddress,
 			TotalAmount:     order.TotalAmount,
 			Items:           items,
 		})
 	}
 
+	return nil
+}
+
+// hold places an order on credit hold and records the credit position
+// that failed the check
+func (uc *ConfirmOrderUseCase) hold(ctx context.Context, order *entity.SalesOrder, credit *customeruc.CreditCheckResult, userID uuid.UUID) (*entity.SalesOrder, error) {
+	var reasons []string
+	if !credit.WithinLimit {
+		reasons = append(reasons, fmt.Sprintf("order amount %.2f exceeds available credit %.2f", order.TotalAmount, credit.AvailableCredit))
+	}
+	if credit.OverdueInvoices > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d overdue invoices totalling %.2f", credit.OverdueInvoices, credit.OverdueAmount))
+	}
+
+	hold := &entity.CreditHold{
+		SalesOrderID:    order.ID,
+		CustomerID:      order.CustomerID,
+		OrderAmount:     order.TotalAmount,
+		CreditLimit:     credit.CreditLimit,
+		CurrentBalance:  credit.CurrentBalance,
+		AvailableCredit: credit.AvailableCredit,
+		OverdueAmount:   credit.OverdueAmount,
+		OverdueInvoices: credit.OverdueInvoices,
+		OverLimit:       !credit.WithinLimit,
+		Reason:          strings.Join(reasons, "; "),
+		Status:          entity.CreditHoldStatusPending,
+		HeldAt:          time.Now(),
+		HeldBy:          &userID,
+	}
+	order.PlaceOnCreditHold()
+
+	if err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.holdRepo.Create(ctx, hold); err != nil {
+			return err
+		}
+		return uc.orderRepo.Update(ctx, order)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	if uc.eventPub != nil {
+		uc.eventPub.PublishOrderCreditHold(creditHoldEventOf(hold, order))
+	}
+
 	return order, nil
 }
 
+// ApproveCreditHoldUseCase handles releasing sales orders from credit hold
+type ApproveCreditHoldUseCase struct {
+	holdRepo     repository.CreditHoldRepository
+	orderRepo    repository.SalesOrderRepository
+	confirmOrder *ConfirmOrderUseCase
+	tx           repository.Transactor
+	eventPub     *event.Publisher
+}
+
+// NewApproveCreditHoldUseCase creates a new use case
+func NewApproveCreditHoldUseCase(
+	holdRepo repository.CreditHoldRepository,
+	orderRepo repository.SalesOrderRepository,
+	confirmOrder *ConfirmOrderUseCase,
+	tx repository.Transactor,
+	eventPub *event.Publisher,
+) *ApproveCreditHoldUseCase {
+	return &ApproveCreditHoldUseCase{
+		holdRepo:     holdRepo,
+		orderRepo:    orderRepo,
+		confirmOrder: confirmOrder,
+		tx:           tx,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute approves a credit exception and confirms the held order, which
+// reserves its stock like any other confirmation
+func (uc *ApproveCreditHoldUseCase) Execute(ctx context.Context, holdID uuid.UUID, reason string, userID uuid.UUID) (*entity.CreditHold, error) {
+	hold, order, err := pendingHold(ctx, uc.holdRepo, uc.orderRepo, holdID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hold is only approved if the order is confirmed with it
+	hold.Approve(userID, reason)
+	if err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.holdRepo.Update(ctx, hold); err != nil {
+			return err
+		}
+		return uc.confirmOrder.confirm(ctx, order, userID)
+	}); err != nil {
+		return nil, err
+	}
+	hold.SalesOrder = order
+
+	if err := uc.confirmOrder.publishConfirmed(ctx, order); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	if uc.eventPub != nil {
+		uc.eventPub.PublishOrderCreditReviewed(creditHoldEventOf(hold, order))
+	}
+
+	return hold, nil
+}
+
+// RejectCreditHoldUseCase handles rejecting credit holds
+type RejectCreditHoldUseCase struct {
+	holdRepo  repository.CreditHoldRepository
+	orderRepo repository.SalesOrderRepository
+	tx        repository.Transactor
+	eventPub  *event.Publisher
+}
+
+// NewRejectCreditHoldUseCase creates a new use case
+func NewRejectCreditHoldUseCase(
+	holdRepo repository.CreditHoldRepository,
+	orderRepo repository.SalesOrderRepository,
+	tx repository.Transactor,
+	eventPub *event.Publisher,
+) *RejectCreditHoldUseCase {
+	return &RejectCreditHoldUseCase{
+		holdRepo:  holdRepo,
+		orderRepo: orderRepo,
+		tx:        tx,
+		eventPub:  eventPub,
+	}
+}
+
+// Execute rejects a credit hold. The order goes back to draft so that sales
+// can revise it, e.g. for a prepayment or a smaller quantity, or cancel it.
+func (uc *RejectCreditHoldUseCase) Execute(ctx context.Context, holdID uuid.UUID, reason string, userID uuid.UUID) (*entity.CreditHold, error) {
+	hold, order, err := pendingHold(ctx, uc.holdRepo, uc.orderRepo, holdID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	hold.Reject(userID, reason)
+	order.ReleaseToDraft()
+	order.UpdatedBy = &userID
+	if err := uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.holdRepo.Update(ctx, hold); err != nil {
+			return err
+		}
+		return uc.orderRepo.Update(ctx, order)
+	}); err != nil {
+		return nil, err
+	}
+	hold.SalesOrder = order
+
+	// Publish event
+	if uc.eventPub != nil {
+		uc.eventPub.PublishOrderCreditReviewed(creditHoldEventOf(hold, order))
+	}
+
+	return hold, nil
+}
+
+// GetCreditHoldUseCase handles getting a credit hold
+type GetCreditHoldUseCase struct {
+	holdRepo repository.CreditHoldRepository
+}
+
+// NewGetCreditHoldUseCase creates a new use case
+func NewGetCreditHoldUseCase(holdRepo repository.CreditHoldRepository) *GetCreditHoldUseCase {
+	return &GetCreditHoldUseCase{holdRepo: holdRepo}
+}
+
+// Execute gets a credit hold by ID
+func (uc *GetCreditHoldUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.CreditHold, error) {
+	return uc.holdRepo.GetByID(ctx, id)
+}
+
+// ListCreditHoldsUseCase handles listing credit holds
+type ListCreditHoldsUseCase struct {
+	holdRepo repository.CreditHoldRepository
+}
+
+// NewListCreditHoldsUseCase creates a new use case
+func NewListCreditHoldsUseCase(holdRepo repository.CreditHoldRepository) *ListCreditHoldsUseCase {
+	return &ListCreditHoldsUseCase{holdRepo: holdRepo}
+}
+
+// Execute lists credit holds with filters
+func (uc *ListCreditHoldsUseCase) Execute(ctx context.Context, filter *repository.CreditHoldFilter) ([]*entity.CreditHold, int64, error) {
+	return uc.holdRepo.List(ctx, filter)
+}
+
+// pendingHold loads a credit hold waiting for a decision with its held order
+func pendingHold(ctx context.Context, holdRepo repository.CreditHoldRepository, orderRepo repository.SalesOrderRepository, holdID uuid.UUID, reason string) (*entity.CreditHold, *entity.SalesOrder, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, nil, ErrReviewReasonRequired
+	}
+
+	hold, err := holdRepo.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, nil, ErrCreditHoldNotFound
+	}
+	if !hold.IsPending() {
+		return nil, nil, ErrCreditHoldReviewed
+	}
+
+	order, err := orderRepo.GetByID(ctx, hold.SalesOrderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !order.IsOnCreditHold() {
+		return nil, nil, ErrOrderNotOnCreditHold
+	}
+
+	return hold, order, nil
+}
+
+// creditHoldEventOf maps a credit hold to its event
+func creditHoldEventOf(hold *entity.CreditHold, order *entity.SalesOrder) *event.CreditHoldEvent {
+	e := &event.CreditHoldEvent{
+		HoldID:          hold.ID.String(),
+		SOID:            order.ID.String(),
+		SONumber:        order.SONumber,
+		CustomerID:      order.CustomerID.String(),
+		OrderAmount:     hold.OrderAmount,
+		AvailableCredit: hold.AvailableCredit,
+		OverdueAmount:   hold.OverdueAmount,
+		Reason:          hold.Reason,
+		Status:          string(hold.Status),
+		ReviewReason:    hold.ReviewReason,
+	}
+	if hold.ReviewedBy != nil {
+		e.ReviewedBy = hold.ReviewedBy.String()
+	}
+	return e
+}
+
 // CancelOrderUseCase handles cancelling sales order
 type CancelOrderUseCase struct {
 	orderRepo    repository.SalesOrderRepository
 	customerRepo repository.CustomerRepository
+	holdRepo     repository.CreditHoldRepository
+	promotions   *promotion.ApplyPromotionsUseCase
+	tx           repository.Transactor
 	eventPub     *event.Publisher
 }
 
@@ -279,16 +645,23 @@ type CancelOrderUseCase struct {
 func NewCancelOrderUseCase(
 	orderRepo repository.SalesOrderRepository,
 	customerRepo repository.CustomerRepository,
+	holdRepo repository.CreditHoldRepository,
+	promotions *promotion.ApplyPromotionsUseCase,
+	tx repository.Transactor,
 	eventPub *event.Publisher,
 ) *CancelOrderUseCase {
 	return &CancelOrderUseCase{
 		orderRepo:    orderRepo,
 		customerRepo: customerRepo,
+		holdRepo:     holdRepo,
+		promotions:   promotions,
+		tx:           tx,
 		eventPub:     eventPub,
 	}
 }
 
-// Execute cancels a sales order
+// Execute cancels a sales order. Cancelling an order on credit hold rejects
+// the pending hold, so it drops off the credit managers' queue.
 func (uc *CancelOrderUseCase) Execute(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, reason string) (*entity.SalesOrder, error) {
 	order, err := uc.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
@@ -299,18 +672,41 @@ func (uc *CancelOrderUseCase) Execute(ctx context.Context, orderID uuid.UUID, us
 		return nil, ErrOrderCannotCancel
 	}
 
-	// If order was confirmed, release the credit hold
-	if order.Status == entity.SOStatusConfirmed {
-		if err := uc.customerRepo.UpdateBalance(ctx, order.CustomerID, -order.TotalAmount); err != nil {
-			return nil, err
+	var released []entity.OrderPromotion
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// If order was confirmed, release the credit hold
+		if order.Status == entity.SOStatusConfirmed {
+			if err := uc.customerRepo.UpdateBalance(ctx, order.CustomerID, -order.TotalAmount); err != nil {
+				return err
+			}
 		}
-	}
 
-	order.Cancel(userID, reason)
+		if order.IsOnCreditHold() {
+			hold, err := uc.holdRepo.GetPendingByOrder(ctx, order.ID)
+			if err != nil {
+				return err
+			}
+			hold.Reject(userID, "Order cancelled: "+reason)
+			if err := uc.holdRepo.Update(ctx, hold); err != nil {
+				return err
+			}
+		}
 
-	if err := uc.orderRepo.Update(ctx, order); err != nil {
+		order.Cancel(userID, reason)
+
+		if err := uc.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+
+		// Give promotion budgets and vouchers back
+		var err error
+		released, err = uc.promotions.Release(ctx, order)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
+	uc.promotions.PublishRelease(order, released)
 
 	// Publish event -> WMS will release reservations
 	if uc.eventPub != nil {
@@ -326,6 +722,643 @@ func (uc *CancelOrderUseCase) Execute(ctx context.Context, orderID uuid.UUID, us
 	return order, nil
 }
 
+// AmendOrderInput represents changes to a confirmed sales order
+type AmendOrderInput struct {
+	OrderID      uuid.UUID
+	DeliveryDate *time.Time // Unchanged if nil
+	Reason       string
+	Lines        []AmendLineInput
+	AmendedBy    uuid.UUID
+}
+
+// AmendLineInput represents a changed, added or removed order line
+type AmendLineInput struct {
+	LineID          *uuid.UUID // Nil adds a line
+	ProductID       uuid.UUID
+	ProductCode     string
+	ProductName     string
+	UomID           *uuid.UUID
+	Quantity        float64  // Zero removes the line
+	UnitPrice       *float64 // Unchanged if nil, resolved from the price lists for added lines
+	DiscountPercent float64  // Of added lines
+	TaxPercent      float64  // Of added lines
+	Notes           string
+}
+
+// AmendOrderUseCase handles amending confirmed sales orders
+type AmendOrderUseCase struct {
+	orderRepo         repository.SalesOrderRepository
+	customerRepo      repository.CustomerRepository
+	shipmentRepo      repository.ShipmentRepository
+	pricing           *pricing.ResolvePricesUseCase
+	promotions        *promotion.ApplyPromotionsUseCase
+	checkCredit       *customeruc.CheckCreditUseCase
+	tx                repository.Transactor
+	eventPub          *event.Publisher
+	enableCreditCheck bool
+}
+
+// NewAmendOrderUseCase creates a new use case
+func NewAmendOrderUseCase(
+	orderRepo repository.SalesOrderRepository,
+	customerRepo repository.CustomerRepository,
+	shipmentRepo repository.ShipmentRepository,
+	pricing *pricing.ResolvePricesUseCase,
+	promotions *promotion.ApplyPromotionsUseCase,
+	checkCredit *customeruc.CheckCreditUseCase,
+	tx repository.Transactor,
+	eventPub *event.Publisher,
+	enableCreditCheck bool,
+) *AmendOrderUseCase {
+	return &AmendOrderUseCase{
+		orderRepo:         orderRepo,
+		customerRepo:      customerRepo,
+		shipmentRepo:      shipmentRepo,
+		pricing:           pricing,
+		promotions:        promotions,
+		checkCredit:       checkCredit,
+		tx:                tx,
+		eventPub:          eventPub,
+		enableCreditCheck: enableCreditCheck,
+	}
+}
+
+// Execute amends the lines and delivery date of a confirmed order and raises
+// its version. Quantities already picked or shipped cannot be reduced and
+// their price cannot change. Free goods and cart discounts are evaluated
+// again on the amended lines. An increase of the order total is credit
+// checked, and WMS reserves or releases the quantity changes.
+func (uc *AmendOrderUseCase) Execute(ctx context.Context, input *AmendOrderInput) (*entity.SalesOrder, *entity.SOAmendment, error) {
+	order, err := uc.orderRepo.GetByID(ctx, input.OrderID)
+	if err != nil {
+		return nil, nil, ErrOrderNotFound
+	}
+
+	if !order.CanBeAmended() {
+		return nil, nil, ErrOrderCannotAmend
+	}
+
+	customer, err := uc.customerRepo.GetByID(ctx, order.CustomerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	committed, err := uc.committedQuantities(ctx, order)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	amendment := &entity.SOAmendment{
+		SalesOrderID:    order.ID,
+		Version:         order.Version + 1,
+		Reason:          input.Reason,
+		OldDeliveryDate: order.DeliveryDate,
+		NewDeliveryDate: order.DeliveryDate,
+		OldTotalAmount:  order.TotalAmount,
+		AmendedAt:       time.Now(),
+		AmendedBy:       &input.AmendedBy,
+	}
+	if input.DeliveryDate != nil && (order.DeliveryDate == nil || !input.DeliveryDate.Equal(*order.DeliveryDate)) {
+		amendment.NewDeliveryDate = input.DeliveryDate
+	}
+
+	// Resolve prices of added lines entered without one, skipping those with
+	// no quantity as they are not added
+	var toPrice []pricing.PriceQueryItem
+	for _, line := range input.Lines {
+		if line.LineID == nil && line.Quantity > 0 && line.UnitPrice == nil {
+			toPrice = append(toPrice, pricing.PriceQueryItem{ProductID: line.ProductID, Quantity: line.Quantity})
+		}
+	}
+	prices, err := uc.pricing.Resolve(ctx, customer, order.Channel, time.Now(), toPrice)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nextLineNumber := 1
+	for _, item := range order.LineItems {
+		if item.LineNumber >= nextLineNumber {
+			nextLineNumber = item.LineNumber + 1
+		}
+	}
+
+	// Apply the line changes to a copy of the order lines
+	lines := make([]entity.SOLineItem, len(order.LineItems))
+	copy(lines, order.LineItems)
+	removed := make(map[uuid.UUID]bool)
+	var added []entity.SOLineItem
+	for _, change := range input.Lines {
+		if change.LineID == nil {
+			if change.Quantity <= 0 {
+				continue
+			}
+			item := entity.SOLineItem{
+				ID:              uuid.New(),
+				SalesOrderID:    order.ID,
+				LineNumber:      nextLineNumber,
+				ProductID:       change.ProductID,
+				ProductCode:     change.ProductCode,
+				ProductName:     change.ProductName,
+				Quantity:        change.Quantity,
+				UomID:           change.UomID,
+				PriceSource:     entity.PriceSourceManual,
+				DiscountPercent: change.DiscountPercent,
+				TaxPercent:      change.TaxPercent,
+				Notes:           change.Notes,
+			}
+			if change.UnitPrice != nil {
+				item.UnitPrice = *change.UnitPrice
+			} else {
+				price := prices[0]
+				prices = prices[1:]
+				item.UnitPrice = price.UnitPrice
+				item.PriceSource = price.Source
+				item.PriceListID = price.PriceListID
+			}
+			item.CalculateLineTotal()
+			nextLineNumber++
+
+			added = append(added, item)
+			amendment.Lines = append(amendment.Lines, entity.SOLineAmendment{
+				SOLineItemID: item.ID,
+				Version:      amendment.Version,
+				LineNumber:   item.LineNumber,
+				ProductID:    item.ProductID,
+				ProductCode:  item.ProductCode,
+				ChangeType:   entity.LineChangeAdded,
+				NewQuantity:  item.Quantity,
+				NewUnitPrice: item.UnitPrice,
+				NewLineTotal: item.LineTotal,
+			})
+			continue
+		}
+
+		item := lineByID(lines, *change.LineID)
+		if item == nil || removed[item.ID] {
+			return nil, nil, ErrOrderLineNotFound
+		}
+		if item.IsFreeGoods() {
+			return nil, nil, ErrAmendsFreeGoods
+		}
+
+		quantity := change.Quantity
+		if quantity < 0 {
+			quantity = 0
+		}
+		unitPrice := item.UnitPrice
+		if change.UnitPrice != nil {
+			unitPrice = *change.UnitPrice
+		}
+		if quantity == item.Quantity && unitPrice == item.UnitPrice {
+			continue
+		}
+
+		// Picked and shipped quantities are out of the order's hands
+		if quantity < committed[item.ID] || (unitPrice != item.UnitPrice && committed[item.ID] > 0) {
+			return nil, nil, ErrAmendsPickedQuantity
+		}
+
+		lineAmendment := entity.SOLineAmendment{
+			SOLineItemID: item.ID,
+			Version:      amendment.Version,
+			LineNumber:   item.LineNumber,
+			ProductID:    item.ProductID,
+			ProductCode:  item.ProductCode,
+			ChangeType:   entity.LineChangeChanged,
+			OldQuantity:  item.Quantity,
+			NewQuantity:  quantity,
+			OldUnitPrice: item.UnitPrice,
+			NewUnitPrice: unitPrice,
+			OldLineTotal: item.LineTotal,
+		}
+
+		reduceQuantity(item, quantity)
+		if unitPrice != item.UnitPrice {
+			item.UnitPrice = unitPrice
+			item.PriceSource = entity.PriceSourceManual
+			item.PriceListID = nil
+		}
+		if quantity == 0 {
+			lineAmendment.ChangeType = entity.LineChangeRemoved
+			lineAmendment.NewUnitPrice = 0
+			removed[item.ID] = true
+		} else {
+			item.CalculateLineTotal()
+			lineAmendment.NewLineTotal = item.LineTotal
+		}
+		amendment.Lines = append(amendment.Lines, lineAmendment)
+	}
+
+	if len(amendment.Lines) == 0 && amendment.NewDeliveryDate == amendment.OldDeliveryDate {
+		return nil, nil, ErrAmendmentEmpty
+	}
+
+	// Apply the amended lines to the order
+	oldLines := order.LineItems
+	order.LineItems = order.LineItems[:0:0]
+	for _, item := range lines {
+		if !removed[item.ID] {
+			order.LineItems = append(order.LineItems, item)
+		}
+	}
+	order.LineItems = append(order.LineItems, added...)
+	order.DeliveryDate = amendment.NewDeliveryDate
+
+	// Promotions are booked against the amended lines with the rest of the
+	// amendment, so a failure leaves neither budgets nor the order changed
+	var released, applied []entity.OrderPromotion
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if len(amendment.Lines) > 0 {
+			var freeGoods []entity.SOLineItem
+			for _, item := range order.LineItems {
+				if item.IsFreeGoods() {
+					freeGoods = append(freeGoods, item)
+				}
+			}
+
+			var err error
+			released, applied, err = uc.promotions.Reapply(ctx, customer, order)
+			if err != nil {
+				return err
+			}
+			earned, err := matchFreeGoods(order, freeGoods, committed, amendment, removed, nextLineNumber)
+			if err != nil {
+				return err
+			}
+			added = append(added, earned...)
+		}
+
+		order.CalculateTotals()
+		amendment.NewTotalAmount = order.TotalAmount
+
+		// Only the increase needs credit, the rest was checked on confirmation
+		delta := order.TotalAmount - amendment.OldTotalAmount
+		if uc.enableCreditCheck && delta > 0 {
+			credit, err := uc.checkCredit.Execute(ctx, order.CustomerID, delta)
+			if err != nil {
+				return err
+			}
+			if !credit.WithinLimit {
+				return ErrInsufficientCredit
+			}
+		}
+
+		// Persist the line changes before the order, whose save only inserts new lines
+		for i := range oldLines {
+			if removed[oldLines[i].ID] {
+				if err := uc.orderRepo.DeleteLineItem(ctx, oldLines[i].ID); err != nil {
+					return err
+				}
+			}
+		}
+		for i := range order.LineItems {
+			item := &order.LineItems[i]
+			if lineAmendmentOf(amendment, item.ID) == nil {
+				continue
+			}
+			if isAdded(added, item.ID) {
+				if err := uc.orderRepo.CreateLineItem(ctx, item); err != nil {
+					return err
+				}
+			} else if err := uc.orderRepo.UpdateLineItem(ctx, item); err != nil {
+				return err
+			}
+		}
+
+		// Keep the customer balance in step with the order total, released on cancel
+		if delta != 0 {
+			if err := uc.customerRepo.UpdateBalance(ctx, order.CustomerID, delta); err != nil {
+				return err
+			}
+		}
+
+		order.Amend(input.AmendedBy)
+
+		if err := uc.orderRepo.Update(ctx, order); err != nil {
+			return err
+		}
+		return uc.orderRepo.CreateAmendment(ctx, amendment)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	uc.promotions.PublishRelease(order, released)
+	uc.promotions.PublishApplied(order, applied)
+
+	// Publish event -> WMS will reserve or release the quantity changes
+	if uc.eventPub != nil {
+		e := &event.OrderAmendedEvent{
+			SOID:             order.ID.String(),
+			SONumber:         order.SONumber,
+			CustomerID:       order.CustomerID.String(),
+			CustomerPriority: customer.AllocationPriority(),
+			SODate:           order.SODate.Format("2006-01-02"),
+			Version:          order.Version,
+			OldTotalAmount:   amendment.OldTotalAmount,
+			TotalAmount:      order.TotalAmount,
+			Reason:           amendment.Reason,
+		}
+		if order.DeliveryDate != nil {
+			e.DeliveryDate = order.DeliveryDate.Format("2006-01-02")
+		}
+		for _, line := range amendment.Lines {
+			amended := event.AmendedLineEvent{
+				LineID:        line.SOLineItemID.String(),
+				ProductID:     line.ProductID.String(),
+				ProductCode:   line.ProductCode,
+				ChangeType:    string(line.ChangeType),
+				OldQuantity:   line.OldQuantity,
+				NewQuantity:   line.NewQuantity,
+				DeltaQuantity: line.DeltaQuantity(),
+			}
+			if item := lineByID(oldLines, line.SOLineItemID); item != nil && item.UomID != nil {
+				amended.UomID = item.UomID.String()
+			} else if item := lineByID(added, line.SOLineItemID); item != nil && item.UomID != nil {
+				amended.UomID = item.UomID.String()
+			}
+			e.Lines = append(e.Lines, amended)
+		}
+		uc.eventPub.PublishOrderAmended(e)
+	}
+
+	return order, amendment, nil
+}
+
+// committedQuantities returns the picked and shipped quantity of each order
+// line. A picked shipment without lines covers the whole order.
+func (uc *AmendOrderUseCase) committedQuantities(ctx context.Context, order *entity.SalesOrder) (map[uuid.UUID]float64, error) {
+	committed := make(map[uuid.UUID]float64)
+	for i := range order.LineItems {
+		committed[order.LineItems[i].ID] = order.ShippedQuantityOf(&order.LineItems[i])
+	}
+
+	shipments, err := uc.shipmentRepo.GetBySalesOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, shipment := range shipments {
+		if shipment.Status != entity.ShipmentStatusPicked && shipment.Status != entity.ShipmentStatusPacked {
+			continue
+		}
+		if len(shipment.LineItems) == 0 {
+			for i := range order.LineItems {
+				committed[order.LineItems[i].ID] = order.LineItems[i].Quantity
+			}
+			return committed, nil
+		}
+		for _, line := range shipment.LineItems {
+			committed[line.SOLineItemID] += line.Quantity
+		}
+	}
+
+	return committed, nil
+}
+
+// matchFreeGoods keeps the free goods lines earned again on the amended
+// order, changing their quantity where it changed, adds the new ones and
+// removes those no longer earned. Free goods picked or shipped cannot be
+// taken back.
+func matchFreeGoods(order *entity.SalesOrder, before []entity.SOLineItem, committed map[uuid.UUID]float64, amendment *entity.SOAmendment, removed map[uuid.UUID]bool, nextLineNumber int) ([]entity.SOLineItem, error) {
+	matched := make(map[uuid.UUID]bool)
+	var added []entity.SOLineItem
+	for i := range order.LineItems {
+		item := &order.LineItems[i]
+		if !item.IsFreeGoods() || item.ID != uuid.Nil {
+			continue
+		}
+
+		var old *entity.SOLineItem
+		for j := range before {
+			if !matched[before[j].ID] && *before[j].PromotionID == *item.PromotionID && before[j].ProductID == item.ProductID {
+				old = &before[j]
+				break
+			}
+		}
+		if old == nil {
+			item.ID = uuid.New()
+			item.SalesOrderID = order.ID
+			item.LineNumber = nextLineNumber
+			nextLineNumber++
+			added = append(added, *item)
+			amendment.Lines = append(amendment.Lines, entity.SOLineAmendment{
+				SOLineItemID: item.ID,
+				Version:      amendment.Version,
+				LineNumber:   item.LineNumber,
+				ProductID:    item.ProductID,
+				ProductCode:  item.ProductCode,
+				ChangeType:   entity.LineChangeAdded,
+				NewQuantity:  item.Quantity,
+				NewUnitPrice: item.UnitPrice,
+				NewLineTotal: item.LineTotal,
+			})
+			continue
+		}
+
+		matched[old.ID] = true
+		if item.Quantity < committed[old.ID] {
+			return nil, ErrAmendsPickedQuantity
+		}
+
+		// Keep the existing line with its reservation and shipped quantity
+		quantity := item.Quantity
+		*item = *old
+		if quantity == old.Quantity {
+			continue
+		}
+		reduceQuantity(item, quantity)
+		item.CalculateLineTotal()
+		amendment.Lines = append(amendment.Lines, entity.SOLineAmendment{
+			SOLineItemID: item.ID,
+			Version:      amendment.Version,
+			LineNumber:   item.LineNumber,
+			ProductID:    item.ProductID,
+			ProductCode:  item.ProductCode,
+			ChangeType:   entity.LineChangeChanged,
+			OldQuantity:  old.Quantity,
+			NewQuantity:  item.Quantity,
+			OldUnitPrice: old.UnitPrice,
+			NewUnitPrice: item.UnitPrice,
+			OldLineTotal: old.LineTotal,
+			NewLineTotal: item.LineTotal,
+		})
+	}
+
+	for _, old := range before {
+		if matched[old.ID] {
+			continue
+		}
+		if committed[old.ID] > 0 {
+			return nil, ErrAmendsPickedQuantity
+		}
+		removed[old.ID] = true
+		amendment.Lines = append(amendment.Lines, entity.SOLineAmendment{
+			SOLineItemID: old.ID,
+			Version:      amendment.Version,
+			LineNumber:   old.LineNumber,
+			ProductID:    old.ProductID,
+			ProductCode:  old.ProductCode,
+			ChangeType:   entity.LineChangeRemoved,
+			OldQuantity:  old.Quantity,
+			OldUnitPrice: old.UnitPrice,
+			OldLineTotal: old.LineTotal,
+		})
+	}
+	return added, nil
+}
+
+// reduceQuantity sets the quantity of a line. A reduction comes off the
+// backordered quantity first.
+func reduceQuantity(item *entity.SOLineItem, quantity float64) {
+	if reduced := item.Quantity - quantity; reduced > 0 && item.BackorderedQuantity > 0 {
+		item.BackorderedQuantity -= reduced
+		if item.BackorderedQuantity <= 0 {
+			item.BackorderedQuantity = 0
+			item.ExpectedDate = nil
+		}
+	}
+	item.Quantity = quantity
+}
+
+func lineByID(lines []entity.SOLineItem, id uuid.UUID) *entity.SOLineItem {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
+
+func lineAmendmentOf(amendment *entity.SOAmendment, lineID uuid.UUID) *entity.SOLineAmendment {
+	for i := range amendment.Lines {
+		if amendment.Lines[i].SOLineItemID == lineID {
+			return &amendment.Lines[i]
+		}
+	}
+	return nil
+}
+
+func isAdded(added []entity.SOLineItem, id uuid.UUID) bool {
+	return lineByID(added, id) != nil
+}
+
+// GetAmendmentsUseCase handles listing the amendments of a sales order
+type GetAmendmentsUseCase struct {
+	orderRepo repository.SalesOrderRepository
+}
+
+// NewGetAmendmentsUseCase creates a new use case
+func NewGetAmendmentsUseCase(orderRepo repository.SalesOrderRepository) *GetAmendmentsUseCase {
+	return &GetAmendmentsUseCase{orderRepo: orderRepo}
+}
+
+// Execute lists the amendments of a sales order, latest version first
+func (uc *GetAmendmentsUseCase) Execute(ctx context.Context, orderID uuid.UUID) ([]*entity.SOAmendment, error) {
+	if _, err := uc.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, ErrOrderNotFound
+	}
+	return uc.orderRepo.GetAmendments(ctx, orderID)
+}
+
+// Backorder updates received from WMS
+const (
+	BackorderStatusBackordered = "BACKORDERED" // Lines could not be reserved in full
+	BackorderStatusAllocated   = "ALLOCATED"   // Received stock was reserved for the lines
+	BackorderStatusRescheduled = "RESCHEDULED" // The expected date changed
+)
+
+// BackorderUpdateInput represents backordered lines of a sales order reported by WMS
+type BackorderUpdateInput struct {
+	SalesOrderID uuid.UUID
+	Status       string
+	Lines        []BackorderLineInput
+}
+
+// BackorderLineInput represents the backordered quantity of an order line
+type BackorderLineInput struct {
+	LineID         uuid.UUID
+	BackorderedQty float64 // Still waiting for stock
+	ExpectedDate   *time.Time
+}
+
+// UpdateBackordersUseCase records backordered quantities on order lines
+type UpdateBackordersUseCase struct {
+	orderRepo repository.SalesOrderRepository
+	eventPub  *event.Publisher
+}
+
+// NewUpdateBackordersUseCase creates a new use case
+func NewUpdateBackordersUseCase(orderRepo repository.SalesOrderRepository, eventPub *event.Publisher) *UpdateBackordersUseCase {
+	return &UpdateBackordersUseCase{
+		orderRepo: orderRepo,
+		eventPub:  eventPub,
+	}
+}
+
+// Execute updates the backordered quantity and expected date of the order
+// lines, and notifies the customer
+func (uc *UpdateBackordersUseCase) Execute(ctx context.Context, input *BackorderUpdateInput) (*entity.SalesOrder, error) {
+	switch input.Status {
+	case BackorderStatusBackordered, BackorderStatusAllocated, BackorderStatusRescheduled:
+	default:
+		return nil, ErrInvalidBackorderStatus
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, input.SalesOrderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	var lines []event.BackorderedLineEvent
+	for _, update := range input.Lines {
+		for i := range order.LineItems {
+			item := &order.LineItems[i]
+			if item.ID != update.LineID {
+				continue
+			}
+
+			item.BackorderedQuantity = update.BackorderedQty
+			item.ExpectedDate = update.ExpectedDate
+			if item.BackorderedQuantity <= 0 {
+				item.BackorderedQuantity = 0
+				item.ExpectedDate = nil
+			}
+			if err := uc.orderRepo.UpdateLineItem(ctx, item); err != nil {
+				return nil, err
+			}
+
+			line := event.BackorderedLineEvent{
+				LineID:         item.ID.String(),
+				ProductCode:    item.ProductCode,
+				ProductName:    item.ProductName,
+				Quantity:       item.Quantity,
+				BackorderedQty: item.BackorderedQuantity,
+			}
+			if item.ExpectedDate != nil {
+				line.ExpectedDate = item.ExpectedDate.Format("2006-01-02")
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	// Publish event -> notification service informs the customer
+	if uc.eventPub != nil && len(lines) > 0 {
+		e := &event.OrderBackorderedEvent{
+			SOID:       order.ID.String(),
+			SONumber:   order.SONumber,
+			CustomerID: order.CustomerID.String(),
+			Status:     input.Status,
+			Lines:      lines,
+		}
+		if order.Customer != nil {
+			e.CustomerName = order.Customer.Name
+			e.CustomerEmail = order.Customer.Email
+		}
+		uc.eventPub.PublishOrderBackordered(e)
+	}
+
+	return order, nil
+}
+
 // ShipOrderUseCase handles shipping sales order
 type ShipOrderUseCase struct {
 	orderRepo repository.SalesOrderRepository
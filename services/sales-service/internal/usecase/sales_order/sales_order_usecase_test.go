@@ -0,0 +1,667 @@
+package sales_order_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	customeruc "github.com/erp-cosmetics/sales-service/internal/usecase/customer"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/pricing"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/promotion"
+	salesorder "github.com/erp-cosmetics/sales-service/internal/usecase/sales_order"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type txKey struct{}
+
+// recordingTransactor marks the context of the transaction so tests can
+// check which calls ran inside it
+type recordingTransactor struct{}
+
+func (recordingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+func inTx() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+}
+
+func price(p float64) *float64 {
+	return &p
+}
+
+type createOrderFixture struct {
+	orderRepo     *testmocks.MockSalesOrderRepository
+	customerRepo  *testmocks.MockCustomerRepository
+	promotionRepo *testmocks.MockPromotionRepository
+	customer      *entity.Customer
+	voucher       *entity.Voucher
+	date          time.Time
+	uc            *salesorder.CreateOrderUseCase
+}
+
+func newCreateOrderFixture(ctx context.Context) *createOrderFixture {
+	f := &createOrderFixture{
+		orderRepo:     new(testmocks.MockSalesOrderRepository),
+		customerRepo:  new(testmocks.MockCustomerRepository),
+		promotionRepo: new(testmocks.MockPromotionRepository),
+		customer:      &entity.Customer{ID: uuid.New(), Currency: "VND"},
+		date:          time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC),
+	}
+
+	resolver := pricing.NewResolvePricesUseCase(new(testmocks.MockPriceListRepository), f.customerRepo, new(testmocks.MockProductCatalog))
+	promotions := promotion.NewApplyPromotionsUseCase(f.promotionRepo, resolver, nil)
+	f.uc = salesorder.NewCreateOrderUseCase(f.orderRepo, f.customerRepo, resolver, promotions, nil, recordingTransactor{}, nil)
+
+	promo := &entity.Promotion{
+		ID:              uuid.New(),
+		Code:            "VIP-TIER",
+		PromotionType:   entity.PromotionTypeCartTiered,
+		ValidFrom:       f.date.AddDate(0, -1, 0),
+		RequiresVoucher: true,
+		IsActive:        true,
+		Budget:          1000000,
+		Tiers:           []entity.PromotionTier{{MinAmount: 0, DiscountAmount: 50000}},
+	}
+	f.voucher = &entity.Voucher{ID: uuid.New(), Code: "VIP-001", PromotionID: promo.ID, Promotion: promo, MaxUses: 1, IsActive: true}
+
+	f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil)
+	f.orderRepo.On("GetNextSONumber", ctx).Return("SO-2605-0001", nil)
+	f.promotionRepo.On("GetAutomatic", ctx, f.date).Return([]*entity.Promotion{}, nil)
+	f.promotionRepo.On("GetVoucherByCode", ctx, "VIP-001").Return(f.voucher, nil)
+	return f
+}
+
+func (f *createOrderFixture) input() *salesorder.CreateOrderInput {
+	return &salesorder.CreateOrderInput{
+		CustomerID:   f.customer.ID,
+		SODate:       f.date,
+		Items:        []salesorder.OrderItemInput{{ProductID: uuid.New(), Quantity: 10, UnitPrice: price(100000)}},
+		VoucherCodes: []string{"VIP-001"},
+	}
+}
+
+func TestCreateOrderUseCase_Execute_BooksVoucherWithOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateOrderFixture(ctx)
+
+	f.orderRepo.On("Create", inTx(), mock.AnythingOfType("*entity.SalesOrder")).Return(nil)
+	f.promotionRepo.On("AddUsage", inTx(), f.voucher.PromotionID, 50000.0, 1).Return(true, nil)
+	f.promotionRepo.On("AddVoucherUse", inTx(), f.voucher.ID, 1).Return(true, nil)
+
+	// Act
+	res, err := f.uc.Execute(ctx, f.input())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 50000.0, res.PromotionDiscount)
+	f.orderRepo.AssertExpectations(t)
+	f.promotionRepo.AssertExpectations(t)
+}
+
+func TestCreateOrderUseCase_Execute_VoucherRedeemedConcurrently(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateOrderFixture(ctx)
+
+	// The voucher had a use left when the promotions were applied, but another
+	// order took it before this one was stored
+	f.orderRepo.On("Create", inTx(), mock.AnythingOfType("*entity.SalesOrder")).Return(nil)
+	f.promotionRepo.On("AddUsage", inTx(), f.voucher.PromotionID, 50000.0, 1).Return(true, nil)
+	f.promotionRepo.On("AddVoucherUse", inTx(), f.voucher.ID, 1).Return(false, nil)
+
+	// Act
+	res, err := f.uc.Execute(ctx, f.input())
+
+	// Assert
+	assert.Nil(t, res)
+	assert.True(t, errors.Is(err, promotion.ErrVoucherNotUsable))
+}
+
+func TestCreateOrderUseCase_Execute_StoreFailureBooksNothing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateOrderFixture(ctx)
+
+	f.orderRepo.On("Create", inTx(), mock.AnythingOfType("*entity.SalesOrder")).Return(errors.New("connection reset"))
+
+	// Act
+	_, err := f.uc.Execute(ctx, f.input())
+
+	// Assert
+	assert.Error(t, err)
+	f.promotionRepo.AssertNotCalled(t, "AddUsage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	f.promotionRepo.AssertNotCalled(t, "AddVoucherUse", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateOrderUseCase_Execute_InvalidChannel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateOrderFixture(ctx)
+
+	input := f.input()
+	input.Channel = "WHOLESALE"
+
+	// Act
+	_, err := f.uc.Execute(ctx, input)
+
+	// Assert
+	assert.Equal(t, pricing.ErrInvalidChannel, err)
+	f.orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+type amendOrderFixture struct {
+	orderRepo     *testmocks.MockSalesOrderRepository
+	customerRepo  *testmocks.MockCustomerRepository
+	shipmentRepo  *testmocks.MockShipmentRepository
+	priceListRepo *testmocks.MockPriceListRepository
+	promotionRepo *testmocks.MockPromotionRepository
+	customer      *entity.Customer
+	order         *entity.SalesOrder
+	uc            *salesorder.AmendOrderUseCase
+}
+
+// newAmendOrderFixture returns a confirmed order of 10 x 100,000 and 5 x 20,000
+func newAmendOrderFixture(ctx context.Context) *amendOrderFixture {
+	f := &amendOrderFixture{
+		orderRepo:     new(testmocks.MockSalesOrderRepository),
+		customerRepo:  new(testmocks.MockCustomerRepository),
+		shipmentRepo:  new(testmocks.MockShipmentRepository),
+		priceListRepo: new(testmocks.MockPriceListRepository),
+		promotionRepo: new(testmocks.MockPromotionRepository),
+		customer:      &entity.Customer{ID: uuid.New(), Currency: "VND"},
+	}
+
+	resolver := pricing.NewResolvePricesUseCase(f.priceListRepo, f.customerRepo, new(testmocks.MockProductCatalog))
+	promotions := promotion.NewApplyPromotionsUseCase(f.promotionRepo, resolver, nil)
+	f.uc = salesorder.NewAmendOrderUseCase(f.orderRepo, f.customerRepo, f.shipmentRepo, resolver, promotions, nil, recordingTransactor{}, nil, false)
+
+	f.order = &entity.SalesOrder{
+		ID:         uuid.New(),
+		SONumber:   "SO-2605-0001",
+		CustomerID: f.customer.ID,
+		Channel:    entity.SalesChannelDirect,
+		Status:     entity.SOStatusConfirmed,
+		Version:    1,
+		LineItems: []entity.SOLineItem{
+			{ID: uuid.New(), LineNumber: 1, ProductID: uuid.New(), Quantity: 10, UnitPrice: 100000},
+			{ID: uuid.New(), LineNumber: 2, ProductID: uuid.New(), Quantity: 5, UnitPrice: 20000},
+		},
+	}
+	for i := range f.order.LineItems {
+		f.order.LineItems[i].CalculateLineTotal()
+	}
+	f.order.CalculateTotals()
+
+	f.orderRepo.On("GetByID", ctx, f.order.ID).Return(f.order, nil)
+	f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil)
+	return f
+}
+
+// expectPromotions returns the promotions booked by the order and the
+// automatic promotions evaluated again on the amended lines
+func (f *amendOrderFixture) expectPromotions(booked []*entity.OrderPromotion, automatic []*entity.Promotion) {
+	f.promotionRepo.On("GetOrderPromotions", inTx(), f.order.ID).Return(booked, nil)
+	f.promotionRepo.On("GetAutomatic", inTx(), f.order.SODate).Return(automatic, nil)
+}
+
+// expectSave accepts the writes of a successful amendment, in its transaction
+func (f *amendOrderFixture) expectSave(delta float64) {
+	f.orderRepo.On("CreateLineItem", inTx(), mock.AnythingOfType("*entity.SOLineItem")).Return(nil)
+	f.orderRepo.On("UpdateLineItem", inTx(), mock.AnythingOfType("*entity.SOLineItem")).Return(nil)
+	f.orderRepo.On("DeleteLineItem", inTx(), mock.AnythingOfType("uuid.UUID")).Return(nil)
+	f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+	f.orderRepo.On("CreateAmendment", inTx(), mock.AnythingOfType("*entity.SOAmendment")).Return(nil)
+	f.customerRepo.On("UpdateBalance", inTx(), f.customer.ID, delta).Return(nil)
+}
+
+func TestAmendOrderUseCase_Execute_QuantityDeltas(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newAmendOrderFixture(ctx)
+
+	reduced, removed := f.order.LineItems[0], f.order.LineItems[1]
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{}, nil)
+	f.expectPromotions(nil, nil)
+	f.expectSave(-500000)
+
+	// Act
+	res, amendment, err := f.uc.Execute(ctx, &salesorder.AmendOrderInput{
+		OrderID: f.order.ID,
+		Reason:  "Customer reduced the order",
+		Lines: []salesorder.AmendLineInput{
+			{LineID: &reduced.ID, Quantity: 6},
+			{LineID: &removed.ID, Quantity: 0},
+		},
+		AmendedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Version)
+	assert.Len(t, res.LineItems, 1)
+	assert.Equal(t, 1100000.0, amendment.OldTotalAmount)
+	assert.Equal(t, 600000.0, amendment.NewTotalAmount)
+
+	assert.Len(t, amendment.Lines, 2)
+	assert.Equal(t, entity.LineChangeChanged, amendment.Lines[0].ChangeType)
+	assert.Equal(t, -4.0, amendment.Lines[0].DeltaQuantity())
+	assert.Equal(t, entity.LineChangeRemoved, amendment.Lines[1].ChangeType)
+	assert.Equal(t, -5.0, amendment.Lines[1].DeltaQuantity())
+	f.orderRepo.AssertCalled(t, "DeleteLineItem", inTx(), removed.ID)
+	f.customerRepo.AssertExpectations(t)
+}
+
+func TestAmendOrderUseCase_Execute_AddedLinesKeepTheirPrices(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newAmendOrderFixture(ctx)
+
+	skipped, serum, toner := uuid.New(), uuid.New(), uuid.New()
+	list := &entity.PriceList{
+		ID:        uuid.New(),
+		Code:      "PL-STD",
+		ListType:  entity.PriceListTypeStandard,
+		ValidFrom: time.Now().AddDate(-1, 0, 0),
+		IsActive:  true,
+		Items: []entity.PriceListItem{
+			{ProductID: skipped, UnitPrice: 999000},
+			{ProductID: serum, UnitPrice: 350000},
+			{ProductID: toner, UnitPrice: 150000},
+		},
+	}
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{}, nil)
+	f.priceListRepo.On("GetValidForProducts", ctx, mock.AnythingOfType("time.Time"), []uuid.UUID{serum, toner}).Return([]*entity.PriceList{list}, nil)
+	f.expectPromotions(nil, nil)
+	f.expectSave(2*350000.0 + 3*150000.0)
+
+	// Act
+	res, amendment, err := f.uc.Execute(ctx, &salesorder.AmendOrderInput{
+		OrderID: f.order.ID,
+		Lines: []salesorder.AmendLineInput{
+			{ProductID: skipped, Quantity: 0}, // An added line without quantity is ignored
+			{ProductID: serum, Quantity: 2},
+			{ProductID: toner, Quantity: 3},
+		},
+		AmendedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.LineItems, 4)
+	assert.Equal(t, serum, res.LineItems[2].ProductID)
+	assert.Equal(t, 350000.0, res.LineItems[2].UnitPrice)
+	assert.Equal(t, 3, res.LineItems[2].LineNumber)
+	assert.Equal(t, toner, res.LineItems[3].ProductID)
+	assert.Equal(t, 150000.0, res.LineItems[3].UnitPrice)
+	assert.Len(t, amendment.Lines, 2)
+	assert.Equal(t, entity.LineChangeAdded, amendment.Lines[0].ChangeType)
+	assert.Equal(t, 2.0, amendment.Lines[0].DeltaQuantity())
+}
+
+func TestAmendOrderUseCase_Execute_CannotReducePickedQuantity(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newAmendOrderFixture(ctx)
+
+	line := f.order.LineItems[0]
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{
+		{Status: entity.ShipmentStatusPicked, LineItems: []entity.ShipmentLineItem{{SOLineItemID: line.ID, Quantity: 8}}},
+	}, nil)
+
+	// Act
+	_, _, err := f.uc.Execute(ctx, &salesorder.AmendOrderInput{
+		OrderID:   f.order.ID,
+		Lines:     []salesorder.AmendLineInput{{LineID: &line.ID, Quantity: 6}},
+		AmendedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.Equal(t, salesorder.ErrAmendsPickedQuantity, err)
+	f.orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAmendOrderUseCase_Execute_EarnsFreeGoodsAgain(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newAmendOrderFixture(ctx)
+
+	paid := f.order.LineItems[0]
+	promo := &entity.Promotion{
+		ID:            uuid.New(),
+		Code:          "BUY2GET1",
+		PromotionType: entity.PromotionTypeBuyXGetY,
+		IsActive:      true,
+		BuyProductID:  &paid.ProductID,
+		BuyQuantity:   2,
+		FreeProductID: &paid.ProductID,
+		FreeQuantity:  1,
+	}
+	free := entity.SOLineItem{ID: uuid.New(), LineNumber: 3, ProductID: paid.ProductID, Quantity: 5, UnitPrice: 100000, DiscountPercent: 100, PromotionID: &promo.ID}
+	free.CalculateLineTotal()
+	f.order.LineItems = append(f.order.LineItems, free)
+	booked := &entity.OrderPromotion{ID: uuid.New(), PromotionID: promo.ID, PromotionType: entity.PromotionTypeBuyXGetY, Cost: 500000}
+
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{}, nil)
+	f.expectPromotions([]*entity.OrderPromotion{booked}, []*entity.Promotion{promo})
+	f.promotionRepo.On("AddUsage", inTx(), promo.ID, -500000.0, -1).Return(true, nil)
+	f.promotionRepo.On("MarkOrderPromotionReleased", inTx(), booked.ID).Return(nil)
+	f.promotionRepo.On("AddUsage", inTx(), promo.ID, 300000.0, 1).Return(true, nil)
+	f.expectSave(-400000)
+
+	// Act
+	res, amendment, err := f.uc.Execute(ctx, &salesorder.AmendOrderInput{
+		OrderID:   f.order.ID,
+		Lines:     []salesorder.AmendLineInput{{LineID: &paid.ID, Quantity: 6}},
+		AmendedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, res.LineItems, 3)
+	assert.Equal(t, free.ID, res.LineItems[2].ID)
+	assert.Equal(t, 3.0, res.LineItems[2].Quantity)
+
+	assert.Len(t, amendment.Lines, 2)
+	assert.Equal(t, free.ID, amendment.Lines[1].SOLineItemID)
+	assert.Equal(t, entity.LineChangeChanged, amendment.Lines[1].ChangeType)
+	assert.Equal(t, -2.0, amendment.Lines[1].DeltaQuantity())
+	f.orderRepo.AssertNotCalled(t, "CreateLineItem", mock.Anything, mock.Anything)
+	f.promotionRepo.AssertExpectations(t)
+	f.customerRepo.AssertExpectations(t)
+}
+
+func TestAmendOrderUseCase_Execute_FreeGoodsCannotBeAmended(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newAmendOrderFixture(ctx)
+
+	promotionID := uuid.New()
+	free := entity.SOLineItem{ID: uuid.New(), LineNumber: 3, ProductID: uuid.New(), Quantity: 1, DiscountPercent: 100, PromotionID: &promotionID}
+	f.order.LineItems = append(f.order.LineItems, free)
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{}, nil)
+
+	// Act
+	_, _, err := f.uc.Execute(ctx, &salesorder.AmendOrderInput{
+		OrderID:   f.order.ID,
+		Lines:     []salesorder.AmendLineInput{{LineID: &free.ID, Quantity: 3}},
+		AmendedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.Equal(t, salesorder.ErrAmendsFreeGoods, err)
+	f.promotionRepo.AssertNotCalled(t, "GetOrderPromotions", mock.Anything, mock.Anything)
+}
+
+func TestAmendOrderUseCase_Execute_StoreFailureStopsAmendment(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newAmendOrderFixture(ctx)
+
+	line := f.order.LineItems[0]
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{}, nil)
+	f.expectPromotions(nil, nil)
+	f.orderRepo.On("UpdateLineItem", inTx(), mock.AnythingOfType("*entity.SOLineItem")).Return(errors.New("connection reset"))
+
+	// Act
+	_, _, err := f.uc.Execute(ctx, &salesorder.AmendOrderInput{
+		OrderID:   f.order.ID,
+		Lines:     []salesorder.AmendLineInput{{LineID: &line.ID, Quantity: 12}},
+		AmendedBy: uuid.New(),
+	})
+
+	// Assert
+	assert.Error(t, err)
+	f.customerRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+	f.orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	f.orderRepo.AssertNotCalled(t, "CreateAmendment", mock.Anything, mock.Anything)
+}
+
+type creditFixture struct {
+	orderRepo    *testmocks.MockSalesOrderRepository
+	customerRepo *testmocks.MockCustomerRepository
+	invoiceRepo  *testmocks.MockInvoiceRepository
+	holdRepo     *testmocks.MockCreditHoldRepository
+	customer     *entity.Customer
+	order        *entity.SalesOrder
+	confirm      *salesorder.ConfirmOrderUseCase
+}
+
+func newCreditFixture(ctx context.Context, status entity.SOStatus) *creditFixture {
+	f := &creditFixture{
+		orderRepo:    new(testmocks.MockSalesOrderRepository),
+		customerRepo: new(testmocks.MockCustomerRepository),
+		invoiceRepo:  new(testmocks.MockInvoiceRepository),
+		holdRepo:     new(testmocks.MockCreditHoldRepository),
+		customer:     &entity.Customer{ID: uuid.New(), Status: entity.CustomerStatusActive, CreditLimit: 100000000},
+	}
+	f.order = &entity.SalesOrder{
+		ID:          uuid.New(),
+		CustomerID:  f.customer.ID,
+		Status:      status,
+		TotalAmount: 30000000,
+		LineItems:   []entity.SOLineItem{{ID: uuid.New(), ProductID: uuid.New(), Quantity: 100, UnitPrice: 300000}},
+	}
+
+	checkCredit := customeruc.NewCheckCreditUseCase(f.customerRepo, f.invoiceRepo)
+	f.confirm = salesorder.NewConfirmOrderUseCase(f.orderRepo, f.customerRepo, f.holdRepo, checkCredit, recordingTransactor{}, nil, true)
+
+	f.orderRepo.On("GetByID", ctx, f.order.ID).Return(f.order, nil)
+	f.customerRepo.On("GetByID", ctx, f.customer.ID).Return(f.customer, nil)
+	return f
+}
+
+func TestConfirmOrderUseCase_Execute_WithinCreditConfirms(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreditFixture(ctx, entity.SOStatusDraft)
+	userID := uuid.New()
+
+	f.invoiceRepo.On("GetSummary", ctx, f.customer.ID, mock.AnythingOfType("time.Time")).
+		Return(&repository.ARSummary{OpenInvoices: 50000000}, nil)
+	f.customerRepo.On("UpdateBalance", inTx(), f.customer.ID, f.order.TotalAmount).Return(nil)
+	f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+
+	// Act
+	res, err := f.confirm.Execute(ctx, f.order.ID, userID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SOStatusConfirmed, res.Status)
+	assert.Equal(t, userID, *res.ConfirmedBy)
+	f.holdRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestConfirmOrderUseCase_Execute_PlacesOnCreditHold(t *testing.T) {
+	tests := []struct {
+		name      string
+		summary   *repository.ARSummary
+		overLimit bool
+	}{
+		{"over the credit limit", &repository.ARSummary{OpenInvoices: 60000000, UnbilledOrders: 20000000}, true},
+		{"overdue invoices", &repository.ARSummary{OpenInvoices: 10000000, OverdueAmount: 5000000, OverdueInvoices: 1}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			f := newCreditFixture(ctx, entity.SOStatusDraft)
+
+			f.invoiceRepo.On("GetSummary", ctx, f.customer.ID, mock.AnythingOfType("time.Time")).Return(tt.summary, nil)
+			f.holdRepo.On("Create", inTx(), mock.AnythingOfType("*entity.CreditHold")).Return(nil)
+			f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+
+			// Act
+			res, err := f.confirm.Execute(ctx, f.order.ID, uuid.New())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, entity.SOStatusOnCreditHold, res.Status)
+			assert.Nil(t, res.ConfirmedAt)
+			hold := f.holdRepo.Calls[0].Arguments.Get(1).(*entity.CreditHold)
+			assert.Equal(t, entity.CreditHoldStatusPending, hold.Status)
+			assert.Equal(t, tt.overLimit, hold.OverLimit)
+			assert.Equal(t, tt.summary.OverdueInvoices, hold.OverdueInvoices)
+			f.customerRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestApproveCreditHoldUseCase_Execute_ConfirmsHeldOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreditFixture(ctx, entity.SOStatusOnCreditHold)
+	uc := salesorder.NewApproveCreditHoldUseCase(f.holdRepo, f.orderRepo, f.confirm, recordingTransactor{}, nil)
+	reviewer := uuid.New()
+
+	hold := &entity.CreditHold{ID: uuid.New(), SalesOrderID: f.order.ID, Status: entity.CreditHoldStatusPending}
+	f.holdRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	f.holdRepo.On("Update", inTx(), hold).Return(nil)
+	f.customerRepo.On("UpdateBalance", inTx(), f.customer.ID, f.order.TotalAmount).Return(nil)
+	f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, hold.ID, "Prepayment of 50% received", reviewer)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CreditHoldStatusApproved, res.Status)
+	assert.Equal(t, reviewer, *res.ReviewedBy)
+	assert.Equal(t, entity.SOStatusConfirmed, f.order.Status)
+	assert.Equal(t, reviewer, *f.order.ConfirmedBy)
+	f.invoiceRepo.AssertNotCalled(t, "GetSummary", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRejectCreditHoldUseCase_Execute_ReleasesOrderToDraft(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreditFixture(ctx, entity.SOStatusOnCreditHold)
+	uc := salesorder.NewRejectCreditHoldUseCase(f.holdRepo, f.orderRepo, recordingTransactor{}, nil)
+	reviewer := uuid.New()
+
+	hold := &entity.CreditHold{ID: uuid.New(), SalesOrderID: f.order.ID, Status: entity.CreditHoldStatusPending}
+	f.holdRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	f.holdRepo.On("Update", inTx(), hold).Return(nil)
+	f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, hold.ID, "Two invoices over 60 days", reviewer)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CreditHoldStatusRejected, res.Status)
+	assert.Equal(t, entity.SOStatusDraft, f.order.Status)
+	assert.Equal(t, reviewer, *f.order.UpdatedBy)
+	f.customerRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRejectCreditHoldUseCase_Execute_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		reason      string
+		holdStatus  entity.CreditHoldStatus
+		orderStatus entity.SOStatus
+		wantErr     error
+	}{
+		{"reason required", " ", entity.CreditHoldStatusPending, entity.SOStatusOnCreditHold, salesorder.ErrReviewReasonRequired},
+		{"already reviewed", "Limit raised", entity.CreditHoldStatusApproved, entity.SOStatusOnCreditHold, salesorder.ErrCreditHoldReviewed},
+		{"order no longer held", "Limit raised", entity.CreditHoldStatusPending, entity.SOStatusCancelled, salesorder.ErrOrderNotOnCreditHold},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			f := newCreditFixture(ctx, tt.orderStatus)
+			uc := salesorder.NewRejectCreditHoldUseCase(f.holdRepo, f.orderRepo, recordingTransactor{}, nil)
+
+			hold := &entity.CreditHold{ID: uuid.New(), SalesOrderID: f.order.ID, Status: tt.holdStatus}
+			f.holdRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+
+			// Act
+			_, err := uc.Execute(ctx, hold.ID, tt.reason, uuid.New())
+
+			// Assert
+			assert.True(t, errors.Is(err, tt.wantErr))
+			f.holdRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestApproveCreditHoldUseCase_Execute_StoreFailureConfirmsNothing(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreditFixture(ctx, entity.SOStatusOnCreditHold)
+	uc := salesorder.NewApproveCreditHoldUseCase(f.holdRepo, f.orderRepo, f.confirm, recordingTransactor{}, nil)
+
+	hold := &entity.CreditHold{ID: uuid.New(), SalesOrderID: f.order.ID, Status: entity.CreditHoldStatusPending}
+	f.holdRepo.On("GetByID", ctx, hold.ID).Return(hold, nil)
+	f.holdRepo.On("Update", inTx(), hold).Return(nil)
+	f.customerRepo.On("UpdateBalance", inTx(), f.customer.ID, f.order.TotalAmount).Return(errors.New("connection reset"))
+
+	// Act
+	_, err := uc.Execute(ctx, hold.ID, "Prepayment of 50% received", uuid.New())
+
+	// Assert
+	assert.Error(t, err)
+	f.orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCancelOrderUseCase_Execute_RejectsPendingCreditHold(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreditFixture(ctx, entity.SOStatusOnCreditHold)
+	promotionRepo := new(testmocks.MockPromotionRepository)
+	resolver := pricing.NewResolvePricesUseCase(new(testmocks.MockPriceListRepository), f.customerRepo, new(testmocks.MockProductCatalog))
+	uc := salesorder.NewCancelOrderUseCase(f.orderRepo, f.customerRepo, f.holdRepo, promotion.NewApplyPromotionsUseCase(promotionRepo, resolver, nil), recordingTransactor{}, nil)
+	userID := uuid.New()
+
+	hold := &entity.CreditHold{ID: uuid.New(), SalesOrderID: f.order.ID, Status: entity.CreditHoldStatusPending}
+	f.holdRepo.On("GetPendingByOrder", inTx(), f.order.ID).Return(hold, nil)
+	f.holdRepo.On("Update", inTx(), hold).Return(nil)
+	f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+	promotionRepo.On("GetOrderPromotions", inTx(), f.order.ID).Return([]*entity.OrderPromotion{}, nil)
+
+	// Act
+	res, err := uc.Execute(ctx, f.order.ID, userID, "Customer withdrew the order")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.SOStatusCancelled, res.Status)
+	assert.Equal(t, entity.CreditHoldStatusRejected, hold.Status)
+	assert.Equal(t, userID, *hold.ReviewedBy)
+	f.customerRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCancelOrderUseCase_Execute_ReleasesCreditAndPromotionsTogether(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreditFixture(ctx, entity.SOStatusConfirmed)
+	promotionRepo := new(testmocks.MockPromotionRepository)
+	resolver := pricing.NewResolvePricesUseCase(new(testmocks.MockPriceListRepository), f.customerRepo, new(testmocks.MockProductCatalog))
+	uc := salesorder.NewCancelOrderUseCase(f.orderRepo, f.customerRepo, f.holdRepo, promotion.NewApplyPromotionsUseCase(promotionRepo, resolver, nil), recordingTransactor{}, nil)
+
+	op := &entity.OrderPromotion{PromotionID: uuid.New(), Cost: 500000}
+	f.customerRepo.On("UpdateBalance", inTx(), f.customer.ID, -f.order.TotalAmount).Return(nil)
+	f.orderRepo.On("Update", inTx(), f.order).Return(nil)
+	promotionRepo.On("GetOrderPromotions", inTx(), f.order.ID).Return([]*entity.OrderPromotion{op}, nil)
+	promotionRepo.On("AddUsage", inTx(), op.PromotionID, -op.Cost, -1).Return(true, nil)
+	promotionRepo.On("MarkOrderPromotionsReleased", inTx(), f.order.ID).Return(errors.New("connection reset"))
+
+	// Act
+	_, err := uc.Execute(ctx, f.order.ID, uuid.New(), "Duplicate order")
+
+	// Assert
+	assert.EqualError(t, err, "connection reset")
+	f.customerRepo.AssertCalled(t, "UpdateBalance", mock.Anything, f.customer.ID, -f.order.TotalAmount)
+	f.orderRepo.AssertExpectations(t)
+	promotionRepo.AssertExpectations(t)
+}
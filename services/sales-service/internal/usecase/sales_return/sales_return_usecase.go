@@ -0,0 +1,707 @@
+package sales_return
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/receivable"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrReturnNotFound        = errors.New("return not found")
+	ErrOrderNotShipped       = errors.New("sales order has not been shipped")
+	ErrShipmentNotShipped    = errors.New("shipment has not been shipped")
+	ErrShipmentNotForOrder   = errors.New("shipment does not belong to the sales order")
+	ErrNoReturnItems         = errors.New("return must have at least one item")
+	ErrOrderLineNotFound     = errors.New("sales order line not found")
+	ErrInvalidReturnQuantity = errors.New("return quantity must be positive")
+	ErrExceedsReturnable     = errors.New("return quantity exceeds shipped quantity not yet returned")
+	ErrInvalidReturnType     = errors.New("invalid return type")
+	ErrReturnCannotApprove   = errors.New("return cannot be approved")
+	ErrReturnCannotReject    = errors.New("return cannot be rejected")
+	ErrReturnCannotReceive   = errors.New("return cannot be received")
+	ErrReturnCannotInspect   = errors.New("return cannot be inspected")
+	ErrReturnCannotComplete  = errors.New("return cannot be completed")
+	ErrReturnLineNotFound    = errors.New("return line not found")
+	ErrLinesNotInspected     = errors.New("every return line must be inspected")
+	ErrInvalidCondition      = errors.New("invalid item condition")
+	ErrInvalidAction         = errors.New("action must be RESTOCK, DISPOSE or EXCHANGE")
+	ErrCannotRestock         = errors.New("only items in good condition can be restocked")
+)
+
+// CreateReturnInput represents input for creating a return
+type CreateReturnInput struct {
+	SalesOrderID uuid.UUID
+	ShipmentID   uuid.UUID
+	ReturnDate   time.Time
+	ReturnReason string
+	ReturnType   entity.ReturnType
+	Notes        string
+	Items        []ReturnItemInput
+	CreatedBy    *uuid.UUID
+}
+
+// ReturnItemInput represents a returned order line
+type ReturnItemInput struct {
+	SOLineItemID uuid.UUID
+	Quantity     float64
+	LotNumber    string
+	Reason       string
+}
+
+// CreateReturnUseCase handles return (RMA) creation
+type CreateReturnUseCase struct {
+	returnRepo   repository.ReturnRepository
+	orderRepo    repository.SalesOrderRepository
+	shipmentRepo repository.ShipmentRepository
+	eventPub     *event.Publisher
+}
+
+// NewCreateReturnUseCase creates a new use case
+func NewCreateReturnUseCase(
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.SalesOrderRepository,
+	shipmentRepo repository.ShipmentRepository,
+	eventPub *event.Publisher,
+) *CreateReturnUseCase {
+	return &CreateReturnUseCase{
+		returnRepo:   returnRepo,
+		orderRepo:    orderRepo,
+		shipmentRepo: shipmentRepo,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute creates a return against a shipped order and shipment. Each line may
+// return at most the quantity shipped on that shipment less what its open or
+// completed returns already cover, and is valued at the net price charged on
+// the order.
+func (uc *CreateReturnUseCase) Execute(ctx context.Context, input *CreateReturnInput) (*entity.Return, error) {
+	switch input.ReturnType {
+	case "":
+		input.ReturnType = entity.ReturnTypeRefund
+	case entity.ReturnTypeRefund, entity.ReturnTypeCredit, entity.ReturnTypeExchange:
+	default:
+		return nil, ErrInvalidReturnType
+	}
+	if len(input.Items) == 0 {
+		return nil, ErrNoReturnItems
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, input.SalesOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != entity.SOStatusShipped && order.Status != entity.SOStatusDelivered &&
+		order.Status != entity.SOStatusPartiallyShipped {
+		return nil, ErrOrderNotShipped
+	}
+
+	shipment, err := uc.shipmentRepo.GetByID(ctx, input.ShipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if shipment.SalesOrderID != order.ID {
+		return nil, ErrShipmentNotForOrder
+	}
+	if shipment.Status != entity.ShipmentStatusShipped && shipment.Status != entity.ShipmentStatusInTransit &&
+		shipment.Status != entity.ShipmentStatusDelivered {
+		return nil, ErrShipmentNotShipped
+	}
+
+	// What went out on this shipment, less what its returns already cover
+	shipped := make(map[uuid.UUID]float64)
+	for _, item := range shipment.LineItems {
+		shipped[item.SOLineItemID] += item.Quantity
+	}
+	returned, err := uc.returnedQuantities(ctx, order.ID, shipment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	number, err := uc.returnRepo.GetNextReturnNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	returnDate := input.ReturnDate
+	if returnDate.IsZero() {
+		returnDate = time.Now()
+	}
+
+	ret := &entity.Return{
+		ReturnNumber: number,
+		SalesOrderID: order.ID,
+		ShipmentID:   &shipment.ID,
+		ReturnDate:   returnDate,
+		ReturnReason: input.ReturnReason,
+		ReturnType:   input.ReturnType,
+		Status:       entity.ReturnStatusPending,
+		Notes:        input.Notes,
+		CreatedBy:    input.CreatedBy,
+	}
+
+	for _, item := range input.Items {
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidReturnQuantity
+		}
+		line := findOrderLine(order, item.SOLineItemID)
+		if line == nil {
+			return nil, ErrOrderLineNotFound
+		}
+		returned[line.ID] += item.Quantity
+		if returned[line.ID] > shipped[line.ID] {
+			return nil, ErrExceedsReturnable
+		}
+
+		lineID := line.ID
+		ret.LineItems = append(ret.LineItems, entity.ReturnLineItem{
+			SOLineItemID: &lineID,
+			ProductID:    line.ProductID,
+			ProductCode:  line.ProductCode,
+			ProductName:  line.ProductName,
+			Quantity:     item.Quantity,
+			UnitPrice:    order.NetUnitPrice(line),
+			LotNumber:    item.LotNumber,
+			Reason:       item.Reason,
+			Condition:    entity.ItemConditionGood,
+			Action:       entity.ItemActionRestock,
+		})
+	}
+
+	ret.CalculateTotals()
+
+	if err := uc.returnRepo.Create(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	if uc.eventPub != nil {
+		uc.eventPub.PublishReturnCreated(&event.ReturnCreatedEvent{
+			ReturnID:     ret.ID.String(),
+			ReturnNumber: ret.ReturnNumber,
+			SOID:         order.ID.String(),
+			CustomerID:   order.CustomerID.String(),
+			ReturnType:   string(ret.ReturnType),
+			RefundAmount: ret.RefundAmount,
+		})
+	}
+
+	return ret, nil
+}
+
+// returnedQuantities sums the quantities per order line of the returns on a
+// shipment of the order that have not been rejected
+func (uc *CreateReturnUseCase) returnedQuantities(ctx context.Context, orderID, shipmentID uuid.UUID) (map[uuid.UUID]float64, error) {
+	returns, err := uc.returnRepo.GetBySalesOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	returned := make(map[uuid.UUID]float64)
+	for _, r := range returns {
+		if r.Status == entity.ReturnStatusRejected || r.ShipmentID == nil || *r.ShipmentID != shipmentID {
+			continue
+		}
+		items, err := uc.returnRepo.GetLineItems(ctx, r.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.SOLineItemID != nil {
+				returned[*item.SOLineItemID] += item.Quantity
+			}
+		}
+	}
+	return returned, nil
+}
+
+// findOrderLine returns the order line with the given ID
+func findOrderLine(order *entity.SalesOrder, lineID uuid.UUID) *entity.SOLineItem {
+	for i := range order.LineItems {
+		if order.LineItems[i].ID == lineID {
+			return &order.LineItems[i]
+		}
+	}
+	return nil
+}
+
+// GetReturnUseCase handles getting a return
+type GetReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// NewGetReturnUseCase creates a new use case
+func NewGetReturnUseCase(repo repository.ReturnRepository) *GetReturnUseCase {
+	return &GetReturnUseCase{returnRepo: repo}
+}
+
+// Execute gets a return by ID
+func (uc *GetReturnUseCase) Execute(ctx context.Context, id uuid.UUID) (*entity.Return, error) {
+	return uc.returnRepo.GetByID(ctx, id)
+}
+
+// ListReturnsUseCase handles listing returns
+type ListReturnsUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// NewListReturnsUseCase creates a new use case
+func NewListReturnsUseCase(repo repository.ReturnRepository) *ListReturnsUseCase {
+	return &ListReturnsUseCase{returnRepo: repo}
+}
+
+// Execute lists returns with filters
+func (uc *ListReturnsUseCase) Execute(ctx context.Context, filter *repository.ReturnFilter) ([]*entity.Return, int64, error) {
+	return uc.returnRepo.List(ctx, filter)
+}
+
+// ApproveReturnUseCase handles return approval
+type ApproveReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// NewApproveReturnUseCase creates a new use case
+func NewApproveReturnUseCase(repo repository.ReturnRepository) *ApproveReturnUseCase {
+	return &ApproveReturnUseCase{returnRepo: repo}
+}
+
+// Execute approves a pending return so the goods can be sent back
+func (uc *ApproveReturnUseCase) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	if !ret.CanBeApproved() {
+		return nil, ErrReturnCannotApprove
+	}
+
+	ret.Approve(userID)
+	ret.UpdatedBy = &userID
+
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// RejectReturnUseCase handles return rejection
+type RejectReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// NewRejectReturnUseCase creates a new use case
+func NewRejectReturnUseCase(repo repository.ReturnRepository) *RejectReturnUseCase {
+	return &RejectReturnUseCase{returnRepo: repo}
+}
+
+// Execute rejects a return before the goods are received
+func (uc *RejectReturnUseCase) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID, reason string) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	if !ret.CanBeRejected() {
+		return nil, ErrReturnCannotReject
+	}
+
+	ret.Reject()
+	ret.UpdatedBy = &userID
+	if reason != "" {
+		if ret.Notes != "" {
+			ret.Notes += "\n"
+		}
+		ret.Notes += "Rejected: " + reason
+	}
+
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ReceiveReturnInput represents input for receiving returned goods
+type ReceiveReturnInput struct {
+	ReturnID uuid.UUID
+	Lots     map[uuid.UUID]string // Return line ID -> lot number read from the packaging
+	UserID   uuid.UUID
+}
+
+// ReceiveReturnUseCase handles receipt of returned goods
+type ReceiveReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+	eventPub   *event.Publisher
+}
+
+// NewReceiveReturnUseCase creates a new use case
+func NewReceiveReturnUseCase(repo repository.ReturnRepository, eventPub *event.Publisher) *ReceiveReturnUseCase {
+	return &ReceiveReturnUseCase{returnRepo: repo, eventPub: eventPub}
+}
+
+// Execute records the goods as received. WMS puts them into the returns
+// location of the shipping warehouse under the original lot.
+func (uc *ReceiveReturnUseCase) Execute(ctx context.Context, input *ReceiveReturnInput) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, input.ReturnID)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	if !ret.CanBeReceived() {
+		return nil, ErrReturnCannotReceive
+	}
+
+	for lineID := range input.Lots {
+		if findReturnLine(ret, lineID) == nil {
+			return nil, ErrReturnLineNotFound
+		}
+	}
+	for i := range ret.LineItems {
+		line := &ret.LineItems[i]
+		if lot, ok := input.Lots[line.ID]; ok && lot != "" {
+			line.LotNumber = lot
+			if err := uc.returnRepo.UpdateLineItem(ctx, line); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ret.MarkReceived()
+	ret.UpdatedBy = &input.UserID
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	if uc.eventPub != nil {
+		uc.eventPub.PublishReturnReceived(&event.ReturnReceivedEvent{
+			ReturnID:     ret.ID.String(),
+			ReturnNumber: ret.ReturnNumber,
+			SOID:         ret.SalesOrderID.String(),
+			CustomerID:   customerID(ret),
+			Items:        returnEventItems(ret, false),
+		})
+	}
+
+	return ret, nil
+}
+
+// InspectReturnInput represents the inspection outcome of a return
+type InspectReturnInput struct {
+	ReturnID uuid.UUID
+	Items    []InspectItemInput
+	UserID   uuid.UUID
+}
+
+// InspectItemInput represents the inspection outcome of a return line
+type InspectItemInput struct {
+	LineItemID uuid.UUID
+	Condition  entity.ItemCondition
+	Action     entity.ItemAction
+	Notes      string
+}
+
+// InspectReturnUseCase handles inspection of returned goods
+type InspectReturnUseCase struct {
+	returnRepo repository.ReturnRepository
+}
+
+// NewInspectReturnUseCase creates a new use case
+func NewInspectReturnUseCase(repo repository.ReturnRepository) *InspectReturnUseCase {
+	return &InspectReturnUseCase{returnRepo: repo}
+}
+
+// Execute records the condition and action of every return line. Only items
+// in good condition may be restocked; the refund amount is recalculated since
+// exchanged items are not refunded.
+func (uc *InspectReturnUseCase) Execute(ctx context.Context, input *InspectReturnInput) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, input.ReturnID)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	if !ret.CanBeInspected() {
+		return nil, ErrReturnCannotInspect
+	}
+
+	inspected := make(map[uuid.UUID]InspectItemInput, len(input.Items))
+	for _, item := range input.Items {
+		if findReturnLine(ret, item.LineItemID) == nil {
+			return nil, ErrReturnLineNotFound
+		}
+		switch item.Condition {
+		case entity.ItemConditionGood, entity.ItemConditionDamaged, entity.ItemConditionDefective, entity.ItemConditionExpired:
+		default:
+			return nil, ErrInvalidCondition
+		}
+		switch item.Action {
+		case entity.ItemActionRestock:
+			if item.Condition != entity.ItemConditionGood {
+				return nil, ErrCannotRestock
+			}
+		case entity.ItemActionDispose, entity.ItemActionExchange:
+		default:
+			return nil, ErrInvalidAction
+		}
+		inspected[item.LineItemID] = item
+	}
+	if len(inspected) != len(ret.LineItems) {
+		return nil, ErrLinesNotInspected
+	}
+
+	for i := range ret.LineItems {
+		line := &ret.LineItems[i]
+		item := inspected[line.ID]
+		line.Condition = item.Condition
+		line.Action = item.Action
+		line.Notes = item.Notes
+		if err := uc.returnRepo.UpdateLineItem(ctx, line); err != nil {
+			return nil, err
+		}
+	}
+
+	ret.CalculateTotals()
+	ret.MarkInspected()
+	ret.UpdatedBy = &input.UserID
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// CompleteReturnUseCase handles settlement of an inspected return
+type CompleteReturnUseCase struct {
+	returnRepo     repository.ReturnRepository
+	orderRepo      repository.SalesOrderRepository
+	customerRepo   repository.CustomerRepository
+	creditNoteRepo repository.CreditNoteRepository
+	creditNotes    *receivable.ApplyCreditNoteUseCase
+	tx             repository.Transactor
+	eventPub       *event.Publisher
+}
+
+// NewCompleteReturnUseCase creates a new use case
+func NewCompleteReturnUseCase(
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.SalesOrderRepository,
+	customerRepo repository.CustomerRepository,
+	creditNoteRepo repository.CreditNoteRepository,
+	creditNotes *receivable.ApplyCreditNoteUseCase,
+	tx repository.Transactor,
+	eventPub *event.Publisher,
+) *CompleteReturnUseCase {
+	return &CompleteReturnUseCase{
+		returnRepo:     returnRepo,
+		orderRepo:      orderRepo,
+		customerRepo:   customerRepo,
+		creditNoteRepo: creditNoteRepo,
+		creditNotes:    creditNotes,
+		tx:             tx,
+		eventPub:       eventPub,
+	}
+}
+
+// Execute completes an inspected return:
+//   - exchanged items are reshipped on a draft replacement order at no charge
+//   - the refund amount is issued as a refund for REFUND returns on paid orders,
+//     otherwise as a credit note that reduces the customer balance and is
+//     applied to the customer's open invoices
+//   - WMS restocks or disposes of each line
+func (uc *CompleteReturnUseCase) Execute(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	if !ret.CanBeCompleted() {
+		return nil, ErrReturnCannotComplete
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, ret.SalesOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Settle in one transaction, so that a failure leaves no exchange order
+	// or credit note behind for a retry to duplicate
+	var exchange *entity.SalesOrder
+	err = uc.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if exchange, err = uc.createExchangeOrder(ctx, ret, order, userID); err != nil {
+			return err
+		}
+		if exchange != nil {
+			ret.ExchangeSOID = &exchange.ID
+		}
+
+		ret.CalculateTotals()
+		if ret.RefundAmount > 0 {
+			if ret.CreditNote, err = uc.issueCreditNote(ctx, ret, order, userID); err != nil {
+				return err
+			}
+		}
+
+		ret.Complete()
+		ret.UpdatedBy = &userID
+		return uc.returnRepo.Update(ctx, ret)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Publish event after commit
+	if uc.eventPub != nil {
+		completed := &event.ReturnCompletedEvent{
+			ReturnID:     ret.ID.String(),
+			ReturnNumber: ret.ReturnNumber,
+			SOID:         order.ID.String(),
+			CustomerID:   order.CustomerID.String(),
+			ReturnType:   string(ret.ReturnType),
+			RefundAmount: ret.RefundAmount,
+			Items:        returnEventItems(ret, true),
+		}
+		if ret.CreditNote != nil {
+			completed.CreditNoteNumber = ret.CreditNote.CreditNoteNumber
+			completed.CreditNoteType = string(ret.CreditNote.NoteType)
+		}
+		if exchange != nil {
+			completed.ExchangeSOID = exchange.ID.String()
+		}
+		uc.eventPub.PublishReturnCompleted(completed)
+	}
+
+	return ret, nil
+}
+
+// createExchangeOrder creates a draft order reshipping the exchanged items at
+// no charge, or returns nil when nothing is exchanged
+func (uc *CompleteReturnUseCase) createExchangeOrder(ctx context.Context, ret *entity.Return, order *entity.SalesOrder, userID uuid.UUID) (*entity.SalesOrder, error) {
+	var lines []entity.SOLineItem
+	for _, item := range ret.LineItems {
+		if item.Action != entity.ItemActionExchange {
+			continue
+		}
+		line := entity.SOLineItem{
+			LineNumber:      len(lines) + 1,
+			ProductID:       item.ProductID,
+			ProductCode:     item.ProductCode,
+			ProductName:     item.ProductName,
+			Quantity:        item.Quantity,
+			UnitPrice:       item.UnitPrice,
+			DiscountPercent: 100,
+			TaxPercent:      0,
+			Notes:           "Exchange for " + ret.ReturnNumber,
+		}
+		if item.SOLineItemID != nil {
+			if orig := findOrderLine(order, *item.SOLineItemID); orig != nil {
+				line.UomID = orig.UomID
+			}
+		}
+		line.CalculateLineTotal()
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	number, err := uc.orderRepo.GetNextSONumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exchange := &entity.SalesOrder{
+		SONumber:        number,
+		CustomerID:      order.CustomerID,
+		SODate:          time.Now(),
+		DeliveryAddress: order.DeliveryAddress,
+		BillingAddress:  order.BillingAddress,
+		TaxPercent:      0,
+		PaymentMethod:   order.PaymentMethod,
+		PaymentStatus:   entity.PaymentStatusPaid,
+		Status:          entity.SOStatusDraft,
+		Notes:           "Exchange for return " + ret.ReturnNumber + " of " + order.SONumber,
+		CreatedBy:       &userID,
+		LineItems:       lines,
+	}
+	exchange.CalculateTotals()
+
+	if err := uc.orderRepo.Create(ctx, exchange); err != nil {
+		return nil, err
+	}
+	return exchange, nil
+}
+
+// issueCreditNote issues the refund amount to the customer. A refund can only
+// pay back money received, so REFUND returns on orders not fully paid are
+// credited against the customer balance instead.
+func (uc *CompleteReturnUseCase) issueCreditNote(ctx context.Context, ret *entity.Return, order *entity.SalesOrder, userID uuid.UUID) (*entity.CreditNote, error) {
+	noteType := entity.CreditNoteTypeCredit
+	if ret.ReturnType == entity.ReturnTypeRefund && order.PaymentStatus == entity.PaymentStatusPaid {
+		noteType = entity.CreditNoteTypeRefund
+	}
+
+	number, err := uc.creditNoteRepo.GetNextCreditNoteNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	note := &entity.CreditNote{
+		CreditNoteNumber: number,
+		NoteType:         noteType,
+		CustomerID:       order.CustomerID,
+		SalesOrderID:     order.ID,
+		ReturnID:         &ret.ID,
+		IssueDate:        time.Now(),
+		Amount:           ret.RefundAmount,
+		Reason:           "Return " + ret.ReturnNumber,
+		CreatedBy:        &userID,
+	}
+	if err := uc.creditNoteRepo.Create(ctx, note); err != nil {
+		return nil, err
+	}
+
+	if noteType == entity.CreditNoteTypeCredit {
+		if err := uc.customerRepo.UpdateBalance(ctx, order.CustomerID, -note.Amount); err != nil {
+			return nil, err
+		}
+		if err := uc.creditNotes.Apply(ctx, note, userID); err != nil {
+			return nil, err
+		}
+	}
+	return note, nil
+}
+
+// findReturnLine returns the return line with the given ID
+func findReturnLine(ret *entity.Return, lineID uuid.UUID) *entity.ReturnLineItem {
+	for i := range ret.LineItems {
+		if ret.LineItems[i].ID == lineID {
+			return &ret.LineItems[i]
+		}
+	}
+	return nil
+}
+
+// customerID returns the customer of the return's sales order
+func customerID(ret *entity.Return) string {
+	if ret.SalesOrder == nil {
+		return ""
+	}
+	return ret.SalesOrder.CustomerID.String()
+}
+
+// returnEventItems maps the return lines to event items, with the warehouse
+// disposition once the return is settled
+func returnEventItems(ret *entity.Return, withDisposition bool) []event.ReturnLineItem {
+	items := make([]event.ReturnLineItem, len(ret.LineItems))
+	for i, line := range ret.LineItems {
+		items[i] = event.ReturnLineItem{
+			ProductID:   line.ProductID.String(),
+			ProductCode: line.ProductCode,
+			LotNumber:   line.LotNumber,
+			Quantity:    line.Quantity,
+		}
+		if withDisposition {
+			items[i].Disposition = string(line.Disposition())
+		}
+	}
+	return items
+}
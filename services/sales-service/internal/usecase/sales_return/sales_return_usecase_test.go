@@ -0,0 +1,275 @@
+package sales_return_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/receivable"
+	salesreturn "github.com/erp-cosmetics/sales-service/internal/usecase/sales_return"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type txKey struct{}
+
+// recordingTransactor marks the context of the transaction so tests can
+// check which calls ran inside it
+type recordingTransactor struct{}
+
+func (recordingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+func inTx() interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool { return ctx.Value(txKey{}) != nil })
+}
+
+type createReturnFixture struct {
+	returnRepo   *testmocks.MockReturnRepository
+	orderRepo    *testmocks.MockSalesOrderRepository
+	shipmentRepo *testmocks.MockShipmentRepository
+	order        *entity.SalesOrder
+	shipment     *entity.Shipment
+	uc           *salesreturn.CreateReturnUseCase
+}
+
+// newCreateReturnFixture sets up an order line of 10 units of which 6 went
+// out on the shipment and 4 of those are already being returned. A rejected
+// return on the shipment and a return on another shipment don't count.
+func newCreateReturnFixture(ctx context.Context) *createReturnFixture {
+	f := &createReturnFixture{
+		returnRepo:   new(testmocks.MockReturnRepository),
+		orderRepo:    new(testmocks.MockSalesOrderRepository),
+		shipmentRepo: new(testmocks.MockShipmentRepository),
+	}
+	f.uc = salesreturn.NewCreateReturnUseCase(f.returnRepo, f.orderRepo, f.shipmentRepo, nil)
+
+	line := entity.SOLineItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 10, ShippedQuantity: 10, UnitPrice: 100000, LineTotal: 1000000}
+	f.order = &entity.SalesOrder{
+		ID:          uuid.New(),
+		Status:      entity.SOStatusShipped,
+		Subtotal:    1000000,
+		TotalAmount: 1000000,
+		LineItems:   []entity.SOLineItem{line},
+	}
+	f.shipment = &entity.Shipment{
+		ID:           uuid.New(),
+		SalesOrderID: f.order.ID,
+		Status:       entity.ShipmentStatusDelivered,
+		LineItems:    []entity.ShipmentLineItem{{SOLineItemID: line.ID, Quantity: 6}},
+	}
+
+	otherShipmentID := uuid.New()
+	open := &entity.Return{ID: uuid.New(), ShipmentID: &f.shipment.ID, Status: entity.ReturnStatusApproved}
+	rejected := &entity.Return{ID: uuid.New(), ShipmentID: &f.shipment.ID, Status: entity.ReturnStatusRejected}
+	other := &entity.Return{ID: uuid.New(), ShipmentID: &otherShipmentID, Status: entity.ReturnStatusCompleted}
+
+	f.orderRepo.On("GetByID", ctx, f.order.ID).Return(f.order, nil)
+	f.shipmentRepo.On("GetByID", ctx, f.shipment.ID).Return(f.shipment, nil)
+	f.returnRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Return{open, rejected, other}, nil)
+	f.returnRepo.On("GetLineItems", ctx, open.ID).Return([]*entity.ReturnLineItem{{SOLineItemID: &line.ID, Quantity: 4}}, nil)
+	return f
+}
+
+func (f *createReturnFixture) input(quantity float64) *salesreturn.CreateReturnInput {
+	return &salesreturn.CreateReturnInput{
+		SalesOrderID: f.order.ID,
+		ShipmentID:   f.shipment.ID,
+		ReturnType:   entity.ReturnTypeRefund,
+		Items:        []salesreturn.ReturnItemInput{{SOLineItemID: f.order.LineItems[0].ID, Quantity: quantity}},
+	}
+}
+
+func TestCreateReturnUseCase_Execute_UpToShippedQuantity(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateReturnFixture(ctx)
+	f.returnRepo.On("GetNextReturnNumber", ctx).Return("RMA-0001", nil)
+	f.returnRepo.On("Create", ctx, mock.AnythingOfType("*entity.Return")).Return(nil)
+
+	// Act
+	ret, err := f.uc.Execute(ctx, f.input(2))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, f.shipment.ID, *ret.ShipmentID)
+	assert.Equal(t, 200000.0, ret.RefundAmount)
+	f.returnRepo.AssertExpectations(t)
+}
+
+func TestCreateReturnUseCase_Execute_ExceedsShippedQuantity(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateReturnFixture(ctx)
+	f.returnRepo.On("GetNextReturnNumber", ctx).Return("RMA-0001", nil)
+
+	// Act
+	_, err := f.uc.Execute(ctx, f.input(3))
+
+	// Assert
+	assert.Equal(t, salesreturn.ErrExceedsReturnable, err)
+	f.returnRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateReturnUseCase_Execute_LineNotOnShipment(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCreateReturnFixture(ctx)
+	f.shipment.LineItems = nil
+	f.returnRepo.On("GetNextReturnNumber", ctx).Return("RMA-0001", nil)
+
+	// Act
+	_, err := f.uc.Execute(ctx, f.input(1))
+
+	// Assert
+	assert.Equal(t, salesreturn.ErrExceedsReturnable, err)
+}
+
+func TestInspectReturnUseCase_Execute_RestocksOnlyGoodItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition entity.ItemCondition
+		action    entity.ItemAction
+		wantErr   error
+	}{
+		{"good item restocked", entity.ItemConditionGood, entity.ItemActionRestock, nil},
+		{"damaged item restocked", entity.ItemConditionDamaged, entity.ItemActionRestock, salesreturn.ErrCannotRestock},
+		{"expired item restocked", entity.ItemConditionExpired, entity.ItemActionRestock, salesreturn.ErrCannotRestock},
+		{"damaged item disposed", entity.ItemConditionDamaged, entity.ItemActionDispose, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			returnRepo := new(testmocks.MockReturnRepository)
+			uc := salesreturn.NewInspectReturnUseCase(returnRepo)
+
+			ret := &entity.Return{
+				ID:        uuid.New(),
+				Status:    entity.ReturnStatusReceived,
+				LineItems: []entity.ReturnLineItem{{ID: uuid.New(), Quantity: 2, UnitPrice: 100000}},
+			}
+			returnRepo.On("GetByID", ctx, ret.ID).Return(ret, nil)
+			returnRepo.On("UpdateLineItem", ctx, mock.AnythingOfType("*entity.ReturnLineItem")).Return(nil)
+			returnRepo.On("Update", ctx, ret).Return(nil)
+
+			// Act
+			_, err := uc.Execute(ctx, &salesreturn.InspectReturnInput{
+				ReturnID: ret.ID,
+				Items:    []salesreturn.InspectItemInput{{LineItemID: ret.LineItems[0].ID, Condition: tt.condition, Action: tt.action}},
+				UserID:   uuid.New(),
+			})
+
+			// Assert
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				returnRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			assert.Equal(t, entity.ReturnStatusInspected, ret.Status)
+			assert.Equal(t, tt.condition, ret.LineItems[0].Condition)
+		})
+	}
+}
+
+type completeReturnFixture struct {
+	returnRepo     *testmocks.MockReturnRepository
+	orderRepo      *testmocks.MockSalesOrderRepository
+	customerRepo   *testmocks.MockCustomerRepository
+	creditNoteRepo *testmocks.MockCreditNoteRepository
+	invoiceRepo    *testmocks.MockInvoiceRepository
+	order          *entity.SalesOrder
+	ret            *entity.Return
+	uc             *salesreturn.CompleteReturnUseCase
+}
+
+func newCompleteReturnFixture(ctx context.Context, returnType entity.ReturnType, paymentStatus entity.PaymentStatus) *completeReturnFixture {
+	f := &completeReturnFixture{
+		returnRepo:     new(testmocks.MockReturnRepository),
+		orderRepo:      new(testmocks.MockSalesOrderRepository),
+		customerRepo:   new(testmocks.MockCustomerRepository),
+		creditNoteRepo: new(testmocks.MockCreditNoteRepository),
+		invoiceRepo:    new(testmocks.MockInvoiceRepository),
+	}
+	creditNotes := receivable.NewApplyCreditNoteUseCase(f.creditNoteRepo, f.invoiceRepo, f.orderRepo)
+	f.uc = salesreturn.NewCompleteReturnUseCase(f.returnRepo, f.orderRepo, f.customerRepo, f.creditNoteRepo, creditNotes, recordingTransactor{}, nil)
+
+	f.order = &entity.SalesOrder{ID: uuid.New(), CustomerID: uuid.New(), SONumber: "SO-0001", PaymentStatus: paymentStatus}
+	f.ret = &entity.Return{
+		ID:           uuid.New(),
+		ReturnNumber: "RMA-0001",
+		SalesOrderID: f.order.ID,
+		ReturnType:   returnType,
+		Status:       entity.ReturnStatusInspected,
+		LineItems: []entity.ReturnLineItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 2, UnitPrice: 100000, Condition: entity.ItemConditionGood, Action: entity.ItemActionRestock},
+		},
+	}
+
+	f.returnRepo.On("GetByID", ctx, f.ret.ID).Return(f.ret, nil)
+	f.orderRepo.On("GetByID", ctx, f.order.ID).Return(f.order, nil)
+	f.creditNoteRepo.On("GetNextCreditNoteNumber", inTx()).Return("CN-0001", nil)
+	f.creditNoteRepo.On("Create", inTx(), mock.AnythingOfType("*entity.CreditNote")).Return(nil)
+	f.returnRepo.On("Update", inTx(), f.ret).Return(nil)
+	return f
+}
+
+func TestCompleteReturnUseCase_Execute_Settlement(t *testing.T) {
+	tests := []struct {
+		name          string
+		returnType    entity.ReturnType
+		paymentStatus entity.PaymentStatus
+		noteType      entity.CreditNoteType
+	}{
+		{"refund of a paid order", entity.ReturnTypeRefund, entity.PaymentStatusPaid, entity.CreditNoteTypeRefund},
+		{"refund of an unpaid order is credited", entity.ReturnTypeRefund, entity.PaymentStatusPending, entity.CreditNoteTypeCredit},
+		{"credit", entity.ReturnTypeCredit, entity.PaymentStatusPaid, entity.CreditNoteTypeCredit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			f := newCompleteReturnFixture(ctx, tt.returnType, tt.paymentStatus)
+			if tt.noteType == entity.CreditNoteTypeCredit {
+				f.customerRepo.On("UpdateBalance", inTx(), f.order.CustomerID, -200000.0).Return(nil)
+				f.invoiceRepo.On("GetOpenByCustomer", inTx(), f.order.CustomerID).Return([]*entity.Invoice{}, nil)
+			}
+
+			// Act
+			ret, err := f.uc.Execute(ctx, f.ret.ID, uuid.New())
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, entity.ReturnStatusCompleted, ret.Status)
+			if assert.NotNil(t, ret.CreditNote) {
+				assert.Equal(t, tt.noteType, ret.CreditNote.NoteType)
+				assert.Equal(t, 200000.0, ret.CreditNote.Amount)
+			}
+			f.customerRepo.AssertExpectations(t)
+			f.invoiceRepo.AssertExpectations(t)
+			if tt.noteType == entity.CreditNoteTypeRefund {
+				f.customerRepo.AssertNotCalled(t, "UpdateBalance", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCompleteReturnUseCase_Execute_FailureLeavesReturnOpen(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newCompleteReturnFixture(ctx, entity.ReturnTypeCredit, entity.PaymentStatusPaid)
+	f.customerRepo.On("UpdateBalance", inTx(), f.order.CustomerID, -200000.0).Return(errors.New("connection reset"))
+
+	// Act
+	_, err := f.uc.Execute(ctx, f.ret.ID, uuid.New())
+
+	// Assert
+	assert.Error(t, err)
+	f.returnRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -3,9 +3,12 @@ package shipment
 import (
 	"context"
 	"errors"
+	"sort"
+	"time"
 
 	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
 	"github.com/erp-cosmetics/sales-service/internal/domain/repository"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/carrier"
 	"github.com/erp-cosmetics/sales-service/internal/infrastructure/event"
 	"github.com/google/uuid"
 )
@@ -14,6 +17,12 @@ var (
 	ErrShipmentNotFound     = errors.New("shipment not found")
 	ErrShipmentCannotShip   = errors.New("shipment cannot be shipped")
 	ErrShipmentCannotDeliver = errors.New("shipment cannot be marked as delivered")
+	ErrShipmentLineNotFound  = errors.New("order line not found")
+	ErrShipQuantityExceeded  = errors.New("quantity exceeds the quantity left to ship")
+	ErrNothingToShip         = errors.New("nothing left to ship on the order")
+	ErrLabelAlreadyCreated   = errors.New("shipment is already booked with a carrier")
+	ErrShipmentNotBooked     = errors.New("shipment is not booked with a carrier")
+	ErrCarrierServiceNotFound = errors.New("carrier service not quoted for the shipment")
 )
 
 // CreateShipmentInput represents input for creating shipment
@@ -27,9 +36,16 @@ type CreateShipmentInput struct {
 	RecipientPhone        string
 	DeliveryAddress       string
 	Notes                 string
+	Items                 []ShipmentItemInput // Empty ships everything left on the order
 	CreatedBy             *uuid.UUID
 }
 
+// ShipmentItemInput represents the quantity of an order line to ship
+type ShipmentItemInput struct {
+	SOLineItemID uuid.UUID
+	Quantity     float64
+}
+
 // CreateShipmentUseCase handles shipment creation
 type CreateShipmentUseCase struct {
 	shipmentRepo repository.ShipmentRepository
@@ -62,6 +78,11 @@ func (uc *CreateShipmentUseCase) Execute(ctx context.Context, input *CreateShipm
 		return nil, ErrShipmentCannotShip
 	}
 
+	lines, err := uc.shipmentLines(ctx, order, input.Items)
+	if err != nil {
+		return nil, err
+	}
+
 	// Generate shipment number
 	number, err := uc.shipmentRepo.GetNextShipmentNumber(ctx)
 	if err != nil {
@@ -81,6 +102,7 @@ func (uc *CreateShipmentUseCase) Execute(ctx context.Context, input *CreateShipm
 		Status:          entity.ShipmentStatusPending,
 		Notes:           input.Notes,
 		CreatedBy:       input.CreatedBy,
+		LineItems:       lines,
 	}
 
 	if err := uc.shipmentRepo.Create(ctx, shipment); err != nil {
@@ -90,6 +112,73 @@ func (uc *CreateShipmentUseCase) Execute(ctx context.Context, input *CreateShipm
 	return shipment, nil
 }
 
+// shipmentLines builds the shipment lines, checking each quantity against what
+// is left to ship: the ordered quantity less the shipped quantity and the
+// quantity on shipments not yet shipped
+func (uc *CreateShipmentUseCase) shipmentLines(ctx context.Context, order *entity.SalesOrder, items []ShipmentItemInput) ([]entity.ShipmentLineItem, error) {
+	existing, err := uc.shipmentRepo.GetBySalesOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	pending := make(map[uuid.UUID]float64)
+	for _, s := range existing {
+		if !s.CanBeShipped() {
+			continue
+		}
+		for _, line := range s.LineItems {
+			pending[line.SOLineItemID] += line.Quantity
+		}
+	}
+	open := func(li *entity.SOLineItem) float64 {
+		return li.Quantity - li.ShippedQuantity - pending[li.ID]
+	}
+
+	var lines []entity.ShipmentLineItem
+	if len(items) == 0 {
+		for i := range order.LineItems {
+			li := &order.LineItems[i]
+			if qty := open(li); qty > 0 {
+				lines = append(lines, shipmentLine(li, qty))
+			}
+		}
+	}
+	for _, item := range items {
+		li := findOrderLine(order, item.SOLineItemID)
+		if li == nil {
+			return nil, ErrShipmentLineNotFound
+		}
+		if item.Quantity <= 0 || item.Quantity > open(li) {
+			return nil, ErrShipQuantityExceeded
+		}
+		lines = append(lines, shipmentLine(li, item.Quantity))
+	}
+	if len(lines) == 0 {
+		return nil, ErrNothingToShip
+	}
+	return lines, nil
+}
+
+// shipmentLine creates a shipment line for a quantity of an order line
+func shipmentLine(li *entity.SOLineItem, quantity float64) entity.ShipmentLineItem {
+	return entity.ShipmentLineItem{
+		SOLineItemID: li.ID,
+		ProductID:    li.ProductID,
+		ProductCode:  li.ProductCode,
+		ProductName:  li.ProductName,
+		Quantity:     quantity,
+	}
+}
+
+// findOrderLine returns the order line with the given ID
+func findOrderLine(order *entity.SalesOrder, lineID uuid.UUID) *entity.SOLineItem {
+	for i := range order.LineItems {
+		if order.LineItems[i].ID == lineID {
+			return &order.LineItems[i]
+		}
+	}
+	return nil
+}
+
 // GetShipmentUseCase handles getting shipment
 type GetShipmentUseCase struct {
 	shipmentRepo repository.ShipmentRepository
@@ -164,10 +253,19 @@ func (uc *ShipShipmentUseCase) Execute(ctx context.Context, input *ShipInput) (*
 		return nil, err
 	}
 
-	// Update order status
+	// Record the shipped quantities, then update order status
+	for _, line := range shipment.LineItems {
+		if err := uc.orderRepo.UpdateShippedQuantity(ctx, line.SOLineItemID, line.Quantity); err != nil {
+			return nil, err
+		}
+	}
 	order, _ := uc.orderRepo.GetByID(ctx, shipment.SalesOrderID)
 	if order != nil {
-		order.MarkShipped()
+		if len(shipment.LineItems) == 0 || order.IsFullyShipped() {
+			order.MarkShipped()
+		} else {
+			order.MarkPartiallyShipped()
+		}
 		uc.orderRepo.Update(ctx, order)
 
 		// Publish event
@@ -224,9 +322,9 @@ func (uc *DeliverShipmentUseCase) Execute(ctx context.Context, shipmentID uuid.U
 		return nil, err
 	}
 
-	// Update order status
+	// Update order status once everything on it has been delivered
 	order, _ := uc.orderRepo.GetByID(ctx, shipment.SalesOrderID)
-	if order != nil {
+	if order != nil && uc.allDelivered(ctx, order) {
 		order.MarkDelivered()
 		uc.orderRepo.Update(ctx, order)
 
@@ -243,3 +341,447 @@ func (uc *DeliverShipmentUseCase) Execute(ctx context.Context, shipmentID uuid.U
 
 	return shipment, nil
 }
+
+// allDelivered checks if the order is fully shipped and all its shipments delivered
+func (uc *DeliverShipmentUseCase) allDelivered(ctx context.Context, order *entity.SalesOrder) bool {
+	if order.Status != entity.SOStatusShipped {
+		return false
+	}
+	shipments, err := uc.shipmentRepo.GetBySalesOrder(ctx, order.ID)
+	if err != nil {
+		return false
+	}
+	for _, s := range shipments {
+		if s.Status != entity.ShipmentStatusDelivered && s.Status != entity.ShipmentStatusReturned {
+			return false
+		}
+	}
+	return true
+}
+
+// QuoteRatesUseCase handles quoting carrier services for a shipment
+type QuoteRatesUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+	carriers     *carrier.Registry
+	shipFrom     carrier.Address
+}
+
+// NewQuoteRatesUseCase creates a new use case. Parcels are sent from shipFrom.
+func NewQuoteRatesUseCase(shipmentRepo repository.ShipmentRepository, carriers *carrier.Registry, shipFrom carrier.Address) *QuoteRatesUseCase {
+	return &QuoteRatesUseCase{
+		shipmentRepo: shipmentRepo,
+		carriers:     carriers,
+		shipFrom:     shipFrom,
+	}
+}
+
+// ParcelInput represents the parcel of a shipment
+type ParcelInput struct {
+	WeightKg      float64
+	DeclaredValue float64
+}
+
+// Execute quotes the services of every carrier, or of the named one, cheapest
+// first. A carrier that cannot quote is left out unless none can.
+func (uc *QuoteRatesUseCase) Execute(ctx context.Context, shipmentID uuid.UUID, carrierName string, parcel ParcelInput) ([]carrier.Rate, error) {
+	shipment, err := uc.shipmentRepo.GetByID(ctx, shipmentID)
+	if err != nil {
+		return nil, ErrShipmentNotFound
+	}
+	return uc.quote(ctx, shipment, carrierName, parcel)
+}
+
+func (uc *QuoteRatesUseCase) quote(ctx context.Context, shipment *entity.Shipment, carrierName string, parcel ParcelInput) ([]carrier.Rate, error) {
+	carriers := uc.carriers.All()
+	if carrierName != "" {
+		c, err := uc.carriers.Get(carrierName)
+		if err != nil {
+			return nil, err
+		}
+		carriers = []carrier.Carrier{c}
+	}
+
+	req := &carrier.RateRequest{
+		ShipFrom: uc.shipFrom,
+		ShipTo:   recipientOf(shipment),
+		Parcel:   parcelOf(shipment, parcel),
+	}
+
+	var rates []carrier.Rate
+	var firstErr error
+	for _, c := range carriers {
+		quoted, err := c.Rates(ctx, req)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		rates = append(rates, quoted...)
+	}
+	if len(rates) == 0 {
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return nil, carrier.ErrNoRates
+	}
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].Amount < rates[j].Amount
+	})
+	return rates, nil
+}
+
+// BookCarrierInput represents input for booking a carrier for a shipment
+type BookCarrierInput struct {
+	ShipmentID  uuid.UUID
+	Carrier     string           // Empty shops all carriers
+	ServiceCode string           // Empty picks a service by strategy
+	Strategy    carrier.Strategy // Cheapest unless fastest
+	Parcel      ParcelInput
+	UpdatedBy   *uuid.UUID
+}
+
+// BookCarrierUseCase handles creating the carrier label of a shipment
+type BookCarrierUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+	quotes       *QuoteRatesUseCase
+	carriers     *carrier.Registry
+}
+
+// NewBookCarrierUseCase creates a new use case
+func NewBookCarrierUseCase(shipmentRepo repository.ShipmentRepository, quotes *QuoteRatesUseCase, carriers *carrier.Registry) *BookCarrierUseCase {
+	return &BookCarrierUseCase{
+		shipmentRepo: shipmentRepo,
+		quotes:       quotes,
+		carriers:     carriers,
+	}
+}
+
+// Execute books the requested carrier service, or shops the quoted rates for
+// the cheapest or fastest one, and records the label and tracking number on
+// the shipment
+func (uc *BookCarrierUseCase) Execute(ctx context.Context, input *BookCarrierInput) (*entity.Shipment, error) {
+	shipment, err := uc.shipmentRepo.GetByID(ctx, input.ShipmentID)
+	if err != nil {
+		return nil, ErrShipmentNotFound
+	}
+	if !shipment.CanBeShipped() {
+		return nil, ErrShipmentCannotShip
+	}
+	if shipment.TrackingNumber != "" {
+		return nil, ErrLabelAlreadyCreated
+	}
+
+	rates, err := uc.quotes.quote(ctx, shipment, input.Carrier, input.Parcel)
+	if err != nil {
+		return nil, err
+	}
+
+	var rate *carrier.Rate
+	if input.ServiceCode != "" {
+		for i := range rates {
+			if rates[i].ServiceCode == input.ServiceCode {
+				rate = &rates[i]
+				break
+			}
+		}
+		if rate == nil {
+			return nil, ErrCarrierServiceNotFound
+		}
+	} else if rate, err = carrier.Select(rates, input.Strategy); err != nil {
+		return nil, err
+	}
+
+	c, err := uc.carriers.Get(rate.Carrier)
+	if err != nil {
+		return nil, err
+	}
+	label, err := c.CreateLabel(ctx, &carrier.LabelRequest{
+		Reference:   shipment.ShipmentNumber,
+		ServiceCode: rate.ServiceCode,
+		ShipFrom:    uc.quotes.shipFrom,
+		ShipTo:      recipientOf(shipment),
+		Parcel:      parcelOf(shipment, input.Parcel),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	estimated := label.EstimatedDelivery
+	shipment.Carrier = c.Name()
+	shipment.CarrierService = label.ServiceCode
+	shipment.ShippingMethod = rate.ServiceName
+	shipment.ShippingCost = label.Amount
+	shipment.TrackingNumber = label.TrackingNumber
+	shipment.LabelURL = label.LabelURL
+	shipment.EstimatedDeliveryDate = &estimated
+	shipment.TrackingStatus = string(carrier.StatusLabelCreated)
+	shipment.TrackingUpdatedAt = &now
+	shipment.UpdatedBy = input.UpdatedBy
+
+	if err := uc.shipmentRepo.Update(ctx, shipment); err != nil {
+		return nil, err
+	}
+
+	return shipment, nil
+}
+
+// recipientOf returns the recipient of a shipment
+func recipientOf(shipment *entity.Shipment) carrier.Address {
+	return carrier.Address{
+		Name:    shipment.RecipientName,
+		Phone:   shipment.RecipientPhone,
+		Address: shipment.DeliveryAddress,
+	}
+}
+
+// parcelOf returns the parcel of a shipment. Cash on delivery orders are
+// collected by the carrier.
+func parcelOf(shipment *entity.Shipment, input ParcelInput) carrier.Parcel {
+	parcel := carrier.Parcel{
+		WeightKg:      input.WeightKg,
+		DeclaredValue: input.DeclaredValue,
+	}
+	if order := shipment.SalesOrder; order != nil && order.PaymentMethod == entity.PaymentMethodCOD && order.PaymentStatus != entity.PaymentStatusPaid {
+		parcel.CODAmount = order.TotalAmount
+	}
+	return parcel
+}
+
+// ApplyTrackingUseCase handles moving shipments on with carrier tracking events
+type ApplyTrackingUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+	ship         *ShipShipmentUseCase
+	deliver      *DeliverShipmentUseCase
+}
+
+// NewApplyTrackingUseCase creates a new use case
+func NewApplyTrackingUseCase(shipmentRepo repository.ShipmentRepository, ship *ShipShipmentUseCase, deliver *DeliverShipmentUseCase) *ApplyTrackingUseCase {
+	return &ApplyTrackingUseCase{
+		shipmentRepo: shipmentRepo,
+		ship:         ship,
+		deliver:      deliver,
+	}
+}
+
+// Execute records the tracking events of a carrier and applies the new ones
+// in the order they occurred: a pickup ships the shipment and its order, a
+// scan on the way marks it in transit, and a delivery delivers it and, once
+// everything on it is delivered, the order. Events of parcels unknown to
+// the service are ignored.
+func (uc *ApplyTrackingUseCase) Execute(ctx context.Context, carrierName string, events []carrier.TrackingEvent) ([]*entity.Shipment, error) {
+	sorted := make([]carrier.TrackingEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OccurredAt.Before(sorted[j].OccurredAt)
+	})
+
+	var updated []*entity.Shipment
+	byTrackingNumber := make(map[string]*entity.Shipment)
+	for _, e := range sorted {
+		shipment, seen := byTrackingNumber[e.TrackingNumber]
+		if !seen {
+			found, err := uc.shipmentRepo.GetByTrackingNumber(ctx, e.TrackingNumber)
+			if err == nil && found.Carrier == carrierName {
+				shipment = found
+				updated = append(updated, shipment)
+			}
+			byTrackingNumber[e.TrackingNumber] = shipment
+		}
+		if shipment == nil {
+			continue
+		}
+
+		added, err := uc.shipmentRepo.AddTrackingEvent(ctx, &entity.ShipmentTrackingEvent{
+			ShipmentID:     shipment.ID,
+			TrackingNumber: e.TrackingNumber,
+			Status:         string(e.Status),
+			Description:    e.Description,
+			Location:       e.Location,
+			OccurredAt:     e.OccurredAt,
+		})
+		if err != nil {
+			return updated, err
+		}
+		if !added {
+			continue
+		}
+
+		if err := uc.apply(ctx, shipment, e); err != nil {
+			return updated, err
+		}
+	}
+
+	// Shipping and delivering reload the shipment, return what is stored
+	for i, shipment := range updated {
+		if reloaded, err := uc.shipmentRepo.GetByID(ctx, shipment.ID); err == nil {
+			updated[i] = reloaded
+		}
+	}
+
+	return updated, nil
+}
+
+// apply moves a shipment on with a tracking event. Shipments only move
+// forward, a late scan of an earlier status is recorded only.
+func (uc *ApplyTrackingUseCase) apply(ctx context.Context, shipment *entity.Shipment, e carrier.TrackingEvent) error {
+	switch e.Status {
+	case carrier.StatusPickedUp, carrier.StatusInTransit, carrier.StatusOutForDelivery, carrier.StatusDelivered:
+		if shipment.CanBeShipped() {
+			shipped, err := uc.ship.Execute(ctx, &ShipInput{
+				ShipmentID:     shipment.ID,
+				Carrier:        shipment.Carrier,
+				TrackingNumber: shipment.TrackingNumber,
+			})
+			if err != nil {
+				return err
+			}
+			*shipment = *shipped
+		}
+		if e.Status == carrier.StatusDelivered && shipment.CanBeDelivered() {
+			delivered, err := uc.deliver.Execute(ctx, shipment.ID)
+			if err != nil {
+				return err
+			}
+			*shipment = *delivered
+		} else if e.Status != carrier.StatusPickedUp && shipment.Status == entity.ShipmentStatusShipped {
+			shipment.MarkInTransit()
+		}
+	case carrier.StatusReturned:
+		if shipment.IsShipped() && shipment.Status != entity.ShipmentStatusDelivered {
+			shipment.MarkReturned()
+		}
+	}
+
+	if shipment.TrackingUpdatedAt == nil || !e.OccurredAt.Before(*shipment.TrackingUpdatedAt) {
+		occurredAt := e.OccurredAt
+		shipment.TrackingStatus = string(e.Status)
+		shipment.TrackingUpdatedAt = &occurredAt
+	}
+	return uc.shipmentRepo.Update(ctx, shipment)
+}
+
+// PollTrackingUseCase handles tracking booked shipments with their carriers
+type PollTrackingUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+	carriers     *carrier.Registry
+	apply        *ApplyTrackingUseCase
+}
+
+// NewPollTrackingUseCase creates a new use case
+func NewPollTrackingUseCase(shipmentRepo repository.ShipmentRepository, carriers *carrier.Registry, apply *ApplyTrackingUseCase) *PollTrackingUseCase {
+	return &PollTrackingUseCase{
+		shipmentRepo: shipmentRepo,
+		carriers:     carriers,
+		apply:        apply,
+	}
+}
+
+// Execute tracks every shipment booked with a configured carrier that is not
+// delivered or returned yet, and returns how many were tracked. Shipments
+// entered with another carrier are left to be updated by hand.
+func (uc *PollTrackingUseCase) Execute(ctx context.Context) (int, error) {
+	shipments, err := uc.shipmentRepo.GetTrackable(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tracked := 0
+	var firstErr error
+	for _, shipment := range shipments {
+		if _, err := uc.carriers.Get(shipment.Carrier); err != nil {
+			continue
+		}
+		if _, err := uc.track(ctx, shipment); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		tracked++
+	}
+
+	return tracked, firstErr
+}
+
+// ExecuteShipment tracks one shipment with its carrier
+func (uc *PollTrackingUseCase) ExecuteShipment(ctx context.Context, shipmentID uuid.UUID) (*entity.Shipment, error) {
+	shipment, err := uc.shipmentRepo.GetByID(ctx, shipmentID)
+	if err != nil {
+		return nil, ErrShipmentNotFound
+	}
+	if shipment.TrackingNumber == "" {
+		return nil, ErrShipmentNotBooked
+	}
+	return uc.track(ctx, shipment)
+}
+
+func (uc *PollTrackingUseCase) track(ctx context.Context, shipment *entity.Shipment) (*entity.Shipment, error) {
+	c, err := uc.carriers.Get(shipment.Carrier)
+	if err != nil {
+		return nil, ErrShipmentNotBooked
+	}
+
+	events, err := c.Track(ctx, shipment.TrackingNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.apply.Execute(ctx, c.Name(), events)
+	if err != nil {
+		return nil, err
+	}
+	if len(updated) > 0 {
+		return updated[0], nil
+	}
+	return shipment, nil
+}
+
+// ReceiveTrackingWebhookUseCase handles tracking webhooks of carriers
+type ReceiveTrackingWebhookUseCase struct {
+	carriers *carrier.Registry
+	apply    *ApplyTrackingUseCase
+}
+
+// NewReceiveTrackingWebhookUseCase creates a new use case
+func NewReceiveTrackingWebhookUseCase(carriers *carrier.Registry, apply *ApplyTrackingUseCase) *ReceiveTrackingWebhookUseCase {
+	return &ReceiveTrackingWebhookUseCase{
+		carriers: carriers,
+		apply:    apply,
+	}
+}
+
+// Execute verifies a webhook with its carrier and applies its tracking events
+func (uc *ReceiveTrackingWebhookUseCase) Execute(ctx context.Context, carrierName string, payload []byte, signature string) ([]*entity.Shipment, error) {
+	c, err := uc.carriers.Get(carrierName)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := c.ParseWebhook(payload, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.apply.Execute(ctx, c.Name(), events)
+}
+
+// GetTrackingEventsUseCase handles listing the tracking events of a shipment
+type GetTrackingEventsUseCase struct {
+	shipmentRepo repository.ShipmentRepository
+}
+
+// NewGetTrackingEventsUseCase creates a new use case
+func NewGetTrackingEventsUseCase(shipmentRepo repository.ShipmentRepository) *GetTrackingEventsUseCase {
+	return &GetTrackingEventsUseCase{shipmentRepo: shipmentRepo}
+}
+
+// Execute lists the tracking events of a shipment, oldest first
+func (uc *GetTrackingEventsUseCase) Execute(ctx context.Context, shipmentID uuid.UUID) ([]*entity.ShipmentTrackingEvent, error) {
+	if _, err := uc.shipmentRepo.GetByID(ctx, shipmentID); err != nil {
+		return nil, ErrShipmentNotFound
+	}
+	return uc.shipmentRepo.GetTrackingEvents(ctx, shipmentID)
+}
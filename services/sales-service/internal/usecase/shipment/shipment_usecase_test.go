@@ -0,0 +1,269 @@
+package shipment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/sales-service/internal/domain/entity"
+	"github.com/erp-cosmetics/sales-service/internal/infrastructure/carrier"
+	"github.com/erp-cosmetics/sales-service/internal/testmocks"
+	"github.com/erp-cosmetics/sales-service/internal/usecase/shipment"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type trackingFixture struct {
+	shipmentRepo *testmocks.MockShipmentRepository
+	orderRepo    *testmocks.MockSalesOrderRepository
+	order        *entity.SalesOrder
+	shipment     *entity.Shipment
+	quote        *shipment.QuoteRatesUseCase
+	book         *shipment.BookCarrierUseCase
+	apply        *shipment.ApplyTrackingUseCase
+	poll         *shipment.PollTrackingUseCase
+}
+
+// newTrackingFixture returns a packed shipment of a whole confirmed order,
+// shipped with the local carrier
+func newTrackingFixture(ctx context.Context) *trackingFixture {
+	f := &trackingFixture{
+		shipmentRepo: new(testmocks.MockShipmentRepository),
+		orderRepo:    new(testmocks.MockSalesOrderRepository),
+	}
+	f.order = &entity.SalesOrder{ID: uuid.New(), SONumber: "SO-2605-0001", Status: entity.SOStatusConfirmed}
+	f.shipment = &entity.Shipment{
+		ID:             uuid.New(),
+		ShipmentNumber: "SHP-2605-0001",
+		SalesOrderID:   f.order.ID,
+		Status:         entity.ShipmentStatusPacked,
+		RecipientName:  "Nhà thuốc An Khang",
+	}
+
+	carriers := carrier.NewRegistry(carrier.NewLocalCarrier(zap.NewNop(), "secret"))
+	ship := shipment.NewShipShipmentUseCase(f.shipmentRepo, f.orderRepo, nil)
+	deliver := shipment.NewDeliverShipmentUseCase(f.shipmentRepo, f.orderRepo, nil)
+	f.quote = shipment.NewQuoteRatesUseCase(f.shipmentRepo, carriers, carrier.Address{Name: "Hoa & Lá"})
+	f.book = shipment.NewBookCarrierUseCase(f.shipmentRepo, f.quote, carriers)
+	f.apply = shipment.NewApplyTrackingUseCase(f.shipmentRepo, ship, deliver)
+	f.poll = shipment.NewPollTrackingUseCase(f.shipmentRepo, carriers, f.apply)
+
+	f.shipmentRepo.On("GetByID", ctx, f.shipment.ID).Return(f.shipment, nil)
+	f.shipmentRepo.On("Update", ctx, f.shipment).Return(nil)
+	f.orderRepo.On("GetByID", ctx, f.order.ID).Return(f.order, nil)
+	f.orderRepo.On("Update", ctx, f.order).Return(nil)
+	return f
+}
+
+// booked books the shipment with the local carrier
+func (f *trackingFixture) booked(t *testing.T, ctx context.Context) {
+	_, err := f.book.Execute(ctx, &shipment.BookCarrierInput{ShipmentID: f.shipment.ID, Parcel: shipment.ParcelInput{WeightKg: 1}})
+	require.NoError(t, err)
+	f.shipmentRepo.On("GetByTrackingNumber", ctx, f.shipment.TrackingNumber).Return(f.shipment, nil)
+}
+
+func (f *trackingFixture) event(status carrier.TrackingStatus, occurredAt time.Time) carrier.TrackingEvent {
+	return carrier.TrackingEvent{TrackingNumber: f.shipment.TrackingNumber, Status: status, OccurredAt: occurredAt}
+}
+
+func TestQuoteRatesUseCase_Execute_CheapestFirst(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+
+	// Act
+	rates, err := f.quote.Execute(ctx, f.shipment.ID, "", shipment.ParcelInput{WeightKg: 1})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, rates, 3)
+	assert.Equal(t, "ECONOMY", rates[0].ServiceCode)
+	assert.Equal(t, "EXPRESS", rates[2].ServiceCode)
+}
+
+func TestBookCarrierUseCase_Execute_PicksServiceByStrategy(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    carrier.Strategy
+		serviceCode string
+		expected    string
+		cost        float64
+	}{
+		{"Cheapest", carrier.StrategyCheapest, "", "ECONOMY", 18000},
+		{"Fastest", carrier.StrategyFastest, "", "EXPRESS", 45000},
+		{"Requested service", carrier.StrategyFastest, "STANDARD", "STANDARD", 25000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			f := newTrackingFixture(ctx)
+
+			// Act
+			res, err := f.book.Execute(ctx, &shipment.BookCarrierInput{
+				ShipmentID:  f.shipment.ID,
+				ServiceCode: tt.serviceCode,
+				Strategy:    tt.strategy,
+				Parcel:      shipment.ParcelInput{WeightKg: 1},
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, "local", res.Carrier)
+			assert.Equal(t, tt.expected, res.CarrierService)
+			assert.Equal(t, tt.cost, res.ShippingCost)
+			assert.NotEmpty(t, res.TrackingNumber)
+			assert.Equal(t, string(carrier.StatusLabelCreated), res.TrackingStatus)
+			// Booking a label does not ship the parcel
+			assert.Equal(t, entity.ShipmentStatusPacked, res.Status)
+		})
+	}
+}
+
+func TestBookCarrierUseCase_Execute_AlreadyBooked(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.booked(t, ctx)
+
+	// Act
+	_, err := f.book.Execute(ctx, &shipment.BookCarrierInput{ShipmentID: f.shipment.ID})
+
+	// Assert
+	assert.Equal(t, shipment.ErrLabelAlreadyCreated, err)
+}
+
+func TestApplyTrackingUseCase_Execute_PickupAndDelivery(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.booked(t, ctx)
+
+	pickedUp := time.Now()
+	delivered := pickedUp.Add(26 * time.Hour)
+	f.shipmentRepo.On("AddTrackingEvent", ctx, mock.AnythingOfType("*entity.ShipmentTrackingEvent")).Return(true, nil)
+	f.shipmentRepo.On("GetBySalesOrder", ctx, f.order.ID).Return([]*entity.Shipment{f.shipment}, nil)
+
+	// Act: the delivery is received first, events apply in the order they occurred
+	updated, err := f.apply.Execute(ctx, "local", []carrier.TrackingEvent{
+		f.event(carrier.StatusDelivered, delivered),
+		f.event(carrier.StatusPickedUp, pickedUp),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, updated, 1)
+	assert.Equal(t, entity.ShipmentStatusDelivered, f.shipment.Status)
+	assert.NotNil(t, f.shipment.ShippedDate)
+	assert.Equal(t, string(carrier.StatusDelivered), f.shipment.TrackingStatus)
+	assert.True(t, delivered.Equal(*f.shipment.TrackingUpdatedAt))
+	assert.Equal(t, entity.SOStatusDelivered, f.order.Status)
+	assert.NotNil(t, f.order.ShippedAt)
+}
+
+func TestApplyTrackingUseCase_Execute_PickupShipsOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.booked(t, ctx)
+	f.shipmentRepo.On("AddTrackingEvent", ctx, mock.AnythingOfType("*entity.ShipmentTrackingEvent")).Return(true, nil)
+
+	// Act
+	_, err := f.apply.Execute(ctx, "local", []carrier.TrackingEvent{f.event(carrier.StatusPickedUp, time.Now())})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ShipmentStatusShipped, f.shipment.Status)
+	assert.Equal(t, entity.SOStatusShipped, f.order.Status)
+}
+
+func TestApplyTrackingUseCase_Execute_DuplicateEventIgnored(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.booked(t, ctx)
+	f.shipmentRepo.On("AddTrackingEvent", ctx, mock.AnythingOfType("*entity.ShipmentTrackingEvent")).Return(false, nil)
+
+	// Act
+	updated, err := f.apply.Execute(ctx, "local", []carrier.TrackingEvent{f.event(carrier.StatusPickedUp, time.Now())})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, updated, 1)
+	assert.Equal(t, entity.ShipmentStatusPacked, f.shipment.Status)
+	assert.Equal(t, entity.SOStatusConfirmed, f.order.Status)
+	f.orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	// Booking was the only save
+	f.shipmentRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestApplyTrackingUseCase_Execute_LateScanDoesNotMoveBack(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.booked(t, ctx)
+
+	deliveredAt := time.Now()
+	f.shipment.Status = entity.ShipmentStatusDelivered
+	f.shipment.TrackingStatus = string(carrier.StatusDelivered)
+	f.shipment.TrackingUpdatedAt = &deliveredAt
+	f.order.Status = entity.SOStatusDelivered
+	f.shipmentRepo.On("AddTrackingEvent", ctx, mock.AnythingOfType("*entity.ShipmentTrackingEvent")).Return(true, nil)
+
+	// Act
+	_, err := f.apply.Execute(ctx, "local", []carrier.TrackingEvent{
+		f.event(carrier.StatusInTransit, deliveredAt.Add(-20*time.Hour)),
+		f.event(carrier.StatusOutForDelivery, deliveredAt.Add(-2*time.Hour)),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ShipmentStatusDelivered, f.shipment.Status)
+	assert.Equal(t, string(carrier.StatusDelivered), f.shipment.TrackingStatus)
+	assert.True(t, deliveredAt.Equal(*f.shipment.TrackingUpdatedAt))
+	assert.Equal(t, entity.SOStatusDelivered, f.order.Status)
+	f.shipmentRepo.AssertNumberOfCalls(t, "AddTrackingEvent", 2)
+}
+
+func TestApplyTrackingUseCase_Execute_UnknownParcelIgnored(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.shipmentRepo.On("GetByTrackingNumber", ctx, "LC-UNKNOWN").Return(nil, assert.AnError)
+
+	// Act
+	updated, err := f.apply.Execute(ctx, "local", []carrier.TrackingEvent{
+		{TrackingNumber: "LC-UNKNOWN", Status: carrier.StatusDelivered, OccurredAt: time.Now()},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, updated)
+	f.shipmentRepo.AssertNotCalled(t, "AddTrackingEvent", mock.Anything, mock.Anything)
+}
+
+func TestPollTrackingUseCase_Execute_ShipsPickedUpParcel(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	f := newTrackingFixture(ctx)
+	f.booked(t, ctx)
+	f.shipmentRepo.On("GetTrackable", ctx).Return([]*entity.Shipment{
+		f.shipment,
+		{ID: uuid.New(), Carrier: "Manual courier", TrackingNumber: "MC-001"}, // Left to be updated by hand
+	}, nil)
+	f.shipmentRepo.On("AddTrackingEvent", ctx, mock.AnythingOfType("*entity.ShipmentTrackingEvent")).Return(true, nil)
+
+	// Act: the local carrier reports a pickup on the first poll
+	tracked, err := f.poll.Execute(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tracked)
+	assert.Equal(t, entity.ShipmentStatusShipped, f.shipment.Status)
+	assert.Equal(t, string(carrier.StatusPickedUp), f.shipment.TrackingStatus)
+	assert.Equal(t, entity.SOStatusShipped, f.order.Status)
+}
@@ -0,0 +1,16 @@
+DROP TABLE IF EXISTS credit_notes;
+
+ALTER TABLE return_line_items DROP CONSTRAINT IF EXISTS return_line_items_action_check;
+UPDATE return_line_items SET action = 'REFUND' WHERE action = 'RESTOCK';
+ALTER TABLE return_line_items
+    ADD CONSTRAINT return_line_items_action_check CHECK (action IN ('REFUND', 'EXCHANGE', 'CREDIT', 'DISPOSE'));
+ALTER TABLE return_line_items ALTER COLUMN action SET DEFAULT 'REFUND';
+
+ALTER TABLE return_line_items
+    DROP COLUMN IF EXISTS notes,
+    DROP COLUMN IF EXISTS lot_number;
+
+ALTER TABLE returns
+    DROP COLUMN IF EXISTS inspected_at,
+    DROP COLUMN IF EXISTS received_at,
+    DROP COLUMN IF EXISTS exchange_so_id;
@@ -0,0 +1,36 @@
+-- Return (RMA) lifecycle timestamps and exchange order
+ALTER TABLE returns
+    ADD COLUMN IF NOT EXISTS exchange_so_id UUID REFERENCES sales_orders(id),
+    ADD COLUMN IF NOT EXISTS received_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS inspected_at TIMESTAMP;
+
+-- Original lot and inspection findings per returned line
+ALTER TABLE return_line_items
+    ADD COLUMN IF NOT EXISTS lot_number VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS notes TEXT;
+
+-- Returned goods are restocked, disposed or exchanged; money is settled per return
+ALTER TABLE return_line_items DROP CONSTRAINT IF EXISTS return_line_items_action_check;
+ALTER TABLE return_line_items
+    ADD CONSTRAINT return_line_items_action_check CHECK (action IN ('RESTOCK', 'REFUND', 'EXCHANGE', 'CREDIT', 'DISPOSE'));
+ALTER TABLE return_line_items ALTER COLUMN action SET DEFAULT 'RESTOCK';
+
+-- Credit notes
+CREATE TABLE IF NOT EXISTS credit_notes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    credit_note_number VARCHAR(20) NOT NULL UNIQUE,
+    note_type VARCHAR(20) NOT NULL CHECK (note_type IN ('REFUND', 'CREDIT')),
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    sales_order_id UUID NOT NULL REFERENCES sales_orders(id),
+    return_id UUID REFERENCES returns(id),
+    issue_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    amount DECIMAL(18,2) NOT NULL,
+    reason VARCHAR(200),
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_credit_notes_customer ON credit_notes(customer_id);
+CREATE INDEX idx_credit_notes_order ON credit_notes(sales_order_id);
+CREATE INDEX idx_credit_notes_return ON credit_notes(return_id);
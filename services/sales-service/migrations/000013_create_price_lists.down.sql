@@ -0,0 +1,11 @@
+ALTER TABLE so_line_items
+    DROP COLUMN IF EXISTS price_list_id,
+    DROP COLUMN IF EXISTS price_source;
+ALTER TABLE quotation_line_items
+    DROP COLUMN IF EXISTS price_list_id,
+    DROP COLUMN IF EXISTS price_source;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS channel;
+ALTER TABLE quotations DROP COLUMN IF EXISTS channel;
+
+DROP TABLE IF EXISTS price_list_items;
+DROP TABLE IF EXISTS price_lists;
@@ -0,0 +1,56 @@
+-- Price lists
+CREATE TABLE IF NOT EXISTS price_lists (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    list_type VARCHAR(20) NOT NULL DEFAULT 'STANDARD' CHECK (list_type IN ('STANDARD', 'CONTRACT', 'PROMOTION')),
+    customer_group_id UUID REFERENCES customer_groups(id),
+    customer_id UUID REFERENCES customers(id),
+    channel VARCHAR(20) CHECK (channel IN ('DIRECT', 'DISTRIBUTOR', 'RETAIL', 'ONLINE')),
+    currency VARCHAR(3) DEFAULT 'VND',
+    campaign_id UUID,
+    priority INTEGER DEFAULT 0,
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    is_active BOOLEAN DEFAULT true,
+    notes TEXT,
+    created_by UUID,
+    updated_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_to IS NULL OR valid_to >= valid_from),
+    CHECK (list_type <> 'CONTRACT' OR customer_id IS NOT NULL),
+    CHECK (list_type <> 'PROMOTION' OR campaign_id IS NOT NULL)
+);
+
+-- Price list items (one row per product and quantity break)
+CREATE TABLE IF NOT EXISTS price_list_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    price_list_id UUID NOT NULL REFERENCES price_lists(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    product_code VARCHAR(50),
+    min_quantity DECIMAL(18,3) DEFAULT 0,
+    unit_price DECIMAL(18,2) NOT NULL CHECK (unit_price > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (price_list_id, product_id, min_quantity)
+);
+
+-- Sales channel of quotations and orders
+ALTER TABLE quotations ADD COLUMN IF NOT EXISTS channel VARCHAR(20) DEFAULT 'DIRECT';
+ALTER TABLE sales_orders ADD COLUMN IF NOT EXISTS channel VARCHAR(20) DEFAULT 'DIRECT';
+
+-- Pricing rule that produced each line price
+ALTER TABLE quotation_line_items
+    ADD COLUMN IF NOT EXISTS price_source VARCHAR(20) DEFAULT 'MANUAL',
+    ADD COLUMN IF NOT EXISTS price_list_id UUID REFERENCES price_lists(id);
+ALTER TABLE so_line_items
+    ADD COLUMN IF NOT EXISTS price_source VARCHAR(20) DEFAULT 'MANUAL',
+    ADD COLUMN IF NOT EXISTS price_list_id UUID REFERENCES price_lists(id);
+
+-- Create indexes
+CREATE INDEX idx_price_lists_validity ON price_lists(valid_from, valid_to) WHERE is_active = true;
+CREATE INDEX idx_price_lists_group ON price_lists(customer_group_id);
+CREATE INDEX idx_price_lists_customer ON price_lists(customer_id);
+CREATE INDEX idx_price_lists_campaign ON price_lists(campaign_id);
+CREATE INDEX idx_price_list_items_product ON price_list_items(product_id);
@@ -0,0 +1,8 @@
+ALTER TABLE so_line_items DROP COLUMN IF EXISTS promotion_id;
+ALTER TABLE sales_orders DROP COLUMN IF EXISTS promotion_discount;
+
+DROP TABLE IF EXISTS so_promotions;
+DROP TABLE IF EXISTS vouchers;
+DROP TABLE IF EXISTS promotion_tiers;
+DROP TABLE IF EXISTS promotion_bundle_items;
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,101 @@
+-- Promotion rules
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(30) NOT NULL UNIQUE,
+    name VARCHAR(200) NOT NULL,
+    promotion_type VARCHAR(20) NOT NULL CHECK (promotion_type IN ('BUY_X_GET_Y', 'BUNDLE', 'CART_TIERED')),
+    campaign_id UUID,
+    customer_group_id UUID REFERENCES customer_groups(id),
+    channel VARCHAR(20) CHECK (channel IN ('DIRECT', 'DISTRIBUTOR', 'RETAIL', 'ONLINE')),
+    valid_from DATE NOT NULL,
+    valid_to DATE,
+    priority INTEGER DEFAULT 0,
+    requires_voucher BOOLEAN DEFAULT false,
+    is_active BOOLEAN DEFAULT true,
+
+    -- Buy X get Y
+    buy_product_id UUID,
+    buy_quantity DECIMAL(18,3) DEFAULT 0,
+    free_product_id UUID,
+    free_product_code VARCHAR(50),
+    free_product_name VARCHAR(200),
+    free_quantity DECIMAL(18,3) DEFAULT 0,
+    max_applications INTEGER DEFAULT 0,
+
+    -- Bundle
+    bundle_price DECIMAL(18,2) DEFAULT 0,
+
+    -- Budget (0 is unlimited)
+    budget DECIMAL(18,2) DEFAULT 0 CHECK (budget >= 0),
+    used_budget DECIMAL(18,2) DEFAULT 0,
+    usage_count INTEGER DEFAULT 0,
+
+    notes TEXT,
+    created_by UUID,
+    updated_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+-- Products of one bundle set
+CREATE TABLE IF NOT EXISTS promotion_bundle_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL,
+    product_code VARCHAR(50),
+    quantity DECIMAL(18,3) NOT NULL CHECK (quantity > 0)
+);
+
+-- Cart discount tiers
+CREATE TABLE IF NOT EXISTS promotion_tiers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    min_amount DECIMAL(18,2) DEFAULT 0,
+    discount_percent DECIMAL(5,2) DEFAULT 0 CHECK (discount_percent >= 0 AND discount_percent <= 100),
+    discount_amount DECIMAL(18,2) DEFAULT 0 CHECK (discount_amount >= 0)
+);
+
+-- Voucher codes (max_uses 0 is unlimited)
+CREATE TABLE IF NOT EXISTS vouchers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code VARCHAR(30) NOT NULL UNIQUE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    customer_id UUID REFERENCES customers(id),
+    max_uses INTEGER DEFAULT 1 CHECK (max_uses >= 0),
+    used_count INTEGER DEFAULT 0,
+    valid_to DATE,
+    is_active BOOLEAN DEFAULT true,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Promotions applied to sales orders
+CREATE TABLE IF NOT EXISTS so_promotions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sales_order_id UUID NOT NULL REFERENCES sales_orders(id) ON DELETE CASCADE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    promotion_code VARCHAR(30),
+    promotion_type VARCHAR(20),
+    campaign_id UUID,
+    voucher_id UUID REFERENCES vouchers(id),
+    voucher_code VARCHAR(30),
+    description VARCHAR(200),
+    cost DECIMAL(18,2) DEFAULT 0,
+    released_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Order discount from cart promotions and the promotion of each line
+ALTER TABLE sales_orders ADD COLUMN IF NOT EXISTS promotion_discount DECIMAL(18,2) DEFAULT 0;
+ALTER TABLE so_line_items ADD COLUMN IF NOT EXISTS promotion_id UUID REFERENCES promotions(id);
+
+-- Create indexes
+CREATE INDEX idx_promotions_validity ON promotions(valid_from, valid_to) WHERE is_active = true;
+CREATE INDEX idx_promotions_campaign ON promotions(campaign_id);
+CREATE INDEX idx_promotion_bundle_items_promotion ON promotion_bundle_items(promotion_id);
+CREATE INDEX idx_promotion_tiers_promotion ON promotion_tiers(promotion_id);
+CREATE INDEX idx_vouchers_promotion ON vouchers(promotion_id);
+CREATE INDEX idx_so_promotions_order ON so_promotions(sales_order_id);
+CREATE INDEX idx_so_promotions_promotion ON so_promotions(promotion_id);
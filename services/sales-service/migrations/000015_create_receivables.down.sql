@@ -0,0 +1,7 @@
+ALTER TABLE credit_notes DROP COLUMN IF EXISTS applied_amount;
+
+DROP TABLE IF EXISTS invoice_allocations;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS invoice_line_items;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS shipment_line_items;
@@ -0,0 +1,96 @@
+-- Quantities of each order line in a shipment (shipments without lines cover the whole order)
+CREATE TABLE IF NOT EXISTS shipment_line_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    so_line_item_id UUID NOT NULL REFERENCES so_line_items(id),
+    product_id UUID NOT NULL,
+    product_code VARCHAR(50),
+    product_name VARCHAR(200),
+    quantity DECIMAL(18,3) NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Customer invoices, one per shipment
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_number VARCHAR(20) NOT NULL UNIQUE,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    sales_order_id UUID NOT NULL REFERENCES sales_orders(id),
+    shipment_id UUID REFERENCES shipments(id),
+    invoice_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    due_date DATE NOT NULL,
+    currency VARCHAR(3) DEFAULT 'VND',
+    subtotal DECIMAL(18,2) DEFAULT 0,
+    discount_amount DECIMAL(18,2) DEFAULT 0,
+    tax_amount DECIMAL(18,2) DEFAULT 0,
+    total_amount DECIMAL(18,2) DEFAULT 0,
+    paid_amount DECIMAL(18,2) DEFAULT 0,
+    credited_amount DECIMAL(18,2) DEFAULT 0,
+    status VARCHAR(20) DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'PARTIALLY_PAID', 'PAID', 'CANCELLED')),
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (due_date >= invoice_date)
+);
+
+CREATE TABLE IF NOT EXISTS invoice_line_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    so_line_item_id UUID NOT NULL REFERENCES so_line_items(id),
+    product_id UUID NOT NULL,
+    product_code VARCHAR(50),
+    product_name VARCHAR(200),
+    quantity DECIMAL(18,3) NOT NULL CHECK (quantity > 0),
+    unit_price DECIMAL(18,2) NOT NULL,
+    discount_amount DECIMAL(18,2) DEFAULT 0,
+    tax_percent DECIMAL(5,2) DEFAULT 0,
+    tax_amount DECIMAL(18,2) DEFAULT 0,
+    line_total DECIMAL(18,2) DEFAULT 0
+);
+
+-- Customer payments
+CREATE TABLE IF NOT EXISTS payments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    payment_number VARCHAR(20) NOT NULL UNIQUE,
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    payment_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    amount DECIMAL(18,2) NOT NULL CHECK (amount > 0),
+    allocated_amount DECIMAL(18,2) DEFAULT 0,
+    payment_method VARCHAR(20) DEFAULT 'BANK_TRANSFER' CHECK (payment_method IN ('CASH', 'BANK_TRANSFER', 'CREDIT', 'COD')),
+    reference VARCHAR(100),
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (allocated_amount <= amount)
+);
+
+-- Payments and credit notes settling invoices
+CREATE TABLE IF NOT EXISTS invoice_allocations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id),
+    payment_id UUID REFERENCES payments(id),
+    credit_note_id UUID REFERENCES credit_notes(id),
+    amount DECIMAL(18,2) NOT NULL CHECK (amount > 0),
+    created_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((payment_id IS NULL) <> (credit_note_id IS NULL))
+);
+
+-- Credit notes applied to invoices
+ALTER TABLE credit_notes ADD COLUMN IF NOT EXISTS applied_amount DECIMAL(18,2) DEFAULT 0;
+
+-- Create indexes
+CREATE INDEX idx_shipment_line_items_shipment ON shipment_line_items(shipment_id);
+CREATE INDEX idx_shipment_line_items_so_line ON shipment_line_items(so_line_item_id);
+CREATE INDEX idx_invoices_customer ON invoices(customer_id);
+CREATE INDEX idx_invoices_order ON invoices(sales_order_id);
+CREATE INDEX idx_invoices_shipment ON invoices(shipment_id);
+CREATE INDEX idx_invoices_status_due ON invoices(status, due_date);
+CREATE INDEX idx_invoice_line_items_invoice ON invoice_line_items(invoice_id);
+CREATE INDEX idx_payments_customer ON payments(customer_id);
+CREATE INDEX idx_payments_date ON payments(payment_date);
+CREATE INDEX idx_invoice_allocations_invoice ON invoice_allocations(invoice_id);
+CREATE INDEX idx_invoice_allocations_payment ON invoice_allocations(payment_id);
+CREATE INDEX idx_invoice_allocations_credit_note ON invoice_allocations(credit_note_id);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS e_invoices;
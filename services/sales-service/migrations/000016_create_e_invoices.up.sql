@@ -0,0 +1,36 @@
+-- Electronic invoices (Decree 123/2020, Circular 78/2021)
+CREATE TABLE IF NOT EXISTS e_invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    invoice_id UUID NOT NULL REFERENCES invoices(id),
+    template_code VARCHAR(1) NOT NULL,
+    series VARCHAR(6) NOT NULL,
+    number INTEGER NOT NULL CHECK (number > 0),
+    issue_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    seller_tax_code VARCHAR(14) NOT NULL,
+    buyer_tax_code VARCHAR(14),
+    buyer_name VARCHAR(400),
+    currency VARCHAR(3) DEFAULT 'VND',
+    amount_before_tax DECIMAL(18,2) DEFAULT 0,
+    tax_amount DECIMAL(18,2) DEFAULT 0,
+    total_amount DECIMAL(18,2) DEFAULT 0,
+    xml_content TEXT,
+    provider VARCHAR(50),
+    provider_ref VARCHAR(100),
+    tax_authority_code VARCHAR(34),
+    lookup_code VARCHAR(50),
+    status VARCHAR(20) DEFAULT 'SIGNED' CHECK (status IN ('SIGNED', 'ISSUED', 'FAILED', 'CANCELLED')),
+    error_message TEXT,
+    issued_at TIMESTAMP,
+    issued_by UUID,
+    cancelled_at TIMESTAMP,
+    cancelled_by UUID,
+    cancel_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (template_code, series, number)
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_e_invoices_active_invoice ON e_invoices(invoice_id) WHERE status <> 'CANCELLED';
+CREATE INDEX idx_e_invoices_status ON e_invoices(status);
+CREATE INDEX idx_e_invoices_issue_date ON e_invoices(issue_date);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS credit_holds;
+
+UPDATE sales_orders SET status = 'DRAFT' WHERE status = 'ON_CREDIT_HOLD';
+ALTER TABLE sales_orders DROP CONSTRAINT IF EXISTS sales_orders_status_check;
+ALTER TABLE sales_orders
+    ADD CONSTRAINT sales_orders_status_check CHECK (status IN ('DRAFT', 'CONFIRMED', 'PROCESSING', 'PARTIALLY_SHIPPED', 'SHIPPED', 'DELIVERED', 'CANCELLED'));
@@ -0,0 +1,32 @@
+-- Orders over the credit limit or with overdue invoices wait for a credit review
+ALTER TABLE sales_orders DROP CONSTRAINT IF EXISTS sales_orders_status_check;
+ALTER TABLE sales_orders
+    ADD CONSTRAINT sales_orders_status_check CHECK (status IN ('DRAFT', 'ON_CREDIT_HOLD', 'CONFIRMED', 'PROCESSING', 'PARTIALLY_SHIPPED', 'SHIPPED', 'DELIVERED', 'CANCELLED'));
+
+-- Credit holds and the credit manager's decisions
+CREATE TABLE IF NOT EXISTS credit_holds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sales_order_id UUID NOT NULL REFERENCES sales_orders(id),
+    customer_id UUID NOT NULL REFERENCES customers(id),
+    order_amount DECIMAL(18,2) DEFAULT 0,
+    credit_limit DECIMAL(18,2) DEFAULT 0,
+    current_balance DECIMAL(18,2) DEFAULT 0,
+    available_credit DECIMAL(18,2) DEFAULT 0,
+    overdue_amount DECIMAL(18,2) DEFAULT 0,
+    overdue_invoices BIGINT DEFAULT 0,
+    over_limit BOOLEAN DEFAULT false,
+    reason TEXT,
+    status VARCHAR(20) DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    held_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    held_by UUID,
+    reviewed_at TIMESTAMP,
+    reviewed_by UUID,
+    review_reason TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE UNIQUE INDEX idx_credit_holds_pending_order ON credit_holds(sales_order_id) WHERE status = 'PENDING';
+CREATE INDEX idx_credit_holds_customer ON credit_holds(customer_id);
+CREATE INDEX idx_credit_holds_status ON credit_holds(status);
@@ -0,0 +1,3 @@
+ALTER TABLE so_line_items
+    DROP COLUMN IF EXISTS expected_date,
+    DROP COLUMN IF EXISTS backordered_quantity;
@@ -0,0 +1,4 @@
+-- Quantity of each line backordered in WMS and when it is expected
+ALTER TABLE so_line_items
+    ADD COLUMN IF NOT EXISTS backordered_quantity DECIMAL(18,3) DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS expected_date DATE;
@@ -0,0 +1,5 @@
+ALTER TABLE so_line_items
+    DROP COLUMN IF EXISTS promise_date;
+
+ALTER TABLE quotation_line_items
+    DROP COLUMN IF EXISTS promise_date;
@@ -0,0 +1,6 @@
+-- Earliest delivery date of each line quoted by WMS available-to-promise
+ALTER TABLE quotation_line_items
+    ADD COLUMN IF NOT EXISTS promise_date DATE;
+
+ALTER TABLE so_line_items
+    ADD COLUMN IF NOT EXISTS promise_date DATE;
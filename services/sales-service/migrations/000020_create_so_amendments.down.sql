@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS so_line_amendments;
+DROP TABLE IF EXISTS so_amendments;
+
+ALTER TABLE sales_orders
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,39 @@
+-- Versioned amendments of confirmed sales orders
+ALTER TABLE sales_orders
+    ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS so_amendments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    sales_order_id UUID NOT NULL REFERENCES sales_orders(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    reason TEXT,
+    old_delivery_date DATE,
+    new_delivery_date DATE,
+    old_total_amount DECIMAL(18,2) DEFAULT 0,
+    new_total_amount DECIMAL(18,2) DEFAULT 0,
+    amended_at TIMESTAMP NOT NULL,
+    amended_by UUID,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (sales_order_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS so_line_amendments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    amendment_id UUID NOT NULL REFERENCES so_amendments(id) ON DELETE CASCADE,
+    so_line_item_id UUID NOT NULL,
+    version INT NOT NULL,
+    line_number INT,
+    product_id UUID NOT NULL,
+    product_code VARCHAR(50),
+    change_type VARCHAR(20) NOT NULL,
+    old_quantity DECIMAL(18,3) DEFAULT 0,
+    new_quantity DECIMAL(18,3) DEFAULT 0,
+    old_unit_price DECIMAL(18,2) DEFAULT 0,
+    new_unit_price DECIMAL(18,2) DEFAULT 0,
+    old_line_total DECIMAL(18,2) DEFAULT 0,
+    new_line_total DECIMAL(18,2) DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_so_amendments_order ON so_amendments(sales_order_id);
+CREATE INDEX IF NOT EXISTS idx_so_line_amendments_line ON so_line_amendments(so_line_item_id);
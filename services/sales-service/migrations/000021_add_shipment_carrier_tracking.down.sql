@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_shipments_tracking_number;
+DROP TABLE IF EXISTS shipment_tracking_events;
+
+ALTER TABLE shipments
+    DROP COLUMN IF EXISTS tracking_updated_at,
+    DROP COLUMN IF EXISTS tracking_status,
+    DROP COLUMN IF EXISTS label_url,
+    DROP COLUMN IF EXISTS carrier_service;
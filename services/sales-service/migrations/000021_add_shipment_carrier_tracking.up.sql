@@ -0,0 +1,20 @@
+-- Carrier bookings and tracking of shipments
+ALTER TABLE shipments
+    ADD COLUMN IF NOT EXISTS carrier_service VARCHAR(50),
+    ADD COLUMN IF NOT EXISTS label_url TEXT,
+    ADD COLUMN IF NOT EXISTS tracking_status VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS tracking_updated_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS shipment_tracking_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    shipment_id UUID NOT NULL REFERENCES shipments(id) ON DELETE CASCADE,
+    tracking_number VARCHAR(100),
+    status VARCHAR(30) NOT NULL,
+    description TEXT,
+    location VARCHAR(200),
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipment_tracking_event ON shipment_tracking_events(shipment_id, status, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_shipments_tracking_number ON shipments(tracking_number);
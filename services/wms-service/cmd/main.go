@@ -16,10 +16,14 @@ import (
 	"github.com/erp-cosmetics/wms-service/internal/delivery/http/router"
 	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
 	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/manufacturing"
 	"github.com/erp-cosmetics/wms-service/internal/infrastructure/persistence/postgres"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/procurement"
 	"github.com/erp-cosmetics/wms-service/internal/infrastructure/scheduler"
 	"github.com/erp-cosmetics/wms-service/internal/infrastructure/subscriber"
 	adjustment_uc "github.com/erp-cosmetics/wms-service/internal/usecase/adjustment"
+	atp_uc "github.com/erp-cosmetics/wms-service/internal/usecase/atp"
+	backorder_uc "github.com/erp-cosmetics/wms-service/internal/usecase/backorder"
 	grn_uc "github.com/erp-cosmetics/wms-service/internal/usecase/grn"
 	inventory_uc "github.com/erp-cosmetics/wms-service/internal/usecase/inventory"
 	issue_uc "github.com/erp-cosmetics/wms-service/internal/usecase/issue"
@@ -30,6 +34,7 @@ import (
 	"github.com/erp-cosmetics/shared/pkg/database"
 	"github.com/erp-cosmetics/shared/pkg/logger"
 	natspkg "github.com/erp-cosmetics/shared/pkg/nats"
+	"github.com/google/uuid"
 	natslib "github.com/nats-io/nats.go"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -79,6 +84,7 @@ func main() {
 		&entity.GILineItem{},
 		&entity.InventoryCount{},
 		&entity.InventoryCountLineItem{},
+		&entity.Backorder{},
 	)
 	if err != nil {
 		log.Warn("Auto-migration warning", zap.Error(err))
@@ -103,6 +109,7 @@ func main() {
 	grnRepo := postgres.NewGRNRepository(db)
 	issueRepo := postgres.NewGoodsIssueRepository(db)
 	inventoryCountRepo := postgres.NewInventoryCountRepository(db)
+	backorderRepo := postgres.NewBackorderRepository(db)
 
 	// Initialize event publisher
 	eventPub := event.NewPublisher(natsClient, log)
@@ -118,12 +125,22 @@ func main() {
 	issueStockFEFOUC := stock_uc.NewIssueStockFEFOUseCase(stockRepo, eventPub)
 	reserveStockUC := stock_uc.NewReserveStockUseCase(stockRepo, eventPub)
 	releaseReservationUC := stock_uc.NewReleaseReservationUseCase(stockRepo)
+	returnToStockUC := stock_uc.NewReturnToStockUseCase(stockRepo, eventPub)
+	receiveSalesReturnUC := stock_uc.NewReceiveSalesReturnUseCase(stockRepo, lotRepo, issueRepo, zoneRepo, locationRepo, eventPub)
+	settleSalesReturnUC := stock_uc.NewSettleSalesReturnUseCase(stockRepo, lotRepo, issueRepo)
+	productionWarehouseID, err := uuid.Parse(cfg.ProductionWarehouseID)
+	if err != nil {
+		log.Warn("PRODUCTION_WAREHOUSE_ID is not set, work order output will not be received", zap.Error(err))
+	}
+	receiveProductionUC := stock_uc.NewReceiveProductionUseCase(stockRepo, lotRepo, zoneRepo, locationRepo, eventPub, productionWarehouseID, cfg.ProductShelfLifeMonths)
 
 	// Initialize lot use cases
 	getLotUC := lot_uc.NewGetLotUseCase(lotRepo)
 	listLotsUC := lot_uc.NewListLotsUseCase(lotRepo)
 	getExpiringLotsUC := lot_uc.NewGetExpiringLotsUseCase(lotRepo)
 	getLotMovementsUC := lot_uc.NewGetLotMovementsUseCase(stockRepo)
+	blockLotsUC := lot_uc.NewBlockLotsUseCase(lotRepo)
+	updateLotCostUC := lot_uc.NewUpdateLotCostUseCase(lotRepo)
 
 	// Initialize GRN use cases
 	createGRNUC := grn_uc.NewCreateGRNUseCase(grnRepo, lotRepo, stockRepo, zoneRepo, locationRepo, eventPub)
@@ -141,6 +158,19 @@ func main() {
 	releaseReservationUC2 := reservation_uc.NewReleaseReservationUseCase(stockRepo)
 	checkAvailabilityUC := reservation_uc.NewCheckAvailabilityUseCase(stockRepo)
 
+	// Initialize Backorder use cases
+	reserveSalesOrderUC := backorder_uc.NewReserveSalesOrderUseCase(stockRepo, backorderRepo, createReservationUC, eventPub, cfg.BackorderLeadTimeDays)
+	allocateBackordersUC := backorder_uc.NewAllocateBackordersUseCase(stockRepo, backorderRepo, createReservationUC, eventPub)
+	cancelBackordersUC := backorder_uc.NewCancelBackordersUseCase(backorderRepo)
+	amendSalesOrderUC := backorder_uc.NewAmendSalesOrderUseCase(stockRepo, backorderRepo, reserveSalesOrderUC, createReservationUC)
+	rescheduleBackorderUC := backorder_uc.NewRescheduleBackorderUseCase(backorderRepo, eventPub)
+	listBackordersUC := backorder_uc.NewListBackordersUseCase(backorderRepo)
+
+	// Initialize ATP use cases
+	procurementClient := procurement.NewClient(cfg.ProcurementServiceURL)
+	manufacturingClient := manufacturing.NewClient(cfg.ManufacturingServiceURL)
+	getATPUC := atp_uc.NewGetATPUseCase(stockRepo, backorderRepo, procurementClient, manufacturingClient, cfg.BackorderLeadTimeDays)
+
 	// Initialize Adjustment use cases
 	createAdjustmentUC := adjustment_uc.NewCreateAdjustmentUseCase(stockRepo)
 	transferStockUC := adjustment_uc.NewTransferStockUseCase(stockRepo)
@@ -165,6 +195,8 @@ func main() {
 		createInventoryCountUC, startInventoryCountUC, recordCountUC,
 		completeInventoryCountUC, getInventoryCountUC, listInventoryCountsUC,
 	)
+	backorderHandler := handler.NewBackorderHandler(listBackordersUC, rescheduleBackorderUC, allocateBackordersUC)
+	atpHandler := handler.NewATPHandler(getATPUC)
 	healthHandler := handler.NewHealthHandler()
 
 	// Setup router
@@ -178,6 +210,8 @@ func main() {
 		adjustmentHandler,
 		inventoryCountHandler,
 		healthHandler,
+		backorderHandler,
+		atpHandler,
 	)
 
 	// Start scheduler for expiry alerts
@@ -231,6 +265,17 @@ func main() {
 		createGRNUC,
 		createReservationUC,
 		releaseReservationUC2,
+		issueStockFEFOUC,
+		returnToStockUC,
+		receiveSalesReturnUC,
+		settleSalesReturnUC,
+		blockLotsUC,
+		updateLotCostUC,
+		reserveSalesOrderUC,
+		allocateBackordersUC,
+		cancelBackordersUC,
+		amendSalesOrderUC,
+		receiveProductionUC,
 	)
 	if err := eventSub.Start(); err != nil {
 		log.Warn("Failed to start event subscriber", zap.Error(err))
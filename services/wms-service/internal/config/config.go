@@ -38,6 +38,13 @@ type Config struct {
 	LowStockCheckInterval  string `mapstructure:"LOW_STOCK_CHECK_INTERVAL"`
 	ColdStorageMinTemp     int    `mapstructure:"COLD_STORAGE_MIN_TEMP"`
 	ColdStorageMaxTemp     int    `mapstructure:"COLD_STORAGE_MAX_TEMP"`
+	BackorderLeadTimeDays  int    `mapstructure:"BACKORDER_LEAD_TIME_DAYS"`  // Expected date of new backorders and capable-to-promise dates
+	ProductionWarehouseID  string `mapstructure:"PRODUCTION_WAREHOUSE_ID"`   // Warehouse receiving the output of completed work orders
+	ProductShelfLifeMonths int    `mapstructure:"PRODUCT_SHELF_LIFE_MONTHS"` // Expiry of produced lots
+
+	// Services read for available-to-promise
+	ProcurementServiceURL   string `mapstructure:"PROCUREMENT_SERVICE_URL"`
+	ManufacturingServiceURL string `mapstructure:"MANUFACTURING_SERVICE_URL"`
 }
 
 // Load loads configuration
@@ -69,6 +76,11 @@ func Load() (*Config, error) {
 	viper.SetDefault("LOW_STOCK_CHECK_INTERVAL", "1h")
 	viper.SetDefault("COLD_STORAGE_MIN_TEMP", 2)
 	viper.SetDefault("COLD_STORAGE_MAX_TEMP", 8)
+	viper.SetDefault("BACKORDER_LEAD_TIME_DAYS", 14)
+	viper.SetDefault("PRODUCT_SHELF_LIFE_MONTHS", 36)
+
+	viper.SetDefault("PROCUREMENT_SERVICE_URL", "http://localhost:8085")
+	viper.SetDefault("MANUFACTURING_SERVICE_URL", "http://localhost:8087")
 
 	var cfg Config
 	if err := viper.Unmarshal(&cfg); err != nil {
@@ -30,6 +30,7 @@ type CreateGRNItemRequest struct {
 	ManufacturedDate  *string    `json:"manufactured_date"`
 	ExpiryDate        string     `json:"expiry_date" binding:"required"`
 	LocationID        *uuid.UUID `json:"location_id"`
+	UnitCost          float64    `json:"unit_cost" binding:"gte=0"` // PO price, optional
 }
 
 // CompleteGRNRequest represents request to complete GRN
@@ -145,3 +146,24 @@ type AdjustmentRequest struct {
 	Reason         string     `json:"reason"`
 	Notes          string     `json:"notes"`
 }
+
+// RescheduleBackorderRequest represents request to change the expected date of a backorder
+type RescheduleBackorderRequest struct {
+	ExpectedDate string `json:"expected_date" binding:"required"` // YYYY-MM-DD
+}
+
+// AllocateBackordersRequest represents request to allocate available stock to backorders
+type AllocateBackordersRequest struct {
+	MaterialID uuid.UUID `json:"material_id" binding:"required"`
+}
+
+// ATPRequest represents request to promise the lines of a quotation or order
+type ATPRequest struct {
+	Lines []ATPLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+// ATPLineRequest represents a quantity of a material to promise
+type ATPLineRequest struct {
+	MaterialID uuid.UUID `json:"material_id" binding:"required"`
+	Quantity   float64   `json:"quantity" binding:"required,gt=0"`
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/erp-cosmetics/wms-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/atp"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ATPHandler handles available-to-promise endpoints
+type ATPHandler struct {
+	getATPUC *atp.GetATPUseCase
+}
+
+// NewATPHandler creates a new ATP handler
+func NewATPHandler(getATPUC *atp.GetATPUseCase) *ATPHandler {
+	return &ATPHandler{getATPUC: getATPUC}
+}
+
+// GetATP handles GET /atp/:material_id
+func (h *ATPHandler) GetATP(c *gin.Context) {
+	materialID, err := uuid.Parse(c.Param("material_id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("Invalid material ID"))
+		return
+	}
+
+	quantity, err := strconv.ParseFloat(c.DefaultQuery("quantity", "0"), 64)
+	if err != nil || quantity < 0 {
+		response.Error(c, errors.BadRequest("Invalid quantity"))
+		return
+	}
+
+	result, err := h.getATPUC.Execute(c.Request.Context(), materialID, quantity)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// PromiseLines handles POST /atp
+func (h *ATPHandler) PromiseLines(c *gin.Context) {
+	var req dto.ATPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	lines := make([]atp.ATPLine, len(req.Lines))
+	for i, line := range req.Lines {
+		lines[i] = atp.ATPLine{MaterialID: line.MaterialID, Quantity: line.Quantity}
+	}
+
+	results, err := h.getATPUC.ExecuteLines(c.Request.Context(), lines)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, results)
+}
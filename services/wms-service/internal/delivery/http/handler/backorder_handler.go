@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/erp-cosmetics/shared/pkg/errors"
+	"github.com/erp-cosmetics/shared/pkg/response"
+	"github.com/erp-cosmetics/wms-service/internal/delivery/http/dto"
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/backorder"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BackorderHandler handles backorder endpoints
+type BackorderHandler struct {
+	listBackordersUC      *backorder.ListBackordersUseCase
+	rescheduleBackorderUC *backorder.RescheduleBackorderUseCase
+	allocateBackordersUC  *backorder.AllocateBackordersUseCase
+}
+
+// NewBackorderHandler creates a new backorder handler
+func NewBackorderHandler(
+	listBackordersUC *backorder.ListBackordersUseCase,
+	rescheduleBackorderUC *backorder.RescheduleBackorderUseCase,
+	allocateBackordersUC *backorder.AllocateBackordersUseCase,
+) *BackorderHandler {
+	return &BackorderHandler{
+		listBackordersUC:      listBackordersUC,
+		rescheduleBackorderUC: rescheduleBackorderUC,
+		allocateBackordersUC:  allocateBackordersUC,
+	}
+}
+
+// ListBackorders handles GET /backorders
+func (h *BackorderHandler) ListBackorders(c *gin.Context) {
+	filter := &repository.BackorderFilter{
+		Status: c.Query("status"),
+		Page:   getPageParam(c),
+		Limit:  getLimitParam(c),
+	}
+
+	if materialID := c.Query("material_id"); materialID != "" {
+		id, _ := uuid.Parse(materialID)
+		filter.MaterialID = &id
+	}
+	if referenceID := c.Query("so_id"); referenceID != "" {
+		id, _ := uuid.Parse(referenceID)
+		filter.ReferenceID = &id
+	}
+	if customerID := c.Query("customer_id"); customerID != "" {
+		id, _ := uuid.Parse(customerID)
+		filter.CustomerID = &id
+	}
+
+	backorders, total, err := h.listBackordersUC.Execute(c.Request.Context(), filter)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.SuccessWithMeta(c, backorders, response.NewMeta(filter.Page, filter.Limit, total))
+}
+
+// RescheduleBackorder handles PATCH /backorders/:id/reschedule
+func (h *BackorderHandler) RescheduleBackorder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.Error(c, errors.BadRequest("Invalid backorder ID"))
+		return
+	}
+
+	var req dto.RescheduleBackorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	expectedDate, err := time.Parse("2006-01-02", req.ExpectedDate)
+	if err != nil {
+		response.Error(c, errors.BadRequest("Invalid expected date format"))
+		return
+	}
+
+	result, err := h.rescheduleBackorderUC.Execute(c.Request.Context(), id, expectedDate)
+	if err != nil {
+		switch err {
+		case entity.ErrNotFound:
+			response.Error(c, errors.NotFound("Backorder"))
+		case entity.ErrBackorderNotOpen:
+			response.Error(c, errors.BadRequest("Backorder is not open"))
+		default:
+			response.Error(c, errors.Internal(err))
+		}
+		return
+	}
+
+	response.Success(c, result)
+}
+
+// AllocateBackorders handles POST /backorders/allocate
+func (h *BackorderHandler) AllocateBackorders(c *gin.Context) {
+	var req dto.AllocateBackordersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, errors.BadRequest(err.Error()))
+		return
+	}
+
+	allocated, err := h.allocateBackordersUC.Execute(c.Request.Context(), req.MaterialID)
+	if err != nil {
+		response.Error(c, errors.Internal(err))
+		return
+	}
+
+	response.Success(c, allocated)
+}
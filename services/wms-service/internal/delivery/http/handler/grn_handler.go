@@ -89,6 +89,7 @@ func (h *GRNHandler) CreateGRN(c *gin.Context) {
 			ManufacturedDate:  manufacturedDate,
 			ExpiryDate:        expiryDate,
 			LocationID:        item.LocationID,
+			UnitCost:          item.UnitCost,
 		}
 	}
 
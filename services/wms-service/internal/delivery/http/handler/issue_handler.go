@@ -135,6 +135,10 @@ func (h *GoodsIssueHandler) ListGoodsIssues(c *gin.Context) {
 		id, _ := uuid.Parse(warehouseID)
 		filter.WarehouseID = &id
 	}
+	if lotID := c.Query("lot_id"); lotID != "" {
+		id, _ := uuid.Parse(lotID)
+		filter.LotID = &id
+	}
 
 	issues, total, err := h.listIssuesUC.Execute(c.Request.Context(), filter)
 	if err != nil {
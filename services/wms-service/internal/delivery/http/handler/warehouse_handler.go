@@ -143,6 +143,10 @@ func (h *StockHandler) ListStock(c *gin.Context) {
 		id, _ := uuid.Parse(materialID)
 		filter.MaterialID = &id
 	}
+	if lotID := c.Query("lot_id"); lotID != "" {
+		id, _ := uuid.Parse(lotID)
+		filter.LotID = &id
+	}
 	if hasStock := c.Query("has_stock"); hasStock == "true" {
 		t := true
 		filter.HasStock = &t
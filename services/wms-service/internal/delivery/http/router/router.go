@@ -16,6 +16,8 @@ func SetupRouter(
 	adjustmentHandler *handler.AdjustmentHandler,
 	inventoryCountHandler *handler.InventoryCountHandler,
 	healthHandler *handler.HealthHandler,
+	backorderHandler *handler.BackorderHandler,
+	atpHandler *handler.ATPHandler,
 ) *gin.Engine {
 	r := gin.New()
 	r.Use(gin.Logger())
@@ -107,6 +109,21 @@ func SetupRouter(
 			inventoryCounts.POST("/:id/record", inventoryCountHandler.RecordCount)
 			inventoryCounts.PATCH("/:id/complete", inventoryCountHandler.CompleteInventoryCount)
 		}
+
+		// Backorder endpoints
+		backorders := v1.Group("/backorders")
+		{
+			backorders.GET("", backorderHandler.ListBackorders)
+			backorders.PATCH("/:id/reschedule", backorderHandler.RescheduleBackorder)
+			backorders.POST("/allocate", backorderHandler.AllocateBackorders)
+		}
+
+		// Available-to-promise endpoints
+		atp := v1.Group("/atp")
+		{
+			atp.GET("/:material_id", atpHandler.GetATP)
+			atp.POST("", atpHandler.PromiseLines)
+		}
 	}
 
 	return r
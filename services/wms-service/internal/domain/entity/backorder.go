@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BackorderStatus represents the status of a backorder
+type BackorderStatus string
+
+const (
+	BackorderStatusOpen      BackorderStatus = "OPEN"
+	BackorderStatusAllocated BackorderStatus = "ALLOCATED" // Fully reserved from stock received later
+	BackorderStatusCancelled BackorderStatus = "CANCELLED"
+)
+
+// Backorder represents the part of a sales order line that could not be
+// reserved when the order was confirmed. It is reserved from stock received
+// later, in priority order.
+type Backorder struct {
+	ID               uuid.UUID       `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	MaterialID       uuid.UUID       `json:"material_id" gorm:"type:uuid;not null"`
+	UnitID           uuid.UUID       `json:"unit_id" gorm:"type:uuid;not null"`
+	ReferenceID      uuid.UUID       `json:"reference_id" gorm:"type:uuid;not null"` // Sales order
+	ReferenceNumber  string          `json:"reference_number" gorm:"type:varchar(30)"`
+	ReferenceLineID  *uuid.UUID      `json:"reference_line_id" gorm:"type:uuid"`
+	CustomerID       uuid.UUID       `json:"customer_id" gorm:"type:uuid;not null"`
+	CustomerPriority int             `json:"customer_priority" gorm:"not null;default:0"` // Customer tier, lower is served first
+	OrderDate        time.Time       `json:"order_date" gorm:"type:date;not null"`
+	PromisedDate     *time.Time      `json:"promised_date" gorm:"type:date"`
+	OrderedQty       float64         `json:"ordered_qty" gorm:"type:decimal(15,4);not null"`
+	BackorderedQty   float64         `json:"backordered_qty" gorm:"type:decimal(15,4);not null"` // Still to reserve
+	AllocatedQty     float64         `json:"allocated_qty" gorm:"type:decimal(15,4);not null;default:0"`
+	ExpectedDate     *time.Time      `json:"expected_date" gorm:"type:date"`
+	Status           BackorderStatus `json:"status" gorm:"type:varchar(20);default:'OPEN'"`
+	AllocatedAt      *time.Time      `json:"allocated_at"`
+	CancelledAt      *time.Time      `json:"cancelled_at"`
+	CreatedAt        time.Time       `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt        time.Time       `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// TableName returns the table name
+func (Backorder) TableName() string {
+	return "backorders"
+}
+
+// IsOpen returns true if the backorder still waits for stock
+func (b *Backorder) IsOpen() bool {
+	return b.Status == BackorderStatusOpen
+}
+
+// ReservedQty returns the quantity of the line reserved so far
+func (b *Backorder) ReservedQty() float64 {
+	return b.OrderedQty - b.BackorderedQty
+}
+
+// Allocate records stock reserved for the backorder
+func (b *Backorder) Allocate(qty float64) {
+	now := time.Now()
+	b.AllocatedQty += qty
+	b.BackorderedQty -= qty
+	if b.BackorderedQty <= 0 {
+		b.BackorderedQty = 0
+		b.Status = BackorderStatusAllocated
+		b.AllocatedAt = &now
+	}
+	b.UpdatedAt = now
+}
+
+// Reduce takes up to qty off the backordered quantity of an amended order
+// line and returns how much was taken. A backorder reduced to nothing is
+// cancelled.
+func (b *Backorder) Reduce(qty float64) float64 {
+	taken := math.Min(qty, b.BackorderedQty)
+	b.OrderedQty -= taken
+	b.BackorderedQty -= taken
+	b.UpdatedAt = time.Now()
+	if b.BackorderedQty <= 0 {
+		b.BackorderedQty = 0
+		b.Cancel()
+	}
+	return taken
+}
+
+// Cancel cancels the backorder
+func (b *Backorder) Cancel() {
+	now := time.Now()
+	b.Status = BackorderStatusCancelled
+	b.CancelledAt = &now
+	b.UpdatedAt = now
+}
+
+// Precedes returns true if the backorder is served before other: higher
+// customer tier first, then earlier order date, then earlier promised date.
+// Backorders without a promised date go last among equals.
+func (b *Backorder) Precedes(other *Backorder) bool {
+	if b.CustomerPriority != other.CustomerPriority {
+		return b.CustomerPriority < other.CustomerPriority
+	}
+	if !b.OrderDate.Equal(other.OrderDate) {
+		return b.OrderDate.Before(other.OrderDate)
+	}
+	switch {
+	case b.PromisedDate == nil && other.PromisedDate == nil:
+	case b.PromisedDate == nil:
+		return false
+	case other.PromisedDate == nil:
+		return true
+	case !b.PromisedDate.Equal(*other.PromisedDate):
+		return b.PromisedDate.Before(*other.PromisedDate)
+	}
+	return b.CreatedAt.Before(other.CreatedAt)
+}
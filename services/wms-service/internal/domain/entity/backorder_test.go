@@ -0,0 +1,76 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackorder_Precedes(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	early := day.AddDate(0, 0, 7)
+	late := day.AddDate(0, 0, 14)
+
+	tests := []struct {
+		name     string
+		a, b     entity.Backorder
+		expected bool
+	}{
+		{
+			name:     "Higher customer tier first",
+			a:        entity.Backorder{CustomerPriority: 1, OrderDate: day.AddDate(0, 0, 1)},
+			b:        entity.Backorder{CustomerPriority: 2, OrderDate: day},
+			expected: true,
+		},
+		{
+			name:     "Earlier order date first",
+			a:        entity.Backorder{CustomerPriority: 2, OrderDate: day.AddDate(0, 0, 1)},
+			b:        entity.Backorder{CustomerPriority: 2, OrderDate: day},
+			expected: false,
+		},
+		{
+			name:     "Earlier promised date first",
+			a:        entity.Backorder{OrderDate: day, PromisedDate: &early},
+			b:        entity.Backorder{OrderDate: day, PromisedDate: &late},
+			expected: true,
+		},
+		{
+			name:     "Without promised date last",
+			a:        entity.Backorder{OrderDate: day},
+			b:        entity.Backorder{OrderDate: day, PromisedDate: &late},
+			expected: false,
+		},
+		{
+			name:     "Created first among equals",
+			a:        entity.Backorder{OrderDate: day, CreatedAt: day},
+			b:        entity.Backorder{OrderDate: day, CreatedAt: day.Add(time.Minute)},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.a.Precedes(&tt.b))
+		})
+	}
+}
+
+func TestBackorder_Allocate(t *testing.T) {
+	backorder := &entity.Backorder{
+		OrderedQty:     100,
+		BackorderedQty: 60,
+		Status:         entity.BackorderStatusOpen,
+	}
+
+	backorder.Allocate(40)
+	assert.True(t, backorder.IsOpen())
+	assert.Equal(t, 20.0, backorder.BackorderedQty)
+	assert.Equal(t, 80.0, backorder.ReservedQty())
+
+	backorder.Allocate(20)
+	assert.Equal(t, entity.BackorderStatusAllocated, backorder.Status)
+	assert.Equal(t, 0.0, backorder.BackorderedQty)
+	assert.NotNil(t, backorder.AllocatedAt)
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// BusinessLocation is the time zone the warehouses operate in (Vietnam, UTC+7,
+// without daylight saving time). Promise and expected dates are calendar days
+// there, not in UTC.
+var BusinessLocation = time.FixedZone("ICT", 7*60*60)
+
+// BusinessDate returns midnight of the business day that t falls on
+func BusinessDate(t time.Time) time.Time {
+	t = t.In(BusinessLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, BusinessLocation)
+}
+
+// BusinessToday returns midnight of the current business day
+func BusinessToday() time.Time {
+	return BusinessDate(time.Now())
+}
+
+// ParseBusinessDate parses a YYYY-MM-DD date as a business day
+func ParseBusinessDate(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", s, BusinessLocation)
+}
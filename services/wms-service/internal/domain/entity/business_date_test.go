@@ -0,0 +1,46 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		t        time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Morning in Vietnam is the previous evening in UTC",
+			t:        time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 3, 2, 0, 0, 0, 0, entity.BusinessLocation),
+		},
+		{
+			name:     "Afternoon in Vietnam",
+			t:        time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 3, 1, 0, 0, 0, 0, entity.BusinessLocation),
+		},
+		{
+			name:     "Midnight in Vietnam",
+			t:        time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC),
+			expected: time.Date(2026, 3, 2, 0, 0, 0, 0, entity.BusinessLocation),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.expected.Equal(entity.BusinessDate(tt.t)))
+		})
+	}
+}
+
+func TestParseBusinessDate(t *testing.T) {
+	date, err := entity.ParseBusinessDate("2026-03-01")
+
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2026, 3, 1, 0, 0, 0, 0, entity.BusinessLocation).Equal(date))
+}
@@ -4,16 +4,23 @@ import "errors"
 
 // Domain errors
 var (
-	ErrNotFound          = errors.New("not found")
-	ErrInsufficientStock = errors.New("insufficient stock")
-	ErrLotExpired        = errors.New("lot is expired")
-	ErrLotNotAvailable   = errors.New("lot is not available")
-	ErrQCNotPassed       = errors.New("QC not passed")
-	ErrAlreadyCompleted  = errors.New("already completed")
-	ErrAlreadyCancelled  = errors.New("already cancelled")
-	ErrInvalidStatus     = errors.New("invalid status")
-	ErrInvalidQuantity   = errors.New("invalid quantity")
-	ErrReservationFailed = errors.New("reservation failed")
-	ErrColdStorageAlert  = errors.New("cold storage temperature out of range")
-	ErrPendingItems      = errors.New("pending items exist")
+	ErrNotFound              = errors.New("not found")
+	ErrInsufficientStock     = errors.New("insufficient stock")
+	ErrLotExpired            = errors.New("lot is expired")
+	ErrLotNotAvailable       = errors.New("lot is not available")
+	ErrQCNotPassed           = errors.New("QC not passed")
+	ErrAlreadyCompleted      = errors.New("already completed")
+	ErrAlreadyCancelled      = errors.New("already cancelled")
+	ErrInvalidStatus         = errors.New("invalid status")
+	ErrInvalidQuantity       = errors.New("invalid quantity")
+	ErrReservationFailed     = errors.New("reservation failed")
+	ErrColdStorageAlert      = errors.New("cold storage temperature out of range")
+	ErrPendingItems          = errors.New("pending items exist")
+	ErrSourceLocationUnknown = errors.New("no issue location found for lot")
+	ErrInvalidUnitCost       = errors.New("invalid unit cost")
+	ErrReturnLotUnknown      = errors.New("returned lot could not be resolved")
+	ErrNoReturnsLocation     = errors.New("no returns location in warehouse")
+	ErrNoQuarantineLocation  = errors.New("no quarantine location in warehouse")
+	ErrReturnNotReceived     = errors.New("return has not been received")
+	ErrBackorderNotOpen      = errors.New("backorder is not open")
 )
@@ -39,6 +39,8 @@ type Lot struct {
 	GRNID             *uuid.UUID `json:"grn_id" gorm:"type:uuid"`
 	QCStatus          QCStatus   `json:"qc_status" gorm:"type:varchar(20);default:'PENDING'"`
 	Status            LotStatus  `json:"status" gorm:"type:varchar(20);default:'AVAILABLE'"`
+	UnitCost          float64    `json:"unit_cost" gorm:"type:decimal(18,4);default:0"` // Valuation per base unit, 0 if unknown
+	CostUpdatedAt     *time.Time `json:"cost_updated_at"`
 	Notes             string     `json:"notes" gorm:"type:text"`
 	CreatedAt         time.Time  `json:"created_at" gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt         time.Time  `json:"updated_at" gorm:"default:CURRENT_TIMESTAMP"`
@@ -106,3 +108,11 @@ func (l *Lot) Unblock() {
 	}
 	l.UpdatedAt = time.Now()
 }
+
+// SetUnitCost revalues the lot
+func (l *Lot) SetUnitCost(unitCost float64) {
+	now := time.Now()
+	l.UnitCost = unitCost
+	l.CostUpdatedAt = &now
+	l.UpdatedAt = now
+}
@@ -26,6 +26,7 @@ const (
 	ReferenceTypeTransfer    ReferenceType = "TRANSFER"
 	ReferenceTypeAdjustment  ReferenceType = "ADJUSTMENT"
 	ReferenceTypeReservation ReferenceType = "RESERVATION"
+	ReferenceTypeSalesReturn ReferenceType = "SALES_RETURN"
 )
 
 // StockMovement represents a stock movement transaction
@@ -46,6 +46,7 @@ const (
 	ZoneTypeCold       ZoneType = "COLD"
 	ZoneTypePicking    ZoneType = "PICKING"
 	ZoneTypeShipping   ZoneType = "SHIPPING"
+	ZoneTypeReturns    ZoneType = "RETURNS"
 )
 
 // Zone represents a zone within a warehouse
@@ -80,3 +81,8 @@ func (z *Zone) IsColdZone() bool {
 func (z *Zone) IsQuarantineZone() bool {
 	return z.ZoneType == ZoneTypeQuarantine
 }
+
+// IsReturnsZone returns true if zone holds customer returns awaiting disposition
+func (z *Zone) IsReturnsZone() bool {
+	return z.ZoneType == ZoneTypeReturns
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// BackorderRepository defines backorder repository interface
+type BackorderRepository interface {
+	Create(ctx context.Context, backorder *entity.Backorder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Backorder, error)
+	Update(ctx context.Context, backorder *entity.Backorder) error
+	List(ctx context.Context, filter *BackorderFilter) ([]*entity.Backorder, int64, error)
+
+	// Open backorders waiting for stock
+	GetOpenByMaterial(ctx context.Context, materialID uuid.UUID) ([]*entity.Backorder, error)
+	GetOpenByReference(ctx context.Context, referenceID uuid.UUID) ([]*entity.Backorder, error)
+}
+
+// BackorderFilter defines filter options for backorders
+type BackorderFilter struct {
+	MaterialID  *uuid.UUID
+	ReferenceID *uuid.UUID
+	CustomerID  *uuid.UUID
+	Status      string
+	Page        int
+	Limit       int
+}
@@ -59,8 +59,10 @@ type GoodsIssueFilter struct {
 	WarehouseID   *uuid.UUID
 	IssueType     string
 	ReferenceType string
+	ReferenceID   *uuid.UUID // Issues for this document (e.g. sales order); line items are loaded
 	Status        string
 	Search        string
+	LotID         *uuid.UUID // Issues with a line from this lot; only those lines are loaded
 	Page          int
 	Limit         int
 }
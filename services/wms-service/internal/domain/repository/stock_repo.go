@@ -34,6 +34,7 @@ type StockRepository interface {
 	// Reservation
 	ReserveStock(ctx context.Context, materialID uuid.UUID, quantity float64, reservation *entity.StockReservation) error
 	ReleaseReservation(ctx context.Context, reservationID uuid.UUID) error
+	GetActiveReservationsByReference(ctx context.Context, referenceID uuid.UUID) ([]*entity.StockReservation, error)
 	
 	// Aggregations
 	GetMaterialSummary(ctx context.Context, materialID uuid.UUID) (*entity.StockSummary, error)
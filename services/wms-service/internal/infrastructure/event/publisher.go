@@ -31,6 +31,10 @@ const (
 	SubjectLowStockAlert   = "wms.stock.low_stock_alert"
 	SubjectLotExpiringSoon = "wms.lot.expiring_soon"
 	SubjectLotExpired      = "wms.lot.expired"
+
+	SubjectBackorderCreated     = "wms.backorder.created"
+	SubjectBackorderAllocated   = "wms.backorder.allocated"
+	SubjectBackorderRescheduled = "wms.backorder.rescheduled"
 )
 
 // GRNCreatedEvent represents GRN created event
@@ -111,6 +115,27 @@ type LotExpiringEvent struct {
 	Quantity        float64 `json:"quantity"`
 }
 
+// BackorderEvent represents backorders of a sales order created, allocated
+// from received stock or rescheduled
+type BackorderEvent struct {
+	SOID       string               `json:"so_id"`
+	SONumber   string               `json:"so_number"`
+	CustomerID string               `json:"customer_id"`
+	Lines      []BackorderEventLine `json:"lines"`
+}
+
+// BackorderEventLine represents a backordered sales order line
+type BackorderEventLine struct {
+	BackorderID    string  `json:"backorder_id"`
+	LineID         string  `json:"line_id,omitempty"`
+	MaterialID     string  `json:"material_id"`
+	OrderedQty     float64 `json:"ordered_qty"`
+	ReservedQty    float64 `json:"reserved_qty"`
+	BackorderedQty float64 `json:"backordered_qty"`
+	ExpectedDate   string  `json:"expected_date,omitempty"`
+	Status         string  `json:"status"`
+}
+
 // PublishGRNCreated publishes GRN created event
 func (p *Publisher) PublishGRNCreated(event *GRNCreatedEvent) error {
 	return p.publish(SubjectGRNCreated, event)
@@ -151,6 +176,21 @@ func (p *Publisher) PublishLotExpired(event *LotExpiringEvent) error {
 	return p.publish(SubjectLotExpired, event)
 }
 
+// PublishBackorderCreated publishes backorder created event
+func (p *Publisher) PublishBackorderCreated(event *BackorderEvent) error {
+	return p.publish(SubjectBackorderCreated, event)
+}
+
+// PublishBackorderAllocated publishes backorder allocated event
+func (p *Publisher) PublishBackorderAllocated(event *BackorderEvent) error {
+	return p.publish(SubjectBackorderAllocated, event)
+}
+
+// PublishBackorderRescheduled publishes backorder rescheduled event
+func (p *Publisher) PublishBackorderRescheduled(event *BackorderEvent) error {
+	return p.publish(SubjectBackorderRescheduled, event)
+}
+
 func (p *Publisher) publish(subject string, data interface{}) error {
 	if p.client == nil {
 		p.logger.Warn("NATS client not available, skipping event publish",
@@ -0,0 +1,120 @@
+package manufacturing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// pageSize is the largest page manufacturing-service returns
+const pageSize = 100
+
+// openStatuses are the work order statuses whose output is still to come
+var openStatuses = []string{"PLANNED", "RELEASED", "IN_PROGRESS", "QC_PENDING"}
+
+// Client reads work orders from manufacturing-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new manufacturing-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// WorkOrder is a work order producing a product
+type WorkOrder struct {
+	ID              uuid.UUID  `json:"id"`
+	WONumber        string     `json:"wo_number"`
+	ProductID       uuid.UUID  `json:"product_id"`
+	Status          string     `json:"status"`
+	PlannedQuantity float64    `json:"planned_quantity"`
+	GoodQuantity    *float64   `json:"good_quantity,omitempty"`
+	PlannedEndDate  *time.Time `json:"planned_end_date,omitempty"`
+}
+
+// ExpectedQuantity returns the good quantity once produced, otherwise the
+// planned quantity
+func (wo *WorkOrder) ExpectedQuantity() float64 {
+	if wo.GoodQuantity != nil {
+		return *wo.GoodQuantity
+	}
+	return wo.PlannedQuantity
+}
+
+// ListOpenWorkOrders returns the planned, released, in-progress and QC pending
+// work orders of a product
+func (c *Client) ListOpenWorkOrders(ctx context.Context, productID uuid.UUID) ([]WorkOrder, error) {
+	var workOrders []WorkOrder
+	for _, status := range openStatuses {
+		query := url.Values{}
+		query.Set("product_id", productID.String())
+		query.Set("status", status)
+
+		err := c.getAll(ctx, "/api/v1/work-orders", query, func(data json.RawMessage) (int, error) {
+			var page []WorkOrder
+			if err := json.Unmarshal(data, &page); err != nil {
+				return 0, err
+			}
+			workOrders = append(workOrders, page...)
+			return len(page), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return workOrders, nil
+}
+
+// getAll pages through a list endpoint, handing each page's data to collect
+func (c *Client) getAll(ctx context.Context, path string, query url.Values, collect func(json.RawMessage) (int, error)) error {
+	query.Set("page_size", strconv.Itoa(pageSize))
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("manufacturing-service request failed: %w", err)
+		}
+
+		var result struct {
+			Success bool            `json:"success"`
+			Data    json.RawMessage `json:"data"`
+			Meta    struct {
+				TotalPages int `json:"total_pages"`
+			} `json:"meta"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode manufacturing-service response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK || !result.Success {
+			return fmt.Errorf("manufacturing-service returned status %d", resp.StatusCode)
+		}
+
+		n, err := collect(result.Data)
+		if err != nil {
+			return fmt.Errorf("failed to decode manufacturing-service data: %w", err)
+		}
+		if n < pageSize || page >= result.Meta.TotalPages {
+			return nil
+		}
+	}
+}
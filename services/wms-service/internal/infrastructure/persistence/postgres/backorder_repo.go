@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type backorderRepository struct {
+	db *gorm.DB
+}
+
+// NewBackorderRepository creates a new backorder repository
+func NewBackorderRepository(db *gorm.DB) repository.BackorderRepository {
+	return &backorderRepository{db: db}
+}
+
+func (r *backorderRepository) Create(ctx context.Context, backorder *entity.Backorder) error {
+	return r.db.WithContext(ctx).Create(backorder).Error
+}
+
+func (r *backorderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Backorder, error) {
+	var backorder entity.Backorder
+	if err := r.db.WithContext(ctx).First(&backorder, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &backorder, nil
+}
+
+func (r *backorderRepository) Update(ctx context.Context, backorder *entity.Backorder) error {
+	backorder.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(backorder).Error
+}
+
+func (r *backorderRepository) List(ctx context.Context, filter *repository.BackorderFilter) ([]*entity.Backorder, int64, error) {
+	var backorders []*entity.Backorder
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Backorder{})
+
+	if filter.MaterialID != nil {
+		query = query.Where("material_id = ?", *filter.MaterialID)
+	}
+	if filter.ReferenceID != nil {
+		query = query.Where("reference_id = ?", *filter.ReferenceID)
+	}
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	} else {
+		query = query.Limit(20)
+	}
+	if filter.Page > 0 {
+		query = query.Offset((filter.Page - 1) * filter.Limit)
+	}
+
+	if err := query.
+		Order("customer_priority ASC, order_date ASC, promised_date ASC NULLS LAST, created_at ASC").
+		Find(&backorders).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return backorders, total, nil
+}
+
+func (r *backorderRepository) GetOpenByMaterial(ctx context.Context, materialID uuid.UUID) ([]*entity.Backorder, error) {
+	var backorders []*entity.Backorder
+	err := r.db.WithContext(ctx).
+		Where("material_id = ? AND status = ?", materialID, entity.BackorderStatusOpen).
+		Order("customer_priority ASC, order_date ASC, promised_date ASC NULLS LAST, created_at ASC").
+		Find(&backorders).Error
+	return backorders, err
+}
+
+func (r *backorderRepository) GetOpenByReference(ctx context.Context, referenceID uuid.UUID) ([]*entity.Backorder, error) {
+	var backorders []*entity.Backorder
+	err := r.db.WithContext(ctx).
+		Where("reference_id = ? AND status = ?", referenceID, entity.BackorderStatusOpen).
+		Order("created_at ASC").
+		Find(&backorders).Error
+	return backorders, err
+}
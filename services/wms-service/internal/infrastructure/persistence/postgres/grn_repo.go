@@ -197,6 +197,9 @@ func (r *goodsIssueRepository) List(ctx context.Context, filter *repository.Good
 	if filter.ReferenceType != "" {
 		query = query.Where("reference_type = ?", filter.ReferenceType)
 	}
+	if filter.ReferenceID != nil {
+		query = query.Where("reference_id = ?", *filter.ReferenceID).Preload("LineItems")
+	}
 	if filter.Status != "" {
 		query = query.Where("status = ?", filter.Status)
 	}
@@ -204,6 +207,11 @@ func (r *goodsIssueRepository) List(ctx context.Context, filter *repository.Good
 		search := "%" + filter.Search + "%"
 		query = query.Where("issue_number ILIKE ? OR reference_number ILIKE ?", search, search)
 	}
+	if filter.LotID != nil {
+		lines := r.db.Model(&entity.GILineItem{}).Select("goods_issue_id").Where("lot_id = ?", *filter.LotID)
+		query = query.Where("id IN (?)", lines).
+			Preload("LineItems", "lot_id = ?", *filter.LotID)
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
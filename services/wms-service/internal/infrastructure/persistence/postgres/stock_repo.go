@@ -429,6 +429,17 @@ func (r *stockRepository) ReleaseReservation(ctx context.Context, reservationID
 	return tx.Commit().Error
 }
 
+// GetActiveReservationsByReference returns the active reservations of a
+// reference document, latest first
+func (r *stockRepository) GetActiveReservationsByReference(ctx context.Context, referenceID uuid.UUID) ([]*entity.StockReservation, error) {
+	var reservations []*entity.StockReservation
+	err := r.db.WithContext(ctx).
+		Where("reference_id = ? AND status = ?", referenceID, entity.ReservationStatusActive).
+		Order("created_at DESC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
 // GetMaterialSummary returns aggregated stock for a material
 func (r *stockRepository) GetMaterialSummary(ctx context.Context, materialID uuid.UUID) (*entity.StockSummary, error) {
 	var result struct {
@@ -0,0 +1,67 @@
+package procurement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Client reads open purchase orders from procurement-service
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a new procurement-service client
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// IncomingSupply is the quantity of a material still to be received on a PO
+type IncomingSupply struct {
+	POID         uuid.UUID `json:"po_id"`
+	PONumber     string    `json:"po_number"`
+	Status       string    `json:"status"`
+	MaterialID   uuid.UUID `json:"material_id"`
+	PendingQty   float64   `json:"pending_qty"`
+	ExpectedDate string    `json:"expected_date"` // YYYY-MM-DD, empty if not scheduled
+}
+
+// ListIncomingSupply returns the pending lines of open POs for a material
+func (c *Client) ListIncomingSupply(ctx context.Context, materialID uuid.UUID) ([]IncomingSupply, error) {
+	query := url.Values{}
+	query.Set("material_id", materialID.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/v1/purchase-orders/incoming?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("procurement-service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool             `json:"success"`
+		Data    []IncomingSupply `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode procurement-service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return nil, fmt.Errorf("procurement-service returned status %d for material %s", resp.StatusCode, materialID)
+	}
+	return result.Data, nil
+}
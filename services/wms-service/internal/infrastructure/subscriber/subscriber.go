@@ -3,10 +3,15 @@ package subscriber
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/backorder"
 	"github.com/erp-cosmetics/wms-service/internal/usecase/grn"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/lot"
 	"github.com/erp-cosmetics/wms-service/internal/usecase/reservation"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/stock"
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
 	"go.uber.org/zap"
@@ -19,6 +24,17 @@ type EventSubscriber struct {
 	createGRNUC      *grn.CreateGRNUseCase
 	reserveStockUC   *reservation.CreateReservationUseCase
 	releaseReservationUC *reservation.ReleaseReservationUseCase
+	issueStockFEFOUC *stock.IssueStockFEFOUseCase
+	returnToStockUC  *stock.ReturnToStockUseCase
+	receiveSalesReturnUC *stock.ReceiveSalesReturnUseCase
+	settleSalesReturnUC  *stock.SettleSalesReturnUseCase
+	blockLotsUC      *lot.BlockLotsUseCase
+	updateLotCostUC  *lot.UpdateLotCostUseCase
+	reserveSalesOrderUC  *backorder.ReserveSalesOrderUseCase
+	allocateBackordersUC *backorder.AllocateBackordersUseCase
+	cancelBackordersUC   *backorder.CancelBackordersUseCase
+	amendSalesOrderUC    *backorder.AmendSalesOrderUseCase
+	receiveProductionUC  *stock.ReceiveProductionUseCase
 	subscriptions    []*nats.Subscription
 }
 
@@ -29,6 +45,17 @@ func NewEventSubscriber(
 	createGRNUC *grn.CreateGRNUseCase,
 	reserveStockUC *reservation.CreateReservationUseCase,
 	releaseReservationUC *reservation.ReleaseReservationUseCase,
+	issueStockFEFOUC *stock.IssueStockFEFOUseCase,
+	returnToStockUC *stock.ReturnToStockUseCase,
+	receiveSalesReturnUC *stock.ReceiveSalesReturnUseCase,
+	settleSalesReturnUC *stock.SettleSalesReturnUseCase,
+	blockLotsUC *lot.BlockLotsUseCase,
+	updateLotCostUC *lot.UpdateLotCostUseCase,
+	reserveSalesOrderUC *backorder.ReserveSalesOrderUseCase,
+	allocateBackordersUC *backorder.AllocateBackordersUseCase,
+	cancelBackordersUC *backorder.CancelBackordersUseCase,
+	amendSalesOrderUC *backorder.AmendSalesOrderUseCase,
+	receiveProductionUC *stock.ReceiveProductionUseCase,
 ) *EventSubscriber {
 	return &EventSubscriber{
 		nc:                   nc,
@@ -36,6 +63,17 @@ func NewEventSubscriber(
 		createGRNUC:          createGRNUC,
 		reserveStockUC:       reserveStockUC,
 		releaseReservationUC: releaseReservationUC,
+		issueStockFEFOUC:     issueStockFEFOUC,
+		returnToStockUC:      returnToStockUC,
+		receiveSalesReturnUC: receiveSalesReturnUC,
+		settleSalesReturnUC:  settleSalesReturnUC,
+		blockLotsUC:          blockLotsUC,
+		updateLotCostUC:      updateLotCostUC,
+		reserveSalesOrderUC:  reserveSalesOrderUC,
+		allocateBackordersUC: allocateBackordersUC,
+		cancelBackordersUC:   cancelBackordersUC,
+		amendSalesOrderUC:    amendSalesOrderUC,
+		receiveProductionUC:  receiveProductionUC,
 	}
 }
 
@@ -74,6 +112,70 @@ func (s *EventSubscriber) Start() error {
 	}
 	s.subscriptions = append(s.subscriptions, sub4)
 
+	sub5, err := s.nc.Subscribe("manufacturing.wo.backflushed", s.handleWorkOrderBackflushed)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub5)
+
+	sub6, err := s.nc.Subscribe("manufacturing.wo.material.returned", s.handleMaterialReturned)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub6)
+
+	sub7, err := s.nc.Subscribe("manufacturing.recall.initiated", s.handleRecallInitiated)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub7)
+
+	sub8, err := s.nc.Subscribe("manufacturing.wo.costed", s.handleWorkOrderCosted)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub8)
+
+	// Subscribe to sales returns
+	sub9, err := s.nc.Subscribe("sales.return.received", s.handleSalesReturnReceived)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub9)
+
+	sub10, err := s.nc.Subscribe("sales.return.completed", s.handleSalesReturnCompleted)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub10)
+
+	// Subscribe to own stock receipts - allocates stock to backorders
+	sub11, err := s.nc.Subscribe(event.SubjectStockReceived, s.handleStockReceived)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub11)
+
+	sub12, err := s.nc.Subscribe(event.SubjectGRNCompleted, s.handleGRNCompleted)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub12)
+
+	// Subscribe to sales order amendments - reserves or releases the changes
+	sub13, err := s.nc.Subscribe("sales.order.amended", s.handleSalesOrderAmended)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub13)
+
+	// Subscribe to work order completion - receives output lots, consumes reworked lots
+	sub14, err := s.nc.Subscribe("manufacturing.wo.completed", s.handleWorkOrderCompleted)
+	if err != nil {
+		return err
+	}
+	s.subscriptions = append(s.subscriptions, sub14)
+
 	s.logger.Info("Event subscriber started",
 		zap.Int("subscriptions", len(s.subscriptions)),
 	)
@@ -127,22 +229,27 @@ func (s *EventSubscriber) handlePOReceived(msg *nats.Msg) {
 	// Implementation depends on business requirements
 }
 
-// SalesOrderEvent represents a sales order event
+// SalesOrderEvent represents a sales order confirmed event
 type SalesOrderEvent struct {
-	OrderID     uuid.UUID          `json:"order_id"`
-	OrderNumber string             `json:"order_number"`
-	CustomerID  uuid.UUID          `json:"customer_id"`
-	LineItems   []OrderLineItemEvent `json:"line_items"`
+	SOID             string               `json:"so_id"`
+	SONumber         string               `json:"so_number"`
+	CustomerID       string               `json:"customer_id"`
+	CustomerPriority int                  `json:"customer_priority"` // Customer tier, lower is served first
+	SODate           string               `json:"so_date"`
+	DeliveryDate     string               `json:"delivery_date"`
+	Items            []OrderLineItemEvent `json:"items"`
 }
 
 // OrderLineItemEvent represents an order line item
 type OrderLineItemEvent struct {
-	MaterialID uuid.UUID `json:"material_id"`
-	Quantity   float64   `json:"quantity"`
-	UnitID     uuid.UUID `json:"unit_id"`
+	LineID    string  `json:"line_id"`
+	ProductID string  `json:"product_id"` // Finished goods are stocked as materials
+	Quantity  float64 `json:"quantity"`
+	UOMID     string  `json:"uom_id"`
 }
 
-// handleSalesOrderConfirmed handles sales order confirmed events - reserves stock
+// handleSalesOrderConfirmed handles sales order confirmed events - reserves
+// available stock and backorders the rest
 func (s *EventSubscriber) handleSalesOrderConfirmed(msg *nats.Msg) {
 	var event SalesOrderEvent
 	if err := json.Unmarshal(msg.Data, &event); err != nil {
@@ -151,47 +258,75 @@ func (s *EventSubscriber) handleSalesOrderConfirmed(msg *nats.Msg) {
 	}
 
 	s.logger.Info("Received sales order confirmed event",
-		zap.String("order_number", event.OrderNumber),
+		zap.String("so_number", event.SONumber),
 	)
 
-	ctx := context.Background()
+	soID, err1 := uuid.Parse(event.SOID)
+	customerID, err2 := uuid.Parse(event.CustomerID)
+	if err1 != nil || err2 != nil {
+		s.logger.Error("Invalid IDs in sales order event", zap.String("so_number", event.SONumber))
+		return
+	}
 
-	// Reserve stock for each line item
-	for _, item := range event.LineItems {
-		input := &reservation.CreateReservationInput{
-			MaterialID:      item.MaterialID,
-			Quantity:        item.Quantity,
-			UnitID:          item.UnitID,
-			ReservationType: entity.ReservationTypeSalesOrder,
-			ReferenceID:     event.OrderID,
-			ReferenceNumber: event.OrderNumber,
-			CreatedBy:       uuid.Nil, // System
-		}
+	input := &backorder.ReserveSalesOrderInput{
+		SalesOrderID:     soID,
+		SONumber:         event.SONumber,
+		CustomerID:       customerID,
+		CustomerPriority: event.CustomerPriority,
+		OrderDate:        time.Now(),
+		CreatedBy:        uuid.Nil, // System
+	}
+	if soDate, err := time.Parse("2006-01-02", event.SODate); err == nil {
+		input.OrderDate = soDate
+	}
+	if deliveryDate, err := time.Parse("2006-01-02", event.DeliveryDate); err == nil {
+		input.PromisedDate = &deliveryDate
+	}
 
-		_, err := s.reserveStockUC.Execute(ctx, input)
+	for _, item := range event.Items {
+		materialID, err := uuid.Parse(item.ProductID)
 		if err != nil {
-			s.logger.Error("Failed to reserve stock for sales order",
-				zap.String("order_number", event.OrderNumber),
-				zap.String("material_id", item.MaterialID.String()),
-				zap.Error(err),
+			s.logger.Error("Invalid product in sales order event",
+				zap.String("so_number", event.SONumber),
+				zap.String("product_id", item.ProductID),
 			)
-			// Publish reservation failed event
 			continue
 		}
+		line := backorder.ReserveSalesOrderLine{
+			MaterialID: materialID,
+			Quantity:   item.Quantity,
+		}
+		if lineID, err := uuid.Parse(item.LineID); err == nil {
+			line.LineID = &lineID
+		}
+		if unitID, err := uuid.Parse(item.UOMID); err == nil {
+			line.UnitID = unitID
+		}
+		input.Lines = append(input.Lines, line)
+	}
+
+	result, err := s.reserveSalesOrderUC.Execute(context.Background(), input)
+	if err != nil {
+		s.logger.Error("Failed to reserve stock for sales order",
+			zap.String("so_number", event.SONumber),
+			zap.Error(err),
+		)
+		return
 	}
 
 	s.logger.Info("Stock reserved for sales order",
-		zap.String("order_number", event.OrderNumber),
-		zap.Int("items", len(event.LineItems)),
+		zap.String("so_number", event.SONumber),
+		zap.Int("reservations", len(result.Reservations)),
+		zap.Int("backorders", len(result.Backorders)),
 	)
 }
 
 // handleSalesOrderCancelled handles sales order cancelled - releases reservations
+// and cancels open backorders
 func (s *EventSubscriber) handleSalesOrderCancelled(msg *nats.Msg) {
 	var event struct {
-		OrderID       uuid.UUID   `json:"order_id"`
-		OrderNumber   string      `json:"order_number"`
-		ReservationIDs []uuid.UUID `json:"reservation_ids"`
+		SOID     string `json:"so_id"`
+		SONumber string `json:"so_number"`
 	}
 
 	if err := json.Unmarshal(msg.Data, &event); err != nil {
@@ -200,27 +335,269 @@ func (s *EventSubscriber) handleSalesOrderCancelled(msg *nats.Msg) {
 	}
 
 	s.logger.Info("Received sales order cancelled event",
-		zap.String("order_number", event.OrderNumber),
+		zap.String("so_number", event.SONumber),
 	)
 
+	soID, err := uuid.Parse(event.SOID)
+	if err != nil {
+		s.logger.Error("Invalid sales order ID in order cancelled event", zap.Error(err))
+		return
+	}
+
 	ctx := context.Background()
 
 	// Release reservations
-	for _, reservationID := range event.ReservationIDs {
-		err := s.releaseReservationUC.Execute(ctx, reservationID)
+	released, err := s.releaseReservationUC.ReleaseForReference(ctx, soID)
+	if err != nil {
+		s.logger.Error("Failed to release reservations",
+			zap.String("so_number", event.SONumber),
+			zap.Error(err),
+		)
+	}
+
+	// Cancel backorders
+	cancelled, err := s.cancelBackordersUC.Execute(ctx, soID)
+	if err != nil {
+		s.logger.Error("Failed to cancel backorders",
+			zap.String("so_number", event.SONumber),
+			zap.Error(err),
+		)
+	} else if cancelled > 0 {
+		s.logger.Info("Backorders cancelled for cancelled order",
+			zap.String("so_number", event.SONumber),
+			zap.Int("backorders", cancelled),
+		)
+	}
+
+	s.logger.Info("Reservations released for cancelled order",
+		zap.String("so_number", event.SONumber),
+		zap.Int("reservations", released),
+	)
+}
+
+// handleSalesOrderAmended handles sales order amended events - reserves
+// increased quantities and releases reduced ones
+func (s *EventSubscriber) handleSalesOrderAmended(msg *nats.Msg) {
+	var event struct {
+		SOID             string `json:"so_id"`
+		SONumber         string `json:"so_number"`
+		CustomerID       string `json:"customer_id"`
+		CustomerPriority int    `json:"customer_priority"`
+		SODate           string `json:"so_date"`
+		Version          int    `json:"version"`
+		DeliveryDate     string `json:"delivery_date"`
+		Lines            []struct {
+			LineID        string  `json:"line_id"`
+			ProductID     string  `json:"product_id"`
+			UomID         string  `json:"uom_id"`
+			DeltaQuantity float64 `json:"delta_quantity"`
+		} `json:"lines"`
+	}
+
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal sales order amended event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received sales order amended event",
+		zap.String("so_number", event.SONumber),
+		zap.Int("version", event.Version),
+	)
+
+	soID, err1 := uuid.Parse(event.SOID)
+	customerID, err2 := uuid.Parse(event.CustomerID)
+	if err1 != nil || err2 != nil {
+		s.logger.Error("Invalid IDs in sales order event", zap.String("so_number", event.SONumber))
+		return
+	}
+
+	input := &backorder.AmendSalesOrderInput{
+		SalesOrderID:     soID,
+		SONumber:         event.SONumber,
+		CustomerID:       customerID,
+		CustomerPriority: event.CustomerPriority,
+		OrderDate:        time.Now(),
+		UpdatedBy:        uuid.Nil, // System
+	}
+	if soDate, err := time.Parse("2006-01-02", event.SODate); err == nil {
+		input.OrderDate = soDate
+	}
+	if deliveryDate, err := time.Parse("2006-01-02", event.DeliveryDate); err == nil {
+		input.PromisedDate = &deliveryDate
+	}
+
+	for _, item := range event.Lines {
+		materialID, err := uuid.Parse(item.ProductID)
 		if err != nil {
-			s.logger.Error("Failed to release reservation",
-				zap.String("reservation_id", reservationID.String()),
-				zap.Error(err),
+			s.logger.Error("Invalid product in sales order event",
+				zap.String("so_number", event.SONumber),
+				zap.String("product_id", item.ProductID),
 			)
+			continue
+		}
+		line := backorder.AmendSalesOrderLine{
+			MaterialID: materialID,
+			DeltaQty:   item.DeltaQuantity,
+		}
+		if lineID, err := uuid.Parse(item.LineID); err == nil {
+			line.LineID = &lineID
+		}
+		if unitID, err := uuid.Parse(item.UomID); err == nil {
+			line.UnitID = unitID
 		}
+		input.Lines = append(input.Lines, line)
 	}
 
-	s.logger.Info("Reservations released for cancelled order",
-		zap.String("order_number", event.OrderNumber),
+	result, err := s.amendSalesOrderUC.Execute(context.Background(), input)
+	if err != nil {
+		s.logger.Error("Failed to apply sales order amendment",
+			zap.String("so_number", event.SONumber),
+			zap.Int("version", event.Version),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Reservations amended for sales order",
+		zap.String("so_number", event.SONumber),
+		zap.Int("version", event.Version),
+		zap.Int("reservations", len(result.Reservations)),
+		zap.Int("released", len(result.Released)),
+		zap.Int("backorders", len(result.Backorders)),
+		zap.Int("backorders_reduced", len(result.Reduced)),
 	)
 }
 
+// handleStockReceived handles stock received - allocates the material to open backorders
+func (s *EventSubscriber) handleStockReceived(msg *nats.Msg) {
+	var event event.StockReceivedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal stock received event", zap.Error(err))
+		return
+	}
+
+	materialID, err := uuid.Parse(event.MaterialID)
+	if err != nil {
+		s.logger.Error("Invalid material ID in stock received event", zap.Error(err))
+		return
+	}
+
+	s.allocateBackorders(materialID)
+}
+
+// handleGRNCompleted handles GRN completed - allocates the received materials to open backorders
+func (s *EventSubscriber) handleGRNCompleted(msg *nats.Msg) {
+	var event event.GRNCompletedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal GRN completed event", zap.Error(err))
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, item := range event.Items {
+		materialID, err := uuid.Parse(item.MaterialID)
+		if err != nil || seen[materialID] {
+			continue
+		}
+		seen[materialID] = true
+		s.allocateBackorders(materialID)
+	}
+}
+
+// allocateBackorders allocates the available stock of a material to its open backorders
+func (s *EventSubscriber) allocateBackorders(materialID uuid.UUID) {
+	allocated, err := s.allocateBackordersUC.Execute(context.Background(), materialID)
+	if err != nil {
+		s.logger.Error("Failed to allocate stock to backorders",
+			zap.String("material_id", materialID.String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	if len(allocated) > 0 {
+		s.logger.Info("Stock allocated to backorders",
+			zap.String("material_id", materialID.String()),
+			zap.Int("backorders", len(allocated)),
+		)
+	}
+}
+
+// SalesReturnEvent represents a customer return received or completed in sales
+type SalesReturnEvent struct {
+	ReturnID     string `json:"return_id"`
+	ReturnNumber string `json:"return_number"`
+	SOID         string `json:"so_id"`
+	Items        []struct {
+		ProductID   string  `json:"product_id"`
+		LotNumber   string  `json:"lot_number"`
+		Quantity    float64 `json:"quantity"`
+		Disposition string  `json:"disposition"` // RESTOCK or DISPOSE, completed returns only
+	} `json:"items"`
+}
+
+// handleSalesReturnReceived handles sales return received - puts the goods into the returns location
+func (s *EventSubscriber) handleSalesReturnReceived(msg *nats.Msg) {
+	s.handleSalesReturn(msg, "received", s.receiveSalesReturnUC.Execute)
+}
+
+// handleSalesReturnCompleted handles sales return completed - restocks or disposes of the inspected goods
+func (s *EventSubscriber) handleSalesReturnCompleted(msg *nats.Msg) {
+	s.handleSalesReturn(msg, "completed", s.settleSalesReturnUC.Execute)
+}
+
+// handleSalesReturn runs a sales return event line by line through apply
+func (s *EventSubscriber) handleSalesReturn(msg *nats.Msg, action string, apply func(context.Context, *stock.SalesReturnLineInput) (string, error)) {
+	var event SalesReturnEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal sales return event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received sales return "+action+" event",
+		zap.String("return_number", event.ReturnNumber),
+	)
+
+	returnID, err1 := uuid.Parse(event.ReturnID)
+	soID, err2 := uuid.Parse(event.SOID)
+	if err1 != nil || err2 != nil {
+		s.logger.Error("Invalid IDs in sales return event", zap.String("return_number", event.ReturnNumber))
+		return
+	}
+
+	ctx := context.Background()
+
+	for _, item := range event.Items {
+		materialID, err := uuid.Parse(item.ProductID)
+		if err != nil {
+			s.logger.Error("Invalid product in sales return event",
+				zap.String("return_number", event.ReturnNumber),
+				zap.String("product_id", item.ProductID),
+			)
+			continue
+		}
+
+		_, err = apply(ctx, &stock.SalesReturnLineInput{
+			ReturnID:     returnID,
+			ReturnNumber: event.ReturnNumber,
+			SalesOrderID: soID,
+			MaterialID:   materialID,
+			LotNumber:    item.LotNumber,
+			Quantity:     item.Quantity,
+			Disposition:  stock.ReturnDisposition(item.Disposition),
+			CreatedBy:    uuid.Nil, // System
+		})
+		if err != nil {
+			s.logger.Error("Failed to process sales return line",
+				zap.String("return_number", event.ReturnNumber),
+				zap.String("product_id", item.ProductID),
+				zap.String("action", action),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
 // WorkOrderEvent represents a work order event from manufacturing
 type WorkOrderEvent struct {
 	WorkOrderID     uuid.UUID              `json:"work_order_id"`
@@ -279,7 +656,328 @@ func (s *EventSubscriber) handleWorkOrderStarted(msg *nats.Msg) {
 	)
 }
 
+// WorkOrderBackflushEvent represents backflushed consumption of a completed work order
+type WorkOrderBackflushEvent struct {
+	WOID      string `json:"wo_id"`
+	WONumber  string `json:"wo_number"`
+	Materials []struct {
+		MaterialID string  `json:"material_id"`
+		Quantity   float64 `json:"quantity"`
+		UOMID      string  `json:"uom_id"`
+	} `json:"materials"`
+}
+
+// handleWorkOrderBackflushed handles work order backflush - issues consumed materials FEFO
+func (s *EventSubscriber) handleWorkOrderBackflushed(msg *nats.Msg) {
+	var event WorkOrderBackflushEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal work order backflush event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received work order backflush event",
+		zap.String("wo_number", event.WONumber),
+	)
+
+	woID, err := uuid.Parse(event.WOID)
+	if err != nil {
+		s.logger.Error("Invalid work order ID in backflush event", zap.Error(err))
+		return
+	}
+
+	ctx := context.Background()
+
+	for _, mat := range event.Materials {
+		materialID, err1 := uuid.Parse(mat.MaterialID)
+		unitID, err2 := uuid.Parse(mat.UOMID)
+		if err1 != nil || err2 != nil {
+			s.logger.Error("Invalid material in backflush event",
+				zap.String("wo_number", event.WONumber),
+				zap.String("material_id", mat.MaterialID),
+			)
+			continue
+		}
+
+		_, err := s.issueStockFEFOUC.Execute(ctx, &stock.IssueStockInput{
+			MaterialID:      materialID,
+			Quantity:        mat.Quantity,
+			UnitID:          unitID,
+			ReferenceType:   entity.ReferenceTypeWO,
+			ReferenceID:     &woID,
+			ReferenceNumber: event.WONumber,
+			CreatedBy:       uuid.Nil, // System
+		})
+		if err != nil {
+			s.logger.Error("Failed to backflush material for work order",
+				zap.String("wo_number", event.WONumber),
+				zap.String("material_id", mat.MaterialID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// WorkOrderCompletedEvent represents a completed work order and the lots it produced
+type WorkOrderCompletedEvent struct {
+	WOID           string  `json:"wo_id"`
+	WONumber       string  `json:"wo_number"`
+	ProductID      string  `json:"product_id"`
+	BatchNumber    string  `json:"batch_number"`
+	GoodQuantity   float64 `json:"good_quantity"`
+	UOMID          string  `json:"uom_id"`
+	OutputLotID    string  `json:"output_lot_id"`
+	SourceLotID    string  `json:"source_lot_id"`
+	SourceQuantity float64 `json:"source_quantity"`
+	Outputs        []struct {
+		OutputType string  `json:"output_type"`
+		ProductID  string  `json:"product_id"`
+		LotID      string  `json:"lot_id"`
+		LotNumber  string  `json:"lot_number"`
+		Quantity   float64 `json:"quantity"`
+		UOMID      string  `json:"uom_id"`
+	} `json:"outputs"`
+}
+
+// handleWorkOrderCompleted handles work order completion - receives the finished,
+// co-product and by-product lots into quarantine and consumes the reworked lot
+func (s *EventSubscriber) handleWorkOrderCompleted(msg *nats.Msg) {
+	var event WorkOrderCompletedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal work order completed event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received work order completed event",
+		zap.String("wo_number", event.WONumber),
+	)
+
+	woID, err := uuid.Parse(event.WOID)
+	if err != nil {
+		s.logger.Error("Invalid work order ID in completed event", zap.Error(err))
+		return
+	}
+
+	input := &stock.ReceiveProductionInput{
+		WOID:           woID,
+		WONumber:       event.WONumber,
+		SourceQuantity: event.SourceQuantity,
+		CreatedBy:      uuid.Nil, // System
+	}
+	if event.SourceLotID != "" {
+		sourceLotID, err := uuid.Parse(event.SourceLotID)
+		if err != nil {
+			s.logger.Error("Invalid source lot in work order completed event",
+				zap.String("wo_number", event.WONumber),
+				zap.String("source_lot_id", event.SourceLotID),
+			)
+			return
+		}
+		input.SourceLotID = &sourceLotID
+	}
+
+	addLot := func(lotID, lotNumber, productID, uomID string, qty float64) {
+		lot, err1 := uuid.Parse(lotID)
+		material, err2 := uuid.Parse(productID)
+		unit, err3 := uuid.Parse(uomID)
+		if err1 != nil || err2 != nil || err3 != nil {
+			s.logger.Error("Invalid output lot in work order completed event",
+				zap.String("wo_number", event.WONumber),
+				zap.String("lot_number", lotNumber),
+			)
+			return
+		}
+		input.Lots = append(input.Lots, stock.ProductionLotInput{
+			LotID:      lot,
+			LotNumber:  lotNumber,
+			MaterialID: material,
+			Quantity:   qty,
+			UnitID:     unit,
+		})
+	}
+	if event.GoodQuantity > 0 {
+		addLot(event.OutputLotID, event.BatchNumber, event.ProductID, event.UOMID, event.GoodQuantity)
+	}
+	for _, out := range event.Outputs {
+		if out.Quantity > 0 {
+			addLot(out.LotID, out.LotNumber, out.ProductID, out.UOMID, out.Quantity)
+		}
+	}
+
+	if err := s.receiveProductionUC.Execute(context.Background(), input); err != nil {
+		s.logger.Error("Failed to receive work order output",
+			zap.String("wo_number", event.WONumber),
+			zap.Error(err),
+		)
+	}
+}
+
+// MaterialReturnedEvent represents surplus lots returned from a work order
+type MaterialReturnedEvent struct {
+	WOID     string `json:"wo_id"`
+	WONumber string `json:"wo_number"`
+	Items    []struct {
+		IssueNumber string  `json:"issue_number"`
+		MaterialID  string  `json:"material_id"`
+		LotID       string  `json:"lot_id"`
+		LotNumber   string  `json:"lot_number"`
+		Quantity    float64 `json:"quantity"`
+		UOMID       string  `json:"uom_id"`
+	} `json:"items"`
+}
+
+// handleMaterialReturned handles material returned from production - puts lots back to stock
+func (s *EventSubscriber) handleMaterialReturned(msg *nats.Msg) {
+	var event MaterialReturnedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal material returned event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received material returned event",
+		zap.String("wo_number", event.WONumber),
+	)
+
+	woID, err := uuid.Parse(event.WOID)
+	if err != nil {
+		s.logger.Error("Invalid work order ID in material returned event", zap.Error(err))
+		return
+	}
+
+	ctx := context.Background()
+
+	for _, item := range event.Items {
+		materialID, err1 := uuid.Parse(item.MaterialID)
+		lotID, err2 := uuid.Parse(item.LotID)
+		unitID, err3 := uuid.Parse(item.UOMID)
+		if err1 != nil || err2 != nil || err3 != nil {
+			s.logger.Error("Invalid item in material returned event",
+				zap.String("wo_number", event.WONumber),
+				zap.String("issue_number", item.IssueNumber),
+			)
+			continue
+		}
+
+		_, err := s.returnToStockUC.Execute(ctx, &stock.ReturnToStockInput{
+			MaterialID:    materialID,
+			LotID:         lotID,
+			Quantity:      item.Quantity,
+			UnitID:        unitID,
+			ReferenceType: entity.ReferenceTypeWO,
+			ReferenceID:   &woID,
+			CreatedBy:     uuid.Nil, // System
+		})
+		if err != nil {
+			s.logger.Error("Failed to return material to stock",
+				zap.String("wo_number", event.WONumber),
+				zap.String("lot_number", item.LotNumber),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// RecallInitiatedEvent represents a product recall started in manufacturing
+type RecallInitiatedEvent struct {
+	RecallID     string   `json:"recall_id"`
+	RecallNumber string   `json:"recall_number"`
+	Reason       string   `json:"reason"`
+	LotIDs       []string `json:"lot_ids"`
+}
+
+// handleRecallInitiated handles recall initiated events - blocks the recalled lots
+func (s *EventSubscriber) handleRecallInitiated(msg *nats.Msg) {
+	var event RecallInitiatedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal recall initiated event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received recall initiated event",
+		zap.String("recall_number", event.RecallNumber),
+		zap.Int("lots", len(event.LotIDs)),
+	)
+
+	var lotIDs []uuid.UUID
+	for _, id := range event.LotIDs {
+		lotID, err := uuid.Parse(id)
+		if err != nil {
+			s.logger.Error("Invalid lot ID in recall initiated event",
+				zap.String("recall_number", event.RecallNumber),
+				zap.String("lot_id", id),
+			)
+			continue
+		}
+		lotIDs = append(lotIDs, lotID)
+	}
+
+	reason := "Recall " + event.RecallNumber
+	if event.Reason != "" {
+		reason += ": " + event.Reason
+	}
+	blocked, err := s.blockLotsUC.Execute(context.Background(), lotIDs, reason)
+	if err != nil {
+		s.logger.Error("Failed to block recalled lots",
+			zap.String("recall_number", event.RecallNumber),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.logger.Info("Recalled lots blocked",
+		zap.String("recall_number", event.RecallNumber),
+		zap.Int("blocked", blocked),
+	)
+}
+
 // ReservationRepository interface for querying reservations
 type ReservationRepository interface {
 	GetByReferenceID(ctx context.Context, referenceID uuid.UUID) ([]*entity.StockReservation, error)
 }
+
+// WOCostedEvent represents the actual cost of a completed work order
+type WOCostedEvent struct {
+	WOID           string  `json:"wo_id"`
+	WONumber       string  `json:"wo_number"`
+	ProductID      string  `json:"product_id"`
+	BatchNumber    string  `json:"batch_number"`
+	OutputLotID    string  `json:"output_lot_id,omitempty"`
+	OutputQuantity float64 `json:"output_quantity"`
+	UnitCost       float64 `json:"unit_cost"`
+	TotalCost      float64 `json:"total_cost"`
+}
+
+// handleWorkOrderCosted handles work order costed events - values the finished goods lot at actual cost
+func (s *EventSubscriber) handleWorkOrderCosted(msg *nats.Msg) {
+	var event WOCostedEvent
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		s.logger.Error("Failed to unmarshal work order costed event", zap.Error(err))
+		return
+	}
+
+	s.logger.Info("Received work order costed event",
+		zap.String("wo_number", event.WONumber),
+		zap.String("batch_number", event.BatchNumber),
+		zap.Float64("unit_cost", event.UnitCost),
+	)
+
+	input := &lot.UpdateLotCostInput{
+		LotNumber: event.BatchNumber, // Finished goods lots are received under the batch number
+		UnitCost:  event.UnitCost,
+	}
+	if event.OutputLotID != "" {
+		lotID, err := uuid.Parse(event.OutputLotID)
+		if err != nil {
+			s.logger.Error("Invalid output lot ID in work order costed event", zap.Error(err))
+			return
+		}
+		input.LotID = &lotID
+	}
+
+	if _, err := s.updateLotCostUC.Execute(context.Background(), input); err != nil {
+		s.logger.Error("Failed to update finished goods lot cost",
+			zap.String("wo_number", event.WONumber),
+			zap.String("batch_number", event.BatchNumber),
+			zap.Error(err),
+		)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
 	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
 	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/manufacturing"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/procurement"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
@@ -80,6 +82,13 @@ func (m *MockStockRepository) ReleaseReservation(ctx context.Context, reservatio
 	args := m.Called(ctx, reservationID)
 	return args.Error(0)
 }
+func (m *MockStockRepository) GetActiveReservationsByReference(ctx context.Context, referenceID uuid.UUID) ([]*entity.StockReservation, error) {
+	args := m.Called(ctx, referenceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.StockReservation), args.Error(1)
+}
 func (m *MockStockRepository) GetMaterialSummary(ctx context.Context, materialID uuid.UUID) (*entity.StockSummary, error) {
 	args := m.Called(ctx, materialID)
 	if args.Get(0) == nil {
@@ -222,7 +231,13 @@ func (m *MockLotRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.
 	}
 	return args.Get(0).(*entity.Lot), args.Error(1)
 }
-func (m *MockLotRepository) GetByLotNumber(ctx context.Context, num string) (*entity.Lot, error) { return nil, nil }
+func (m *MockLotRepository) GetByLotNumber(ctx context.Context, num string) (*entity.Lot, error) {
+	args := m.Called(ctx, num)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Lot), args.Error(1)
+}
 func (m *MockLotRepository) List(ctx context.Context, filter *repository.LotFilter) ([]*entity.Lot, int64, error) { return nil, 0, nil }
 func (m *MockLotRepository) GetAvailableLots(ctx context.Context, matID uuid.UUID) ([]*entity.Lot, error) { return nil, nil }
 func (m *MockLotRepository) GetExpiringLots(ctx context.Context, days int) ([]*entity.Lot, error) { return nil, nil }
@@ -261,3 +276,79 @@ func (m *MockEventPublisher) PublishStockReserved(e *event.StockReservedEvent) e
 func (m *MockEventPublisher) PublishLowStockAlert(e *event.LowStockAlertEvent) error { return nil }
 func (m *MockEventPublisher) PublishLotExpiringSoon(e *event.LotExpiringEvent) error { return nil }
 func (m *MockEventPublisher) PublishLotExpired(e *event.LotExpiringEvent) error { return nil }
+func (m *MockEventPublisher) PublishBackorderCreated(e *event.BackorderEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+func (m *MockEventPublisher) PublishBackorderAllocated(e *event.BackorderEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+func (m *MockEventPublisher) PublishBackorderRescheduled(e *event.BackorderEvent) error {
+	args := m.Called(e)
+	return args.Error(0)
+}
+
+// MockBackorderRepository
+type MockBackorderRepository struct {
+	mock.Mock
+}
+
+func (m *MockBackorderRepository) Create(ctx context.Context, backorder *entity.Backorder) error {
+	args := m.Called(ctx, backorder)
+	return args.Error(0)
+}
+func (m *MockBackorderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Backorder, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Backorder), args.Error(1)
+}
+func (m *MockBackorderRepository) Update(ctx context.Context, backorder *entity.Backorder) error {
+	args := m.Called(ctx, backorder)
+	return args.Error(0)
+}
+func (m *MockBackorderRepository) List(ctx context.Context, filter *repository.BackorderFilter) ([]*entity.Backorder, int64, error) {
+	return nil, 0, nil
+}
+func (m *MockBackorderRepository) GetOpenByMaterial(ctx context.Context, materialID uuid.UUID) ([]*entity.Backorder, error) {
+	args := m.Called(ctx, materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Backorder), args.Error(1)
+}
+func (m *MockBackorderRepository) GetOpenByReference(ctx context.Context, referenceID uuid.UUID) ([]*entity.Backorder, error) {
+	args := m.Called(ctx, referenceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Backorder), args.Error(1)
+}
+
+// MockPurchasingClient
+type MockPurchasingClient struct {
+	mock.Mock
+}
+
+func (m *MockPurchasingClient) ListIncomingSupply(ctx context.Context, materialID uuid.UUID) ([]procurement.IncomingSupply, error) {
+	args := m.Called(ctx, materialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]procurement.IncomingSupply), args.Error(1)
+}
+
+// MockProductionClient
+type MockProductionClient struct {
+	mock.Mock
+}
+
+func (m *MockProductionClient) ListOpenWorkOrders(ctx context.Context, productID uuid.UUID) ([]manufacturing.WorkOrder, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]manufacturing.WorkOrder), args.Error(1)
+}
@@ -0,0 +1,226 @@
+package atp
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/manufacturing"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/procurement"
+	"github.com/google/uuid"
+)
+
+// PurchasingClient lists what is still to be received on open purchase orders
+type PurchasingClient interface {
+	ListIncomingSupply(ctx context.Context, materialID uuid.UUID) ([]procurement.IncomingSupply, error)
+}
+
+// ProductionClient lists the open work orders of a product
+type ProductionClient interface {
+	ListOpenWorkOrders(ctx context.Context, productID uuid.UUID) ([]manufacturing.WorkOrder, error)
+}
+
+// SupplySource represents where a planned receipt comes from
+type SupplySource string
+
+const (
+	SupplySourcePurchaseOrder SupplySource = "PURCHASE_ORDER"
+	SupplySourceWorkOrder     SupplySource = "WORK_ORDER"
+)
+
+// SupplyBucket is a planned receipt and the quantity available to promise
+// once it is in
+type SupplyBucket struct {
+	Date            time.Time    `json:"date"`
+	Source          SupplySource `json:"source"`
+	ReferenceID     uuid.UUID    `json:"reference_id"`
+	ReferenceNumber string       `json:"reference_number"`
+	Quantity        float64      `json:"quantity"`
+	CumulativeATP   float64      `json:"cumulative_atp"`
+}
+
+// ATPResult represents the promise for a quantity of a material
+type ATPResult struct {
+	MaterialID       uuid.UUID      `json:"material_id"`
+	RequestedQty     float64        `json:"requested_qty"`
+	OnHandAvailable  float64        `json:"on_hand_available"` // Unreserved stock
+	BackorderedQty   float64        `json:"backordered_qty"`   // Owed to open backorders first
+	AvailableNow     float64        `json:"available_now"`
+	PromiseDate      time.Time      `json:"promise_date"`
+	CapableToPromise bool           `json:"capable_to_promise"` // Not covered by stock or planned supply, promised at the replenishment lead time
+	SupplyIncomplete bool           `json:"supply_incomplete"`  // A supply source could not be reached
+	Schedule         []SupplyBucket `json:"schedule"`
+}
+
+// ATPLine represents a quantity of a material to promise
+type ATPLine struct {
+	MaterialID uuid.UUID
+	Quantity   float64
+}
+
+// GetATPUseCase quotes promise dates from unreserved stock and planned supply
+type GetATPUseCase struct {
+	stockRepo     repository.StockRepository
+	backorderRepo repository.BackorderRepository
+	purchasing    PurchasingClient
+	production    ProductionClient
+	leadTimeDays  int
+}
+
+// NewGetATPUseCase creates a new use case. Quantities that stock and planned
+// supply cannot cover are promised leadTimeDays from today.
+func NewGetATPUseCase(
+	stockRepo repository.StockRepository,
+	backorderRepo repository.BackorderRepository,
+	purchasing PurchasingClient,
+	production ProductionClient,
+	leadTimeDays int,
+) *GetATPUseCase {
+	return &GetATPUseCase{
+		stockRepo:     stockRepo,
+		backorderRepo: backorderRepo,
+		purchasing:    purchasing,
+		production:    production,
+		leadTimeDays:  leadTimeDays,
+	}
+}
+
+// Execute returns the earliest date a quantity of a material can be promised
+func (uc *GetATPUseCase) Execute(ctx context.Context, materialID uuid.UUID, quantity float64) (*ATPResult, error) {
+	results, err := uc.ExecuteLines(ctx, []ATPLine{{MaterialID: materialID, Quantity: quantity}})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// ExecuteLines promises the lines of one quotation or order. Lines of the same
+// material are promised in order, each after the lines before it.
+func (uc *GetATPUseCase) ExecuteLines(ctx context.Context, lines []ATPLine) ([]*ATPResult, error) {
+	today := entity.BusinessToday()
+	timelines := make(map[uuid.UUID]*ATPResult)
+	promised := make(map[uuid.UUID]float64)
+
+	results := make([]*ATPResult, len(lines))
+	for i, line := range lines {
+		timeline, ok := timelines[line.MaterialID]
+		if !ok {
+			var err error
+			timeline, err = uc.timeline(ctx, line.MaterialID, today)
+			if err != nil {
+				return nil, err
+			}
+			timelines[line.MaterialID] = timeline
+		}
+
+		result := *timeline
+		result.RequestedQty = line.Quantity
+		promised[line.MaterialID] += line.Quantity
+		result.PromiseDate, result.CapableToPromise = uc.promise(timeline, promised[line.MaterialID], today)
+		results[i] = &result
+	}
+
+	return results, nil
+}
+
+// timeline builds the cumulative quantity available to promise of a material
+// from its unreserved stock and planned receipts, after open backorders
+func (uc *GetATPUseCase) timeline(ctx context.Context, materialID uuid.UUID, today time.Time) (*ATPResult, error) {
+	result := &ATPResult{MaterialID: materialID}
+
+	// Unreserved stock, on the same FEFO stock that reservations are taken from
+	stocks, err := uc.stockRepo.GetAvailableStockFEFO(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+	for _, stock := range stocks {
+		if qty := stock.GetAvailableQuantity(); qty > 0 {
+			result.OnHandAvailable += qty
+		}
+	}
+
+	backorders, err := uc.backorderRepo.GetOpenByMaterial(ctx, materialID)
+	if err != nil {
+		return nil, err
+	}
+	for _, backorder := range backorders {
+		result.BackorderedQty += backorder.BackorderedQty
+	}
+
+	// Planned receipts without a date cannot be promised against
+	if uc.purchasing != nil {
+		supply, err := uc.purchasing.ListIncomingSupply(ctx, materialID)
+		if err != nil {
+			result.SupplyIncomplete = true
+		}
+		for _, s := range supply {
+			date, err := entity.ParseBusinessDate(s.ExpectedDate)
+			if err != nil || s.PendingQty <= 0 {
+				continue
+			}
+			result.Schedule = append(result.Schedule, SupplyBucket{
+				Date:            date,
+				Source:          SupplySourcePurchaseOrder,
+				ReferenceID:     s.POID,
+				ReferenceNumber: s.PONumber,
+				Quantity:        s.PendingQty,
+			})
+		}
+	}
+	if uc.production != nil {
+		workOrders, err := uc.production.ListOpenWorkOrders(ctx, materialID)
+		if err != nil {
+			result.SupplyIncomplete = true
+		}
+		for _, wo := range workOrders {
+			qty := wo.ExpectedQuantity()
+			if wo.PlannedEndDate == nil || qty <= 0 {
+				continue
+			}
+			result.Schedule = append(result.Schedule, SupplyBucket{
+				Date:            entity.BusinessDate(*wo.PlannedEndDate),
+				Source:          SupplySourceWorkOrder,
+				ReferenceID:     wo.ID,
+				ReferenceNumber: wo.WONumber,
+				Quantity:        qty,
+			})
+		}
+	}
+
+	// Overdue receipts are expected today at the earliest
+	for i := range result.Schedule {
+		if result.Schedule[i].Date.Before(today) {
+			result.Schedule[i].Date = today
+		}
+	}
+	sort.SliceStable(result.Schedule, func(i, j int) bool {
+		return result.Schedule[i].Date.Before(result.Schedule[j].Date)
+	})
+
+	cumulative := result.OnHandAvailable - result.BackorderedQty
+	if cumulative > 0 {
+		result.AvailableNow = cumulative
+	}
+	for i := range result.Schedule {
+		cumulative += result.Schedule[i].Quantity
+		result.Schedule[i].CumulativeATP = cumulative
+	}
+
+	return result, nil
+}
+
+// promise returns the first date the cumulative quantity covers qty, or the
+// replenishment lead time if planned supply never does
+func (uc *GetATPUseCase) promise(timeline *ATPResult, qty float64, today time.Time) (time.Time, bool) {
+	if timeline.OnHandAvailable-timeline.BackorderedQty >= qty {
+		return today, false
+	}
+	for _, bucket := range timeline.Schedule {
+		if bucket.CumulativeATP >= qty {
+			return bucket.Date, false
+		}
+	}
+	return today.AddDate(0, 0, uc.leadTimeDays), true
+}
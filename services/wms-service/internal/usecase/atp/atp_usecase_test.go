@@ -0,0 +1,137 @@
+package atp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/manufacturing"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/procurement"
+	"github.com/erp-cosmetics/wms-service/internal/testmocks"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/atp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setup(ctx context.Context, materialID uuid.UUID) (*testmocks.MockStockRepository, *testmocks.MockBackorderRepository, *testmocks.MockPurchasingClient, *testmocks.MockProductionClient) {
+	stockRepo := new(testmocks.MockStockRepository)
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	purchasing := new(testmocks.MockPurchasingClient)
+	production := new(testmocks.MockProductionClient)
+
+	stockRepo.On("GetAvailableStockFEFO", ctx, materialID).Return([]*entity.Stock{
+		{MaterialID: materialID, Quantity: 50, ReservedQty: 20},
+		{MaterialID: materialID, Quantity: 10},
+	}, nil)
+	backorderRepo.On("GetOpenByMaterial", ctx, materialID).Return([]*entity.Backorder{
+		{MaterialID: materialID, BackorderedQty: 15},
+	}, nil)
+	return stockRepo, backorderRepo, purchasing, production
+}
+
+func TestGetATPUseCase_Execute_FromStock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	materialID := uuid.New()
+	stockRepo, backorderRepo, purchasing, production := setup(ctx, materialID)
+	purchasing.On("ListIncomingSupply", ctx, materialID).Return([]procurement.IncomingSupply{}, nil)
+	production.On("ListOpenWorkOrders", ctx, materialID).Return([]manufacturing.WorkOrder{}, nil)
+
+	uc := atp.NewGetATPUseCase(stockRepo, backorderRepo, purchasing, production, 14)
+
+	// Act
+	result, err := uc.Execute(ctx, materialID, 25)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, result.OnHandAvailable)
+	assert.Equal(t, 15.0, result.BackorderedQty)
+	assert.Equal(t, 25.0, result.AvailableNow)
+	assert.Equal(t, entity.BusinessToday(), result.PromiseDate)
+	assert.False(t, result.CapableToPromise)
+}
+
+func TestGetATPUseCase_Execute_FromPlannedSupply(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	materialID := uuid.New()
+	stockRepo, backorderRepo, purchasing, production := setup(ctx, materialID)
+
+	today := entity.BusinessToday()
+	poDate := today.AddDate(0, 0, 10)
+	woDate := today.AddDate(0, 0, 5)
+	good := 30.0
+	purchasing.On("ListIncomingSupply", ctx, materialID).Return([]procurement.IncomingSupply{
+		{POID: uuid.New(), PONumber: "PO-2026-0001", PendingQty: 100, ExpectedDate: poDate.Format("2006-01-02")},
+		{POID: uuid.New(), PONumber: "PO-2026-0002", PendingQty: 500}, // Not scheduled
+	}, nil)
+	production.On("ListOpenWorkOrders", ctx, materialID).Return([]manufacturing.WorkOrder{
+		{ID: uuid.New(), WONumber: "WO-2026-0001", PlannedQuantity: 40, GoodQuantity: &good, PlannedEndDate: &woDate},
+	}, nil)
+
+	uc := atp.NewGetATPUseCase(stockRepo, backorderRepo, purchasing, production, 14)
+
+	// Act
+	results, err := uc.ExecuteLines(ctx, []atp.ATPLine{
+		{MaterialID: materialID, Quantity: 50},
+		{MaterialID: materialID, Quantity: 50},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Len(t, results[0].Schedule, 2)
+	assert.Equal(t, atp.SupplySourceWorkOrder, results[0].Schedule[0].Source)
+	assert.Equal(t, 55.0, results[0].Schedule[0].CumulativeATP)
+	assert.Equal(t, 155.0, results[0].Schedule[1].CumulativeATP)
+	assert.True(t, woDate.Equal(results[0].PromiseDate))
+	// The second line is promised after the first
+	assert.True(t, poDate.Equal(results[1].PromiseDate))
+	assert.Equal(t, 50.0, results[1].RequestedQty)
+}
+
+func TestGetATPUseCase_Execute_CapableToPromise(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	materialID := uuid.New()
+	stockRepo, backorderRepo, purchasing, production := setup(ctx, materialID)
+	purchasing.On("ListIncomingSupply", ctx, materialID).Return(nil, errors.New("connection refused"))
+	production.On("ListOpenWorkOrders", ctx, materialID).Return([]manufacturing.WorkOrder{}, nil)
+
+	uc := atp.NewGetATPUseCase(stockRepo, backorderRepo, purchasing, production, 14)
+
+	// Act
+	result, err := uc.Execute(ctx, materialID, 100)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, result.CapableToPromise)
+	assert.True(t, result.SupplyIncomplete)
+	assert.Equal(t, entity.BusinessToday().AddDate(0, 0, 14), result.PromiseDate)
+}
+
+func TestGetATPUseCase_Execute_WorkOrderEndsOnBusinessDay(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	materialID := uuid.New()
+	stockRepo, backorderRepo, purchasing, production := setup(ctx, materialID)
+
+	// 20:00 UTC is already the next morning in Vietnam
+	day := entity.BusinessToday().AddDate(0, 0, 5)
+	end := time.Date(day.Year(), day.Month(), day.Day(), 20, 0, 0, 0, time.UTC)
+	purchasing.On("ListIncomingSupply", ctx, materialID).Return([]procurement.IncomingSupply{}, nil)
+	production.On("ListOpenWorkOrders", ctx, materialID).Return([]manufacturing.WorkOrder{
+		{ID: uuid.New(), WONumber: "WO-2026-0001", PlannedQuantity: 40, PlannedEndDate: &end},
+	}, nil)
+
+	uc := atp.NewGetATPUseCase(stockRepo, backorderRepo, purchasing, production, 14)
+
+	// Act
+	result, err := uc.Execute(ctx, materialID, 50)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, day.AddDate(0, 0, 1).Equal(result.PromiseDate))
+}
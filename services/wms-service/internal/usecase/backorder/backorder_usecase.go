@@ -0,0 +1,525 @@
+package backorder
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/reservation"
+	"github.com/google/uuid"
+)
+
+// EventPublisher defines event publishing interface for backorders
+type EventPublisher interface {
+	PublishBackorderCreated(event *event.BackorderEvent) error
+	PublishBackorderAllocated(event *event.BackorderEvent) error
+	PublishBackorderRescheduled(event *event.BackorderEvent) error
+}
+
+// availableQty returns the unreserved quantity of a material, on the same
+// FEFO stock that reservations are taken from
+func availableQty(ctx context.Context, stockRepo repository.StockRepository, materialID uuid.UUID) (float64, error) {
+	stocks, err := stockRepo.GetAvailableStockFEFO(ctx, materialID)
+	if err != nil {
+		return 0, err
+	}
+	var available float64
+	for _, stock := range stocks {
+		if qty := stock.GetAvailableQuantity(); qty > 0 {
+			available += qty
+		}
+	}
+	return available, nil
+}
+
+// ReserveSalesOrderUseCase reserves stock for a confirmed sales order,
+// backordering what is not available
+type ReserveSalesOrderUseCase struct {
+	stockRepo     repository.StockRepository
+	backorderRepo repository.BackorderRepository
+	reserve       *reservation.CreateReservationUseCase
+	eventPub      EventPublisher
+	leadTimeDays  int
+}
+
+// NewReserveSalesOrderUseCase creates a new use case. Backorders are expected
+// leadTimeDays after the order is confirmed until rescheduled.
+func NewReserveSalesOrderUseCase(
+	stockRepo repository.StockRepository,
+	backorderRepo repository.BackorderRepository,
+	reserve *reservation.CreateReservationUseCase,
+	eventPub EventPublisher,
+	leadTimeDays int,
+) *ReserveSalesOrderUseCase {
+	return &ReserveSalesOrderUseCase{
+		stockRepo:     stockRepo,
+		backorderRepo: backorderRepo,
+		reserve:       reserve,
+		eventPub:      eventPub,
+		leadTimeDays:  leadTimeDays,
+	}
+}
+
+// ReserveSalesOrderInput represents a confirmed sales order
+type ReserveSalesOrderInput struct {
+	SalesOrderID     uuid.UUID
+	SONumber         string
+	CustomerID       uuid.UUID
+	CustomerPriority int // Lower is served first
+	OrderDate        time.Time
+	PromisedDate     *time.Time
+	Lines            []ReserveSalesOrderLine
+	CreatedBy        uuid.UUID
+}
+
+// ReserveSalesOrderLine represents a sales order line to reserve
+type ReserveSalesOrderLine struct {
+	LineID     *uuid.UUID
+	MaterialID uuid.UUID
+	UnitID     uuid.UUID
+	Quantity   float64
+}
+
+// ReserveSalesOrderResult represents the reservations and backorders of a sales order
+type ReserveSalesOrderResult struct {
+	Reservations []*entity.StockReservation
+	Backorders   []*entity.Backorder
+}
+
+// Execute reserves what is available of each line and backorders the rest
+func (uc *ReserveSalesOrderUseCase) Execute(ctx context.Context, input *ReserveSalesOrderInput) (*ReserveSalesOrderResult, error) {
+	result := &ReserveSalesOrderResult{}
+	expected := entity.BusinessToday().AddDate(0, 0, uc.leadTimeDays)
+
+	for _, line := range input.Lines {
+		if line.Quantity <= 0 {
+			continue
+		}
+
+		available, err := availableQty(ctx, uc.stockRepo, line.MaterialID)
+		if err != nil {
+			return result, err
+		}
+
+		reserved := math.Min(available, line.Quantity)
+		if reserved > 0 {
+			res, err := uc.reserve.Execute(ctx, &reservation.CreateReservationInput{
+				MaterialID:      line.MaterialID,
+				Quantity:        reserved,
+				UnitID:          line.UnitID,
+				ReservationType: entity.ReservationTypeSalesOrder,
+				ReferenceID:     input.SalesOrderID,
+				ReferenceNumber: input.SONumber,
+				CreatedBy:       input.CreatedBy,
+			})
+			switch {
+			case errors.Is(err, entity.ErrInsufficientStock):
+				// Taken by another reservation meanwhile, backorder the whole line
+				reserved = 0
+			case err != nil:
+				return result, err
+			default:
+				result.Reservations = append(result.Reservations, res)
+			}
+		}
+
+		if shortfall := line.Quantity - reserved; shortfall > 0 {
+			expectedDate := expected
+			backorder := &entity.Backorder{
+				MaterialID:       line.MaterialID,
+				UnitID:           line.UnitID,
+				ReferenceID:      input.SalesOrderID,
+				ReferenceNumber:  input.SONumber,
+				ReferenceLineID:  line.LineID,
+				CustomerID:       input.CustomerID,
+				CustomerPriority: input.CustomerPriority,
+				OrderDate:        input.OrderDate,
+				PromisedDate:     input.PromisedDate,
+				OrderedQty:       line.Quantity,
+				BackorderedQty:   shortfall,
+				ExpectedDate:     &expectedDate,
+				Status:           entity.BackorderStatusOpen,
+			}
+			if err := uc.backorderRepo.Create(ctx, backorder); err != nil {
+				return result, err
+			}
+			result.Backorders = append(result.Backorders, backorder)
+		}
+	}
+
+	// Publish event
+	if len(result.Backorders) > 0 {
+		uc.eventPub.PublishBackorderCreated(eventOf(result.Backorders))
+	}
+
+	return result, nil
+}
+
+// AllocateBackordersUseCase reserves received stock for open backorders
+type AllocateBackordersUseCase struct {
+	stockRepo     repository.StockRepository
+	backorderRepo repository.BackorderRepository
+	reserve       *reservation.CreateReservationUseCase
+	eventPub      EventPublisher
+}
+
+// NewAllocateBackordersUseCase creates a new use case
+func NewAllocateBackordersUseCase(
+	stockRepo repository.StockRepository,
+	backorderRepo repository.BackorderRepository,
+	reserve *reservation.CreateReservationUseCase,
+	eventPub EventPublisher,
+) *AllocateBackordersUseCase {
+	return &AllocateBackordersUseCase{
+		stockRepo:     stockRepo,
+		backorderRepo: backorderRepo,
+		reserve:       reserve,
+		eventPub:      eventPub,
+	}
+}
+
+// Execute allocates the available stock of a material to its open
+// backorders by customer tier, order date and promised date. A backorder
+// that cannot be filled completely takes what is left.
+func (uc *AllocateBackordersUseCase) Execute(ctx context.Context, materialID uuid.UUID) ([]*entity.Backorder, error) {
+	backorders, err := uc.backorderRepo.GetOpenByMaterial(ctx, materialID)
+	if err != nil || len(backorders) == 0 {
+		return nil, err
+	}
+	sort.SliceStable(backorders, func(i, j int) bool {
+		return backorders[i].Precedes(backorders[j])
+	})
+
+	available, err := availableQty(ctx, uc.stockRepo, materialID)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocated []*entity.Backorder
+	for _, backorder := range backorders {
+		if available <= 0 {
+			break
+		}
+
+		qty := math.Min(available, backorder.BackorderedQty)
+		_, err := uc.reserve.Execute(ctx, &reservation.CreateReservationInput{
+			MaterialID:      backorder.MaterialID,
+			Quantity:        qty,
+			UnitID:          backorder.UnitID,
+			ReservationType: entity.ReservationTypeSalesOrder,
+			ReferenceID:     backorder.ReferenceID,
+			ReferenceNumber: backorder.ReferenceNumber,
+			CreatedBy:       uuid.Nil, // System
+		})
+		if errors.Is(err, entity.ErrInsufficientStock) {
+			break
+		}
+		if err != nil {
+			return allocated, err
+		}
+
+		backorder.Allocate(qty)
+		if err := uc.backorderRepo.Update(ctx, backorder); err != nil {
+			return allocated, err
+		}
+		available -= qty
+		allocated = append(allocated, backorder)
+	}
+
+	// Publish one event per sales order
+	byOrder := make(map[uuid.UUID][]*entity.Backorder)
+	var orders []uuid.UUID
+	for _, backorder := range allocated {
+		if _, ok := byOrder[backorder.ReferenceID]; !ok {
+			orders = append(orders, backorder.ReferenceID)
+		}
+		byOrder[backorder.ReferenceID] = append(byOrder[backorder.ReferenceID], backorder)
+	}
+	for _, soID := range orders {
+		uc.eventPub.PublishBackorderAllocated(eventOf(byOrder[soID]))
+	}
+
+	return allocated, nil
+}
+
+// CancelBackordersUseCase cancels the open backorders of a sales order
+type CancelBackordersUseCase struct {
+	backorderRepo repository.BackorderRepository
+}
+
+// NewCancelBackordersUseCase creates a new use case
+func NewCancelBackordersUseCase(backorderRepo repository.BackorderRepository) *CancelBackordersUseCase {
+	return &CancelBackordersUseCase{backorderRepo: backorderRepo}
+}
+
+// Execute cancels the open backorders of a sales order and returns how many
+// were cancelled
+func (uc *CancelBackordersUseCase) Execute(ctx context.Context, salesOrderID uuid.UUID) (int, error) {
+	backorders, err := uc.backorderRepo.GetOpenByReference(ctx, salesOrderID)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, backorder := range backorders {
+		backorder.Cancel()
+		if err := uc.backorderRepo.Update(ctx, backorder); err != nil {
+			return i, err
+		}
+	}
+
+	return len(backorders), nil
+}
+
+// AmendSalesOrderUseCase reserves or releases the quantity changes of an
+// amended sales order
+type AmendSalesOrderUseCase struct {
+	stockRepo     repository.StockRepository
+	backorderRepo repository.BackorderRepository
+	reserveOrder  *ReserveSalesOrderUseCase
+	reserve       *reservation.CreateReservationUseCase
+}
+
+// NewAmendSalesOrderUseCase creates a new use case
+func NewAmendSalesOrderUseCase(
+	stockRepo repository.StockRepository,
+	backorderRepo repository.BackorderRepository,
+	reserveOrder *ReserveSalesOrderUseCase,
+	reserve *reservation.CreateReservationUseCase,
+) *AmendSalesOrderUseCase {
+	return &AmendSalesOrderUseCase{
+		stockRepo:     stockRepo,
+		backorderRepo: backorderRepo,
+		reserveOrder:  reserveOrder,
+		reserve:       reserve,
+	}
+}
+
+// AmendSalesOrderInput represents the quantity changes of an amended sales order
+type AmendSalesOrderInput struct {
+	SalesOrderID     uuid.UUID
+	SONumber         string
+	CustomerID       uuid.UUID
+	CustomerPriority int
+	OrderDate        time.Time
+	PromisedDate     *time.Time
+	Lines            []AmendSalesOrderLine
+	UpdatedBy        uuid.UUID
+}
+
+// AmendSalesOrderLine represents the quantity change of a sales order line
+type AmendSalesOrderLine struct {
+	LineID     *uuid.UUID
+	MaterialID uuid.UUID
+	UnitID     uuid.UUID
+	DeltaQty   float64 // Negative if reduced
+}
+
+// AmendSalesOrderResult represents the reservation changes of an amended sales order
+type AmendSalesOrderResult struct {
+	Reservations []*entity.StockReservation // Reserved for increases, or re-reserved after a partial release
+	Released     []*entity.StockReservation
+	Backorders   []*entity.Backorder // Created for increases
+	Reduced      []*entity.Backorder
+}
+
+// Execute reserves increases like a confirmed order, backordering what is
+// not available. Reductions come off the open backorders of the line first,
+// then off the reservations of the order, latest first.
+func (uc *AmendSalesOrderUseCase) Execute(ctx context.Context, input *AmendSalesOrderInput) (*AmendSalesOrderResult, error) {
+	result := &AmendSalesOrderResult{}
+
+	var increases []ReserveSalesOrderLine
+	var reductions []AmendSalesOrderLine
+	for _, line := range input.Lines {
+		switch {
+		case line.DeltaQty > 0:
+			increases = append(increases, ReserveSalesOrderLine{
+				LineID:     line.LineID,
+				MaterialID: line.MaterialID,
+				UnitID:     line.UnitID,
+				Quantity:   line.DeltaQty,
+			})
+		case line.DeltaQty < 0:
+			reductions = append(reductions, line)
+		}
+	}
+
+	if len(reductions) > 0 {
+		backorders, err := uc.backorderRepo.GetOpenByReference(ctx, input.SalesOrderID)
+		if err != nil {
+			return result, err
+		}
+		reservations, err := uc.stockRepo.GetActiveReservationsByReference(ctx, input.SalesOrderID)
+		if err != nil {
+			return result, err
+		}
+
+		for _, line := range reductions {
+			remaining := -line.DeltaQty
+
+			for _, backorder := range backorders {
+				if remaining <= 0 {
+					break
+				}
+				if !backorder.IsOpen() || !sameLine(backorder, line) {
+					continue
+				}
+				remaining -= backorder.Reduce(remaining)
+				if err := uc.backorderRepo.Update(ctx, backorder); err != nil {
+					return result, err
+				}
+				result.Reduced = append(result.Reduced, backorder)
+			}
+
+			for _, res := range reservations {
+				if remaining <= 0 {
+					break
+				}
+				if !res.IsActive() || res.MaterialID != line.MaterialID {
+					continue
+				}
+				if err := uc.stockRepo.ReleaseReservation(ctx, res.ID); err != nil {
+					return result, err
+				}
+				res.Release()
+				result.Released = append(result.Released, res)
+
+				// Reservations are taken whole, so reserve back what is kept
+				if kept := res.Quantity - remaining; kept > 0 {
+					rereserved, err := uc.reserve.Execute(ctx, &reservation.CreateReservationInput{
+						MaterialID:      res.MaterialID,
+						Quantity:        kept,
+						UnitID:          res.UnitID,
+						ReservationType: res.ReservationType,
+						ReferenceID:     res.ReferenceID,
+						ReferenceNumber: res.ReferenceNumber,
+						ExpiresAt:       res.ExpiresAt,
+						CreatedBy:       input.UpdatedBy,
+					})
+					if err != nil {
+						return result, err
+					}
+					result.Reservations = append(result.Reservations, rereserved)
+					remaining = 0
+					break
+				}
+				remaining -= res.Quantity
+			}
+		}
+	}
+
+	if len(increases) > 0 {
+		reserved, err := uc.reserveOrder.Execute(ctx, &ReserveSalesOrderInput{
+			SalesOrderID:     input.SalesOrderID,
+			SONumber:         input.SONumber,
+			CustomerID:       input.CustomerID,
+			CustomerPriority: input.CustomerPriority,
+			OrderDate:        input.OrderDate,
+			PromisedDate:     input.PromisedDate,
+			Lines:            increases,
+			CreatedBy:        input.UpdatedBy,
+		})
+		if reserved != nil {
+			result.Reservations = append(result.Reservations, reserved.Reservations...)
+			result.Backorders = append(result.Backorders, reserved.Backorders...)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// sameLine returns true if the backorder is of the amended order line
+func sameLine(backorder *entity.Backorder, line AmendSalesOrderLine) bool {
+	if line.LineID != nil && backorder.ReferenceLineID != nil {
+		return *backorder.ReferenceLineID == *line.LineID
+	}
+	return backorder.MaterialID == line.MaterialID
+}
+
+// RescheduleBackorderUseCase changes the date a backorder is expected
+type RescheduleBackorderUseCase struct {
+	backorderRepo repository.BackorderRepository
+	eventPub      EventPublisher
+}
+
+// NewRescheduleBackorderUseCase creates a new use case
+func NewRescheduleBackorderUseCase(backorderRepo repository.BackorderRepository, eventPub EventPublisher) *RescheduleBackorderUseCase {
+	return &RescheduleBackorderUseCase{
+		backorderRepo: backorderRepo,
+		eventPub:      eventPub,
+	}
+}
+
+// Execute sets the expected date of an open backorder, so that the customer
+// is notified
+func (uc *RescheduleBackorderUseCase) Execute(ctx context.Context, id uuid.UUID, expectedDate time.Time) (*entity.Backorder, error) {
+	backorder, err := uc.backorderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.ErrNotFound
+	}
+	if !backorder.IsOpen() {
+		return nil, entity.ErrBackorderNotOpen
+	}
+
+	backorder.ExpectedDate = &expectedDate
+	if err := uc.backorderRepo.Update(ctx, backorder); err != nil {
+		return nil, err
+	}
+
+	// Publish event
+	uc.eventPub.PublishBackorderRescheduled(eventOf([]*entity.Backorder{backorder}))
+
+	return backorder, nil
+}
+
+// ListBackordersUseCase handles listing backorders
+type ListBackordersUseCase struct {
+	backorderRepo repository.BackorderRepository
+}
+
+// NewListBackordersUseCase creates a new use case
+func NewListBackordersUseCase(backorderRepo repository.BackorderRepository) *ListBackordersUseCase {
+	return &ListBackordersUseCase{backorderRepo: backorderRepo}
+}
+
+// Execute lists backorders with filters
+func (uc *ListBackordersUseCase) Execute(ctx context.Context, filter *repository.BackorderFilter) ([]*entity.Backorder, int64, error) {
+	return uc.backorderRepo.List(ctx, filter)
+}
+
+// eventOf maps backorders of one sales order to their event
+func eventOf(backorders []*entity.Backorder) *event.BackorderEvent {
+	first := backorders[0]
+	evt := &event.BackorderEvent{
+		SOID:       first.ReferenceID.String(),
+		SONumber:   first.ReferenceNumber,
+		CustomerID: first.CustomerID.String(),
+		Lines:      make([]event.BackorderEventLine, len(backorders)),
+	}
+	for i, backorder := range backorders {
+		line := event.BackorderEventLine{
+			BackorderID:    backorder.ID.String(),
+			MaterialID:     backorder.MaterialID.String(),
+			OrderedQty:     backorder.OrderedQty,
+			ReservedQty:    backorder.ReservedQty(),
+			BackorderedQty: backorder.BackorderedQty,
+			Status:         string(backorder.Status),
+		}
+		if backorder.ReferenceLineID != nil {
+			line.LineID = backorder.ReferenceLineID.String()
+		}
+		if backorder.ExpectedDate != nil {
+			line.ExpectedDate = backorder.ExpectedDate.Format("2006-01-02")
+		}
+		evt.Lines[i] = line
+	}
+	return evt
+}
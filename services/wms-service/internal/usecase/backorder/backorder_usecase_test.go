@@ -0,0 +1,272 @@
+package backorder_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/erp-cosmetics/wms-service/internal/testmocks"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/backorder"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/reservation"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReserveSalesOrderUseCase_Execute_PartialStock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stockRepo := new(testmocks.MockStockRepository)
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	reserve := reservation.NewCreateReservationUseCase(stockRepo, eventPub)
+	uc := backorder.NewReserveSalesOrderUseCase(stockRepo, backorderRepo, reserve, eventPub, 14)
+
+	materialID := uuid.New()
+	lineID := uuid.New()
+
+	stockRepo.On("GetAvailableStockFEFO", ctx, materialID).Return([]*entity.Stock{
+		{MaterialID: materialID, Quantity: 50, ReservedQty: 20},
+		{MaterialID: materialID, Quantity: 40},
+	}, nil)
+	stockRepo.On("ReserveStock", ctx, materialID, 70.0, mock.AnythingOfType("*entity.StockReservation")).Return(nil)
+	eventPub.On("PublishStockReserved", mock.AnythingOfType("*event.StockReservedEvent")).Return(nil)
+	backorderRepo.On("Create", ctx, mock.AnythingOfType("*entity.Backorder")).Return(nil)
+	eventPub.On("PublishBackorderCreated", mock.MatchedBy(func(e *event.BackorderEvent) bool {
+		return len(e.Lines) == 1 && e.Lines[0].LineID == lineID.String() && e.Lines[0].BackorderedQty == 30
+	})).Return(nil)
+
+	// Act
+	result, err := uc.Execute(ctx, &backorder.ReserveSalesOrderInput{
+		SalesOrderID: uuid.New(),
+		SONumber:     "SO-2026-0001",
+		CustomerID:   uuid.New(),
+		OrderDate:    time.Now(),
+		Lines: []backorder.ReserveSalesOrderLine{
+			{LineID: &lineID, MaterialID: materialID, Quantity: 100},
+		},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Reservations, 1)
+	assert.Len(t, result.Backorders, 1)
+	assert.Equal(t, 30.0, result.Backorders[0].BackorderedQty)
+	assert.Equal(t, entity.BackorderStatusOpen, result.Backorders[0].Status)
+	assert.NotNil(t, result.Backorders[0].ExpectedDate)
+
+	stockRepo.AssertExpectations(t)
+	backorderRepo.AssertExpectations(t)
+	eventPub.AssertExpectations(t)
+}
+
+func TestReserveSalesOrderUseCase_Execute_FullStock(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stockRepo := new(testmocks.MockStockRepository)
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	reserve := reservation.NewCreateReservationUseCase(stockRepo, eventPub)
+	uc := backorder.NewReserveSalesOrderUseCase(stockRepo, backorderRepo, reserve, eventPub, 14)
+
+	materialID := uuid.New()
+
+	stockRepo.On("GetAvailableStockFEFO", ctx, materialID).Return([]*entity.Stock{
+		{MaterialID: materialID, Quantity: 200},
+	}, nil)
+	stockRepo.On("ReserveStock", ctx, materialID, 100.0, mock.AnythingOfType("*entity.StockReservation")).Return(nil)
+	eventPub.On("PublishStockReserved", mock.AnythingOfType("*event.StockReservedEvent")).Return(nil)
+
+	// Act
+	result, err := uc.Execute(ctx, &backorder.ReserveSalesOrderInput{
+		SalesOrderID: uuid.New(),
+		OrderDate:    time.Now(),
+		Lines: []backorder.ReserveSalesOrderLine{
+			{MaterialID: materialID, Quantity: 100},
+		},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Reservations, 1)
+	assert.Empty(t, result.Backorders)
+
+	backorderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	eventPub.AssertNotCalled(t, "PublishBackorderCreated", mock.Anything)
+}
+
+func TestAllocateBackordersUseCase_Execute_ByPriority(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stockRepo := new(testmocks.MockStockRepository)
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	reserve := reservation.NewCreateReservationUseCase(stockRepo, eventPub)
+	uc := backorder.NewAllocateBackordersUseCase(stockRepo, backorderRepo, reserve, eventPub)
+
+	materialID := uuid.New()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	retail := &entity.Backorder{
+		ID: uuid.New(), MaterialID: materialID, ReferenceID: uuid.New(),
+		CustomerPriority: 3, OrderDate: day,
+		OrderedQty: 50, BackorderedQty: 50, Status: entity.BackorderStatusOpen,
+	}
+	distributor := &entity.Backorder{
+		ID: uuid.New(), MaterialID: materialID, ReferenceID: uuid.New(),
+		CustomerPriority: 1, OrderDate: day.AddDate(0, 0, 5),
+		OrderedQty: 80, BackorderedQty: 40, Status: entity.BackorderStatusOpen,
+	}
+
+	backorderRepo.On("GetOpenByMaterial", ctx, materialID).Return([]*entity.Backorder{retail, distributor}, nil)
+	stockRepo.On("GetAvailableStockFEFO", ctx, materialID).Return([]*entity.Stock{
+		{MaterialID: materialID, Quantity: 60},
+	}, nil)
+	stockRepo.On("ReserveStock", ctx, materialID, 40.0, mock.AnythingOfType("*entity.StockReservation")).Return(nil)
+	stockRepo.On("ReserveStock", ctx, materialID, 20.0, mock.AnythingOfType("*entity.StockReservation")).Return(nil)
+	eventPub.On("PublishStockReserved", mock.AnythingOfType("*event.StockReservedEvent")).Return(nil)
+	backorderRepo.On("Update", ctx, mock.AnythingOfType("*entity.Backorder")).Return(nil)
+	eventPub.On("PublishBackorderAllocated", mock.AnythingOfType("*event.BackorderEvent")).Return(nil)
+
+	// Act
+	allocated, err := uc.Execute(ctx, materialID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, allocated, 2)
+	assert.Equal(t, distributor.ID, allocated[0].ID)
+	assert.Equal(t, entity.BackorderStatusAllocated, distributor.Status)
+	assert.Equal(t, entity.BackorderStatusOpen, retail.Status)
+	assert.Equal(t, 30.0, retail.BackorderedQty)
+
+	eventPub.AssertNumberOfCalls(t, "PublishBackorderAllocated", 2)
+	stockRepo.AssertExpectations(t)
+}
+
+func TestRescheduleBackorderUseCase_Execute_NotOpen(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	uc := backorder.NewRescheduleBackorderUseCase(backorderRepo, eventPub)
+
+	id := uuid.New()
+	backorderRepo.On("GetByID", ctx, id).Return(&entity.Backorder{ID: id, Status: entity.BackorderStatusAllocated}, nil)
+
+	// Act
+	result, err := uc.Execute(ctx, id, time.Now().AddDate(0, 0, 7))
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrBackorderNotOpen)
+	assert.Nil(t, result)
+	eventPub.AssertNotCalled(t, "PublishBackorderRescheduled", mock.Anything)
+}
+
+func TestAmendSalesOrderUseCase_Execute_Reduction(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stockRepo := new(testmocks.MockStockRepository)
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	reserve := reservation.NewCreateReservationUseCase(stockRepo, eventPub)
+	reserveOrder := backorder.NewReserveSalesOrderUseCase(stockRepo, backorderRepo, reserve, eventPub, 14)
+	uc := backorder.NewAmendSalesOrderUseCase(stockRepo, backorderRepo, reserveOrder, reserve)
+
+	soID := uuid.New()
+	materialID := uuid.New()
+	lineID := uuid.New()
+	open := &entity.Backorder{
+		ID:              uuid.New(),
+		MaterialID:      materialID,
+		ReferenceID:     soID,
+		ReferenceLineID: &lineID,
+		OrderedQty:      100,
+		BackorderedQty:  20,
+		Status:          entity.BackorderStatusOpen,
+	}
+	reserved := &entity.StockReservation{
+		ID:              uuid.New(),
+		MaterialID:      materialID,
+		Quantity:        80,
+		ReservationType: entity.ReservationTypeSalesOrder,
+		ReferenceID:     soID,
+		Status:          entity.ReservationStatusActive,
+	}
+
+	backorderRepo.On("GetOpenByReference", ctx, soID).Return([]*entity.Backorder{open}, nil)
+	backorderRepo.On("Update", ctx, open).Return(nil)
+	stockRepo.On("GetActiveReservationsByReference", ctx, soID).Return([]*entity.StockReservation{reserved}, nil)
+	stockRepo.On("ReleaseReservation", ctx, reserved.ID).Return(nil)
+	stockRepo.On("ReserveStock", ctx, materialID, 50.0, mock.AnythingOfType("*entity.StockReservation")).Return(nil)
+	eventPub.On("PublishStockReserved", mock.AnythingOfType("*event.StockReservedEvent")).Return(nil)
+
+	// Act - 50 off a line of 100 with 20 backordered
+	result, err := uc.Execute(ctx, &backorder.AmendSalesOrderInput{
+		SalesOrderID: soID,
+		OrderDate:    time.Now(),
+		Lines: []backorder.AmendSalesOrderLine{
+			{LineID: &lineID, MaterialID: materialID, DeltaQty: -50},
+		},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Reduced, 1)
+	assert.Equal(t, entity.BackorderStatusCancelled, open.Status)
+	assert.Equal(t, 80.0, open.OrderedQty)
+	assert.Len(t, result.Released, 1)
+	assert.Len(t, result.Reservations, 1)
+	assert.Equal(t, 50.0, result.Reservations[0].Quantity)
+
+	stockRepo.AssertExpectations(t)
+	backorderRepo.AssertExpectations(t)
+}
+
+func TestAmendSalesOrderUseCase_Execute_Increase(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stockRepo := new(testmocks.MockStockRepository)
+	backorderRepo := new(testmocks.MockBackorderRepository)
+	eventPub := new(testmocks.MockEventPublisher)
+
+	reserve := reservation.NewCreateReservationUseCase(stockRepo, eventPub)
+	reserveOrder := backorder.NewReserveSalesOrderUseCase(stockRepo, backorderRepo, reserve, eventPub, 14)
+	uc := backorder.NewAmendSalesOrderUseCase(stockRepo, backorderRepo, reserveOrder, reserve)
+
+	materialID := uuid.New()
+	lineID := uuid.New()
+
+	stockRepo.On("GetAvailableStockFEFO", ctx, materialID).Return([]*entity.Stock{
+		{MaterialID: materialID, Quantity: 5},
+	}, nil)
+	stockRepo.On("ReserveStock", ctx, materialID, 5.0, mock.AnythingOfType("*entity.StockReservation")).Return(nil)
+	eventPub.On("PublishStockReserved", mock.AnythingOfType("*event.StockReservedEvent")).Return(nil)
+	backorderRepo.On("Create", ctx, mock.AnythingOfType("*entity.Backorder")).Return(nil)
+	eventPub.On("PublishBackorderCreated", mock.AnythingOfType("*event.BackorderEvent")).Return(nil)
+
+	// Act
+	result, err := uc.Execute(ctx, &backorder.AmendSalesOrderInput{
+		SalesOrderID: uuid.New(),
+		OrderDate:    time.Now(),
+		Lines: []backorder.AmendSalesOrderLine{
+			{LineID: &lineID, MaterialID: materialID, DeltaQty: 10},
+		},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Reservations, 1)
+	assert.Len(t, result.Backorders, 1)
+	assert.Equal(t, 5.0, result.Backorders[0].BackorderedQty)
+	assert.Empty(t, result.Released)
+
+	stockRepo.AssertNotCalled(t, "GetActiveReservationsByReference", mock.Anything, mock.Anything)
+	backorderRepo.AssertNotCalled(t, "GetOpenByReference", mock.Anything, mock.Anything)
+}
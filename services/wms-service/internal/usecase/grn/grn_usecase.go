@@ -71,6 +71,7 @@ type CreateGRNItemInput struct {
 	ManufacturedDate  *time.Time
 	ExpiryDate        time.Time
 	LocationID        *uuid.UUID
+	UnitCost          float64 // Purchase price per unit, becomes the lot valuation
 }
 
 // Execute creates a GRN
@@ -131,6 +132,7 @@ func (uc *CreateGRNUseCase) Execute(ctx context.Context, input *CreateGRNInput)
 			GRNID:             &grn.ID,
 			QCStatus:          entity.QCStatusPending,
 			Status:            entity.LotStatusAvailable,
+			UnitCost:          item.UnitCost,
 		}
 
 		if err := uc.lotRepo.Create(ctx, lot); err != nil {
@@ -67,3 +67,80 @@ func NewGetLotMovementsUseCase(stockRepo repository.StockRepository) *GetLotMove
 func (uc *GetLotMovementsUseCase) Execute(ctx context.Context, lotID uuid.UUID) ([]*entity.StockMovement, error) {
 	return uc.stockRepo.GetMovementsByLot(ctx, lotID)
 }
+
+// BlockLotsUseCase handles blocking lots on hold, e.g. for a product recall
+type BlockLotsUseCase struct {
+	lotRepo repository.LotRepository
+}
+
+// NewBlockLotsUseCase creates a new use case
+func NewBlockLotsUseCase(lotRepo repository.LotRepository) *BlockLotsUseCase {
+	return &BlockLotsUseCase{lotRepo: lotRepo}
+}
+
+// Execute blocks the lots and notes the reason; returns the number of lots newly blocked
+func (uc *BlockLotsUseCase) Execute(ctx context.Context, lotIDs []uuid.UUID, reason string) (int, error) {
+	blocked := 0
+	for _, id := range lotIDs {
+		l, err := uc.lotRepo.GetByID(ctx, id)
+		if err != nil {
+			return blocked, err
+		}
+		if l.Status == entity.LotStatusBlocked {
+			continue
+		}
+		l.Block()
+		if reason != "" {
+			if l.Notes != "" {
+				l.Notes += "\n"
+			}
+			l.Notes += reason
+		}
+		if err := uc.lotRepo.Update(ctx, l); err != nil {
+			return blocked, err
+		}
+		blocked++
+	}
+	return blocked, nil
+}
+
+// UpdateLotCostUseCase handles lot revaluation, e.g. finished goods valued at actual work order cost
+type UpdateLotCostUseCase struct {
+	lotRepo repository.LotRepository
+}
+
+// NewUpdateLotCostUseCase creates a new use case
+func NewUpdateLotCostUseCase(lotRepo repository.LotRepository) *UpdateLotCostUseCase {
+	return &UpdateLotCostUseCase{lotRepo: lotRepo}
+}
+
+// UpdateLotCostInput identifies the lot by ID or, when the ID is unknown to the sender, by lot number
+type UpdateLotCostInput struct {
+	LotID     *uuid.UUID
+	LotNumber string
+	UnitCost  float64
+}
+
+// Execute sets the unit cost of the lot
+func (uc *UpdateLotCostUseCase) Execute(ctx context.Context, input *UpdateLotCostInput) (*entity.Lot, error) {
+	if input.UnitCost < 0 {
+		return nil, entity.ErrInvalidUnitCost
+	}
+
+	var l *entity.Lot
+	var err error
+	if input.LotID != nil {
+		l, err = uc.lotRepo.GetByID(ctx, *input.LotID)
+	} else {
+		l, err = uc.lotRepo.GetByLotNumber(ctx, input.LotNumber)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l.SetUnitCost(input.UnitCost)
+	if err := uc.lotRepo.Update(ctx, l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
@@ -0,0 +1,72 @@
+package lot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/testmocks"
+	"github.com/erp-cosmetics/wms-service/internal/usecase/lot"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBlockLotsUseCase_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	lotRepo := new(testmocks.MockLotRepository)
+	uc := lot.NewBlockLotsUseCase(lotRepo)
+
+	available := &entity.Lot{ID: uuid.New(), Status: entity.LotStatusAvailable, QCStatus: entity.QCStatusPassed}
+	alreadyBlocked := &entity.Lot{ID: uuid.New(), Status: entity.LotStatusBlocked}
+
+	lotRepo.On("GetByID", ctx, available.ID).Return(available, nil)
+	lotRepo.On("GetByID", ctx, alreadyBlocked.ID).Return(alreadyBlocked, nil)
+	lotRepo.On("Update", ctx, mock.AnythingOfType("*entity.Lot")).Return(nil)
+
+	// Act
+	blocked, err := uc.Execute(ctx, []uuid.UUID{available.ID, alreadyBlocked.ID}, "Recall RCL-2026-0001")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, blocked)
+	assert.Equal(t, entity.LotStatusBlocked, available.Status)
+	assert.Equal(t, "Recall RCL-2026-0001", available.Notes)
+	lotRepo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestUpdateLotCostUseCase_Execute_ByLotNumber(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	lotRepo := new(testmocks.MockLotRepository)
+	uc := lot.NewUpdateLotCostUseCase(lotRepo)
+
+	fg := &entity.Lot{ID: uuid.New(), LotNumber: "B2026-0042", Status: entity.LotStatusAvailable}
+	lotRepo.On("GetByLotNumber", ctx, "B2026-0042").Return(fg, nil)
+	lotRepo.On("Update", ctx, fg).Return(nil)
+
+	// Act
+	res, err := uc.Execute(ctx, &lot.UpdateLotCostInput{LotNumber: "B2026-0042", UnitCost: 18250.5})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 18250.5, res.UnitCost)
+	assert.NotNil(t, res.CostUpdatedAt)
+}
+
+func TestUpdateLotCostUseCase_Execute_NegativeCost(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	lotRepo := new(testmocks.MockLotRepository)
+	uc := lot.NewUpdateLotCostUseCase(lotRepo)
+
+	id := uuid.New()
+
+	// Act
+	_, err := uc.Execute(ctx, &lot.UpdateLotCostInput{LotID: &id, UnitCost: -1})
+
+	// Assert
+	assert.Equal(t, entity.ErrInvalidUnitCost, err)
+	lotRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
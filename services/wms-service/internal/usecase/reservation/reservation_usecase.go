@@ -86,6 +86,30 @@ func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, reservationID
 	return uc.stockRepo.ReleaseReservation(ctx, reservationID)
 }
 
+// ReleaseForReference releases the active reservations of a reference
+// document, such as a cancelled sales order, and returns how many it released.
+// It carries on past a failed release so one bad reservation does not keep the
+// others held, and returns the first error.
+func (uc *ReleaseReservationUseCase) ReleaseForReference(ctx context.Context, referenceID uuid.UUID) (int, error) {
+	reservations, err := uc.stockRepo.GetActiveReservationsByReference(ctx, referenceID)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	var firstErr error
+	for _, res := range reservations {
+		if err := uc.stockRepo.ReleaseReservation(ctx, res.ID); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		released++
+	}
+	return released, firstErr
+}
+
 // CheckAvailabilityUseCase checks stock availability
 type CheckAvailabilityUseCase struct {
 	stockRepo repository.StockRepository
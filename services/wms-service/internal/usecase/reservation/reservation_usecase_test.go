@@ -87,3 +87,25 @@ func TestReleaseReservationUseCase_Execute_Success(t *testing.T) {
 	assert.NoError(t, err)
 	stockRepo.AssertExpectations(t)
 }
+
+func TestReleaseReservationUseCase_ReleaseForReference(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	stockRepo := new(testmocks.MockStockRepository)
+	uc := reservation.NewReleaseReservationUseCase(stockRepo)
+
+	soID := uuid.New()
+	first := &entity.StockReservation{ID: uuid.New(), ReferenceID: soID, Status: entity.ReservationStatusActive}
+	second := &entity.StockReservation{ID: uuid.New(), ReferenceID: soID, Status: entity.ReservationStatusActive}
+	stockRepo.On("GetActiveReservationsByReference", ctx, soID).Return([]*entity.StockReservation{first, second}, nil)
+	stockRepo.On("ReleaseReservation", ctx, first.ID).Return(entity.ErrNotFound)
+	stockRepo.On("ReleaseReservation", ctx, second.ID).Return(nil)
+
+	// Act
+	released, err := uc.ReleaseForReference(ctx, soID)
+
+	// Assert
+	assert.Equal(t, entity.ErrNotFound, err)
+	assert.Equal(t, 1, released) // The failed release does not keep the second reservation held
+	stockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,262 @@
+package stock
+
+import (
+	"context"
+	"time"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+// ProductionLotInput represents a lot produced by a work order
+type ProductionLotInput struct {
+	LotID      uuid.UUID // Assigned by manufacturing, kept so traceability and costing match
+	LotNumber  string
+	MaterialID uuid.UUID
+	Quantity   float64
+	UnitID     uuid.UUID
+}
+
+// ReceiveProductionInput represents the output of a completed work order
+type ReceiveProductionInput struct {
+	WOID           uuid.UUID
+	WONumber       string
+	Lots           []ProductionLotInput // Finished lot, co-products and by-products
+	SourceLotID    *uuid.UUID           // Rework: rejected lot consumed by the work order
+	SourceQuantity float64
+	CreatedBy      uuid.UUID
+}
+
+// ReceiveProductionUseCase receives the lots of a completed work order into the
+// quarantine zone of the production warehouse, pending QC release, and consumes
+// the rejected lot of a rework work order
+type ReceiveProductionUseCase struct {
+	stockRepo       repository.StockRepository
+	lotRepo         repository.LotRepository
+	zoneRepo        repository.ZoneRepository
+	locationRepo    repository.LocationRepository
+	eventPub        *event.Publisher
+	warehouseID     uuid.UUID
+	shelfLifeMonths int
+}
+
+// NewReceiveProductionUseCase creates a new use case. shelfLifeMonths sets the
+// expiry of new lots; reworked lots keep the expiry of the rejected lot.
+func NewReceiveProductionUseCase(
+	stockRepo repository.StockRepository,
+	lotRepo repository.LotRepository,
+	zoneRepo repository.ZoneRepository,
+	locationRepo repository.LocationRepository,
+	eventPub *event.Publisher,
+	warehouseID uuid.UUID,
+	shelfLifeMonths int,
+) *ReceiveProductionUseCase {
+	return &ReceiveProductionUseCase{
+		stockRepo:       stockRepo,
+		lotRepo:         lotRepo,
+		zoneRepo:        zoneRepo,
+		locationRepo:    locationRepo,
+		eventPub:        eventPub,
+		warehouseID:     warehouseID,
+		shelfLifeMonths: shelfLifeMonths,
+	}
+}
+
+// Execute consumes the rejected lot, if any, and receives the produced lots.
+// Lots already received for the work order are skipped, so a redelivered
+// event does not double the stock.
+func (uc *ReceiveProductionUseCase) Execute(ctx context.Context, input *ReceiveProductionInput) error {
+	now := time.Now()
+	expiry := now.AddDate(0, uc.shelfLifeMonths, 0)
+
+	if input.SourceLotID != nil {
+		source, err := uc.lotRepo.GetByID(ctx, *input.SourceLotID)
+		if err != nil {
+			return entity.ErrNotFound
+		}
+		expiry = source.ExpiryDate
+		if err := uc.consumeSourceLot(ctx, input, source); err != nil {
+			return err
+		}
+	}
+
+	zone, location, err := uc.quarantineLocation(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, in := range input.Lots {
+		if in.Quantity <= 0 {
+			continue
+		}
+		if err := uc.receiveLot(ctx, input, in, zone, location, now, expiry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveLot creates the lot under the ID given by manufacturing and puts the
+// produced quantity into the quarantine location
+func (uc *ReceiveProductionUseCase) receiveLot(ctx context.Context, input *ReceiveProductionInput, in ProductionLotInput, zone *entity.Zone, location *entity.Location, producedAt, expiry time.Time) error {
+	if lot, err := uc.lotRepo.GetByID(ctx, in.LotID); err == nil {
+		received, err := uc.movedForWO(ctx, lot.ID, entity.MovementTypeIn, input.WOID)
+		if err != nil || received {
+			return err
+		}
+	} else {
+		lot := &entity.Lot{
+			ID:               in.LotID,
+			LotNumber:        in.LotNumber,
+			MaterialID:       in.MaterialID,
+			ManufacturedDate: &producedAt,
+			ExpiryDate:       expiry,
+			ReceivedDate:     producedAt,
+			QCStatus:         entity.QCStatusPending,
+			Status:           entity.LotStatusAvailable,
+			Notes:            "Produced by " + input.WONumber,
+		}
+		if err := uc.lotRepo.Create(ctx, lot); err != nil {
+			return err
+		}
+	}
+
+	stock := &entity.Stock{
+		WarehouseID: uc.warehouseID,
+		ZoneID:      zone.ID,
+		LocationID:  location.ID,
+		MaterialID:  in.MaterialID,
+		LotID:       &in.LotID,
+		Quantity:    in.Quantity,
+		UnitID:      in.UnitID,
+	}
+
+	movementNumber, err := uc.stockRepo.GetNextMovementNumber(ctx, entity.MovementTypeIn)
+	if err != nil {
+		return err
+	}
+	movement := entity.NewStockMovementIn(
+		in.MaterialID,
+		in.LotID,
+		location.ID,
+		in.UnitID,
+		input.CreatedBy,
+		in.Quantity,
+		entity.ReferenceTypeWO,
+		&input.WOID,
+		movementNumber,
+	)
+	movement.Notes = "Production output " + input.WONumber
+
+	if err := uc.stockRepo.ReceiveStock(ctx, stock, movement); err != nil {
+		return err
+	}
+
+	uc.eventPub.PublishStockReceived(&event.StockReceivedEvent{
+		MaterialID:  in.MaterialID.String(),
+		LotID:       in.LotID.String(),
+		Quantity:    in.Quantity,
+		LocationID:  location.ID.String(),
+		WarehouseID: uc.warehouseID.String(),
+	})
+	return nil
+}
+
+// consumeSourceLot issues the reworked quantity of the rejected lot from
+// wherever it is held
+func (uc *ReceiveProductionUseCase) consumeSourceLot(ctx context.Context, input *ReceiveProductionInput, source *entity.Lot) error {
+	if input.SourceQuantity <= 0 {
+		return nil
+	}
+	consumed, err := uc.movedForWO(ctx, source.ID, entity.MovementTypeOut, input.WOID)
+	if err != nil || consumed {
+		return err
+	}
+
+	hasStock := true
+	stocks, _, err := uc.stockRepo.List(ctx, &repository.StockFilter{LotID: &source.ID, HasStock: &hasStock})
+	if err != nil {
+		return err
+	}
+	available := 0.0
+	for _, s := range stocks {
+		available += s.Quantity - s.ReservedQty
+	}
+	if available < input.SourceQuantity {
+		return entity.ErrInsufficientStock
+	}
+
+	remaining := input.SourceQuantity
+	for _, s := range stocks {
+		if remaining <= 0 {
+			break
+		}
+		qty := s.Quantity - s.ReservedQty
+		if qty <= 0 {
+			continue
+		}
+		if qty > remaining {
+			qty = remaining
+		}
+
+		movementNumber, err := uc.stockRepo.GetNextMovementNumber(ctx, entity.MovementTypeOut)
+		if err != nil {
+			return err
+		}
+		movement := entity.NewStockMovementOut(
+			source.MaterialID,
+			&source.ID,
+			&s.LocationID,
+			s.UnitID,
+			input.CreatedBy,
+			qty,
+			entity.ReferenceTypeWO,
+			&input.WOID,
+			movementNumber,
+		)
+		movement.Notes = "Consumed by rework " + input.WONumber
+
+		s.Quantity -= qty
+		if err := uc.stockRepo.IssueStock(ctx, s, movement); err != nil {
+			return err
+		}
+		remaining -= qty
+	}
+	return nil
+}
+
+// movedForWO returns true if the lot already has a movement of the type for the work order
+func (uc *ReceiveProductionUseCase) movedForWO(ctx context.Context, lotID uuid.UUID, movementType entity.MovementType, woID uuid.UUID) (bool, error) {
+	movements, err := uc.stockRepo.GetMovementsByLot(ctx, lotID)
+	if err != nil {
+		return false, err
+	}
+	for _, m := range movements {
+		if m.MovementType == movementType && m.ReferenceType == entity.ReferenceTypeWO &&
+			m.ReferenceID != nil && *m.ReferenceID == woID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// quarantineLocation returns the first active location of the production
+// warehouse's quarantine zone
+func (uc *ReceiveProductionUseCase) quarantineLocation(ctx context.Context) (*entity.Zone, *entity.Location, error) {
+	zone, err := uc.zoneRepo.GetQuarantineZone(ctx, uc.warehouseID)
+	if err != nil {
+		return nil, nil, entity.ErrNoQuarantineLocation
+	}
+	locations, err := uc.locationRepo.GetByZoneID(ctx, zone.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, location := range locations {
+		if location.IsActive {
+			return zone, location, nil
+		}
+	}
+	return nil, nil, entity.ErrNoQuarantineLocation
+}
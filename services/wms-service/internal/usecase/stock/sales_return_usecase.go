@@ -0,0 +1,343 @@
+package stock
+
+import (
+	"context"
+
+	"github.com/erp-cosmetics/wms-service/internal/domain/entity"
+	"github.com/erp-cosmetics/wms-service/internal/domain/repository"
+	"github.com/erp-cosmetics/wms-service/internal/infrastructure/event"
+	"github.com/google/uuid"
+)
+
+// ReturnDisposition is what happens to returned goods once the return is inspected
+type ReturnDisposition string
+
+const (
+	ReturnDispositionRestock ReturnDisposition = "RESTOCK" // Back to the location the lot was shipped from
+	ReturnDispositionDispose ReturnDisposition = "DISPOSE" // Written off from the returns location
+)
+
+// SalesReturnLineInput represents one line of a customer return
+type SalesReturnLineInput struct {
+	ReturnID     uuid.UUID
+	ReturnNumber string
+	SalesOrderID uuid.UUID
+	MaterialID   uuid.UUID
+	LotNumber    string // Lot reported on the return; resolved from the sales issue when empty
+	Quantity     float64
+	Disposition  ReturnDisposition // Only used when settling
+	CreatedBy    uuid.UUID
+}
+
+// resolveReturnLot finds the lot a returned line was shipped from: the reported
+// lot number, else the lot that shipped most of the material on the sales order
+func resolveReturnLot(ctx context.Context, lotRepo repository.LotRepository, issueRepo repository.GoodsIssueRepository, input *SalesReturnLineInput) (*entity.Lot, error) {
+	if input.LotNumber != "" {
+		lot, err := lotRepo.GetByLotNumber(ctx, input.LotNumber)
+		if err != nil || lot.MaterialID != input.MaterialID {
+			return nil, entity.ErrReturnLotUnknown
+		}
+		return lot, nil
+	}
+
+	issues, _, err := issueRepo.List(ctx, &repository.GoodsIssueFilter{
+		IssueType:   string(entity.IssueTypeSales),
+		ReferenceID: &input.SalesOrderID,
+		Limit:       100,
+	})
+	if err != nil {
+		return nil, err
+	}
+	shipped := make(map[uuid.UUID]float64)
+	var lotID *uuid.UUID
+	for _, issue := range issues {
+		if issue.Status == entity.GoodsIssueStatusCancelled {
+			continue
+		}
+		for _, line := range issue.LineItems {
+			if line.MaterialID != input.MaterialID || line.LotID == nil {
+				continue
+			}
+			shipped[*line.LotID] += line.IssuedQty
+			if lotID == nil || shipped[*line.LotID] > shipped[*lotID] {
+				id := *line.LotID
+				lotID = &id
+			}
+		}
+	}
+	if lotID == nil {
+		return nil, entity.ErrReturnLotUnknown
+	}
+	return lotRepo.GetByID(ctx, *lotID)
+}
+
+// ReceiveSalesReturnUseCase receives returned goods into the returns zone of the
+// warehouse that shipped them, under the original lot
+type ReceiveSalesReturnUseCase struct {
+	stockRepo    repository.StockRepository
+	lotRepo      repository.LotRepository
+	issueRepo    repository.GoodsIssueRepository
+	zoneRepo     repository.ZoneRepository
+	locationRepo repository.LocationRepository
+	eventPub     *event.Publisher
+}
+
+// NewReceiveSalesReturnUseCase creates a new use case
+func NewReceiveSalesReturnUseCase(
+	stockRepo repository.StockRepository,
+	lotRepo repository.LotRepository,
+	issueRepo repository.GoodsIssueRepository,
+	zoneRepo repository.ZoneRepository,
+	locationRepo repository.LocationRepository,
+	eventPub *event.Publisher,
+) *ReceiveSalesReturnUseCase {
+	return &ReceiveSalesReturnUseCase{
+		stockRepo:    stockRepo,
+		lotRepo:      lotRepo,
+		issueRepo:    issueRepo,
+		zoneRepo:     zoneRepo,
+		locationRepo: locationRepo,
+		eventPub:     eventPub,
+	}
+}
+
+// Execute puts a returned line into the returns location and returns the movement number
+func (uc *ReceiveSalesReturnUseCase) Execute(ctx context.Context, input *SalesReturnLineInput) (string, error) {
+	if input.Quantity <= 0 {
+		return "", entity.ErrInvalidQuantity
+	}
+
+	lot, err := resolveReturnLot(ctx, uc.lotRepo, uc.issueRepo, input)
+	if err != nil {
+		return "", err
+	}
+
+	// The last issue of the lot gives the shipping warehouse and the stock unit
+	movements, err := uc.stockRepo.GetMovementsByLot(ctx, lot.ID)
+	if err != nil {
+		return "", err
+	}
+	var issued *entity.StockMovement
+	for _, m := range movements {
+		if m.MovementType == entity.MovementTypeOut && m.MaterialID == input.MaterialID && m.FromLocationID != nil {
+			issued = m
+			break
+		}
+	}
+	if issued == nil {
+		return "", entity.ErrSourceLocationUnknown
+	}
+	issueLocation, err := uc.locationRepo.GetByID(ctx, *issued.FromLocationID)
+	if err != nil {
+		return "", err
+	}
+	issueZone, err := uc.zoneRepo.GetByID(ctx, issueLocation.ZoneID)
+	if err != nil {
+		return "", err
+	}
+
+	zone, location, err := uc.returnsLocation(ctx, issueZone.WarehouseID)
+	if err != nil {
+		return "", err
+	}
+
+	stock := &entity.Stock{
+		WarehouseID: issueZone.WarehouseID,
+		ZoneID:      zone.ID,
+		LocationID:  location.ID,
+		MaterialID:  input.MaterialID,
+		LotID:       &lot.ID,
+		Quantity:    input.Quantity,
+		UnitID:      issued.UnitID,
+	}
+
+	movementNumber, err := uc.stockRepo.GetNextMovementNumber(ctx, entity.MovementTypeIn)
+	if err != nil {
+		return "", err
+	}
+	movement := entity.NewStockMovementIn(
+		input.MaterialID,
+		lot.ID,
+		location.ID,
+		issued.UnitID,
+		input.CreatedBy,
+		input.Quantity,
+		entity.ReferenceTypeSalesReturn,
+		&input.ReturnID,
+		movementNumber,
+	)
+	movement.Notes = "Customer return " + input.ReturnNumber
+
+	if err := uc.stockRepo.ReceiveStock(ctx, stock, movement); err != nil {
+		return "", err
+	}
+
+	uc.eventPub.PublishStockReceived(&event.StockReceivedEvent{
+		MaterialID:  input.MaterialID.String(),
+		LotID:       lot.ID.String(),
+		Quantity:    input.Quantity,
+		LocationID:  location.ID.String(),
+		WarehouseID: issueZone.WarehouseID.String(),
+	})
+
+	return movementNumber, nil
+}
+
+// returnsLocation returns the first active location of the warehouse's returns zone
+func (uc *ReceiveSalesReturnUseCase) returnsLocation(ctx context.Context, warehouseID uuid.UUID) (*entity.Zone, *entity.Location, error) {
+	zones, err := uc.zoneRepo.GetByWarehouseID(ctx, warehouseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, zone := range zones {
+		if !zone.IsReturnsZone() || !zone.IsActive {
+			continue
+		}
+		locations, err := uc.locationRepo.GetByZoneID(ctx, zone.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, location := range locations {
+			if location.IsActive {
+				return zone, location, nil
+			}
+		}
+	}
+	return nil, nil, entity.ErrNoReturnsLocation
+}
+
+// SettleSalesReturnUseCase applies the inspection outcome to returned goods
+// held in the returns location
+type SettleSalesReturnUseCase struct {
+	stockRepo repository.StockRepository
+	lotRepo   repository.LotRepository
+	issueRepo repository.GoodsIssueRepository
+}
+
+// NewSettleSalesReturnUseCase creates a new use case
+func NewSettleSalesReturnUseCase(
+	stockRepo repository.StockRepository,
+	lotRepo repository.LotRepository,
+	issueRepo repository.GoodsIssueRepository,
+) *SettleSalesReturnUseCase {
+	return &SettleSalesReturnUseCase{
+		stockRepo: stockRepo,
+		lotRepo:   lotRepo,
+		issueRepo: issueRepo,
+	}
+}
+
+// Execute restocks or disposes of a returned line and returns the movement number
+func (uc *SettleSalesReturnUseCase) Execute(ctx context.Context, input *SalesReturnLineInput) (string, error) {
+	if input.Quantity <= 0 {
+		return "", entity.ErrInvalidQuantity
+	}
+	if input.Disposition != ReturnDispositionRestock && input.Disposition != ReturnDispositionDispose {
+		return "", entity.ErrInvalidStatus
+	}
+
+	lot, err := resolveReturnLot(ctx, uc.lotRepo, uc.issueRepo, input)
+	if err != nil {
+		return "", err
+	}
+
+	// Movements are returned newest first
+	movements, err := uc.stockRepo.GetMovementsByLot(ctx, lot.ID)
+	if err != nil {
+		return "", err
+	}
+	var received, issued *entity.StockMovement
+	for _, m := range movements {
+		if m.MaterialID != input.MaterialID {
+			continue
+		}
+		if received == nil && m.MovementType == entity.MovementTypeIn && m.ReferenceType == entity.ReferenceTypeSalesReturn &&
+			m.ReferenceID != nil && *m.ReferenceID == input.ReturnID {
+			received = m
+		}
+		if issued == nil && m.MovementType == entity.MovementTypeOut && m.FromLocationID != nil {
+			issued = m
+		}
+	}
+	if received == nil || received.ToLocationID == nil {
+		return "", entity.ErrReturnNotReceived
+	}
+	returnsLocationID := *received.ToLocationID
+
+	fromStock, err := uc.stockRepo.GetByLocationMaterialLot(ctx, returnsLocationID, input.MaterialID, &lot.ID)
+	if err != nil {
+		return "", err
+	}
+	if fromStock.Quantity-fromStock.ReservedQty < input.Quantity {
+		return "", entity.ErrInsufficientStock
+	}
+
+	notes := "Customer return " + input.ReturnNumber
+
+	if input.Disposition == ReturnDispositionDispose {
+		movementNumber, err := uc.stockRepo.GetNextMovementNumber(ctx, entity.MovementTypeAdjustment)
+		if err != nil {
+			return "", err
+		}
+		movement := &entity.StockMovement{
+			MovementNumber: movementNumber,
+			MovementType:   entity.MovementTypeAdjustment,
+			ReferenceType:  entity.ReferenceTypeSalesReturn,
+			ReferenceID:    &input.ReturnID,
+			MaterialID:     input.MaterialID,
+			LotID:          &lot.ID,
+			FromLocationID: &returnsLocationID,
+			ToLocationID:   &returnsLocationID,
+			Quantity:       -input.Quantity,
+			UnitID:         received.UnitID,
+			Notes:          notes + " disposed",
+			CreatedBy:      input.CreatedBy,
+		}
+		if err := uc.stockRepo.AdjustStock(ctx, fromStock, -input.Quantity, movement); err != nil {
+			return "", err
+		}
+		return movementNumber, nil
+	}
+
+	if issued == nil {
+		return "", entity.ErrSourceLocationUnknown
+	}
+	existing, err := uc.stockRepo.GetByLocationMaterialLot(ctx, *issued.FromLocationID, input.MaterialID, &lot.ID)
+	if err != nil {
+		return "", err
+	}
+
+	fromStock.Quantity -= input.Quantity
+	toStock := &entity.Stock{
+		WarehouseID: existing.WarehouseID,
+		ZoneID:      existing.ZoneID,
+		LocationID:  *issued.FromLocationID,
+		MaterialID:  input.MaterialID,
+		LotID:       &lot.ID,
+		Quantity:    input.Quantity,
+		UnitID:      received.UnitID,
+	}
+
+	movementNumber, err := uc.stockRepo.GetNextMovementNumber(ctx, entity.MovementTypeTransfer)
+	if err != nil {
+		return "", err
+	}
+	movement := entity.NewStockMovementTransfer(
+		input.MaterialID,
+		&lot.ID,
+		returnsLocationID,
+		*issued.FromLocationID,
+		received.UnitID,
+		input.CreatedBy,
+		input.Quantity,
+		movementNumber,
+	)
+	movement.ReferenceType = entity.ReferenceTypeSalesReturn
+	movement.ReferenceID = &input.ReturnID
+	movement.Notes = notes + " restocked"
+
+	if err := uc.stockRepo.TransferStock(ctx, fromStock, toStock, movement); err != nil {
+		return "", err
+	}
+	return movementNumber, nil
+}
@@ -205,3 +205,96 @@ func NewReleaseReservationUseCase(stockRepo repository.StockRepository) *Release
 func (uc *ReleaseReservationUseCase) Execute(ctx context.Context, reservationID uuid.UUID) error {
 	return uc.stockRepo.ReleaseReservation(ctx, reservationID)
 }
+
+// ReturnToStockUseCase handles material returned from production
+type ReturnToStockUseCase struct {
+	stockRepo repository.StockRepository
+	eventPub  *event.Publisher
+}
+
+// NewReturnToStockUseCase creates a new use case
+func NewReturnToStockUseCase(stockRepo repository.StockRepository, eventPub *event.Publisher) *ReturnToStockUseCase {
+	return &ReturnToStockUseCase{
+		stockRepo: stockRepo,
+		eventPub:  eventPub,
+	}
+}
+
+// ReturnToStockInput represents input for returning a lot to stock
+type ReturnToStockInput struct {
+	MaterialID    uuid.UUID
+	LotID         uuid.UUID
+	Quantity      float64
+	UnitID        uuid.UUID
+	ReferenceType entity.ReferenceType
+	ReferenceID   *uuid.UUID
+	CreatedBy     uuid.UUID
+}
+
+// Execute puts the lot back into the location it was last issued from
+func (uc *ReturnToStockUseCase) Execute(ctx context.Context, input *ReturnToStockInput) (string, error) {
+	if input.Quantity <= 0 {
+		return "", entity.ErrInvalidQuantity
+	}
+
+	// Movements are returned newest first
+	movements, err := uc.stockRepo.GetMovementsByLot(ctx, input.LotID)
+	if err != nil {
+		return "", err
+	}
+	var locationID *uuid.UUID
+	for _, m := range movements {
+		if m.MovementType == entity.MovementTypeOut && m.MaterialID == input.MaterialID && m.FromLocationID != nil {
+			locationID = m.FromLocationID
+			break
+		}
+	}
+	if locationID == nil {
+		return "", entity.ErrSourceLocationUnknown
+	}
+
+	existing, err := uc.stockRepo.GetByLocationMaterialLot(ctx, *locationID, input.MaterialID, &input.LotID)
+	if err != nil {
+		return "", err
+	}
+
+	stock := &entity.Stock{
+		WarehouseID: existing.WarehouseID,
+		ZoneID:      existing.ZoneID,
+		LocationID:  *locationID,
+		MaterialID:  input.MaterialID,
+		LotID:       &input.LotID,
+		Quantity:    input.Quantity,
+		UnitID:      input.UnitID,
+	}
+
+	movementNumber, err := uc.stockRepo.GetNextMovementNumber(ctx, entity.MovementTypeIn)
+	if err != nil {
+		return "", err
+	}
+	movement := entity.NewStockMovementIn(
+		input.MaterialID,
+		input.LotID,
+		*locationID,
+		input.UnitID,
+		input.CreatedBy,
+		input.Quantity,
+		input.ReferenceType,
+		input.ReferenceID,
+		movementNumber,
+	)
+
+	if err := uc.stockRepo.ReceiveStock(ctx, stock, movement); err != nil {
+		return "", err
+	}
+
+	uc.eventPub.PublishStockReceived(&event.StockReceivedEvent{
+		MaterialID:  input.MaterialID.String(),
+		LotID:       input.LotID.String(),
+		Quantity:    input.Quantity,
+		LocationID:  locationID.String(),
+		WarehouseID: existing.WarehouseID.String(),
+	})
+
+	return movementNumber, nil
+}
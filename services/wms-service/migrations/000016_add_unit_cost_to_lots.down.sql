@@ -0,0 +1,2 @@
+ALTER TABLE lots DROP COLUMN IF EXISTS cost_updated_at;
+ALTER TABLE lots DROP COLUMN IF EXISTS unit_cost;
@@ -0,0 +1,3 @@
+-- Lot valuation: purchase price on receipt, actual work order cost for finished goods
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS unit_cost DECIMAL(18,4) DEFAULT 0;
+ALTER TABLE lots ADD COLUMN IF NOT EXISTS cost_updated_at TIMESTAMP;
@@ -0,0 +1,2 @@
+DELETE FROM locations WHERE id IN ('c1b2c3d4-1212-1212-1212-121212121212', 'c1b2c3d4-3434-3434-3434-343434343434');
+DELETE FROM zones WHERE id IN ('b1b2c3d4-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'b1b2c3d4-bbbb-bbbb-bbbb-bbbbbbbbbbbb');
@@ -0,0 +1,10 @@
+-- Returns zones hold customer returns until they are inspected and restocked or disposed
+INSERT INTO zones (id, warehouse_id, code, name, zone_type, is_active) VALUES
+    ('b1b2c3d4-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'a1b2c3d4-1111-1111-1111-111111111111', 'ZONE-RET', 'Returns Zone', 'RETURNS', true),
+    ('b1b2c3d4-bbbb-bbbb-bbbb-bbbbbbbbbbbb', 'a1b2c3d4-3333-3333-3333-333333333333', 'ZONE-FG-RET', 'FG Returns Zone', 'RETURNS', true)
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO locations (id, zone_id, code, aisle, rack, shelf, bin, capacity, is_active) VALUES
+    ('c1b2c3d4-1212-1212-1212-121212121212', 'b1b2c3d4-aaaa-aaaa-aaaa-aaaaaaaaaaaa', 'RET01-R01-S01', 'RET01', 'R01', 'S01', NULL, 500.00, true),
+    ('c1b2c3d4-3434-3434-3434-343434343434', 'b1b2c3d4-bbbb-bbbb-bbbb-bbbbbbbbbbbb', 'FGRET01-R01-S01', 'FGRET01', 'R01', 'S01', NULL, 500.00, true)
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,27 @@
+-- Backorders: sales order quantities waiting for stock
+CREATE TABLE IF NOT EXISTS backorders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    material_id UUID NOT NULL,
+    unit_id UUID NOT NULL,
+    reference_id UUID NOT NULL,
+    reference_number VARCHAR(30),
+    reference_line_id UUID,
+    customer_id UUID NOT NULL,
+    customer_priority INTEGER NOT NULL DEFAULT 0, -- Customer tier, lower is served first
+    order_date DATE NOT NULL,
+    promised_date DATE,
+    ordered_qty DECIMAL(15,4) NOT NULL,
+    backordered_qty DECIMAL(15,4) NOT NULL,
+    allocated_qty DECIMAL(15,4) NOT NULL DEFAULT 0,
+    expected_date DATE,
+    status VARCHAR(20) DEFAULT 'OPEN', -- OPEN, ALLOCATED, CANCELLED
+    allocated_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Indexes
+CREATE INDEX idx_backorders_material_status ON backorders(material_id, status);
+CREATE INDEX idx_backorders_reference ON backorders(reference_id);
+CREATE INDEX idx_backorders_customer ON backorders(customer_id);